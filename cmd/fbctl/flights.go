@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flight-booking-system/internal/inventory"
+	"github.com/flight-booking-system/internal/timetable"
+)
+
+// runFlightsImport previews, and with -apply performs, a flight schedule
+// import. A file with invalid rows is reported row by row and nothing is
+// imported.
+func runFlightsImport(args []string) error {
+	fs := flag.NewFlagSet("flights import", flag.ExitOnError)
+	file := fs.String("f", "", "schedule file (required); format is taken from the extension unless -format is set")
+	formatFlag := fs.String("format", "", "csv or json")
+	apply := fs.Bool("apply", false, "add the flights; without it only what would be added is printed")
+	fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+	if *formatFlag == "" {
+		*formatFlag = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+	format, err := inventory.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", *file, err)
+	}
+	defer f.Close()
+
+	flights, rowErrors, err := timetable.Read(f, format)
+	if err != nil {
+		return err
+	}
+	if len(rowErrors) > 0 {
+		for _, e := range rowErrors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		return fmt.Errorf("%d invalid rows; nothing imported", len(rowErrors))
+	}
+
+	ctx := context.Background()
+	flightService, closeAll, err := openFlightService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	result, err := flightService.ImportSchedule(ctx, flights, !*apply)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %3d  %s\n", "create", len(result.Created), strings.Join(result.Created, " "))
+	fmt.Printf("%-8s %3d  %s\n", "skip", len(result.Skipped), strings.Join(result.Skipped, " "))
+	if *apply {
+		fmt.Println("Schedule imported")
+	} else {
+		fmt.Println("Dry run; re-run with -apply to import")
+	}
+	return nil
+}
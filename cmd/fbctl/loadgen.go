@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/flight-booking-system/internal/loadgen"
+)
+
+// runLoadgen simulates customers following persona scripts against the API
+// and prints how their orders ended
+func runLoadgen(args []string) error {
+	fs := flag.NewFlagSet("loadgen run", flag.ExitOnError)
+	url := fs.String("url", "http://localhost:8080", "API server base URL")
+	users := fs.Int("users", 50, "simulated users to run")
+	concurrency := fs.Int("concurrency", 10, "users running at once")
+	mixFlag := fs.String("mix", loadgen.DefaultMix.String(), "persona weights: fast, ditherer, abandoner, canceler, decliner")
+	think := fs.Duration("think", 2*time.Second, "mean pause between a user's actions")
+	timeout := fs.Duration("timeout", 2*time.Minute, "longest one user waits for its order to settle")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "random seed; reuse one to repeat a run's script")
+	fs.Parse(args)
+
+	mix, err := loadgen.ParseMix(*mixFlag)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Running %d users (%d at once), mix %s, seed %d\n", *users, *concurrency, mix, *seed)
+	start := time.Now()
+	report, err := loadgen.Run(ctx, loadgen.Config{
+		BaseURL:     *url,
+		Users:       *users,
+		Concurrency: *concurrency,
+		Mix:         mix,
+		Think:       *think,
+		Timeout:     *timeout,
+		Seed:        *seed,
+	})
+	if report != nil {
+		report.Write(os.Stdout)
+		fmt.Printf("Finished in %s\n", time.Since(start).Round(time.Second))
+	}
+	return err
+}
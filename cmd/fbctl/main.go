@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `fbctl - operational commands for the flight booking system
+
+Usage:
+  fbctl workflows migrate [flags]   List running booking workflows, optionally drain them,
+                                    and report residual seat locks
+  fbctl seats export [flags]        Export a flight's seat inventory as CSV or JSON
+  fbctl seats import [flags]        Preview, and with -apply import, a seat inventory file
+  fbctl flights import [flags]      Preview, and with -apply import, a flight schedule file
+  fbctl loadgen run [flags]         Simulate customers following persona scripts against the API
+  fbctl verify [flags]              Check seat counts, seats, orders and seat locks agree,
+                                    and with -fix repair what can be repaired safely
+  fbctl seed [flags]                Generate flights on realistic routes with varied seat maps
+
+Run "fbctl <command> [<subcommand>] -h" for flags.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if os.Args[1] == "verify" {
+		exit(runVerify(os.Args[2:]))
+	}
+	if os.Args[1] == "seed" {
+		exit(runSeed(os.Args[2:]))
+	}
+	if len(os.Args) < 3 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] + " " + os.Args[2] {
+	case "workflows migrate":
+		err = runWorkflowsMigrate(os.Args[3:])
+	case "seats export":
+		err = runSeatsExport(os.Args[3:])
+	case "seats import":
+		err = runSeatsImport(os.Args[3:])
+	case "flights import":
+		err = runFlightsImport(os.Args[3:])
+	case "loadgen run":
+		err = runLoadgen(os.Args[3:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	exit(err)
+}
+
+// exit ends fbctl, failing if the command returned an error
+func exit(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/inventory"
+	"github.com/flight-booking-system/internal/repository"
+	"github.com/flight-booking-system/internal/service"
+)
+
+// runSeatsExport writes a flight's seat inventory to a file or stdout
+func runSeatsExport(args []string) error {
+	fs := flag.NewFlagSet("seats export", flag.ExitOnError)
+	flightID := fs.String("flight", "", "flight ID (required)")
+	formatFlag := fs.String("format", "csv", "csv or json")
+	out := fs.String("o", "", "output file (default stdout)")
+	fs.Parse(args)
+
+	format, err := inventory.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+	if *flightID == "" {
+		return errors.New("-flight is required")
+	}
+
+	ctx := context.Background()
+	flights, closeAll, err := openFlightService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	seats, err := flights.ExportInventory(ctx, *flightID)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return inventory.Write(w, format, *flightID, seats)
+}
+
+// runSeatsImport previews, and with -apply performs, a seat inventory import
+func runSeatsImport(args []string) error {
+	fs := flag.NewFlagSet("seats import", flag.ExitOnError)
+	flightID := fs.String("flight", "", "flight ID (required)")
+	file := fs.String("f", "", "inventory file (required); format is taken from the extension unless -format is set")
+	formatFlag := fs.String("format", "", "csv or json")
+	apply := fs.Bool("apply", false, "apply the change; without it only the diff is printed")
+	fs.Parse(args)
+
+	if *flightID == "" || *file == "" {
+		return errors.New("-flight and -f are required")
+	}
+	if *formatFlag == "" {
+		*formatFlag = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+	format, err := inventory.ParseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", *file, err)
+	}
+	defer f.Close()
+
+	seats, err := inventory.Read(f, format)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	flights, closeAll, err := openFlightService(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	change, err := flights.ImportInventory(ctx, *flightID, seats, !*apply)
+	if err != nil {
+		return err
+	}
+
+	added := make([]string, len(change.Add))
+	for i, seat := range change.Add {
+		added[i] = seat.ID
+	}
+	for _, line := range []struct {
+		label string
+		seats []string
+	}{{"add", added}, {"remove", change.Remove}, {"block", change.Block}, {"unblock", change.Unblock}} {
+		fmt.Printf("%-8s %3d  %s\n", line.label, len(line.seats), strings.Join(line.seats, " "))
+	}
+
+	if *apply {
+		fmt.Println("Inventory imported")
+	} else {
+		fmt.Println("Dry run; re-run with -apply to import")
+	}
+	return nil
+}
+
+// openFlightService connects to Postgres and Redis and returns a FlightService
+// with a function that closes both connections
+func openFlightService(ctx context.Context) (*service.FlightService, func(), error) {
+	cfg := config.Load()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
+	if err != nil {
+		pool.Close()
+		return nil, nil, err
+	}
+
+	flights := service.NewFlightService(repository.NewFlightRepo(pool), repository.NewSeatLockRepo(redisClient))
+	return flights, func() {
+		redisClient.Close()
+		pool.Close()
+	}, nil
+}
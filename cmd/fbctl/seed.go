@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/repository"
+	"github.com/flight-booking-system/internal/seed"
+)
+
+// runSeed generates flights with seat maps and stores them, skipping any
+// whose flight number is already taken, so seeding again with the same flags
+// adds nothing
+func runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	n := fs.Int("n", 20, "number of flights to generate")
+	days := fs.Int("days", 14, "spread departures over this many days, starting tomorrow")
+	prefix := fs.String("prefix", "SD", "flight number prefix; numbers follow from 100")
+	seedValue := fs.Int64("seed", 1, "random seed; the same seed and flags generate the same flights")
+	dryRun := fs.Bool("dry-run", false, "print the flights without storing them")
+	fs.Parse(args)
+
+	if *n < 1 {
+		return fmt.Errorf("-n must be at least 1")
+	}
+
+	flights := seed.Generate(seed.Options{
+		Flights: *n,
+		Start:   time.Now(),
+		Days:    *days,
+		Prefix:  *prefix,
+		Seed:    *seedValue,
+	})
+
+	if *dryRun {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "FLIGHT\tROUTE\tDEPARTURE\tARRIVAL\tLAYOUT\tSEATS\tPRICE")
+		for _, f := range flights {
+			fmt.Fprintf(w, "%s\t%s-%s\t%s\t%s\t%s\t%d\t%d.%02d\n",
+				f.FlightNumber, f.Origin, f.Destination,
+				f.DepartureTime.Format(time.RFC3339), f.ArrivalTime.Format(time.RFC3339),
+				f.Layout, len(f.Seats), f.PriceCents/100, f.PriceCents%100)
+		}
+		return w.Flush()
+	}
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	flightRepo := repository.NewFlightRepo(pool)
+	created, skipped := 0, 0
+	for _, f := range flights {
+		ok, err := flightRepo.Create(ctx, &f.Flight, f.Seats)
+		if err != nil {
+			return err
+		}
+		if ok {
+			created++
+		} else {
+			skipped++
+		}
+	}
+
+	fmt.Printf("%d flights created, %d skipped as already present\n", created, skipped)
+	return nil
+}
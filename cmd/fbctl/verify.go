@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/repository"
+	"github.com/flight-booking-system/internal/service"
+)
+
+// runVerify checks flight seat counts, seat rows, orders and Redis locks
+// against each other and reports, and with -fix repairs, what disagrees. It
+// fails while any discrepancy is left unrepaired.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	flightID := fs.String("flight", "", "flight ID (default every flight)")
+	fix := fs.Bool("fix", false, "repair fixable discrepancies; without it they are only reported")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
+	if err != nil {
+		return err
+	}
+	defer redisClient.Close()
+
+	consistency := service.NewConsistencyService(
+		repository.NewFlightRepo(pool), repository.NewOrderRepo(pool), repository.NewSeatLockRepo(redisClient),
+	)
+	found, err := consistency.Verify(ctx, *flightID, *fix)
+	if err != nil {
+		return err
+	}
+
+	remaining := 0
+	if len(found) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "FLIGHT\tCHECK\tSEAT\tORDER\tRESULT\tDETAIL")
+		for _, d := range found {
+			result := "report"
+			switch {
+			case d.Fixed:
+				result = "fixed"
+			case d.Fixable:
+				result = "fixable"
+			}
+			if !d.Fixed {
+				remaining++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", d.FlightID, d.Kind, d.SeatID, d.OrderID, result, d.Detail)
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("Discrepancies: %d found, %d fixed\n", len(found), len(found)-remaining)
+	if remaining > 0 {
+		return fmt.Errorf("%d discrepancies remain", remaining)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+	"github.com/flight-booking-system/internal/service"
+)
+
+// Drain modes for workflows migrate
+const (
+	drainNone   = "none"
+	drainWait   = "wait"
+	drainCancel = "cancel"
+)
+
+// runWorkflowsMigrate prepares for a breaking workflow deployment: it lists open
+// BookingWorkflow executions, optionally drains them, and reports seat locks left behind
+func runWorkflowsMigrate(args []string) error {
+	fs := flag.NewFlagSet("workflows migrate", flag.ExitOnError)
+	drain := fs.String("drain", drainNone, "none: report only; wait: wait for workflows to finish; cancel: cancel holds (compensation releases seats) then wait")
+	timeout := fs.Duration("timeout", 5*time.Minute, "how long to wait for workflows to drain")
+	fs.Parse(args)
+
+	if *drain != drainNone && *drain != drainWait && *drain != drainCancel {
+		return fmt.Errorf("unknown drain mode %q", *drain)
+	}
+
+	cfg := config.Load()
+	ctx := context.Background()
+
+	temporalClient, err := service.NewTemporalClient(&cfg.Temporal)
+	if err != nil {
+		return err
+	}
+	defer temporalClient.Close()
+
+	workflowIDs, err := temporalClient.ListRunningBookingWorkflows(ctx)
+	if err != nil {
+		return err
+	}
+	printWorkflows(ctx, temporalClient, workflowIDs)
+
+	if *drain == drainCancel {
+		cancelHolds(ctx, temporalClient, workflowIDs)
+	}
+	if *drain != drainNone {
+		if err := waitForDrain(ctx, temporalClient, *timeout); err != nil {
+			return err
+		}
+	}
+
+	return reportSeatLocks(ctx, cfg)
+}
+
+func printWorkflows(ctx context.Context, tc *service.TemporalClient, workflowIDs []string) {
+	fmt.Printf("Running booking workflows: %d\n", len(workflowIDs))
+	if len(workflowIDs) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "WORKFLOW\tSTATUS\tFLIGHT\tSEATS\tEXPIRES")
+	for _, id := range workflowIDs {
+		status, err := tc.QueryBookingStatus(ctx, orderIDFromWorkflowID(id))
+		if err != nil {
+			fmt.Fprintf(w, "%s\tunknown (%v)\t\t\t\n", id, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, status.Status, status.FlightID,
+			strings.Join(status.Seats, ","), status.ExpiresAt.Format(time.RFC3339))
+	}
+	w.Flush()
+}
+
+// cancelHolds signals cancellation to workflows still waiting on the customer.
+// Workflows already processing payment are left to complete on their own.
+func cancelHolds(ctx context.Context, tc *service.TemporalClient, workflowIDs []string) {
+	for _, id := range workflowIDs {
+		orderID := orderIDFromWorkflowID(id)
+		status, err := tc.QueryBookingStatus(ctx, orderID)
+		if err != nil {
+			fmt.Printf("skip %s: %v\n", id, err)
+			continue
+		}
+		if status.Status == domain.OrderStatusPaymentProcessing || status.Status == domain.OrderStatusConfirmed {
+			fmt.Printf("leave %s: %s\n", id, status.Status)
+			continue
+		}
+
+		if err := tc.SignalCancelBooking(ctx, orderID); err != nil {
+			fmt.Printf("cancel %s failed: %v\n", id, err)
+			continue
+		}
+		fmt.Printf("canceled %s\n", id)
+	}
+}
+
+func waitForDrain(ctx context.Context, tc *service.TemporalClient, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		workflowIDs, err := tc.ListRunningBookingWorkflows(ctx)
+		if err != nil {
+			return err
+		}
+		if len(workflowIDs) == 0 {
+			fmt.Println("All booking workflows drained")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d booking workflows still running after %s", len(workflowIDs), timeout)
+		}
+
+		fmt.Printf("Waiting for %d booking workflows...\n", len(workflowIDs))
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// reportSeatLocks lists Redis seat locks that remain per flight
+func reportSeatLocks(ctx context.Context, cfg *config.Config) error {
+	pool, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
+	if err != nil {
+		return err
+	}
+	defer redisClient.Close()
+
+	flightIDs, err := repository.NewFlightRepo(pool).GetAllFlightIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	seatLocks := repository.NewSeatLockRepo(redisClient)
+	total := 0
+	for _, flightID := range flightIDs {
+		locks, err := seatLocks.GetLockedSeats(ctx, flightID)
+		if err != nil {
+			return err
+		}
+		if len(locks) == 0 {
+			continue
+		}
+
+		seats := make([]string, 0, len(locks))
+		for seatID, orderID := range locks {
+			seats = append(seats, seatID+"="+orderID)
+		}
+		sort.Strings(seats)
+		fmt.Printf("flight %s: %d residual locks: %s\n", flightID, len(locks), strings.Join(seats, " "))
+		total += len(locks)
+	}
+
+	fmt.Printf("Residual seat locks: %d\n", total)
+	return nil
+}
+
+func orderIDFromWorkflowID(workflowID string) string {
+	return strings.TrimPrefix(workflowID, "booking-")
+}
@@ -4,26 +4,35 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
-	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	sdklog "go.temporal.io/sdk/log"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+
 	"github.com/flight-booking-system/internal/api"
 	"github.com/flight-booking-system/internal/config"
 	"github.com/flight-booking-system/internal/database"
+	grpcapi "github.com/flight-booking-system/internal/grpc"
+	"github.com/flight-booking-system/internal/redisops"
 	"github.com/flight-booking-system/internal/repository"
 	"github.com/flight-booking-system/internal/service"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Cancel everything on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Connect to PostgreSQL
 	pool, err := database.NewPostgresPool(ctx, cfg.Database)
@@ -33,6 +42,29 @@ func main() {
 	defer pool.Close()
 	log.Println("Connected to PostgreSQL")
 
+	// Listings read from the replica when one is configured and reachable
+	var replica *pgxpool.Pool
+	if replicaCfg, ok := cfg.Database.Replica(); ok {
+		replica, err = database.NewPostgresPool(ctx, replicaCfg)
+		if err != nil {
+			log.Printf("Warning: Failed to connect to read replica, reading from the primary: %v", err)
+		} else {
+			defer replica.Close()
+			log.Println("Connected to PostgreSQL read replica")
+		}
+	}
+
+	// Report schema drift up front instead of failing later with obscure SQL errors
+	schemaChecker := database.NewSchemaChecker(pool)
+	if report := schemaChecker.Check(ctx); !report.Healthy() {
+		if report.Error != "" {
+			log.Printf("Warning: schema check failed: %s", report.Error)
+		}
+		for _, d := range report.Drift {
+			log.Printf("Warning: schema drift: %s", d)
+		}
+	}
+
 	// Connect to Redis
 	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
 	if err != nil {
@@ -40,9 +72,15 @@ func main() {
 	}
 	defer redisClient.Close()
 	log.Println("Connected to Redis")
+	if err := redisops.Load(ctx, redisClient); err != nil {
+		log.Printf("Warning: Failed to preload Redis scripts: %v", err)
+	}
 
 	// Connect to Temporal
-	temporalClient, err := service.NewTemporalClient(&cfg.Temporal)
+	// Every Temporal call is logged and counted for /api/status
+	temporalStats := temporalpkg.NewInvocationMetrics()
+	temporalClient, err := service.NewTemporalClient(&cfg.Temporal,
+		temporalpkg.NewObservabilityInterceptor(temporalStats, sdklog.NewStructuredLogger(slog.Default())))
 	if err != nil {
 		log.Fatalf("Failed to connect to Temporal: %v", err)
 	}
@@ -50,22 +88,53 @@ func main() {
 	log.Println("Connected to Temporal")
 
 	// Create repositories
-	flightRepo := repository.NewFlightRepo(pool)
-	orderRepo := repository.NewOrderRepo(pool)
+	flightRepo := repository.NewFlightRepo(pool).WithReplica(replica)
+	orderRepo := repository.NewOrderRepo(pool).WithReplica(replica)
 	seatLockRepo := repository.NewSeatLockRepo(redisClient)
+	swapRepo := repository.NewSwapRepo(pool)
+	notificationRepo := repository.NewNotificationRepo(pool)
+	webhookRepo := repository.NewWebhookRepo(pool)
+	paymentRepo := repository.NewPaymentRepo(pool)
+	orderEventRepo := repository.NewOrderEventRepo(pool)
 
 	// Create services
 	flightService := service.NewFlightService(flightRepo, seatLockRepo)
-	bookingService := service.NewBookingService(orderRepo, flightRepo, temporalClient)
+	bookingService := service.NewBookingService(orderRepo, flightRepo, seatLockRepo, paymentRepo, orderEventRepo, temporalClient, &cfg.Booking)
+	swapService := service.NewSwapService(swapRepo, orderRepo, temporalClient)
+	notificationService := service.NewNotificationService(notificationRepo, orderRepo)
+	webhookService := service.NewWebhookService(webhookRepo)
+	tripService := service.NewTripService(orderRepo, flightRepo)
 
 	// Create handlers
-	handlers := api.NewHandlers(flightService, bookingService)
+	handlers := api.NewHandlers(flightService, bookingService, swapService, notificationService, webhookService, tripService)
+
+	if len(cfg.Server.AdminAPIKeys) == 0 {
+		log.Println("Warning: ADMIN_API_KEYS is empty; admin routes will reject all requests")
+	}
+
+	// REST and gRPC spend the same per-client request budgets
+	rateLimiter := repository.NewRateLimitRepo(redisClient)
+	rateLimit := api.RateLimitPolicy{
+		PerIP:    cfg.RateLimit.PerIP,
+		PerOrder: cfg.RateLimit.PerOrder,
+		Window:   cfg.RateLimit.Window,
+	}
 
 	// Create router
 	router := api.NewRouter(api.RouterConfig{
-		Pool:        pool,
-		RedisClient: redisClient,
-		Handlers:    handlers,
+		Pool:                  pool,
+		RedisClient:           redisClient,
+		TemporalClient:        temporalClient,
+		TemporalStats:         temporalStats,
+		Handlers:              handlers,
+		AllowedOrigins:        cfg.Server.AllowedOrigins,
+		AdminAPIKeys:          cfg.Server.AdminAPIKeys,
+		RateLimiter:           rateLimiter,
+		RateLimit:             rateLimit,
+		Settings:              cfg.Sanitized(),
+		SchemaChecker:         schemaChecker,
+		Currency:              cfg.Booking.PriceCurrency,
+		PaymentCallbackSecret: cfg.Booking.PaymentCallbackSecret,
 	})
 
 	// Create server
@@ -78,28 +147,90 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
-	// Start server in goroutine
+	// Create gRPC server sharing the same services
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.GRPCPort)
+	grpcSrv := grpcapi.NewServer(flightService, bookingService, rateLimiter, rateLimit)
+
+	// Run long-lived components under one group: the first to fail cancels
+	// the shared context so every other component shuts down with it
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return serveHTTP(gctx, srv)
+	})
+	g.Go(func() error {
+		return serveGRPC(gctx, grpcSrv, grpcAddr)
+	})
+
+	if err := g.Wait(); err != nil {
+		log.Fatalf("Server stopped with error: %v", err)
+	}
+
+	log.Println("Server stopped")
+}
+
+// shutdownTimeout bounds how long each server waits for in-flight requests
+// once shutdown begins
+const shutdownTimeout = 10 * time.Second
+
+// serveHTTP runs srv until ctx is canceled, then shuts it down gracefully
+func serveHTTP(ctx context.Context, srv *http.Server) error {
+	errCh := make(chan error, 1)
 	go func() {
-		log.Printf("Server starting on %s", addr)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
-		}
+		log.Printf("Server starting on %s", srv.Addr)
+		errCh <- srv.ListenAndServe()
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("http server: %w", err)
+	case <-ctx.Done():
+	}
 
 	log.Println("Shutting down server...")
 
-	// Graceful shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer shutdownCancel()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		return fmt.Errorf("shutdown http server: %w", err)
 	}
 
-	log.Println("Server stopped")
+	return nil
+}
+
+// serveGRPC runs srv on addr until ctx is canceled, then stops it
+// gracefully; RPCs still running after shutdownTimeout are cut off
+func serveGRPC(ctx context.Context, srv *grpc.Server, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen grpc: %w", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("gRPC server starting on %s", addr)
+		errCh <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("grpc server: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down gRPC server...")
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		log.Println("gRPC graceful stop timed out, closing open connections")
+		srv.Stop()
+	}
+
+	return nil
 }
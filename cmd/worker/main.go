@@ -3,17 +3,26 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	sdklog "go.temporal.io/sdk/log"
 	"go.temporal.io/sdk/worker"
 
 	"github.com/flight-booking-system/internal/config"
 	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/redisops"
+	"github.com/flight-booking-system/internal/repository"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
 	"github.com/flight-booking-system/internal/temporal/activities"
 	"github.com/flight-booking-system/internal/temporal/workflows"
+	"github.com/flight-booking-system/internal/webhook"
 )
 
 func main() {
@@ -39,11 +48,19 @@ func main() {
 	}
 	defer redisClient.Close()
 	log.Println("Connected to Redis")
+	if err := redisops.Load(ctx, redisClient); err != nil {
+		log.Printf("Warning: Failed to preload Redis scripts: %v", err)
+	}
 
-	// Connect to Temporal
+	// Connect to Temporal. The interceptor applies to the workers created
+	// from the client too, logging and counting every workflow and activity.
+	temporalStats := temporalpkg.NewInvocationMetrics()
 	temporalClient, err := client.Dial(client.Options{
 		HostPort:  cfg.Temporal.Host,
 		Namespace: cfg.Temporal.Namespace,
+		Interceptors: []interceptor.ClientInterceptor{
+			temporalpkg.NewObservabilityInterceptor(temporalStats, sdklog.NewStructuredLogger(slog.Default())),
+		},
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Temporal: %v", err)
@@ -56,29 +73,150 @@ func main() {
 
 	// Register workflows
 	w.RegisterWorkflow(workflows.BookingWorkflow)
+	w.RegisterWorkflow(workflows.PaymentWorkflow)
+	w.RegisterWorkflow(workflows.GroupBookingWorkflow)
 	w.RegisterWorkflow(workflows.SeatReconciliationWorkflow)
+	w.RegisterWorkflow(workflows.SeatSwapWorkflow)
+	w.RegisterWorkflow(workflows.TripWorkflow)
+	w.RegisterWorkflow(workflows.CheckInWorkflow)
+	w.RegisterWorkflow(workflows.RefundWorkflow)
+	w.RegisterWorkflow(workflows.BalanceDueWorkflow)
+	w.RegisterWorkflow(workflows.DisruptionWorkflow)
+	w.RegisterWorkflow(workflows.DepartureSchedulerWorkflow)
+	w.RegisterWorkflow(workflows.FlightDepartureWorkflow)
+	w.RegisterWorkflow(workflows.OverbookingBumpWorkflow)
+	w.RegisterWorkflow(workflows.RepricingWorkflow)
+	w.RegisterWorkflow(workflows.OpsDigestWorkflow)
+	w.RegisterWorkflow(workflows.StaleOrderSweepWorkflow)
+	w.RegisterWorkflow(workflows.OrderArchivalWorkflow)
+
+	// A bad pricing curve would otherwise only surface as warnings on bookings
+	if _, err := domain.ParsePricingCurve(cfg.Booking.PricingCurve); err != nil {
+		log.Fatalf("Invalid PRICING_CURVE: %v", err)
+	}
+
+	// Unseeded runs report the seed they draw so they can be replayed
+	if cfg.Booking.SimulationSeed == 0 {
+		cfg.Booking.SimulationSeed = time.Now().UnixNano()
+		log.Printf("Simulation seed %d (set SIMULATION_SEED to replay this run)", cfg.Booking.SimulationSeed)
+	}
 
 	// Create and register activities
-	bookingActivities := activities.NewBookingActivities(pool, redisClient, &cfg.Booking)
+	bookingActivities := activities.NewBookingActivities(pool, redisClient, temporalClient, &cfg.Booking)
 	w.RegisterActivity(bookingActivities)
 
+	// A second worker serves payment activities of orders close to expiry, so
+	// they are not queued behind fresh orders' work
+	var urgent worker.Worker
+	if queue := cfg.Temporal.UrgentTaskQueue; queue != "" {
+		urgent = worker.New(temporalClient, queue, worker.Options{})
+		urgent.RegisterActivity(bookingActivities)
+	}
+
 	log.Println("Registered workflows and activities")
 
-	// Start seat reconciliation cron workflow
+	// Register the seat reconciliation schedule, replacing the old cron workflow
+	go func() {
+		if err := ensureReconciliationSchedule(ctx, temporalClient, cfg.Reconciliation, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register reconciliation schedule: %v", err)
+		} else if cfg.Reconciliation.Interval > 0 {
+			log.Printf("Registered seat reconciliation schedule (every %s, jitter %s, overlap %s)",
+				cfg.Reconciliation.Interval, cfg.Reconciliation.Jitter, cfg.Reconciliation.Overlap)
+		}
+	}()
+
+	// Register the schedule that takes flights through sales close and departure
+	go func() {
+		if err := ensureDepartureSchedule(ctx, temporalClient, cfg.Departure, cfg.Booking.SalesCloseBefore, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register departure schedule: %v", err)
+		} else if cfg.Departure.ScanInterval > 0 {
+			log.Printf("Registered flight departure schedule (every %s, horizon %s, sales close %s before departure)",
+				cfg.Departure.ScanInterval, cfg.Departure.Horizon, cfg.Booking.SalesCloseBefore)
+		}
+	}()
+
+	// Register the dynamic pricing schedule when flights are repriced periodically
+	go func() {
+		if err := ensureRepricingSchedule(ctx, temporalClient, cfg.Booking, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register repricing schedule: %v", err)
+		} else if cfg.Booking.PricingCurve != "" && cfg.Booking.PricingRecompute == string(domain.PriceChangeSchedule) {
+			log.Printf("Registered flight repricing schedule (every %s)", cfg.Booking.PricingInterval)
+		}
+	}()
+
+	// Register the schedule that expires orders whose workflows were lost
+	go func() {
+		if err := ensureStaleOrderSweepSchedule(ctx, temporalClient, cfg.Sweep, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register stale order sweep schedule: %v", err)
+		} else if cfg.Sweep.Interval > 0 {
+			log.Printf("Registered stale order sweep schedule (every %s, grace %s)", cfg.Sweep.Interval, cfg.Sweep.Grace)
+		}
+	}()
+
+	// Register the schedule that archives finished orders past their retention
+	go func() {
+		if err := ensureOrderArchivalSchedule(ctx, temporalClient, cfg.Archive, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register order archival schedule: %v", err)
+		} else if cfg.Archive.Interval > 0 {
+			log.Printf("Registered order archival schedule (every %s, retention %s)", cfg.Archive.Interval, cfg.Archive.Retention)
+		}
+	}()
+
+	// Register the daily operator digest schedule when an ops address is set
 	go func() {
-		workflowOptions := client.StartWorkflowOptions{
-			ID:           "seat-reconciliation-cron",
-			TaskQueue:    cfg.Temporal.TaskQueue,
-			CronSchedule: "*/10 * * * *", // Every 10 minutes
+		if err := ensureOpsDigestSchedule(ctx, temporalClient, cfg.Digest, cfg.Temporal.TaskQueue); err != nil {
+			log.Printf("Warning: Failed to register ops digest schedule: %v", err)
+		} else if cfg.Digest.Address != "" {
+			log.Printf("Registered ops digest schedule (%q to %s on %v)", cfg.Digest.Schedule, cfg.Digest.Address, cfg.Digest.Channels)
 		}
-		_, err := temporalClient.ExecuteWorkflow(ctx, workflowOptions, workflows.SeatReconciliationWorkflow)
-		if err != nil {
-			log.Printf("Warning: Failed to start reconciliation cron workflow: %v", err)
-		} else {
-			log.Println("Started seat reconciliation cron workflow (runs every 10 minutes)")
+	}()
+
+	// Start the simulated flight disruption cron workflow when enabled
+	if disruption := cfg.Disruption; disruption.Probability > 0 {
+		go func() {
+			workflowOptions := client.StartWorkflowOptions{
+				ID:           "disruption-generator-cron",
+				TaskQueue:    cfg.Temporal.TaskQueue,
+				CronSchedule: disruption.Schedule,
+			}
+			input := temporalpkg.DisruptionWorkflowInput{
+				Policy: domain.DisruptionPolicy{
+					Probability: disruption.Probability,
+					CancelShare: disruption.CancelShare,
+					MaxDelay:    disruption.MaxDelay,
+				},
+				Horizon: disruption.Horizon,
+			}
+			_, err := temporalClient.ExecuteWorkflow(ctx, workflowOptions, workflows.DisruptionWorkflow, input)
+			if err != nil {
+				log.Printf("Warning: Failed to start disruption cron workflow: %v", err)
+			} else {
+				log.Printf("Started disruption cron workflow (schedule %q)", disruption.Schedule)
+			}
+		}()
+	}
+
+	// Log workflow and activity counts periodically; the worker serves no status page
+	if interval := cfg.Temporal.MetricsLogInterval; interval > 0 {
+		go logInvocationMetrics(ctx, temporalStats, interval)
+	}
+
+	// Deliver queued order lifecycle webhooks until shutdown
+	dispatcher := webhook.NewDispatcher(repository.NewWebhookRepo(pool), cfg.Webhook)
+	go func() {
+		log.Println("Webhook dispatcher started")
+		if err := dispatcher.Run(ctx); err != nil {
+			log.Printf("Webhook dispatcher stopped: %v", err)
 		}
 	}()
 
+	if urgent != nil {
+		if err := urgent.Start(); err != nil {
+			log.Fatalf("Urgent worker failed: %v", err)
+		}
+		log.Printf("Urgent worker started on task queue: %s", cfg.Temporal.UrgentTaskQueue)
+	}
+
 	// Start worker in goroutine
 	go func() {
 		log.Printf("Worker starting on task queue: %s", cfg.Temporal.TaskQueue)
@@ -93,6 +231,33 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down worker...")
+	cancel()
 	w.Stop()
+	if urgent != nil {
+		urgent.Stop()
+	}
 	log.Println("Worker stopped")
 }
+
+// logInvocationMetrics logs the counts of each workflow and activity that ran
+// every interval until ctx is done, so failing activities show in the logs
+func logInvocationMetrics(ctx context.Context, metrics *temporalpkg.InvocationMetrics, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, s := range metrics.Snapshot() {
+			var avg time.Duration
+			if s.Calls > 0 {
+				avg = s.TotalLatency / time.Duration(s.Calls)
+			}
+			slog.Info("Temporal invocation metrics", "kind", s.Kind, "name", s.Name,
+				"calls", s.Calls, "failures", s.Failures, "retries", s.Retries, "avgLatency", avg, "maxLatency", s.MaxLatency)
+		}
+	}
+}
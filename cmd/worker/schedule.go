@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+// ensureReconciliationSchedule creates the seat reconciliation schedule, or
+// updates it to the configured interval, jitter and overlap policy when an
+// earlier worker already created it. A zero interval deletes it. The cron
+// workflow that ran reconciliation before schedules is terminated so the two
+// don't both run.
+func ensureReconciliationSchedule(ctx context.Context, c client.Client, cfg config.ReconciliationConfig, taskQueue string) error {
+	err := c.TerminateWorkflow(ctx, temporalpkg.ReconciliationCronWorkflowID, "", "replaced by the "+temporalpkg.ReconciliationScheduleID+" schedule")
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("terminate reconciliation cron workflow: %w", err)
+	}
+
+	if cfg.Interval <= 0 {
+		return deleteSchedule(ctx, c, temporalpkg.ReconciliationScheduleID)
+	}
+
+	overlap, err := temporalpkg.ParseOverlapPolicy(cfg.Overlap)
+	if err != nil {
+		return err
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.ReconciliationScheduleID, client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{{Every: cfg.Interval}},
+		Jitter:    cfg.Jitter,
+	}, &client.ScheduleWorkflowAction{
+		ID:        "seat-reconciliation",
+		Workflow:  workflows.SeatReconciliationWorkflow,
+		TaskQueue: taskQueue,
+	}, overlap)
+}
+
+// ensureDepartureSchedule creates or updates the schedule that starts a
+// departure workflow for each flight about to depart; a zero scan interval
+// deletes it. A scan still running when the next is due is skipped.
+func ensureDepartureSchedule(ctx context.Context, c client.Client, cfg config.DepartureConfig, salesCloseBefore time.Duration, taskQueue string) error {
+	if cfg.ScanInterval <= 0 {
+		return deleteSchedule(ctx, c, temporalpkg.DepartureScheduleID)
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.DepartureScheduleID, client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{{Every: cfg.ScanInterval}},
+	}, &client.ScheduleWorkflowAction{
+		ID:       "departure-scheduler",
+		Workflow: workflows.DepartureSchedulerWorkflow,
+		Args: []interface{}{temporalpkg.DepartureSchedulerWorkflowInput{
+			Horizon:          cfg.Horizon,
+			SalesCloseBefore: salesCloseBefore,
+			Bump: domain.BumpPolicy{
+				CompensationCents: cfg.BumpCompensationCents,
+				RebookWindow:      cfg.BumpRebookWindow,
+			},
+		}},
+		TaskQueue: taskQueue,
+	}, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP)
+}
+
+// ensureRepricingSchedule creates or updates the schedule that reprices
+// flights on sale when dynamic pricing recomputes on a schedule, and deletes
+// it otherwise. A run still going when the next is due is skipped.
+func ensureRepricingSchedule(ctx context.Context, c client.Client, cfg config.BookingConfig, taskQueue string) error {
+	if cfg.PricingCurve == "" || cfg.PricingRecompute != string(domain.PriceChangeSchedule) || cfg.PricingInterval <= 0 {
+		return deleteSchedule(ctx, c, temporalpkg.RepricingScheduleID)
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.RepricingScheduleID, client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{{Every: cfg.PricingInterval}},
+	}, &client.ScheduleWorkflowAction{
+		ID:        "flight-repricing",
+		Workflow:  workflows.RepricingWorkflow,
+		TaskQueue: taskQueue,
+	}, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP)
+}
+
+// ensureStaleOrderSweepSchedule creates or updates the schedule that
+// expires orders whose booking workflows are gone; a zero interval deletes
+// it. A sweep still running when the next is due is skipped.
+func ensureStaleOrderSweepSchedule(ctx context.Context, c client.Client, cfg config.SweepConfig, taskQueue string) error {
+	if cfg.Interval <= 0 {
+		return deleteSchedule(ctx, c, temporalpkg.StaleOrderSweepScheduleID)
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.StaleOrderSweepScheduleID, client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{{Every: cfg.Interval}},
+	}, &client.ScheduleWorkflowAction{
+		ID:       "stale-order-sweep",
+		Workflow: workflows.StaleOrderSweepWorkflow,
+		Args: []interface{}{temporalpkg.StaleOrderSweepWorkflowInput{
+			Grace: cfg.Grace,
+			Limit: cfg.BatchSize,
+		}},
+		TaskQueue: taskQueue,
+	}, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP)
+}
+
+// ensureOrderArchivalSchedule creates or updates the schedule that archives
+// finished orders past their retention; a zero interval deletes it. A run
+// still going when the next is due is skipped.
+func ensureOrderArchivalSchedule(ctx context.Context, c client.Client, cfg config.ArchiveConfig, taskQueue string) error {
+	if cfg.Interval <= 0 {
+		return deleteSchedule(ctx, c, temporalpkg.OrderArchivalScheduleID)
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.OrderArchivalScheduleID, client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{{Every: cfg.Interval}},
+	}, &client.ScheduleWorkflowAction{
+		ID:       "order-archival",
+		Workflow: workflows.OrderArchivalWorkflow,
+		Args: []interface{}{temporalpkg.OrderArchivalWorkflowInput{
+			Retention: cfg.Retention,
+			BatchSize: cfg.BatchSize,
+		}},
+		TaskQueue: taskQueue,
+	}, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP)
+}
+
+// ensureOpsDigestSchedule creates or updates the schedule that sends the
+// operator digest on its cron schedule; an empty ops address deletes it. Each
+// digest covers the day before it runs, and a run still going when the next
+// is due is skipped.
+func ensureOpsDigestSchedule(ctx context.Context, c client.Client, cfg config.DigestConfig, taskQueue string) error {
+	if cfg.Address == "" {
+		return deleteSchedule(ctx, c, temporalpkg.OpsDigestScheduleID)
+	}
+
+	channels := make([]domain.NotificationChannel, 0, len(cfg.Channels))
+	for _, name := range cfg.Channels {
+		channel := domain.NotificationChannel(name)
+		if !slices.Contains(domain.AllNotificationChannels, channel) {
+			return fmt.Errorf("unknown ops digest channel %q", name)
+		}
+		channels = append(channels, channel)
+	}
+
+	return ensureSchedule(ctx, c, temporalpkg.OpsDigestScheduleID, client.ScheduleSpec{
+		CronExpressions: []string{cfg.Schedule},
+	}, &client.ScheduleWorkflowAction{
+		ID:       "ops-digest",
+		Workflow: workflows.OpsDigestWorkflow,
+		Args: []interface{}{temporalpkg.OpsDigestWorkflowInput{
+			Address:     cfg.Address,
+			Channels:    channels,
+			Period:      24 * time.Hour,
+			SpikeFactor: cfg.SpikeFactor,
+		}},
+		TaskQueue: taskQueue,
+	}, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP)
+}
+
+// ensureSchedule creates a schedule, or brings one an earlier worker created
+// in line with this worker's spec, action and overlap policy
+func ensureSchedule(ctx context.Context, c client.Client, id string, spec client.ScheduleSpec, action *client.ScheduleWorkflowAction, overlap enumspb.ScheduleOverlapPolicy) error {
+	_, err := c.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:      id,
+		Spec:    spec,
+		Action:  action,
+		Overlap: overlap,
+	})
+	if !errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		if err != nil {
+			return fmt.Errorf("create %s schedule: %w", id, err)
+		}
+		return nil
+	}
+
+	// Another worker created it; bring it in line with this worker's config
+	err = c.ScheduleClient().GetHandle(ctx, id).Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.Spec = &spec
+			schedule.Action = action
+			if schedule.Policy == nil {
+				schedule.Policy = &client.SchedulePolicies{}
+			}
+			schedule.Policy.Overlap = overlap
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("update %s schedule: %w", id, err)
+	}
+
+	return nil
+}
+
+// deleteSchedule removes a schedule if it exists
+func deleteSchedule(ctx context.Context, c client.Client, id string) error {
+	if err := c.ScheduleClient().GetHandle(ctx, id).Delete(ctx); err != nil && !isNotFound(err) {
+		return fmt.Errorf("delete %s schedule: %w", id, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.As(err, &notFound)
+}
@@ -0,0 +1,531 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/inventory"
+	"github.com/flight-booking-system/internal/timetable"
+)
+
+// UpdateSeatMap handles PATCH /api/admin/flights/{flightId}/seats[?dryRun=true]
+func (h *Handlers) UpdateSeatMap(w http.ResponseWriter, r *http.Request) {
+	flightID := chi.URLParam(r, "flightId")
+	if flightID == "" {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "flight ID is required")
+		return
+	}
+
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+
+	var req AdminSeatMapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	change := domain.SeatMapChange{
+		Remove:  req.Remove,
+		Block:   req.Block,
+		Unblock: req.Unblock,
+	}
+	for _, seat := range req.Add {
+		change.Add = append(change.Add, domain.Seat{ID: seat.ID, Row: seat.Row, Column: seat.Column, Class: domain.CabinClass(seat.Class)})
+	}
+
+	if dryRun {
+		plan, err := h.flightService.PlanSeatMapChange(r.Context(), flightID, change)
+		if err != nil {
+			HandleServiceError(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, newAdminPlanResponse(*plan))
+		return
+	}
+
+	flight, err := h.flightService.UpdateSeatMap(r.Context(), flightID, change)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newFlightResponse(*flight))
+}
+
+// ReleaseSeatLocks handles POST /api/admin/flights/{flightId}/release-locks[?dryRun=true]
+func (h *Handlers) ReleaseSeatLocks(w http.ResponseWriter, r *http.Request) {
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+
+	var req AdminReleaseLocksRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+			return
+		}
+	}
+
+	plan, err := h.flightService.ReleaseLocks(r.Context(), chi.URLParam(r, "flightId"), req.Seats, dryRun)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newAdminPlanResponse(*plan))
+}
+
+// ExportInventory handles GET /api/admin/flights/{flightId}/inventory[?format=csv|json]
+func (h *Handlers) ExportInventory(w http.ResponseWriter, r *http.Request) {
+	flightID := chi.URLParam(r, "flightId")
+	format, ok := parseInventoryFormat(w, r)
+	if !ok {
+		return
+	}
+
+	seats, err := h.flightService.ExportInventory(r.Context(), flightID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	contentType := "application/json"
+	if format == inventory.FormatCSV {
+		contentType = "text/csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="seats-`+flightID+`.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	inventory.Write(w, format, flightID, seats)
+}
+
+// ImportInventory handles PUT /api/admin/flights/{flightId}/inventory[?format=csv|json][&dryRun=true]
+func (h *Handlers) ImportInventory(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseInventoryFormat(w, r)
+	if !ok {
+		return
+	}
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+
+	seats, err := inventory.Read(r.Body, format)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+
+	change, err := h.flightService.ImportInventory(r.Context(), chi.URLParam(r, "flightId"), seats, dryRun)
+	if errors.Is(err, domain.ErrInvalidSeatChange) {
+		// The detail names the offending seat, which is what an operator needs to fix the file
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidSeats, err.Error())
+		return
+	}
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := InventoryDiffResponse{
+		DryRun:  dryRun,
+		Remove:  nonNil(change.Remove),
+		Block:   nonNil(change.Block),
+		Unblock: nonNil(change.Unblock),
+		Add:     []string{},
+	}
+	for _, seat := range change.Add {
+		response.Add = append(response.Add, seat.ID)
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// ImportSchedule handles POST /api/admin/flights/import[?format=csv|json][&dryRun=true]
+func (h *Handlers) ImportSchedule(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseInventoryFormat(w, r)
+	if !ok {
+		return
+	}
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+
+	flights, rowErrors, err := timetable.Read(r.Body, format)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	// Nothing is imported from a file with bad rows; each is reported so the
+	// file can be fixed in one pass
+	if len(rowErrors) > 0 {
+		var v validator
+		for _, e := range rowErrors {
+			v.fail(fmt.Sprintf("line %d %s", e.Line, e.Field), "%s", e.Message)
+		}
+		v.check(w)
+		return
+	}
+
+	result, err := h.flightService.ImportSchedule(r.Context(), flights, dryRun)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, ScheduleImportResponse{
+		DryRun:  dryRun,
+		Created: result.Created,
+		Skipped: result.Skipped,
+	})
+}
+
+// FreezeBookings handles PUT /api/admin/flights/{flightId}/freeze
+func (h *Handlers) FreezeBookings(w http.ResponseWriter, r *http.Request) {
+	var req AdminFreezeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Frozen == nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "frozen must be true or false")
+		return
+	}
+
+	flight, err := h.flightService.SetBookingFrozen(r.Context(), chi.URLParam(r, "flightId"), *req.Frozen)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newFlightResponse(*flight))
+}
+
+// SetOverbooking handles PUT /api/admin/flights/{flightId}/overbooking
+func (h *Handlers) SetOverbooking(w http.ResponseWriter, r *http.Request) {
+	var req AdminOverbookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Percent == nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "percent is required")
+		return
+	}
+
+	flight, err := h.flightService.SetOverbooking(r.Context(), chi.URLParam(r, "flightId"), *req.Percent)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newFlightResponse(*flight))
+}
+
+// FlightBumps handles GET /api/admin/flights/{flightId}/bumps
+func (h *Handlers) FlightBumps(w http.ResponseWriter, r *http.Request) {
+	flightID := chi.URLParam(r, "flightId")
+	bumps, err := h.flightService.Bumps(r.Context(), flightID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := BumpListResponse{FlightID: flightID, Bumps: make([]BumpResponse, 0, len(bumps))}
+	for _, b := range bumps {
+		response.Bumps = append(response.Bumps, BumpResponse{
+			OrderID:             b.OrderID,
+			Seats:               b.Seats,
+			Status:              string(b.Status),
+			AlternativeFlightID: b.AlternativeFlightID,
+			CompensationCents:   b.CompensationCents,
+			BumpedAt:            b.CreatedAt,
+		})
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// FlightPriceHistory handles GET /api/admin/flights/{flightId}/prices
+func (h *Handlers) FlightPriceHistory(w http.ResponseWriter, r *http.Request) {
+	flightID := chi.URLParam(r, "flightId")
+	changes, err := h.flightService.PriceHistory(r.Context(), flightID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := PriceHistoryResponse{FlightID: flightID, Changes: make([]PriceChangeResponse, 0, len(changes))}
+	for _, c := range changes {
+		response.Changes = append(response.Changes, PriceChangeResponse{
+			PriceCents:         c.PriceCents,
+			PreviousPriceCents: c.PreviousPriceCents,
+			BasePriceCents:     c.BasePriceCents,
+			TotalSeats:         c.TotalSeats,
+			AvailableSeats:     c.AvailableSeats,
+			Reason:             string(c.Reason),
+			OrderID:            c.OrderID,
+			ChangedAt:          c.ChangedAt,
+		})
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// FlightManifest handles GET /api/admin/flights/{flightId}/manifest
+func (h *Handlers) FlightManifest(w http.ResponseWriter, r *http.Request) {
+	manifest, err := h.flightService.Manifest(r.Context(), chi.URLParam(r, "flightId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := ManifestResponse{
+		FlightID:      manifest.FlightID,
+		FlightNumber:  manifest.FlightNumber,
+		DepartureTime: manifest.DepartureTime,
+		Passengers:    make([]ManifestEntryResponse, 0, len(manifest.Passengers)),
+		SeatlessCount: manifest.SeatlessCount,
+		GeneratedAt:   manifest.GeneratedAt,
+	}
+	for _, p := range manifest.Passengers {
+		response.Passengers = append(response.Passengers, ManifestEntryResponse(p))
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// FlightRevenue handles GET /api/admin/flights/{flightId}/revenue
+func (h *Handlers) FlightRevenue(w http.ResponseWriter, r *http.Request) {
+	revenue, err := h.flightService.Revenue(r.Context(), chi.URLParam(r, "flightId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, FlightRevenueResponse{
+		FlightID:        revenue.FlightID,
+		Orders:          revenue.Orders,
+		RecognizedCents: revenue.RecognizedCents,
+		ReversedOrders:  revenue.ReversedOrders,
+		ReversedCents:   revenue.ReversedCents,
+	})
+}
+
+// ListOrders handles GET /api/admin/orders?status=&flightId=&createdAfter=&createdBefore=&sort=&desc=&limit=&offset=
+func (h *Handlers) ListOrders(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	filter, page := v.orderListQuery(r.URL.Query())
+	if !v.check(w) {
+		return
+	}
+
+	orders, err := h.bookingService.ListOrders(r.Context(), filter, page)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := OrderListResponse{
+		Orders: make([]OrderSummaryResponse, 0, len(orders.Orders)),
+		Total:  orders.Total,
+		Limit:  orders.Page.Limit,
+		Offset: orders.Page.Offset,
+	}
+	for _, o := range orders.Orders {
+		summary := OrderSummaryResponse{
+			OrderID:          o.ID,
+			FlightID:         o.FlightID,
+			Status:           string(o.Status),
+			Seats:            o.Seats,
+			CabinSeats:       o.CabinSeats,
+			TotalPriceCents:  o.TotalPriceCents,
+			BookingReference: derefString(o.BookingReference),
+			TripID:           derefString(o.TripID),
+			FailureReason:    derefString(o.FailureReason),
+			Version:          o.Version,
+			CreatedAt:        o.CreatedAt,
+			ConfirmedAt:      o.ConfirmedAt,
+		}
+		if o.PaymentMethod != nil {
+			summary.PaymentMethod = string(*o.PaymentMethod)
+		}
+		response.Orders = append(response.Orders, summary)
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// parseInventoryFormat reads the format query parameter, defaulting to json
+func parseInventoryFormat(w http.ResponseWriter, r *http.Request) (inventory.Format, bool) {
+	raw := r.URL.Query().Get("format")
+	if raw == "" {
+		return inventory.FormatJSON, true
+	}
+
+	format, err := inventory.ParseFormat(raw)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "format must be csv or json")
+		return "", false
+	}
+	return format, true
+}
+
+// parseDryRun reads the dryRun query flag shared by admin endpoints,
+// writing a 400 and returning false when it is malformed
+func parseDryRun(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	raw := r.URL.Query().Get("dryRun")
+	if raw == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(raw)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "dryRun must be true or false")
+		return false, false
+	}
+	return dryRun, true
+}
+
+func newAdminPlanResponse(p domain.AdminPlan) AdminPlanResponse {
+	return AdminPlanResponse{
+		Action:    p.Action,
+		DryRun:    p.DryRun,
+		Seats:     nonNil(p.Seats),
+		Orders:    nonNil(p.Orders),
+		Workflows: nonNil(p.Workflows),
+	}
+}
+
+// nonNil keeps empty lists as [] rather than null in JSON
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// CreateWebhook handles POST /api/admin/webhooks
+func (h *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req AdminWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	events := make([]domain.WebhookEvent, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = domain.WebhookEvent(e)
+	}
+
+	sub, err := h.webhookService.Subscribe(r.Context(), req.URL, req.Secret, events)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := newWebhookResponse(*sub)
+	response.Secret = sub.Secret
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListWebhooks handles GET /api/admin/webhooks
+func (h *Handlers) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := WebhookListResponse{Webhooks: make([]WebhookResponse, len(subs))}
+	for i, s := range subs {
+		response.Webhooks[i] = newWebhookResponse(s)
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /api/admin/webhooks/{webhookId}
+func (h *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.webhookService.Unsubscribe(r.Context(), chi.URLParam(r, "webhookId")); err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maxRefundReason bounds the reason recorded on a refund
+const maxRefundReason = 500
+
+// RefundOrder handles POST /api/orders/{orderId}/refund[?dryRun=true]; the
+// body is optional
+func (h *Handlers) RefundOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+
+	dryRun, ok := parseDryRun(w, r)
+	if !ok {
+		return
+	}
+
+	var req AdminRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("orderId", orderID)
+	if len(req.Reason) > maxRefundReason {
+		v.fail("reason", "must be at most %d characters", maxRefundReason)
+	}
+	if req.Version != nil && *req.Version < 1 {
+		v.fail("version", "must be at least 1")
+	}
+	if !v.check(w) {
+		return
+	}
+
+	if dryRun {
+		plan, err := h.bookingService.PlanRefund(r.Context(), orderID, req.Version)
+		if err != nil {
+			HandleServiceError(w, err)
+			return
+		}
+		WriteJSON(w, http.StatusOK, newAdminPlanResponse(*plan))
+		return
+	}
+
+	refund, err := h.bookingService.RefundOrder(r.Context(), orderID, req.Reason, req.Version)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, RefundResponse{
+		ID:          refund.ID,
+		OrderID:     refund.OrderID,
+		AmountCents: refund.AmountCents,
+		Reason:      refund.Reason,
+		Status:      string(refund.Status),
+		CreatedAt:   refund.CreatedAt,
+		CompletedAt: refund.CompletedAt,
+	})
+}
+
+func newWebhookResponse(s domain.WebhookSubscription) WebhookResponse {
+	response := WebhookResponse{
+		ID:        s.ID,
+		URL:       s.URL,
+		Events:    make([]string, len(s.Events)),
+		CreatedAt: s.CreatedAt,
+	}
+	for i, e := range s.Events {
+		response.Events[i] = string(e)
+	}
+	return response
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/flight-booking-system/internal/domain"
 )
@@ -12,17 +15,81 @@ import (
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
+
+	// RetryAfterSeconds hints when a conflict may clear, e.g. when the first
+	// lock on a contested seat expires; it matches the Retry-After header
+	RetryAfterSeconds int `json:"retryAfterSeconds,omitempty"`
+
+	// SeatID names the seat another order holds when its lock stopped the
+	// request
+	SeatID string `json:"seatId,omitempty"`
+}
+
+// FieldError describes one invalid request field, e.g. seats[2]
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse is an error response listing every invalid field
+type ValidationErrorResponse struct {
+	Error   string       `json:"error"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields"`
 }
 
 // Error codes
 const (
 	ErrCodeInvalidRequest   = "INVALID_REQUEST"
+	ErrCodeValidation       = "VALIDATION_FAILED"
+	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	ErrCodeRateLimited      = "RATE_LIMITED"
+	ErrCodeUnauthorized     = "UNAUTHORIZED"
 	ErrCodeInvalidSeats     = "INVALID_SEATS"
 	ErrCodeFlightNotFound   = "FLIGHT_NOT_FOUND"
 	ErrCodeOrderNotFound    = "ORDER_NOT_FOUND"
 	ErrCodeOrderExpired     = "ORDER_EXPIRED"
 	ErrCodeSeatsUnavailable = "SEATS_UNAVAILABLE"
+	ErrCodeDoubleBooking    = "DOUBLE_BOOKING"
+	ErrCodeSeatNotFound     = "SEAT_NOT_FOUND"
+	ErrCodeSeatExists       = "SEAT_EXISTS"
+	ErrCodeSeatInUse        = "SEAT_IN_USE"
+	ErrCodeSwapNotFound     = "SWAP_OFFER_NOT_FOUND"
+	ErrCodeSwapNotAllowed   = "SWAP_NOT_ALLOWED"
+	ErrCodeInvalidPrefs     = "INVALID_PREFERENCES"
+	ErrCodeTokenNotFound    = "TOKEN_NOT_FOUND"
+	ErrCodeWebhookNotFound  = "WEBHOOK_NOT_FOUND"
+	ErrCodeInvalidWebhook   = "INVALID_WEBHOOK"
+	ErrCodeHoldNotExtended  = "HOLD_NOT_EXTENDABLE"
+	ErrCodeHoldLimit        = "HOLD_EXTENSION_LIMIT"
+	ErrCodeInvalidPassenger = "INVALID_PASSENGERS"
 	ErrCodePaymentFailed    = "PAYMENT_FAILED"
+	ErrCodePaymentNotFound  = "PAYMENT_NOT_FOUND"
+	ErrCodeUpdatePending    = "UPDATE_PENDING"
+	ErrCodeVersionConflict  = "VERSION_CONFLICT"
+	ErrCodeNotConfirmed     = "ORDER_NOT_CONFIRMED"
+	ErrCodePassNotReady     = "BOARDING_PASS_NOT_READY"
+	ErrCodeSeatlessOrder    = "SEATLESS_ORDER"
+	ErrCodeNoCapacity       = "INSUFFICIENT_SEATS"
+	ErrCodeTripNotFound     = "TRIP_NOT_FOUND"
+	ErrCodeInvalidTrip      = "INVALID_TRIP"
+	ErrCodeTripLeg          = "TRIP_LEG"
+	ErrCodeInvalidGroup     = "INVALID_GROUP_SIZE"
+	ErrCodeCheckInNotOpen   = "CHECKIN_NOT_OPEN"
+	ErrCodeCheckInClosed    = "CHECKIN_CLOSED"
+	ErrCodeCabinMismatch    = "CABIN_MISMATCH"
+	ErrCodeNotApproved      = "PARTIAL_NOT_APPROVED"
+	ErrCodeNoPartial        = "NO_PARTIAL_BOOKING"
+	ErrCodeNoUpsellOffer    = "NO_UPSELL_OFFER"
+	ErrCodeNoChallenge      = "NO_PAYMENT_CHALLENGE"
+	ErrCodeFlightCancelled  = "FLIGHT_CANCELLED"
+	ErrCodeNotRefundable    = "ORDER_NOT_REFUNDABLE"
+	ErrCodeRefundFailed     = "REFUND_FAILED"
+	ErrCodeNoDeposit        = "DEPOSIT_UNAVAILABLE"
+	ErrCodeFlightFrozen     = "FLIGHT_FROZEN"
+	ErrCodeSalesClosed      = "SALES_CLOSED"
+	ErrCodeManifestNotFound = "MANIFEST_NOT_FOUND"
+	ErrCodeInvalidOverbook  = "INVALID_OVERBOOKING"
 	ErrCodeInternalError    = "INTERNAL_ERROR"
 	ErrCodeWorkflowError    = "WORKFLOW_ERROR"
 )
@@ -55,17 +122,117 @@ func MapDomainError(err error) (int, string, string) {
 		return http.StatusConflict, ErrCodeOrderExpired, "Order reservation has expired"
 	case errors.Is(err, domain.ErrSeatUnavailable), errors.Is(err, domain.ErrSeatsAlreadyLocked):
 		return http.StatusConflict, ErrCodeSeatsUnavailable, "One or more seats are not available"
+	case errors.Is(err, domain.ErrDoubleBooking):
+		return http.StatusConflict, ErrCodeDoubleBooking, "Seat is already held by another order"
+	case errors.Is(err, domain.ErrSeatNotFound):
+		return http.StatusNotFound, ErrCodeSeatNotFound, "Seat not found"
+	case errors.Is(err, domain.ErrSeatExists):
+		return http.StatusConflict, ErrCodeSeatExists, "Seat already exists"
+	case errors.Is(err, domain.ErrSeatInUse):
+		return http.StatusConflict, ErrCodeSeatInUse, "Seat is reserved or booked"
+	case errors.Is(err, domain.ErrInvalidSeatChange):
+		return http.StatusBadRequest, ErrCodeInvalidSeats, "Invalid seat map change"
+	case errors.Is(err, domain.ErrSwapOfferNotFound):
+		return http.StatusNotFound, ErrCodeSwapNotFound, "Swap offer not found"
+	case errors.Is(err, domain.ErrSwapNotAllowed):
+		return http.StatusConflict, ErrCodeSwapNotAllowed, "Seat swap is not allowed for this order or offer"
+	case errors.Is(err, domain.ErrInvalidPreferences):
+		return http.StatusBadRequest, ErrCodeInvalidPrefs, "Unknown or duplicate notification channel or category"
+	case errors.Is(err, domain.ErrUnsubscribeTokenNotFound):
+		return http.StatusNotFound, ErrCodeTokenNotFound, "Unsubscribe link is invalid"
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		return http.StatusNotFound, ErrCodeWebhookNotFound, "Webhook subscription not found"
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		return http.StatusBadRequest, ErrCodeInvalidWebhook, "Webhook needs an http(s) URL and known, unique events"
+	case errors.Is(err, domain.ErrHoldNotExtendable):
+		return http.StatusConflict, ErrCodeHoldNotExtended, "Order is no longer holding seats"
+	case errors.Is(err, domain.ErrHoldExtensionLimit):
+		return http.StatusConflict, ErrCodeHoldLimit, "No hold extensions left for this order"
+	case errors.Is(err, domain.ErrInvalidPassengers):
+		return http.StatusBadRequest, ErrCodeInvalidPassenger, "Passengers must have valid details and one seat each"
+	case errors.Is(err, domain.ErrOrderNotConfirmed):
+		return http.StatusConflict, ErrCodeNotConfirmed, "Itinerary is available once the order is confirmed"
+	case errors.Is(err, domain.ErrBoardingPassNotReady):
+		return http.StatusConflict, ErrCodePassNotReady, "Boarding pass is still being generated; retry shortly"
+	case errors.Is(err, domain.ErrSeatlessOrder):
+		return http.StatusConflict, ErrCodeSeatlessOrder, "Seatless orders are assigned seats at check-in"
+	case errors.Is(err, domain.ErrInsufficientSeats):
+		return http.StatusConflict, ErrCodeNoCapacity, "Not enough seats left on this flight"
+	case errors.Is(err, domain.ErrTripNotFound):
+		return http.StatusNotFound, ErrCodeTripNotFound, "Trip not found"
+	case errors.Is(err, domain.ErrInvalidTrip):
+		return http.StatusBadRequest, ErrCodeInvalidTrip, "Trip legs must connect, each departing where and after the previous one lands"
+	case errors.Is(err, domain.ErrTripLeg):
+		return http.StatusConflict, ErrCodeTripLeg, "This order is part of a trip; pay through the trip"
+	case errors.Is(err, domain.ErrCheckInNotOpen):
+		return http.StatusConflict, ErrCodeCheckInNotOpen, "Check-in opens 24 hours before departure"
+	case errors.Is(err, domain.ErrCheckInClosed):
+		return http.StatusConflict, ErrCodeCheckInClosed, "Check-in has closed; the flight has departed"
+	case errors.Is(err, domain.ErrFlightCancelled):
+		return http.StatusConflict, ErrCodeFlightCancelled, "This flight has been cancelled"
+	case errors.Is(err, domain.ErrFlightFrozen):
+		return http.StatusConflict, ErrCodeFlightFrozen, "Bookings on this flight are temporarily frozen"
+	case errors.Is(err, domain.ErrSalesClosed):
+		return http.StatusConflict, ErrCodeSalesClosed, "This flight has stopped taking bookings ahead of departure"
+	case errors.Is(err, domain.ErrManifestNotFound):
+		return http.StatusNotFound, ErrCodeManifestNotFound, "This flight has no manifest until it departs"
+	case errors.Is(err, domain.ErrInvalidOverbooking):
+		return http.StatusBadRequest, ErrCodeInvalidOverbook, fmt.Sprintf("Overbooking must be between 0 and %d percent", domain.MaxOverbookingPercent)
+	case errors.Is(err, domain.ErrCabinMismatch):
+		return http.StatusBadRequest, ErrCodeCabinMismatch, "Seats can only change within the cabin that was booked"
+	case errors.Is(err, domain.ErrInvalidGroupSize):
+		return http.StatusBadRequest, ErrCodeInvalidGroup, fmt.Sprintf("Group bookings hold between %d and %d seats", domain.MinGroupSeats, domain.MaxGroupSeats)
+	case errors.Is(err, domain.ErrPartialNotApproved):
+		return http.StatusConflict, ErrCodeNotApproved, "Only some seats could be reserved; approve them before paying"
+	case errors.Is(err, domain.ErrNoPartialBooking):
+		return http.StatusConflict, ErrCodeNoPartial, "This order is not waiting for approval of a partial booking"
+	case errors.Is(err, domain.ErrNoUpsellOffer):
+		return http.StatusConflict, ErrCodeNoUpsellOffer, "This order has no open upgrade offer"
+	case errors.Is(err, domain.ErrNoPaymentChallenge):
+		return http.StatusConflict, ErrCodeNoChallenge, "No payment challenge is waiting for a code"
+	case errors.Is(err, domain.ErrInvalidChallengeCode):
+		return http.StatusBadRequest, ErrCodePaymentFailed, "Invalid challenge code format"
+	case errors.Is(err, domain.ErrOrderNotRefundable):
+		return http.StatusConflict, ErrCodeNotRefundable, "Only confirmed orders can be refunded"
+	case errors.Is(err, domain.ErrRefundFailed):
+		return http.StatusBadGateway, ErrCodeRefundFailed, "The payment provider did not complete the refund; the order stays confirmed"
+	case errors.Is(err, domain.ErrDepositUnavailable):
+		return http.StatusConflict, ErrCodeNoDeposit, "This order must be paid in full"
+	case errors.Is(err, domain.ErrUpdatePending):
+		return http.StatusServiceUnavailable, ErrCodeUpdatePending, "Order update accepted but not applied yet; retry the status check"
+	case errors.Is(err, domain.ErrVersionConflict):
+		return http.StatusConflict, ErrCodeVersionConflict, "The order changed since it was read; reload it and try again"
 	case errors.Is(err, domain.ErrInvalidPaymentCode):
 		return http.StatusBadRequest, ErrCodePaymentFailed, "Invalid payment code format"
+	case errors.Is(err, domain.ErrInvalidPaymentMethod):
+		return http.StatusBadRequest, ErrCodePaymentFailed, "Unknown payment method"
 	case errors.Is(err, domain.ErrPaymentFailed):
 		return http.StatusBadRequest, ErrCodePaymentFailed, "Payment validation failed"
+	case errors.Is(err, domain.ErrPaymentNotAwaited):
+		return http.StatusNotFound, ErrCodePaymentNotFound, "No payment is awaiting this result"
 	default:
 		return http.StatusInternalServerError, ErrCodeInternalError, "An internal error occurred"
 	}
 }
 
-// HandleServiceError writes appropriate error response based on service error
+// HandleServiceError writes appropriate error response based on service error.
+// Seats other orders hold also name the conflicting seat and get a
+// Retry-After hint when known.
 func HandleServiceError(w http.ResponseWriter, err error) {
 	statusCode, code, message := MapDomainError(err)
+	var unavailable *domain.SeatsUnavailableError
+	if errors.As(err, &unavailable) {
+		resp := ErrorResponse{
+			Error:   code,
+			Message: message,
+			SeatID:  unavailable.SeatID,
+		}
+		if unavailable.RetryAfter > 0 {
+			resp.RetryAfterSeconds = int((unavailable.RetryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(resp.RetryAfterSeconds))
+		}
+		WriteJSON(w, statusCode, resp)
+		return
+	}
 	WriteError(w, statusCode, code, message)
 }
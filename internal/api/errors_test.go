@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestHandleServiceError_SeatConflictRetryAfter(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry int
+		wantSeat  string
+	}{
+		{"locks held by other orders", fmt.Errorf("update seats: %w", &domain.SeatsUnavailableError{RetryAfter: 90*time.Second + time.Millisecond}), 91, ""},
+		{"conflicting seat named", &domain.SeatsUnavailableError{SeatID: "12C", RetryAfter: time.Minute}, 60, "12C"},
+		{"seat named without a lock to wait for", &domain.SeatsUnavailableError{SeatID: "3A"}, 0, "3A"},
+		{"no lock to wait for", domain.ErrSeatUnavailable, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			HandleServiceError(rec, tt.err)
+
+			if rec.Code != http.StatusConflict {
+				t.Fatalf("status = %d, want 409", rec.Code)
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Error != ErrCodeSeatsUnavailable || resp.RetryAfterSeconds != tt.wantRetry || resp.SeatID != tt.wantSeat {
+				t.Errorf("response = %+v, want %s on seat %q retrying after %d", resp, ErrCodeSeatsUnavailable, tt.wantSeat, tt.wantRetry)
+			}
+
+			wantHeader := ""
+			if tt.wantRetry > 0 {
+				wantHeader = fmt.Sprint(tt.wantRetry)
+			}
+			if got := rec.Header().Get("Retry-After"); got != wantHeader {
+				t.Errorf("Retry-After = %q, want %q", got, wantHeader)
+			}
+		})
+	}
+}
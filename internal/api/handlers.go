@@ -2,10 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/flight-booking-system/internal/domain"
 	"github.com/flight-booking-system/internal/service"
 )
 
@@ -13,13 +20,28 @@ import (
 type Handlers struct {
 	flightService  *service.FlightService
 	bookingService *service.BookingService
+	swapService    *service.SwapService
+	notifyService  *service.NotificationService
+	webhookService *service.WebhookService
+	tripService    *service.TripService
 }
 
 // NewHandlers creates a new Handlers instance
-func NewHandlers(flightService *service.FlightService, bookingService *service.BookingService) *Handlers {
+func NewHandlers(
+	flightService *service.FlightService,
+	bookingService *service.BookingService,
+	swapService *service.SwapService,
+	notifyService *service.NotificationService,
+	webhookService *service.WebhookService,
+	tripService *service.TripService,
+) *Handlers {
 	return &Handlers{
 		flightService:  flightService,
 		bookingService: bookingService,
+		swapService:    swapService,
+		notifyService:  notifyService,
+		webhookService: webhookService,
+		tripService:    tripService,
 	}
 }
 
@@ -35,26 +57,72 @@ func (h *Handlers) ListFlights(w http.ResponseWriter, r *http.Request) {
 		Flights: make([]FlightResponse, len(flights)),
 	}
 	for i, f := range flights {
-		response.Flights[i] = FlightResponse{
-			ID:             f.ID,
-			FlightNumber:   f.FlightNumber,
-			Origin:         f.Origin,
-			Destination:    f.Destination,
-			DepartureTime:  f.DepartureTime,
-			TotalSeats:     f.TotalSeats,
-			AvailableSeats: f.AvailableSeats,
-			PriceCents:     f.PriceCents,
+		response.Flights[i] = newFlightResponse(f)
+	}
+
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// maxFlexDays bounds the flexible-date search window on each side
+const maxFlexDays = 14
+
+// FareCalendar handles GET /api/flights/calendar?origin=&destination=&date=&flexDays=
+func (h *Handlers) FareCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	origin := strings.ToUpper(q.Get("origin"))
+	destination := strings.ToUpper(q.Get("destination"))
+	if len(origin) != 3 || len(destination) != 3 {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "origin and destination must be 3-letter airport codes")
+		return
+	}
+
+	date, err := time.Parse(time.DateOnly, q.Get("date"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "date must be formatted as YYYY-MM-DD")
+		return
+	}
+
+	flexDays := 3
+	if raw := q.Get("flexDays"); raw != "" {
+		flexDays, err = strconv.Atoi(raw)
+		if err != nil || flexDays < 0 || flexDays > maxFlexDays {
+			WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, fmt.Sprintf("flexDays must be between 0 and %d", maxFlexDays))
+			return
 		}
 	}
 
-	WriteJSON(w, http.StatusOK, response)
+	days, err := h.flightService.FareCalendar(r.Context(), service.FareCalendarInput{
+		Origin:      origin,
+		Destination: destination,
+		Date:        date,
+		FlexDays:    flexDays,
+	})
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := FareCalendarResponse{
+		Origin:      origin,
+		Destination: destination,
+		Days:        make([]FareDayResponse, len(days)),
+	}
+	for i, d := range days {
+		response.Days[i] = FareDayResponse{
+			Date:               d.Date.Format(time.DateOnly),
+			FlightCount:        d.FlightCount,
+			CheapestPriceCents: d.CheapestPriceCents,
+		}
+	}
+
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
 }
 
 // GetFlight handles GET /api/flights/{flightId}
 func (h *Handlers) GetFlight(w http.ResponseWriter, r *http.Request) {
 	flightID := chi.URLParam(r, "flightId")
-	if flightID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "flight ID is required")
+	var v validator
+	if v.uuid("flightId", flightID); !v.check(w) {
 		return
 	}
 
@@ -68,32 +136,29 @@ func (h *Handlers) GetFlight(w http.ResponseWriter, r *http.Request) {
 	seats := make([]SeatResponse, len(flight.SeatMap.Seats))
 	for i, s := range flight.SeatMap.Seats {
 		seats[i] = SeatResponse{
-			ID:     s.ID,
-			Row:    s.Row,
-			Column: s.Column,
-			Status: string(s.Status),
+			ID:        s.ID,
+			Row:       s.Row,
+			Column:    s.Column,
+			Class:     string(s.Class),
+			Status:    string(s.Status),
+			HeldUntil: s.HeldUntil,
 		}
 	}
 
+	rows := make([]SeatRowResponse, len(flight.SeatMap.Rows))
+	for i, row := range flight.SeatMap.Rows {
+		rows[i] = SeatRowResponse{Number: row.Number, Columns: row.Columns, Class: string(row.Class)}
+	}
+
 	response := FlightDetailResponse{
-		FlightResponse: FlightResponse{
-			ID:             flight.ID,
-			FlightNumber:   flight.FlightNumber,
-			Origin:         flight.Origin,
-			Destination:    flight.Destination,
-			DepartureTime:  flight.DepartureTime,
-			TotalSeats:     flight.TotalSeats,
-			AvailableSeats: flight.AvailableSeats,
-			PriceCents:     flight.PriceCents,
-		},
+		FlightResponse: newFlightResponse(flight.Flight),
 		SeatMap: SeatMapResponse{
-			Rows:        flight.SeatMap.Rows,
-			SeatsPerRow: flight.SeatMap.SeatsPerRow,
-			Seats:       seats,
+			Rows:  rows,
+			Seats: seats,
 		},
 	}
 
-	WriteJSON(w, http.StatusOK, response)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
 }
 
 // CreateOrder handles POST /api/orders
@@ -104,19 +169,54 @@ func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate request
-	if req.FlightID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "flightId is required")
+	v := validator{fields: ValidateCreateOrder(req)}
+	if !v.check(w) {
 		return
 	}
-	if len(req.Seats) == 0 {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidSeats, "at least one seat must be selected")
+
+	output, err := h.bookingService.CreateOrder(r.Context(), service.CreateOrderInput{
+		FlightID:   req.FlightID,
+		Seats:      req.Seats,
+		SeatCount:  req.SeatCount,
+		Passengers: newPassengers(req.Passengers),
+
+		SimulationSeed: req.SimulationSeed,
+	})
+	if err != nil {
+		HandleServiceError(w, err)
 		return
 	}
 
-	output, err := h.bookingService.CreateOrder(r.Context(), service.CreateOrderInput{
-		FlightID: req.FlightID,
-		Seats:    req.Seats,
+	response := CreateOrderResponse{
+		OrderID:    output.OrderID,
+		WorkflowID: output.WorkflowID,
+		Status:     string(output.Status),
+		ExpiresAt:  output.ExpiresAt,
+	}
+
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// CreateGroupOrder handles POST /api/orders/group
+func (h *Handlers) CreateGroupOrder(w http.ResponseWriter, r *http.Request) {
+	var req CreateGroupOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("flightId", req.FlightID)
+	v.seatsBetween("seats", req.Seats, domain.MinGroupSeats, domain.MaxGroupSeats)
+	v.passengers("passengers", req.Passengers, req.Seats, 0)
+	if !v.check(w) {
+		return
+	}
+
+	output, err := h.bookingService.CreateGroupOrder(r.Context(), service.CreateOrderInput{
+		FlightID:   req.FlightID,
+		Seats:      req.Seats,
+		Passengers: newPassengers(req.Passengers),
 	})
 	if err != nil {
 		HandleServiceError(w, err)
@@ -133,13 +233,124 @@ func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusCreated, response)
 }
 
+// maxRecommendations bounds how many seat suggestions one request returns
+const maxRecommendations = 20
+
+// RecommendSeats handles POST /api/flights/{flightId}/recommend-seats
+func (h *Handlers) RecommendSeats(w http.ResponseWriter, r *http.Request) {
+	flightID := chi.URLParam(r, "flightId")
+	var v validator
+	if v.uuid("flightId", flightID); !v.check(w) {
+		return
+	}
+
+	var req RecommendSeatsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	prefs := domain.SeatPreferences{
+		Position:  domain.SeatPosition(req.Position),
+		Zone:      domain.CabinZone(req.Zone),
+		Quiet:     req.Quiet,
+		PartySize: req.PartySize,
+	}
+	if msg := validatePreferences(prefs, req.Limit); msg != "" {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, msg)
+		return
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 5
+	}
+
+	recs, err := h.flightService.RecommendSeats(r.Context(), flightID, prefs, limit)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := RecommendSeatsResponse{FlightID: flightID, Recommendations: make([]SeatRecommendationResponse, len(recs))}
+	for i, rec := range recs {
+		response.Recommendations[i] = SeatRecommendationResponse{Seats: rec.Seats, Score: rec.Score, Reasons: rec.Reasons}
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// validatePreferences returns a client-facing message for invalid preferences, or ""
+func validatePreferences(prefs domain.SeatPreferences, limit int) string {
+	switch {
+	case prefs.Position != domain.SeatPositionAny && prefs.Position != domain.SeatPositionWindow && prefs.Position != domain.SeatPositionAisle:
+		return "position must be window or aisle"
+	case prefs.Zone != domain.CabinZoneAny && prefs.Zone != domain.CabinZoneFront && prefs.Zone != domain.CabinZoneBack:
+		return "zone must be front or back"
+	case prefs.PartySize < 0 || prefs.PartySize > 9:
+		return "partySize must be between 1 and 9"
+	case limit < 0 || limit > maxRecommendations:
+		return fmt.Sprintf("limit must be between 1 and %d", maxRecommendations)
+	}
+	return ""
+}
+
+// maxBulkGroups bounds how many orders one bulk request may start
+const maxBulkGroups = 50
+
+// CreateOrdersBulk handles POST /api/orders/bulk
+func (h *Handlers) CreateOrdersBulk(w http.ResponseWriter, r *http.Request) {
+	var req BulkCreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("flightId", req.FlightID)
+	if len(req.Groups) == 0 || len(req.Groups) > maxBulkGroups {
+		v.fail("groups", "must contain between 1 and %d entries", maxBulkGroups)
+	}
+	groups := make([][]string, len(req.Groups))
+	for i, g := range req.Groups {
+		v.seats(fmt.Sprintf("groups[%d].seats", i), g.Seats, 1)
+		groups[i] = g.Seats
+	}
+	if !v.check(w) {
+		return
+	}
+
+	results, err := h.bookingService.CreateOrders(r.Context(), req.FlightID, groups)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := BulkCreateOrderResponse{Results: make([]BulkOrderItemResult, len(results))}
+	for i, res := range results {
+		item := BulkOrderItemResult{Index: i, Seats: res.Seats}
+		if res.Err != nil {
+			_, code, message := MapDomainError(res.Err)
+			item.Error = &ErrorResponse{Error: code, Message: message}
+			response.Failed++
+		} else {
+			item.Order = &CreateOrderResponse{
+				OrderID:    res.Output.OrderID,
+				WorkflowID: res.Output.WorkflowID,
+				Status:     string(res.Output.Status),
+				ExpiresAt:  res.Output.ExpiresAt,
+			}
+			response.Succeeded++
+		}
+		response.Results[i] = item
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
 // UpdateSeats handles PUT /api/orders/{orderId}/seats
 func (h *Handlers) UpdateSeats(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "orderId")
-	if orderID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "order ID is required")
-		return
-	}
 
 	var req UpdateSeatsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -149,6 +360,12 @@ func (h *Handlers) UpdateSeats(w http.ResponseWriter, r *http.Request) {
 
 	// Note: Allow empty seats array to release all seats and reset timer
 	// This enables users to deselect all seats and restart their reservation
+	var v validator
+	v.uuid("orderId", orderID)
+	v.seats("seats", req.Seats, 0)
+	if !v.check(w) {
+		return
+	}
 
 	output, err := h.bookingService.UpdateSeats(r.Context(), orderID, req.Seats)
 	if err != nil {
@@ -157,20 +374,22 @@ func (h *Handlers) UpdateSeats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := UpdateSeatsResponse{
-		OrderID:   output.OrderID,
-		Status:    string(output.Status),
-		Seats:     output.Seats,
-		ExpiresAt: output.ExpiresAt,
+		OrderID:       output.OrderID,
+		Status:        string(output.Status),
+		Seats:         output.Seats,
+		ExpiresAt:     output.ExpiresAt,
+		Price:         newPriceResponse(output.Price),
+		ReleasedSeats: output.ReleasedSeats,
 	}
 
-	WriteJSON(w, http.StatusOK, response)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
 }
 
 // GetOrderStatus handles GET /api/orders/{orderId}/status
 func (h *Handlers) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "orderId")
-	if orderID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "order ID is required")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
 		return
 	}
 
@@ -180,38 +399,238 @@ func (h *Handlers) GetOrderStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := OrderStatusResponse{
-		OrderID:         status.OrderID,
-		Status:          string(status.Status),
-		Seats:           status.Seats,
-		TimerRemaining:  status.TimerRemaining,
-		PaymentAttempts: status.PaymentAttempts,
-		LastError:       status.LastError,
+	response := newOrderStatusResponse(status)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// GetItinerary handles GET /api/orders/{orderId}/itinerary
+func (h *Handlers) GetItinerary(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	itinerary, err := h.bookingService.GetItinerary(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := newItineraryResponse(itinerary)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// ListPayments handles GET /api/orders/{orderId}/payments
+func (h *Handlers) ListPayments(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	payments, err := h.bookingService.ListPayments(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := PaymentListResponse{Payments: make([]PaymentResponse, 0, len(payments))}
+	for _, p := range payments {
+		response.Payments = append(response.Payments, PaymentResponse{
+			ID:               p.ID,
+			Attempt:          p.Attempt,
+			Method:           string(p.Method),
+			AmountCents:      p.AmountCents,
+			Status:           string(p.Status),
+			ProviderRef:      derefString(p.ProviderRef),
+			IdempotencyKey:   p.IdempotencyKey,
+			Message:          p.Message,
+			Error:            derefString(p.Error),
+			ResumedByAttempt: p.ResumedByAttempt,
+			CreatedAt:        p.CreatedAt,
+			UpdatedAt:        p.UpdatedAt,
+		})
 	}
 
 	WriteJSON(w, http.StatusOK, response)
 }
 
-// SubmitPayment handles POST /api/orders/{orderId}/pay
-func (h *Handlers) SubmitPayment(w http.ResponseWriter, r *http.Request) {
+// ListOrderEvents handles GET /api/orders/{orderId}/events
+func (h *Handlers) ListOrderEvents(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	events, err := h.bookingService.ListOrderEvents(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := OrderEventListResponse{Events: make([]OrderEventResponse, 0, len(events))}
+	for _, e := range events {
+		response.Events = append(response.Events, OrderEventResponse{
+			Type:       string(e.Type),
+			Data:       e.Data,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// CheckIn handles POST /api/orders/{orderId}/checkin; the body is optional
+func (h *Handlers) CheckIn(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+
+	var req CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("orderId", orderID)
+	v.seats("seats", req.Seats, 0)
+	if !v.check(w) {
+		return
+	}
+
+	itinerary, err := h.bookingService.CheckIn(r.Context(), orderID, req.Seats)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := newItineraryResponse(itinerary)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+func newItineraryResponse(itinerary *domain.Itinerary) ItineraryResponse {
+	response := ItineraryResponse{
+		BookingReference: itinerary.BookingReference,
+		OrderID:          itinerary.OrderID,
+		Flight:           newFlightResponse(itinerary.Flight),
+		Seats:            itinerary.Seats,
+		Passengers:       newPassengerResponses(itinerary.Passengers),
+		Price:            newPriceResponse(itinerary.Price),
+		ConfirmedAt:      itinerary.ConfirmedAt,
+		CheckedInAt:      itinerary.CheckedInAt,
+		Deposit:          newDepositResponse(itinerary.Deposit),
+	}
+	if response.Passengers == nil {
+		response.Passengers = []PassengerResponse{}
+	}
+	return response
+}
+
+// GetBoardingPass handles GET /api/orders/{orderId}/boarding-pass
+func (h *Handlers) GetBoardingPass(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	image, err := h.bookingService.GetBoardingPass(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Content-Disposition", `inline; filename="boarding-pass-`+orderID+`.png"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+// ExtendHold handles POST /api/orders/{orderId}/extend
+func (h *Handlers) ExtendHold(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "orderId")
-	if orderID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "order ID is required")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	output, err := h.bookingService.ExtendHold(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := ExtendHoldResponse{
+		OrderID:        output.OrderID,
+		ExpiresAt:      output.ExpiresAt,
+		ExtensionsLeft: output.ExtensionsLeft,
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// ApprovePartial handles POST /api/orders/{orderId}/approve-partial
+func (h *Handlers) ApprovePartial(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	status, err := h.bookingService.ApprovePartial(r.Context(), orderID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := newOrderStatusResponse(status)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// RespondUpsell handles POST /api/orders/{orderId}/upsell
+func (h *Handlers) RespondUpsell(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
+		return
+	}
+
+	var req RespondUpsellRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	status, err := h.bookingService.RespondUpsell(r.Context(), orderID, domain.UpsellResponse{
+		Upgrade:          req.Upgrade,
+		PriorityBoarding: req.PriorityBoarding,
+	})
+	if err != nil {
+		HandleServiceError(w, err)
 		return
 	}
 
+	response := newOrderStatusResponse(status)
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// SubmitPayment handles POST /api/orders/{orderId}/pay
+func (h *Handlers) SubmitPayment(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+
 	var req SubmitPaymentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
 		return
 	}
 
-	if req.PaymentCode == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodePaymentFailed, "payment code is required")
+	v := validator{fields: ValidateSubmitPayment(orderID, req)}
+	if !v.check(w) {
 		return
 	}
 
-	err := h.bookingService.SubmitPayment(r.Context(), orderID, req.PaymentCode)
+	status, err := h.bookingService.SubmitPayment(r.Context(), orderID, domain.PaymentMethod(req.Method), req.PaymentCode, req.Deposit)
 	if err != nil {
 		HandleServiceError(w, err)
 		return
@@ -219,17 +638,46 @@ func (h *Handlers) SubmitPayment(w http.ResponseWriter, r *http.Request) {
 
 	response := PaymentAcceptedResponse{
 		OrderID: orderID,
-		Status:  "PAYMENT_PROCESSING",
+		Status:  string(status),
 	}
 
 	WriteJSON(w, http.StatusAccepted, response)
 }
 
+// RespondPaymentChallenge handles POST /api/orders/{orderId}/challenge
+func (h *Handlers) RespondPaymentChallenge(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+
+	var req PaymentChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("orderId", orderID)
+	v.challengeCode("code", req.Code)
+	if !v.check(w) {
+		return
+	}
+
+	status, err := h.bookingService.RespondPaymentChallenge(r.Context(), orderID, req.Code)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, PaymentAcceptedResponse{
+		OrderID: orderID,
+		Status:  string(status),
+	})
+}
+
 // CancelOrder handles DELETE /api/orders/{orderId}
 func (h *Handlers) CancelOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "orderId")
-	if orderID == "" {
-		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "order ID is required")
+	var v validator
+	if v.uuid("orderId", orderID); !v.check(w) {
 		return
 	}
 
@@ -241,3 +689,126 @@ func (h *Handlers) CancelOrder(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// newFlightResponse converts a domain flight to its API representation
+func newFlightResponse(f domain.Flight) FlightResponse {
+	return FlightResponse{
+		ID:             f.ID,
+		FlightNumber:   f.FlightNumber,
+		Origin:         f.Origin,
+		Destination:    f.Destination,
+		DepartureTime:  f.DepartureTime,
+		TotalSeats:     f.TotalSeats,
+		AvailableSeats: f.AvailableSeats,
+		PriceCents:     f.PriceCents,
+		Status:         string(f.Status),
+		DelayMinutes:   f.DelayMinutes,
+		BookingFrozen:  f.BookingFrozen,
+		SalesClosedAt:  f.SalesClosedAt,
+
+		OverbookingPercent: f.OverbookingPercent,
+	}
+}
+
+// newOrderStatusResponse converts an order status to its API representation
+func newOrderStatusResponse(status *domain.OrderStatusResponse) OrderStatusResponse {
+	return OrderStatusResponse{
+		OrderID:         status.OrderID,
+		Status:          string(status.Status),
+		Seats:           status.Seats,
+		TimerRemaining:  status.TimerRemaining,
+		PaymentAttempts: status.PaymentAttempts,
+		LastError:       status.LastError,
+		Price:           newPriceResponse(status.Price),
+		Passengers:      newPassengerResponses(status.Passengers),
+		Seatless:        status.Seatless,
+		SeatCount:       status.SeatCount,
+		TripID:          status.TripID,
+
+		UnavailableSeats: status.UnavailableSeats,
+		AwaitingApproval: status.AwaitingApproval,
+
+		HoldExtensionsLeft: status.HoldExtensionsLeft,
+
+		UpsellOffer:      newUpsellOfferResponse(status.UpsellOffer),
+		PaymentChallenge: newPaymentChallengeResponse(status.PaymentChallenge),
+		Deposit:          newDepositResponse(status.Deposit),
+	}
+}
+
+// newDepositResponse converts an order's deposit, if any, to its API
+// representation
+func newDepositResponse(deposit *domain.Deposit) *DepositResponse {
+	if deposit == nil {
+		return nil
+	}
+	return &DepositResponse{
+		DepositCents:  deposit.DepositCents,
+		BalanceCents:  deposit.BalanceCents,
+		BalanceDueAt:  deposit.BalanceDueAt,
+		BalancePaidAt: deposit.BalancePaidAt,
+	}
+}
+
+// newPaymentChallengeResponse converts an open payment challenge, if any, to
+// its API representation
+func newPaymentChallengeResponse(challenge *domain.PaymentChallenge) *PaymentChallengeResponse {
+	if challenge == nil {
+		return nil
+	}
+	return &PaymentChallengeResponse{
+		ExpiresAt:    challenge.ExpiresAt,
+		AttemptsLeft: challenge.AttemptsLeft,
+	}
+}
+
+// newUpsellOfferResponse converts an open upsell offer, if any, to its API
+// representation
+func newUpsellOfferResponse(offer *domain.UpsellOffer) *UpsellOfferResponse {
+	if offer == nil {
+		return nil
+	}
+	return &UpsellOfferResponse{
+		Class:                 string(offer.Class),
+		Seats:                 offer.Seats,
+		UpgradeCents:          offer.UpgradeCents,
+		PriorityBoardingCents: offer.PriorityBoardingCents,
+		ExpiresAt:             offer.ExpiresAt,
+	}
+}
+
+// newPriceResponse converts a locked price to its API representation
+func newPriceResponse(p domain.PriceBreakdown) PriceResponse {
+	return PriceResponse{
+		QuoteID:       p.QuoteID,
+		SeatCount:     p.SeatCount,
+		BaseFareCents: p.BaseFareCents,
+		FeesCents:     p.FeesCents,
+		DiscountCents: p.DiscountCents,
+		UpsellCents:   p.UpsellCents,
+		TotalCents:    p.TotalCents,
+	}
+}
+
+// newPassengers converts requested passengers to domain passengers
+func newPassengers(req []PassengerRequest) []domain.Passenger {
+	passengers := make([]domain.Passenger, len(req))
+	for i, p := range req {
+		passengers[i] = domain.Passenger{SeatID: p.SeatID, Name: p.Name, DocumentNumber: p.DocumentNumber, Email: p.Email}
+	}
+	return passengers
+}
+
+// newPassengerResponses converts passengers to their API representation
+func newPassengerResponses(passengers []domain.Passenger) []PassengerResponse {
+	var out []PassengerResponse
+	for _, p := range passengers {
+		out = append(out, PassengerResponse{
+			SeatID:         p.SeatID,
+			Name:           p.Name,
+			DocumentNumber: p.DocumentNumber,
+			Email:          p.Email,
+		})
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// ReadinessCheck is one dependency the server needs to serve orders
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ServeLiveness handles GET /healthz; it only shows the process is serving
+func ServeLiveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// ServeReadiness handles GET /readyz. All checks run in parallel and any
+// failure answers 503, so traffic is held back while a dependency such as
+// Temporal is down. degraded, when set, explains a non-fatal problem.
+func ServeReadiness(checks []ReadinessCheck, degraded func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := ReadinessResponse{Ready: true, Checks: make([]ReadinessCheckResponse, len(checks))}
+
+		var wg sync.WaitGroup
+		for i, c := range checks {
+			wg.Add(1)
+			go func(i int, c ReadinessCheck) {
+				defer wg.Done()
+				response.Checks[i] = ReadinessCheckResponse{Name: c.Name, OK: true}
+				if err := c.Check(r.Context()); err != nil {
+					response.Checks[i].OK = false
+					response.Checks[i].Error = err.Error()
+				}
+			}(i, c)
+		}
+		wg.Wait()
+
+		for _, c := range response.Checks {
+			response.Ready = response.Ready && c.OK
+		}
+		if degraded != nil {
+			response.Degraded = degraded()
+		}
+
+		status := http.StatusOK
+		if !response.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		WriteJSON(w, status, response)
+	}
+}
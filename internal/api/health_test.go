@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeReadiness(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name     string
+		temporal func(context.Context) error
+		degraded string
+		want     int
+	}{
+		{"all up", ok, "", http.StatusOK},
+		{"degraded stays ready", ok, "schema drift", http.StatusOK},
+		{"temporal down", down, "", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ServeReadiness([]ReadinessCheck{
+				{"postgres", ok},
+				{"temporal", tt.temporal},
+			}, func() string { return tt.degraded })
+
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("got %d, want %d", rec.Code, tt.want)
+			}
+			var resp ReadinessResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Ready != (tt.want == http.StatusOK) || resp.Degraded != tt.degraded {
+				t.Errorf("got %+v", resp)
+			}
+			if temporal := resp.Checks[1]; temporal.Name != "temporal" || temporal.OK != (tt.want == http.StatusOK) {
+				t.Errorf("temporal check = %+v", temporal)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// locale is how one supported language formats money and times
+type locale struct {
+	tag         language.Tag
+	timeLayout  string
+	symbolAfter bool // "12,50 €" rather than "€12.50"
+}
+
+// locales are the languages formatted fields support; the first is used
+// when Accept-Language names none of them
+var locales = []locale{
+	{language.AmericanEnglish, "Jan 2, 2006, 3:04 PM MST", false},
+	{language.BritishEnglish, "2 Jan 2006, 15:04 MST", false},
+	{language.German, "02.01.2006, 15:04 MST", true},
+	{language.French, "02/01/2006 15:04 MST", true},
+	{language.Spanish, "02/01/2006, 15:04 MST", true},
+	{language.Italian, "02/01/2006, 15:04 MST", true},
+	{language.Japanese, "2006/01/02 15:04 MST", false},
+}
+
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = l.tag
+	}
+	return language.NewMatcher(tags)
+}()
+
+// Localizer formats prices and times for one caller. Prices are in the
+// configured currency; times are shown in UTC, as the API returns them.
+type Localizer struct {
+	locale   locale
+	currency currency.Unit
+	printer  *message.Printer
+}
+
+// NewLocalizer returns a localizer for an Accept-Language header value and
+// an ISO 4217 currency code. An unknown currency falls back to USD.
+func NewLocalizer(acceptLanguage, currencyCode string) *Localizer {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	_, index, _ := localeMatcher.Match(tags...)
+	l := locales[index]
+
+	unit, err := currency.ParseISO(currencyCode)
+	if err != nil {
+		unit = currency.USD
+	}
+
+	return &Localizer{locale: l, currency: unit, printer: message.NewPrinter(l.tag)}
+}
+
+// Language is the tag of the locale chosen for the caller
+func (l *Localizer) Language() language.Tag {
+	return l.locale.tag
+}
+
+// Money formats an amount in the currency's minor unit, which the API calls
+// cents, such as "$1,234.50" or "1.234,50 €"
+func (l *Localizer) Money(cents int64) string {
+	scale, _ := currency.Standard.Rounding(l.currency)
+	amount := l.printer.Sprint(number.Decimal(float64(cents)/math.Pow10(scale), number.Scale(scale)))
+	symbol := l.printer.Sprint(currency.NarrowSymbol(l.currency))
+	if l.locale.symbolAfter {
+		return amount + " " + symbol
+	}
+	return symbol + amount
+}
+
+// Time formats a time in UTC in the locale's date and time order
+func (l *Localizer) Time(t time.Time) string {
+	return t.UTC().Format(l.locale.timeLayout)
+}
+
+type localizerKey struct{}
+
+// Localize attaches a Localizer for the request's Accept-Language to its
+// context, so responses carry formatted fields beside the raw ones. Requests
+// without the header get no formatted fields.
+func Localize(currencyCode string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Language")
+			if accept := strings.TrimSpace(r.Header.Get("Accept-Language")); accept != "" {
+				l := NewLocalizer(accept, currencyCode)
+				w.Header().Set("Content-Language", l.Language().String())
+				r = r.WithContext(context.WithValue(r.Context(), localizerKey{}, l))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// localizerFrom returns the request's Localizer, or nil when it asked for no
+// formatted fields
+func localizerFrom(ctx context.Context) *Localizer {
+	l, _ := ctx.Value(localizerKey{}).(*Localizer)
+	return l
+}
+
+// localizable is a response with formatted fields to fill in
+type localizable interface {
+	localize(l *Localizer)
+}
+
+// writeLocalizedJSON fills in the response's formatted fields for the
+// request's locale, if it has one, and writes it as JSON
+func writeLocalizedJSON(w http.ResponseWriter, r *http.Request, statusCode int, data localizable) {
+	if l := localizerFrom(r.Context()); l != nil {
+		data.localize(l)
+	}
+	WriteJSON(w, statusCode, data)
+}
+
+func (f *FlightResponse) localize(l *Localizer) {
+	f.PriceFormatted = l.Money(f.PriceCents)
+	f.DepartureTimeFormatted = l.Time(f.DepartureTime)
+}
+
+func (f *FlightListResponse) localize(l *Localizer) {
+	for i := range f.Flights {
+		f.Flights[i].localize(l)
+	}
+}
+
+func (c *FareCalendarResponse) localize(l *Localizer) {
+	for i, d := range c.Days {
+		if d.CheapestPriceCents != nil {
+			c.Days[i].CheapestPriceFormatted = l.Money(*d.CheapestPriceCents)
+		}
+	}
+}
+
+func (p *PriceResponse) localize(l *Localizer) {
+	p.TotalFormatted = l.Money(p.TotalCents)
+}
+
+func (s *OrderStatusResponse) localize(l *Localizer) {
+	s.Price.localize(l)
+}
+
+func (u *UpdateSeatsResponse) localize(l *Localizer) {
+	u.Price.localize(l)
+}
+
+func (it *ItineraryResponse) localize(l *Localizer) {
+	it.Flight.localize(l)
+	it.Price.localize(l)
+}
+
+func (t *TripSummaryResponse) localize(l *Localizer) {
+	t.TotalFormatted = l.Money(t.TotalCents)
+	for i := range t.Legs {
+		t.Legs[i].Flight.localize(l)
+		t.Legs[i].Price.localize(l)
+	}
+}
+
+func (t *TripStatusResponse) localize(l *Localizer) {
+	t.TotalFormatted = l.Money(t.TotalCents)
+	for i := range t.Legs {
+		t.Legs[i].localize(l)
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLocalizerFormats(t *testing.T) {
+	departure := time.Date(2026, 3, 15, 14, 5, 0, 0, time.UTC)
+	tests := []struct {
+		accept    string
+		currency  string
+		wantMoney string
+		wantTime  string
+	}{
+		{"en-US,en;q=0.9", "USD", "$1,234.50", "Mar 15, 2026, 2:05 PM UTC"},
+		{"en-GB", "GBP", "£1,234.50", "15 Mar 2026, 14:05 UTC"},
+		{"de-DE,de;q=0.9", "EUR", "1.234,50 €", "15.03.2026, 14:05 UTC"},
+		{"fr-CH, fr;q=0.9", "EUR", "1 234,50 €", "15/03/2026 14:05 UTC"},
+		{"ja", "JPY", "￥123,450", "2026/03/15 14:05 UTC"},
+		{"tlh", "XXX-bogus", "$1,234.50", "Mar 15, 2026, 2:05 PM UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			l := NewLocalizer(tt.accept, tt.currency)
+			if got := l.Money(123450); got != tt.wantMoney {
+				t.Errorf("Money = %q, want %q", got, tt.wantMoney)
+			}
+			if got := l.Time(departure); got != tt.wantTime {
+				t.Errorf("Time = %q, want %q", got, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestLocalizeAddsFormattedFields(t *testing.T) {
+	handler := Localize("EUR")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeLocalizedJSON(w, r, http.StatusOK, &FlightListResponse{Flights: []FlightResponse{
+			{ID: "f1", PriceCents: 9900, DepartureTime: time.Date(2026, 3, 15, 9, 30, 0, 0, time.UTC)},
+		}})
+	}))
+
+	tests := []struct {
+		name      string
+		accept    string
+		wantPrice string
+		wantTime  string
+	}{
+		{"no header", "", "", ""},
+		{"german", "de", "99,00 €", "15.03.2026, 09:30 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/flights", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Language", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			var resp FlightListResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			f := resp.Flights[0]
+			if f.PriceFormatted != tt.wantPrice || f.DepartureTimeFormatted != tt.wantTime {
+				t.Errorf("formatted = %q, %q; want %q, %q", f.PriceFormatted, f.DepartureTimeFormatted, tt.wantPrice, tt.wantTime)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Language" {
+				t.Errorf("Vary = %q, want Accept-Language", got)
+			}
+		})
+	}
+}
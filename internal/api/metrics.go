@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Rolling metrics keep metricsBuckets buckets of metricsBucketSize each
+const (
+	metricsBucketSize = 30 * time.Second
+	metricsBuckets    = 10
+)
+
+// unmatchedEndpoint groups requests that matched no route, so probes of
+// random paths can't grow the metrics without bound
+const unmatchedEndpoint = "unmatched"
+
+// EndpointCounts are request counts for one endpoint
+type EndpointCounts struct {
+	Requests     int64
+	ClientErrors int64 // 4xx responses
+	ServerErrors int64 // 5xx responses
+}
+
+func (c *EndpointCounts) add(status int) {
+	c.Requests++
+	switch {
+	case status >= 500:
+		c.ServerErrors++
+	case status >= 400:
+		c.ClientErrors++
+	}
+}
+
+// EndpointMetrics are the rolling and lifetime counts of one endpoint
+type EndpointMetrics struct {
+	Endpoint string
+	Recent   EndpointCounts
+	Total    EndpointCounts
+}
+
+type metricsBucket struct {
+	start  time.Time
+	counts map[string]*EndpointCounts
+}
+
+// RequestMetrics counts requests and errors per route pattern over a
+// rolling window and since start
+type RequestMetrics struct {
+	mu      sync.Mutex
+	started time.Time
+	buckets [metricsBuckets]metricsBucket
+	totals  map[string]*EndpointCounts
+	now     func() time.Time
+}
+
+// NewRequestMetrics creates an empty RequestMetrics starting now
+func NewRequestMetrics() *RequestMetrics {
+	return newRequestMetrics(time.Now)
+}
+
+func newRequestMetrics(now func() time.Time) *RequestMetrics {
+	return &RequestMetrics{
+		started: now(),
+		totals:  make(map[string]*EndpointCounts),
+		now:     now,
+	}
+}
+
+// Window is how far back the rolling counts reach
+func (m *RequestMetrics) Window() time.Duration {
+	return metricsBucketSize * metricsBuckets
+}
+
+// Started is when the metrics, and so the server, started
+func (m *RequestMetrics) Started() time.Time {
+	return m.started
+}
+
+// Middleware records every request under its method and route pattern
+func (m *RequestMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		// The pattern is only known once routing has run
+		endpoint := unmatchedEndpoint
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			endpoint = r.Method + " " + rctx.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		m.record(endpoint, status)
+	})
+}
+
+// record counts one response for endpoint
+func (m *RequestMetrics) record(endpoint string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	start := m.now().Truncate(metricsBucketSize)
+	bucket := &m.buckets[start.UnixNano()/int64(metricsBucketSize)%metricsBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = metricsBucket{start: start, counts: make(map[string]*EndpointCounts)}
+	}
+
+	countsFor(bucket.counts, endpoint).add(status)
+	countsFor(m.totals, endpoint).add(status)
+}
+
+// Snapshot returns the counts of every endpoint seen, sorted by endpoint
+func (m *RequestMetrics) Snapshot() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldest := m.now().Truncate(metricsBucketSize).Add(-m.Window() + metricsBucketSize)
+	recent := make(map[string]EndpointCounts)
+	for _, bucket := range m.buckets {
+		if bucket.start.Before(oldest) {
+			continue
+		}
+		for endpoint, c := range bucket.counts {
+			r := recent[endpoint]
+			r.Requests += c.Requests
+			r.ClientErrors += c.ClientErrors
+			r.ServerErrors += c.ServerErrors
+			recent[endpoint] = r
+		}
+	}
+
+	out := make([]EndpointMetrics, 0, len(m.totals))
+	for endpoint, total := range m.totals {
+		out = append(out, EndpointMetrics{Endpoint: endpoint, Recent: recent[endpoint], Total: *total})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Endpoint < out[j].Endpoint })
+
+	return out
+}
+
+// countsFor returns the counts for endpoint, creating them on first use
+func countsFor(counts map[string]*EndpointCounts, endpoint string) *EndpointCounts {
+	c, ok := counts[endpoint]
+	if !ok {
+		c = &EndpointCounts{}
+		counts[endpoint] = c
+	}
+	return c
+}
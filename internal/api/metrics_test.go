@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestRequestMetricsMiddleware(t *testing.T) {
+	metrics := NewRequestMetrics()
+	r := chi.NewRouter()
+	r.Use(metrics.Middleware)
+	r.Route("/api/orders/{orderId}", func(r chi.Router) {
+		r.Get("/status", func(w http.ResponseWriter, r *http.Request) {
+			if chi.URLParam(r, "orderId") == "missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte("ok"))
+		})
+		r.Post("/pay", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+	})
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/api/orders/a/status"},
+		{http.MethodGet, "/api/orders/b/status"},
+		{http.MethodGet, "/api/orders/missing/status"},
+		{http.MethodPost, "/api/orders/a/pay"},
+		{http.MethodGet, "/nope"},
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	got := make(map[string]EndpointCounts)
+	for _, e := range metrics.Snapshot() {
+		if e.Recent != e.Total {
+			t.Errorf("%s: recent %+v differs from total %+v", e.Endpoint, e.Recent, e.Total)
+		}
+		got[e.Endpoint] = e.Total
+	}
+
+	want := map[string]EndpointCounts{
+		"GET /api/orders/{orderId}/status": {Requests: 3, ClientErrors: 1},
+		"POST /api/orders/{orderId}/pay":   {Requests: 1, ServerErrors: 1},
+		unmatchedEndpoint:                  {Requests: 1, ClientErrors: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("endpoints = %v, want %v", got, want)
+	}
+	for endpoint, counts := range want {
+		if got[endpoint] != counts {
+			t.Errorf("%s = %+v, want %+v", endpoint, got[endpoint], counts)
+		}
+	}
+}
+
+func TestRequestMetricsWindow(t *testing.T) {
+	now := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	metrics := newRequestMetrics(func() time.Time { return now })
+
+	metrics.record("GET /a", http.StatusOK)
+	now = now.Add(metrics.Window() / 2)
+	metrics.record("GET /a", http.StatusBadRequest)
+
+	snapshot := metrics.Snapshot()
+	if snapshot[0].Recent.Requests != 2 {
+		t.Errorf("recent requests = %d, want 2", snapshot[0].Recent.Requests)
+	}
+
+	// The first request ages out of the window; the total keeps it
+	now = now.Add(metrics.Window() / 2)
+	snapshot = metrics.Snapshot()
+	if snapshot[0].Recent != (EndpointCounts{Requests: 1, ClientErrors: 1}) {
+		t.Errorf("recent = %+v, want one client error", snapshot[0].Recent)
+	}
+	if snapshot[0].Total.Requests != 2 {
+		t.Errorf("total requests = %d, want 2", snapshot[0].Total.Requests)
+	}
+
+	// A reused ring slot starts from zero
+	now = now.Add(metrics.Window())
+	metrics.record("GET /a", http.StatusOK)
+	if got := metrics.Snapshot()[0].Recent; got != (EndpointCounts{Requests: 1}) {
+		t.Errorf("recent after wrap = %+v, want one request", got)
+	}
+}
@@ -1,9 +1,26 @@
 package api
 
-import "net/http"
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
 
-// CORS middleware adds CORS headers for cross-origin requests
-func CORS(allowedOrigins ...string) func(http.Handler) http.Handler {
+	"github.com/go-chi/chi/v5"
+)
+
+// routeMethods lists the methods probed when building Allow headers
+var routeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// CORS middleware adds CORS headers for cross-origin requests.
+// Preflight responses advertise the methods the router actually serves for the path.
+func CORS(routes chi.Routes, allowedOrigins ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
@@ -19,16 +36,18 @@ func CORS(allowedOrigins ...string) func(http.Handler) http.Handler {
 
 			if allowed {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
 			} else if len(allowedOrigins) > 0 {
 				w.Header().Set("Access-Control-Allow-Origin", allowedOrigins[0])
 			}
 
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-			w.Header().Set("Access-Control-Max-Age", "86400")
-
 			// Handle preflight
 			if r.Method == http.MethodOptions {
+				methods := strings.Join(allowedMethods(routes, r.URL.Path), ", ")
+				w.Header().Set("Allow", methods)
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+				w.Header().Set("Access-Control-Max-Age", "86400")
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
@@ -37,3 +56,107 @@ func CORS(allowedOrigins ...string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// RequireAPIKey admits only requests whose X-API-Key matches one of keys.
+// With no keys configured every request is rejected.
+func RequireAPIKey(keys []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented := []byte(r.Header.Get("X-API-Key"))
+			valid := false
+			for _, key := range keys {
+				if subtle.ConstantTimeCompare(presented, []byte(key)) == 1 {
+					valid = true
+				}
+			}
+
+			if len(presented) == 0 || !valid {
+				WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "a valid X-API-Key header is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RejectMethodOverride refuses requests that try to tunnel a different method
+// through override headers, which the API does not honor
+func RejectMethodOverride(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-HTTP-Method-Override") != "" || r.Header.Get("X-HTTP-Method") != "" {
+			WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "method override headers are not supported")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LegacyPathDeprecation marks unversioned /api responses as deprecated and
+// points clients at the equivalent /api/v1 path
+func LegacyPathDeprecation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		successor := "/api/v1" + strings.TrimPrefix(r.URL.Path, "/api")
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MethodNotAllowed returns a 405 handler whose Allow header is derived from the router
+func MethodNotAllowed(routes chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", strings.Join(allowedMethods(routes, r.URL.Path), ", "))
+		WriteError(w, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, "method "+r.Method+" is not allowed on this resource")
+	}
+}
+
+// allowedMethods returns the methods routed for path; HEAD is implied by GET
+func allowedMethods(routes chi.Routes, path string) []string {
+	routed := make(map[string]bool)
+	_ = chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if routeMatches(route, path) {
+			routed[method] = true
+		}
+		return nil
+	})
+
+	if routed[http.MethodGet] {
+		routed[http.MethodHead] = true
+	}
+
+	var methods []string
+	for _, m := range routeMethods {
+		if routed[m] {
+			methods = append(methods, m)
+		}
+	}
+
+	return append(methods, http.MethodOptions)
+}
+
+// routeMatches reports whether a chi route pattern such as
+// /api/orders/{orderId}/seats matches a concrete request path
+func routeMatches(route, path string) bool {
+	want := strings.Split(strings.Trim(route, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+
+	for i, segment := range want {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if got[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != got[i] {
+			return false
+		}
+	}
+
+	return true
+}
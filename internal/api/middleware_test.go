@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireAPIKey(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name string
+		keys []string
+		key  string
+		want int
+	}{
+		{"valid key", []string{"old", "new"}, "new", http.StatusOK},
+		{"wrong key", []string{"new"}, "guess", http.StatusUnauthorized},
+		{"missing key", []string{"new"}, "", http.StatusUnauthorized},
+		{"no keys configured", nil, "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/flights/f1/release-locks", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			rec := httptest.NewRecorder()
+			RequireAPIKey(tt.keys)(ok).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("got %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
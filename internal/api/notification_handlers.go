@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// GetNotificationPreferences handles GET /api/orders/{orderId}/notification-preferences
+func (h *Handlers) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.notifyService.GetPreferences(r.Context(), chi.URLParam(r, "orderId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newPreferencesResponse(*prefs))
+}
+
+// UpdateNotificationPreferences handles PUT /api/orders/{orderId}/notification-preferences
+func (h *Handlers) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var req NotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	channels := make([]domain.NotificationChannel, len(req.Channels))
+	for i, c := range req.Channels {
+		channels[i] = domain.NotificationChannel(c)
+	}
+	categories := make([]domain.NotificationCategory, len(req.Categories))
+	for i, c := range req.Categories {
+		categories[i] = domain.NotificationCategory(c)
+	}
+
+	prefs, err := h.notifyService.UpdatePreferences(r.Context(), chi.URLParam(r, "orderId"), channels, categories)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newPreferencesResponse(*prefs))
+}
+
+// Unsubscribe handles POST /api/notifications/unsubscribe/{token}[?category=]
+func (h *Handlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	category := domain.NotificationCategory(r.URL.Query().Get("category"))
+
+	prefs, err := h.notifyService.Unsubscribe(r.Context(), chi.URLParam(r, "token"), category)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newPreferencesResponse(*prefs))
+}
+
+func newPreferencesResponse(p domain.NotificationPreferences) NotificationPreferencesResponse {
+	response := NotificationPreferencesResponse{
+		OrderID:      p.OrderID,
+		Channels:     make([]string, len(p.Channels)),
+		Categories:   make([]string, len(p.Categories)),
+		Unsubscribed: p.UnsubscribedAt != nil,
+	}
+	for i, c := range p.Channels {
+		response.Channels[i] = string(c)
+	}
+	for i, c := range p.Categories {
+		response.Categories[i] = string(c)
+	}
+	return response
+}
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// operation describes one documented API endpoint. Request and Response hold
+// zero values of the types in types.go; their schemas are derived by reflection.
+type operation struct {
+	Method   string
+	Path     string
+	Summary  string
+	Request  interface{}
+	Response interface{}
+	Status   int
+}
+
+// operations is the documented v1 surface. Keep it in sync with v1Routes;
+// TestOpenAPICoversRoutes fails when a route is missing here.
+var operations = []operation{
+	{http.MethodGet, "/flights", "List flights", nil, FlightListResponse{}, http.StatusOK},
+	{http.MethodGet, "/flights/calendar", "Cheapest fare per day around a target date", nil, FareCalendarResponse{}, http.StatusOK},
+	{http.MethodGet, "/flights/{flightId}", "Get a flight with its seat map", nil, FlightDetailResponse{}, http.StatusOK},
+	{http.MethodPost, "/flights/{flightId}/recommend-seats", "Rank available seats against passenger preferences", RecommendSeatsRequest{}, RecommendSeatsResponse{}, http.StatusOK},
+	{http.MethodGet, "/flights/{flightId}/swap-offers", "List open seat swap offers on a flight", nil, SwapOfferListResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders", "Create an order and start its booking workflow", CreateOrderRequest{}, CreateOrderResponse{}, http.StatusCreated},
+	{http.MethodPost, "/orders/bulk", "Create one order per seat group on a flight", BulkCreateOrderRequest{}, BulkCreateOrderResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/group", "Book a large party on one flight with chunked reservation and a longer hold", CreateGroupOrderRequest{}, CreateOrderResponse{}, http.StatusCreated},
+	{http.MethodPut, "/orders/{orderId}/seats", "Replace the seat selection and reset the hold timer", UpdateSeatsRequest{}, UpdateSeatsResponse{}, http.StatusOK},
+	{http.MethodGet, "/orders/{orderId}/status", "Get the live order status", nil, OrderStatusResponse{}, http.StatusOK},
+	{http.MethodGet, "/orders/{orderId}/itinerary", "Get the receipt and booking reference of a confirmed order", nil, ItineraryResponse{}, http.StatusOK},
+	{http.MethodGet, "/orders/{orderId}/payments", "List every attempt to take the order's payment, failed ones included", nil, PaymentListResponse{}, http.StatusOK},
+	{http.MethodGet, "/orders/{orderId}/events", "List what happened to the order: seats held, changed and released, failed payments, status changes and confirmation", nil, OrderEventListResponse{}, http.StatusOK},
+	{http.MethodGet, "/orders/{orderId}/boarding-pass", "Get the PNG boarding pass of a confirmed order, one QR-coded panel per seat", nil, nil, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/checkin", "Check in from 24 hours before departure, optionally moving to other seats in the same cabin, and issue the boarding pass", CheckInRequest{}, ItineraryResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/check-in", "Deprecated alias of /orders/{orderId}/checkin", CheckInRequest{}, ItineraryResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/extend", "Refresh the seat hold without changing seats", nil, ExtendHoldResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/approve-partial", "Accept the seats a partially reserved group booking holds", nil, OrderStatusResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/pay", "Submit a payment method and code", SubmitPaymentRequest{}, PaymentAcceptedResponse{}, http.StatusAccepted},
+	{http.MethodPost, "/orders/{orderId}/challenge", "Answer the card issuer's payment challenge with the one-time code", PaymentChallengeRequest{}, PaymentAcceptedResponse{}, http.StatusAccepted},
+	{http.MethodPost, "/orders/{orderId}/upsell", "Accept or decline the upgrade and priority boarding offered before payment is taken", RespondUpsellRequest{}, OrderStatusResponse{}, http.StatusOK},
+	{http.MethodDelete, "/orders/{orderId}", "Cancel an order", nil, nil, http.StatusNoContent},
+	{http.MethodPost, "/orders/{orderId}/refund", "Refund a confirmed order through the payment provider and put its seats back on sale, or with dryRun list what would be (admin)", AdminRefundRequest{}, RefundResponse{}, http.StatusOK},
+	{http.MethodPost, "/orders/{orderId}/swap-offers", "Offer a confirmed seat for swap", SwapOfferRequest{}, SwapOfferResponse{}, http.StatusCreated},
+	{http.MethodDelete, "/orders/{orderId}/swap-offers/{offerId}", "Withdraw an open swap offer", nil, nil, http.StatusNoContent},
+	{http.MethodGet, "/orders/{orderId}/notification-preferences", "Get notification preferences", nil, NotificationPreferencesResponse{}, http.StatusOK},
+	{http.MethodPut, "/orders/{orderId}/notification-preferences", "Replace notification channels and categories", NotificationPreferencesRequest{}, NotificationPreferencesResponse{}, http.StatusOK},
+	{http.MethodPost, "/trips", "Book connecting flights as one order, one booking workflow per leg", CreateTripRequest{}, CreateTripResponse{}, http.StatusCreated},
+	{http.MethodGet, "/trips/{tripId}", "Get the consolidated itinerary of a trip by trip ID or booking reference", nil, TripSummaryResponse{}, http.StatusOK},
+	{http.MethodGet, "/trips/{tripId}/status", "Get the live trip status and each leg's order status", nil, TripStatusResponse{}, http.StatusOK},
+	{http.MethodPost, "/trips/{tripId}/pay", "Submit one payment code for every leg", SubmitPaymentRequest{}, TripPaymentAcceptedResponse{}, http.StatusAccepted},
+	{http.MethodPost, "/trips/{tripId}/challenge", "Answer the card issuer's payment challenge on a trip with the one-time code", PaymentChallengeRequest{}, TripPaymentAcceptedResponse{}, http.StatusAccepted},
+	{http.MethodDelete, "/trips/{tripId}", "Cancel a trip and release every leg", nil, nil, http.StatusNoContent},
+	{http.MethodPost, "/notifications/unsubscribe/{token}", "Unsubscribe from all notifications or one category", nil, NotificationPreferencesResponse{}, http.StatusOK},
+	{http.MethodPost, "/payments/callback", "Deliver a gateway's result for a payment it settled out of band, signed in X-Payment-Signature", PaymentCallbackRequest{}, nil, http.StatusNoContent},
+	{http.MethodGet, "/swap-offers/{offerId}", "Get a swap offer", nil, SwapOfferResponse{}, http.StatusOK},
+	{http.MethodPost, "/swap-offers/{offerId}/accept", "Accept a swap offer with one of your seats", AcceptSwapRequest{}, SwapOfferResponse{}, http.StatusAccepted},
+	{http.MethodPost, "/admin/flights/import", "Add flights with generated seat maps from a CSV or JSON schedule", nil, ScheduleImportResponse{}, http.StatusOK},
+	{http.MethodPatch, "/admin/flights/{flightId}/seats", "Add, remove, block, or unblock seats", AdminSeatMapRequest{}, FlightResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/orders", "List orders by status, flight and creation time, a page at a time", nil, OrderListResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/webhooks", "List webhook subscriptions", nil, WebhookListResponse{}, http.StatusOK},
+	{http.MethodPost, "/admin/webhooks", "Subscribe an endpoint to order lifecycle events", AdminWebhookRequest{}, WebhookResponse{}, http.StatusCreated},
+	{http.MethodDelete, "/admin/webhooks/{webhookId}", "Remove a webhook subscription", nil, nil, http.StatusNoContent},
+	{http.MethodPost, "/admin/flights/{flightId}/release-locks", "Force-release Redis seat locks", AdminReleaseLocksRequest{}, AdminPlanResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/flights/{flightId}/inventory", "Export the seat inventory as CSV or JSON", nil, nil, http.StatusOK},
+	{http.MethodPut, "/admin/flights/{flightId}/inventory", "Replace the seat inventory from a CSV or JSON file", nil, InventoryDiffResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/flights/{flightId}/revenue", "Get the revenue recognized on a flight", nil, FlightRevenueResponse{}, http.StatusOK},
+	{http.MethodPut, "/admin/flights/{flightId}/freeze", "Freeze or unfreeze bookings on a flight", AdminFreezeRequest{}, FlightResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/flights/{flightId}/manifest", "Get the passenger and seat manifest of a departed flight", nil, ManifestResponse{}, http.StatusOK},
+	{http.MethodPut, "/admin/flights/{flightId}/overbooking", "Set how far beyond its seats a flight may sell", AdminOverbookingRequest{}, FlightResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/flights/{flightId}/bumps", "List the orders bumped off an oversold flight", nil, BumpListResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/flights/{flightId}/prices", "List the fare changes dynamic pricing made to a flight", nil, PriceHistoryResponse{}, http.StatusOK},
+	{http.MethodGet, "/admin/schema", "Get the latest schema drift report", nil, SchemaReportResponse{}, http.StatusOK},
+	{http.MethodPost, "/admin/schema/verify", "Compare the live database with the migrations now", nil, SchemaReportResponse{}, http.StatusOK},
+}
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at the generated spec
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Flight Booking API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({ url: "/api/openapi.json", dom_id: "#swagger-ui" });</script>
+</body>
+</html>`
+
+// ServeOpenAPI handles GET /api/openapi.json
+func ServeOpenAPI(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, OpenAPISpec())
+}
+
+// ServeSwaggerUI handles GET /api/docs
+func ServeSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUIPage))
+}
+
+// queryParameters documents query strings by "METHOD path"
+var queryParameters = map[string][]string{
+	"GET /flights/calendar":                        {"origin", "destination", "date", "flexDays"},
+	"POST /notifications/unsubscribe/{token}":      {"category"},
+	"PATCH /admin/flights/{flightId}/seats":        {"dryRun"},
+	"POST /admin/flights/{flightId}/release-locks": {"dryRun"},
+	"GET /admin/flights/{flightId}/inventory":      {"format"},
+	"GET /admin/orders":                            {"status", "flightId", "createdAfter", "createdBefore", "sort", "desc", "limit", "offset"},
+	"PUT /admin/flights/{flightId}/inventory":      {"format", "dryRun"},
+	"POST /admin/flights/import":                   {"format", "dryRun"},
+	"POST /orders/{orderId}/refund":                {"dryRun"},
+}
+
+// validatedOperations lists endpoints that answer 422 with per-field errors
+var validatedOperations = map[string]bool{
+	"GET /flights/{flightId}":                  true,
+	"POST /flights/{flightId}/recommend-seats": true,
+	"POST /orders":                             true,
+	"POST /orders/bulk":                        true,
+	"POST /orders/group":                       true,
+	"PUT /orders/{orderId}/seats":              true,
+	"GET /orders/{orderId}/status":             true,
+	"GET /orders/{orderId}/itinerary":          true,
+	"GET /orders/{orderId}/payments":           true,
+	"GET /orders/{orderId}/events":             true,
+	"GET /orders/{orderId}/boarding-pass":      true,
+	"POST /orders/{orderId}/checkin":           true,
+	"POST /orders/{orderId}/check-in":          true,
+	"POST /orders/{orderId}/extend":            true,
+	"POST /orders/{orderId}/approve-partial":   true,
+	"POST /orders/{orderId}/pay":               true,
+	"POST /orders/{orderId}/challenge":         true,
+	"POST /orders/{orderId}/upsell":            true,
+	"DELETE /orders/{orderId}":                 true,
+	"POST /orders/{orderId}/refund":            true,
+	"POST /orders/{orderId}/swap-offers":       true,
+	"POST /swap-offers/{offerId}/accept":       true,
+	"POST /trips":                              true,
+	"GET /trips/{tripId}":                      true,
+	"GET /trips/{tripId}/status":               true,
+	"POST /trips/{tripId}/pay":                 true,
+	"POST /trips/{tripId}/challenge":           true,
+	"DELETE /trips/{tripId}":                   true,
+	"POST /payments/callback":                  true,
+	"GET /admin/orders":                        true,
+	"POST /admin/flights/import":               true,
+}
+
+// OpenAPISpec builds an OpenAPI 3 document for the v1 API
+func OpenAPISpec() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	errorRef := schemaRef(reflect.TypeOf(ErrorResponse{}), schemas)
+
+	paths := map[string]interface{}{}
+	for _, op := range operations {
+		item, ok := paths[op.Path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = buildOperation(op, errorRef, schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Flight Booking API",
+			"version": "v1",
+		},
+		"servers": []interface{}{map[string]interface{}{"url": "/api/v1"}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"ApiKeyAuth": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+}
+
+func buildOperation(op operation, errorRef map[string]interface{}, schemas map[string]interface{}) map[string]interface{} {
+	response := map[string]interface{}{"description": http.StatusText(op.Status)}
+	if op.Response != nil {
+		response["content"] = jsonContent(schemaRef(reflect.TypeOf(op.Response), schemas))
+	}
+
+	out := map[string]interface{}{
+		"summary": op.Summary,
+		"responses": map[string]interface{}{
+			strconv.Itoa(op.Status): response,
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content":     jsonContent(errorRef),
+			},
+		},
+	}
+
+	if validatedOperations[op.Method+" "+op.Path] {
+		out["responses"].(map[string]interface{})["422"] = map[string]interface{}{
+			"description": "Invalid request fields",
+			"content":     jsonContent(schemaRef(reflect.TypeOf(ValidationErrorResponse{}), schemas)),
+		}
+	}
+
+	params := pathParameters(op.Path)
+	for _, name := range queryParameters[op.Method+" "+op.Path] {
+		params = append(params, map[string]interface{}{
+			"name":   name,
+			"in":     "query",
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+	if strings.HasPrefix(op.Path, "/admin/") {
+		out["security"] = []interface{}{map[string]interface{}{"ApiKeyAuth": []string{}}}
+	}
+	if op.Request != nil {
+		out["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(schemaRef(reflect.TypeOf(op.Request), schemas)),
+		}
+	}
+
+	return out
+}
+
+func pathParameters(path string) []interface{} {
+	var params []interface{}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, map[string]interface{}{
+				"name":     strings.Trim(segment, "{}"),
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	return params
+}
+
+// schemaRef registers struct types under components and returns a $ref;
+// other types are returned inline
+func schemaRef(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return schemaFor(t, schemas)
+	}
+
+	if _, ok := schemas[t.Name()]; !ok {
+		schemas[t.Name()] = map[string]interface{}{} // placeholder guards recursion
+		schemas[t.Name()] = objectSchema(t, schemas)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+}
+
+func schemaFor(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaRef(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaRef(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		return schemaRef(t, schemas)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// objectSchema maps exported fields by their json tags, flattening embedded structs
+func objectSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				collect(field.Type)
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaFor(field.Type, schemas)
+			if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(t)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestOpenAPICoversRoutes(t *testing.T) {
+	router := NewRouter(RouterConfig{Handlers: &Handlers{}})
+
+	documented := make(map[string]bool)
+	for _, op := range operations {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !strings.HasPrefix(route, "/api/v1/") {
+			return nil
+		}
+		path := strings.TrimSuffix(strings.TrimPrefix(route, "/api/v1"), "/")
+		if !documented[method+" "+path] {
+			t.Errorf("route %s %s is not documented in operations", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+}
+
+func TestOpenAPISpecReferencesRegisteredSchemas(t *testing.T) {
+	spec := OpenAPISpec()
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	for _, name := range []string{"FlightDetailResponse", "SeatMapResponse", "CreateOrderRequest", "ErrorResponse"} {
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("schema %s missing from components", name)
+		}
+	}
+}
+
+func TestValidatedOperationsAreDocumented(t *testing.T) {
+	documented := make(map[string]bool)
+	for _, op := range operations {
+		documented[op.Method+" "+op.Path] = true
+	}
+	for key := range validatedOperations {
+		if !documented[key] {
+			t.Errorf("validated operation %s is not in operations", key)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/webhook"
+)
+
+// maxCallbackBody bounds the payment callback body read before it is verified
+const maxCallbackBody = 64 << 10
+
+// PaymentResultSignaler routes a gateway's payment result to the workflow
+// awaiting it
+type PaymentResultSignaler interface {
+	SignalPaymentResult(ctx context.Context, result temporalpkg.PaymentResultSignal) error
+}
+
+// PaymentCallback handles POST /api/payments/callback, where gateways that
+// settle payments out of band report the result. The raw body must be signed
+// like outbound webhooks, as X-Payment-Signature: sha256=<hex HMAC-SHA256>
+// keyed with secret. With no secret configured every callback is rejected.
+func PaymentCallback(signaler PaymentResultSignaler, secret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallbackBody))
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+			return
+		}
+
+		signature := r.Header.Get("X-Payment-Signature")
+		if secret == "" || !hmac.Equal([]byte(signature), []byte(webhook.Sign(secret, body))) {
+			WriteError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "a valid X-Payment-Signature header is required")
+			return
+		}
+
+		var req PaymentCallbackRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+			return
+		}
+
+		var v validator
+		if req.Reference == "" {
+			v.fail("reference", "is required")
+		}
+		if req.AuthorizationID == "" {
+			v.fail("authorizationId", "is required")
+		}
+		switch req.Status {
+		case temporalpkg.PaymentResultSucceeded, temporalpkg.PaymentResultDeclined, temporalpkg.PaymentResultFailed:
+		default:
+			v.fail("status", "must be one of succeeded, declined, failed")
+		}
+		if !v.check(w) {
+			return
+		}
+
+		err = signaler.SignalPaymentResult(r.Context(), temporalpkg.PaymentResultSignal{
+			Reference:       req.Reference,
+			AuthorizationID: req.AuthorizationID,
+			Status:          req.Status,
+			Error:           req.Error,
+		})
+		if err != nil {
+			HandleServiceError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/webhook"
+)
+
+type fakePaymentSignaler struct {
+	delivered []temporalpkg.PaymentResultSignal
+	err       error
+}
+
+func (f *fakePaymentSignaler) SignalPaymentResult(ctx context.Context, result temporalpkg.PaymentResultSignal) error {
+	f.delivered = append(f.delivered, result)
+	return f.err
+}
+
+func TestPaymentCallback(t *testing.T) {
+	const body = `{"reference":"booking-o1","authorizationId":"auth-1","status":"succeeded"}`
+	const unknownStatus = `{"reference":"booking-o1","authorizationId":"auth-1","status":"maybe"}`
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      string
+		signature string
+		err       error
+		want      int
+		delivered bool
+	}{
+		{"signed result", "s3cret", body, webhook.Sign("s3cret", []byte(body)), nil, http.StatusNoContent, true},
+		{"wrong signature", "s3cret", body, webhook.Sign("guess", []byte(body)), nil, http.StatusUnauthorized, false},
+		{"missing signature", "s3cret", body, "", nil, http.StatusUnauthorized, false},
+		{"no secret configured", "", body, webhook.Sign("", []byte(body)), nil, http.StatusUnauthorized, false},
+		{"unknown status", "s3cret", unknownStatus, webhook.Sign("s3cret", []byte(unknownStatus)), nil, http.StatusUnprocessableEntity, false},
+		{"workflow gone", "s3cret", body, webhook.Sign("s3cret", []byte(body)), fmt.Errorf("%w: booking-o1", domain.ErrPaymentNotAwaited), http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signaler := &fakePaymentSignaler{err: tt.err}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/callback", strings.NewReader(tt.body))
+			if tt.signature != "" {
+				req.Header.Set("X-Payment-Signature", tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			PaymentCallback(signaler, tt.secret).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("got %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+			if got := len(signaler.delivered) == 1; got != tt.delivered {
+				t.Fatalf("delivered = %v, want %v", got, tt.delivered)
+			}
+			if tt.delivered && signaler.delivered[0].Reference != "booking-o1" {
+				t.Errorf("delivered to %q, want booking-o1", signaler.delivered[0].Reference)
+			}
+		})
+	}
+}
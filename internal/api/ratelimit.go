@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RateLimiter keeps a token bucket per key holding limit requests that
+// refills completely over window. Take spends one request and returns the
+// requests left and, when none was left, how long until one is.
+type RateLimiter interface {
+	Take(ctx context.Context, key string, limit int, window time.Duration) (int64, time.Duration, error)
+}
+
+// RateLimitPolicy configures the limits applied to order endpoints
+type RateLimitPolicy struct {
+	PerIP    int
+	PerOrder int
+	Window   time.Duration
+}
+
+// RateLimitByIP limits requests per client IP within scope
+func RateLimitByIP(limiter RateLimiter, scope string, limit int, window time.Duration) func(http.Handler) http.Handler {
+	return rateLimit(limiter, limit, window, func(r *http.Request) string {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		return scope + ":ip:" + host
+	})
+}
+
+// RateLimitByOrder limits requests per {orderId} URL parameter within scope
+func RateLimitByOrder(limiter RateLimiter, scope string, limit int, window time.Duration) func(http.Handler) http.Handler {
+	return rateLimit(limiter, limit, window, func(r *http.Request) string {
+		return scope + ":order:" + chi.URLParam(r, "orderId")
+	})
+}
+
+// RateLimitByTrip limits requests per {tripId} URL parameter within scope
+func RateLimitByTrip(limiter RateLimiter, scope string, limit int, window time.Duration) func(http.Handler) http.Handler {
+	return rateLimit(limiter, limit, window, func(r *http.Request) string {
+		return scope + ":trip:" + chi.URLParam(r, "tripId")
+	})
+}
+
+// rateLimit rejects requests over limit with 429. It is a no-op without a
+// limiter or with a zero limit, and fails open if Redis is unavailable so an
+// outage does not block bookings.
+func rateLimit(limiter RateLimiter, limit int, window time.Duration, key func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil || limit <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			remaining, wait, err := limiter.Take(r.Context(), key(r), limit, window)
+			if err != nil {
+				log.Printf("rate limit check failed: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+
+			if wait > 0 {
+				retryAfter := int((wait + time.Second - 1) / time.Second)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				WriteError(w, http.StatusTooManyRequests, ErrCodeRateLimited, "too many requests, retry later")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// countingLimiter is an in-memory RateLimiter whose buckets never refill
+type countingLimiter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (l *countingLimiter) Take(_ context.Context, key string, limit int, window time.Duration) (int64, time.Duration, error) {
+	if l.err != nil {
+		return 0, 0, l.err
+	}
+	if l.counts[key] >= int64(limit) {
+		return 0, window / time.Duration(limit), nil
+	}
+	l.counts[key]++
+	return int64(limit) - l.counts[key], 0, nil
+}
+
+func TestRateLimitByIP(t *testing.T) {
+	limiter := &countingLimiter{counts: map[string]int64{}}
+	handler := RateLimitByIP(limiter, "create", 2, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	want := []int{http.StatusCreated, http.StatusCreated, http.StatusTooManyRequests}
+	for i, status := range want {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		handler.ServeHTTP(rec, req)
+		if rec.Code != status {
+			t.Fatalf("request %d: got %d, want %d", i+1, rec.Code, status)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	handler.ServeHTTP(rec, req)
+	if rec.Header().Get("Retry-After") != "30" {
+		t.Errorf("Retry-After = %q, want 30", rec.Header().Get("Retry-After"))
+	}
+
+	// Another client has its own budget
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+	req.RemoteAddr = "10.0.0.2:5000"
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Errorf("second client: got %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	limiter := &countingLimiter{err: errors.New("redis down")}
+	handler := RateLimitByIP(limiter, "create", 1, time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("request %d: got %d, want %d", i+1, rec.Code, http.StatusCreated)
+		}
+	}
+}
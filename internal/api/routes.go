@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,64 +10,181 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/service"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
 )
 
 // RouterConfig holds dependencies for router creation
 type RouterConfig struct {
-	Pool        *pgxpool.Pool
-	RedisClient *redis.Client
-	Handlers    *Handlers
+	Pool           *pgxpool.Pool
+	RedisClient    *redis.Client
+	TemporalClient *service.TemporalClient
+	TemporalStats  *temporalpkg.InvocationMetrics // Temporal calls shown by /api/status; nil omits them
+	Handlers       *Handlers
+	AllowedOrigins []string
+	AdminAPIKeys   []string
+	RateLimiter    RateLimiter
+	RateLimit      RateLimitPolicy
+	Settings       map[string]string // sanitized configuration shown by /api/status
+	SchemaChecker  *database.SchemaChecker
+	Currency       string // ISO 4217 code prices are formatted in
+
+	PaymentCallbackSecret string // HMAC key of payment gateway callbacks; empty rejects them
 }
 
 // NewRouter creates a new Chi router with all routes configured
 func NewRouter(cfg RouterConfig) *chi.Mux {
 	r := chi.NewRouter()
+	metrics := NewRequestMetrics()
 
 	// Global middleware
+	r.Use(metrics.Middleware)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(CORS("http://localhost:3000", "http://localhost:5173"))
-
-	// Health check
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		// Check database
-		if err := database.HealthCheck(r.Context(), cfg.Pool); err != nil {
-			http.Error(w, "database unhealthy", http.StatusServiceUnavailable)
-			return
-		}
+	r.Use(CORS(r, cfg.AllowedOrigins...))
+	r.Use(RejectMethodOverride)
+	r.Use(Localize(cfg.Currency))
+	// Serve HEAD from GET handlers; net/http drops the body for HEAD responses
+	r.Use(middleware.GetHead)
 
-		// Check Redis
-		if err := database.RedisHealthCheck(r.Context(), cfg.RedisClient); err != nil {
-			http.Error(w, "redis unhealthy", http.StatusServiceUnavailable)
-			return
-		}
+	r.MethodNotAllowed(MethodNotAllowed(r))
 
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
+	// Probes: liveness only shows the process is up, readiness that every
+	// dependency an order needs answers. /health is kept for older callers.
+	readiness := ServeReadiness(readinessChecks(cfg), func() string {
+		// Schema drift degrades readiness without failing it; the server still runs
+		if cfg.SchemaChecker != nil {
+			if report := cfg.SchemaChecker.Last(); !report.CheckedAt.IsZero() && !report.Healthy() {
+				return "schema drift, see /api/v1/admin/schema"
+			}
+		}
+		return ""
 	})
+	r.Get("/healthz", ServeLiveness)
+	r.Get("/readyz", readiness)
+	r.Get("/health", readiness)
 
-	// API routes
+	// API routes: /api/v1 is canonical, unversioned paths are a compatibility shim
 	r.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", ServeOpenAPI)
+		r.Get("/docs", ServeSwaggerUI)
+		r.Get("/status", ServeStatus(metrics, cfg.TemporalStats, cfg.Settings))
+
+		r.Route("/v1", v1Routes(cfg))
+
+		r.Group(func(r chi.Router) {
+			r.Use(LegacyPathDeprecation)
+			v1Routes(cfg)(r)
+		})
+	})
+
+	return r
+}
+
+// readinessChecks lists the dependency checks behind /readyz
+func readinessChecks(cfg RouterConfig) []ReadinessCheck {
+	return []ReadinessCheck{
+		{"postgres", func(ctx context.Context) error { return database.HealthCheck(ctx, cfg.Pool) }},
+		{"redis", func(ctx context.Context) error { return database.RedisHealthCheck(ctx, cfg.RedisClient) }},
+		{"temporal", cfg.TemporalClient.HealthCheck},
+	}
+}
+
+// v1Routes registers the version 1 API surface on the given router
+func v1Routes(cfg RouterConfig) func(r chi.Router) {
+	limit := cfg.RateLimit
+	perIP := func(scope string) func(http.Handler) http.Handler {
+		return RateLimitByIP(cfg.RateLimiter, scope, limit.PerIP, limit.Window)
+	}
+	perOrder := RateLimitByOrder(cfg.RateLimiter, "pay", limit.PerOrder, limit.Window)
+	perTrip := RateLimitByTrip(cfg.RateLimiter, "pay", limit.PerOrder, limit.Window)
+
+	return func(r chi.Router) {
 		// Flight routes
 		r.Route("/flights", func(r chi.Router) {
 			r.Get("/", cfg.Handlers.ListFlights)
+			r.Get("/calendar", cfg.Handlers.FareCalendar)
 			r.Get("/{flightId}", cfg.Handlers.GetFlight)
+			r.Post("/{flightId}/recommend-seats", cfg.Handlers.RecommendSeats)
+			r.Get("/{flightId}/swap-offers", cfg.Handlers.ListSwapOffers)
 		})
 
 		// Order routes
 		r.Route("/orders", func(r chi.Router) {
-			r.Post("/", cfg.Handlers.CreateOrder)
+			r.With(perIP("create")).Post("/", cfg.Handlers.CreateOrder)
+			r.With(perIP("create")).Post("/bulk", cfg.Handlers.CreateOrdersBulk)
+			r.With(perIP("create")).Post("/group", cfg.Handlers.CreateGroupOrder)
 
 			r.Route("/{orderId}", func(r chi.Router) {
 				r.Put("/seats", cfg.Handlers.UpdateSeats)
 				r.Get("/status", cfg.Handlers.GetOrderStatus)
-				r.Post("/pay", cfg.Handlers.SubmitPayment)
+				r.Get("/itinerary", cfg.Handlers.GetItinerary)
+				r.Get("/payments", cfg.Handlers.ListPayments)
+				r.Get("/events", cfg.Handlers.ListOrderEvents)
+				r.Get("/boarding-pass", cfg.Handlers.GetBoardingPass)
+				r.Post("/checkin", cfg.Handlers.CheckIn)
+				r.Post("/check-in", cfg.Handlers.CheckIn) // deprecated alias of /checkin
+				r.With(perOrder).Post("/extend", cfg.Handlers.ExtendHold)
+				r.With(perOrder).Post("/approve-partial", cfg.Handlers.ApprovePartial)
+				r.With(perIP("pay"), perOrder).Post("/pay", cfg.Handlers.SubmitPayment)
+				r.With(perIP("pay"), perOrder).Post("/challenge", cfg.Handlers.RespondPaymentChallenge)
+				r.With(perOrder).Post("/upsell", cfg.Handlers.RespondUpsell)
 				r.Delete("/", cfg.Handlers.CancelOrder)
+				r.With(RequireAPIKey(cfg.AdminAPIKeys)).Post("/refund", cfg.Handlers.RefundOrder)
+				r.Post("/swap-offers", cfg.Handlers.OfferSwap)
+				r.Delete("/swap-offers/{offerId}", cfg.Handlers.CancelSwapOffer)
+				r.Get("/notification-preferences", cfg.Handlers.GetNotificationPreferences)
+				r.Put("/notification-preferences", cfg.Handlers.UpdateNotificationPreferences)
 			})
 		})
-	})
 
-	return r
+		// Connecting itineraries booked as one order
+		r.Route("/trips", func(r chi.Router) {
+			r.With(perIP("create")).Post("/", cfg.Handlers.CreateTrip)
+
+			r.Route("/{tripId}", func(r chi.Router) {
+				r.With(perIP("lookup")).Get("/", cfg.Handlers.GetTrip) // by trip ID or booking reference
+				r.Get("/status", cfg.Handlers.GetTripStatus)
+				r.With(perIP("pay"), perTrip).Post("/pay", cfg.Handlers.SubmitTripPayment)
+				r.With(perIP("pay"), perTrip).Post("/challenge", cfg.Handlers.RespondTripChallenge)
+				r.Delete("/", cfg.Handlers.CancelTrip)
+			})
+		})
+
+		// Seat swap marketplace
+		r.Route("/swap-offers/{offerId}", func(r chi.Router) {
+			r.Get("/", cfg.Handlers.GetSwapOffer)
+			r.Post("/accept", cfg.Handlers.AcceptSwapOffer)
+		})
+
+		// One-click unsubscribe links carried in notifications
+		r.Post("/notifications/unsubscribe/{token}", cfg.Handlers.Unsubscribe)
+
+		// Results of payments a gateway settles out of band, signed by the gateway
+		r.Post("/payments/callback", PaymentCallback(cfg.TemporalClient, cfg.PaymentCallbackSecret))
+
+		// Admin and maintenance routes, authenticated separately from customer traffic
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(RequireAPIKey(cfg.AdminAPIKeys))
+			r.Post("/flights/import", cfg.Handlers.ImportSchedule)
+			r.Patch("/flights/{flightId}/seats", cfg.Handlers.UpdateSeatMap)
+			r.Post("/flights/{flightId}/release-locks", cfg.Handlers.ReleaseSeatLocks)
+			r.Get("/flights/{flightId}/inventory", cfg.Handlers.ExportInventory)
+			r.Put("/flights/{flightId}/inventory", cfg.Handlers.ImportInventory)
+			r.Get("/flights/{flightId}/revenue", cfg.Handlers.FlightRevenue)
+			r.Put("/flights/{flightId}/freeze", cfg.Handlers.FreezeBookings)
+			r.Get("/flights/{flightId}/manifest", cfg.Handlers.FlightManifest)
+			r.Put("/flights/{flightId}/overbooking", cfg.Handlers.SetOverbooking)
+			r.Get("/flights/{flightId}/bumps", cfg.Handlers.FlightBumps)
+			r.Get("/flights/{flightId}/prices", cfg.Handlers.FlightPriceHistory)
+			r.Get("/orders", cfg.Handlers.ListOrders)
+			r.Get("/webhooks", cfg.Handlers.ListWebhooks)
+			r.Post("/webhooks", cfg.Handlers.CreateWebhook)
+			r.Delete("/webhooks/{webhookId}", cfg.Handlers.DeleteWebhook)
+			r.Get("/schema", ServeSchemaReport(cfg.SchemaChecker))
+			r.Post("/schema/verify", VerifySchema(cfg.SchemaChecker))
+		})
+	}
 }
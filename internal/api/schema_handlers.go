@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/flight-booking-system/internal/database"
+)
+
+// ServeSchemaReport handles GET /api/admin/schema with the latest drift report
+func ServeSchemaReport(checker *database.SchemaChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, newSchemaReportResponse(checker.Last()))
+	}
+}
+
+// VerifySchema handles POST /api/admin/schema/verify by re-running the check
+func VerifySchema(checker *database.SchemaChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, http.StatusOK, newSchemaReportResponse(checker.Check(r.Context())))
+	}
+}
+
+// newSchemaReportResponse converts a schema report to its API representation
+func newSchemaReportResponse(report database.SchemaReport) SchemaReportResponse {
+	response := SchemaReportResponse{
+		Drift: []SchemaDriftResponse{},
+		Error: report.Error,
+	}
+	// An unchecked schema is not known to be healthy
+	if !report.CheckedAt.IsZero() {
+		response.CheckedAt = &report.CheckedAt
+		response.Healthy = report.Healthy()
+	}
+	for _, d := range report.Drift {
+		response.Drift = append(response.Drift, SchemaDriftResponse{Kind: string(d.Kind), Table: d.Table, Name: d.Name})
+	}
+	return response
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// statusDependencies are the modules whose versions /api/status reports
+var statusDependencies = []string{
+	"github.com/go-chi/chi/v5",
+	"github.com/jackc/pgx/v5",
+	"github.com/redis/go-redis/v9",
+	"go.temporal.io/sdk",
+	"google.golang.org/grpc",
+}
+
+// ServeStatus returns a handler describing the running server: build,
+// uptime, dependency versions, sanitized settings, request metrics and, when
+// temporal is set, the Temporal calls the server made
+func ServeStatus(metrics *RequestMetrics, temporal *temporalpkg.InvocationMetrics, settings map[string]string) http.HandlerFunc {
+	build, deps := readBuildInfo()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := StatusResponse{
+			Build:         build,
+			StartedAt:     metrics.Started(),
+			UptimeSeconds: int64(time.Since(metrics.Started()).Seconds()),
+			Dependencies:  deps,
+			Config:        settings,
+			WindowSeconds: int(metrics.Window().Seconds()),
+			Endpoints:     []EndpointMetricsResponse{},
+			Temporal:      []InvocationMetricsResponse{},
+		}
+		for _, e := range metrics.Snapshot() {
+			response.Endpoints = append(response.Endpoints, EndpointMetricsResponse{
+				Endpoint: e.Endpoint,
+				Recent:   newEndpointCountsResponse(e.Recent),
+				Total:    newEndpointCountsResponse(e.Total),
+			})
+		}
+
+		if temporal != nil {
+			for _, stats := range temporal.Snapshot() {
+				response.Temporal = append(response.Temporal, newInvocationMetricsResponse(stats))
+			}
+		}
+
+		WriteJSON(w, http.StatusOK, response)
+	}
+}
+
+// readBuildInfo extracts version control details and dependency versions
+// stamped into the binary by the go tool
+func readBuildInfo() (BuildInfoResponse, map[string]string) {
+	build := BuildInfoResponse{Version: "unknown", GoVersion: runtime.Version()}
+	deps := make(map[string]string, len(statusDependencies))
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build, deps
+	}
+
+	build.Version = info.Main.Version
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			build.Commit = s.Value
+		case "vcs.time":
+			build.CommitTime = s.Value
+		case "vcs.modified":
+			build.Modified = s.Value == "true"
+		}
+	}
+
+	for _, dep := range info.Deps {
+		if slices.Contains(statusDependencies, dep.Path) {
+			deps[dep.Path] = dep.Version
+		}
+	}
+
+	return build, deps
+}
+
+func newEndpointCountsResponse(c EndpointCounts) EndpointCountsResponse {
+	return EndpointCountsResponse{
+		Requests:     c.Requests,
+		ClientErrors: c.ClientErrors,
+		ServerErrors: c.ServerErrors,
+	}
+}
+
+func newInvocationMetricsResponse(s temporalpkg.InvocationStats) InvocationMetricsResponse {
+	response := InvocationMetricsResponse{
+		Kind:         s.Kind,
+		Name:         s.Name,
+		Calls:        s.Calls,
+		Failures:     s.Failures,
+		Retries:      s.Retries,
+		MaxLatencyMs: float64(s.MaxLatency) / float64(time.Millisecond),
+	}
+	if s.Calls > 0 {
+		response.AvgLatencyMs = float64(s.TotalLatency) / float64(s.Calls) / float64(time.Millisecond)
+	}
+	return response
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// OfferSwap handles POST /api/orders/{orderId}/swap-offers
+func (h *Handlers) OfferSwap(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "orderId")
+
+	var req SwapOfferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("orderId", orderID)
+	v.seatID("seatId", req.SeatID)
+	if !v.check(w) {
+		return
+	}
+
+	offer, err := h.swapService.OfferSwap(r.Context(), orderID, req.SeatID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, newSwapOfferResponse(*offer))
+}
+
+// CancelSwapOffer handles DELETE /api/orders/{orderId}/swap-offers/{offerId}
+func (h *Handlers) CancelSwapOffer(w http.ResponseWriter, r *http.Request) {
+	err := h.swapService.CancelOffer(r.Context(), chi.URLParam(r, "offerId"), chi.URLParam(r, "orderId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSwapOffers handles GET /api/flights/{flightId}/swap-offers
+func (h *Handlers) ListSwapOffers(w http.ResponseWriter, r *http.Request) {
+	offers, err := h.swapService.ListOpenOffers(r.Context(), chi.URLParam(r, "flightId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := SwapOfferListResponse{Offers: make([]SwapOfferResponse, len(offers))}
+	for i, o := range offers {
+		response.Offers[i] = newSwapOfferResponse(o)
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// GetSwapOffer handles GET /api/swap-offers/{offerId}
+func (h *Handlers) GetSwapOffer(w http.ResponseWriter, r *http.Request) {
+	offer, err := h.swapService.GetOffer(r.Context(), chi.URLParam(r, "offerId"))
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, newSwapOfferResponse(*offer))
+}
+
+// AcceptSwapOffer handles POST /api/swap-offers/{offerId}/accept
+func (h *Handlers) AcceptSwapOffer(w http.ResponseWriter, r *http.Request) {
+	var req AcceptSwapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	offerID := chi.URLParam(r, "offerId")
+	var v validator
+	v.uuid("offerId", offerID)
+	v.uuid("orderId", req.OrderID)
+	v.seatID("seatId", req.SeatID)
+	if !v.check(w) {
+		return
+	}
+
+	offer, err := h.swapService.AcceptSwap(r.Context(), offerID, req.OrderID, req.SeatID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	// The exchange completes asynchronously in the seat swap workflow
+	WriteJSON(w, http.StatusAccepted, newSwapOfferResponse(*offer))
+}
+
+func newSwapOfferResponse(o domain.SeatSwapOffer) SwapOfferResponse {
+	return SwapOfferResponse{
+		ID:              o.ID,
+		FlightID:        o.FlightID,
+		OrderID:         o.OrderID,
+		SeatID:          o.SeatID,
+		Status:          string(o.Status),
+		AcceptedOrderID: derefString(o.AcceptedOrderID),
+		AcceptedSeatID:  derefString(o.AcceptedSeatID),
+		FailureReason:   derefString(o.FailureReason),
+		CreatedAt:       o.CreatedAt,
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
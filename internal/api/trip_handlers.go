@@ -0,0 +1,210 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/service"
+)
+
+// CreateTrip handles POST /api/trips
+func (h *Handlers) CreateTrip(w http.ResponseWriter, r *http.Request) {
+	var req CreateTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	if v.tripLegs("legs", req.Legs); !v.check(w) {
+		return
+	}
+
+	input := service.CreateTripInput{Legs: make([]service.CreateOrderInput, len(req.Legs))}
+	for i, leg := range req.Legs {
+		input.Legs[i] = service.CreateOrderInput{
+			FlightID:   leg.FlightID,
+			Seats:      leg.Seats,
+			SeatCount:  leg.SeatCount,
+			Passengers: newPassengers(leg.Passengers),
+		}
+	}
+
+	output, err := h.bookingService.CreateTrip(r.Context(), input)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := CreateTripResponse{
+		TripID:     output.TripID,
+		WorkflowID: output.WorkflowID,
+		Status:     string(output.Status),
+		Legs:       make([]CreateOrderResponse, len(output.Legs)),
+	}
+	for i, leg := range output.Legs {
+		response.Legs[i] = CreateOrderResponse{
+			OrderID:    leg.OrderID,
+			WorkflowID: leg.WorkflowID,
+			Status:     string(leg.Status),
+			ExpiresAt:  leg.ExpiresAt,
+		}
+	}
+
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// GetTripStatus handles GET /api/trips/{tripId}/status
+func (h *Handlers) GetTripStatus(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "tripId")
+	var v validator
+	if v.uuid("tripId", tripID); !v.check(w) {
+		return
+	}
+
+	trip, err := h.bookingService.GetTripStatus(r.Context(), tripID)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := TripStatusResponse{
+		TripID:          trip.TripID,
+		Status:          string(trip.Status),
+		PaymentAttempts: trip.PaymentAttempts,
+		LastError:       trip.LastError,
+		Legs:            make([]OrderStatusResponse, len(trip.Legs)),
+
+		PaymentChallenge: newPaymentChallengeResponse(trip.PaymentChallenge),
+	}
+	for i := range trip.Legs {
+		response.Legs[i] = newOrderStatusResponse(&trip.Legs[i])
+		response.TotalCents += trip.Legs[i].Price.TotalCents
+	}
+
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// GetTrip handles GET /api/trips/{tripId}; the trip may also be looked up by
+// the booking reference of any confirmed leg
+func (h *Handlers) GetTrip(w http.ResponseWriter, r *http.Request) {
+	ref := chi.URLParam(r, "tripId")
+	if !uuidPattern.MatchString(ref) {
+		ref = strings.ToUpper(ref) // booking references are case-insensitive
+	}
+
+	var v validator
+	if v.tripRef("tripId", ref); !v.check(w) {
+		return
+	}
+
+	trip, err := h.tripService.GetTrip(r.Context(), ref)
+	if err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	response := TripSummaryResponse{
+		TripID: trip.TripID,
+		Status: string(trip.Status),
+		Legs:   make([]TripLegResponse, len(trip.Legs)),
+	}
+	for i, leg := range trip.Legs {
+		response.Legs[i] = newTripLegResponse(leg)
+		response.TotalCents += leg.Price.TotalCents
+	}
+
+	writeLocalizedJSON(w, r, http.StatusOK, &response)
+}
+
+// newTripLegResponse converts a trip leg to its API representation
+func newTripLegResponse(leg domain.TripLeg) TripLegResponse {
+	itinerary := newItineraryResponse(&leg.Itinerary)
+	response := TripLegResponse{
+		Direction:        string(leg.Direction),
+		Status:           string(leg.Status),
+		BookingReference: itinerary.BookingReference,
+		OrderID:          itinerary.OrderID,
+		Flight:           itinerary.Flight,
+		Seats:            itinerary.Seats,
+		Passengers:       itinerary.Passengers,
+		Price:            itinerary.Price,
+		CheckedInAt:      itinerary.CheckedInAt,
+	}
+	if !leg.ConfirmedAt.IsZero() {
+		response.ConfirmedAt = &leg.ConfirmedAt
+	}
+	return response
+}
+
+// SubmitTripPayment handles POST /api/trips/{tripId}/pay
+func (h *Handlers) SubmitTripPayment(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "tripId")
+
+	var req SubmitPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	method := domain.PaymentMethod(req.Method)
+	v.uuid("tripId", tripID)
+	v.paymentMethod("method", method)
+	v.paymentCode("paymentCode", req.PaymentCode, method)
+	if !v.check(w) {
+		return
+	}
+
+	if err := h.bookingService.SubmitTripPayment(r.Context(), tripID, method, req.PaymentCode); err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, TripPaymentAcceptedResponse{TripID: tripID})
+}
+
+// RespondTripChallenge handles POST /api/trips/{tripId}/challenge
+func (h *Handlers) RespondTripChallenge(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "tripId")
+
+	var req PaymentChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	var v validator
+	v.uuid("tripId", tripID)
+	v.challengeCode("code", req.Code)
+	if !v.check(w) {
+		return
+	}
+
+	if err := h.bookingService.RespondTripChallenge(r.Context(), tripID, req.Code); err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, TripPaymentAcceptedResponse{TripID: tripID})
+}
+
+// CancelTrip handles DELETE /api/trips/{tripId}
+func (h *Handlers) CancelTrip(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "tripId")
+	var v validator
+	if v.uuid("tripId", tripID); !v.check(w) {
+		return
+	}
+
+	if err := h.bookingService.CancelTrip(r.Context(), tripID); err != nil {
+		HandleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
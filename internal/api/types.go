@@ -6,8 +6,84 @@ import "time"
 
 // CreateOrderRequest is the request body for creating a new order
 type CreateOrderRequest struct {
-	FlightID string   `json:"flightId"`
-	Seats    []string `json:"seats"`
+	FlightID   string             `json:"flightId"`
+	Seats      []string           `json:"seats,omitempty"`
+	SeatCount  int                `json:"seatCount,omitempty"`  // seatless booking instead of seats; seats are assigned at check-in
+	Passengers []PassengerRequest `json:"passengers,omitempty"` // one per seat when given
+
+	// SimulationSeed replays the same simulated payment outcomes for this
+	// order in any run, overriding SIMULATION_SEED
+	SimulationSeed *int64 `json:"simulationSeed,omitempty"`
+}
+
+// CreateGroupOrderRequest is the request body for booking a large party on one flight
+type CreateGroupOrderRequest struct {
+	FlightID   string             `json:"flightId"`
+	Seats      []string           `json:"seats"`
+	Passengers []PassengerRequest `json:"passengers,omitempty"` // one per seat when given
+}
+
+// CheckInRequest optionally moves an order to other seats in the same cabins at check-in
+type CheckInRequest struct {
+	Seats []string `json:"seats,omitempty"` // one per seat the order holds; seatless orders are seated automatically without
+}
+
+// PassengerRequest describes the traveller in one seat
+type PassengerRequest struct {
+	SeatID         string `json:"seatId,omitempty"` // omitted for seatless orders
+	Name           string `json:"name"`
+	DocumentNumber string `json:"documentNumber"` // passport or national ID, A-Z and 0-9
+	Email          string `json:"email"`
+}
+
+// CreateTripRequest is the request body for booking connecting flights as one order
+type CreateTripRequest struct {
+	Legs []TripLegRequest `json:"legs"` // in travel order
+}
+
+// TripLegRequest is one flight of a trip; it takes the same fields as an order
+type TripLegRequest struct {
+	FlightID   string             `json:"flightId"`
+	Seats      []string           `json:"seats,omitempty"`
+	SeatCount  int                `json:"seatCount,omitempty"`
+	Passengers []PassengerRequest `json:"passengers,omitempty"`
+}
+
+// BulkCreateOrderRequest is the request body for creating several orders on one flight
+type BulkCreateOrderRequest struct {
+	FlightID string             `json:"flightId"`
+	Groups   []BulkOrderGroupIn `json:"groups"`
+}
+
+// BulkOrderGroupIn is one party's seat selection in a bulk request
+type BulkOrderGroupIn struct {
+	Seats []string `json:"seats"`
+}
+
+// RecommendSeatsRequest is the request body for seat recommendations
+type RecommendSeatsRequest struct {
+	Position  string `json:"position,omitempty"` // window, aisle, or empty
+	Zone      string `json:"zone,omitempty"`     // front, back, or empty
+	Quiet     bool   `json:"quiet,omitempty"`
+	PartySize int    `json:"partySize,omitempty"` // seats needed together; defaults to 1
+	Limit     int    `json:"limit,omitempty"`
+}
+
+// SwapOfferRequest is the request body for offering a seat for swap
+type SwapOfferRequest struct {
+	SeatID string `json:"seatId"`
+}
+
+// AcceptSwapRequest is the request body for accepting a swap offer with one of your seats
+type AcceptSwapRequest struct {
+	OrderID string `json:"orderId"`
+	SeatID  string `json:"seatId"`
+}
+
+// NotificationPreferencesRequest replaces an order's notification preferences
+type NotificationPreferencesRequest struct {
+	Channels   []string `json:"channels"`   // email, sms, push
+	Categories []string `json:"categories"` // booking, reminder, marketing
 }
 
 // UpdateSeatsRequest is the request body for updating seat selection
@@ -17,7 +93,76 @@ type UpdateSeatsRequest struct {
 
 // SubmitPaymentRequest is the request body for submitting payment
 type SubmitPaymentRequest struct {
+	Method      string `json:"method,omitempty"` // card, wallet or voucher; card when empty
 	PaymentCode string `json:"paymentCode"`
+	Deposit     bool   `json:"deposit,omitempty"` // pay only the configured deposit now
+}
+
+// PaymentChallengeRequest answers an open payment challenge
+type PaymentChallengeRequest struct {
+	Code string `json:"code"` // the 6-digit one-time code the customer was sent
+}
+
+// PaymentCallbackRequest is a gateway's result for a payment it settled out
+// of band. Reference is the workflow ID the gateway was given when the
+// payment was authorized.
+type PaymentCallbackRequest struct {
+	Reference       string `json:"reference"`
+	AuthorizationID string `json:"authorizationId"`
+	Status          string `json:"status"` // succeeded, declined or failed
+	Error           string `json:"error,omitempty"`
+}
+
+// RespondUpsellRequest answers the upgrade offer made once payment is
+// submitted; leaving both false declines it
+type RespondUpsellRequest struct {
+	Upgrade          bool `json:"upgrade"`
+	PriorityBoarding bool `json:"priorityBoarding"`
+}
+
+// AdminSeatMapRequest is the request body for editing a flight's seat inventory
+type AdminSeatMapRequest struct {
+	Add     []AdminSeatInput `json:"add,omitempty"`
+	Remove  []string         `json:"remove,omitempty"`
+	Block   []string         `json:"block,omitempty"`
+	Unblock []string         `json:"unblock,omitempty"`
+}
+
+// AdminRefundRequest refunds a confirmed order; the reason is recorded on it.
+// A version refunds the order only if nothing has written it since.
+type AdminRefundRequest struct {
+	Reason  string `json:"reason,omitempty"`
+	Version *int   `json:"version,omitempty"`
+}
+
+// AdminFreezeRequest freezes or unfreezes bookings on a flight
+type AdminFreezeRequest struct {
+	Frozen *bool `json:"frozen"`
+}
+
+// AdminOverbookingRequest sets how far beyond its seats a flight may sell
+type AdminOverbookingRequest struct {
+	Percent *int `json:"percent"`
+}
+
+// AdminReleaseLocksRequest optionally limits a force release to specific seats
+type AdminReleaseLocksRequest struct {
+	Seats []string `json:"seats,omitempty"`
+}
+
+// AdminWebhookRequest registers a webhook endpoint
+type AdminWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`           // ORDER_CREATED, SEATS_RESERVED, CONFIRMED, EXPIRED, FAILED
+	Secret string   `json:"secret,omitempty"` // generated when omitted
+}
+
+// AdminSeatInput describes a seat to add
+type AdminSeatInput struct {
+	ID     string `json:"id"`
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Class  string `json:"class,omitempty"` // defaults to economy
 }
 
 // Response types
@@ -37,6 +182,33 @@ type FlightResponse struct {
 	TotalSeats     int       `json:"totalSeats"`
 	AvailableSeats int       `json:"availableSeats"`
 	PriceCents     int64     `json:"priceCents"`
+
+	Status        string     `json:"status"` // "scheduled", "delayed", "cancelled", "departed"
+	DelayMinutes  int        `json:"delayMinutes,omitempty"`
+	BookingFrozen bool       `json:"bookingFrozen,omitempty"`
+	SalesClosedAt *time.Time `json:"salesClosedAt,omitempty"`
+
+	OverbookingPercent int `json:"overbookingPercent,omitempty"` // availableSeats goes negative by up to this share of totalSeats
+
+	// Formatted for the caller's Accept-Language; omitted without the header
+	PriceFormatted         string `json:"priceFormatted,omitempty"`
+	DepartureTimeFormatted string `json:"departureTimeFormatted,omitempty"`
+}
+
+// FareCalendarResponse is the flexible-date fare matrix for a route
+type FareCalendarResponse struct {
+	Origin      string            `json:"origin"`
+	Destination string            `json:"destination"`
+	Days        []FareDayResponse `json:"days"`
+}
+
+// FareDayResponse is the cheapest fare for one departure day
+type FareDayResponse struct {
+	Date               string `json:"date"` // YYYY-MM-DD
+	FlightCount        int    `json:"flightCount"`
+	CheapestPriceCents *int64 `json:"cheapestPriceCents,omitempty"`
+
+	CheapestPriceFormatted string `json:"cheapestPriceFormatted,omitempty"` // per Accept-Language
 }
 
 // FlightDetailResponse represents a flight with seat map
@@ -47,17 +219,26 @@ type FlightDetailResponse struct {
 
 // SeatMapResponse represents seat map configuration
 type SeatMapResponse struct {
-	Rows        int            `json:"rows"`
-	SeatsPerRow int            `json:"seatsPerRow"`
-	Seats       []SeatResponse `json:"seats"`
+	Rows  []SeatRowResponse `json:"rows"`
+	Seats []SeatResponse    `json:"seats"`
+}
+
+// SeatRowResponse is the layout of one row; rows can differ in width and
+// skip columns, so clients should draw from this rather than a fixed grid
+type SeatRowResponse struct {
+	Number  int      `json:"number"`
+	Columns []string `json:"columns"` // seats present, in column order
+	Class   string   `json:"class"`   // "economy", "premium_economy", "business", "first"
 }
 
 // SeatResponse represents a seat in API responses
 type SeatResponse struct {
-	ID     string `json:"id"`
-	Row    int    `json:"row"`
-	Column string `json:"column"`
-	Status string `json:"status"` // "available", "reserved", "booked"
+	ID        string     `json:"id"`
+	Row       int        `json:"row"`
+	Column    string     `json:"column"`
+	Class     string     `json:"class"`
+	Status    string     `json:"status"`              // "available", "reserved", "booked", "blocked"
+	HeldUntil *time.Time `json:"heldUntil,omitempty"` // when the hold on a reserved seat ends
 }
 
 // CreateOrderResponse is the response for order creation
@@ -68,22 +249,397 @@ type CreateOrderResponse struct {
 	ExpiresAt  time.Time `json:"expiresAt"`
 }
 
+// CreateTripResponse is the response for trip creation
+type CreateTripResponse struct {
+	TripID     string                `json:"tripId"`
+	WorkflowID string                `json:"workflowId"`
+	Status     string                `json:"status"`
+	Legs       []CreateOrderResponse `json:"legs"`
+}
+
+// BulkCreateOrderResponse reports the outcome of each group in request order
+type BulkCreateOrderResponse struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BulkOrderItemResult `json:"results"`
+}
+
+// BulkOrderItemResult is the outcome for one group; exactly one of Order or Error is set
+type BulkOrderItemResult struct {
+	Index int                  `json:"index"`
+	Seats []string             `json:"seats"`
+	Order *CreateOrderResponse `json:"order,omitempty"`
+	Error *ErrorResponse       `json:"error,omitempty"`
+}
+
+// RecommendSeatsResponse lists ranked seat suggestions, best first
+type RecommendSeatsResponse struct {
+	FlightID        string                       `json:"flightId"`
+	Recommendations []SeatRecommendationResponse `json:"recommendations"`
+}
+
+// SeatRecommendationResponse is one suggested seat group with its score and rationale
+type SeatRecommendationResponse struct {
+	Seats   []string `json:"seats"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// SwapOfferResponse represents a seat swap offer
+type SwapOfferResponse struct {
+	ID              string    `json:"id"`
+	FlightID        string    `json:"flightId"`
+	OrderID         string    `json:"orderId"`
+	SeatID          string    `json:"seatId"`
+	Status          string    `json:"status"`
+	AcceptedOrderID string    `json:"acceptedOrderId,omitempty"`
+	AcceptedSeatID  string    `json:"acceptedSeatId,omitempty"`
+	FailureReason   string    `json:"failureReason,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// SwapOfferListResponse lists open swap offers on a flight
+type SwapOfferListResponse struct {
+	Offers []SwapOfferResponse `json:"offers"`
+}
+
+// AdminPlanResponse lists what an admin action touched, or would touch when dryRun is true
+type AdminPlanResponse struct {
+	Action    string   `json:"action"`
+	DryRun    bool     `json:"dryRun"`
+	Seats     []string `json:"seats"`
+	Orders    []string `json:"orders"`
+	Workflows []string `json:"workflows"`
+}
+
+// InventoryDiffResponse lists the seat changes an inventory import made, or
+// would make when dryRun is true
+type InventoryDiffResponse struct {
+	DryRun  bool     `json:"dryRun"`
+	Add     []string `json:"add"`
+	Remove  []string `json:"remove"`
+	Block   []string `json:"block"`
+	Unblock []string `json:"unblock"`
+}
+
+// ScheduleImportResponse lists the flight numbers a schedule import added, or
+// would add when dryRun is true, and those skipped because they were taken
+type ScheduleImportResponse struct {
+	DryRun  bool     `json:"dryRun"`
+	Created []string `json:"created"`
+	Skipped []string `json:"skipped"`
+}
+
+// FlightRevenueResponse reports the revenue recognized on a flight's
+// confirmed orders and how much of it refunds reversed
+type FlightRevenueResponse struct {
+	FlightID        string `json:"flightId"`
+	Orders          int    `json:"orders"`
+	RecognizedCents int64  `json:"recognizedCents"`
+	ReversedOrders  int    `json:"reversedOrders"`
+	ReversedCents   int64  `json:"reversedCents"`
+}
+
+// ManifestResponse lists the passengers and seats of a departed flight
+type ManifestResponse struct {
+	FlightID      string                  `json:"flightId"`
+	FlightNumber  string                  `json:"flightNumber"`
+	DepartureTime time.Time               `json:"departureTime"`
+	Passengers    []ManifestEntryResponse `json:"passengers"`
+	SeatlessCount int                     `json:"seatlessCount"` // seats of seatless orders confirmed without passenger details
+	GeneratedAt   time.Time               `json:"generatedAt"`
+}
+
+// ManifestEntryResponse is one passenger on a manifest
+type ManifestEntryResponse struct {
+	OrderID          string     `json:"orderId"`
+	BookingReference string     `json:"bookingReference"`
+	SeatID           string     `json:"seatId,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	DocumentNumber   string     `json:"documentNumber,omitempty"`
+	CheckedInAt      *time.Time `json:"checkedInAt,omitempty"`
+}
+
+// BumpResponse is an order bumped off an oversold flight
+type BumpResponse struct {
+	OrderID             string    `json:"orderId"`
+	Seats               int       `json:"seats"`
+	Status              string    `json:"status"` // "rebooked" or "refunded"
+	AlternativeFlightID *string   `json:"alternativeFlightId,omitempty"`
+	CompensationCents   int64     `json:"compensationCents"`
+	BumpedAt            time.Time `json:"bumpedAt"`
+}
+
+// BumpListResponse lists the orders bumped off a flight
+type BumpListResponse struct {
+	FlightID string         `json:"flightId"`
+	Bumps    []BumpResponse `json:"bumps"`
+}
+
+// PriceChangeResponse is one fare change dynamic pricing made
+type PriceChangeResponse struct {
+	PriceCents         int64     `json:"priceCents"`
+	PreviousPriceCents int64     `json:"previousPriceCents"`
+	BasePriceCents     int64     `json:"basePriceCents"`
+	TotalSeats         int       `json:"totalSeats"`
+	AvailableSeats     int       `json:"availableSeats"`
+	Reason             string    `json:"reason"`            // "confirmation" or "schedule"
+	OrderID            *string   `json:"orderId,omitempty"` // the order whose confirmation triggered it
+	ChangedAt          time.Time `json:"changedAt"`
+}
+
+// PriceHistoryResponse lists a flight's fare changes, oldest first
+type PriceHistoryResponse struct {
+	FlightID string                `json:"flightId"`
+	Changes  []PriceChangeResponse `json:"changes"`
+}
+
+// NotificationPreferencesResponse represents an order's notification preferences
+type NotificationPreferencesResponse struct {
+	OrderID      string   `json:"orderId"`
+	Channels     []string `json:"channels"`
+	Categories   []string `json:"categories"`
+	Unsubscribed bool     `json:"unsubscribed"`
+}
+
+// WebhookResponse represents a webhook subscription. Secret is only returned on creation.
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookListResponse lists active webhook subscriptions
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// RefundResponse represents the refund of an order's payment
+type RefundResponse struct {
+	ID          string     `json:"id"`
+	OrderID     string     `json:"orderId"`
+	AmountCents int64      `json:"amountCents"`
+	Reason      string     `json:"reason"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// OrderListResponse is one page of an order listing
+type OrderListResponse struct {
+	Orders []OrderSummaryResponse `json:"orders"`
+	Total  int                    `json:"total"` // orders matching the filter across every page
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// OrderSummaryResponse is an order as an order listing shows it
+type OrderSummaryResponse struct {
+	OrderID          string     `json:"orderId"`
+	FlightID         string     `json:"flightId"`
+	Status           string     `json:"status"`
+	Seats            []string   `json:"seats"`
+	CabinSeats       int        `json:"cabinSeats,omitempty"`
+	TotalPriceCents  int64      `json:"totalPriceCents"`
+	PaymentMethod    string     `json:"paymentMethod,omitempty"`
+	BookingReference string     `json:"bookingReference,omitempty"`
+	TripID           string     `json:"tripId,omitempty"`
+	FailureReason    string     `json:"failureReason,omitempty"`
+	Version          int        `json:"version"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	ConfirmedAt      *time.Time `json:"confirmedAt,omitempty"`
+}
+
+// PaymentListResponse lists the attempts to take an order's payment
+type PaymentListResponse struct {
+	Payments []PaymentResponse `json:"payments"`
+}
+
+// OrderEventListResponse is the history of an order, oldest first
+type OrderEventListResponse struct {
+	Events []OrderEventResponse `json:"events"`
+}
+
+// OrderEventResponse is one entry of an order's history. What data holds
+// depends on the type: seats for SEATS_RESERVED, SEATS_RELEASED and
+// CONFIRMED, from and to for SEATS_CHANGED and STATUS_CHANGED, attempt and
+// message for PAYMENT_FAILED.
+type OrderEventResponse struct {
+	Type       string                 `json:"type"`
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt time.Time              `json:"occurredAt"`
+}
+
+// PaymentResponse is one attempt to take an order's payment. Attempts number
+// from 1 in each payment run; one that finished a lost earlier attempt
+// shares its idempotency key, and the earlier one names it in
+// resumedByAttempt.
+type PaymentResponse struct {
+	ID               string    `json:"id"`
+	Attempt          int       `json:"attempt"`
+	Method           string    `json:"method"`
+	AmountCents      int64     `json:"amountCents"`
+	Status           string    `json:"status"`
+	ProviderRef      string    `json:"providerRef,omitempty"`
+	IdempotencyKey   string    `json:"idempotencyKey"`
+	Message          string    `json:"message,omitempty"`
+	Error            string    `json:"error,omitempty"`
+	ResumedByAttempt *int      `json:"resumedByAttempt,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
 // OrderStatusResponse is the response for order status queries
 type OrderStatusResponse struct {
-	OrderID         string   `json:"orderId"`
-	Status          string   `json:"status"`
-	Seats           []string `json:"seats"`
-	TimerRemaining  int      `json:"timerRemaining"`
-	PaymentAttempts int      `json:"paymentAttempts"`
-	LastError       string   `json:"lastError,omitempty"`
+	OrderID         string        `json:"orderId"`
+	Status          string        `json:"status"`
+	Seats           []string      `json:"seats"`
+	TimerRemaining  int           `json:"timerRemaining"`
+	PaymentAttempts int           `json:"paymentAttempts"`
+	LastError       string        `json:"lastError,omitempty"`
+	Price           PriceResponse `json:"price"`
+	Seatless        bool          `json:"seatless,omitempty"`
+	SeatCount       int           `json:"seatCount"`
+	TripID          string        `json:"tripId,omitempty"` // legs of a trip are paid and canceled through it
+
+	// A group booking that could hold only some seats lists the rest here
+	// and cannot be paid until approve-partial accepts the seats it holds
+	UnavailableSeats []string `json:"unavailableSeats,omitempty"`
+	AwaitingApproval bool     `json:"awaitingApproval,omitempty"`
+
+	Passengers         []PassengerResponse `json:"passengers,omitempty"`
+	HoldExtensionsLeft int                 `json:"holdExtensionsLeft"`
+
+	// Open from the payment signal until answered with POST
+	// /orders/{orderId}/upsell or it expires; payment is taken after
+	UpsellOffer *UpsellOfferResponse `json:"upsellOffer,omitempty"`
+
+	// Open while the card issuer waits for the one-time code, answered with
+	// POST /orders/{orderId}/challenge
+	PaymentChallenge *PaymentChallengeResponse `json:"paymentChallenge,omitempty"`
+
+	// Set when the order pays a deposit and owes the balance before departure
+	Deposit *DepositResponse `json:"deposit,omitempty"`
+}
+
+// DepositResponse is the deposit an order was confirmed on and the balance
+// it owes
+type DepositResponse struct {
+	DepositCents  int64      `json:"depositCents"`
+	BalanceCents  int64      `json:"balanceCents"`
+	BalanceDueAt  time.Time  `json:"balanceDueAt"`
+	BalancePaidAt *time.Time `json:"balancePaidAt,omitempty"`
+}
+
+// PaymentChallengeResponse is a payment challenge waiting for the customer's
+// one-time code
+type PaymentChallengeResponse struct {
+	ExpiresAt    time.Time `json:"expiresAt"`
+	AttemptsLeft int       `json:"attemptsLeft"`
+}
+
+// UpsellOfferResponse is an open offer of seats in a higher cabin and
+// priority boarding, priced per seat
+type UpsellOfferResponse struct {
+	Class                 string    `json:"class,omitempty"` // omitted when only priority boarding is offered
+	Seats                 []string  `json:"seats,omitempty"`
+	UpgradeCents          int64     `json:"upgradeCents,omitempty"`
+	PriorityBoardingCents int64     `json:"priorityBoardingCents,omitempty"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+}
+
+// TripSummaryResponse is the consolidated itinerary of a trip
+type TripSummaryResponse struct {
+	TripID     string            `json:"tripId,omitempty"` // omitted for an order booked on its own
+	Status     string            `json:"status"`
+	TotalCents int64             `json:"totalCents"`
+	Legs       []TripLegResponse `json:"legs"`
+
+	TotalFormatted string `json:"totalFormatted,omitempty"` // per Accept-Language
+}
+
+// TripLegResponse is one order of a trip summary; the booking reference,
+// passengers and confirmation time appear once the leg is confirmed
+type TripLegResponse struct {
+	Direction        string              `json:"direction"` // "outbound" or "return"
+	Status           string              `json:"status"`
+	BookingReference string              `json:"bookingReference,omitempty"`
+	OrderID          string              `json:"orderId"`
+	Flight           FlightResponse      `json:"flight"`
+	Seats            []string            `json:"seats"`
+	Passengers       []PassengerResponse `json:"passengers"`
+	Price            PriceResponse       `json:"price"`
+	ConfirmedAt      *time.Time          `json:"confirmedAt,omitempty"`
+	CheckedInAt      *time.Time          `json:"checkedInAt,omitempty"`
+}
+
+// TripStatusResponse is the response for trip status queries
+type TripStatusResponse struct {
+	TripID          string                `json:"tripId"`
+	Status          string                `json:"status"`
+	TotalCents      int64                 `json:"totalCents"`
+	PaymentAttempts int                   `json:"paymentAttempts"`
+	LastError       string                `json:"lastError,omitempty"`
+	Legs            []OrderStatusResponse `json:"legs"`
+
+	// Open while the card issuer waits for the one-time code, answered with
+	// POST /trips/{tripId}/challenge
+	PaymentChallenge *PaymentChallengeResponse `json:"paymentChallenge,omitempty"`
+
+	TotalFormatted string `json:"totalFormatted,omitempty"` // per Accept-Language
+}
+
+// ItineraryResponse is the receipt for a confirmed order
+type ItineraryResponse struct {
+	BookingReference string              `json:"bookingReference"`
+	OrderID          string              `json:"orderId"`
+	Flight           FlightResponse      `json:"flight"`
+	Seats            []string            `json:"seats"`
+	Passengers       []PassengerResponse `json:"passengers"`
+	Price            PriceResponse       `json:"price"`
+	ConfirmedAt      time.Time           `json:"confirmedAt"`
+	CheckedInAt      *time.Time          `json:"checkedInAt,omitempty"`
+	Deposit          *DepositResponse    `json:"deposit,omitempty"`
+}
+
+// PassengerResponse is a traveller on an order; seatId is empty when unseated
+type PassengerResponse struct {
+	SeatID         string `json:"seatId,omitempty"`
+	Name           string `json:"name"`
+	DocumentNumber string `json:"documentNumber"`
+	Email          string `json:"email"`
+}
+
+// ExtendHoldResponse is the refreshed seat hold of an order
+type ExtendHoldResponse struct {
+	OrderID        string    `json:"orderId"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	ExtensionsLeft int       `json:"extensionsLeft"`
+}
+
+// PriceResponse is the price breakdown locked on an order
+type PriceResponse struct {
+	QuoteID       string `json:"quoteId"`
+	SeatCount     int    `json:"seatCount"`
+	BaseFareCents int64  `json:"baseFareCents"`
+	FeesCents     int64  `json:"feesCents"`
+	DiscountCents int64  `json:"discountCents"`
+	UpsellCents   int64  `json:"upsellCents,omitempty"` // upgrade and priority boarding accepted at payment
+	TotalCents    int64  `json:"totalCents"`
+
+	TotalFormatted string `json:"totalFormatted,omitempty"` // per Accept-Language
 }
 
 // UpdateSeatsResponse is the response for seat update
 type UpdateSeatsResponse struct {
-	OrderID   string    `json:"orderId"`
-	Status    string    `json:"status"`
-	Seats     []string  `json:"seats"`
-	ExpiresAt time.Time `json:"expiresAt"`
+	OrderID       string        `json:"orderId"`
+	Status        string        `json:"status"`
+	Seats         []string      `json:"seats"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+	Price         PriceResponse `json:"price"`
+	ReleasedSeats []string      `json:"releasedSeats,omitempty"` // dropped by a downsize and free for others
 }
 
 // PaymentAcceptedResponse is the response for payment submission
@@ -91,3 +647,85 @@ type PaymentAcceptedResponse struct {
 	OrderID string `json:"orderId"`
 	Status  string `json:"status"`
 }
+
+// TripPaymentAcceptedResponse is the response for trip payment submission;
+// poll the trip status for the outcome
+type TripPaymentAcceptedResponse struct {
+	TripID string `json:"tripId"`
+}
+
+// StatusResponse describes the running server for clients and dashboards
+type StatusResponse struct {
+	Build         BuildInfoResponse           `json:"build"`
+	StartedAt     time.Time                   `json:"startedAt"`
+	UptimeSeconds int64                       `json:"uptimeSeconds"`
+	Dependencies  map[string]string           `json:"dependencies"`
+	Config        map[string]string           `json:"config"`
+	WindowSeconds int                         `json:"windowSeconds"` // span of each endpoint's recent counts
+	Endpoints     []EndpointMetricsResponse   `json:"endpoints"`
+	Temporal      []InvocationMetricsResponse `json:"temporal"` // Temporal calls this server made since it started
+}
+
+// BuildInfoResponse identifies the server binary
+type BuildInfoResponse struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	CommitTime string `json:"commitTime,omitempty"`
+	Modified   bool   `json:"modified"` // built from a tree with uncommitted changes
+	GoVersion  string `json:"goVersion"`
+}
+
+// EndpointMetricsResponse are request counts for one method and route
+type EndpointMetricsResponse struct {
+	Endpoint string                 `json:"endpoint"`
+	Recent   EndpointCountsResponse `json:"recent"`
+	Total    EndpointCountsResponse `json:"total"`
+}
+
+// EndpointCountsResponse counts requests and error responses
+type EndpointCountsResponse struct {
+	Requests     int64 `json:"requests"`
+	ClientErrors int64 `json:"clientErrors"`
+	ServerErrors int64 `json:"serverErrors"`
+}
+
+// InvocationMetricsResponse counts the calls of one Temporal workflow,
+// activity or client call
+type InvocationMetricsResponse struct {
+	Kind         string  `json:"kind"`
+	Name         string  `json:"name"`
+	Calls        int64   `json:"calls"`
+	Failures     int64   `json:"failures"`
+	Retries      int64   `json:"retries"`
+	AvgLatencyMs float64 `json:"avgLatencyMs"`
+	MaxLatencyMs float64 `json:"maxLatencyMs"`
+}
+
+// ReadinessResponse reports each dependency check behind /readyz
+type ReadinessResponse struct {
+	Ready    bool                     `json:"ready"`
+	Checks   []ReadinessCheckResponse `json:"checks"`
+	Degraded string                   `json:"degraded,omitempty"` // a problem that does not fail readiness
+}
+
+// ReadinessCheckResponse is the outcome of one dependency check
+type ReadinessCheckResponse struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// SchemaReportResponse is the result of comparing the live database with the migrations
+type SchemaReportResponse struct {
+	Healthy   bool                  `json:"healthy"`
+	CheckedAt *time.Time            `json:"checkedAt,omitempty"` // absent until the first check
+	Drift     []SchemaDriftResponse `json:"drift"`
+	Error     string                `json:"error,omitempty"`
+}
+
+// SchemaDriftResponse is one difference, e.g. missing_column orders.booking_reference
+type SchemaDriftResponse struct {
+	Kind  string `json:"kind"`
+	Table string `json:"table"`
+	Name  string `json:"name,omitempty"`
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// maxSeatsPerOrder bounds how many seats one order may hold
+const maxSeatsPerOrder = 9
+
+// uuidPattern matches canonical UUIDs as generated by Postgres
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validator collects field errors so a request reports all of them at once
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) fail(field, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// uuid checks a required ID field
+func (v *validator) uuid(field, value string) {
+	switch {
+	case value == "":
+		v.fail(field, "is required")
+	case !uuidPattern.MatchString(value):
+		v.fail(field, "must be a UUID")
+	}
+}
+
+// tripRef checks a trip ID or booking reference
+func (v *validator) tripRef(field, value string) {
+	switch {
+	case value == "":
+		v.fail(field, "is required")
+	case !uuidPattern.MatchString(value) && !domain.IsValidBookingReference(value):
+		v.fail(field, "must be a trip ID or booking reference")
+	}
+}
+
+// seats checks the count and format of a seat selection; min is 0 where an
+// empty selection is meaningful
+func (v *validator) seats(field string, seats []string, min int) {
+	v.seatsBetween(field, seats, min, maxSeatsPerOrder)
+}
+
+// seatsBetween checks the format of a seat selection of min to max seats
+func (v *validator) seatsBetween(field string, seats []string, min, max int) {
+	if len(seats) < min || len(seats) > max {
+		v.fail(field, "must contain between %d and %d seats", min, max)
+	}
+
+	seen := make(map[string]bool, len(seats))
+	for i, seat := range seats {
+		item := fmt.Sprintf("%s[%d]", field, i)
+		switch {
+		case !domain.IsValidSeatID(seat):
+			v.fail(item, "invalid format")
+		case seen[seat]:
+			v.fail(item, "duplicate seat %s", seat)
+		}
+		seen[seat] = true
+	}
+}
+
+// seatID checks a single required seat ID
+func (v *validator) seatID(field, seat string) {
+	switch {
+	case seat == "":
+		v.fail(field, "is required")
+	case !domain.IsValidSeatID(seat):
+		v.fail(field, "invalid format")
+	}
+}
+
+// seatCount checks a seatless order's seat count, which replaces the seat list
+func (v *validator) seatCount(field string, count int, seats []string) {
+	switch {
+	case len(seats) > 0:
+		v.fail(field, "must not be combined with seats")
+	case count < 1 || count > maxSeatsPerOrder:
+		v.fail(field, "must be between 1 and %d", maxSeatsPerOrder)
+	}
+}
+
+// passengers checks optional passenger details; when present there must be
+// exactly one passenger per selected seat, or per seat of a seatless order
+func (v *validator) passengers(field string, passengers []PassengerRequest, seats []string, seatCount int) {
+	if len(passengers) == 0 {
+		return
+	}
+	if len(passengers) != len(seats)+seatCount {
+		v.fail(field, "must list one passenger per seat")
+	}
+
+	seated := make(map[string]bool, len(passengers))
+	for i, p := range passengers {
+		item := fmt.Sprintf("%s[%d]", field, i)
+		switch {
+		case seatCount > 0:
+			if p.SeatID != "" {
+				v.fail(item+".seatId", "must be empty; seatless orders are seated at check-in")
+			}
+		case !slices.Contains(seats, p.SeatID):
+			v.fail(item+".seatId", "must be one of the selected seats")
+		case seated[p.SeatID]:
+			v.fail(item+".seatId", "seat %s already has a passenger", p.SeatID)
+		}
+		seated[p.SeatID] = true
+
+		if p.Name == "" || len(p.Name) > domain.MaxPassengerNameLength {
+			v.fail(item+".name", "must be 1 to %d characters", domain.MaxPassengerNameLength)
+		}
+		if !domain.IsValidDocumentNumber(p.DocumentNumber) {
+			v.fail(item+".documentNumber", "must be 5 to 20 uppercase letters or digits")
+		}
+		if !domain.IsValidEmail(p.Email) {
+			v.fail(item+".email", "invalid format")
+		}
+	}
+}
+
+// tripLegs checks the leg count and each leg as an order of its own
+func (v *validator) tripLegs(field string, legs []TripLegRequest) {
+	if len(legs) < domain.MinTripLegs || len(legs) > domain.MaxTripLegs {
+		v.fail(field, "must contain between %d and %d legs", domain.MinTripLegs, domain.MaxTripLegs)
+	}
+
+	for i, leg := range legs {
+		item := fmt.Sprintf("%s[%d]", field, i)
+		v.uuid(item+".flightId", leg.FlightID)
+		if leg.SeatCount != 0 {
+			v.seatCount(item+".seatCount", leg.SeatCount, leg.Seats)
+		} else {
+			v.seats(item+".seats", leg.Seats, 1)
+		}
+		v.passengers(item+".passengers", leg.Passengers, leg.Seats, leg.SeatCount)
+	}
+}
+
+// paymentMethod checks the payment method is one the gateway takes; an
+// empty one pays by card
+func (v *validator) paymentMethod(field string, method domain.PaymentMethod) {
+	if !method.Valid() {
+		v.fail(field, "must be card, wallet or voucher")
+	}
+}
+
+// paymentCode checks the payment code has the shape its method takes; whether
+// it pays is the workflow's call
+func (v *validator) paymentCode(field, code string, method domain.PaymentMethod) {
+	switch {
+	case code == "":
+		v.fail(field, "is required")
+	case method.Valid() && !method.ValidCode(code):
+		v.fail(field, "must be "+method.CodeFormat())
+	}
+}
+
+// challengeCode checks the one-time code answering a payment challenge
+func (v *validator) challengeCode(field, code string) {
+	switch {
+	case code == "":
+		v.fail(field, "is required")
+	case !domain.IsValidChallengeCode(code):
+		v.fail(field, "must be 6 digits")
+	}
+}
+
+// orderListQuery reads the filter and page of an order listing from its
+// query string: status takes a comma-separated list, the created bounds
+// RFC 3339 times, sort createdAt or price
+func (v *validator) orderListQuery(q url.Values) (domain.OrderFilter, domain.Page) {
+	var filter domain.OrderFilter
+	if raw := q.Get("status"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			status := domain.OrderStatus(strings.ToUpper(strings.TrimSpace(s)))
+			if !status.IsValid() {
+				v.fail("status", "%q is not an order status", s)
+				continue
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	if filter.FlightID = q.Get("flightId"); filter.FlightID != "" {
+		v.uuid("flightId", filter.FlightID)
+	}
+	filter.CreatedAfter = v.queryTime(q, "createdAfter")
+	filter.CreatedBefore = v.queryTime(q, "createdBefore")
+
+	page := domain.Page{
+		Limit:      v.queryInt(q, "limit", 1, domain.MaxPageLimit),
+		Offset:     v.queryInt(q, "offset", 0, math.MaxInt32),
+		Sort:       domain.OrderSort(q.Get("sort")),
+		Descending: q.Get("desc") == "true",
+	}
+	if page.Sort != "" && !page.Sort.IsValid() {
+		v.fail("sort", "must be createdAt or price")
+	}
+	if raw := q.Get("desc"); raw != "" && raw != "true" && raw != "false" {
+		v.fail("desc", "must be true or false")
+	}
+	return filter, page
+}
+
+// queryTime reads an optional RFC 3339 time from the query string
+func (v *validator) queryTime(q url.Values, field string) *time.Time {
+	raw := q.Get(field)
+	if raw == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		v.fail(field, "must be an RFC 3339 time")
+		return nil
+	}
+	return &t
+}
+
+// queryInt reads an optional integer between min and max from the query
+// string; zero when it is absent
+func (v *validator) queryInt(q url.Values, field string, min, max int) int {
+	raw := q.Get(field)
+	if raw == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min || n > max {
+		v.fail(field, "must be between %d and %d", min, max)
+		return 0
+	}
+	return n
+}
+
+// ValidateCreateOrder returns the field errors of an order creation, which
+// POST /orders answers with 422; the gRPC API checks CreateOrder with it too
+func ValidateCreateOrder(req CreateOrderRequest) []FieldError {
+	var v validator
+	v.uuid("flightId", req.FlightID)
+	if req.SeatCount != 0 {
+		v.seatCount("seatCount", req.SeatCount, req.Seats)
+	} else {
+		v.seats("seats", req.Seats, 1)
+	}
+	v.passengers("passengers", req.Passengers, req.Seats, req.SeatCount)
+	return v.fields
+}
+
+// ValidateSubmitPayment returns the field errors of a payment for orderID,
+// which POST /orders/{orderId}/pay answers with 422; the gRPC API checks
+// SubmitPayment with it too
+func ValidateSubmitPayment(orderID string, req SubmitPaymentRequest) []FieldError {
+	var v validator
+	method := domain.PaymentMethod(req.Method)
+	v.uuid("orderId", orderID)
+	v.paymentMethod("method", method)
+	v.paymentCode("paymentCode", req.PaymentCode, method)
+	return v.fields
+}
+
+// check writes a 422 listing the collected errors and reports whether the request was valid
+func (v *validator) check(w http.ResponseWriter) bool {
+	if len(v.fields) == 0 {
+		return true
+	}
+
+	WriteJSON(w, http.StatusUnprocessableEntity, ValidationErrorResponse{
+		Error:   ErrCodeValidation,
+		Message: "request validation failed",
+		Fields:  v.fields,
+	})
+	return false
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestCreateOrderValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []FieldError
+	}{
+		{
+			"bad flight and seat",
+			`{"flightId":"f1","seats":["1A","2B","22"]}`,
+			[]FieldError{{"flightId", "must be a UUID"}, {"seats[2]", "invalid format"}},
+		},
+		{
+			"missing seats",
+			`{"flightId":"0b8f0a47-6a1c-4d2e-9a53-8c2b1f0e7d11","seats":[]}`,
+			[]FieldError{{"seats", "must contain between 1 and 9 seats"}},
+		},
+		{
+			"duplicate seat",
+			`{"flightId":"0b8f0a47-6a1c-4d2e-9a53-8c2b1f0e7d11","seats":["1A","1A"]}`,
+			[]FieldError{{"seats[1]", "duplicate seat 1A"}},
+		},
+		{
+			"seat count with seats",
+			`{"flightId":"0b8f0a47-6a1c-4d2e-9a53-8c2b1f0e7d11","seats":["1A"],"seatCount":2}`,
+			[]FieldError{{"seatCount", "must not be combined with seats"}},
+		},
+		{
+			"seatless passenger with seat",
+			`{"flightId":"0b8f0a47-6a1c-4d2e-9a53-8c2b1f0e7d11","seatCount":1,"passengers":[{"seatId":"1A","name":"Ada","documentNumber":"AB12345","email":"ada@example.com"}]}`,
+			[]FieldError{{"passengers[0].seatId", "must be empty; seatless orders are seated at check-in"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			(&Handlers{}).CreateOrder(rec, req)
+
+			if rec.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("got %d, want 422", rec.Code)
+			}
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if !slices.Equal(resp.Fields, tt.want) {
+				t.Errorf("got fields %v, want %v", resp.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentCodeValidation(t *testing.T) {
+	for code, want := range map[string]string{"": "is required", "12a45": "must be 5 digits", "123456": "must be 5 digits"} {
+		var v validator
+		v.paymentCode("paymentCode", code, "")
+		if len(v.fields) != 1 || v.fields[0].Message != want {
+			t.Errorf("code %q: got %v, want %q", code, v.fields, want)
+		}
+	}
+
+	var v validator
+	if v.paymentCode("paymentCode", "12345", domain.PaymentMethodCard); len(v.fields) != 0 {
+		t.Errorf("valid code rejected: %v", v.fields)
+	}
+	if v.paymentCode("paymentCode", "12345", domain.PaymentMethodVoucher); len(v.fields) != 1 ||
+		v.fields[0].Message != "must be a voucher code like ABCD-1234-EFGH" {
+		t.Errorf("card code as a voucher: got %v", v.fields)
+	}
+}
+
+func TestOrderListQueryValidation(t *testing.T) {
+	var v validator
+	q, _ := url.ParseQuery("status=confirmed,FAILED&sort=price&desc=true&limit=20&offset=40&createdAfter=2026-03-01T00:00:00Z")
+	filter, page := v.orderListQuery(q)
+	if len(v.fields) != 0 {
+		t.Fatalf("valid query rejected: %v", v.fields)
+	}
+	if !slices.Equal(filter.Statuses, []domain.OrderStatus{domain.OrderStatusConfirmed, domain.OrderStatusFailed}) ||
+		filter.CreatedAfter == nil || filter.CreatedBefore != nil {
+		t.Errorf("filter = %+v", filter)
+	}
+	if page != (domain.Page{Limit: 20, Offset: 40, Sort: domain.OrderSortPrice, Descending: true}) {
+		t.Errorf("page = %+v", page)
+	}
+
+	v = validator{}
+	q, _ = url.ParseQuery("status=BOOKED&flightId=abc&sort=seats&limit=500&createdBefore=yesterday")
+	v.orderListQuery(q)
+	var fields []string
+	for _, f := range v.fields {
+		fields = append(fields, f.Field)
+	}
+	if want := []string{"status", "flightId", "createdBefore", "limit", "sort"}; !slices.Equal(fields, want) {
+		t.Errorf("got fields %v, want %v", fields, want)
+	}
+}
@@ -0,0 +1,106 @@
+// Package boardingpass renders boarding passes as PNG images
+package boardingpass
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Pass layout in pixels. Text is drawn at half size with the 7x13 basic
+// font and scaled up, which keeps the renderer free of font files.
+const (
+	passWidth  = 800
+	passHeight = 260
+	qrSize     = 220
+	margin     = 20
+	textScale  = 2
+	lineHeight = 18 // at half size
+)
+
+var (
+	background = color.RGBA{R: 0xf7, G: 0xf9, B: 0xfc, A: 0xff}
+	banner     = color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xff}
+	ink        = color.Black
+	divider    = color.RGBA{R: 0xcb, G: 0xd5, B: 0xe1, A: 0xff}
+)
+
+// Render draws passes stacked top to bottom into one PNG
+func Render(passes []domain.BoardingPass) ([]byte, error) {
+	if len(passes) == 0 {
+		return nil, fmt.Errorf("render boarding pass: no passes")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, passWidth, passHeight*len(passes)))
+	for i, pass := range passes {
+		if err := drawPass(img, image.Pt(0, i*passHeight), pass); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode boarding pass: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawPass draws one pass with its top-left corner at origin
+func drawPass(dst *image.RGBA, origin image.Point, pass domain.BoardingPass) error {
+	bounds := image.Rectangle{Min: origin, Max: origin.Add(image.Pt(passWidth, passHeight))}
+	draw.Draw(dst, bounds, image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(dst, image.Rect(origin.X, origin.Y+passHeight-2, origin.X+passWidth, origin.Y+passHeight), image.NewUniform(divider), image.Point{}, draw.Src)
+
+	// Text panel left of the QR code, drawn at half size and scaled up
+	text := image.NewRGBA(image.Rect(0, 0, (passWidth-qrSize-2*margin)/textScale, passHeight/textScale))
+	draw.Draw(text, text.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(text, image.Rect(0, 0, text.Bounds().Dx(), lineHeight+4), image.NewUniform(banner), image.Point{}, draw.Src)
+
+	writeLine(text, 0, "BOARDING PASS  "+pass.BookingReference, color.White)
+	name := pass.PassengerName
+	if name == "" {
+		name = "SEAT HOLDER"
+	}
+	lines := []string{
+		"PASSENGER  " + name,
+		"FLIGHT     " + pass.FlightNumber + "  " + pass.Route(),
+		"DEPARTS    " + pass.DepartureTime.UTC().Format("02 Jan 2006 15:04 UTC"),
+		"SEAT       " + pass.SeatID,
+	}
+	for i, line := range lines {
+		writeLine(text, i+2, line, ink)
+	}
+
+	scaled := image.Rect(origin.X+margin, origin.Y+margin/2, origin.X+margin+text.Bounds().Dx()*textScale, origin.Y+margin/2+text.Bounds().Dy()*textScale)
+	draw.NearestNeighbor.Scale(dst, scaled, text, text.Bounds(), draw.Over, nil)
+
+	qr, err := qrcode.New(pass.Payload(), qrcode.Medium)
+	if err != nil {
+		return fmt.Errorf("encode boarding pass QR code: %w", err)
+	}
+	qrOrigin := image.Pt(origin.X+passWidth-qrSize-margin, origin.Y+(passHeight-qrSize)/2)
+	draw.Draw(dst, image.Rectangle{Min: qrOrigin, Max: qrOrigin.Add(image.Pt(qrSize, qrSize))}, qr.Image(qrSize), image.Point{}, draw.Src)
+
+	return nil
+}
+
+// writeLine draws s on the given line of a half-size text panel
+func writeLine(dst *image.RGBA, line int, s string, c color.Color) {
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(6, (line+1)*lineHeight-4),
+	}
+	d.DrawString(s)
+}
@@ -0,0 +1,39 @@
+package boardingpass
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestRender(t *testing.T) {
+	pass := domain.BoardingPass{
+		BookingReference: "K7QH2M",
+		FlightNumber:     "FL101",
+		Origin:           "TLV",
+		Destination:      "JFK",
+		DepartureTime:    time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC),
+		SeatID:           "14A",
+		PassengerName:    "Ada Lovelace",
+	}
+
+	data, err := Render([]domain.BoardingPass{pass, pass})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode rendered PNG: %v", err)
+	}
+	if got := img.Bounds().Size(); got.X != passWidth || got.Y != 2*passHeight {
+		t.Errorf("size = %v, want %dx%d", got, passWidth, 2*passHeight)
+	}
+
+	if _, err := Render(nil); err == nil {
+		t.Error("Render(nil) succeeded, want error")
+	}
+}
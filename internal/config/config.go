@@ -1,23 +1,39 @@
 package config
 
 import (
+	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Temporal TemporalConfig
-	Booking  BookingConfig
+	Profile string // CONFIG_PROFILE the defaults were layered from
+
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	Temporal  TemporalConfig
+	Booking   BookingConfig
+	RateLimit RateLimitConfig
+	Webhook   WebhookConfig
+
+	Disruption     DisruptionConfig
+	Reconciliation ReconciliationConfig
+	Departure      DepartureConfig
+	Digest         DigestConfig
+	Sweep          SweepConfig
+	Archive        ArchiveConfig
 }
 
 type ServerConfig struct {
-	Host string
-	Port int
+	Host           string
+	Port           int
+	GRPCPort       int
+	AllowedOrigins []string
+	AdminAPIKeys   []string
 }
 
 type DatabaseConfig struct {
@@ -27,18 +43,28 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	MaxConns int
+
+	// ReplicaHost, when set, is a read replica of the same database that
+	// serves read-heavy listings; everything else stays on the primary
+	ReplicaHost string
+	ReplicaPort int
 }
 
 type RedisConfig struct {
 	Addr     string
 	Password string
 	DB       int
+	PoolSize int
 }
 
 type TemporalConfig struct {
-	Host      string
-	Namespace string
-	TaskQueue string
+	Host            string
+	Namespace       string
+	TaskQueue       string
+	UrgentTaskQueue string // payment activities of orders close to expiry; empty disables
+
+	MetricsLogInterval time.Duration // how often the worker logs its workflow and activity counts; zero disables
 }
 
 type BookingConfig struct {
@@ -46,38 +72,228 @@ type BookingConfig struct {
 	PaymentValidationTimeout time.Duration
 	PaymentMaxRetries        int
 	PaymentFailureRate       float64
+	PaymentChallengeRate     float64 // share of simulated payments the issuer challenges for a one-time code
+	WalletFailureRate        float64 // share of simulated wallet payments failing with a temporary error
+	VoucherDeclineRate       float64 // share of simulated vouchers declined as already redeemed
+	PaymentProvider          string  // "simulator" draws outcomes in the worker; "http" calls the gateway at PaymentGatewayURL
+	PaymentGatewayURL        string
+	PaymentCallbackSecret    string // signs gateway posts to the payment callback; empty rejects them all
+	BookingFeeCents          int64
+	MaxHoldExtensions        int           // times a seat hold can be refreshed without changing seats
+	SignalApplyTimeout       time.Duration // how long a write waits for the workflow to apply its signal
+	UrgentPaymentWindow      time.Duration // hold time left below which payment goes to the urgent queue
+	PaymentWindow            time.Duration // bound on payment processing once payment is signaled; 0 disables
+	SimulationSeed           int64         // seeds simulated payment and disruption outcomes; 0 seeds from the clock
+	GroupHoldDuration        time.Duration // seat hold of a group booking, which takes longer to organise
+	GroupChunkSize           int           // seats a group booking reserves per activity call
+	StatusCacheTTL           time.Duration // how long a queried order status is reused; zero disables
+	HoldReminderBefore       time.Duration // hold time left when the expiry reminder is sent; zero disables
+	ConfirmCompensation      string        // "refund" voids the payment when confirmation fails; "none" only fails the order
+	ActivityCacheTTL         time.Duration // how long a worker reuses read-only activity results; zero disables
+	SalesCloseBefore         time.Duration // time before departure when a flight stops taking bookings
+	PriceCurrency            string        // ISO 4217 code of all prices, used to format them for display
+	PricingCurve             string        // occupancy:multiplier points of dynamic pricing; empty disables it
+	PricingRecompute         string        // "confirmation" reprices a flight as orders confirm; "schedule" reprices periodically
+	PricingInterval          time.Duration // how often the repricing schedule runs in "schedule" mode
+	UpsellWindow             time.Duration // how long the upgrade offer made at payment stays open; zero disables it
+	UpgradeCentsPerCabin     int64         // per seat, for each cabin an accepted upgrade moves up
+	PriorityBoardingCents    int64         // per seat; zero does not offer priority boarding
+	DepositPercent           int           // share of the price an order may pay up front; zero disables deposits
+	DepositBalanceDueBefore  time.Duration // time before departure when a deposit order's balance is charged
+}
+
+// RateLimitConfig bounds request rates on order endpoints; a zero limit disables that check
+type RateLimitConfig struct {
+	PerIP    int
+	PerOrder int
+	Window   time.Duration // time for a drained limit to refill completely
+}
+
+// WebhookConfig tunes the outbound webhook dispatcher run by the worker
+type WebhookConfig struct {
+	PollInterval   time.Duration
+	RequestTimeout time.Duration
+	MaxAttempts    int
+	BatchSize      int
+}
+
+// DisruptionConfig drives the simulated weather/ATC disruption generator run
+// by the worker; a zero probability disables it
+type DisruptionConfig struct {
+	Schedule    string        // cron schedule of generator runs
+	Probability float64       // chance per run that an upcoming flight is disrupted
+	CancelShare float64       // share of disruptions that cancel rather than delay
+	MaxDelay    time.Duration // longest delay one disruption adds
+	Horizon     time.Duration // how far ahead flights can be disrupted
+}
+
+// ReconciliationConfig drives the Temporal Schedule the worker registers for
+// the seat reconciliation workflow; a zero interval removes the schedule
+type ReconciliationConfig struct {
+	Interval time.Duration // time between runs
+	Jitter   time.Duration // random delay added to each run, so workers don't hit Postgres in step
+	Overlap  string        // what a run due while the last is still going does: skip, buffer_one, buffer_all, cancel_other, terminate_other, allow_all
+}
+
+// DepartureConfig drives the Temporal Schedule that starts a departure
+// workflow for each flight about to depart; a zero interval removes it
+type DepartureConfig struct {
+	ScanInterval time.Duration // time between scans for departing flights
+	Horizon      time.Duration // how far ahead of departure a flight's workflow is started
+
+	BumpCompensationCents int64         // paid per seat to orders bumped off an oversold flight; twice this when refunded
+	BumpRebookWindow      time.Duration // how long after an oversold flight a rebooking alternative may depart
+}
+
+// SweepConfig drives the Temporal Schedule that expires orders left holding
+// seats after their workflow was lost; a zero interval removes it
+type SweepConfig struct {
+	Interval  time.Duration // time between sweeps
+	Grace     time.Duration // how long past its hold an order is left to its own workflow
+	BatchSize int           // most orders expired per sweep
+}
+
+// ArchiveConfig drives the Temporal Schedule that moves finished orders past
+// their retention into orders_archive; a zero interval removes it
+type ArchiveConfig struct {
+	Interval  time.Duration // time between archival runs
+	Retention time.Duration // how long a finished order stays in orders
+	BatchSize int           // orders archived per transaction
+}
+
+// DigestConfig drives the Temporal Schedule that sends operators a daily
+// digest of operational stats; an empty address removes the schedule
+type DigestConfig struct {
+	Address     string   // ops address the digest is sent to
+	Channels    []string // notification channels the digest is delivered on
+	Schedule    string   // cron schedule of digest runs
+	SpikeFactor float64  // payment failures over the previous day's flagged as a spike; 0 never flags
 }
 
 // Load reads configuration from environment variables with defaults
 func Load() *Config {
+	name := getEnv("CONFIG_PROFILE", DefaultProfile)
+	profile, ok := profiles[name]
+	if !ok {
+		log.Printf("Warning: unknown CONFIG_PROFILE %q, using built-in defaults", name)
+	}
+	l := loader{profile: profile}
+
 	return &Config{
+		Profile: name,
+
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Port: getEnvInt("SERVER_PORT", 8080),
+			Host:           l.getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:           l.getEnvInt("SERVER_PORT", 8080),
+			GRPCPort:       l.getEnvInt("GRPC_PORT", 9090),
+			AllowedOrigins: l.getEnvList("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173"}),
+			AdminAPIKeys:   l.getEnvList("ADMIN_API_KEYS", nil),
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DATABASE_HOST", "localhost"),
-			Port:     getEnvInt("DATABASE_PORT", 5433),
-			User:     getEnv("DATABASE_USER", "flightapp"),
-			Password: getEnv("DATABASE_PASSWORD", "flightapp"),
-			Name:     getEnv("DATABASE_NAME", "flight_booking"),
-			SSLMode:  getEnv("DATABASE_SSLMODE", "disable"),
+			Host:     l.getEnv("DATABASE_HOST", "localhost"),
+			Port:     l.getEnvInt("DATABASE_PORT", 5433),
+			User:     l.getEnv("DATABASE_USER", "flightapp"),
+			Password: l.getEnv("DATABASE_PASSWORD", "flightapp"),
+			Name:     l.getEnv("DATABASE_NAME", "flight_booking"),
+			SSLMode:  l.getEnv("DATABASE_SSLMODE", "disable"),
+			MaxConns: l.getEnvInt("DATABASE_MAX_CONNS", 25),
+
+			ReplicaHost: l.getEnv("DATABASE_REPLICA_HOST", ""),
+			ReplicaPort: l.getEnvInt("DATABASE_REPLICA_PORT", l.getEnvInt("DATABASE_PORT", 5433)),
 		},
 		Redis: RedisConfig{
-			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnvInt("REDIS_DB", 0),
+			Addr:     l.getEnv("REDIS_ADDR", "localhost:6379"),
+			Password: l.getEnv("REDIS_PASSWORD", ""),
+			DB:       l.getEnvInt("REDIS_DB", 0),
+			PoolSize: l.getEnvInt("REDIS_POOL_SIZE", 10),
 		},
 		Temporal: TemporalConfig{
-			Host:      getEnv("TEMPORAL_HOST", "localhost:7233"),
-			Namespace: getEnv("TEMPORAL_NAMESPACE", "default"),
-			TaskQueue: getEnv("TEMPORAL_TASK_QUEUE", "booking-queue"),
+			Host:            l.getEnv("TEMPORAL_HOST", "localhost:7233"),
+			Namespace:       l.getEnv("TEMPORAL_NAMESPACE", "default"),
+			TaskQueue:       l.getEnv("TEMPORAL_TASK_QUEUE", "booking-queue"),
+			UrgentTaskQueue: l.getEnv("TEMPORAL_URGENT_TASK_QUEUE", "booking-queue-urgent"),
+
+			MetricsLogInterval: l.getEnvDuration("TEMPORAL_METRICS_LOG_INTERVAL", time.Minute),
 		},
 		Booking: BookingConfig{
-			SeatReservationTimeout:   getEnvDuration("SEAT_RESERVATION_TIMEOUT", 15*time.Minute),
-			PaymentValidationTimeout: getEnvDuration("PAYMENT_VALIDATION_TIMEOUT", 10*time.Second),
-			PaymentMaxRetries:        getEnvInt("PAYMENT_MAX_RETRIES", 3),
-			PaymentFailureRate:       getEnvFloat("PAYMENT_FAILURE_RATE", 0.15),
+			SeatReservationTimeout:   l.getEnvDuration("SEAT_RESERVATION_TIMEOUT", 15*time.Minute),
+			PaymentValidationTimeout: l.getEnvDuration("PAYMENT_VALIDATION_TIMEOUT", 10*time.Second),
+			PaymentMaxRetries:        l.getEnvInt("PAYMENT_MAX_RETRIES", 3),
+			PaymentFailureRate:       l.getEnvFloat("PAYMENT_FAILURE_RATE", 0.15),
+			PaymentChallengeRate:     l.getEnvFloat("PAYMENT_CHALLENGE_RATE", 0),
+			WalletFailureRate:        l.getEnvFloat("WALLET_FAILURE_RATE", 0.05),
+			VoucherDeclineRate:       l.getEnvFloat("VOUCHER_DECLINE_RATE", 0.1),
+			PaymentProvider:          l.getEnv("PAYMENT_PROVIDER", "simulator"),
+			PaymentGatewayURL:        l.getEnv("PAYMENT_GATEWAY_URL", "http://localhost:8090"),
+			PaymentCallbackSecret:    l.getEnv("PAYMENT_CALLBACK_SECRET", ""),
+			SimulationSeed:           int64(l.getEnvInt("SIMULATION_SEED", 0)),
+			BookingFeeCents:          int64(l.getEnvInt("BOOKING_FEE_CENTS", 0)),
+			MaxHoldExtensions:        l.getEnvInt("MAX_HOLD_EXTENSIONS", 2),
+			SignalApplyTimeout:       l.getEnvDuration("SIGNAL_APPLY_TIMEOUT", 10*time.Second),
+			UrgentPaymentWindow:      l.getEnvDuration("URGENT_PAYMENT_WINDOW", 3*time.Minute),
+			PaymentWindow:            l.getEnvDuration("PAYMENT_WINDOW", 2*time.Minute),
+			GroupHoldDuration:        l.getEnvDuration("GROUP_HOLD_DURATION", 45*time.Minute),
+			GroupChunkSize:           l.getEnvInt("GROUP_CHUNK_SIZE", 10),
+			StatusCacheTTL:           l.getEnvDuration("STATUS_CACHE_TTL", time.Second),
+			HoldReminderBefore:       l.getEnvDuration("HOLD_REMINDER_BEFORE", 3*time.Minute),
+			ConfirmCompensation:      l.getEnv("CONFIRM_FAILURE_COMPENSATION", "refund"),
+			ActivityCacheTTL:         l.getEnvDuration("ACTIVITY_CACHE_TTL", time.Minute),
+			SalesCloseBefore:         l.getEnvDuration("SALES_CLOSE_BEFORE", 2*time.Hour),
+			PriceCurrency:            l.getEnv("PRICE_CURRENCY", "USD"),
+			PricingCurve:             l.getEnv("PRICING_CURVE", ""),
+			PricingRecompute:         l.getEnv("PRICING_RECOMPUTE", "confirmation"),
+			PricingInterval:          l.getEnvDuration("PRICING_INTERVAL", 15*time.Minute),
+			UpsellWindow:             l.getEnvDuration("UPSELL_WINDOW", 30*time.Second),
+			UpgradeCentsPerCabin:     int64(l.getEnvInt("UPGRADE_CENTS_PER_CABIN", 15000)),
+			PriorityBoardingCents:    int64(l.getEnvInt("PRIORITY_BOARDING_CENTS", 2500)),
+			DepositPercent:           l.getEnvInt("DEPOSIT_PERCENT", 0),
+			DepositBalanceDueBefore:  l.getEnvDuration("DEPOSIT_BALANCE_DUE_BEFORE", 14*24*time.Hour),
+		},
+		RateLimit: RateLimitConfig{
+			PerIP:    l.getEnvInt("RATE_LIMIT_PER_IP", 30),
+			PerOrder: l.getEnvInt("RATE_LIMIT_PER_ORDER", 10),
+			Window:   l.getEnvDuration("RATE_LIMIT_WINDOW", time.Minute),
+		},
+		Webhook: WebhookConfig{
+			PollInterval:   l.getEnvDuration("WEBHOOK_POLL_INTERVAL", 2*time.Second),
+			RequestTimeout: l.getEnvDuration("WEBHOOK_REQUEST_TIMEOUT", 5*time.Second),
+			MaxAttempts:    l.getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BatchSize:      l.getEnvInt("WEBHOOK_BATCH_SIZE", 20),
+		},
+		Disruption: DisruptionConfig{
+			Schedule:    l.getEnv("DISRUPTION_SCHEDULE", "*/15 * * * *"),
+			Probability: l.getEnvFloat("DISRUPTION_PROBABILITY", 0),
+			CancelShare: l.getEnvFloat("DISRUPTION_CANCEL_SHARE", 0.1),
+			MaxDelay:    l.getEnvDuration("DISRUPTION_MAX_DELAY", 3*time.Hour),
+			Horizon:     l.getEnvDuration("DISRUPTION_HORIZON", 48*time.Hour),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval: l.getEnvDuration("RECONCILIATION_INTERVAL", 10*time.Minute),
+			Jitter:   l.getEnvDuration("RECONCILIATION_JITTER", 30*time.Second),
+			Overlap:  l.getEnv("RECONCILIATION_OVERLAP", "skip"),
+		},
+		Departure: DepartureConfig{
+			ScanInterval: l.getEnvDuration("DEPARTURE_SCAN_INTERVAL", 15*time.Minute),
+			Horizon:      l.getEnvDuration("DEPARTURE_SCAN_HORIZON", 24*time.Hour),
+
+			BumpCompensationCents: int64(l.getEnvInt("OVERBOOKING_COMPENSATION_CENTS", 30000)),
+			BumpRebookWindow:      l.getEnvDuration("OVERBOOKING_REBOOK_WINDOW", 24*time.Hour),
+		},
+		Sweep: SweepConfig{
+			Interval:  l.getEnvDuration("STALE_ORDER_SWEEP_INTERVAL", 15*time.Minute),
+			Grace:     l.getEnvDuration("STALE_ORDER_GRACE", 5*time.Minute),
+			BatchSize: l.getEnvInt("STALE_ORDER_BATCH_SIZE", 100),
+		},
+		Archive: ArchiveConfig{
+			Interval:  l.getEnvDuration("ORDER_ARCHIVE_INTERVAL", 24*time.Hour),
+			Retention: l.getEnvDuration("ORDER_RETENTION", 30*24*time.Hour),
+			BatchSize: l.getEnvInt("ORDER_ARCHIVE_BATCH_SIZE", 500),
+		},
+		Digest: DigestConfig{
+			Address:     l.getEnv("OPS_DIGEST_ADDRESS", ""),
+			Channels:    l.getEnvList("OPS_DIGEST_CHANNELS", []string{"email"}),
+			Schedule:    l.getEnv("OPS_DIGEST_SCHEDULE", "0 7 * * *"),
+			SpikeFactor: l.getEnvFloat("OPS_DIGEST_SPIKE_FACTOR", 2),
 		},
 	}
 }
@@ -87,6 +303,18 @@ func (c *DatabaseConfig) DatabaseURL() string {
 	return "postgres://" + c.User + ":" + c.Password + "@" + c.Host + ":" + strconv.Itoa(c.Port) + "/" + c.Name + "?sslmode=" + c.SSLMode
 }
 
+// Replica returns the settings for connecting to the read replica, and false
+// when none is configured
+func (c *DatabaseConfig) Replica() (DatabaseConfig, bool) {
+	if c.ReplicaHost == "" {
+		return DatabaseConfig{}, false
+	}
+	replica := *c
+	replica.Host, replica.Port = c.ReplicaHost, c.ReplicaPort
+	replica.ReplicaHost, replica.ReplicaPort = "", 0
+	return replica, true
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -94,8 +322,30 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-func getEnvInt(key string, defaultValue int) int {
+// loader reads settings from the environment, falling back to the active
+// profile's defaults before the built-in ones
+type loader struct {
+	profile Profile
+}
+
+// lookup returns the explicit environment value of key, or else the
+// profile's default for it
+func (l loader) lookup(key string) string {
 	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return l.profile[key]
+}
+
+func (l loader) getEnv(key, defaultValue string) string {
+	if value := l.lookup(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func (l loader) getEnvInt(key string, defaultValue int) int {
+	if value := l.lookup(key); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
 			return intVal
 		}
@@ -103,8 +353,8 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
-func getEnvFloat(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
+func (l loader) getEnvFloat(key string, defaultValue float64) float64 {
+	if value := l.lookup(key); value != "" {
 		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
 			return floatVal
 		}
@@ -112,11 +362,24 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	return defaultValue
 }
 
-func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
+func (l loader) getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := l.lookup(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
 			return duration
 		}
 	}
 	return defaultValue
 }
+
+func (l loader) getEnvList(key string, defaultValue []string) []string {
+	if value := l.lookup(key); value != "" {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return defaultValue
+}
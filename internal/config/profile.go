@@ -0,0 +1,59 @@
+package config
+
+// DefaultProfile is the profile loaded when CONFIG_PROFILE is unset
+const DefaultProfile = "dev"
+
+// Profile holds the defaults a named environment layers over the built-in
+// ones, keyed by environment variable. An explicitly set variable always
+// wins over its profile default.
+type Profile map[string]string
+
+// profiles are the named environments CONFIG_PROFILE selects from
+var profiles = map[string]Profile{
+	// dev keeps the built-in defaults: simulated payment failures, no
+	// disruptions, small pools
+	"dev": {},
+
+	// demo makes the interesting paths show up within a short session
+	"demo": {
+		"PAYMENT_FAILURE_RATE":    "0.25",
+		"HOLD_REMINDER_BEFORE":    "1m",
+		"UPSELL_WINDOW":           "45s",
+		"PRICING_CURVE":           "0.5:1.1,0.8:1.3,0.95:1.6",
+		"DISRUPTION_PROBABILITY":  "0.2",
+		"DISRUPTION_SCHEDULE":     "*/5 * * * *",
+		"RECONCILIATION_INTERVAL": "2m",
+		"DEPARTURE_SCAN_INTERVAL": "5m",
+	},
+
+	// loadtest removes simulated failures, chaos and customer think time, and
+	// widens the limits and pools a load generator would otherwise hit
+	"loadtest": {
+		"PAYMENT_FAILURE_RATE":   "0",
+		"WALLET_FAILURE_RATE":    "0",
+		"VOUCHER_DECLINE_RATE":   "0",
+		"DISRUPTION_PROBABILITY": "0",
+		"UPSELL_WINDOW":          "0s",
+		"HOLD_REMINDER_BEFORE":   "0s",
+		"RATE_LIMIT_PER_IP":      "0",
+		"RATE_LIMIT_PER_ORDER":   "0",
+		"SIGNAL_APPLY_TIMEOUT":   "30s",
+		"STATUS_CACHE_TTL":       "2s",
+		"ACTIVITY_CACHE_TTL":     "5m",
+		"WEBHOOK_BATCH_SIZE":     "100",
+		"DATABASE_MAX_CONNS":     "100",
+		"REDIS_POOL_SIZE":        "100",
+	},
+
+	// prod turns off everything simulated and expects TLS to the database
+	"prod": {
+		"PAYMENT_FAILURE_RATE":    "0",
+		"WALLET_FAILURE_RATE":     "0",
+		"VOUCHER_DECLINE_RATE":    "0",
+		"DISRUPTION_PROBABILITY":  "0",
+		"DATABASE_SSLMODE":        "require",
+		"DATABASE_MAX_CONNS":      "50",
+		"REDIS_POOL_SIZE":         "50",
+		"RECONCILIATION_INTERVAL": "5m",
+	},
+}
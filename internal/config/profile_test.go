@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_ProfileLayersDefaults(t *testing.T) {
+	t.Setenv("CONFIG_PROFILE", "loadtest")
+	t.Setenv("REDIS_POOL_SIZE", "")
+	t.Setenv("DATABASE_MAX_CONNS", "40")
+	t.Setenv("PAYMENT_FAILURE_RATE", "")
+	t.Setenv("SEAT_RESERVATION_TIMEOUT", "")
+
+	cfg := Load()
+
+	assert.Equal(t, "loadtest", cfg.Profile)
+	assert.Equal(t, 100, cfg.Redis.PoolSize, "profile default replaces the built-in one")
+	assert.Equal(t, 40, cfg.Database.MaxConns, "explicit variable wins over the profile")
+	assert.Equal(t, 0.0, cfg.Booking.PaymentFailureRate)
+	assert.Equal(t, 15*time.Minute, cfg.Booking.SeatReservationTimeout, "unset by the profile keeps the built-in default")
+}
+
+func TestLoad_UnknownProfileUsesBuiltInDefaults(t *testing.T) {
+	t.Setenv("CONFIG_PROFILE", "staging")
+	t.Setenv("REDIS_POOL_SIZE", "")
+
+	cfg := Load()
+
+	assert.Equal(t, "staging", cfg.Profile)
+	assert.Equal(t, 10, cfg.Redis.PoolSize)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Sanitized returns the settings worth showing to API clients, keyed by
+// environment variable. Passwords and API keys are never included; admin
+// keys are reported only as a count.
+func (c *Config) Sanitized() map[string]string {
+	return map[string]string{
+		"CONFIG_PROFILE": c.Profile,
+
+		"SERVER_PORT":          strconv.Itoa(c.Server.Port),
+		"GRPC_PORT":            strconv.Itoa(c.Server.GRPCPort),
+		"CORS_ALLOWED_ORIGINS": strings.Join(c.Server.AllowedOrigins, ","),
+		"ADMIN_API_KEYS":       strconv.Itoa(len(c.Server.AdminAPIKeys)) + " configured",
+
+		"DATABASE_HOST":         c.Database.Host + ":" + strconv.Itoa(c.Database.Port),
+		"DATABASE_NAME":         c.Database.Name,
+		"DATABASE_REPLICA_HOST": replicaSetting(c.Database),
+		"REDIS_ADDR":            c.Redis.Addr,
+
+		"DATABASE_MAX_CONNS": strconv.Itoa(c.Database.MaxConns),
+		"REDIS_POOL_SIZE":    strconv.Itoa(c.Redis.PoolSize),
+
+		"TEMPORAL_HOST":       c.Temporal.Host,
+		"TEMPORAL_NAMESPACE":  c.Temporal.Namespace,
+		"TEMPORAL_TASK_QUEUE": c.Temporal.TaskQueue,
+
+		"TEMPORAL_URGENT_TASK_QUEUE":    c.Temporal.UrgentTaskQueue,
+		"TEMPORAL_METRICS_LOG_INTERVAL": c.Temporal.MetricsLogInterval.String(),
+
+		"SEAT_RESERVATION_TIMEOUT":     c.Booking.SeatReservationTimeout.String(),
+		"PAYMENT_VALIDATION_TIMEOUT":   c.Booking.PaymentValidationTimeout.String(),
+		"PAYMENT_MAX_RETRIES":          strconv.Itoa(c.Booking.PaymentMaxRetries),
+		"PAYMENT_FAILURE_RATE":         strconv.FormatFloat(c.Booking.PaymentFailureRate, 'f', -1, 64),
+		"PAYMENT_CHALLENGE_RATE":       strconv.FormatFloat(c.Booking.PaymentChallengeRate, 'f', -1, 64),
+		"WALLET_FAILURE_RATE":          strconv.FormatFloat(c.Booking.WalletFailureRate, 'f', -1, 64),
+		"VOUCHER_DECLINE_RATE":         strconv.FormatFloat(c.Booking.VoucherDeclineRate, 'f', -1, 64),
+		"PAYMENT_PROVIDER":             c.Booking.PaymentProvider,
+		"PAYMENT_GATEWAY_URL":          c.Booking.PaymentGatewayURL,
+		"PAYMENT_CALLBACK_SECRET":      secretSetting(c.Booking.PaymentCallbackSecret),
+		"SIMULATION_SEED":              seedSetting(c.Booking.SimulationSeed),
+		"BOOKING_FEE_CENTS":            strconv.FormatInt(c.Booking.BookingFeeCents, 10),
+		"MAX_HOLD_EXTENSIONS":          strconv.Itoa(c.Booking.MaxHoldExtensions),
+		"SIGNAL_APPLY_TIMEOUT":         c.Booking.SignalApplyTimeout.String(),
+		"URGENT_PAYMENT_WINDOW":        c.Booking.UrgentPaymentWindow.String(),
+		"PAYMENT_WINDOW":               c.Booking.PaymentWindow.String(),
+		"GROUP_HOLD_DURATION":          c.Booking.GroupHoldDuration.String(),
+		"GROUP_CHUNK_SIZE":             strconv.Itoa(c.Booking.GroupChunkSize),
+		"STATUS_CACHE_TTL":             c.Booking.StatusCacheTTL.String(),
+		"HOLD_REMINDER_BEFORE":         c.Booking.HoldReminderBefore.String(),
+		"CONFIRM_FAILURE_COMPENSATION": c.Booking.ConfirmCompensation,
+		"ACTIVITY_CACHE_TTL":           c.Booking.ActivityCacheTTL.String(),
+		"SALES_CLOSE_BEFORE":           c.Booking.SalesCloseBefore.String(),
+		"PRICE_CURRENCY":               c.Booking.PriceCurrency,
+		"PRICING_CURVE":                c.Booking.PricingCurve,
+		"PRICING_RECOMPUTE":            c.Booking.PricingRecompute,
+		"PRICING_INTERVAL":             c.Booking.PricingInterval.String(),
+		"UPSELL_WINDOW":                c.Booking.UpsellWindow.String(),
+		"UPGRADE_CENTS_PER_CABIN":      strconv.FormatInt(c.Booking.UpgradeCentsPerCabin, 10),
+		"PRIORITY_BOARDING_CENTS":      strconv.FormatInt(c.Booking.PriorityBoardingCents, 10),
+		"DEPOSIT_PERCENT":              strconv.Itoa(c.Booking.DepositPercent),
+		"DEPOSIT_BALANCE_DUE_BEFORE":   c.Booking.DepositBalanceDueBefore.String(),
+
+		"RATE_LIMIT_PER_IP":    strconv.Itoa(c.RateLimit.PerIP),
+		"RATE_LIMIT_PER_ORDER": strconv.Itoa(c.RateLimit.PerOrder),
+		"RATE_LIMIT_WINDOW":    c.RateLimit.Window.String(),
+
+		"DISRUPTION_SCHEDULE":     c.Disruption.Schedule,
+		"DISRUPTION_PROBABILITY":  strconv.FormatFloat(c.Disruption.Probability, 'f', -1, 64),
+		"DISRUPTION_CANCEL_SHARE": strconv.FormatFloat(c.Disruption.CancelShare, 'f', -1, 64),
+		"DISRUPTION_MAX_DELAY":    c.Disruption.MaxDelay.String(),
+		"DISRUPTION_HORIZON":      c.Disruption.Horizon.String(),
+
+		"RECONCILIATION_INTERVAL": c.Reconciliation.Interval.String(),
+		"RECONCILIATION_JITTER":   c.Reconciliation.Jitter.String(),
+		"RECONCILIATION_OVERLAP":  c.Reconciliation.Overlap,
+
+		"DEPARTURE_SCAN_INTERVAL": c.Departure.ScanInterval.String(),
+		"DEPARTURE_SCAN_HORIZON":  c.Departure.Horizon.String(),
+
+		"OVERBOOKING_COMPENSATION_CENTS": strconv.FormatInt(c.Departure.BumpCompensationCents, 10),
+		"OVERBOOKING_REBOOK_WINDOW":      c.Departure.BumpRebookWindow.String(),
+
+		"STALE_ORDER_SWEEP_INTERVAL": c.Sweep.Interval.String(),
+		"STALE_ORDER_GRACE":          c.Sweep.Grace.String(),
+		"STALE_ORDER_BATCH_SIZE":     strconv.Itoa(c.Sweep.BatchSize),
+
+		"ORDER_ARCHIVE_INTERVAL":   c.Archive.Interval.String(),
+		"ORDER_RETENTION":          c.Archive.Retention.String(),
+		"ORDER_ARCHIVE_BATCH_SIZE": strconv.Itoa(c.Archive.BatchSize),
+
+		"OPS_DIGEST_ADDRESS":      c.Digest.Address,
+		"OPS_DIGEST_CHANNELS":     strings.Join(c.Digest.Channels, ","),
+		"OPS_DIGEST_SCHEDULE":     c.Digest.Schedule,
+		"OPS_DIGEST_SPIKE_FACTOR": strconv.FormatFloat(c.Digest.SpikeFactor, 'f', -1, 64),
+	}
+}
+
+// seedSetting reports SIMULATION_SEED, which seeds from the clock when unset
+func seedSetting(seed int64) string {
+	if seed == 0 {
+		return "unset (seeded from the clock)"
+	}
+	return strconv.FormatInt(seed, 10)
+}
+
+// secretSetting reports whether a secret is set without showing it
+func secretSetting(secret string) string {
+	if secret == "" {
+		return "not set"
+	}
+	return "configured"
+}
+
+// replicaSetting shows where listings are read from
+func replicaSetting(db DatabaseConfig) string {
+	if db.ReplicaHost == "" {
+		return "not set (reads from the primary)"
+	}
+	return db.ReplicaHost + ":" + strconv.Itoa(db.ReplicaPort)
+}
@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS price_breakdown, DROP COLUMN IF EXISTS quote_id;
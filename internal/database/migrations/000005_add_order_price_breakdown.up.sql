@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Price components locked when the order was quoted
+ALTER TABLE orders
+    ADD COLUMN quote_id VARCHAR(64),
+    ADD COLUMN price_breakdown JSONB NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+UPDATE seats SET status = 'available' WHERE status = 'blocked';
+ALTER TABLE seats DROP CONSTRAINT seats_status_check;
+ALTER TABLE seats ADD CONSTRAINT seats_status_check CHECK (status IN ('available', 'reserved', 'booked'));
+
+COMMIT;
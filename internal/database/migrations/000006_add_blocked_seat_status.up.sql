@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Admins can take individual seats out of sale
+ALTER TABLE seats DROP CONSTRAINT seats_status_check;
+ALTER TABLE seats ADD CONSTRAINT seats_status_check CHECK (status IN ('available', 'reserved', 'booked', 'blocked'));
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS seat_swap_audit;
+DROP TABLE IF EXISTS seat_swap_offers;
+
+COMMIT;
@@ -0,0 +1,40 @@
+BEGIN;
+
+-- Confirmed passengers can offer their seat for a swap with another confirmed order
+CREATE TABLE IF NOT EXISTS seat_swap_offers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    flight_id UUID NOT NULL REFERENCES flights(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    seat_id VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    accepted_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    accepted_seat_id VARCHAR(10),
+    failure_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT seat_swap_offers_status_check CHECK (status IN (
+        'OPEN', 'MATCHED', 'COMPLETED', 'CANCELLED', 'FAILED'
+    ))
+);
+
+-- A seat can only be on offer once at a time
+CREATE UNIQUE INDEX idx_seat_swap_offers_active_seat ON seat_swap_offers(flight_id, seat_id)
+    WHERE status IN ('OPEN', 'MATCHED');
+CREATE INDEX idx_seat_swap_offers_open ON seat_swap_offers(flight_id) WHERE status = 'OPEN';
+
+-- Audit trail of every completed exchange
+CREATE TABLE IF NOT EXISTS seat_swap_audit (
+    id BIGSERIAL PRIMARY KEY,
+    offer_id UUID NOT NULL REFERENCES seat_swap_offers(id) ON DELETE CASCADE,
+    flight_id UUID NOT NULL,
+    offering_order_id UUID NOT NULL,
+    offering_seat_id VARCHAR(10) NOT NULL,
+    accepting_order_id UUID NOT NULL,
+    accepting_seat_id VARCHAR(10) NOT NULL,
+    swapped_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_seat_swap_audit_flight ON seat_swap_audit(flight_id, swapped_at);
+
+COMMIT;
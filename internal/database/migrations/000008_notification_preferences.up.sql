@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Per-order notification preferences; absence of a row means defaults apply
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    channels TEXT[] NOT NULL DEFAULT '{email}',
+    categories TEXT[] NOT NULL DEFAULT '{booking,reminder}',
+    unsubscribe_token VARCHAR(64) NOT NULL,
+    unsubscribed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT notification_preferences_token_unique UNIQUE (unsubscribe_token)
+);
+
+COMMIT;
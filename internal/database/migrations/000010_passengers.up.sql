@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Travellers on an order; seat_id is NULL when a seat change left a passenger unseated
+CREATE TABLE IF NOT EXISTS passengers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    seat_id VARCHAR(10),
+    position INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    document_number VARCHAR(20) NOT NULL,
+    email VARCHAR(254) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT passengers_order_seat_unique UNIQUE (order_id, seat_id),
+    CONSTRAINT passengers_order_position_unique UNIQUE (order_id, position)
+);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- PNR-style reference assigned when the order is confirmed
+ALTER TABLE orders
+    ADD COLUMN booking_reference CHAR(6),
+    ADD CONSTRAINT orders_booking_reference_unique UNIQUE (booking_reference);
+
+COMMIT;
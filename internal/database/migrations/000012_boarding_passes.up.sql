@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Rendered boarding pass image per confirmed order, one panel per seat
+CREATE TABLE IF NOT EXISTS boarding_passes (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    image BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
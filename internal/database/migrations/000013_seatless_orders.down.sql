@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_orders_cabin_seats;
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_cabin_seats_check,
+    DROP COLUMN IF EXISTS cabin_seats,
+    DROP COLUMN IF EXISTS seatless;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Seatless orders buy cabin capacity and get seats at check-in. cabin_seats
+-- is the capacity an order holds without seats; check-in moves it to seats.
+ALTER TABLE orders
+    ADD COLUMN seatless BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN cabin_seats INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT orders_cabin_seats_check CHECK (cabin_seats >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_orders_cabin_seats ON orders(flight_id) WHERE cabin_seats > 0;
+
+COMMIT;
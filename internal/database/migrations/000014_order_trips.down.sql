@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_orders_trip;
+ALTER TABLE orders DROP COLUMN IF EXISTS trip_id;
+
+COMMIT;
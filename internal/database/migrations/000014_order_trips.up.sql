@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Orders booked together as the legs of a connecting itinerary share a trip
+ALTER TABLE orders ADD COLUMN trip_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_orders_trip ON orders(trip_id) WHERE trip_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE seats DROP CONSTRAINT IF EXISTS seats_cabin_class_check;
+ALTER TABLE seats DROP COLUMN IF EXISTS cabin_class;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Each seat belongs to a cabin; rows of different cabins can differ in width
+ALTER TABLE seats ADD COLUMN cabin_class VARCHAR(20) NOT NULL DEFAULT 'economy';
+ALTER TABLE seats ADD CONSTRAINT seats_cabin_class_check CHECK (cabin_class IN ('economy', 'premium_economy', 'business', 'first'));
+
+-- The demo long-haul flights get a business cabin in their first two rows
+UPDATE seats SET cabin_class = 'business'
+WHERE row_num <= 2
+  AND flight_id IN (SELECT id FROM flights WHERE flight_number IN ('FL101', 'FL102'));
+
+COMMIT;
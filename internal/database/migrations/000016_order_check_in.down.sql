@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS checked_in_at;
+
+COMMIT;
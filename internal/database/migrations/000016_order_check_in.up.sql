@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Set when online check-in completes and the boarding document is issued
+ALTER TABLE orders ADD COLUMN checked_in_at TIMESTAMPTZ;
+
+COMMIT;
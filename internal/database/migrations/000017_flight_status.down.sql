@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE flights DROP CONSTRAINT IF EXISTS flights_status_check;
+ALTER TABLE flights DROP COLUMN IF EXISTS delay_minutes;
+ALTER TABLE flights DROP COLUMN IF EXISTS status;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Operational status; delays shift departure and arrival and are summed here
+ALTER TABLE flights ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'scheduled';
+ALTER TABLE flights ADD COLUMN delay_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE flights ADD CONSTRAINT flights_status_check CHECK (status IN ('scheduled', 'delayed', 'cancelled'));
+
+COMMIT;
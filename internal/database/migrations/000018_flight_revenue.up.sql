@@ -0,0 +1,22 @@
+BEGIN;
+
+-- Revenue recognized per confirmed order, so per-flight reporting never scans
+-- orders. A refund sets reversed_at rather than deleting the row.
+CREATE TABLE IF NOT EXISTS flight_revenue (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    flight_id UUID NOT NULL REFERENCES flights(id),
+    amount_cents BIGINT NOT NULL,
+    recognized_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reversed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_revenue_flight_id ON flight_revenue(flight_id);
+
+-- Orders confirmed before this migration
+INSERT INTO flight_revenue (order_id, flight_id, amount_cents, recognized_at)
+SELECT id, flight_id, total_price_cents, COALESCE(confirmed_at, updated_at)
+FROM orders
+WHERE status = 'CONFIRMED'
+ON CONFLICT (order_id) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Paid orders whose confirmation failed end refunded rather than failed
+ALTER TABLE orders DROP CONSTRAINT orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN (
+    'CREATED', 'SEATS_RESERVED', 'PAYMENT_PENDING',
+    'PAYMENT_PROCESSING', 'CONFIRMED', 'FAILED', 'EXPIRED', 'PAYMENT_REFUNDED'
+));
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS seats_counted;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Set once an order's seats are taken off its flight's available_seats, so the
+-- count and its compensation each apply at most once however often they retry
+ALTER TABLE orders ADD COLUMN seats_counted BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
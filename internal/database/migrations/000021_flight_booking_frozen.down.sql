@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE flights DROP COLUMN IF EXISTS booking_frozen;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Set by an admin to stop new bookings and seat changes on a flight while it
+-- is under maintenance or investigation
+ALTER TABLE flights ADD COLUMN booking_frozen BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
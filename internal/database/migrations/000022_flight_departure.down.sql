@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS flight_manifests;
+UPDATE flights SET status = 'scheduled' WHERE status = 'departed';
+ALTER TABLE flights DROP CONSTRAINT flights_status_check;
+ALTER TABLE flights ADD CONSTRAINT flights_status_check CHECK (status IN ('scheduled', 'delayed', 'cancelled'));
+ALTER TABLE flights DROP COLUMN IF EXISTS sales_closed_at;
+
+COMMIT;
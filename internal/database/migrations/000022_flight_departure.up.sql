@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Flights are finalized before departure: sales close at departure minus
+-- SALES_CLOSE_BEFORE, and the flight is marked departed once it leaves
+ALTER TABLE flights ADD COLUMN sales_closed_at TIMESTAMPTZ;
+ALTER TABLE flights DROP CONSTRAINT flights_status_check;
+ALTER TABLE flights ADD CONSTRAINT flights_status_check CHECK (status IN ('scheduled', 'delayed', 'cancelled', 'departed'));
+
+-- Passenger and seat manifest of a departed flight, one row per flight
+CREATE TABLE IF NOT EXISTS flight_manifests (
+    flight_id UUID PRIMARY KEY REFERENCES flights(id) ON DELETE CASCADE,
+    manifest JSONB NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS flight_bumps;
+UPDATE flights SET available_seats = 0 WHERE available_seats < 0;
+ALTER TABLE flights DROP CONSTRAINT flights_seats_check;
+ALTER TABLE flights ADD CONSTRAINT flights_seats_check CHECK (available_seats >= 0 AND available_seats <= total_seats);
+ALTER TABLE flights DROP COLUMN IF EXISTS overbooking_percent;
+
+COMMIT;
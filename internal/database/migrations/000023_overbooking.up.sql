@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Flights may sell seatless capacity beyond their seats, by up to
+-- overbooking_percent of total_seats, so available_seats can go negative
+ALTER TABLE flights ADD COLUMN overbooking_percent INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE flights ADD CONSTRAINT flights_overbooking_percent_check CHECK (overbooking_percent BETWEEN 0 AND 50);
+ALTER TABLE flights DROP CONSTRAINT flights_seats_check;
+ALTER TABLE flights ADD CONSTRAINT flights_seats_check
+    CHECK (available_seats >= -(total_seats * overbooking_percent / 100) AND available_seats <= total_seats);
+
+-- Orders bumped off an oversold flight before departure: moved to
+-- alternative_flight_id, or refunded when there was none
+CREATE TABLE IF NOT EXISTS flight_bumps (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    flight_id UUID NOT NULL REFERENCES flights(id),
+    seats INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    alternative_flight_id UUID REFERENCES flights(id),
+    compensation_cents BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT flight_bumps_status_check CHECK (status IN ('rebooked', 'refunded'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_bumps_flight_id ON flight_bumps(flight_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS flight_price_history;
+
+ALTER TABLE flights DROP CONSTRAINT IF EXISTS flights_base_price_check;
+ALTER TABLE flights DROP COLUMN IF EXISTS base_price_cents;
+
+COMMIT;
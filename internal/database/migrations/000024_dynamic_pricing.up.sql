@@ -0,0 +1,28 @@
+BEGIN;
+
+-- The fare dynamic pricing scales by occupancy; price_cents is what is sold
+-- now. Flights inserted without one take their current price_cents the first
+-- time they are repriced.
+ALTER TABLE flights ADD COLUMN base_price_cents BIGINT;
+UPDATE flights SET base_price_cents = price_cents;
+ALTER TABLE flights ADD CONSTRAINT flights_base_price_check CHECK (base_price_cents >= 0);
+
+-- Every change dynamic pricing made to a flight's fare and the occupancy
+-- behind it, so a quote can be traced to the price in force when it was made
+CREATE TABLE IF NOT EXISTS flight_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    flight_id UUID NOT NULL REFERENCES flights(id) ON DELETE CASCADE,
+    price_cents BIGINT NOT NULL,
+    previous_price_cents BIGINT NOT NULL,
+    base_price_cents BIGINT NOT NULL,
+    total_seats INTEGER NOT NULL,
+    available_seats INTEGER NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    order_id UUID,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT flight_price_history_reason_check CHECK (reason IN ('confirmation', 'schedule'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_flight_price_history_flight_id ON flight_price_history(flight_id, changed_at);
+
+COMMIT;
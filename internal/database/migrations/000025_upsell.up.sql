@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Priority boarding bought with the upsell offered at payment time; a cabin
+-- upgrade needs no column since it only moves the order's seats
+ALTER TABLE orders ADD COLUMN priority_boarding BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
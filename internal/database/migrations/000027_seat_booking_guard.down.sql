@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE seats
+    DROP CONSTRAINT IF EXISTS seats_order_flight_fkey,
+    DROP CONSTRAINT IF EXISTS seats_held_order_check;
+DROP INDEX IF EXISTS orders_id_flight_unique;
+
+COMMIT;
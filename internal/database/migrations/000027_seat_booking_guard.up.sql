@@ -0,0 +1,17 @@
+BEGIN;
+
+-- A database backstop beneath the Redis seat locks. The seats primary key
+-- already keeps one row per flight and seat, so a seat can only ever name one
+-- order; these constraints make sure that order is real. A reserved or booked
+-- seat must name an order, and that order must be on the seat's flight.
+-- Existing rows are left to fbctl verify; the constraints hold for every
+-- write from now on.
+CREATE UNIQUE INDEX orders_id_flight_unique ON orders (id, flight_id);
+
+ALTER TABLE seats
+    ADD CONSTRAINT seats_held_order_check
+        CHECK (status IN ('available', 'blocked') OR order_id IS NOT NULL) NOT VALID,
+    ADD CONSTRAINT seats_order_flight_fkey
+        FOREIGN KEY (order_id, flight_id) REFERENCES orders (id, flight_id) NOT VALID;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_failed;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS failed_at;
+
+DROP TABLE IF EXISTS seat_lock_repairs;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Orphaned seat locks released by reconciliation, counted by the ops digest
+CREATE TABLE IF NOT EXISTS seat_lock_repairs (
+    id BIGSERIAL PRIMARY KEY,
+    flight_id UUID NOT NULL,
+    seat_id VARCHAR(10) NOT NULL,
+    order_id TEXT NOT NULL,
+    repaired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_seat_lock_repairs_repaired_at ON seat_lock_repairs(repaired_at);
+
+-- When a delivery was given up on, so dead letters can be counted per day
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+CREATE INDEX idx_webhook_deliveries_failed ON webhook_deliveries(failed_at) WHERE status = 'FAILED';
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- Refunds of confirmed orders, one row per attempt at the payment provider
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount_cents BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT refunds_status_check CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED')),
+    CONSTRAINT refunds_amount_check CHECK (amount_cents >= 0)
+);
+
+CREATE INDEX idx_refunds_order ON refunds(order_id, created_at);
+
+-- An order is refunded at most once; failed attempts may be retried
+CREATE UNIQUE INDEX idx_refunds_order_active ON refunds(order_id) WHERE status <> 'FAILED';
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_orders_balance_due;
+ALTER TABLE orders DROP COLUMN IF EXISTS balance_paid_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS balance_due_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS balance_cents;
+ALTER TABLE orders DROP COLUMN IF EXISTS deposit_cents;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Orders confirmed on a deposit, with the balance charged ahead of departure
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS deposit_cents BIGINT;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS balance_cents BIGINT;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS balance_due_at TIMESTAMPTZ;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS balance_paid_at TIMESTAMPTZ;
+
+CREATE INDEX idx_orders_balance_due ON orders(balance_due_at)
+    WHERE balance_due_at IS NOT NULL AND balance_paid_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+-- Payment charges, persisted under their idempotency key before the payment
+-- provider is called, so a retried attempt finishes a charge the provider may
+-- already have taken instead of taking it twice. A trip's payment is charged
+-- under the trip's ID, so order_id has no foreign key.
+CREATE TABLE IF NOT EXISTS payment_charges (
+    idempotency_key TEXT PRIMARY KEY,
+    order_id UUID NOT NULL,
+    scope TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'STARTED',
+    authorization_id TEXT,
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT payment_charges_status_check CHECK (status IN (
+        'STARTED', 'AUTHORIZED', 'PENDING', 'CHALLENGED', 'CAPTURED', 'DECLINED', 'VOIDED'
+    ))
+);
+
+CREATE INDEX idx_payment_charges_scope ON payment_charges(order_id, scope, attempt);
+CREATE INDEX idx_payment_charges_authorization ON payment_charges(authorization_id)
+    WHERE authorization_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS payment_charges (
+    idempotency_key TEXT PRIMARY KEY,
+    order_id UUID NOT NULL,
+    scope TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'STARTED',
+    authorization_id TEXT,
+    message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT payment_charges_status_check CHECK (status IN (
+        'STARTED', 'AUTHORIZED', 'PENDING', 'CHALLENGED', 'CAPTURED', 'DECLINED', 'VOIDED'
+    ))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_charges_scope ON payment_charges(order_id, scope, attempt);
+CREATE INDEX IF NOT EXISTS idx_payment_charges_authorization ON payment_charges(authorization_id)
+    WHERE authorization_id IS NOT NULL;
+
+-- Attempts another resumed share its key; the resuming attempt is kept
+INSERT INTO payment_charges (idempotency_key, order_id, scope, attempt, amount_cents, status, authorization_id, message, created_at, updated_at)
+SELECT idempotency_key, order_id, scope, attempt, amount_cents,
+       CASE WHEN status = 'FAILED' THEN 'STARTED' ELSE status END,
+       provider_ref, message, created_at, updated_at
+FROM payments
+WHERE resumed_by_attempt IS NULL;
+
+DROP TABLE IF EXISTS payments;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN;
+
+-- Payment attempts, failed ones included, kept as an audit trail. Each is
+-- recorded under its idempotency key before the payment provider is called;
+-- an attempt that finishes a lost earlier one shares its key and the earlier
+-- attempt records which one resumed it. This replaces payment_charges, which
+-- kept one row per key.
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    idempotency_key TEXT NOT NULL,
+    order_id UUID NOT NULL,
+    scope TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'STARTED',
+    provider_ref TEXT,
+    message TEXT NOT NULL DEFAULT '',
+    error TEXT,
+    resumed_by_attempt INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT payments_status_check CHECK (status IN (
+        'STARTED', 'FAILED', 'AUTHORIZED', 'PENDING', 'CHALLENGED', 'CAPTURED', 'DECLINED', 'VOIDED'
+    ))
+);
+
+CREATE UNIQUE INDEX idx_payments_attempt ON payments(order_id, scope, attempt);
+CREATE INDEX idx_payments_order ON payments(order_id, created_at);
+CREATE INDEX idx_payments_provider_ref ON payments(provider_ref) WHERE provider_ref IS NOT NULL;
+
+INSERT INTO payments (idempotency_key, order_id, scope, attempt, amount_cents, status, provider_ref, message, created_at, updated_at)
+SELECT idempotency_key, order_id, scope, attempt, amount_cents, status, authorization_id, message, created_at, updated_at
+FROM payment_charges;
+
+DROP TABLE IF EXISTS payment_charges;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS method;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_payment_method_check;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_method;
+UPDATE orders SET payment_code = NULL WHERE LENGTH(payment_code) > 5;
+ALTER TABLE orders ALTER COLUMN payment_code TYPE VARCHAR(5);
+
+COMMIT;
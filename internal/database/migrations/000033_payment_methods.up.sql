@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Orders pay by card, wallet or voucher. Wallet tokens and voucher codes are
+-- longer than the 5-digit card codes, and an order keeps the method and code
+-- it was paid with so its balance and refunds go back through them.
+ALTER TABLE orders ALTER COLUMN payment_code TYPE VARCHAR(64);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_method VARCHAR(20);
+ALTER TABLE orders ADD CONSTRAINT orders_payment_method_check
+    CHECK (payment_method IN ('card', 'wallet', 'voucher'));
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS method VARCHAR(20) NOT NULL DEFAULT 'card';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Order listings page through orders newest or oldest first, by default
+-- across every flight and status
+CREATE INDEX IF NOT EXISTS idx_orders_created ON orders(created_at, id);
+
+COMMIT;
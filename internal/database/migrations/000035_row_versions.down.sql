@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE seats DROP COLUMN IF EXISTS version;
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Every write to an order or seat bumps its version, so a writer holding a
+-- stale copy can compare and swap instead of overwriting newer state
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_orders_archivable;
+DROP TABLE IF EXISTS orders_archive;
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+-- Finished orders past the retention period, moved out of orders by the
+-- order-archival schedule. The order row, its passengers, payment attempts
+-- and refunds are kept as JSON, so orders can gain columns without this
+-- table following.
+CREATE TABLE IF NOT EXISTS orders_archive (
+    id UUID PRIMARY KEY,
+    flight_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL,
+    data JSONB NOT NULL,
+    passengers JSONB NOT NULL DEFAULT '[]',
+    payments JSONB NOT NULL DEFAULT '[]',
+    refunds JSONB NOT NULL DEFAULT '[]',
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_orders_archive_flight ON orders_archive(flight_id);
+CREATE INDEX idx_orders_archive_archived_at ON orders_archive(archived_at);
+
+-- Archival picks finished orders, oldest first
+CREATE INDEX IF NOT EXISTS idx_orders_archivable ON orders(updated_at)
+    WHERE status IN ('FAILED', 'EXPIRED', 'PAYMENT_REFUNDED', 'CANCELLED');
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orders_archive DROP COLUMN IF EXISTS events;
+DROP TABLE IF EXISTS order_events;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- What happened to an order, appended by the booking activities so the
+-- order's story outlives Temporal's history retention. An activity retried
+-- by Temporal appends under the same event_key, which is ignored.
+CREATE TABLE IF NOT EXISTS order_events (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    event_key TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT order_events_key_unique UNIQUE (order_id, event_key)
+);
+
+CREATE INDEX idx_order_events_order ON order_events(order_id, occurred_at);
+
+-- Archived orders keep their events
+ALTER TABLE orders_archive ADD COLUMN events JSONB NOT NULL DEFAULT '[]';
+
+COMMIT;
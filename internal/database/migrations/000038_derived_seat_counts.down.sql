@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS orders_recount_on_update ON orders;
+DROP TRIGGER IF EXISTS seats_recount_on_delete ON seats;
+DROP TRIGGER IF EXISTS seats_recount_on_update ON seats;
+DROP TRIGGER IF EXISTS seats_recount_on_insert ON seats;
+DROP FUNCTION IF EXISTS orders_recount_flights();
+DROP FUNCTION IF EXISTS seats_recount_flights();
+DROP FUNCTION IF EXISTS recount_flight_seats(UUID[]);
+DROP VIEW IF EXISTS flight_seat_counts;
+
+COMMIT;
@@ -0,0 +1,88 @@
+BEGIN;
+
+-- flights.total_seats and available_seats are no longer moved by deltas from
+-- the application; triggers on seats and orders recount them, in the
+-- transaction that writes the rows they summarize, to what this view derives:
+--   total_seats     = the flight's seat rows
+--   available_seats = seats neither booked nor blocked, less the cabin
+--                     capacity of confirmed seatless orders
+CREATE OR REPLACE VIEW flight_seat_counts AS
+SELECT f.id AS flight_id,
+       (SELECT COUNT(*) FROM seats s WHERE s.flight_id = f.id)::int AS total_seats,
+       ((SELECT COUNT(*) FROM seats s WHERE s.flight_id = f.id AND s.status NOT IN ('booked', 'blocked'))
+        - (SELECT COALESCE(SUM(o.cabin_seats), 0) FROM orders o WHERE o.flight_id = f.id AND o.status = 'CONFIRMED'))::int AS available_seats
+FROM flights f;
+
+-- The flight rows are locked before counting, so concurrent writers count in
+-- turn and each sees the rows the other committed. flights_seats_check still
+-- caps overselling: a write that would take available_seats past the
+-- overbooking allowance fails on it.
+CREATE OR REPLACE FUNCTION recount_flight_seats(flight_ids UUID[]) RETURNS void AS $$
+BEGIN
+    IF cardinality(flight_ids) = 0 THEN
+        RETURN;
+    END IF;
+    PERFORM 1 FROM flights WHERE id = ANY(flight_ids) ORDER BY id FOR UPDATE;
+
+    UPDATE flights f
+    SET total_seats = c.total_seats, available_seats = c.available_seats, updated_at = NOW()
+    FROM flight_seat_counts c
+    WHERE c.flight_id = f.id AND c.flight_id = ANY(flight_ids)
+      AND (f.total_seats, f.available_seats) IS DISTINCT FROM (c.total_seats, c.available_seats);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Seat inserts, deletes, and updates that book, block or free a seat
+CREATE OR REPLACE FUNCTION seats_recount_flights() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM recount_flight_seats(ARRAY(SELECT DISTINCT flight_id FROM new_rows));
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM recount_flight_seats(ARRAY(SELECT DISTINCT flight_id FROM old_rows));
+    ELSE
+        PERFORM recount_flight_seats(ARRAY(
+            SELECT DISTINCT n.flight_id
+            FROM new_rows n JOIN old_rows o ON o.flight_id = n.flight_id AND o.id = n.id
+            WHERE (o.status IN ('booked', 'blocked')) <> (n.status IN ('booked', 'blocked'))
+        ));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Order writes that change how much cabin capacity a confirmed order holds,
+-- or move it to another flight
+CREATE OR REPLACE FUNCTION orders_recount_flights() RETURNS trigger AS $$
+BEGIN
+    PERFORM recount_flight_seats(ARRAY(
+        WITH changed AS (
+            SELECT o.flight_id AS old_flight_id, n.flight_id AS new_flight_id
+            FROM old_rows o JOIN new_rows n ON n.id = o.id
+            WHERE (o.flight_id, CASE WHEN o.status = 'CONFIRMED' THEN o.cabin_seats ELSE 0 END)
+                  IS DISTINCT FROM (n.flight_id, CASE WHEN n.status = 'CONFIRMED' THEN n.cabin_seats ELSE 0 END)
+        )
+        SELECT old_flight_id FROM changed
+        UNION
+        SELECT new_flight_id FROM changed
+    ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER seats_recount_on_insert AFTER INSERT ON seats
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION seats_recount_flights();
+CREATE TRIGGER seats_recount_on_update AFTER UPDATE ON seats
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION seats_recount_flights();
+CREATE TRIGGER seats_recount_on_delete AFTER DELETE ON seats
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION seats_recount_flights();
+CREATE TRIGGER orders_recount_on_update AFTER UPDATE ON orders
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT EXECUTE FUNCTION orders_recount_flights();
+
+-- Counts that drifted under the old deltas start out right
+SELECT recount_flight_seats(ARRAY(SELECT id FROM flights));
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE seats DROP COLUMN IF EXISTS lock_token;
+
+COMMIT;
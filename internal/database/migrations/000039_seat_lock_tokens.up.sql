@@ -0,0 +1,8 @@
+BEGIN;
+
+-- The fencing token a reserved seat's Redis lock was taken under. Booking
+-- the seat must present it, so an order acting on a lock it has since lost
+-- or retaken cannot book the seat; NULL for seats reserved without a token.
+ALTER TABLE seats ADD COLUMN lock_token BIGINT;
+
+COMMIT;
@@ -18,7 +18,7 @@ func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.P
 	}
 
 	// Configure pool settings
-	poolConfig.MaxConns = 25
+	poolConfig.MaxConns = int32(cfg.MaxConns)
 	poolConfig.MinConns = 5
 	poolConfig.MaxConnLifetime = time.Hour
 	poolConfig.MaxConnIdleTime = 30 * time.Minute
@@ -16,7 +16,7 @@ func NewRedisClient(ctx context.Context, cfg config.RedisConfig) (*redis.Client,
 		Addr:         cfg.Addr,
 		Password:     cfg.Password,
 		DB:           cfg.DB,
-		PoolSize:     10,
+		PoolSize:     cfg.PoolSize,
 		MinIdleConns: 5,
 		ReadTimeout:  3 * time.Second,
 		WriteTimeout: 3 * time.Second,
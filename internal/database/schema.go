@@ -0,0 +1,326 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationFiles is the migration set the live schema is checked against
+//
+//go:embed migrations/*.up.sql
+var migrationFiles embed.FS
+
+// Schema is the set of tables, columns, indexes and named constraints
+type Schema map[string]*TableSchema
+
+// TableSchema lists the objects of one table
+type TableSchema struct {
+	Columns     map[string]bool
+	Indexes     map[string]bool
+	Constraints map[string]bool
+}
+
+// table returns the named table, adding it when missing
+func (s Schema) table(name string) *TableSchema {
+	t, ok := s[name]
+	if !ok {
+		t = &TableSchema{Columns: map[string]bool{}, Indexes: map[string]bool{}, Constraints: map[string]bool{}}
+		s[name] = t
+	}
+	return t
+}
+
+// DriftKind names one way the live schema differs from the migrations
+type DriftKind string
+
+const (
+	DriftMissingTable      DriftKind = "missing_table"
+	DriftMissingColumn     DriftKind = "missing_column"
+	DriftUnexpectedColumn  DriftKind = "unexpected_column"
+	DriftMissingIndex      DriftKind = "missing_index"
+	DriftMissingConstraint DriftKind = "missing_constraint"
+)
+
+// Drift is one difference between the expected and live schema
+type Drift struct {
+	Kind  DriftKind `json:"kind"`
+	Table string    `json:"table"`
+	Name  string    `json:"name,omitempty"` // column, index or constraint
+}
+
+func (d Drift) String() string {
+	if d.Name == "" {
+		return fmt.Sprintf("%s %s", d.Kind, d.Table)
+	}
+	return fmt.Sprintf("%s %s.%s", d.Kind, d.Table, d.Name)
+}
+
+// SchemaReport is the outcome of one schema check
+type SchemaReport struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Drift     []Drift   `json:"drift"`
+	Error     string    `json:"error,omitempty"` // set when the check itself failed
+}
+
+// Healthy reports whether the check ran and found no drift
+func (r SchemaReport) Healthy() bool {
+	return r.Error == "" && len(r.Drift) == 0
+}
+
+// ExpectedSchema replays the embedded migrations into the schema they produce
+func ExpectedSchema() (Schema, error) {
+	names, err := fs.Glob(migrationFiles, "migrations/*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	schema := Schema{}
+	for _, name := range names {
+		sql, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+		for _, stmt := range splitTopLevel(stripComments(string(sql)), ';') {
+			applyStatement(schema, stmt)
+		}
+	}
+
+	return schema, nil
+}
+
+var (
+	createTablePattern = regexp.MustCompile(`(?is)^CREATE TABLE (?:IF NOT EXISTS )?(\w+)\s*\((.*)\)$`)
+	alterTablePattern  = regexp.MustCompile(`(?is)^ALTER TABLE (?:IF EXISTS )?(\w+)\s+(.*)$`)
+	dropTablePattern   = regexp.MustCompile(`(?is)^DROP TABLE (?:IF EXISTS )?(\w+)`)
+	createIndexPattern = regexp.MustCompile(`(?is)^CREATE (?:UNIQUE )?INDEX (?:IF NOT EXISTS )?(\w+) ON (\w+)`)
+	dropIndexPattern   = regexp.MustCompile(`(?is)^DROP INDEX (?:IF EXISTS )?(\w+)`)
+	constraintPattern  = regexp.MustCompile(`(?is)^CONSTRAINT (\w+)`)
+	addColumnPattern   = regexp.MustCompile(`(?is)^ADD COLUMN (?:IF NOT EXISTS )?(\w+)`)
+	dropColumnPattern  = regexp.MustCompile(`(?is)^DROP COLUMN (?:IF EXISTS )?(\w+)`)
+	addConstraintPat   = regexp.MustCompile(`(?is)^ADD CONSTRAINT (\w+)`)
+	dropConstraintPat  = regexp.MustCompile(`(?is)^DROP CONSTRAINT (?:IF EXISTS )?(\w+)`)
+	tableConstraintPat = regexp.MustCompile(`(?i)^(PRIMARY KEY|UNIQUE|CHECK|FOREIGN KEY|EXCLUDE)\b`)
+)
+
+// applyStatement folds one DDL statement into schema; data statements are ignored
+func applyStatement(schema Schema, stmt string) {
+	stmt = strings.Join(strings.Fields(stmt), " ")
+
+	if m := createTablePattern.FindStringSubmatch(stmt); m != nil {
+		t := schema.table(m[1])
+		for _, def := range splitTopLevel(m[2], ',') {
+			if c := constraintPattern.FindStringSubmatch(def); c != nil {
+				t.Constraints[c[1]] = true
+			} else if !tableConstraintPat.MatchString(def) {
+				t.Columns[strings.Fields(def)[0]] = true
+			}
+		}
+		return
+	}
+
+	if m := alterTablePattern.FindStringSubmatch(stmt); m != nil {
+		t := schema.table(m[1])
+		for _, action := range splitTopLevel(m[2], ',') {
+			switch {
+			case addColumnPattern.MatchString(action):
+				t.Columns[addColumnPattern.FindStringSubmatch(action)[1]] = true
+			case dropColumnPattern.MatchString(action):
+				delete(t.Columns, dropColumnPattern.FindStringSubmatch(action)[1])
+			case addConstraintPat.MatchString(action):
+				t.Constraints[addConstraintPat.FindStringSubmatch(action)[1]] = true
+			case dropConstraintPat.MatchString(action):
+				delete(t.Constraints, dropConstraintPat.FindStringSubmatch(action)[1])
+			}
+		}
+		return
+	}
+
+	if m := dropTablePattern.FindStringSubmatch(stmt); m != nil {
+		delete(schema, m[1])
+		return
+	}
+
+	if m := createIndexPattern.FindStringSubmatch(stmt); m != nil {
+		schema.table(m[2]).Indexes[m[1]] = true
+		return
+	}
+
+	if m := dropIndexPattern.FindStringSubmatch(stmt); m != nil {
+		for _, t := range schema {
+			delete(t.Indexes, m[1])
+		}
+	}
+}
+
+// stripComments removes -- line comments
+func stripComments(sql string) string {
+	lines := strings.Split(sql, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "--"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitTopLevel splits s on sep outside parentheses and quotes, dropping blank parts
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth, quoted, start := 0, false, 0
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// LiveSchema reads the tables, columns, indexes and constraints of the
+// connection's current schema
+func LiveSchema(ctx context.Context, pool *pgxpool.Pool) (Schema, error) {
+	schema := Schema{}
+	queries := []struct {
+		sql string
+		add func(t *TableSchema, name string)
+	}{
+		{`SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = current_schema()`,
+			func(t *TableSchema, name string) { t.Columns[name] = true }},
+		{`SELECT tablename, indexname FROM pg_indexes WHERE schemaname = current_schema()`,
+			func(t *TableSchema, name string) { t.Indexes[name] = true }},
+		{`SELECT table_name, constraint_name FROM information_schema.table_constraints WHERE table_schema = current_schema()`,
+			func(t *TableSchema, name string) { t.Constraints[name] = true }},
+	}
+
+	for _, q := range queries {
+		rows, err := pool.Query(ctx, q.sql)
+		if err != nil {
+			return nil, fmt.Errorf("read live schema: %w", err)
+		}
+		for rows.Next() {
+			var table, name string
+			if err := rows.Scan(&table, &name); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan live schema: %w", err)
+			}
+			q.add(schema.table(table), name)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("read live schema: %w", err)
+		}
+	}
+
+	return schema, nil
+}
+
+// DiffSchema lists how live falls short of, or has columns beyond, expected.
+// Extra tables, indexes and constraints are not drift: Postgres names its
+// own (e.g. orders_pkey) and tools such as migrate add their own tables.
+func DiffSchema(expected, live Schema) []Drift {
+	var drift []Drift
+	for _, table := range sortedKeys(expected) {
+		want := expected[table]
+		got, ok := live[table]
+		if !ok {
+			drift = append(drift, Drift{Kind: DriftMissingTable, Table: table})
+			continue
+		}
+
+		drift = append(drift, missing(DriftMissingColumn, table, want.Columns, got.Columns)...)
+		drift = append(drift, missing(DriftUnexpectedColumn, table, got.Columns, want.Columns)...)
+		drift = append(drift, missing(DriftMissingIndex, table, want.Indexes, got.Indexes)...)
+		drift = append(drift, missing(DriftMissingConstraint, table, want.Constraints, got.Constraints)...)
+	}
+	return drift
+}
+
+// missing reports each name in want that is absent from got
+func missing(kind DriftKind, table string, want, got map[string]bool) []Drift {
+	var drift []Drift
+	for _, name := range sortedKeys(want) {
+		if !got[name] {
+			drift = append(drift, Drift{Kind: kind, Table: table, Name: name})
+		}
+	}
+	return drift
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SchemaChecker compares the live database with the migration set and keeps
+// the latest report for health and admin endpoints
+type SchemaChecker struct {
+	pool *pgxpool.Pool
+
+	mu   sync.RWMutex
+	last SchemaReport
+}
+
+// NewSchemaChecker creates a new SchemaChecker
+func NewSchemaChecker(pool *pgxpool.Pool) *SchemaChecker {
+	return &SchemaChecker{pool: pool}
+}
+
+// Check runs the comparison and stores its report. A failed check is
+// recorded in the report rather than returned, so callers only log it.
+func (c *SchemaChecker) Check(ctx context.Context) SchemaReport {
+	report := SchemaReport{CheckedAt: time.Now(), Drift: []Drift{}}
+
+	expected, err := ExpectedSchema()
+	if err == nil {
+		var live Schema
+		if live, err = LiveSchema(ctx, c.pool); err == nil {
+			report.Drift = append(report.Drift, DiffSchema(expected, live)...)
+		}
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	c.mu.Lock()
+	c.last = report
+	c.mu.Unlock()
+
+	return report
+}
+
+// Last returns the most recent report; CheckedAt is zero before the first check
+func (c *SchemaChecker) Last() SchemaReport {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpectedSchema(t *testing.T) {
+	schema, err := ExpectedSchema()
+	if err != nil {
+		t.Fatalf("ExpectedSchema: %v", err)
+	}
+
+	orders, ok := schema["orders"]
+	if !ok {
+		t.Fatal("orders table missing")
+	}
+	for _, col := range []string{"id", "flight_id", "status", "price_breakdown", "booking_reference"} {
+		if !orders.Columns[col] {
+			t.Errorf("orders.%s missing", col)
+		}
+	}
+	if orders.Columns["CONSTRAINT"] || orders.Columns["PRIMARY"] {
+		t.Error("table constraints parsed as columns")
+	}
+	if !orders.Constraints["orders_status_check"] || !orders.Constraints["orders_booking_reference_unique"] {
+		t.Errorf("orders constraints = %v", orders.Constraints)
+	}
+	if !orders.Indexes["idx_orders_expires"] {
+		t.Errorf("orders indexes = %v", orders.Indexes)
+	}
+
+	// Migration 6 drops and re-adds the seat status check
+	if !schema["seats"].Constraints["seats_status_check"] {
+		t.Error("seats_status_check missing after drop and re-add")
+	}
+	if !schema["seat_swap_offers"].Indexes["idx_seat_swap_offers_active_seat"] {
+		t.Error("unique index missing")
+	}
+}
+
+func TestApplyStatementDrops(t *testing.T) {
+	schema := Schema{}
+	for _, stmt := range []string{
+		"CREATE TABLE t (a INT, b TEXT DEFAULT 'x,y', PRIMARY KEY (a))",
+		"CREATE INDEX idx_t_b ON t(b)",
+		"ALTER TABLE t ADD COLUMN c INT, DROP COLUMN IF EXISTS b",
+		"DROP INDEX IF EXISTS idx_t_b",
+		"CREATE TABLE gone (id INT)",
+		"DROP TABLE gone",
+	} {
+		applyStatement(schema, stmt)
+	}
+
+	if got := sortedKeys(schema["t"].Columns); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("columns = %v, want [a c]", got)
+	}
+	if len(schema["t"].Indexes) != 0 {
+		t.Errorf("indexes = %v, want none", schema["t"].Indexes)
+	}
+	if _, ok := schema["gone"]; ok {
+		t.Error("dropped table still present")
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	expected := Schema{}
+	applyStatement(expected, "CREATE TABLE orders (id UUID, booking_reference CHAR(6), CONSTRAINT orders_ref_unique UNIQUE (booking_reference))")
+	applyStatement(expected, "CREATE INDEX idx_orders_ref ON orders(booking_reference)")
+	applyStatement(expected, "CREATE TABLE passengers (id UUID)")
+
+	live := Schema{}
+	live.table("orders").Columns["id"] = true
+	live.table("orders").Columns["legacy_code"] = true
+	live.table("orders").Constraints["orders_pkey"] = true
+	live.table("schema_migrations").Columns["version"] = true
+
+	var got []string
+	for _, d := range DiffSchema(expected, live) {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"missing_column orders.booking_reference",
+		"unexpected_column orders.legacy_code",
+		"missing_index orders.idx_orders_ref",
+		"missing_constraint orders.orders_ref_unique",
+		"missing_table passengers",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("drift = %v\nwant %v", got, want)
+	}
+}
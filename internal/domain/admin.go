@@ -0,0 +1,11 @@
+package domain
+
+// AdminPlan lists everything a destructive admin action touches. When DryRun
+// is set the plan was computed but nothing was changed.
+type AdminPlan struct {
+	Action    string
+	DryRun    bool
+	Seats     []string
+	Orders    []string
+	Workflows []string
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BoardingPass is the pass for one seat of a confirmed order
+type BoardingPass struct {
+	BookingReference string
+	FlightNumber     string
+	Origin           string
+	Destination      string
+	DepartureTime    time.Time
+	SeatID           string
+	PassengerName    string // empty when the order was booked without passenger details
+}
+
+// Payload is the text encoded in the pass's QR code:
+// FBS1|reference|flight|origin|destination|departure|seat|name
+func (b BoardingPass) Payload() string {
+	return strings.Join([]string{
+		"FBS1",
+		b.BookingReference,
+		b.FlightNumber,
+		b.Origin,
+		b.Destination,
+		b.DepartureTime.UTC().Format("20060102T1504Z"),
+		b.SeatID,
+		strings.ToUpper(b.PassengerName),
+	}, "|")
+}
+
+// Route formats the origin and destination, e.g. TLV-JFK
+func (b BoardingPass) Route() string {
+	return fmt.Sprintf("%s-%s", b.Origin, b.Destination)
+}
+
+// BoardingPasses returns one pass per seat of the itinerary, in seat order,
+// named after the passenger sitting there
+func BoardingPasses(it Itinerary) []BoardingPass {
+	names := make(map[string]string, len(it.Passengers))
+	for _, p := range it.Passengers {
+		if p.SeatID != "" {
+			names[p.SeatID] = p.Name
+		}
+	}
+
+	passes := make([]BoardingPass, len(it.Seats))
+	for i, seat := range it.Seats {
+		passes[i] = BoardingPass{
+			BookingReference: it.BookingReference,
+			FlightNumber:     it.Flight.FlightNumber,
+			Origin:           it.Flight.Origin,
+			Destination:      it.Flight.Destination,
+			DepartureTime:    it.Flight.DepartureTime,
+			SeatID:           seat,
+			PassengerName:    names[seat],
+		}
+	}
+	return passes
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBoardingPasses(t *testing.T) {
+	it := Itinerary{
+		BookingReference: "K7QH2M",
+		Flight: Flight{
+			FlightNumber:  "FL101",
+			Origin:        "TLV",
+			Destination:   "JFK",
+			DepartureTime: time.Date(2024, 3, 15, 9, 5, 0, 0, time.UTC),
+		},
+		Seats:      []string{"14A", "14B"},
+		Passengers: []Passenger{{SeatID: "14B", Name: "Ada Lovelace"}},
+	}
+
+	passes := BoardingPasses(it)
+	if len(passes) != 2 {
+		t.Fatalf("got %d passes, want 2", len(passes))
+	}
+	if passes[0].SeatID != "14A" || passes[0].PassengerName != "" {
+		t.Errorf("first pass = %+v, want unnamed 14A", passes[0])
+	}
+	if want := "FBS1|K7QH2M|FL101|TLV|JFK|20240315T0905Z|14B|ADA LOVELACE"; passes[1].Payload() != want {
+		t.Errorf("payload = %q, want %q", passes[1].Payload(), want)
+	}
+}
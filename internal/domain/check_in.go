@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// CheckInOpensBefore is how long before departure online check-in opens
+const CheckInOpensBefore = 24 * time.Hour
+
+// SeatlessCabin is the cabin seatless capacity is sold in and seated in at check-in
+const SeatlessCabin = CabinEconomy
+
+// CheckInWindow reports whether online check-in for a flight departing at
+// departure is open at now: from CheckInOpensBefore until departure
+func CheckInWindow(departure, now time.Time) error {
+	switch {
+	case now.Before(departure.Add(-CheckInOpensBefore)):
+		return ErrCheckInNotOpen
+	case !now.Before(departure):
+		return ErrCheckInClosed
+	}
+	return nil
+}
+
+// SameCabins reports whether next seats the order in the same cabins as
+// current, seat for seat, given the class of every seat on the flight
+func SameCabins(classes map[string]CabinClass, current, next []string) bool {
+	if len(current) != len(next) {
+		return false
+	}
+
+	cabins := func(seats []string) []CabinClass {
+		out := make([]CabinClass, len(seats))
+		for i, seat := range seats {
+			out[i] = classes[seat]
+		}
+		slices.Sort(out)
+		return out
+	}
+	return slices.Equal(cabins(current), cabins(next))
+}
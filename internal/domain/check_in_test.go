@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckInWindow(t *testing.T) {
+	departure := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		now  time.Time
+		want error
+	}{
+		{"two days out", departure.Add(-48 * time.Hour), ErrCheckInNotOpen},
+		{"opens", departure.Add(-CheckInOpensBefore), nil},
+		{"an hour out", departure.Add(-time.Hour), nil},
+		{"departed", departure, ErrCheckInClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckInWindow(departure, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("CheckInWindow = %v; want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameCabins(t *testing.T) {
+	classes := map[string]CabinClass{
+		"1A": CabinBusiness, "1B": CabinBusiness,
+		"9A": CabinEconomy, "9B": CabinEconomy, "9C": CabinEconomy,
+	}
+	tests := []struct {
+		name    string
+		current []string
+		next    []string
+		want    bool
+	}{
+		{"within economy", []string{"9A"}, []string{"9C"}, true},
+		{"mixed cabins kept", []string{"1A", "9A"}, []string{"9B", "1B"}, true},
+		{"upgrade", []string{"9A"}, []string{"1A"}, false},
+		{"fewer seats", []string{"9A", "9B"}, []string{"9C"}, false},
+		{"unknown seat", []string{"9A"}, []string{"30A"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameCabins(classes, tt.current, tt.next); got != tt.want {
+				t.Errorf("SameCabins = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DiscrepancyKind names a consistency check a flight failed
+type DiscrepancyKind string
+
+const (
+	DiscrepancyAvailableSeats DiscrepancyKind = "available_seats" // flights.available_seats disagrees with the seats table
+	DiscrepancySeatNoOrder    DiscrepancyKind = "seat_no_order"   // a reserved or booked seat names no order
+	DiscrepancySeatDeadOrder  DiscrepancyKind = "seat_dead_order" // a seat is held by a failed, expired, refunded or missing order
+	DiscrepancySeatStatus     DiscrepancyKind = "seat_status"     // a seat's status disagrees with its order's status
+	DiscrepancyStaleOrderID   DiscrepancyKind = "stale_order_id"  // an available seat still names an order
+	DiscrepancyOrphanLock     DiscrepancyKind = "orphan_lock"     // a Redis lock without a matching reservation
+	DiscrepancyMissingLock    DiscrepancyKind = "missing_lock"    // a reservation for a booking in progress without its Redis lock
+)
+
+// Discrepancy is one inconsistency found on a flight. Fixable ones can be
+// repaired without guessing what the booking meant to do; the rest are for an
+// operator to look at.
+type Discrepancy struct {
+	Kind     DiscrepancyKind `json:"kind"`
+	FlightID string          `json:"flightId"`
+	SeatID   string          `json:"seatId,omitempty"`
+	OrderID  string          `json:"orderId,omitempty"`
+	Detail   string          `json:"detail"`
+	Fixable  bool            `json:"fixable"`
+	Fixed    bool            `json:"fixed,omitempty"`
+}
+
+// FlightState is what the consistency checks read for one flight: its row,
+// its seats, its Redis locks (seat ID to order ID) and every order placed on
+// it, by ID
+type FlightState struct {
+	Flight Flight
+	Seats  []Seat
+	Locks  map[string]string
+	Orders map[string]*Order
+}
+
+// ExpectedAvailableSeats is what flights.available_seats should read: seats
+// neither booked nor blocked, less the capacity confirmed seatless orders
+// hold without seat rows
+func (st FlightState) ExpectedAvailableSeats() int {
+	available := 0
+	for _, seat := range st.Seats {
+		if seat.Status != SeatStatusBooked && seat.Status != SeatStatusBlocked {
+			available++
+		}
+	}
+	for _, order := range st.Orders {
+		if order.Status == OrderStatusConfirmed {
+			available -= order.CabinSeats
+		}
+	}
+	return available
+}
+
+// CheckConsistency compares a flight's seat count, seat rows, orders and
+// Redis locks with each other and returns what disagrees: the seat count
+// first, then seats in seat map order, then locks
+func (st FlightState) CheckConsistency() []Discrepancy {
+	flightID := st.Flight.ID
+	var found []Discrepancy
+
+	if expected := st.ExpectedAvailableSeats(); st.Flight.AvailableSeats != expected {
+		found = append(found, Discrepancy{
+			Kind:     DiscrepancyAvailableSeats,
+			FlightID: flightID,
+			Detail:   fmt.Sprintf("available_seats is %d; seats and confirmed orders give %d", st.Flight.AvailableSeats, expected),
+			Fixable:  true,
+		})
+	}
+
+	for _, seat := range st.Seats {
+		d := Discrepancy{FlightID: flightID, SeatID: seat.ID}
+		if seat.OrderID != nil {
+			d.OrderID = *seat.OrderID
+		}
+
+		switch seat.Status {
+		case SeatStatusAvailable, SeatStatusBlocked:
+			if seat.OrderID != nil {
+				d.Kind = DiscrepancyStaleOrderID
+				d.Detail = fmt.Sprintf("%s seat still names its order", seat.Status)
+				d.Fixable = seat.Status == SeatStatusAvailable
+				found = append(found, d)
+			}
+			continue
+		}
+
+		if seat.OrderID == nil {
+			d.Kind = DiscrepancySeatNoOrder
+			d.Detail = fmt.Sprintf("%s seat has no order", seat.Status)
+			d.Fixable = true
+			found = append(found, d)
+			continue
+		}
+
+		order, ok := st.Orders[*seat.OrderID]
+		switch {
+		case !ok:
+			d.Kind = DiscrepancySeatDeadOrder
+			d.Detail = fmt.Sprintf("%s seat names an order not on this flight", seat.Status)
+			d.Fixable = true
+		case order.Status.IsFinished():
+			d.Kind = DiscrepancySeatDeadOrder
+			d.Detail = fmt.Sprintf("%s seat is held by a %s order", seat.Status, order.Status)
+			d.Fixable = true
+		case seat.Status == SeatStatusBooked && order.Status != OrderStatusConfirmed:
+			d.Kind = DiscrepancySeatStatus
+			d.Detail = fmt.Sprintf("seat is booked but its order is %s", order.Status)
+		case seat.Status == SeatStatusReserved && order.Status == OrderStatusConfirmed:
+			d.Kind = DiscrepancySeatStatus
+			d.Detail = "seat is still reserved but its order is CONFIRMED"
+		case seat.Status == SeatStatusReserved && st.Locks[seat.ID] != order.ID:
+			d.Kind = DiscrepancyMissingLock
+			d.Detail = fmt.Sprintf("seat is reserved for a %s order that holds no lock on it", order.Status)
+		default:
+			continue
+		}
+		found = append(found, d)
+	}
+
+	reserved := make(map[string]string, len(st.Seats))
+	for _, seat := range st.Seats {
+		if seat.Status == SeatStatusReserved && seat.OrderID != nil {
+			reserved[seat.ID] = *seat.OrderID
+		}
+	}
+	lockedSeats := make([]string, 0, len(st.Locks))
+	for seatID := range st.Locks {
+		lockedSeats = append(lockedSeats, seatID)
+	}
+	sort.Strings(lockedSeats)
+	for _, seatID := range lockedSeats {
+		orderID := st.Locks[seatID]
+		if reserved[seatID] == orderID {
+			continue
+		}
+		d := Discrepancy{Kind: DiscrepancyOrphanLock, FlightID: flightID, SeatID: seatID, OrderID: orderID}
+		// A lock taken by a booking still in progress may just be ahead of
+		// its seat row, so only locks of orders done booking are released
+		order, ok := st.Orders[orderID]
+		switch {
+		case !ok:
+			d.Detail = "seat is locked by an order not on this flight"
+			d.Fixable = true
+		case order.Status.IsFinished() || order.Status == OrderStatusConfirmed:
+			d.Detail = fmt.Sprintf("seat is locked by a %s order without a reservation", order.Status)
+			d.Fixable = true
+		default:
+			d.Detail = fmt.Sprintf("seat is locked by a %s order without a reservation", order.Status)
+		}
+		found = append(found, d)
+	}
+
+	return found
+}
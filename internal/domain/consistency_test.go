@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	seat := func(id string, status SeatStatus, orderID *string) Seat {
+		return Seat{ID: id, FlightID: "f1", Status: status, OrderID: orderID}
+	}
+	orders := map[string]*Order{
+		"holding":   {ID: "holding", Status: OrderStatusPaymentPending},
+		"confirmed": {ID: "confirmed", Status: OrderStatusConfirmed},
+		"seatless":  {ID: "seatless", Status: OrderStatusConfirmed, Seatless: true, CabinSeats: 2},
+		"expired":   {ID: "expired", Status: OrderStatusExpired},
+	}
+
+	tests := []struct {
+		name      string
+		available int
+		seats     []Seat
+		locks     map[string]string
+		want      []DiscrepancyKind
+	}{
+		{
+			name:      "consistent",
+			available: 2,
+			seats: []Seat{
+				seat("1A", SeatStatusReserved, ptr("holding")),
+				seat("1B", SeatStatusBooked, ptr("confirmed")),
+				seat("1C", SeatStatusAvailable, nil),
+				seat("1D", SeatStatusAvailable, nil),
+				seat("1E", SeatStatusAvailable, nil),
+				seat("1F", SeatStatusBlocked, nil),
+			},
+			locks: map[string]string{"1A": "holding"},
+		},
+		{
+			name:      "seat count drift",
+			available: 3,
+			seats:     []Seat{seat("1A", SeatStatusAvailable, nil), seat("1B", SeatStatusAvailable, nil), seat("1C", SeatStatusAvailable, nil)},
+			want:      []DiscrepancyKind{DiscrepancyAvailableSeats},
+		},
+		{
+			name:      "seats held by nobody or by a dead order",
+			available: 3,
+			seats: []Seat{
+				seat("1A", SeatStatusReserved, nil),
+				seat("1B", SeatStatusReserved, ptr("expired")),
+				seat("1C", SeatStatusReserved, ptr("gone")),
+				seat("1D", SeatStatusAvailable, ptr("expired")),
+				seat("1E", SeatStatusAvailable, nil),
+			},
+			locks: map[string]string{"1B": "expired", "1C": "gone"},
+			want:  []DiscrepancyKind{DiscrepancySeatNoOrder, DiscrepancySeatDeadOrder, DiscrepancySeatDeadOrder, DiscrepancyStaleOrderID},
+		},
+		{
+			name:      "seat status disagrees with order",
+			available: 0,
+			seats: []Seat{
+				seat("1A", SeatStatusBooked, ptr("holding")),
+				seat("1B", SeatStatusReserved, ptr("confirmed")),
+				seat("1C", SeatStatusAvailable, nil),
+			},
+			locks: map[string]string{"1B": "confirmed"},
+			want:  []DiscrepancyKind{DiscrepancySeatStatus, DiscrepancySeatStatus},
+		},
+		{
+			name:      "locks and reservations out of step",
+			available: 1,
+			seats: []Seat{
+				seat("1A", SeatStatusReserved, ptr("holding")),
+				seat("1B", SeatStatusAvailable, nil),
+				seat("1C", SeatStatusAvailable, nil),
+			},
+			locks: map[string]string{"1B": "holding", "1C": "expired"},
+			want:  []DiscrepancyKind{DiscrepancyMissingLock, DiscrepancyOrphanLock, DiscrepancyOrphanLock},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := FlightState{
+				Flight: Flight{ID: "f1", AvailableSeats: tt.available},
+				Seats:  tt.seats,
+				Locks:  tt.locks,
+				Orders: orders,
+			}
+			var got []DiscrepancyKind
+			for _, d := range st.CheckConsistency() {
+				got = append(got, d.Kind)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kinds = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckConsistencyOrphanLockFixable(t *testing.T) {
+	st := FlightState{
+		Flight: Flight{ID: "f1", AvailableSeats: 2},
+		Seats:  []Seat{{ID: "1A", Status: SeatStatusAvailable}, {ID: "1B", Status: SeatStatusAvailable}},
+		Locks:  map[string]string{"1A": "holding", "1B": "expired"},
+		Orders: map[string]*Order{
+			"holding": {ID: "holding", Status: OrderStatusSeatsReserved},
+			"expired": {ID: "expired", Status: OrderStatusExpired},
+		},
+	}
+
+	found := st.CheckConsistency()
+	if len(found) != 2 {
+		t.Fatalf("found %d discrepancies; want 2", len(found))
+	}
+	if found[0].SeatID != "1A" || found[0].Fixable {
+		t.Errorf("lock of a booking in progress should be reported only: %+v", found[0])
+	}
+	if found[1].SeatID != "1B" || !found[1].Fixable {
+		t.Errorf("lock of an expired order should be fixable: %+v", found[1])
+	}
+}
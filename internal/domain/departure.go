@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// SalesCloseTime is when sales close on the flight, closeBefore ahead of its
+// current departure time
+func (f *Flight) SalesCloseTime(closeBefore time.Duration) time.Time {
+	return f.DepartureTime.Add(-closeBefore)
+}
+
+// SalesOpen reports whether the flight still takes new bookings and seat
+// changes at now. Sales end when the departure workflow closes them, or at
+// SalesCloseTime if it has not run yet, and never reopen once the flight is
+// cancelled or departed.
+func (f *Flight) SalesOpen(now time.Time, closeBefore time.Duration) bool {
+	if f.Status == FlightStatusCancelled || f.Status == FlightStatusDeparted || f.SalesClosedAt != nil {
+		return false
+	}
+	return now.Before(f.SalesCloseTime(closeBefore))
+}
+
+// Manifest lists who flies on a departed flight and where they sit
+type Manifest struct {
+	FlightID      string          `json:"flightId"`
+	FlightNumber  string          `json:"flightNumber"`
+	DepartureTime time.Time       `json:"departureTime"`
+	Passengers    []ManifestEntry `json:"passengers"`
+	SeatlessCount int             `json:"seatlessCount"` // seats held by confirmed seatless orders without passenger details
+	GeneratedAt   time.Time       `json:"generatedAt"`
+}
+
+// ManifestEntry is one passenger of a confirmed order. SeatID is empty for a
+// passenger of a seatless order who did not check in.
+type ManifestEntry struct {
+	OrderID          string     `json:"orderId"`
+	BookingReference string     `json:"bookingReference"`
+	SeatID           string     `json:"seatId,omitempty"`
+	Name             string     `json:"name"`
+	DocumentNumber   string     `json:"documentNumber"`
+	CheckedInAt      *time.Time `json:"checkedInAt,omitempty"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlightSalesOpen(t *testing.T) {
+	departure := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	closedAt := departure.Add(-3 * time.Hour)
+	tests := []struct {
+		name   string
+		flight Flight
+		now    time.Time
+		want   bool
+	}{
+		{"day before", Flight{DepartureTime: departure, Status: FlightStatusScheduled}, departure.Add(-24 * time.Hour), true},
+		{"at close", Flight{DepartureTime: departure, Status: FlightStatusScheduled}, departure.Add(-2 * time.Hour), false},
+		{"in the past", Flight{DepartureTime: departure, Status: FlightStatusScheduled}, departure.Add(time.Hour), false},
+		{"delayed", Flight{DepartureTime: departure, Status: FlightStatusDelayed}, departure.Add(-150 * time.Minute), true},
+		{"closed early", Flight{DepartureTime: departure, Status: FlightStatusScheduled, SalesClosedAt: &closedAt}, departure.Add(-24 * time.Hour), false},
+		{"cancelled", Flight{DepartureTime: departure, Status: FlightStatusCancelled}, departure.Add(-24 * time.Hour), false},
+		{"departed", Flight{DepartureTime: departure, Status: FlightStatusDeparted}, departure.Add(-24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flight.SalesOpen(tt.now, 2*time.Hour); got != tt.want {
+				t.Errorf("SalesOpen = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// DepositPolicy lets an order be confirmed on part of its price, with the
+// balance charged ahead of departure
+type DepositPolicy struct {
+	Percent          int           `json:"percent"`          // share of the price taken at payment; zero disables deposits
+	BalanceDueBefore time.Duration `json:"balanceDueBefore"` // time before departure when the balance is charged
+}
+
+// Enabled reports whether orders may pay a deposit
+func (p DepositPolicy) Enabled() bool {
+	return p.Percent > 0 && p.Percent < 100
+}
+
+// Terms returns the deposit terms of an order on a flight departing at
+// departure, or false when a deposit is not available: deposits are
+// disabled, or the balance would already be due at now.
+func (p DepositPolicy) Terms(departure, now time.Time) (DepositTerms, bool) {
+	due := departure.Add(-p.BalanceDueBefore)
+	if !p.Enabled() || !due.After(now) {
+		return DepositTerms{}, false
+	}
+	return DepositTerms{Percent: p.Percent, BalanceDueAt: due}, true
+}
+
+// DepositTerms are what a customer who pays a deposit agrees to
+type DepositTerms struct {
+	Percent      int       `json:"percent"`
+	BalanceDueAt time.Time `json:"balanceDueAt"`
+}
+
+// Split divides totalCents into the deposit taken now, rounded up to the
+// cent, and the balance
+func (t DepositTerms) Split(totalCents int64) Deposit {
+	deposit := (totalCents*int64(t.Percent) + 99) / 100
+	return Deposit{
+		DepositCents: deposit,
+		BalanceCents: totalCents - deposit,
+		BalanceDueAt: t.BalanceDueAt,
+	}
+}
+
+// Deposit is the part payment an order was confirmed on. The balance is
+// charged at BalanceDueAt; an order whose balance cannot be charged fails
+// and keeps no seats.
+type Deposit struct {
+	DepositCents  int64      `json:"depositCents"`
+	BalanceCents  int64      `json:"balanceCents"`
+	BalanceDueAt  time.Time  `json:"balanceDueAt"`
+	BalancePaidAt *time.Time `json:"balancePaidAt,omitempty"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDepositPolicyTerms(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	departure := now.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		policy  DepositPolicy
+		wantOK  bool
+		wantDue time.Time
+	}{
+		{"balance due before departure", DepositPolicy{Percent: 20, BalanceDueBefore: 14 * 24 * time.Hour}, true, departure.Add(-14 * 24 * time.Hour)},
+		{"disabled", DepositPolicy{BalanceDueBefore: 14 * 24 * time.Hour}, false, time.Time{}},
+		{"full price is no deposit", DepositPolicy{Percent: 100}, false, time.Time{}},
+		{"balance already due", DepositPolicy{Percent: 20, BalanceDueBefore: 30 * 24 * time.Hour}, false, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			terms, ok := tt.policy.Terms(departure, now)
+			if ok != tt.wantOK || !terms.BalanceDueAt.Equal(tt.wantDue) {
+				t.Errorf("Terms = %+v, %v; want due %s, %v", terms, ok, tt.wantDue, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDepositTermsSplit(t *testing.T) {
+	due := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	deposit := DepositTerms{Percent: 15, BalanceDueAt: due}.Split(33333)
+
+	// The deposit rounds up, so the balance never exceeds its share
+	if deposit.DepositCents != 5000 || deposit.BalanceCents != 28333 {
+		t.Errorf("Split(33333) = %d + %d; want 5000 + 28333", deposit.DepositCents, deposit.BalanceCents)
+	}
+	if !deposit.BalanceDueAt.Equal(due) {
+		t.Errorf("BalanceDueAt = %s; want %s", deposit.BalanceDueAt, due)
+	}
+}
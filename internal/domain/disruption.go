@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// FlightStatus is the operational status of a flight
+type FlightStatus string
+
+const (
+	FlightStatusScheduled FlightStatus = "scheduled"
+	FlightStatusDelayed   FlightStatus = "delayed"
+	FlightStatusCancelled FlightStatus = "cancelled"
+	FlightStatusDeparted  FlightStatus = "departed"
+)
+
+// Delays are drawn between MinDisruptionDelay and the policy's MaxDelay, in
+// whole disruptionDelayStep steps
+const (
+	MinDisruptionDelay  = 15 * time.Minute
+	disruptionDelayStep = 5 * time.Minute
+)
+
+// DisruptionPolicy describes how often simulated weather and ATC events hit
+// upcoming flights
+type DisruptionPolicy struct {
+	Probability float64       // chance per run that a flight is disrupted
+	CancelShare float64       // share of disruptions that cancel rather than delay
+	MaxDelay    time.Duration // longest delay one disruption adds
+}
+
+// FlightDisruption is a delay or cancellation applied to one flight
+type FlightDisruption struct {
+	FlightID string        `json:"flightId"`
+	Status   FlightStatus  `json:"status"` // delayed or cancelled
+	Delay    time.Duration `json:"delay,omitempty"`
+}
+
+// Disrupt decides from two uniform rolls in [0, 1) whether a flight is
+// disrupted and how: roll against Probability, then pick against CancelShare,
+// the rest of pick's range scaling the delay
+func (p DisruptionPolicy) Disrupt(flightID string, roll, pick float64) (FlightDisruption, bool) {
+	if roll >= p.Probability {
+		return FlightDisruption{}, false
+	}
+	if pick < p.CancelShare {
+		return FlightDisruption{FlightID: flightID, Status: FlightStatusCancelled}, true
+	}
+
+	scale := (pick - p.CancelShare) / (1 - p.CancelShare)
+	delay := MinDisruptionDelay + time.Duration(scale*float64(max(p.MaxDelay-MinDisruptionDelay, 0)))
+	delay = delay.Round(disruptionDelayStep)
+	return FlightDisruption{FlightID: flightID, Status: FlightStatusDelayed, Delay: delay}, true
+}
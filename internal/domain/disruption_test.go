@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDisruptionPolicyDisrupt(t *testing.T) {
+	policy := DisruptionPolicy{Probability: 0.1, CancelShare: 0.2, MaxDelay: 3 * time.Hour}
+	tests := []struct {
+		name       string
+		roll, pick float64
+		want       FlightDisruption
+		ok         bool
+	}{
+		{"spared", 0.5, 0, FlightDisruption{}, false},
+		{"cancelled", 0.05, 0.1, FlightDisruption{FlightID: "f1", Status: FlightStatusCancelled}, true},
+		{"shortest delay", 0.05, 0.2, FlightDisruption{FlightID: "f1", Status: FlightStatusDelayed, Delay: MinDisruptionDelay}, true},
+		{"longer delay", 0.05, 0.7, FlightDisruption{FlightID: "f1", Status: FlightStatusDelayed, Delay: 2 * time.Hour}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policy.Disrupt("f1", tt.roll, tt.pick)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Disrupt = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
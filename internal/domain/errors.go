@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	// ErrFlightNotFound indicates a flight was not found
@@ -15,6 +19,14 @@ var (
 	// ErrSeatsAlreadyLocked indicates seats are already locked by another order
 	ErrSeatsAlreadyLocked = errors.New("seats are already locked")
 
+	// ErrStaleLock indicates a seat lock acted on under a fencing token that
+	// another lock on the seat has since replaced
+	ErrStaleLock = errors.New("seat lock is held under a newer fencing token")
+
+	// ErrDoubleBooking indicates a seat write would hand a seat held by one
+	// order to another, or leave it held by no order on its flight
+	ErrDoubleBooking = errors.New("seat is held by another order")
+
 	// ErrInsufficientSeats indicates not enough seats available
 	ErrInsufficientSeats = errors.New("insufficient seats available")
 
@@ -24,6 +36,167 @@ var (
 	// ErrInvalidPaymentCode indicates the payment code format is invalid
 	ErrInvalidPaymentCode = errors.New("invalid payment code format")
 
+	// ErrInvalidPaymentMethod indicates a payment method the gateway does not take
+	ErrInvalidPaymentMethod = errors.New("unknown payment method")
+
 	// ErrPaymentFailed indicates payment validation failed
 	ErrPaymentFailed = errors.New("payment validation failed")
+
+	// ErrPaymentNotAwaited indicates a payment result for a workflow that is
+	// gone or already finished
+	ErrPaymentNotAwaited = errors.New("no payment awaiting this result")
+
+	// ErrSeatNotFound indicates a seat does not exist on the flight
+	ErrSeatNotFound = errors.New("seat not found")
+
+	// ErrSeatExists indicates a seat being added already exists on the flight
+	ErrSeatExists = errors.New("seat already exists")
+
+	// ErrSeatInUse indicates a seat is reserved or booked and cannot be changed
+	ErrSeatInUse = errors.New("seat is reserved or booked")
+
+	// ErrInvalidSeatChange indicates a malformed or contradictory seat map change
+	ErrInvalidSeatChange = errors.New("invalid seat map change")
+
+	// ErrSwapOfferNotFound indicates a seat swap offer was not found
+	ErrSwapOfferNotFound = errors.New("swap offer not found")
+
+	// ErrSwapNotAllowed indicates an order or offer is not eligible for a seat swap
+	ErrSwapNotAllowed = errors.New("seat swap not allowed")
+
+	// ErrInvalidPreferences indicates unknown notification channels or categories
+	ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+	// ErrUnsubscribeTokenNotFound indicates an unknown unsubscribe token
+	ErrUnsubscribeTokenNotFound = errors.New("unsubscribe token not found")
+
+	// ErrWebhookNotFound indicates a webhook subscription was not found
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+
+	// ErrInvalidWebhook indicates a malformed webhook URL or unknown event
+	ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+	// ErrHoldNotExtendable indicates the order is no longer holding seats
+	ErrHoldNotExtendable = errors.New("order is not holding seats")
+
+	// ErrHoldExtensionLimit indicates the order has used all its hold extensions
+	ErrHoldExtensionLimit = errors.New("hold extension limit reached")
+
+	// ErrInvalidPassengers indicates malformed passengers or a passenger-to-seat mismatch
+	ErrInvalidPassengers = errors.New("invalid passengers")
+
+	// ErrOrderNotConfirmed indicates the order has no itinerary because it is not confirmed
+	ErrOrderNotConfirmed = errors.New("order is not confirmed")
+
+	// ErrBoardingPassNotReady indicates a confirmed order's boarding pass has not been generated yet
+	ErrBoardingPassNotReady = errors.New("boarding pass not ready")
+
+	// ErrCheckInNotOpen indicates check-in before it opens, CheckInOpensBefore departure
+	ErrCheckInNotOpen = errors.New("check-in is not open yet")
+
+	// ErrCheckInClosed indicates check-in after the flight has departed
+	ErrCheckInClosed = errors.New("check-in has closed")
+
+	// ErrCabinMismatch indicates a check-in seat change that would move a passenger to another cabin
+	ErrCabinMismatch = errors.New("seats must stay in the same cabin")
+
+	// ErrSeatlessOrder indicates a seat change on an order whose seats are assigned at check-in
+	ErrSeatlessOrder = errors.New("seatless orders get seats at check-in")
+
+	// ErrTripNotFound indicates the trip does not exist
+	ErrTripNotFound = errors.New("trip not found")
+
+	// ErrInvalidTrip indicates trip legs that do not form a connecting itinerary
+	ErrInvalidTrip = errors.New("invalid trip")
+
+	// ErrTripLeg indicates a direct payment on an order that is paid through its trip
+	ErrTripLeg = errors.New("order is a trip leg; pay through the trip")
+
+	// ErrInvalidGroupSize indicates a group booking outside the group seat limits
+	ErrInvalidGroupSize = errors.New("invalid group size")
+
+	// ErrPartialNotApproved indicates payment on a partially reserved group booking before approval
+	ErrPartialNotApproved = errors.New("approve the partial group booking before paying")
+
+	// ErrNoPartialBooking indicates an approval for an order that is not awaiting one
+	ErrNoPartialBooking = errors.New("order has no partial booking to approve")
+
+	// ErrFlightCancelled indicates booking or checking in on a cancelled flight
+	ErrFlightCancelled = errors.New("flight is cancelled")
+
+	// ErrFlightFrozen indicates booking or changing seats on a flight an admin has frozen
+	ErrFlightFrozen = errors.New("bookings on this flight are frozen")
+
+	// ErrSalesClosed indicates booking or changing seats on a flight too close to departure
+	ErrSalesClosed = errors.New("sales on this flight are closed")
+
+	// ErrInvalidOverbooking indicates an overbooking percentage outside 0 to MaxOverbookingPercent
+	ErrInvalidOverbooking = errors.New("invalid overbooking percentage")
+
+	// ErrNoUpsellOffer indicates a reply to an upsell offer on an order that has none open
+	ErrNoUpsellOffer = errors.New("order has no open upsell offer")
+
+	// ErrNoPaymentChallenge indicates a challenge response for a payment with no open challenge
+	ErrNoPaymentChallenge = errors.New("no open payment challenge")
+
+	// ErrInvalidChallengeCode indicates a one-time code of the wrong shape
+	ErrInvalidChallengeCode = errors.New("invalid challenge code format")
+
+	// ErrDepositUnavailable indicates a deposit on an order that must be paid in
+	// full: deposits are disabled, or its balance would already be due
+	ErrDepositUnavailable = errors.New("deposit not available for this order")
+
+	// ErrOrderNotRefundable indicates a refund of an order that is not confirmed
+	ErrOrderNotRefundable = errors.New("only confirmed orders can be refunded")
+
+	// ErrRefundFailed indicates the payment provider did not return an order's payment
+	ErrRefundFailed = errors.New("refund failed")
+
+	// ErrManifestNotFound indicates a flight has no manifest because it has not departed
+	ErrManifestNotFound = errors.New("manifest not found")
+
+	// ErrUpdatePending indicates the workflow accepted a change but has not applied it yet
+	ErrUpdatePending = errors.New("order update still being applied")
+
+	// ErrVersionConflict indicates an order or seat changed since the version
+	// the writer read
+	ErrVersionConflict = errors.New("record was changed concurrently")
 )
+
+// SeatLockedError is ErrSeatsAlreadyLocked naming the first requested seat
+// another order holds, and that order
+type SeatLockedError struct {
+	SeatID  string
+	OrderID string
+}
+
+func (e *SeatLockedError) Error() string {
+	return fmt.Sprintf("seat %s already locked by order %s: %s", e.SeatID, e.OrderID, ErrSeatsAlreadyLocked)
+}
+
+func (e *SeatLockedError) Unwrap() error {
+	return ErrSeatsAlreadyLocked
+}
+
+// SeatsUnavailableError is ErrSeatUnavailable for seats other orders hold,
+// with SeatID, the seat whose lock stopped the request when known, and
+// RetryAfter, the time left on the first of their locks to expire
+type SeatsUnavailableError struct {
+	SeatID     string
+	RetryAfter time.Duration
+}
+
+func (e *SeatsUnavailableError) Error() string {
+	msg := ErrSeatUnavailable.Error()
+	if e.SeatID != "" {
+		msg = fmt.Sprintf("seat %s: %s", e.SeatID, msg)
+	}
+	if e.RetryAfter > 0 {
+		msg = fmt.Sprintf("%s; retry after %s", msg, e.RetryAfter.Round(time.Second))
+	}
+	return msg
+}
+
+func (e *SeatsUnavailableError) Unwrap() error {
+	return ErrSeatUnavailable
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // Flight represents a flight in the system
 type Flight struct {
@@ -13,8 +16,16 @@ type Flight struct {
 	TotalSeats     int       `json:"totalSeats"`
 	AvailableSeats int       `json:"availableSeats"`
 	PriceCents     int64     `json:"priceCents"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
+
+	Status       FlightStatus `json:"status"`
+	DelayMinutes int          `json:"delayMinutes,omitempty"` // total delay already added to departure and arrival
+
+	BookingFrozen      bool       `json:"bookingFrozen"`           // new bookings and seat changes are refused while set
+	SalesClosedAt      *time.Time `json:"salesClosedAt,omitempty"` // set when the departure workflow closes sales
+	OverbookingPercent int        `json:"overbookingPercent"`      // seatless capacity sold beyond the seat map, as a share of it
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 // FlightWithSeats represents a flight with its seat map
@@ -23,9 +34,70 @@ type FlightWithSeats struct {
 	SeatMap SeatMap `json:"seatMap"`
 }
 
+// ScheduledFlight is a flight to add to the schedule with its seat map
+type ScheduledFlight struct {
+	Flight Flight
+	Seats  []Seat
+}
+
+// ScheduleImport lists the flight numbers a schedule import added, or would
+// add, and those it skipped because a flight already has them
+type ScheduleImport struct {
+	Created []string
+	Skipped []string
+}
+
 // SeatMap represents the seat configuration of a flight
 type SeatMap struct {
-	Rows        int    `json:"rows"`
-	SeatsPerRow int    `json:"seatsPerRow"`
-	Seats       []Seat `json:"seats"`
+	Rows  []SeatRow `json:"rows"`
+	Seats []Seat    `json:"seats"`
+}
+
+// SeatRow is the layout of one row as it exists in the inventory, so cabins
+// with different widths or missing seats are described exactly
+type SeatRow struct {
+	Number  int        `json:"number"`
+	Columns []string   `json:"columns"` // seats present, in column order
+	Class   CabinClass `json:"class"`
+}
+
+// SeatRows groups seats into rows ordered by row number. A row takes the
+// class of its first seat.
+func SeatRows(seats []Seat) []SeatRow {
+	byRow := make(map[int]*SeatRow)
+	var numbers []int
+	for _, seat := range seats {
+		row, ok := byRow[seat.Row]
+		if !ok {
+			row = &SeatRow{Number: seat.Row, Class: seat.Class}
+			byRow[seat.Row] = row
+			numbers = append(numbers, seat.Row)
+		}
+		row.Columns = append(row.Columns, seat.Column)
+	}
+	slices.Sort(numbers)
+
+	rows := make([]SeatRow, len(numbers))
+	for i, n := range numbers {
+		rows[i] = *byRow[n]
+		slices.Sort(rows[i].Columns)
+	}
+	return rows
+}
+
+// FareDay is one day of a flexible-date fare calendar
+type FareDay struct {
+	Date               time.Time `json:"date"`
+	FlightCount        int       `json:"flightCount"`
+	CheapestPriceCents *int64    `json:"cheapestPriceCents,omitempty"`
+}
+
+// FlightRevenue is the revenue recognized on a flight's confirmed orders.
+// Refunded orders move from recognized into reversed.
+type FlightRevenue struct {
+	FlightID        string `json:"flightId"`
+	Orders          int    `json:"orders"`
+	RecognizedCents int64  `json:"recognizedCents"`
+	ReversedOrders  int    `json:"reversedOrders"`
+	ReversedCents   int64  `json:"reversedCents"`
 }
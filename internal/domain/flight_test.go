@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeatRows(t *testing.T) {
+	seats := []Seat{
+		{Row: 3, Column: "C", Class: CabinEconomy},
+		{Row: 1, Column: "D", Class: CabinBusiness},
+		{Row: 1, Column: "A", Class: CabinBusiness},
+		{Row: 3, Column: "A", Class: CabinEconomy},
+		{Row: 3, Column: "B", Class: CabinEconomy},
+	}
+
+	want := []SeatRow{
+		{Number: 1, Columns: []string{"A", "D"}, Class: CabinBusiness},
+		{Number: 3, Columns: []string{"A", "B", "C"}, Class: CabinEconomy},
+	}
+	if got := SeatRows(seats); !reflect.DeepEqual(got, want) {
+		t.Errorf("SeatRows = %+v; want %+v", got, want)
+	}
+
+	if got := SeatRows(nil); len(got) != 0 {
+		t.Errorf("SeatRows(nil) = %+v; want empty", got)
+	}
+}
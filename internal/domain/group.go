@@ -0,0 +1,7 @@
+package domain
+
+// Group booking limits; smaller parties book as a regular order
+const (
+	MinGroupSeats = 10
+	MaxGroupSeats = 100
+)
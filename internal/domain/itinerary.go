@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"crypto/rand"
+	"math/big"
+	"regexp"
+	"time"
+)
+
+// bookingReferenceAlphabet omits 0, 1, I and O, which are misread on printed receipts
+const bookingReferenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// BookingReferenceLength is the length of a PNR-style booking reference
+const BookingReferenceLength = 6
+
+// bookingReferencePattern matches references produced by NewBookingReference
+var bookingReferencePattern = regexp.MustCompile(`^[A-HJ-NP-Z2-9]{6}$`)
+
+// NewBookingReference returns a random PNR-style booking reference, e.g. K7QH2M
+func NewBookingReference() (string, error) {
+	ref := make([]byte, BookingReferenceLength)
+	limit := big.NewInt(int64(len(bookingReferenceAlphabet)))
+	for i := range ref {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", err
+		}
+		ref[i] = bookingReferenceAlphabet[n.Int64()]
+	}
+	return string(ref), nil
+}
+
+// IsValidBookingReference reports whether s is shaped like a booking reference
+func IsValidBookingReference(s string) bool {
+	return bookingReferencePattern.MatchString(s)
+}
+
+// Itinerary is the receipt for a confirmed order
+type Itinerary struct {
+	BookingReference string
+	OrderID          string
+	Flight           Flight
+	Seats            []string
+	Passengers       []Passenger
+	Price            PriceBreakdown
+	ConfirmedAt      time.Time
+	CheckedInAt      *time.Time // set once online check-in has completed
+	Deposit          *Deposit   // set when the order was confirmed on a deposit
+}
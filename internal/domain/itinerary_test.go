@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestNewBookingReference(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		ref, err := NewBookingReference()
+		if err != nil {
+			t.Fatalf("NewBookingReference: %v", err)
+		}
+		if !IsValidBookingReference(ref) {
+			t.Fatalf("reference %q is not valid", ref)
+		}
+		seen[ref] = true
+	}
+	if len(seen) < 990 {
+		t.Errorf("only %d distinct references in 1000", len(seen))
+	}
+}
+
+func TestIsValidBookingReference(t *testing.T) {
+	tests := map[string]bool{
+		"K7QH2M":  true,
+		"ABCDEF":  true,
+		"K7QH2":   false,
+		"K7QH2MX": false,
+		"k7qh2m":  false,
+		"K0QH2M":  false, // zero is excluded
+		"KOQH2M":  false, // so is the letter O
+		"K1QH2M":  false,
+	}
+	for ref, want := range tests {
+		if got := IsValidBookingReference(ref); got != want {
+			t.Errorf("IsValidBookingReference(%q) = %v, want %v", ref, got, want)
+		}
+	}
+}
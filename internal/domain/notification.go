@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// NotificationChannel is a delivery channel for order notifications
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email"
+	ChannelSMS   NotificationChannel = "sms"
+	ChannelPush  NotificationChannel = "push"
+)
+
+// NotificationCategory groups notifications a passenger can opt out of
+type NotificationCategory string
+
+const (
+	CategoryBooking   NotificationCategory = "booking"   // confirmation, expiry, failure
+	CategoryReminder  NotificationCategory = "reminder"  // upcoming departure, hold about to expire
+	CategoryMarketing NotificationCategory = "marketing" // offers and upgrades
+)
+
+// AllNotificationChannels lists every supported channel
+var AllNotificationChannels = []NotificationChannel{ChannelEmail, ChannelSMS, ChannelPush}
+
+// AllNotificationCategories lists every supported category
+var AllNotificationCategories = []NotificationCategory{CategoryBooking, CategoryReminder, CategoryMarketing}
+
+// NotificationPreferences controls which notifications an order receives
+type NotificationPreferences struct {
+	OrderID          string                 `json:"orderId"`
+	Channels         []NotificationChannel  `json:"channels"`
+	Categories       []NotificationCategory `json:"categories"`
+	UnsubscribeToken string                 `json:"-"`
+	UnsubscribedAt   *time.Time             `json:"unsubscribedAt,omitempty"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+// DefaultNotificationPreferences returns the preferences used before a passenger sets any
+func DefaultNotificationPreferences(orderID string) NotificationPreferences {
+	return NotificationPreferences{
+		OrderID:    orderID,
+		Channels:   []NotificationChannel{ChannelEmail},
+		Categories: []NotificationCategory{CategoryBooking, CategoryReminder},
+	}
+}
+
+// Allows reports whether a notification may be delivered on channel for category
+func (p NotificationPreferences) Allows(channel NotificationChannel, category NotificationCategory) bool {
+	if p.UnsubscribedAt != nil {
+		return false
+	}
+	return slices.Contains(p.Channels, channel) && slices.Contains(p.Categories, category)
+}
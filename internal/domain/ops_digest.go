@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// minPaymentFailureSpike is the fewest payment failures flagged as a spike,
+// so a quiet day going from one failure to three does not page anyone
+const minPaymentFailureSpike = 5
+
+// OpsDigest summarises one period of operational health for operators
+type OpsDigest struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	ExpiredOrders           int  `json:"expiredOrders"`
+	PaymentFailures         int  `json:"paymentFailures"`
+	PreviousPaymentFailures int  `json:"previousPaymentFailures"` // in the period before, for spike detection
+	PaymentFailureSpike     bool `json:"paymentFailureSpike"`
+	ReconciliationFixes     int  `json:"reconciliationFixes"` // orphaned seat locks released
+	DeadLetteredWebhooks    int  `json:"deadLetteredWebhooks"`
+}
+
+// FlagSpike marks a payment failure spike when failures reach factor times
+// the previous period's, and at least minPaymentFailureSpike; a factor of
+// zero or less never flags one
+func (d *OpsDigest) FlagSpike(factor float64) {
+	d.PaymentFailureSpike = factor > 0 &&
+		d.PaymentFailures >= minPaymentFailureSpike &&
+		float64(d.PaymentFailures) >= factor*float64(max(d.PreviousPaymentFailures, 1))
+}
+
+// Summary renders the digest as the plain-text body of the notification
+func (d OpsDigest) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Operations digest %s to %s UTC\n",
+		d.Since.UTC().Format("2006-01-02 15:04"), d.Until.UTC().Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "Expired orders: %d\n", d.ExpiredOrders)
+	fmt.Fprintf(&b, "Payment failures: %d (previous period %d)", d.PaymentFailures, d.PreviousPaymentFailures)
+	if d.PaymentFailureSpike {
+		b.WriteString(" SPIKE")
+	}
+	fmt.Fprintf(&b, "\nSeat locks repaired by reconciliation: %d\n", d.ReconciliationFixes)
+	fmt.Fprintf(&b, "Dead-lettered webhooks: %d", d.DeadLetteredWebhooks)
+	return b.String()
+}
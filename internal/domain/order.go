@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"regexp"
+	"time"
+)
 
 // OrderStatus represents the current status of an order
 type OrderStatus string
@@ -13,48 +16,111 @@ const (
 	OrderStatusConfirmed         OrderStatus = "CONFIRMED"
 	OrderStatusFailed            OrderStatus = "FAILED"
 	OrderStatusExpired           OrderStatus = "EXPIRED"
+	OrderStatusPaymentRefunded   OrderStatus = "PAYMENT_REFUNDED" // paid, then refunded: confirmation failed or an operator refunded it
+	OrderStatusCancelled         OrderStatus = "CANCELLED"        // its workflow was canceled by an operator before payment completed
 )
 
+// IsValid reports whether s is a status an order can have
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusCreated, OrderStatusSeatsReserved, OrderStatusPaymentPending, OrderStatusPaymentProcessing,
+		OrderStatusConfirmed, OrderStatusFailed, OrderStatusExpired, OrderStatusPaymentRefunded, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsFinished reports whether the order has ended without a booking, so it
+// should hold neither seats nor locks
+func (s OrderStatus) IsFinished() bool {
+	return s == OrderStatusFailed || s == OrderStatusExpired || s == OrderStatusPaymentRefunded ||
+		s == OrderStatusCancelled
+}
+
 // Order represents a booking order
 type Order struct {
-	ID              string      `json:"id"`
-	FlightID        string      `json:"flightId"`
-	WorkflowID      string      `json:"workflowId"`
-	Status          OrderStatus `json:"status"`
-	Seats           []string    `json:"seats"`
-	TotalPriceCents int64       `json:"totalPriceCents"`
-	PaymentCode     *string     `json:"paymentCode,omitempty"`
-	ExpiresAt       *time.Time  `json:"expiresAt,omitempty"`
-	ConfirmedAt     *time.Time  `json:"confirmedAt,omitempty"`
-	FailureReason   *string     `json:"failureReason,omitempty"`
-	CreatedAt       time.Time   `json:"createdAt"`
-	UpdatedAt       time.Time   `json:"updatedAt"`
+	ID               string         `json:"id"`
+	FlightID         string         `json:"flightId"`
+	WorkflowID       string         `json:"workflowId"`
+	Status           OrderStatus    `json:"status"`
+	Seats            []string       `json:"seats"`
+	TotalPriceCents  int64          `json:"totalPriceCents"`
+	Price            PriceBreakdown `json:"price"`
+	PaymentMethod    *PaymentMethod `json:"paymentMethod,omitempty"` // recorded with PaymentCode once a payment is authorized
+	PaymentCode      *string        `json:"paymentCode,omitempty"`
+	ExpiresAt        *time.Time     `json:"expiresAt,omitempty"`
+	ConfirmedAt      *time.Time     `json:"confirmedAt,omitempty"`
+	FailureReason    *string        `json:"failureReason,omitempty"`
+	BookingReference *string        `json:"bookingReference,omitempty"`
+	Seatless         bool           `json:"seatless,omitempty"`   // bought as cabin capacity; seats come at check-in
+	CabinSeats       int            `json:"cabinSeats,omitempty"` // capacity held without seats until check-in
+	TripID           *string        `json:"tripId,omitempty"`     // set on the legs of a connecting itinerary
+	CheckedInAt      *time.Time     `json:"checkedInAt,omitempty"`
+	PriorityBoarding bool           `json:"priorityBoarding,omitempty"` // bought with the payment-time upsell
+	Deposit          *Deposit       `json:"deposit,omitempty"`          // set when the order was confirmed on a deposit
+	Version          int            `json:"version"`                    // bumped by every write
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
 }
 
 // OrderStatusResponse represents the status response for polling
 type OrderStatusResponse struct {
-	OrderID         string      `json:"orderId"`
-	Status          OrderStatus `json:"status"`
-	Seats           []string    `json:"seats"`
-	TimerRemaining  int         `json:"timerRemaining"` // seconds
-	PaymentAttempts int         `json:"paymentAttempts"`
-	LastError       string      `json:"lastError,omitempty"`
+	OrderID         string         `json:"orderId"`
+	Status          OrderStatus    `json:"status"`
+	Seats           []string       `json:"seats"`
+	TimerRemaining  int            `json:"timerRemaining"` // seconds
+	PaymentAttempts int            `json:"paymentAttempts"`
+	LastError       string         `json:"lastError,omitempty"`
+	Price           PriceBreakdown `json:"price"`
+	Passengers      []Passenger    `json:"passengers,omitempty"`
+	Seatless        bool           `json:"seatless,omitempty"`
+	SeatCount       int            `json:"seatCount"`
+	TripID          string         `json:"tripId,omitempty"`
+
+	UnavailableSeats []string `json:"unavailableSeats,omitempty"` // group seats that could not be held
+	AwaitingApproval bool     `json:"awaitingApproval,omitempty"`
+
+	HoldExtensionsLeft int `json:"holdExtensionsLeft"`
+
+	UpsellOffer *UpsellOffer `json:"upsellOffer,omitempty"` // open between the payment signal and taking payment
+
+	PaymentChallenge *PaymentChallenge `json:"paymentChallenge,omitempty"` // open while the issuer waits for a one-time code
+
+	Deposit *Deposit `json:"deposit,omitempty"` // set once a deposit was chosen at payment
+}
+
+// OrderConfirmation is everything confirming an order writes at once
+type OrderConfirmation struct {
+	OrderID          string
+	FlightID         string
+	Seats            []string
+	Passengers       []Passenger
+	BookingReference string // kept only if the order has none yet
+	LockToken        int64  // fencing token the seats were reserved under; zero skips the check
 }
 
 // IsTerminal returns true if the order is in a final state
 func (o *Order) IsTerminal() bool {
 	return o.Status == OrderStatusConfirmed ||
 		o.Status == OrderStatusFailed ||
-		o.Status == OrderStatusExpired
+		o.Status == OrderStatusExpired ||
+		o.Status == OrderStatusPaymentRefunded ||
+		o.Status == OrderStatusCancelled
+}
+
+// HoldsSeats reports whether an order in status still holds its seats while
+// it waits for payment, including after a declined payment
+func HoldsSeats(status OrderStatus) bool {
+	return status == OrderStatusSeatsReserved || status == OrderStatusPaymentPending
 }
 
 // CanTransitionTo checks if the order can transition to the given status
 func (o *Order) CanTransitionTo(status OrderStatus) bool {
 	validTransitions := map[OrderStatus][]OrderStatus{
-		OrderStatusCreated:           {OrderStatusSeatsReserved, OrderStatusFailed},
-		OrderStatusSeatsReserved:     {OrderStatusPaymentPending, OrderStatusExpired, OrderStatusFailed},
-		OrderStatusPaymentPending:    {OrderStatusPaymentProcessing, OrderStatusExpired, OrderStatusFailed},
-		OrderStatusPaymentProcessing: {OrderStatusConfirmed, OrderStatusFailed},
+		OrderStatusCreated:           {OrderStatusSeatsReserved, OrderStatusFailed, OrderStatusCancelled},
+		OrderStatusSeatsReserved:     {OrderStatusPaymentPending, OrderStatusExpired, OrderStatusFailed, OrderStatusCancelled},
+		OrderStatusPaymentPending:    {OrderStatusPaymentProcessing, OrderStatusExpired, OrderStatusFailed, OrderStatusCancelled},
+		OrderStatusPaymentProcessing: {OrderStatusConfirmed, OrderStatusPaymentPending, OrderStatusFailed, OrderStatusPaymentRefunded, OrderStatusCancelled},
 	}
 
 	allowed, exists := validTransitions[o.Status]
@@ -69,3 +135,28 @@ func (o *Order) CanTransitionTo(status OrderStatus) bool {
 	}
 	return false
 }
+
+// paymentCodePattern matches the simulator's 5-digit payment codes
+var paymentCodePattern = regexp.MustCompile(`^\d{5}$`)
+
+// IsValidPaymentCode reports whether code has the card payment code shape
+func IsValidPaymentCode(code string) bool {
+	return paymentCodePattern.MatchString(code)
+}
+
+// PaymentChallenge is an open verification step, like 3-D Secure, that the
+// card issuer requires before a payment is taken. The customer answers it
+// with the one-time code they were sent.
+type PaymentChallenge struct {
+	AuthorizationID string    `json:"authorizationId"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	AttemptsLeft    int       `json:"attemptsLeft"` // wrong codes allowed before the payment is declined
+}
+
+// challengeCodePattern matches the 6-digit one-time codes of payment challenges
+var challengeCodePattern = regexp.MustCompile(`^\d{6}$`)
+
+// IsValidChallengeCode reports whether code has the one-time code shape
+func IsValidChallengeCode(code string) bool {
+	return challengeCodePattern.MatchString(code)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// OrderEventType is what happened to an order
+type OrderEventType string
+
+const (
+	OrderEventSeatsReserved OrderEventType = "SEATS_RESERVED" // data: seats
+	OrderEventSeatsChanged  OrderEventType = "SEATS_CHANGED"  // data: from, to
+	OrderEventSeatsReleased OrderEventType = "SEATS_RELEASED" // data: seats
+	OrderEventPaymentFailed OrderEventType = "PAYMENT_FAILED" // data: attempt, message
+	OrderEventConfirmed     OrderEventType = "CONFIRMED"      // data: seats
+	OrderEventStatusChanged OrderEventType = "STATUS_CHANGED" // data: from, to
+)
+
+// OrderEvent is one entry of an order's history, as the booking activities
+// recorded it. Unlike the workflow's Temporal history it is kept for as long
+// as the order, archived orders included.
+type OrderEvent struct {
+	ID         int64
+	OrderID    string
+	Type       OrderEventType
+	Data       map[string]interface{}
+	OccurredAt time.Time
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// Bounds on how many orders one page of a listing holds
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// OrderFilter narrows an order listing; zero fields match every order
+type OrderFilter struct {
+	Statuses      []OrderStatus // any of them
+	FlightID      string
+	CreatedAfter  *time.Time // inclusive
+	CreatedBefore *time.Time // exclusive
+}
+
+// OrderSort is what an order listing is sorted by
+type OrderSort string
+
+const (
+	OrderSortCreatedAt OrderSort = "createdAt"
+	OrderSortPrice     OrderSort = "price" // total price
+)
+
+// IsValid reports whether s is a sort an order listing supports
+func (s OrderSort) IsValid() bool {
+	return s == OrderSortCreatedAt || s == OrderSortPrice
+}
+
+// Page selects one page of a listing. A zero Limit takes DefaultPageLimit,
+// an empty Sort sorts by creation, and Descending puts the newest or most
+// expensive first.
+type Page struct {
+	Limit      int
+	Offset     int
+	Sort       OrderSort
+	Descending bool
+}
+
+// Normalized returns the page with its limit defaulted and bounded, and its
+// offset and sort defaulted
+func (p Page) Normalized() Page {
+	switch {
+	case p.Limit <= 0:
+		p.Limit = DefaultPageLimit
+	case p.Limit > MaxPageLimit:
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	if !p.Sort.IsValid() {
+		p.Sort = OrderSortCreatedAt
+	}
+	return p
+}
+
+// OrderPage is one page of an order listing and how many orders matched its
+// filter across every page
+type OrderPage struct {
+	Orders []*Order
+	Total  int
+	Page   Page
+}
+
+// OrderArchival is what one archival batch moved out of the hot tables
+type OrderArchival struct {
+	Orders int   // orders moved to orders_archive
+	Pruned int64 // webhook deliveries and seat lock repairs of those orders deleted
+}
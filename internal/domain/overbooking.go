@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// MaxOverbookingPercent is the most a flight may be overbooked, as a share of
+// its seats
+const MaxOverbookingPercent = 50
+
+// OverbookingAllowance is how many seats beyond its seat map the flight may
+// sell as seatless capacity
+func (f *Flight) OverbookingAllowance() int {
+	return f.TotalSeats * f.OverbookingPercent / 100
+}
+
+// BumpStatus is how a bumped order was reaccommodated
+type BumpStatus string
+
+const (
+	BumpStatusRebooked BumpStatus = "rebooked" // moved to a later flight on the same route
+	BumpStatusRefunded BumpStatus = "refunded" // no later flight had room; the fare is refunded
+)
+
+// BumpPolicy is how the departure workflow reaccommodates orders on an
+// oversold flight
+type BumpPolicy struct {
+	CompensationCents int64         `json:"compensationCents"` // paid per seat to a rebooked order; refunded ones get twice this
+	RebookWindow      time.Duration `json:"rebookWindow"`      // how long after the original departure an alternative may leave
+}
+
+// Compensation is what a bumped order of seats is paid
+func (p BumpPolicy) Compensation(seats int, status BumpStatus) int64 {
+	amount := p.CompensationCents * int64(seats)
+	if status == BumpStatusRefunded {
+		amount *= 2
+	}
+	return amount
+}
+
+// BumpCandidate is a confirmed seatless order that has not checked in, so
+// it holds capacity without seats and can be moved
+type BumpCandidate struct {
+	OrderID     string    `json:"orderId"`
+	Seats       int       `json:"seats"`
+	ConfirmedAt time.Time `json:"confirmedAt"`
+}
+
+// SelectBumps picks the orders to bump off a flight oversold by oversold
+// seats: the most recently confirmed first, until enough seats are freed.
+// An order is never split, so a little more than oversold may be freed.
+func SelectBumps(candidates []BumpCandidate, oversold int) []BumpCandidate {
+	if oversold <= 0 {
+		return nil
+	}
+
+	ordered := append([]BumpCandidate(nil), candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ConfirmedAt.After(ordered[j].ConfirmedAt)
+	})
+
+	var selected []BumpCandidate
+	for _, c := range ordered {
+		if oversold <= 0 {
+			break
+		}
+		selected = append(selected, c)
+		oversold -= c.Seats
+	}
+	return selected
+}
+
+// Bump records an order bumped off its flight and what it was offered
+type Bump struct {
+	OrderID             string     `json:"orderId"`
+	FlightID            string     `json:"flightId"` // the oversold flight
+	Seats               int        `json:"seats"`
+	Status              BumpStatus `json:"status"`
+	AlternativeFlightID *string    `json:"alternativeFlightId,omitempty"`
+	CompensationCents   int64      `json:"compensationCents"`
+	CreatedAt           time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectBumps(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	candidates := []BumpCandidate{
+		{OrderID: "first", Seats: 1, ConfirmedAt: base},
+		{OrderID: "third", Seats: 3, ConfirmedAt: base.Add(2 * time.Hour)},
+		{OrderID: "second", Seats: 2, ConfirmedAt: base.Add(time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		oversold int
+		want     []string
+	}{
+		{"not oversold", 0, nil},
+		{"latest covers it", 2, []string{"third"}},
+		{"exactly two orders", 5, []string{"third", "second"}},
+		{"parties are not split", 4, []string{"third", "second"}},
+		{"more than candidates hold", 10, []string{"third", "second", "first"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range SelectBumps(candidates, tt.oversold) {
+				got = append(got, c.OrderID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SelectBumps = %v; want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("SelectBumps = %v; want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if candidates[0].OrderID != "first" {
+		t.Error("SelectBumps reordered its input")
+	}
+}
+
+func TestBumpCompensation(t *testing.T) {
+	policy := BumpPolicy{CompensationCents: 25000}
+	if got := policy.Compensation(2, BumpStatusRebooked); got != 50000 {
+		t.Errorf("rebooked compensation = %d; want 50000", got)
+	}
+	if got := policy.Compensation(2, BumpStatusRefunded); got != 100000 {
+		t.Errorf("refunded compensation = %d; want 100000", got)
+	}
+}
+
+func TestOverbookingAllowance(t *testing.T) {
+	flight := Flight{TotalSeats: 150, OverbookingPercent: 5}
+	if got := flight.OverbookingAllowance(); got != 7 {
+		t.Errorf("OverbookingAllowance = %d; want 7", got)
+	}
+}
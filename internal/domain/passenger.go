@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"net/mail"
+	"regexp"
+	"slices"
+)
+
+// Passenger is a traveller on an order, assigned to one of its seats
+type Passenger struct {
+	SeatID         string `json:"seatId,omitempty"` // empty when a seat change left the passenger unseated
+	Name           string `json:"name"`
+	DocumentNumber string `json:"documentNumber"`
+	Email          string `json:"email"`
+}
+
+// MaxPassengerNameLength bounds a passenger's name as stored
+const MaxPassengerNameLength = 100
+
+// documentPattern matches passport and national ID numbers
+var documentPattern = regexp.MustCompile(`^[A-Z0-9]{5,20}$`)
+
+// IsValidDocumentNumber reports whether s looks like a travel document number
+func IsValidDocumentNumber(s string) bool {
+	return documentPattern.MatchString(s)
+}
+
+// IsValidEmail reports whether s is a bare email address
+func IsValidEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// AssignSeats re-seats passengers after a seat change. Passengers keep seats
+// that are still selected; the rest take the remaining seats in order, and
+// are left unseated once seats run out.
+func AssignSeats(passengers []Passenger, seats []string) []Passenger {
+	taken := make(map[string]bool, len(seats))
+	out := make([]Passenger, len(passengers))
+	for i, p := range passengers {
+		if p.SeatID != "" && slices.Contains(seats, p.SeatID) && !taken[p.SeatID] {
+			taken[p.SeatID] = true
+		} else {
+			p.SeatID = ""
+		}
+		out[i] = p
+	}
+
+	free := 0
+	for i := range out {
+		if out[i].SeatID != "" {
+			continue
+		}
+		for free < len(seats) && taken[seats[free]] {
+			free++
+		}
+		if free == len(seats) {
+			break
+		}
+		out[i].SeatID = seats[free]
+		taken[seats[free]] = true
+	}
+
+	return out
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestAssignSeats(t *testing.T) {
+	passengers := []Passenger{{SeatID: "1A", Name: "Ada"}, {SeatID: "1B", Name: "Grace"}, {SeatID: "1C", Name: "Alan"}}
+
+	tests := []struct {
+		name  string
+		seats []string
+		want  []string
+	}{
+		{"unchanged", []string{"1A", "1B", "1C"}, []string{"1A", "1B", "1C"}},
+		{"one seat moved", []string{"1A", "2B", "1C"}, []string{"1A", "2B", "1C"}},
+		{"all moved", []string{"3A", "3B", "3C"}, []string{"3A", "3B", "3C"}},
+		{"fewer seats", []string{"1B"}, []string{"", "1B", ""}},
+		{"released", nil, []string{"", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssignSeats(passengers, tt.seats)
+			for i, p := range got {
+				if p.SeatID != tt.want[i] || p.Name != passengers[i].Name {
+					t.Errorf("passenger %d: got %+v, want seat %q", i, p, tt.want[i])
+				}
+			}
+		})
+	}
+}
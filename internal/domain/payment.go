@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// PaymentMethod is what a customer pays with. Each method takes codes of its
+// own shape; an empty method pays by card.
+type PaymentMethod string
+
+const (
+	PaymentMethodCard    PaymentMethod = "card"    // a 5-digit simulated card code
+	PaymentMethodWallet  PaymentMethod = "wallet"  // a token issued by a digital wallet
+	PaymentMethodVoucher PaymentMethod = "voucher" // a prepaid voucher, redeemed whole
+)
+
+// paymentMethodCodes are the code shapes each payment method takes, and how
+// they are described to a customer who gets one wrong
+var paymentMethodCodes = map[PaymentMethod]struct {
+	pattern *regexp.Regexp
+	format  string
+}{
+	PaymentMethodCard:    {paymentCodePattern, "5 digits"},
+	PaymentMethodWallet:  {regexp.MustCompile(`^[A-Za-z0-9_-]{8,64}$`), "a wallet token of 8 to 64 letters, digits, - or _"},
+	PaymentMethodVoucher: {regexp.MustCompile(`^[A-Z0-9]{4}-[A-Z0-9]{4}-[A-Z0-9]{4}$`), "a voucher code like ABCD-1234-EFGH"},
+}
+
+// OrCard returns the method, or card when it is unset
+func (m PaymentMethod) OrCard() PaymentMethod {
+	if m == "" {
+		return PaymentMethodCard
+	}
+	return m
+}
+
+// Valid reports whether m is a payment method the gateway takes; an empty
+// method is card
+func (m PaymentMethod) Valid() bool {
+	_, ok := paymentMethodCodes[m.OrCard()]
+	return ok
+}
+
+// ValidCode reports whether code has the shape m takes
+func (m PaymentMethod) ValidCode(code string) bool {
+	codes, ok := paymentMethodCodes[m.OrCard()]
+	return ok && codes.pattern.MatchString(code)
+}
+
+// CodeFormat describes the code shape m takes
+func (m PaymentMethod) CodeFormat() string {
+	return paymentMethodCodes[m.OrCard()].format
+}
+
+// PaymentStatus is how far a payment attempt got at the payment provider
+type PaymentStatus string
+
+const (
+	PaymentStarted    PaymentStatus = "STARTED"    // key persisted; the provider may or may not have seen it
+	PaymentFailed     PaymentStatus = "FAILED"     // the provider erred; it may still have taken the payment
+	PaymentAuthorized PaymentStatus = "AUTHORIZED" // approved but not captured yet
+	PaymentPending    PaymentStatus = "PENDING"    // settled by the provider out of band
+	PaymentChallenged PaymentStatus = "CHALLENGED" // held until the customer's one-time code
+	PaymentCaptured   PaymentStatus = "CAPTURED"
+	PaymentDeclined   PaymentStatus = "DECLINED"
+	PaymentVoided     PaymentStatus = "VOIDED" // authorized, then released when its capture failed
+)
+
+// Payment is one attempt to take an order's payment, recorded under its
+// idempotency key before the payment provider is called. OrderID is the
+// trip's ID for a trip's payment. Scope is the workflow run taking the
+// payment, so attempts number from 1 in each run. An attempt that finishes
+// a lost earlier one shares its idempotency key, and the earlier attempt
+// records it in ResumedByAttempt.
+type Payment struct {
+	ID               string        `json:"id"`
+	IdempotencyKey   string        `json:"idempotencyKey"`
+	OrderID          string        `json:"orderId"`
+	Method           PaymentMethod `json:"method"`
+	Scope            string        `json:"scope"`
+	Attempt          int           `json:"attempt"`
+	AmountCents      int64         `json:"amountCents"` // zero when a trip's whole price was charged
+	Status           PaymentStatus `json:"status"`
+	ProviderRef      *string       `json:"providerRef,omitempty"` // the provider's authorization ID
+	Message          string        `json:"message,omitempty"`
+	Error            *string       `json:"error,omitempty"`
+	ResumedByAttempt *int          `json:"resumedByAttempt,omitempty"`
+	CreatedAt        time.Time     `json:"createdAt"`
+	UpdatedAt        time.Time     `json:"updatedAt"`
+}
+
+// PaymentKey derives the idempotency key of an order's payment attempt within
+// scope
+func PaymentKey(orderID, scope string, attempt int) string {
+	return orderID + ":" + scope + ":" + strconv.Itoa(attempt)
+}
+
+// Resumable reports whether a later attempt in the same scope must finish
+// the payment rather than start another, because the provider may already
+// have taken it
+func (p Payment) Resumable() bool {
+	switch p.Status {
+	case PaymentStarted, PaymentFailed, PaymentAuthorized:
+		return p.ResumedByAttempt == nil
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestPaymentMethodValidCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		method PaymentMethod
+		code   string
+		want   bool
+	}{
+		{"card", PaymentMethodCard, "12345", true},
+		{"unset method is card", "", "12345", true},
+		{"card too short", PaymentMethodCard, "1234", false},
+		{"wallet token", PaymentMethodWallet, "wlt_9f8E-2a1b", true},
+		{"wallet token too short", PaymentMethodWallet, "wlt_1", false},
+		{"card code is no wallet token", PaymentMethodWallet, "12345", false},
+		{"voucher", PaymentMethodVoucher, "ABCD-1234-EFGH", true},
+		{"voucher in lower case", PaymentMethodVoucher, "abcd-1234-efgh", false},
+		{"unknown method", PaymentMethod("crypto"), "12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.method.ValidCode(tt.code); got != tt.want {
+				t.Errorf("%q.ValidCode(%q) = %v, want %v", tt.method, tt.code, got, tt.want)
+			}
+		})
+	}
+
+	if PaymentMethod("crypto").Valid() || !PaymentMethod("").Valid() {
+		t.Error("Valid should reject unknown methods and take an unset one as card")
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+// PriceBreakdown is the price locked on an order when it was quoted.
+// Per-seat amounts are kept so seat changes rescale the quote instead of
+// re-reading the flight's current fare.
+type PriceBreakdown struct {
+	QuoteID           string `json:"quoteId"`
+	SeatCount         int    `json:"seatCount"`
+	UnitFareCents     int64  `json:"unitFareCents"`
+	UnitFeeCents      int64  `json:"unitFeeCents"`
+	UnitDiscountCents int64  `json:"unitDiscountCents"`
+	UnitUpsellCents   int64  `json:"unitUpsellCents,omitempty"` // cabin upgrade and priority boarding accepted at payment
+	BaseFareCents     int64  `json:"baseFareCents"`
+	FeesCents         int64  `json:"feesCents"`
+	DiscountCents     int64  `json:"discountCents"`
+	UpsellCents       int64  `json:"upsellCents,omitempty"`
+	TotalCents        int64  `json:"totalCents"`
+}
+
+// ForSeats returns the same quote applied to a different number of seats
+func (p PriceBreakdown) ForSeats(seatCount int) PriceBreakdown {
+	n := int64(seatCount)
+	p.SeatCount = seatCount
+	p.BaseFareCents = p.UnitFareCents * n
+	p.FeesCents = p.UnitFeeCents * n
+	p.DiscountCents = p.UnitDiscountCents * n
+	p.UpsellCents = p.UnitUpsellCents * n
+	p.TotalCents = p.BaseFareCents + p.FeesCents - p.DiscountCents + p.UpsellCents
+	return p
+}
+
+// WithUpsell returns the quote with unitCents more charged per seat for
+// extras accepted at payment
+func (p PriceBreakdown) WithUpsell(unitCents int64) PriceBreakdown {
+	p.UnitUpsellCents += unitCents
+	return p.ForSeats(p.SeatCount)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PricePoint raises a flight's fare to Multiplier times its base price once
+// Occupancy, the share of its seats sold, is reached
+type PricePoint struct {
+	Occupancy  float64 `json:"occupancy"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// PricingCurve is the step curve dynamic pricing follows, ordered by
+// occupancy. Below its first point a flight sells at its base price; an
+// empty curve disables dynamic pricing.
+type PricingCurve []PricePoint
+
+// ParsePricingCurve parses a curve written as comma-separated
+// occupancy:multiplier points, such as "0.5:1.1,0.8:1.3,0.95:1.6"
+func ParsePricingCurve(s string) (PricingCurve, error) {
+	var curve PricingCurve
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		occupancy, multiplier, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("pricing curve point %q is not occupancy:multiplier", part)
+		}
+		var p PricePoint
+		var err error
+		if p.Occupancy, err = strconv.ParseFloat(strings.TrimSpace(occupancy), 64); err != nil {
+			return nil, fmt.Errorf("pricing curve point %q: bad occupancy: %w", part, err)
+		}
+		if p.Multiplier, err = strconv.ParseFloat(strings.TrimSpace(multiplier), 64); err != nil {
+			return nil, fmt.Errorf("pricing curve point %q: bad multiplier: %w", part, err)
+		}
+		if p.Occupancy <= 0 || p.Occupancy > 1 {
+			return nil, fmt.Errorf("pricing curve point %q: occupancy must be in (0, 1]", part)
+		}
+		if p.Multiplier <= 0 {
+			return nil, fmt.Errorf("pricing curve point %q: multiplier must be positive", part)
+		}
+		curve = append(curve, p)
+	}
+
+	sort.Slice(curve, func(i, j int) bool { return curve[i].Occupancy < curve[j].Occupancy })
+	for i := 1; i < len(curve); i++ {
+		if curve[i].Occupancy == curve[i-1].Occupancy {
+			return nil, fmt.Errorf("pricing curve has two points at occupancy %g", curve[i].Occupancy)
+		}
+	}
+	return curve, nil
+}
+
+// Multiplier is the factor applied to the base price of a flight with
+// availableSeats of totalSeats left. Overbooked flights count as full.
+func (c PricingCurve) Multiplier(totalSeats, availableSeats int) float64 {
+	if totalSeats <= 0 {
+		return 1
+	}
+	occupancy := float64(totalSeats-availableSeats) / float64(totalSeats)
+
+	multiplier := 1.0
+	for _, p := range c {
+		if occupancy < p.Occupancy {
+			break
+		}
+		multiplier = p.Multiplier
+	}
+	return multiplier
+}
+
+// Price is the fare of a flight with the given base price and occupancy,
+// rounded to the cent
+func (c PricingCurve) Price(baseCents int64, totalSeats, availableSeats int) int64 {
+	return int64(math.Round(float64(baseCents) * c.Multiplier(totalSeats, availableSeats)))
+}
+
+// PriceChangeReason is what triggered a dynamic price change
+type PriceChangeReason string
+
+const (
+	PriceChangeConfirmation PriceChangeReason = "confirmation" // an order was confirmed on, or released from, the flight
+	PriceChangeSchedule     PriceChangeReason = "schedule"     // the periodic repricing workflow
+)
+
+// PriceChange records one change dynamic pricing made to a flight's fare
+type PriceChange struct {
+	FlightID           string            `json:"flightId"`
+	PriceCents         int64             `json:"priceCents"`
+	PreviousPriceCents int64             `json:"previousPriceCents"`
+	BasePriceCents     int64             `json:"basePriceCents"`
+	TotalSeats         int               `json:"totalSeats"`
+	AvailableSeats     int               `json:"availableSeats"`
+	Reason             PriceChangeReason `json:"reason"`
+	OrderID            *string           `json:"orderId,omitempty"` // the order whose confirmation triggered it
+	ChangedAt          time.Time         `json:"changedAt"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestParsePricingCurve(t *testing.T) {
+	curve, err := ParsePricingCurve(" 0.8:1.3, 0.5:1.1 ,")
+	if err != nil {
+		t.Fatalf("ParsePricingCurve: %v", err)
+	}
+	want := PricingCurve{{Occupancy: 0.5, Multiplier: 1.1}, {Occupancy: 0.8, Multiplier: 1.3}}
+	if len(curve) != len(want) {
+		t.Fatalf("ParsePricingCurve = %v; want %v", curve, want)
+	}
+	for i := range curve {
+		if curve[i] != want[i] {
+			t.Fatalf("ParsePricingCurve = %v; want %v", curve, want)
+		}
+	}
+
+	if curve, err := ParsePricingCurve(""); err != nil || len(curve) != 0 {
+		t.Errorf("ParsePricingCurve(\"\") = %v, %v; want an empty curve", curve, err)
+	}
+
+	for _, bad := range []string{"0.5", "half:1.1", "0.5:x", "0:1.1", "1.5:1.1", "0.5:0", "0.5:1.1,0.5:1.2"} {
+		if _, err := ParsePricingCurve(bad); err == nil {
+			t.Errorf("ParsePricingCurve(%q) succeeded; want an error", bad)
+		}
+	}
+}
+
+func TestPricingCurvePrice(t *testing.T) {
+	curve := PricingCurve{{Occupancy: 0.5, Multiplier: 1.1}, {Occupancy: 0.8, Multiplier: 1.5}}
+
+	tests := []struct {
+		name      string
+		curve     PricingCurve
+		total     int
+		available int
+		want      int64
+	}{
+		{"empty flight", curve, 100, 100, 10000},
+		{"just below a step", curve, 100, 51, 10000},
+		{"at a step", curve, 100, 50, 11000},
+		{"between steps", curve, 100, 30, 11000},
+		{"last step", curve, 100, 20, 15000},
+		{"overbooked counts as full", curve, 100, -5, 15000},
+		{"no curve", nil, 100, 0, 10000},
+		{"no seats", curve, 0, 0, 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.curve.Price(10000, tt.total, tt.available); got != tt.want {
+				t.Errorf("Price = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// RefundStatus represents the outcome of a refund at the payment provider
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "PENDING"
+	RefundSucceeded RefundStatus = "SUCCEEDED"
+	RefundFailed    RefundStatus = "FAILED"
+)
+
+// Refund returns a confirmed order's payment. An order is refunded at most
+// once; a failed refund may be tried again.
+type Refund struct {
+	ID          string       `json:"id"`
+	OrderID     string       `json:"orderId"`
+	AmountCents int64        `json:"amountCents"`
+	Reason      string       `json:"reason"`
+	Status      RefundStatus `json:"status"`
+	Error       *string      `json:"error,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+}
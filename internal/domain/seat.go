@@ -1,6 +1,15 @@
 package domain
 
-import "time"
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // SeatStatus represents the current status of a seat
 type SeatStatus string
@@ -9,16 +18,180 @@ const (
 	SeatStatusAvailable SeatStatus = "available"
 	SeatStatusReserved  SeatStatus = "reserved"
 	SeatStatusBooked    SeatStatus = "booked"
+	SeatStatusBlocked   SeatStatus = "blocked"
+)
+
+// CabinClass is the cabin a seat belongs to
+type CabinClass string
+
+const (
+	CabinEconomy        CabinClass = "economy"
+	CabinPremiumEconomy CabinClass = "premium_economy"
+	CabinBusiness       CabinClass = "business"
+	CabinFirst          CabinClass = "first"
 )
 
+// IsValidCabinClass reports whether class is a known cabin
+func IsValidCabinClass(class CabinClass) bool {
+	return slices.Contains([]CabinClass{CabinEconomy, CabinPremiumEconomy, CabinBusiness, CabinFirst}, class)
+}
+
 // Seat represents an individual seat on a flight
 type Seat struct {
 	ID        string     `json:"id"`
 	FlightID  string     `json:"flightId"`
 	Row       int        `json:"row"`
 	Column    string     `json:"column"`
+	Class     CabinClass `json:"class"`
 	Status    SeatStatus `json:"status"`
 	OrderID   *string    `json:"orderId,omitempty"`
+	Version   int        `json:"version"` // bumped by every write
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt time.Time  `json:"updatedAt"`
+	// HeldUntil is when the hold on a reserved seat ends, set only on seat
+	// maps read with live locks
+	HeldUntil *time.Time `json:"heldUntil,omitempty"`
+}
+
+// SeatLockGrace is how much longer a seat lock lives than the hold it backs,
+// so the booking's hold timer fires before the lock lapses
+const SeatLockGrace = time.Minute
+
+// SeatLock is an order's hold on a seat in Redis
+type SeatLock struct {
+	OrderID    string    `json:"orderId"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"` // when the lock lapses, SeatLockGrace after the hold ends
+}
+
+// HoldEndsAt is when the hold the lock backs ends
+func (l SeatLock) HoldEndsAt() time.Time {
+	return l.ExpiresAt.Add(-SeatLockGrace)
+}
+
+// SeatMapChange is an admin edit of a flight's seat inventory
+type SeatMapChange struct {
+	Add     []Seat
+	Remove  []string
+	Block   []string
+	Unblock []string
+}
+
+// SeatPosition is a preferred seat placement within a row
+type SeatPosition string
+
+const (
+	SeatPositionAny    SeatPosition = ""
+	SeatPositionWindow SeatPosition = "window"
+	SeatPositionAisle  SeatPosition = "aisle"
+)
+
+// CabinZone is a preferred area of the cabin
+type CabinZone string
+
+const (
+	CabinZoneAny   CabinZone = ""
+	CabinZoneFront CabinZone = "front"
+	CabinZoneBack  CabinZone = "back"
+)
+
+// SeatPreferences describes what a passenger party wants from a seat assignment
+type SeatPreferences struct {
+	Position  SeatPosition
+	Zone      CabinZone
+	Quiet     bool
+	PartySize int // seats needed side by side in one row; 0 or 1 means a single seat
+}
+
+// SeatRecommendation is a ranked candidate seat group
+type SeatRecommendation struct {
+	Seats   []string
+	Score   float64
+	Reasons []string
+}
+
+// seatIDPattern matches seat IDs made of a row number and a single column letter
+var seatIDPattern = regexp.MustCompile(`^[1-9]\d{0,2}[A-Z]$`)
+
+// IsValidSeatID reports whether id is shaped like a seat ID, e.g. 12C
+func IsValidSeatID(id string) bool {
+	return seatIDPattern.MatchString(id)
+}
+
+// NormalizeSeats returns a copy of seats trimmed, upper-cased and sorted by
+// row, then column, so a selection reads the same whatever order it was
+// picked in
+func NormalizeSeats(seats []string) []string {
+	if seats == nil {
+		return nil
+	}
+	out := make([]string, len(seats))
+	for i, seat := range seats {
+		out[i] = strings.ToUpper(strings.TrimSpace(seat))
+	}
+	slices.SortFunc(out, compareSeats)
+	return out
+}
+
+// compareSeats orders seat IDs by row number, then by column
+func compareSeats(a, b string) int {
+	rowA, colA := splitSeatID(a)
+	rowB, colB := splitSeatID(b)
+	if c := cmp.Compare(rowA, rowB); c != 0 {
+		return c
+	}
+	return cmp.Compare(colA, colB)
+}
+
+// splitSeatID splits a seat ID into its row number and column; IDs without a
+// leading row number sort first
+func splitSeatID(id string) (int, string) {
+	i := strings.IndexFunc(id, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 0 {
+		i = len(id)
+	}
+	row, err := strconv.Atoi(id[:i])
+	if err != nil {
+		return 0, id
+	}
+	return row, id[i:]
+}
+
+// SeatsHash fingerprints a seat selection independent of the order its seats
+// are listed in, for comparing selections across retries and logs
+func SeatsHash(seats []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(NormalizeSeats(seats), ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// DroppedSeats reports whether next keeps only some of the current seats and,
+// if so, returns the seats it drops. Empty or changed selections are not a
+// downsize; they replace the whole selection.
+func DroppedSeats(current, next []string) ([]string, bool) {
+	if len(next) == 0 || len(next) >= len(current) {
+		return nil, false
+	}
+
+	for _, seat := range next {
+		if !slices.Contains(current, seat) {
+			return nil, false
+		}
+	}
+
+	var dropped []string
+	for _, seat := range current {
+		if !slices.Contains(next, seat) {
+			dropped = append(dropped, seat)
+		}
+	}
+	return dropped, true
+}
+
+// SeatChange is a change of status of some of a flight's seats, published
+// for API nodes to push to clients watching the seat map
+type SeatChange struct {
+	FlightID   string     `json:"flightId"`
+	Seats      []string   `json:"seats"`
+	Status     SeatStatus `json:"status"`
+	OccurredAt time.Time  `json:"occurredAt"`
 }
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDroppedSeats(t *testing.T) {
+	current := []string{"7A", "7B", "7C", "7D"}
+	tests := []struct {
+		name    string
+		next    []string
+		dropped []string
+		ok      bool
+	}{
+		{"keeps two", []string{"7B", "7A"}, []string{"7C", "7D"}, true},
+		{"keeps one", []string{"7D"}, []string{"7A", "7B", "7C"}, true},
+		{"same seats", []string{"7A", "7B", "7C", "7D"}, nil, false},
+		{"adds a seat", []string{"7A", "8A"}, nil, false},
+		{"empty", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dropped, ok := DroppedSeats(current, tt.next)
+			if ok != tt.ok || !slices.Equal(dropped, tt.dropped) {
+				t.Errorf("DroppedSeats = %v, %v; want %v, %v", dropped, ok, tt.dropped, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNormalizeSeats(t *testing.T) {
+	got := NormalizeSeats([]string{"10A", " 2c", "2B", "1F"})
+	want := []string{"1F", "2B", "2C", "10A"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NormalizeSeats = %v; want %v", got, want)
+	}
+	if NormalizeSeats(nil) != nil {
+		t.Error("NormalizeSeats(nil) should stay nil")
+	}
+}
+
+func TestSeatsHash(t *testing.T) {
+	if SeatsHash([]string{"12C", "3A"}) != SeatsHash([]string{"3a", "12C"}) {
+		t.Error("SeatsHash should not depend on seat order or case")
+	}
+	if SeatsHash([]string{"12C", "3A"}) == SeatsHash([]string{"12C", "3B"}) {
+		t.Error("SeatsHash should differ for different seats")
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// SwapOfferStatus represents the lifecycle of a seat swap offer
+type SwapOfferStatus string
+
+const (
+	SwapOfferOpen      SwapOfferStatus = "OPEN"
+	SwapOfferMatched   SwapOfferStatus = "MATCHED"
+	SwapOfferCompleted SwapOfferStatus = "COMPLETED"
+	SwapOfferCancelled SwapOfferStatus = "CANCELLED"
+	SwapOfferFailed    SwapOfferStatus = "FAILED"
+)
+
+// SeatSwapOffer is a confirmed passenger's offer to exchange their seat
+type SeatSwapOffer struct {
+	ID              string          `json:"id"`
+	FlightID        string          `json:"flightId"`
+	OrderID         string          `json:"orderId"`
+	SeatID          string          `json:"seatId"`
+	Status          SwapOfferStatus `json:"status"`
+	AcceptedOrderID *string         `json:"acceptedOrderId,omitempty"`
+	AcceptedSeatID  *string         `json:"acceptedSeatId,omitempty"`
+	FailureReason   *string         `json:"failureReason,omitempty"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
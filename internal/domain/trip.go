@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Trip limits
+const (
+	MinTripLegs = 2
+	MaxTripLegs = 4
+
+	// MinConnectionTime is the shortest layover accepted between two legs
+	MinConnectionTime = 45 * time.Minute
+)
+
+// TripStatusResponse represents the status of a connecting itinerary and its legs
+type TripStatusResponse struct {
+	TripID          string                `json:"tripId"`
+	Status          OrderStatus           `json:"status"`
+	Legs            []OrderStatusResponse `json:"legs"`
+	PaymentAttempts int                   `json:"paymentAttempts"`
+	LastError       string                `json:"lastError,omitempty"`
+
+	PaymentChallenge *PaymentChallenge `json:"paymentChallenge,omitempty"`
+}
+
+// ValidateConnections checks that flights, in travel order, form a connecting
+// itinerary: each leg leaves from where the previous one landed, at least
+// MinConnectionTime after it landed
+func ValidateConnections(flights []*Flight) error {
+	if len(flights) < MinTripLegs || len(flights) > MaxTripLegs {
+		return ErrInvalidTrip
+	}
+
+	for i := 1; i < len(flights); i++ {
+		prev, next := flights[i-1], flights[i]
+		if next.Origin != prev.Destination {
+			return ErrInvalidTrip
+		}
+		if next.DepartureTime.Before(prev.ArrivalTime.Add(MinConnectionTime)) {
+			return ErrInvalidTrip
+		}
+	}
+
+	return nil
+}
+
+// TripStatus derives a trip's status from its legs: failed if any leg failed,
+// expired if any leg expired, cancelled if any leg was cancelled, otherwise
+// the least advanced leg's status, so the trip is confirmed only once every
+// leg is
+func TripStatus(legs []OrderStatus) OrderStatus {
+	if len(legs) == 0 || slices.Contains(legs, OrderStatusFailed) {
+		return OrderStatusFailed
+	}
+	if slices.Contains(legs, OrderStatusExpired) {
+		return OrderStatusExpired
+	}
+	if slices.Contains(legs, OrderStatusCancelled) {
+		return OrderStatusCancelled
+	}
+
+	progress := []OrderStatus{
+		OrderStatusCreated,
+		OrderStatusSeatsReserved,
+		OrderStatusPaymentPending,
+		OrderStatusPaymentProcessing,
+		OrderStatusConfirmed,
+	}
+	least := len(progress) - 1
+	for _, status := range legs {
+		if i := slices.Index(progress, status); i >= 0 && i < least {
+			least = i
+		}
+	}
+
+	return progress[least]
+}
+
+// LegDirection tells whether a trip leg travels away from or back to the trip's origin
+type LegDirection string
+
+const (
+	LegOutbound LegDirection = "outbound"
+	LegReturn   LegDirection = "return"
+)
+
+// TripLeg is one order of a trip summary; its itinerary carries a booking
+// reference, passengers and confirmation time only once the leg is confirmed
+type TripLeg struct {
+	Itinerary
+	Direction LegDirection
+	Status    OrderStatus
+}
+
+// TripSummary is the passenger-facing view of every order booked together
+type TripSummary struct {
+	TripID string // empty for an order booked on its own
+	Status OrderStatus
+	Legs   []TripLeg
+}
+
+// LegDirections labels flights, in travel order, as outbound or return. A
+// trip ending where it started is a round trip whose return starts after the
+// longest stay on the ground; any other trip is outbound only.
+func LegDirections(flights []Flight) []LegDirection {
+	directions := make([]LegDirection, len(flights))
+	for i := range directions {
+		directions[i] = LegOutbound
+	}
+	if len(flights) < 2 || flights[len(flights)-1].Destination != flights[0].Origin {
+		return directions
+	}
+
+	turnaround, longest := 1, time.Duration(0)
+	for i := 1; i < len(flights); i++ {
+		if stay := flights[i].DepartureTime.Sub(flights[i-1].ArrivalTime); stay > longest {
+			turnaround, longest = i, stay
+		}
+	}
+	for i := turnaround; i < len(directions); i++ {
+		directions[i] = LegReturn
+	}
+
+	return directions
+}
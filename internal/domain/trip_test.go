@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateConnections(t *testing.T) {
+	base := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	leg := func(origin, destination string, departs, lands time.Duration) *Flight {
+		return &Flight{Origin: origin, Destination: destination, DepartureTime: base.Add(departs), ArrivalTime: base.Add(lands)}
+	}
+
+	tests := []struct {
+		name    string
+		flights []*Flight
+		valid   bool
+	}{
+		{"connecting", []*Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "LHR", 3*time.Hour, 7*time.Hour)}, true},
+		{"single leg", []*Flight{leg("TLV", "ATH", 0, 2*time.Hour)}, false},
+		{"wrong airport", []*Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("FCO", "LHR", 3*time.Hour, 7*time.Hour)}, false},
+		{"short layover", []*Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "LHR", 2*time.Hour+30*time.Minute, 7*time.Hour)}, false},
+		{"departs before landing", []*Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "LHR", time.Hour, 5*time.Hour)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnections(tt.flights)
+			if tt.valid && err != nil {
+				t.Errorf("ValidateConnections: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidTrip) {
+				t.Errorf("ValidateConnections = %v, want ErrInvalidTrip", err)
+			}
+		})
+	}
+}
+
+func TestTripStatus(t *testing.T) {
+	tests := []struct {
+		legs []OrderStatus
+		want OrderStatus
+	}{
+		{[]OrderStatus{OrderStatusConfirmed, OrderStatusConfirmed}, OrderStatusConfirmed},
+		{[]OrderStatus{OrderStatusConfirmed, OrderStatusPaymentProcessing}, OrderStatusPaymentProcessing},
+		{[]OrderStatus{OrderStatusSeatsReserved, OrderStatusCreated}, OrderStatusCreated},
+		{[]OrderStatus{OrderStatusConfirmed, OrderStatusExpired}, OrderStatusExpired},
+		{[]OrderStatus{OrderStatusExpired, OrderStatusFailed}, OrderStatusFailed},
+		{nil, OrderStatusFailed},
+	}
+
+	for _, tt := range tests {
+		if got := TripStatus(tt.legs); got != tt.want {
+			t.Errorf("TripStatus(%v) = %s, want %s", tt.legs, got, tt.want)
+		}
+	}
+}
+
+func TestLegDirections(t *testing.T) {
+	base := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	leg := func(origin, destination string, departs, lands time.Duration) Flight {
+		return Flight{Origin: origin, Destination: destination, DepartureTime: base.Add(departs), ArrivalTime: base.Add(lands)}
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name    string
+		flights []Flight
+		want    []LegDirection
+	}{
+		{"single leg", []Flight{leg("TLV", "ATH", 0, 2*time.Hour)},
+			[]LegDirection{LegOutbound}},
+		{"one way connection", []Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "LHR", 3*time.Hour, 7*time.Hour)},
+			[]LegDirection{LegOutbound, LegOutbound}},
+		{"round trip", []Flight{leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "TLV", 5*day, 5*day+2*time.Hour)},
+			[]LegDirection{LegOutbound, LegReturn}},
+		{"round trip with connections", []Flight{
+			leg("TLV", "ATH", 0, 2*time.Hour), leg("ATH", "LHR", 3*time.Hour, 7*time.Hour),
+			leg("LHR", "ATH", 4*day, 4*day+4*time.Hour), leg("ATH", "TLV", 4*day+5*time.Hour, 4*day+7*time.Hour),
+		}, []LegDirection{LegOutbound, LegOutbound, LegReturn, LegReturn}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LegDirections(tt.flights)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// cabinOrder lists cabins from lowest to highest
+var cabinOrder = []CabinClass{CabinEconomy, CabinPremiumEconomy, CabinBusiness, CabinFirst}
+
+// CabinRank orders cabins from economy (0) up to first; unknown cabins rank
+// below economy
+func CabinRank(class CabinClass) int {
+	return slices.Index(cabinOrder, class)
+}
+
+// UpsellPolicy is what a booking offers between the payment signal and
+// taking payment
+type UpsellPolicy struct {
+	Window                time.Duration `json:"window"`                // how long the offer stays open; zero offers nothing
+	UpgradeCentsPerCabin  int64         `json:"upgradeCentsPerCabin"`  // per seat, for each cabin moved up
+	PriorityBoardingCents int64         `json:"priorityBoardingCents"` // per seat; zero does not offer it
+}
+
+// UpsellOffer is an open offer to move an order's seats up a cabin, add
+// priority boarding, or both, before its payment is taken
+type UpsellOffer struct {
+	Class                 CabinClass `json:"class,omitempty"` // cabin of the upgrade seats; empty when no upgrade is offered
+	Seats                 []string   `json:"seats,omitempty"`
+	UpgradeCents          int64      `json:"upgradeCents,omitempty"` // per seat
+	PriorityBoardingCents int64      `json:"priorityBoardingCents,omitempty"`
+	ExpiresAt             time.Time  `json:"expiresAt"`
+}
+
+// UpsellResponse is the customer's answer to an upsell offer; neither set
+// declines it
+type UpsellResponse struct {
+	Upgrade          bool `json:"upgrade"`
+	PriorityBoarding bool `json:"priorityBoarding"`
+}
+
+// UpgradeSeats picks seats for an order holding current to move up to: as
+// many available seats in the lowest cabin above the order's that has
+// enough, front rows first. The order's cabin is that of its highest seat.
+// It returns the cabin, the seats and how many cabins they move up, or no
+// seats when there is no such cabin.
+func UpgradeSeats(seatMap []Seat, current []string) (CabinClass, []string, int) {
+	byID := make(map[string]Seat, len(seatMap))
+	for _, seat := range seatMap {
+		byID[seat.ID] = seat
+	}
+	rank := -1
+	for _, id := range current {
+		seat, ok := byID[id]
+		if !ok {
+			return "", nil, 0
+		}
+		rank = max(rank, CabinRank(seat.Class))
+	}
+	if rank < 0 {
+		return "", nil, 0
+	}
+
+	available := slices.DeleteFunc(slices.Clone(seatMap), func(s Seat) bool {
+		return s.Status != SeatStatusAvailable
+	})
+	slices.SortFunc(available, func(a, b Seat) int {
+		if a.Row != b.Row {
+			return cmp.Compare(a.Row, b.Row)
+		}
+		return cmp.Compare(a.Column, b.Column)
+	})
+
+	for _, class := range cabinOrder[rank+1:] {
+		var seats []string
+		for _, seat := range available {
+			if seat.Class == class && len(seats) < len(current) {
+				seats = append(seats, seat.ID)
+			}
+		}
+		if len(seats) == len(current) {
+			return class, seats, CabinRank(class) - rank
+		}
+	}
+	return "", nil, 0
+}
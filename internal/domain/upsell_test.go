@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUpgradeSeats(t *testing.T) {
+	seatMap := []Seat{
+		{ID: "1A", Row: 1, Column: "A", Class: CabinFirst, Status: SeatStatusAvailable},
+		{ID: "2A", Row: 2, Column: "A", Class: CabinBusiness, Status: SeatStatusBooked},
+		{ID: "2B", Row: 2, Column: "B", Class: CabinBusiness, Status: SeatStatusAvailable},
+		{ID: "3B", Row: 3, Column: "B", Class: CabinPremiumEconomy, Status: SeatStatusAvailable},
+		{ID: "3A", Row: 3, Column: "A", Class: CabinPremiumEconomy, Status: SeatStatusAvailable},
+		{ID: "9A", Row: 9, Column: "A", Class: CabinEconomy, Status: SeatStatusReserved},
+		{ID: "9B", Row: 9, Column: "B", Class: CabinEconomy, Status: SeatStatusReserved},
+	}
+
+	tests := []struct {
+		name      string
+		current   []string
+		wantClass CabinClass
+		wantSeats []string
+		wantSteps int
+	}{
+		{"next cabin up, front seats first", []string{"9A", "9B"}, CabinPremiumEconomy, []string{"3A", "3B"}, 1},
+		{"no cabin above has room", []string{"3A", "3B"}, "", nil, 0},
+		{"single seat", []string{"3A"}, CabinBusiness, []string{"2B"}, 1},
+		{"highest seat sets the cabin", []string{"9A", "2A"}, "", nil, 0},
+		{"first has nothing above", []string{"1A"}, "", nil, 0},
+		{"unknown seat", []string{"7C"}, "", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, seats, steps := UpgradeSeats(seatMap, tt.current)
+			if class != tt.wantClass || !slices.Equal(seats, tt.wantSeats) || steps != tt.wantSteps {
+				t.Errorf("UpgradeSeats = %q, %v, %d; want %q, %v, %d", class, seats, steps, tt.wantClass, tt.wantSeats, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestPriceWithUpsell(t *testing.T) {
+	price := PriceBreakdown{UnitFareCents: 10000, UnitFeeCents: 500, UnitDiscountCents: 1000}.ForSeats(2)
+	upsold := price.WithUpsell(2500)
+	if upsold.UpsellCents != 5000 || upsold.TotalCents != price.TotalCents+5000 {
+		t.Errorf("WithUpsell = %+v; want 5000 more than %d", upsold, price.TotalCents)
+	}
+	if shrunk := upsold.ForSeats(1); shrunk.UpsellCents != 2500 || shrunk.TotalCents != 9500+2500 {
+		t.Errorf("ForSeats(1) = %+v; want the upsell rescaled", shrunk)
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// WebhookEvent is an order lifecycle event delivered to subscribers
+type WebhookEvent string
+
+const (
+	EventOrderCreated  WebhookEvent = "ORDER_CREATED"
+	EventSeatsReserved WebhookEvent = "SEATS_RESERVED"
+	EventSeatsReleased WebhookEvent = "SEATS_RELEASED" // an order dropped some of its seats
+	EventHoldExpiring  WebhookEvent = "HOLD_EXPIRING"  // the seat hold is about to expire unpaid
+	EventConfirmed     WebhookEvent = "CONFIRMED"
+	EventExpired       WebhookEvent = "EXPIRED"
+	EventFailed        WebhookEvent = "FAILED"
+	EventRefunded      WebhookEvent = "PAYMENT_REFUNDED" // the payment was returned: confirmation failed, or an operator refunded it
+	EventCancelled     WebhookEvent = "CANCELLED"        // an operator canceled the booking workflow
+
+	EventFlightDisrupted WebhookEvent = "FLIGHT_DISRUPTED" // the order's flight was delayed or cancelled
+	EventOrderBumped     WebhookEvent = "ORDER_BUMPED"     // the order was moved off its oversold flight
+)
+
+// AllWebhookEvents lists every event a subscription may select
+var AllWebhookEvents = []WebhookEvent{
+	EventOrderCreated, EventSeatsReserved, EventSeatsReleased, EventHoldExpiring, EventConfirmed, EventExpired,
+	EventFailed, EventRefunded, EventCancelled, EventFlightDisrupted, EventOrderBumped,
+}
+
+// WebhookSubscription is a downstream endpoint receiving signed event payloads
+type WebhookSubscription struct {
+	ID        string         `json:"id"`
+	URL       string         `json:"url"`
+	Secret    string         `json:"-"`
+	Events    []WebhookEvent `json:"events"`
+	Active    bool           `json:"active"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+// WebhookDelivery is one pending POST of an event payload to a subscription
+type WebhookDelivery struct {
+	ID       int64
+	URL      string
+	Secret   string
+	Event    WebhookEvent
+	Payload  []byte
+	Attempts int
+}
+
+// WebhookPayload is the JSON body POSTed to subscribers
+type WebhookPayload struct {
+	Event      WebhookEvent `json:"event"`
+	OccurredAt time.Time    `json:"occurredAt"`
+	Order      WebhookOrder `json:"order"`
+}
+
+// WebhookOrder is the order snapshot carried in a webhook payload
+type WebhookOrder struct {
+	OrderID         string      `json:"orderId"`
+	FlightID        string      `json:"flightId"`
+	Status          OrderStatus `json:"status"`
+	Seats           []string    `json:"seats"`
+	TotalPriceCents int64       `json:"totalPriceCents"`
+}
@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: internal/grpc/booking.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BookingService_ListFlights_FullMethodName   = "/flightbooking.v1.BookingService/ListFlights"
+	BookingService_GetFlight_FullMethodName     = "/flightbooking.v1.BookingService/GetFlight"
+	BookingService_CreateOrder_FullMethodName   = "/flightbooking.v1.BookingService/CreateOrder"
+	BookingService_UpdateSeats_FullMethodName   = "/flightbooking.v1.BookingService/UpdateSeats"
+	BookingService_SubmitPayment_FullMethodName = "/flightbooking.v1.BookingService/SubmitPayment"
+	BookingService_GetStatus_FullMethodName     = "/flightbooking.v1.BookingService/GetStatus"
+	BookingService_Cancel_FullMethodName        = "/flightbooking.v1.BookingService/Cancel"
+)
+
+// BookingServiceClient is the client API for BookingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BookingServiceClient interface {
+	ListFlights(ctx context.Context, in *ListFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error)
+	GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*FlightDetail, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
+	UpdateSeats(ctx context.Context, in *UpdateSeatsRequest, opts ...grpc.CallOption) (*UpdateSeatsResponse, error)
+	SubmitPayment(ctx context.Context, in *SubmitPaymentRequest, opts ...grpc.CallOption) (*SubmitPaymentResponse, error)
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*OrderStatus, error)
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+}
+
+type bookingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBookingServiceClient(cc grpc.ClientConnInterface) BookingServiceClient {
+	return &bookingServiceClient{cc}
+}
+
+func (c *bookingServiceClient) ListFlights(ctx context.Context, in *ListFlightsRequest, opts ...grpc.CallOption) (*ListFlightsResponse, error) {
+	out := new(ListFlightsResponse)
+	err := c.cc.Invoke(ctx, BookingService_ListFlights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetFlight(ctx context.Context, in *GetFlightRequest, opts ...grpc.CallOption) (*FlightDetail, error) {
+	out := new(FlightDetail)
+	err := c.cc.Invoke(ctx, BookingService_GetFlight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error) {
+	out := new(CreateOrderResponse)
+	err := c.cc.Invoke(ctx, BookingService_CreateOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) UpdateSeats(ctx context.Context, in *UpdateSeatsRequest, opts ...grpc.CallOption) (*UpdateSeatsResponse, error) {
+	out := new(UpdateSeatsResponse)
+	err := c.cc.Invoke(ctx, BookingService_UpdateSeats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) SubmitPayment(ctx context.Context, in *SubmitPaymentRequest, opts ...grpc.CallOption) (*SubmitPaymentResponse, error) {
+	out := new(SubmitPaymentResponse)
+	err := c.cc.Invoke(ctx, BookingService_SubmitPayment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*OrderStatus, error) {
+	out := new(OrderStatus)
+	err := c.cc.Invoke(ctx, BookingService_GetStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, BookingService_Cancel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BookingServiceServer is the server API for BookingService service.
+// All implementations must embed UnimplementedBookingServiceServer
+// for forward compatibility
+type BookingServiceServer interface {
+	ListFlights(context.Context, *ListFlightsRequest) (*ListFlightsResponse, error)
+	GetFlight(context.Context, *GetFlightRequest) (*FlightDetail, error)
+	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
+	UpdateSeats(context.Context, *UpdateSeatsRequest) (*UpdateSeatsResponse, error)
+	SubmitPayment(context.Context, *SubmitPaymentRequest) (*SubmitPaymentResponse, error)
+	GetStatus(context.Context, *GetStatusRequest) (*OrderStatus, error)
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	mustEmbedUnimplementedBookingServiceServer()
+}
+
+// UnimplementedBookingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBookingServiceServer struct {
+}
+
+func (UnimplementedBookingServiceServer) ListFlights(context.Context, *ListFlightsRequest) (*ListFlightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFlights not implemented")
+}
+func (UnimplementedBookingServiceServer) GetFlight(context.Context, *GetFlightRequest) (*FlightDetail, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlight not implemented")
+}
+func (UnimplementedBookingServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedBookingServiceServer) UpdateSeats(context.Context, *UpdateSeatsRequest) (*UpdateSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSeats not implemented")
+}
+func (UnimplementedBookingServiceServer) SubmitPayment(context.Context, *SubmitPaymentRequest) (*SubmitPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPayment not implemented")
+}
+func (UnimplementedBookingServiceServer) GetStatus(context.Context, *GetStatusRequest) (*OrderStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedBookingServiceServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedBookingServiceServer) mustEmbedUnimplementedBookingServiceServer() {}
+
+// UnsafeBookingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BookingServiceServer will
+// result in compilation errors.
+type UnsafeBookingServiceServer interface {
+	mustEmbedUnimplementedBookingServiceServer()
+}
+
+func RegisterBookingServiceServer(s grpc.ServiceRegistrar, srv BookingServiceServer) {
+	s.RegisterService(&BookingService_ServiceDesc, srv)
+}
+
+func _BookingService_ListFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).ListFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_ListFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).ListFlights(ctx, req.(*ListFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetFlight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetFlight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetFlight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetFlight(ctx, req.(*GetFlightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_UpdateSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).UpdateSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_UpdateSeats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).UpdateSeats(ctx, req.(*UpdateSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_SubmitPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).SubmitPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_SubmitPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).SubmitPayment(ctx, req.(*SubmitPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BookingService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BookingService_ServiceDesc is the grpc.ServiceDesc for BookingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BookingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "flightbooking.v1.BookingService",
+	HandlerType: (*BookingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFlights",
+			Handler:    _BookingService_ListFlights_Handler,
+		},
+		{
+			MethodName: "GetFlight",
+			Handler:    _BookingService_GetFlight_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _BookingService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "UpdateSeats",
+			Handler:    _BookingService_UpdateSeats_Handler,
+		},
+		{
+			MethodName: "SubmitPayment",
+			Handler:    _BookingService_SubmitPayment_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _BookingService_GetStatus_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _BookingService_Cancel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/grpc/booking.proto",
+}
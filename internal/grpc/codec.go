@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CodecName is the content-subtype of clients that call with the proto JSON
+// mapping instead of protobuf (application/grpc+json)
+const CodecName = "json"
+
+// jsonCodec marshals the booking.proto messages with protojson; field names
+// are the proto field names, and zero values are written out
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("json codec: %T is not a proto message", v)
+	}
+	return protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(msg)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("json codec: %T is not a proto message", v)
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, msg)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/flight-booking-system/internal/api"
+)
+
+// The interceptors below give CreateOrder and SubmitPayment the rate limits
+// and request validation their REST routes have, so gRPC is not a way
+// around them.
+
+// rateLimitInterceptor spends requests from the REST API's buckets: an order
+// creation from its peer's create budget, a payment from its peer's and its
+// order's pay budgets. Like the REST middleware it is a no-op without a
+// limiter or with a zero limit, and fails open if Redis is unavailable.
+func rateLimitInterceptor(limiter api.RateLimiter, policy api.RateLimitPolicy) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+		if limiter == nil {
+			return handler(ctx, req)
+		}
+
+		type bucket struct {
+			key   string
+			limit int
+		}
+		var buckets []bucket
+		switch r := req.(type) {
+		case *CreateOrderRequest:
+			buckets = []bucket{{"create:ip:" + peerHost(ctx), policy.PerIP}}
+		case *SubmitPaymentRequest:
+			buckets = []bucket{{"pay:ip:" + peerHost(ctx), policy.PerIP}, {"pay:order:" + r.OrderId, policy.PerOrder}}
+		}
+
+		for _, b := range buckets {
+			if b.limit <= 0 {
+				continue
+			}
+			_, wait, err := limiter.Take(ctx, b.key, b.limit, policy.Window)
+			if err != nil {
+				log.Printf("rate limit check failed: %v", err)
+				continue
+			}
+			if wait > 0 {
+				st, detailErr := status.New(codes.ResourceExhausted, "too many requests, retry later").WithDetails(&errdetails.RetryInfo{
+					RetryDelay: durationpb.New(wait),
+				})
+				if detailErr != nil {
+					return nil, status.Error(codes.ResourceExhausted, "too many requests, retry later")
+				}
+				return nil, st.Err()
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerHost returns the IP of the calling client
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// validationInterceptor checks requests with the REST API's validation and
+// answers InvalidArgument with a BadRequest detail listing every invalid
+// field, as REST answers 422
+func validationInterceptor(ctx context.Context, req interface{}, _ *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+	var fields []api.FieldError
+	switch r := req.(type) {
+	case *CreateOrderRequest:
+		passengers := make([]api.PassengerRequest, len(r.Passengers))
+		for i, p := range r.Passengers {
+			passengers[i] = api.PassengerRequest{SeatID: p.SeatId, Name: p.Name, DocumentNumber: p.DocumentNumber, Email: p.Email}
+		}
+		fields = api.ValidateCreateOrder(api.CreateOrderRequest{
+			FlightID:   r.FlightId,
+			Seats:      r.Seats,
+			SeatCount:  int(r.SeatCount),
+			Passengers: passengers,
+		})
+	case *SubmitPaymentRequest:
+		fields = api.ValidateSubmitPayment(r.OrderId, api.SubmitPaymentRequest{
+			Method:      r.Method,
+			PaymentCode: r.PaymentCode,
+			Deposit:     r.Deposit,
+		})
+	}
+	if len(fields) == 0 {
+		return handler(ctx, req)
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+	for i, f := range fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+	}
+	st, err := status.New(codes.InvalidArgument, "request validation failed").WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "request validation failed")
+	}
+	return nil, st.Err()
+}
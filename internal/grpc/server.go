@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/flight-booking-system/internal/api"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/service"
+)
+
+//go:generate protoc --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative -I ../.. ../../internal/grpc/booking.proto
+
+// ServiceName is the fully-qualified service name from booking.proto
+const ServiceName = "flightbooking.v1.BookingService"
+
+// Server implements BookingService on top of the shared services
+type Server struct {
+	UnimplementedBookingServiceServer
+
+	flightService  *service.FlightService
+	bookingService *service.BookingService
+}
+
+// NewServer creates a gRPC server with BookingService registered. Requests
+// are protobuf unless the client calls with the "json" content-subtype.
+// Order creation and payment are rate limited by limiter under policy, as
+// their REST routes are, and validated as REST validates them.
+func NewServer(flightService *service.FlightService, bookingService *service.BookingService, limiter api.RateLimiter, policy api.RateLimitPolicy) *grpclib.Server {
+	srv := grpclib.NewServer(grpclib.ChainUnaryInterceptor(
+		rateLimitInterceptor(limiter, policy),
+		validationInterceptor,
+	))
+	RegisterBookingServiceServer(srv, &Server{
+		flightService:  flightService,
+		bookingService: bookingService,
+	})
+	return srv
+}
+
+// ListFlights returns all flights
+func (s *Server) ListFlights(ctx context.Context, _ *ListFlightsRequest) (*ListFlightsResponse, error) {
+	flights, err := s.flightService.ListFlights(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &ListFlightsResponse{Flights: make([]*Flight, len(flights))}
+	for i, f := range flights {
+		resp.Flights[i] = toFlight(f)
+	}
+	return resp, nil
+}
+
+// GetFlight returns a flight with live seat availability
+func (s *Server) GetFlight(ctx context.Context, req *GetFlightRequest) (*FlightDetail, error) {
+	if req.FlightId == "" {
+		return nil, status.Error(codes.InvalidArgument, "flightId is required")
+	}
+
+	flight, err := s.flightService.GetFlightWithSeats(ctx, req.FlightId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &FlightDetail{
+		Flight: toFlight(flight.Flight),
+		Seats:  make([]*Seat, len(flight.SeatMap.Seats)),
+	}
+	for i, seat := range flight.SeatMap.Seats {
+		resp.Seats[i] = &Seat{Id: seat.ID, Row: int32(seat.Row), Column: seat.Column, Class: string(seat.Class), Status: string(seat.Status)}
+	}
+	return resp, nil
+}
+
+// CreateOrder creates an order and starts its booking workflow; see
+// validationInterceptor for its checks
+func (s *Server) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*CreateOrderResponse, error) {
+	passengers := make([]domain.Passenger, len(req.Passengers))
+	for i, p := range req.Passengers {
+		passengers[i] = domain.Passenger{SeatID: p.SeatId, Name: p.Name, DocumentNumber: p.DocumentNumber, Email: p.Email}
+	}
+
+	output, err := s.bookingService.CreateOrder(ctx, service.CreateOrderInput{
+		FlightID:   req.FlightId,
+		Seats:      req.Seats,
+		SeatCount:  int(req.SeatCount),
+		Passengers: passengers,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &CreateOrderResponse{
+		OrderId:    output.OrderID,
+		WorkflowId: output.WorkflowID,
+		Status:     string(output.Status),
+		ExpiresAt:  output.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// UpdateSeats replaces the seat selection of an order
+func (s *Server) UpdateSeats(ctx context.Context, req *UpdateSeatsRequest) (*UpdateSeatsResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "orderId is required")
+	}
+
+	output, err := s.bookingService.UpdateSeats(ctx, req.OrderId, req.Seats)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &UpdateSeatsResponse{
+		OrderId:   output.OrderID,
+		Status:    string(output.Status),
+		Seats:     output.Seats,
+		ExpiresAt: output.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// SubmitPayment sends the payment code to the booking workflow; see
+// validationInterceptor for its checks
+func (s *Server) SubmitPayment(ctx context.Context, req *SubmitPaymentRequest) (*SubmitPaymentResponse, error) {
+	st, err := s.bookingService.SubmitPayment(ctx, req.OrderId, domain.PaymentMethod(req.Method), req.PaymentCode, req.Deposit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &SubmitPaymentResponse{OrderId: req.OrderId, Status: string(st)}, nil
+}
+
+// GetStatus returns the live order status
+func (s *Server) GetStatus(ctx context.Context, req *GetStatusRequest) (*OrderStatus, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "orderId is required")
+	}
+
+	st, err := s.bookingService.GetOrderStatus(ctx, req.OrderId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &OrderStatus{
+		OrderId:         st.OrderID,
+		Status:          string(st.Status),
+		Seats:           st.Seats,
+		TimerRemaining:  int32(st.TimerRemaining),
+		PaymentAttempts: int32(st.PaymentAttempts),
+		LastError:       st.LastError,
+		TotalCents:      st.Price.TotalCents,
+	}, nil
+}
+
+// Cancel cancels an order
+func (s *Server) Cancel(ctx context.Context, req *CancelRequest) (*CancelResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "orderId is required")
+	}
+
+	if err := s.bookingService.CancelOrder(ctx, req.OrderId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &CancelResponse{}, nil
+}
+
+func toFlight(f domain.Flight) *Flight {
+	return &Flight{
+		Id:             f.ID,
+		FlightNumber:   f.FlightNumber,
+		Origin:         f.Origin,
+		Destination:    f.Destination,
+		DepartureTime:  f.DepartureTime.Format(time.RFC3339),
+		TotalSeats:     int32(f.TotalSeats),
+		AvailableSeats: int32(f.AvailableSeats),
+		PriceCents:     f.PriceCents,
+	}
+}
+
+// toStatus maps domain errors to gRPC status codes, mirroring api.MapDomainError.
+// Seats other orders hold carry RetryInfo with the time left on their locks.
+func toStatus(err error) error {
+	var unavailable *domain.SeatsUnavailableError
+	if errors.As(err, &unavailable) && unavailable.RetryAfter > 0 {
+		st, detailErr := status.New(codes.Aborted, err.Error()).WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(unavailable.RetryAfter),
+		})
+		if detailErr == nil {
+			return st.Err()
+		}
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrFlightNotFound), errors.Is(err, domain.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrOrderExpired), errors.Is(err, domain.ErrSeatlessOrder),
+		errors.Is(err, domain.ErrTripLeg), errors.Is(err, domain.ErrPartialNotApproved),
+		errors.Is(err, domain.ErrNoPartialBooking), errors.Is(err, domain.ErrNoUpsellOffer),
+		errors.Is(err, domain.ErrDepositUnavailable),
+		errors.Is(err, domain.ErrCheckInNotOpen),
+		errors.Is(err, domain.ErrCheckInClosed), errors.Is(err, domain.ErrFlightCancelled),
+		errors.Is(err, domain.ErrFlightFrozen), errors.Is(err, domain.ErrSalesClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrSeatUnavailable), errors.Is(err, domain.ErrSeatsAlreadyLocked),
+		errors.Is(err, domain.ErrInsufficientSeats), errors.Is(err, domain.ErrDoubleBooking),
+		errors.Is(err, domain.ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrInvalidPaymentCode), errors.Is(err, domain.ErrInvalidPaymentMethod),
+		errors.Is(err, domain.ErrPaymentFailed),
+		errors.Is(err, domain.ErrInvalidPassengers), errors.Is(err, domain.ErrInvalidGroupSize),
+		errors.Is(err, domain.ErrCabinMismatch), errors.Is(err, domain.ErrInvalidOverbooking):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrUpdatePending):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, "an internal error occurred")
+	}
+}
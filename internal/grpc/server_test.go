@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/flight-booking-system/internal/api"
+)
+
+// dialTestServer serves NewServer, rate limited by limiter under policy,
+// over an in-memory listener and returns a client connection to it
+func dialTestServer(t *testing.T, limiter api.RateLimiter, policy api.RateLimitPolicy, opts ...grpclib.DialOption) *grpclib.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(nil, nil, limiter, policy)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	opts = append(opts,
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpclib.DialContext(context.Background(), "bufnet", opts...)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestServer_ValidatesRequestsOverTheWire(t *testing.T) {
+	// Protobuf, as a client generated from booking.proto sends it, and the
+	// JSON mapping
+	for name, opts := range map[string][]grpclib.DialOption{
+		"proto": nil,
+		"json":  {grpclib.WithDefaultCallOptions(grpclib.CallContentSubtype(CodecName))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := NewBookingServiceClient(dialTestServer(t, nil, api.RateLimitPolicy{}, opts...))
+			ctx := context.Background()
+
+			tests := []struct {
+				method string
+				call   func() error
+			}{
+				{"GetFlight", func() error { _, err := client.GetFlight(ctx, &GetFlightRequest{}); return err }},
+				{"CreateOrder", func() error {
+					_, err := client.CreateOrder(ctx, &CreateOrderRequest{FlightId: "f1"})
+					return err
+				}},
+				{"SubmitPayment", func() error {
+					_, err := client.SubmitPayment(ctx, &SubmitPaymentRequest{OrderId: "o1", Method: "wallet", Deposit: true})
+					return err
+				}},
+			}
+			for _, tt := range tests {
+				if err := tt.call(); status.Code(err) != codes.InvalidArgument {
+					t.Errorf("%s: got %v, want InvalidArgument", tt.method, err)
+				}
+			}
+		})
+	}
+}
+
+// The same field errors as REST's 422, one violation per field
+func TestServer_ValidatesAsREST(t *testing.T) {
+	client := NewBookingServiceClient(dialTestServer(t, nil, api.RateLimitPolicy{}))
+
+	_, err := client.CreateOrder(context.Background(), &CreateOrderRequest{
+		FlightId:   "not-a-uuid",
+		Seats:      []string{"1A", "1A"},
+		Passengers: []*Passenger{{SeatId: "1A", Name: "Ada", DocumentNumber: "P1", Email: "ada@example.com"}},
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	want := []string{"flightId", "seats[1]", "passengers", "passengers[0].documentNumber"}
+	if len(fields) != len(want) {
+		t.Fatalf("field violations %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("field violations %v, want %v", fields, want)
+			break
+		}
+	}
+}
+
+// countingLimiter is an in-memory api.RateLimiter whose buckets never refill
+type countingLimiter struct {
+	counts map[string]int64
+}
+
+func (l *countingLimiter) Take(_ context.Context, key string, limit int, window time.Duration) (int64, time.Duration, error) {
+	if l.counts[key] >= int64(limit) {
+		return 0, window / time.Duration(limit), nil
+	}
+	l.counts[key]++
+	return int64(limit) - l.counts[key], 0, nil
+}
+
+// Payments spend the order's REST pay budget, so the two APIs share it
+func TestServer_RateLimitsAsREST(t *testing.T) {
+	const orderID = "7b0c2f6e-3d8a-4c1e-9f5b-2a6d8e4c1b3f"
+	limiter := &countingLimiter{counts: map[string]int64{"pay:order:" + orderID: 1}}
+	policy := api.RateLimitPolicy{PerIP: 10, PerOrder: 2, Window: time.Minute}
+	client := NewBookingServiceClient(dialTestServer(t, limiter, policy))
+	ctx := context.Background()
+
+	// Invalid payments still spend requests, as they do over REST
+	if _, err := client.SubmitPayment(ctx, &SubmitPaymentRequest{OrderId: orderID}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("first payment: got %v, want InvalidArgument", err)
+	}
+
+	_, err := client.SubmitPayment(ctx, &SubmitPaymentRequest{OrderId: orderID})
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("second payment: got %v, want ResourceExhausted", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("details %v, want RetryInfo", st.Details())
+	}
+	if info, ok := st.Details()[0].(*errdetails.RetryInfo); !ok || info.RetryDelay.AsDuration() != 30*time.Second {
+		t.Errorf("details %v, want a 30s RetryInfo", st.Details())
+	}
+	if limiter.counts["pay:ip:bufconn"] != 2 {
+		t.Errorf("IP budget spent %d times, want 2", limiter.counts["pay:ip:bufconn"])
+	}
+}
@@ -0,0 +1,127 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Format is a seat inventory file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ErrUnknownFormat indicates a format other than csv or json
+var ErrUnknownFormat = errors.New("unknown inventory format")
+
+// csvHeader is the first line of every CSV inventory
+var csvHeader = []string{"seat_id", "row", "column", "status"}
+
+// Seat is one line of an inventory file
+type Seat struct {
+	ID     string `json:"id"`
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Status string `json:"status"`
+}
+
+// document is the JSON inventory layout
+type document struct {
+	FlightID string `json:"flightId,omitempty"`
+	Seats    []Seat `json:"seats"`
+}
+
+// ParseFormat accepts csv or json, case-insensitively
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// Write encodes a flight's seats in the given format
+func Write(w io.Writer, format Format, flightID string, seats []domain.Seat) error {
+	rows := make([]Seat, len(seats))
+	for i, s := range seats {
+		rows[i] = Seat{ID: s.ID, Row: s.Row, Column: s.Column, Status: string(s.Status)}
+	}
+
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(document{FlightID: flightID, Seats: rows})
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(csvHeader)
+		for _, s := range rows {
+			cw.Write([]string{s.ID, strconv.Itoa(s.Row), s.Column, s.Status})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Read decodes an inventory file. It checks the file's shape only; seat rules
+// are enforced when the inventory is diffed against the flight.
+func Read(r io.Reader, format Format) ([]domain.Seat, error) {
+	var rows []Seat
+	switch format {
+	case FormatJSON:
+		var doc document
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("decode inventory json: %w", err)
+		}
+		rows = doc.Seats
+	case FormatCSV:
+		var err error
+		if rows, err = readCSV(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	seats := make([]domain.Seat, len(rows))
+	for i, s := range rows {
+		seats[i] = domain.Seat{ID: s.ID, Row: s.Row, Column: s.Column, Status: domain.SeatStatus(s.Status)}
+	}
+	return seats, nil
+}
+
+func readCSV(r io.Reader) ([]Seat, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read inventory csv: %w", err)
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return nil, fmt.Errorf("read inventory csv: header must be %s", strings.Join(csvHeader, ","))
+	}
+
+	rows := make([]Seat, 0, len(records)-1)
+	for i, rec := range records[1:] {
+		row, err := strconv.Atoi(rec[1])
+		if err != nil {
+			return nil, fmt.Errorf("read inventory csv: line %d: row %q is not a number", i+2, rec[1])
+		}
+		rows = append(rows, Seat{ID: rec[0], Row: row, Column: rec[2], Status: rec[3]})
+	}
+	return rows, nil
+}
@@ -0,0 +1,52 @@
+package inventory
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestRoundTrip(t *testing.T) {
+	seats := []domain.Seat{
+		{ID: "1A", Row: 1, Column: "A", Status: domain.SeatStatusAvailable},
+		{ID: "1B", Row: 1, Column: "B", Status: domain.SeatStatusBlocked},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, "f1", seats); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if len(got) != len(seats) {
+				t.Fatalf("got %d seats, want %d", len(got), len(seats))
+			}
+			for i := range seats {
+				if got[i].ID != seats[i].ID || got[i].Row != seats[i].Row || got[i].Column != seats[i].Column || got[i].Status != seats[i].Status {
+					t.Errorf("seat %d: got %+v, want %+v", i, got[i], seats[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing header": "1A,1,A,available\n",
+		"bad row":        "seat_id,row,column,status\n1A,one,A,available\n",
+		"short line":     "seat_id,row,column,status\n1A,1,A\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(input), FormatCSV); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
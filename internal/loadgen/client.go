@@ -0,0 +1,113 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/flight-booking-system/internal/api"
+)
+
+// client calls the booking API the way the web frontend does
+type client struct {
+	baseURL string // e.g. http://localhost:8080, without the /api/v1 prefix
+	http    *http.Client
+}
+
+// apiError is a non-2xx API response
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+func (c *client) listFlights(ctx context.Context) ([]api.FlightResponse, error) {
+	var resp api.FlightListResponse
+	err := c.do(ctx, http.MethodGet, "/flights", nil, &resp)
+	return resp.Flights, err
+}
+
+func (c *client) flight(ctx context.Context, flightID string) (*api.FlightDetailResponse, error) {
+	var resp api.FlightDetailResponse
+	if err := c.do(ctx, http.MethodGet, "/flights/"+flightID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *client) createOrder(ctx context.Context, flightID string, seats []string) (*api.CreateOrderResponse, error) {
+	var resp api.CreateOrderResponse
+	err := c.do(ctx, http.MethodPost, "/orders", api.CreateOrderRequest{FlightID: flightID, Seats: seats}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *client) updateSeats(ctx context.Context, orderID string, seats []string) error {
+	return c.do(ctx, http.MethodPut, "/orders/"+orderID+"/seats", api.UpdateSeatsRequest{Seats: seats}, nil)
+}
+
+func (c *client) pay(ctx context.Context, orderID, code string) error {
+	return c.do(ctx, http.MethodPost, "/orders/"+orderID+"/pay", api.SubmitPaymentRequest{PaymentCode: code}, nil)
+}
+
+func (c *client) cancel(ctx context.Context, orderID string) error {
+	return c.do(ctx, http.MethodDelete, "/orders/"+orderID, nil, nil)
+}
+
+func (c *client) status(ctx context.Context, orderID string) (*api.OrderStatusResponse, error) {
+	var resp api.OrderStatusResponse
+	if err := c.do(ctx, http.MethodGet, "/orders/"+orderID+"/status", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// do sends a JSON request to /api/v1 and decodes a JSON response into out
+// when out is non-nil. Non-2xx responses become *apiError.
+func (c *client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode %s %s: %w", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+"/api/v1"+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var errResp api.ErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return &apiError{Status: resp.StatusCode, Code: errResp.Error, Message: errResp.Message}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Package loadgen drives simulated customers against the HTTP API. Each
+// simulated user follows a persona, a scripted behavior chosen so that the
+// traffic as a whole reaches every branch of the booking workflow: payment,
+// declined payment retries, seat changes that reset the hold timer, expiry,
+// and cancellation with its compensation.
+package loadgen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Persona is a scripted customer behavior
+type Persona string
+
+const (
+	// PersonaFastPayer picks seats and pays straight away
+	PersonaFastPayer Persona = "fast"
+	// PersonaDitherer changes seats several times, resetting the hold timer
+	// each time, before paying
+	PersonaDitherer Persona = "ditherer"
+	// PersonaAbandoner holds seats and never pays, leaving the hold to expire
+	PersonaAbandoner Persona = "abandoner"
+	// PersonaCanceler holds seats, maybe changes them, then cancels the order
+	PersonaCanceler Persona = "canceler"
+	// PersonaDecliner pays with a card that is declined, then pays again
+	PersonaDecliner Persona = "decliner"
+)
+
+// Personas lists every persona in report order
+var Personas = []Persona{PersonaFastPayer, PersonaDitherer, PersonaAbandoner, PersonaCanceler, PersonaDecliner}
+
+// Mix weights how often each persona is picked
+type Mix map[Persona]int
+
+// DefaultMix leans on paying customers while still reaching every branch
+var DefaultMix = Mix{
+	PersonaFastPayer: 4,
+	PersonaDitherer:  2,
+	PersonaAbandoner: 1,
+	PersonaCanceler:  2,
+	PersonaDecliner:  1,
+}
+
+// ParseMix reads a mix such as "fast=4,ditherer=2,canceler=1". Personas left
+// out are never picked; at least one weight must be positive.
+func ParseMix(s string) (Mix, error) {
+	mix := make(Mix)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, rawWeight, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("mix entry %q is not persona=weight", part)
+		}
+		persona := Persona(strings.TrimSpace(name))
+		if !persona.valid() {
+			return nil, fmt.Errorf("unknown persona %q", name)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(rawWeight))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("persona %s weight %q is not a non-negative integer", persona, rawWeight)
+		}
+		mix[persona] = weight
+	}
+
+	if mix.total() == 0 {
+		return nil, fmt.Errorf("mix %q gives no persona a weight", s)
+	}
+	return mix, nil
+}
+
+// String formats the mix the way ParseMix reads it
+func (m Mix) String() string {
+	parts := make([]string, 0, len(m))
+	for _, p := range Personas {
+		if w, ok := m[p]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%d", p, w))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Pick chooses a persona with probability proportional to its weight
+func (m Mix) Pick(r *rand.Rand) Persona {
+	personas := make([]Persona, 0, len(m))
+	for p, w := range m {
+		if w > 0 {
+			personas = append(personas, p)
+		}
+	}
+	// Map order is random; sort so a seeded run picks the same personas
+	sort.Slice(personas, func(i, j int) bool { return personas[i] < personas[j] })
+
+	n := r.Intn(m.total())
+	for _, p := range personas {
+		if n < m[p] {
+			return p
+		}
+		n -= m[p]
+	}
+	return personas[len(personas)-1]
+}
+
+func (m Mix) total() int {
+	total := 0
+	for _, w := range m {
+		if w > 0 {
+			total += w
+		}
+	}
+	return total
+}
+
+func (p Persona) valid() bool {
+	for _, known := range Personas {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package loadgen
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseMix(t *testing.T) {
+	mix, err := ParseMix("fast=3, ditherer=1,canceler=0")
+	if err != nil {
+		t.Fatalf("ParseMix: %v", err)
+	}
+	if mix[PersonaFastPayer] != 3 || mix[PersonaDitherer] != 1 || mix[PersonaCanceler] != 0 {
+		t.Errorf("mix = %v", mix)
+	}
+	if got := mix.String(); got != "fast=3,ditherer=1,canceler=0" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, bad := range []string{"", "fast", "fast=x", "fast=-1", "sleeper=1", "fast=0,canceler=0"} {
+		if _, err := ParseMix(bad); err == nil {
+			t.Errorf("ParseMix(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestMixPick(t *testing.T) {
+	mix := Mix{PersonaFastPayer: 3, PersonaAbandoner: 1, PersonaCanceler: 0}
+	r := rand.New(rand.NewSource(1))
+
+	counts := make(map[Persona]int)
+	for i := 0; i < 4000; i++ {
+		counts[mix.Pick(r)]++
+	}
+
+	if counts[PersonaCanceler] != 0 {
+		t.Errorf("picked zero-weight canceler %d times", counts[PersonaCanceler])
+	}
+	if fast := counts[PersonaFastPayer]; fast < 2800 || fast > 3200 {
+		t.Errorf("picked fast %d times of 4000, want about 3000", fast)
+	}
+}
+
+func TestMixPickIsSeeded(t *testing.T) {
+	pick := func() []Persona {
+		r := rand.New(rand.NewSource(42))
+		var picks []Persona
+		for i := 0; i < 20; i++ {
+			picks = append(picks, DefaultMix.Pick(r))
+		}
+		return picks
+	}
+
+	first, second := pick(), pick()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("pick %d differs between runs with one seed: %s vs %s", i, first[i], second[i])
+		}
+	}
+}
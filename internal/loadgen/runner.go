@@ -0,0 +1,440 @@
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/flight-booking-system/internal/api"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+const (
+	declinedPaymentCode = "11111" // the simulated gateway always declines it, so the order waits for another payment
+	maxSeatsPerOrder    = 2
+	maxCreateAttempts   = 3 // orders a user creates before giving up on getting seats
+	pollInterval        = 500 * time.Millisecond
+)
+
+// Config controls a load generation run
+type Config struct {
+	BaseURL     string        // API server, e.g. http://localhost:8080
+	Users       int           // simulated users to run
+	Concurrency int           // users running at once
+	Mix         Mix           // persona weights
+	Think       time.Duration // mean pause between a user's actions
+	Timeout     time.Duration // longest one user waits for its order to settle
+	Seed        int64         // seeds persona picks and choices; the same seed repeats a run's script
+}
+
+// Outcome is how a simulated user's order ended
+type Outcome string
+
+const (
+	OutcomeConfirmed Outcome = "confirmed"
+	OutcomeRefunded  Outcome = "refunded"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeExpired   Outcome = "expired"
+	OutcomeCanceled  Outcome = "canceled"
+	OutcomeAbandoned Outcome = "abandoned" // left holding seats for the hold timer to expire
+	OutcomeNoSeats   Outcome = "no_seats"  // every seat picked was taken by another user
+	OutcomeError     Outcome = "error"
+)
+
+var outcomes = []Outcome{
+	OutcomeConfirmed, OutcomeRefunded, OutcomeFailed, OutcomeExpired,
+	OutcomeCanceled, OutcomeAbandoned, OutcomeNoSeats, OutcomeError,
+}
+
+// Report tallies a run by persona
+type Report struct {
+	mu          sync.Mutex
+	Outcomes    map[Persona]map[Outcome]int
+	SeatChanges map[Persona]int // seat updates applied, each resetting the hold timer
+	Conflicts   map[Persona]int // seat picks rejected because another user held them
+	Errors      []string        // first few unexpected errors, for the summary
+}
+
+func newReport() *Report {
+	return &Report{
+		Outcomes:    make(map[Persona]map[Outcome]int),
+		SeatChanges: make(map[Persona]int),
+		Conflicts:   make(map[Persona]int),
+	}
+}
+
+func (r *Report) record(p Persona, outcome Outcome, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Outcomes[p] == nil {
+		r.Outcomes[p] = make(map[Outcome]int)
+	}
+	r.Outcomes[p][outcome]++
+	if err != nil && len(r.Errors) < 10 {
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", p, err))
+	}
+}
+
+func (r *Report) count(m map[Persona]int, p Persona) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m[p]++
+}
+
+// Write prints the report as a table, one row per persona that ran
+func (r *Report) Write(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := []string{"persona"}
+	for _, o := range outcomes {
+		header = append(header, string(o))
+	}
+	header = append(header, "seat_changes", "conflicts")
+	fmt.Fprintln(tw, strings.Join(header, "\t")+"\t")
+
+	for _, p := range Personas {
+		counts, ok := r.Outcomes[p]
+		if !ok {
+			continue
+		}
+		row := []string{string(p)}
+		for _, o := range outcomes {
+			row = append(row, fmt.Sprint(counts[o]))
+		}
+		row = append(row, fmt.Sprint(r.SeatChanges[p]), fmt.Sprint(r.Conflicts[p]))
+		fmt.Fprintln(tw, strings.Join(row, "\t")+"\t")
+	}
+	tw.Flush()
+
+	for _, e := range r.Errors {
+		fmt.Fprintln(w, "error:", e)
+	}
+}
+
+// Run simulates cfg.Users customers against the API, each following a persona
+// picked from cfg.Mix, and reports how their orders ended
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	c := &client{baseURL: cfg.BaseURL, http: &http.Client{Timeout: 30 * time.Second}}
+
+	flights, err := c.listFlights(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list flights: %w", err)
+	}
+	var bookable []string
+	for _, f := range flights {
+		if f.Status != string(domain.FlightStatusCancelled) && !f.BookingFrozen && f.SalesClosedAt == nil && f.AvailableSeats > 0 {
+			bookable = append(bookable, f.ID)
+		}
+	}
+	if len(bookable) == 0 {
+		return nil, errors.New("no bookable flights")
+	}
+
+	report := newReport()
+	sem := make(chan struct{}, max(cfg.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Users; i++ {
+		// Each user gets its own source: rand.Rand is not safe for concurrent use
+		r := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+		u := &user{
+			client:  c,
+			cfg:     cfg,
+			r:       r,
+			persona: cfg.Mix.Pick(r),
+			report:  report,
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return report, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			u.run(ctx, bookable[u.r.Intn(len(bookable))])
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// user is one simulated customer
+type user struct {
+	client  *client
+	cfg     Config
+	r       *rand.Rand
+	persona Persona
+	report  *Report
+	seats   []string
+}
+
+func (u *user) run(ctx context.Context, flightID string) {
+	ctx, cancel := context.WithTimeout(ctx, u.cfg.Timeout)
+	defer cancel()
+
+	outcome, err := u.script(ctx, flightID)
+	u.report.record(u.persona, outcome, err)
+}
+
+// script plays the persona and returns how the order ended
+func (u *user) script(ctx context.Context, flightID string) (Outcome, error) {
+	orderID, err := u.book(ctx, flightID)
+	if errors.Is(err, errNoSeats) {
+		return OutcomeNoSeats, nil
+	}
+	if err != nil {
+		return OutcomeError, err
+	}
+
+	switch u.persona {
+	case PersonaFastPayer:
+		return u.payAndSettle(ctx, orderID)
+
+	case PersonaDitherer:
+		for changes := 2 + u.r.Intn(3); changes > 0; changes-- {
+			u.think(ctx)
+			if err := u.changeSeats(ctx, flightID, orderID); err != nil {
+				return u.actionFailed(ctx, orderID, err)
+			}
+		}
+		return u.payAndSettle(ctx, orderID)
+
+	case PersonaAbandoner:
+		u.think(ctx)
+		return OutcomeAbandoned, nil
+
+	case PersonaCanceler:
+		if u.r.Intn(2) == 0 {
+			u.think(ctx)
+			if err := u.changeSeats(ctx, flightID, orderID); err != nil {
+				return u.actionFailed(ctx, orderID, err)
+			}
+		}
+		u.think(ctx)
+		if err := u.client.cancel(ctx, orderID); err != nil {
+			return u.actionFailed(ctx, orderID, fmt.Errorf("cancel order %s: %w", orderID, err))
+		}
+		status, err := u.waitFor(ctx, orderID)
+		if err != nil {
+			return OutcomeError, err
+		}
+		if status == domain.OrderStatusFailed || status == domain.OrderStatusExpired {
+			return OutcomeCanceled, nil
+		}
+		return outcomeOf(status), nil
+
+	case PersonaDecliner:
+		u.think(ctx)
+		if err := u.client.pay(ctx, orderID, declinedPaymentCode); err != nil {
+			return u.actionFailed(ctx, orderID, fmt.Errorf("pay order %s: %w", orderID, err))
+		}
+		status, err := u.waitFor(ctx, orderID, domain.OrderStatusPaymentPending)
+		if err != nil {
+			return OutcomeError, err
+		}
+		if status != domain.OrderStatusPaymentPending {
+			return outcomeOf(status), nil
+		}
+		return u.payAndSettle(ctx, orderID)
+	}
+
+	return OutcomeError, fmt.Errorf("unknown persona %q", u.persona)
+}
+
+var errNoSeats = errors.New("no seats could be held")
+
+// terminalStatuses end a booking workflow
+var terminalStatuses = []domain.OrderStatus{
+	domain.OrderStatusConfirmed, domain.OrderStatusFailed,
+	domain.OrderStatusExpired, domain.OrderStatusPaymentRefunded, domain.OrderStatusCancelled,
+}
+
+func isTerminal(status domain.OrderStatus) bool {
+	return slices.Contains(terminalStatuses, status)
+}
+
+func outcomeOf(status domain.OrderStatus) Outcome {
+	switch status {
+	case domain.OrderStatusConfirmed:
+		return OutcomeConfirmed
+	case domain.OrderStatusPaymentRefunded:
+		return OutcomeRefunded
+	case domain.OrderStatusExpired:
+		return OutcomeExpired
+	default:
+		return OutcomeFailed
+	}
+}
+
+// book creates an order on available seats and waits for the workflow to
+// hold them. The workflow fails an order whose seats another user took
+// first; the user then picks again.
+func (u *user) book(ctx context.Context, flightID string) (string, error) {
+	count := 1 + u.r.Intn(maxSeatsPerOrder)
+	for attempt := 0; attempt < maxCreateAttempts; attempt++ {
+		seats, err := u.pickSeats(ctx, flightID, count)
+		if err != nil {
+			return "", err
+		}
+		resp, err := u.client.createOrder(ctx, flightID, seats)
+		if isSeatConflict(err) {
+			u.report.count(u.report.Conflicts, u.persona)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("create order: %w", err)
+		}
+
+		status, err := u.waitFor(ctx, resp.OrderID, domain.OrderStatusSeatsReserved)
+		if err != nil {
+			return "", err
+		}
+		if status != domain.OrderStatusSeatsReserved {
+			u.report.count(u.report.Conflicts, u.persona)
+			continue
+		}
+		u.seats = seats
+		return resp.OrderID, nil
+	}
+	return "", errNoSeats
+}
+
+// changeSeats moves the order to other available seats; a pick another user
+// holds is counted and skipped, keeping the current seats
+func (u *user) changeSeats(ctx context.Context, flightID, orderID string) error {
+	seats, err := u.pickSeats(ctx, flightID, len(u.seats))
+	if errors.Is(err, errNoSeats) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = u.client.updateSeats(ctx, orderID, seats)
+	if isSeatConflict(err) {
+		u.report.count(u.report.Conflicts, u.persona)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("update seats of order %s: %w", orderID, err)
+	}
+	u.seats = seats
+	u.report.count(u.report.SeatChanges, u.persona)
+	return nil
+}
+
+// pickSeats chooses count random seats the seat map shows as available
+func (u *user) pickSeats(ctx context.Context, flightID string, count int) ([]string, error) {
+	flight, err := u.client.flight(ctx, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("get flight %s: %w", flightID, err)
+	}
+
+	var available []api.SeatResponse
+	for _, s := range flight.SeatMap.Seats {
+		if s.Status == string(domain.SeatStatusAvailable) {
+			available = append(available, s)
+		}
+	}
+	if len(available) < count {
+		return nil, errNoSeats
+	}
+
+	u.r.Shuffle(len(available), func(i, j int) { available[i], available[j] = available[j], available[i] })
+	seats := make([]string, count)
+	for i := range seats {
+		seats[i] = available[i].ID
+	}
+	return seats, nil
+}
+
+// payAndSettle pays with a code the simulated gateway accepts, subject to its
+// failure rate, and waits for the order to finish
+func (u *user) payAndSettle(ctx context.Context, orderID string) (Outcome, error) {
+	u.think(ctx)
+	if err := u.client.pay(ctx, orderID, u.paymentCode()); err != nil {
+		return u.actionFailed(ctx, orderID, fmt.Errorf("pay order %s: %w", orderID, err))
+	}
+	status, err := u.waitFor(ctx, orderID)
+	if err != nil {
+		return OutcomeError, err
+	}
+	return outcomeOf(status), nil
+}
+
+// actionFailed reports an order whose workflow ended before the user's
+// action reached it, such as a hold that failed or expired, by how it ended,
+// and anything else as an error
+func (u *user) actionFailed(ctx context.Context, orderID string, err error) (Outcome, error) {
+	resp, statusErr := u.client.status(ctx, orderID)
+	if statusErr == nil && isTerminal(domain.OrderStatus(resp.Status)) {
+		return outcomeOf(domain.OrderStatus(resp.Status)), nil
+	}
+	return OutcomeError, err
+}
+
+// paymentCode returns a random five digit code that is not one of the
+// simulator's special codes
+func (u *user) paymentCode() string {
+	for {
+		code := fmt.Sprintf("%05d", u.r.Intn(100000))
+		if code != "00000" && code != "11111" && code != "99999" {
+			return code
+		}
+	}
+}
+
+// waitFor polls the order until it reaches one of statuses or ends
+func (u *user) waitFor(ctx context.Context, orderID string, statuses ...domain.OrderStatus) (domain.OrderStatus, error) {
+	for {
+		resp, err := u.client.status(ctx, orderID)
+		var apiErr *apiError
+		// The workflow creates the order row, so a fresh order can briefly 404
+		if err != nil && !(errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound) {
+			return "", fmt.Errorf("get status of order %s: %w", orderID, err)
+		}
+		if err == nil {
+			status := domain.OrderStatus(resp.Status)
+			// A hold that ends early is the answer whatever was awaited
+			if slices.Contains(statuses, status) || isTerminal(status) {
+				return status, nil
+			}
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return "", fmt.Errorf("wait for order %s: %w", orderID, ctx.Err())
+		}
+	}
+}
+
+// think pauses for a random time around cfg.Think
+func (u *user) think(ctx context.Context) {
+	if u.cfg.Think <= 0 {
+		return
+	}
+	d := u.cfg.Think/2 + time.Duration(u.r.Int63n(int64(u.cfg.Think)))
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
+
+func isSeatConflict(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict &&
+		apiErr.Code == api.ErrCodeSeatsUnavailable
+}
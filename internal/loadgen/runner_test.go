@@ -0,0 +1,104 @@
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/api"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// fakeAPI is a booking API whose orders hold their seats at once, confirm on
+// payment, fail on the declined code, and fail when canceled
+type fakeAPI struct {
+	mu     sync.Mutex
+	next   int
+	orders map[string]domain.OrderStatus
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && path == "/flights":
+		api.WriteJSON(w, http.StatusOK, api.FlightListResponse{Flights: []api.FlightResponse{
+			{ID: "f1", Status: "scheduled", AvailableSeats: 4},
+		}})
+	case r.Method == http.MethodGet && parts[0] == "flights":
+		seats := []api.SeatResponse{{ID: "1A"}, {ID: "1B"}, {ID: "2A"}, {ID: "2B"}}
+		for i := range seats {
+			seats[i].Status = string(domain.SeatStatusAvailable)
+		}
+		api.WriteJSON(w, http.StatusOK, api.FlightDetailResponse{SeatMap: api.SeatMapResponse{Seats: seats}})
+	case r.Method == http.MethodPost && path == "/orders":
+		f.next++
+		id := fmt.Sprintf("order-%d", f.next)
+		f.orders[id] = domain.OrderStatusSeatsReserved
+		api.WriteJSON(w, http.StatusCreated, api.CreateOrderResponse{OrderID: id})
+	case r.Method == http.MethodPut && len(parts) == 3 && parts[2] == "seats":
+		api.WriteJSON(w, http.StatusOK, struct{}{})
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "pay":
+		var req api.SubmitPaymentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.PaymentCode == declinedPaymentCode {
+			f.orders[parts[1]] = domain.OrderStatusPaymentPending
+		} else {
+			f.orders[parts[1]] = domain.OrderStatusConfirmed
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		f.orders[parts[1]] = domain.OrderStatusFailed
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[2] == "status":
+		api.WriteJSON(w, http.StatusOK, api.OrderStatusResponse{OrderID: parts[1], Status: string(f.orders[parts[1]])})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestRunPersonas(t *testing.T) {
+	server := httptest.NewServer(&fakeAPI{orders: make(map[string]domain.OrderStatus)})
+	defer server.Close()
+
+	tests := []struct {
+		persona Persona
+		want    Outcome
+	}{
+		{PersonaFastPayer, OutcomeConfirmed},
+		{PersonaDitherer, OutcomeConfirmed},
+		{PersonaAbandoner, OutcomeAbandoned},
+		{PersonaCanceler, OutcomeCanceled},
+		{PersonaDecliner, OutcomeConfirmed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.persona), func(t *testing.T) {
+			report, err := Run(context.Background(), Config{
+				BaseURL:     server.URL,
+				Users:       3,
+				Concurrency: 1,
+				Mix:         Mix{tt.persona: 1},
+				Timeout:     5 * time.Second,
+			})
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if got := report.Outcomes[tt.persona][tt.want]; got != 3 {
+				t.Errorf("outcomes = %v, want 3 %s (errors %v)", report.Outcomes[tt.persona], tt.want, report.Errors)
+			}
+			if tt.persona == PersonaDitherer && report.SeatChanges[tt.persona] < 6 {
+				t.Errorf("ditherers changed seats %d times, want at least 6", report.SeatChanges[tt.persona])
+			}
+		})
+	}
+}
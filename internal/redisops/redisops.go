@@ -0,0 +1,221 @@
+// Package redisops holds the Lua scripts behind the atomic Redis operations
+// used across the system: seat locks and rate-limit token buckets. Scripts are
+// embedded at build time and run by SHA, falling back to sending the source
+// when Redis has not cached them yet.
+package redisops
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed scripts/*.lua
+var scriptFS embed.FS
+
+var (
+	lockAcquire = mustScript("lock_acquire.lua")
+	lockRelease = mustScript("lock_release.lua")
+	lockExtend  = mustScript("lock_extend.lua")
+	lockHolders = mustScript("lock_holders.lua")
+	lockList    = mustScript("lock_list.lua")
+	tokenBucket = mustScript("token_bucket.lua")
+)
+
+// scripts lists every script for Load
+var scripts = []*redis.Script{lockAcquire, lockRelease, lockExtend, lockHolders, lockList, tokenBucket}
+
+func mustScript(name string) *redis.Script {
+	src, err := scriptFS.ReadFile("scripts/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("redisops: %v", err))
+	}
+	return redis.NewScript(string(src))
+}
+
+// Load caches every script in Redis so the first calls after startup run by
+// SHA instead of falling back to sending the source
+func Load(ctx context.Context, c redis.Scripter) error {
+	for _, script := range scripts {
+		if err := script.Load(ctx, c).Err(); err != nil {
+			return fmt.Errorf("load script: %w", err)
+		}
+	}
+	return nil
+}
+
+// LockSet is a group of locks in three keys: a hash of field to lock, a
+// sorted set of each field's expiry in Unix milliseconds, and a counter
+// issuing fencing tokens. Each lock records its owner and the token it was
+// taken under, so an owner acting on a lock it has since lost or retaken is
+// turned away. Expired fields are ignored by reads and dropped by the next
+// acquire, and the hash and sorted set expire with their last lock, so a set
+// is read whole without scanning the keyspace. The counter never expires, so
+// tokens only grow.
+type LockSet struct {
+	Key       string
+	ExpiryKey string
+	FenceKey  string
+}
+
+// keys lists the set's keys in the order every lock script takes them
+func (s LockSet) keys() []string {
+	return []string{s.Key, s.ExpiryKey, s.FenceKey}
+}
+
+// LockHolder is the owner of a lock, the fencing token it holds the lock
+// under, when it first took the lock, when the lock expires, and the time
+// left on it
+type LockHolder struct {
+	Owner      string
+	Token      int64
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+	TTL        time.Duration
+}
+
+// lockValue is a lock as the scripts store it, times in Unix milliseconds
+type lockValue struct {
+	Owner      string `json:"owner"`
+	Token      int64  `json:"token"`
+	AcquiredAt int64  `json:"acquiredAt"`
+	ExpiresAt  int64  `json:"expiresAt"`
+}
+
+// decodeHolder turns a stored lock and the milliseconds left on it into a
+// holder; an empty lock is an empty holder
+func decodeHolder(lock interface{}, ms interface{}) (LockHolder, error) {
+	raw, _ := lock.(string)
+	if raw == "" {
+		return LockHolder{}, nil
+	}
+
+	var v lockValue
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return LockHolder{}, fmt.Errorf("decode lock: %w", err)
+	}
+	left, _ := ms.(int64)
+	return LockHolder{
+		Owner:      v.Owner,
+		Token:      v.Token,
+		AcquiredAt: time.UnixMilli(v.AcquiredAt),
+		ExpiresAt:  time.UnixMilli(v.ExpiresAt),
+		TTL:        time.Duration(left) * time.Millisecond,
+	}, nil
+}
+
+// LockConflict is the first field AcquireLocks found held by another owner
+type LockConflict struct {
+	Index int
+	Owner string
+}
+
+// lockArgs appends fields to the script arguments that precede them
+func lockArgs(fields []string, args ...interface{}) []interface{} {
+	for _, field := range fields {
+		args = append(args, field)
+	}
+	return args
+}
+
+// AcquireLocks locks every field to owner for ttl, or none of them if another
+// owner holds any; fields owner already holds are refreshed. A token of zero
+// issues a new fencing token; any other token is one owner already holds,
+// which the fields join. It returns the token the fields are held under, or
+// the conflict that stopped them.
+func AcquireLocks(ctx context.Context, c redis.Scripter, set LockSet, fields []string, owner string, token int64, ttl time.Duration) (int64, *LockConflict, error) {
+	result, err := lockAcquire.Run(ctx, c, set.keys(), lockArgs(fields, owner, ttl.Milliseconds(), token)...).Slice()
+	if err != nil {
+		return 0, nil, fmt.Errorf("acquire locks: %w", err)
+	}
+
+	if i, _ := result[0].(int64); i > 0 {
+		holder, _ := result[1].(string)
+		return 0, &LockConflict{Index: int(i) - 1, Owner: holder}, nil
+	}
+	token, _ = result[1].(int64)
+	return token, nil, nil
+}
+
+// ReleaseLocks deletes the fields owner holds under token and returns how
+// many it deleted; a token of zero matches any of owner's tokens. Fields held
+// by other owners, or under another token, are left alone.
+func ReleaseLocks(ctx context.Context, c redis.Scripter, set LockSet, fields []string, owner string, token int64) (int64, error) {
+	released, err := lockRelease.Run(ctx, c, set.keys(), lockArgs(fields, owner, token)...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("release locks: %w", err)
+	}
+	return released, nil
+}
+
+// ExtendLocks resets the TTL of the live fields owner holds under token and
+// returns how many it extended; a token of zero matches any of owner's tokens
+func ExtendLocks(ctx context.Context, c redis.Scripter, set LockSet, fields []string, owner string, token int64, ttl time.Duration) (int64, error) {
+	extended, err := lockExtend.Run(ctx, c, set.keys(), lockArgs(fields, owner, ttl.Milliseconds(), token)...).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("extend locks: %w", err)
+	}
+	return extended, nil
+}
+
+// LockHolders returns the holder of each field's lock; fields not locked
+// have an empty holder
+func LockHolders(ctx context.Context, c redis.Scripter, set LockSet, fields []string) ([]LockHolder, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	result, err := lockHolders.Run(ctx, c, set.keys(), lockArgs(fields)...).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("get lock holders: %w", err)
+	}
+
+	holders := make([]LockHolder, len(fields))
+	for i := range holders {
+		holder, err := decodeHolder(result[2*i], result[2*i+1])
+		if err != nil {
+			return nil, fmt.Errorf("get lock holders: %w", err)
+		}
+		holders[i] = holder
+	}
+	return holders, nil
+}
+
+// ListLocks returns the holder of every live lock in the set by field
+func ListLocks(ctx context.Context, c redis.Scripter, set LockSet) (map[string]LockHolder, error) {
+	result, err := lockList.Run(ctx, c, set.keys()).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+
+	locks := make(map[string]LockHolder, len(result)/3)
+	for i := 0; i+2 < len(result); i += 3 {
+		field, _ := result[i].(string)
+		holder, err := decodeHolder(result[i+1], result[i+2])
+		if err != nil {
+			return nil, fmt.Errorf("list locks: %w", err)
+		}
+		locks[field] = holder
+	}
+	return locks, nil
+}
+
+// TakeToken takes one token from the bucket at key, which holds up to
+// capacity tokens and refills completely over refill. It returns the tokens
+// left and, when the bucket was empty, how long until a token is available.
+func TakeToken(ctx context.Context, c redis.Scripter, key string, capacity int, refill time.Duration) (int64, time.Duration, error) {
+	if capacity <= 0 || refill < time.Millisecond {
+		return 0, 0, fmt.Errorf("take token: invalid bucket of %d tokens over %s", capacity, refill)
+	}
+
+	result, err := tokenBucket.Run(ctx, c, []string{key}, capacity, refill.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("take token: %w", err)
+	}
+
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
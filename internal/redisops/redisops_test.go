@@ -0,0 +1,281 @@
+package redisops
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+)
+
+func TestScriptsEmbedded(t *testing.T) {
+	names := []string{"lock_acquire.lua", "lock_release.lua", "lock_extend.lua", "lock_holders.lua", "lock_list.lua", "token_bucket.lua"}
+	if len(names) != len(scripts) {
+		t.Fatalf("%d scripts registered, %d expected", len(scripts), len(names))
+	}
+
+	for i, name := range names {
+		src, err := scriptFS.ReadFile("scripts/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := sha1.Sum(src)
+		if got, want := scripts[i].Hash(), hex.EncodeToString(sum[:]); got != want {
+			t.Errorf("%s: hash %s, want %s", name, got, want)
+		}
+	}
+}
+
+// redisClient connects to the docker-compose Redis, skipping the test without it
+func redisClient(t *testing.T) *redis.Client {
+	t.Helper()
+	client, err := database.NewRedisClient(context.Background(), config.Load().Redis)
+	if err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// testKeys returns n keys unique to this run, deleted when the test ends
+func testKeys(t *testing.T, client *redis.Client, n int) []string {
+	t.Helper()
+	prefix := "redisops:test:" + uuid.New().String()
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = prefix + ":" + string(rune('a'+i))
+	}
+	t.Cleanup(func() { client.Del(context.Background(), keys...) })
+	return keys
+}
+
+// testLockSet returns a lock set unique to this run, deleted when the test ends
+func testLockSet(t *testing.T, client *redis.Client) LockSet {
+	t.Helper()
+	keys := testKeys(t, client, 3)
+	return LockSet{Key: keys[0], ExpiryKey: keys[1], FenceKey: keys[2]}
+}
+
+func TestAcquireLocks_AllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	set := testLockSet(t, client)
+	fields := []string{"1A", "1B", "1C"}
+
+	_, conflict, err := AcquireLocks(ctx, client, set, fields[1:2], "order-1", 0, time.Minute)
+	if err != nil || conflict != nil {
+		t.Fatalf("first lock: conflict %+v, err %v", conflict, err)
+	}
+
+	_, conflict, err = AcquireLocks(ctx, client, set, fields, "order-2", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflict == nil || conflict.Index != 1 || conflict.Owner != "order-1" {
+		t.Fatalf("conflict %+v, want 1 held by order-1", conflict)
+	}
+	if n := client.HLen(ctx, set.Key).Val(); n != 1 {
+		t.Errorf("%d fields locked after a conflict, want only the first lock", n)
+	}
+
+	// The holder may lock again, which refreshes its fields
+	_, conflict, err = AcquireLocks(ctx, client, set, fields, "order-1", 0, 2*time.Minute)
+	if err != nil || conflict != nil {
+		t.Fatalf("relock: conflict %+v, err %v", conflict, err)
+	}
+	holders, err := LockHolders(ctx, client, set, fields[1:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holders[0].Owner != "order-1" || holders[0].TTL <= time.Minute {
+		t.Errorf("holder %+v after relock, want order-1 for over a minute", holders[0])
+	}
+	if ttl := client.PTTL(ctx, set.Key).Val(); ttl <= time.Minute {
+		t.Errorf("set expires in %s, want with its last lock", ttl)
+	}
+}
+
+func TestAcquireLocks_ExpiredFieldsFree(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	set := testLockSet(t, client)
+
+	if _, _, err := AcquireLocks(ctx, client, set, []string{"1A"}, "order-1", 0, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := AcquireLocks(ctx, client, set, []string{"1B"}, "order-1", 0, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	locks, err := ListLocks(ctx, client, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks["1B"].Owner != "order-1" {
+		t.Fatalf("locks %v, want only 1B once 1A expired", locks)
+	}
+
+	_, conflict, err := AcquireLocks(ctx, client, set, []string{"1A"}, "order-2", 0, time.Minute)
+	if err != nil || conflict != nil {
+		t.Fatalf("lock expired field: conflict %+v, err %v", conflict, err)
+	}
+}
+
+func TestReleaseAndExtendLocks_OnlyOwnFields(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	set := testLockSet(t, client)
+
+	AcquireLocks(ctx, client, set, []string{"1A"}, "order-1", 0, time.Minute)
+	AcquireLocks(ctx, client, set, []string{"1B"}, "order-2", 0, time.Minute)
+	fields := []string{"1A", "1B"}
+
+	extended, err := ExtendLocks(ctx, client, set, fields, "order-1", 0, time.Hour)
+	if err != nil || extended != 1 {
+		t.Fatalf("extended %d, err %v; want 1", extended, err)
+	}
+	holders, err := LockHolders(ctx, client, set, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holders[0].TTL <= time.Minute || holders[1].TTL > time.Minute {
+		t.Errorf("holders %+v, want only order-1's lock extended", holders)
+	}
+
+	released, err := ReleaseLocks(ctx, client, set, fields, "order-1", 0)
+	if err != nil || released != 1 {
+		t.Fatalf("released %d, err %v; want 1", released, err)
+	}
+	locks, err := ListLocks(ctx, client, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locks) != 1 || locks["1B"].Owner != "order-2" {
+		t.Errorf("locks %v after release, want order-2's lock kept", locks)
+	}
+}
+
+func TestLocks_StaleTokenRejected(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	set := testLockSet(t, client)
+	fields := []string{"1A", "1B"}
+
+	first, _, err := AcquireLocks(ctx, client, set, fields, "order-1", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := AcquireLocks(ctx, client, set, fields, "order-1", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Fatalf("tokens %d then %d, want them to grow", first, second)
+	}
+
+	// A holder that lost the lock to a newer token is turned away
+	if n, err := ExtendLocks(ctx, client, set, fields, "order-1", first, time.Hour); err != nil || n != 0 {
+		t.Errorf("stale extend: extended %d, err %v; want none", n, err)
+	}
+	if n, err := ReleaseLocks(ctx, client, set, fields, "order-1", first); err != nil || n != 0 {
+		t.Errorf("stale release: released %d, err %v; want none", n, err)
+	}
+
+	// A later lock may join the current token
+	joined, _, err := AcquireLocks(ctx, client, set, []string{"1C"}, "order-1", second, time.Minute)
+	if err != nil || joined != second {
+		t.Fatalf("join: token %d, err %v; want %d", joined, err, second)
+	}
+	if n, err := ReleaseLocks(ctx, client, set, []string{"1A", "1B", "1C"}, "order-1", second); err != nil || n != 3 {
+		t.Errorf("current release: released %d, err %v; want 3", n, err)
+	}
+}
+
+func TestLocks_RecordAcquiredAndExpiresAt(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	set := testLockSet(t, client)
+	fields := []string{"1A"}
+
+	if _, _, err := AcquireLocks(ctx, client, set, fields, "order-1", 0, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	first, err := ListLocks(ctx, client, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	held := first["1A"]
+	if held.AcquiredAt.IsZero() || held.ExpiresAt.Sub(held.AcquiredAt) != time.Minute {
+		t.Fatalf("lock %+v, want acquired a minute before it expires", held)
+	}
+
+	// Refreshing and extending move the expiry but keep when it was taken
+	time.Sleep(10 * time.Millisecond)
+	if _, _, err := AcquireLocks(ctx, client, set, fields, "order-1", 0, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExtendLocks(ctx, client, set, fields, "order-1", 0, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	holders, err := LockHolders(ctx, client, set, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !holders[0].AcquiredAt.Equal(held.AcquiredAt) {
+		t.Errorf("acquired at %s after refresh, want %s kept", holders[0].AcquiredAt, held.AcquiredAt)
+	}
+	if holders[0].ExpiresAt.Sub(held.AcquiredAt) <= time.Minute {
+		t.Errorf("expires at %s after extending, want about an hour out", holders[0].ExpiresAt)
+	}
+}
+
+func TestTakeToken_DrainsAndRefills(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+	key := testKeys(t, client, 1)[0]
+
+	for want := int64(2); want >= 0; want-- {
+		remaining, wait, err := TakeToken(ctx, client, key, 3, 300*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remaining != want || wait != 0 {
+			t.Fatalf("remaining %d wait %s, want %d and no wait", remaining, wait, want)
+		}
+	}
+
+	_, wait, err := TakeToken(ctx, client, key, 3, 300*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wait <= 0 || wait > 100*time.Millisecond {
+		t.Fatalf("wait %s on an empty bucket, want up to one token's refill", wait)
+	}
+
+	time.Sleep(wait + 10*time.Millisecond)
+	if _, wait, err = TakeToken(ctx, client, key, 3, 300*time.Millisecond); err != nil || wait != 0 {
+		t.Fatalf("after refilling: wait %s, err %v", wait, err)
+	}
+}
+
+func TestLoad_RunsBySHA(t *testing.T) {
+	ctx := context.Background()
+	client := redisClient(t)
+
+	if err := Load(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	for _, script := range scripts {
+		exists, err := script.Exists(ctx, client).Result()
+		if err != nil || !exists[0] {
+			t.Fatalf("script %s not cached: %v", script.Hash(), err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+-- Locks fields ARGV[4..] of the hash KEYS[1] for owner ARGV[1] for ARGV[2]
+-- milliseconds, or none of them if another owner holds any. KEYS[2] is a
+-- sorted set of each field's expiry in Unix milliseconds; expired fields are
+-- dropped first. Each lock is stored as JSON {owner, token, acquiredAt,
+-- expiresAt}, times in Unix milliseconds: a token ARGV[3] of 0 takes a new
+-- fencing token from the counter KEYS[3], which never expires so tokens only
+-- grow; any other token is one the owner already holds, which the fields
+-- join. Fields the owner already holds are refreshed and keep acquiredAt.
+-- Returns {0, token} on success, or {i, owner} for the first field held by another owner.
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+for _, field in ipairs(redis.call("ZRANGEBYSCORE", KEYS[2], "-inf", now)) do
+	redis.call("HDEL", KEYS[1], field)
+end
+redis.call("ZREMRANGEBYSCORE", KEYS[2], "-inf", now)
+
+for i = 4, #ARGV do
+	local lock = redis.call("HGET", KEYS[1], ARGV[i])
+	if lock then
+		local owner = cjson.decode(lock).owner
+		if owner ~= ARGV[1] then
+			return {i - 3, owner}
+		end
+	end
+end
+
+local token = tonumber(ARGV[3])
+if token == 0 then
+	token = redis.call("INCR", KEYS[3])
+end
+
+local expiry = now + tonumber(ARGV[2])
+for i = 4, #ARGV do
+	-- Any field still locked is the owner's, as conflicts returned above
+	local acquiredAt = now
+	local held = redis.call("HGET", KEYS[1], ARGV[i])
+	if held then
+		acquiredAt = cjson.decode(held).acquiredAt or now
+	end
+	local lock = {owner = ARGV[1], token = token, acquiredAt = acquiredAt, expiresAt = expiry}
+	redis.call("HSET", KEYS[1], ARGV[i], cjson.encode(lock))
+	redis.call("ZADD", KEYS[2], expiry, ARGV[i])
+end
+
+-- Both keys live until their last lock expires
+local last = redis.call("ZRANGE", KEYS[2], -1, -1, "WITHSCORES")[2]
+if last then
+	redis.call("PEXPIREAT", KEYS[1], last)
+	redis.call("PEXPIREAT", KEYS[2], last)
+end
+return {0, token}
@@ -0,0 +1,32 @@
+-- Sets the expiry of fields ARGV[4..] of the hash KEYS[1] held by owner
+-- ARGV[1] under fencing token ARGV[3] to ARGV[2] milliseconds from now, in
+-- the lock and the sorted set KEYS[2]. A token of 0 matches any of the
+-- owner's tokens.
+-- Expired fields are not extended. Returns the number of fields extended.
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local token = tonumber(ARGV[3])
+local expiry = now + tonumber(ARGV[2])
+local extended = 0
+for i = 4, #ARGV do
+	local current = tonumber(redis.call("ZSCORE", KEYS[2], ARGV[i]))
+	local lock = redis.call("HGET", KEYS[1], ARGV[i])
+	if current and current > now and lock then
+		lock = cjson.decode(lock)
+		if lock.owner == ARGV[1] and (token == 0 or lock.token == token) then
+			lock.expiresAt = expiry
+			redis.call("HSET", KEYS[1], ARGV[i], cjson.encode(lock))
+			redis.call("ZADD", KEYS[2], expiry, ARGV[i])
+			extended = extended + 1
+		end
+	end
+end
+
+-- Both keys live until their last lock expires
+local last = redis.call("ZRANGE", KEYS[2], -1, -1, "WITHSCORES")[2]
+if last then
+	redis.call("PEXPIREAT", KEYS[1], last)
+	redis.call("PEXPIREAT", KEYS[2], last)
+end
+return extended
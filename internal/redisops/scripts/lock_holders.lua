@@ -0,0 +1,20 @@
+-- Reads the locks on fields ARGV of the hash KEYS[1], whose expiries in Unix
+-- milliseconds are in the sorted set KEYS[2].
+-- Returns {lock JSON, milliseconds left} for each field; "" and 0 when not
+-- locked.
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local result = {}
+for _, field in ipairs(ARGV) do
+	local expiry = tonumber(redis.call("ZSCORE", KEYS[2], field))
+	local lock = redis.call("HGET", KEYS[1], field)
+	if expiry and expiry > now and lock then
+		table.insert(result, lock)
+		table.insert(result, expiry - now)
+	else
+		table.insert(result, "")
+		table.insert(result, 0)
+	end
+end
+return result
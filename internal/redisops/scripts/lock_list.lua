@@ -0,0 +1,18 @@
+-- Lists the live locks in the hash KEYS[1], whose expiries in Unix
+-- milliseconds are in the sorted set KEYS[2].
+-- Returns {field, lock JSON, milliseconds left, ...} for every lock that has
+-- not expired.
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local result = {}
+local live = redis.call("ZRANGEBYSCORE", KEYS[2], "(" .. now, "+inf", "WITHSCORES")
+for i = 1, #live, 2 do
+	local lock = redis.call("HGET", KEYS[1], live[i])
+	if lock then
+		table.insert(result, live[i])
+		table.insert(result, lock)
+		table.insert(result, tonumber(live[i + 1]) - now)
+	end
+end
+return result
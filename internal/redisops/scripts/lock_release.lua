@@ -0,0 +1,18 @@
+-- Deletes fields ARGV[3..] of the hash KEYS[1] held by owner ARGV[1] under
+-- fencing token ARGV[2], with their expiries in the sorted set KEYS[2]. A
+-- token of 0 matches any of the owner's tokens. Fields held by other owners,
+-- or by the owner under another token, are left alone.
+-- Returns the number of fields deleted.
+local token = tonumber(ARGV[2])
+local released = 0
+for i = 3, #ARGV do
+	local lock = redis.call("HGET", KEYS[1], ARGV[i])
+	if lock then
+		lock = cjson.decode(lock)
+		if lock.owner == ARGV[1] and (token == 0 or lock.token == token) then
+			released = released + redis.call("HDEL", KEYS[1], ARGV[i])
+			redis.call("ZREM", KEYS[2], ARGV[i])
+		end
+	end
+end
+return released
@@ -0,0 +1,27 @@
+-- Takes one token from the bucket at KEYS[1], which holds up to ARGV[1]
+-- tokens and refills completely over ARGV[2] milliseconds. The bucket starts
+-- full and is forgotten once it would have refilled.
+-- Returns {tokens left, milliseconds until a token is available}; the wait is
+-- 0 when a token was taken.
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or capacity
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / window)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * window / capacity)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], window)
+
+return {math.floor(tokens), wait}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/flight-booking-system/internal/domain"
@@ -13,7 +16,8 @@ import (
 
 // FlightRepo handles flight data access
 type FlightRepo struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool // serves listings when set
 }
 
 // NewFlightRepo creates a new FlightRepo
@@ -21,6 +25,21 @@ func NewFlightRepo(pool *pgxpool.Pool) *FlightRepo {
 	return &FlightRepo{pool: pool}
 }
 
+// WithReplica has the repo's listings read from replica, which may lag the
+// primary. A nil replica keeps them on the primary.
+func (r *FlightRepo) WithReplica(replica *pgxpool.Pool) *FlightRepo {
+	r.replica = replica
+	return r
+}
+
+// reader is the pool listings read from: the replica when there is one
+func (r *FlightRepo) reader() *pgxpool.Pool {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.pool
+}
+
 // GetAllFlightIDs returns all flight IDs in the system
 func (r *FlightRepo) GetAllFlightIDs(ctx context.Context) ([]string, error) {
 	query := `SELECT id FROM flights ORDER BY id ASC`
@@ -47,12 +66,13 @@ func (r *FlightRepo) GetAllFlightIDs(ctx context.Context) ([]string, error) {
 func (r *FlightRepo) FindAll(ctx context.Context) ([]domain.Flight, error) {
 	query := `
 		SELECT id, flight_number, origin, destination, departure_time, arrival_time,
-		       total_seats, available_seats, price_cents, created_at, updated_at
+		       total_seats, available_seats, price_cents, status, delay_minutes, booking_frozen,
+		       sales_closed_at, overbooking_percent, created_at, updated_at
 		FROM flights
 		ORDER BY departure_time ASC
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.reader().Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("query flights: %w", err)
 	}
@@ -64,7 +84,8 @@ func (r *FlightRepo) FindAll(ctx context.Context) ([]domain.Flight, error) {
 		err := rows.Scan(
 			&f.ID, &f.FlightNumber, &f.Origin, &f.Destination,
 			&f.DepartureTime, &f.ArrivalTime, &f.TotalSeats,
-			&f.AvailableSeats, &f.PriceCents, &f.CreatedAt, &f.UpdatedAt,
+			&f.AvailableSeats, &f.PriceCents, &f.Status, &f.DelayMinutes, &f.BookingFrozen,
+			&f.SalesClosedAt, &f.OverbookingPercent, &f.CreatedAt, &f.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan flight: %w", err)
@@ -75,11 +96,67 @@ func (r *FlightRepo) FindAll(ctx context.Context) ([]domain.Flight, error) {
 	return flights, rows.Err()
 }
 
+// Create stores a new flight with its seat map in one transaction and sets
+// flight.ID. A flight whose number is already taken is skipped and reported
+// as not created.
+func (r *FlightRepo) Create(ctx context.Context, flight *domain.Flight, seats []domain.Seat) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin create flight: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO flights (flight_number, origin, destination, departure_time, arrival_time,
+		                     total_seats, available_seats, price_cents)
+		VALUES ($1, $2, $3, $4, $5, $6, $6, $7)
+		ON CONFLICT (flight_number) DO NOTHING
+		RETURNING id
+	`, flight.FlightNumber, flight.Origin, flight.Destination, flight.DepartureTime, flight.ArrivalTime,
+		len(seats), flight.PriceCents).Scan(&flight.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("insert flight %s: %w", flight.FlightNumber, err)
+	}
+
+	rows := make([][]interface{}, len(seats))
+	for i, seat := range seats {
+		rows[i] = []interface{}{seat.ID, flight.ID, seat.Row, seat.Column, seat.Class}
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"seats"},
+		[]string{"id", "flight_id", "row_num", "col", "cabin_class"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		return false, fmt.Errorf("insert seats of flight %s: %w", flight.FlightNumber, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit create flight: %w", err)
+	}
+
+	return true, nil
+}
+
+// FindTakenNumbers returns those of numbers that a flight already has
+func (r *FlightRepo) FindTakenNumbers(ctx context.Context, numbers []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT flight_number FROM flights WHERE flight_number = ANY($1)`, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("query flight numbers: %w", err)
+	}
+	taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("query flight numbers: %w", err)
+	}
+	return taken, nil
+}
+
 // FindByID returns a flight by ID
 func (r *FlightRepo) FindByID(ctx context.Context, id string) (*domain.Flight, error) {
 	query := `
 		SELECT id, flight_number, origin, destination, departure_time, arrival_time,
-		       total_seats, available_seats, price_cents, created_at, updated_at
+		       total_seats, available_seats, price_cents, status, delay_minutes, booking_frozen,
+		       sales_closed_at, overbooking_percent, created_at, updated_at
 		FROM flights
 		WHERE id = $1
 	`
@@ -88,7 +165,8 @@ func (r *FlightRepo) FindByID(ctx context.Context, id string) (*domain.Flight, e
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&f.ID, &f.FlightNumber, &f.Origin, &f.Destination,
 		&f.DepartureTime, &f.ArrivalTime, &f.TotalSeats,
-		&f.AvailableSeats, &f.PriceCents, &f.CreatedAt, &f.UpdatedAt,
+		&f.AvailableSeats, &f.PriceCents, &f.Status, &f.DelayMinutes, &f.BookingFrozen,
+		&f.SalesClosedAt, &f.OverbookingPercent, &f.CreatedAt, &f.UpdatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -101,16 +179,349 @@ func (r *FlightRepo) FindByID(ctx context.Context, id string) (*domain.Flight, e
 	return &f, nil
 }
 
+// FindUpcomingIDs returns flights departing between from and to that are
+// neither cancelled nor departed
+func (r *FlightRepo) FindUpcomingIDs(ctx context.Context, from, to time.Time) ([]string, error) {
+	query := `
+		SELECT id FROM flights
+		WHERE departure_time BETWEEN $1 AND $2 AND status NOT IN ('cancelled', 'departed')
+		ORDER BY departure_time ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query upcoming flights: %w", err)
+	}
+	defer rows.Close()
+
+	var flightIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan flight ID: %w", err)
+		}
+		flightIDs = append(flightIDs, id)
+	}
+
+	return flightIDs, rows.Err()
+}
+
+// ApplyDisruption delays or cancels a flight that has not departed or been
+// cancelled. A delay moves departure and arrival and adds to delay_minutes.
+// It reports false when the flight was no longer eligible.
+func (r *FlightRepo) ApplyDisruption(ctx context.Context, d domain.FlightDisruption) (bool, error) {
+	query := `
+		UPDATE flights
+		SET status = $2,
+		    delay_minutes = delay_minutes + $3,
+		    departure_time = departure_time + make_interval(mins => $3),
+		    arrival_time = arrival_time + make_interval(mins => $3),
+		    updated_at = NOW()
+		WHERE id = $1 AND status NOT IN ('cancelled', 'departed') AND departure_time > NOW()
+	`
+
+	result, err := r.pool.Exec(ctx, query, d.FlightID, d.Status, int(d.Delay.Minutes()))
+	if err != nil {
+		return false, fmt.Errorf("apply disruption: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// SetBookingFrozen freezes or unfreezes bookings on a flight
+func (r *FlightRepo) SetBookingFrozen(ctx context.Context, flightID string, frozen bool) error {
+	query := `
+		UPDATE flights SET booking_frozen = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, flightID, frozen)
+	if err != nil {
+		return fmt.Errorf("set booking frozen: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrFlightNotFound
+	}
+
+	return nil
+}
+
+// SetOverbookingPercent sets how far beyond its seat map a flight may sell.
+// It fails with domain.ErrInsufficientSeats if more is already sold than the
+// new percentage allows.
+func (r *FlightRepo) SetOverbookingPercent(ctx context.Context, flightID string, percent int) error {
+	query := `
+		UPDATE flights SET overbooking_percent = $2, updated_at = NOW()
+		WHERE id = $1 AND available_seats >= -(total_seats * $2 / 100)
+	`
+
+	result, err := r.pool.Exec(ctx, query, flightID, percent)
+	if err != nil {
+		return fmt.Errorf("set overbooking percent: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		if _, err := r.FindByID(ctx, flightID); err != nil {
+			return err
+		}
+		return domain.ErrInsufficientSeats
+	}
+
+	return nil
+}
+
+// Oversold counts the seats a flight has promised beyond its available seat
+// rows; zero or less means every order can be seated
+func (r *FlightRepo) Oversold(ctx context.Context, flightID string) (int, error) {
+	var unpromised int
+	if err := r.pool.QueryRow(ctx, `SELECT `+unpromisedSeats, flightID, nil).Scan(&unpromised); err != nil {
+		return 0, fmt.Errorf("count oversold seats: %w", err)
+	}
+
+	return -unpromised, nil
+}
+
+// FindBumps returns the orders bumped off a flight, in the order they were bumped
+func (r *FlightRepo) FindBumps(ctx context.Context, flightID string) ([]domain.Bump, error) {
+	query := `
+		SELECT order_id, flight_id, seats, status, alternative_flight_id, compensation_cents, created_at
+		FROM flight_bumps
+		WHERE flight_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query bumps: %w", err)
+	}
+	defer rows.Close()
+
+	var bumps []domain.Bump
+	for rows.Next() {
+		var b domain.Bump
+		err := rows.Scan(&b.OrderID, &b.FlightID, &b.Seats, &b.Status, &b.AlternativeFlightID, &b.CompensationCents, &b.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan bump: %w", err)
+		}
+		bumps = append(bumps, b)
+	}
+
+	return bumps, rows.Err()
+}
+
+// FindOnSaleIDs returns flights still taking bookings: not departed,
+// cancelled or closed for sale
+func (r *FlightRepo) FindOnSaleIDs(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT id FROM flights
+		WHERE status NOT IN ('cancelled', 'departed') AND sales_closed_at IS NULL AND departure_time > NOW()
+		ORDER BY departure_time ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query on-sale flights: %w", err)
+	}
+	defer rows.Close()
+
+	var flightIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan flight ID: %w", err)
+		}
+		flightIDs = append(flightIDs, id)
+	}
+
+	return flightIDs, rows.Err()
+}
+
+// Reprice sets a flight's fare to its base price scaled by curve at its
+// current occupancy and records the change in its price history. It returns
+// nil when the fare is unchanged or the flight is no longer on sale.
+func (r *FlightRepo) Reprice(ctx context.Context, flightID string, curve domain.PricingCurve, reason domain.PriceChangeReason, orderID *string) (*domain.PriceChange, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin reprice: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	change := domain.PriceChange{FlightID: flightID, Reason: reason, OrderID: orderID}
+	var onSale bool
+	err = tx.QueryRow(ctx, `
+		SELECT price_cents, COALESCE(base_price_cents, price_cents), total_seats, available_seats,
+		       status NOT IN ('cancelled', 'departed') AND sales_closed_at IS NULL
+		FROM flights WHERE id = $1
+		FOR UPDATE
+	`, flightID).Scan(&change.PreviousPriceCents, &change.BasePriceCents, &change.TotalSeats, &change.AvailableSeats, &onSale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrFlightNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock flight: %w", err)
+	}
+
+	change.PriceCents = curve.Price(change.BasePriceCents, change.TotalSeats, change.AvailableSeats)
+	if !onSale || change.PriceCents == change.PreviousPriceCents {
+		return nil, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE flights SET price_cents = $2, base_price_cents = $3, updated_at = NOW()
+		WHERE id = $1
+	`, flightID, change.PriceCents, change.BasePriceCents)
+	if err != nil {
+		return nil, fmt.Errorf("update price: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO flight_price_history
+		    (flight_id, price_cents, previous_price_cents, base_price_cents, total_seats, available_seats, reason, order_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING changed_at
+	`, flightID, change.PriceCents, change.PreviousPriceCents, change.BasePriceCents,
+		change.TotalSeats, change.AvailableSeats, change.Reason, change.OrderID).Scan(&change.ChangedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert price history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit reprice: %w", err)
+	}
+	return &change, nil
+}
+
+// FindPriceHistory returns the fare changes dynamic pricing made to a
+// flight, oldest first
+func (r *FlightRepo) FindPriceHistory(ctx context.Context, flightID string) ([]domain.PriceChange, error) {
+	query := `
+		SELECT flight_id, price_cents, previous_price_cents, base_price_cents, total_seats, available_seats,
+		       reason, order_id, changed_at
+		FROM flight_price_history
+		WHERE flight_id = $1
+		ORDER BY changed_at, id
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query price history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []domain.PriceChange
+	for rows.Next() {
+		var c domain.PriceChange
+		err := rows.Scan(&c.FlightID, &c.PriceCents, &c.PreviousPriceCents, &c.BasePriceCents, &c.TotalSeats,
+			&c.AvailableSeats, &c.Reason, &c.OrderID, &c.ChangedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan price change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+// FindUnfinalizedIDs returns flights departing before the given time that
+// are neither cancelled nor departed, including any whose departure has passed
+func (r *FlightRepo) FindUnfinalizedIDs(ctx context.Context, before time.Time) ([]string, error) {
+	query := `
+		SELECT id FROM flights
+		WHERE departure_time < $1 AND status NOT IN ('cancelled', 'departed')
+		ORDER BY departure_time ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("query unfinalized flights: %w", err)
+	}
+	defer rows.Close()
+
+	var flightIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan flight ID: %w", err)
+		}
+		flightIDs = append(flightIDs, id)
+	}
+
+	return flightIDs, rows.Err()
+}
+
+// CloseSales stops new bookings and seat changes on a flight; closing it
+// again keeps the first closing time
+func (r *FlightRepo) CloseSales(ctx context.Context, flightID string) error {
+	query := `
+		UPDATE flights SET sales_closed_at = COALESCE(sales_closed_at, NOW()), updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, flightID)
+	if err != nil {
+		return fmt.Errorf("close sales: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrFlightNotFound
+	}
+
+	return nil
+}
+
+// MarkDeparted sets a flight that was not cancelled to departed, reporting
+// false when it was cancelled
+func (r *FlightRepo) MarkDeparted(ctx context.Context, flightID string) (bool, error) {
+	query := `
+		UPDATE flights SET status = 'departed', updated_at = NOW()
+		WHERE id = $1 AND status <> 'cancelled'
+	`
+
+	result, err := r.pool.Exec(ctx, query, flightID)
+	if err != nil {
+		return false, fmt.Errorf("mark departed: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// SaveManifest stores a flight's manifest, replacing an earlier one
+func (r *FlightRepo) SaveManifest(ctx context.Context, manifest domain.Manifest) error {
+	query := `
+		INSERT INTO flight_manifests (flight_id, manifest, generated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (flight_id) DO UPDATE SET manifest = EXCLUDED.manifest, generated_at = EXCLUDED.generated_at
+	`
+
+	if _, err := r.pool.Exec(ctx, query, manifest.FlightID, manifest, manifest.GeneratedAt); err != nil {
+		return fmt.Errorf("save manifest: %w", err)
+	}
+
+	return nil
+}
+
+// FindManifest returns the manifest stored for a flight
+func (r *FlightRepo) FindManifest(ctx context.Context, flightID string) (*domain.Manifest, error) {
+	var manifest domain.Manifest
+	err := r.pool.QueryRow(ctx, `SELECT manifest FROM flight_manifests WHERE flight_id = $1`, flightID).Scan(&manifest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrManifestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
 // FindSeats returns all seats for a flight
 func (r *FlightRepo) FindSeats(ctx context.Context, flightID string) ([]domain.Seat, error) {
 	query := `
-		SELECT id, flight_id, row_num, col, status, order_id, created_at, updated_at
+		SELECT id, flight_id, row_num, col, cabin_class, status, order_id, version, created_at, updated_at
 		FROM seats
 		WHERE flight_id = $1
 		ORDER BY row_num, col
 	`
 
-	rows, err := r.pool.Query(ctx, query, flightID)
+	rows, err := r.reader().Query(ctx, query, flightID)
 	if err != nil {
 		return nil, fmt.Errorf("query seats: %w", err)
 	}
@@ -120,8 +531,8 @@ func (r *FlightRepo) FindSeats(ctx context.Context, flightID string) ([]domain.S
 	for rows.Next() {
 		var s domain.Seat
 		err := rows.Scan(
-			&s.ID, &s.FlightID, &s.Row, &s.Column,
-			&s.Status, &s.OrderID, &s.CreatedAt, &s.UpdatedAt,
+			&s.ID, &s.FlightID, &s.Row, &s.Column, &s.Class,
+			&s.Status, &s.OrderID, &s.Version, &s.CreatedAt, &s.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan seat: %w", err)
@@ -132,41 +543,147 @@ func (r *FlightRepo) FindSeats(ctx context.Context, flightID string) ([]domain.S
 	return seats, rows.Err()
 }
 
-// UpdateAvailableSeats updates the available seat count
-func (r *FlightRepo) UpdateAvailableSeats(ctx context.Context, flightID string, delta int) error {
-	query := `
-		UPDATE flights
-		SET available_seats = available_seats + $1, updated_at = NOW()
-		WHERE id = $2 AND available_seats + $1 >= 0
-	`
+// unpromisedSeats counts flight $1's available seats not already promised
+// to seatless orders, other than order $2 (which may be NULL). It goes
+// negative when the flight is overbooked.
+const unpromisedSeats = `
+	(SELECT COUNT(*) FROM seats WHERE flight_id = $1 AND status = 'available')
+	- (SELECT COALESCE(SUM(cabin_seats), 0) FROM orders
+	   WHERE flight_id = $1 AND id IS DISTINCT FROM $2::uuid AND status NOT IN ('FAILED', 'EXPIRED', 'PAYMENT_REFUNDED', 'CANCELLED'))
+`
+
+// uncommittedSeatsQuery counts the seats flight $1 can still sell, as
+// unpromisedSeats plus its overbooking allowance
+const uncommittedSeatsQuery = `SELECT ` + unpromisedSeats + `
+	+ (SELECT total_seats * overbooking_percent / 100 FROM flights WHERE id = $1)
+`
+
+// RecomputeSeatCounts rewrites the seat counts of flights to what their seat
+// rows and confirmed orders give, for repairing counts that have drifted.
+// The database keeps them current on every seat and order write; this is for
+// counts changed behind its back. The flights are locked in ID order first,
+// so two calls cannot deadlock.
+func (r *FlightRepo) RecomputeSeatCounts(ctx context.Context, flightIDs []string) error {
+	if _, err := r.pool.Exec(ctx, `SELECT recount_flight_seats($1::uuid[])`, flightIDs); err != nil {
+		return fmt.Errorf("recompute seat counts: %w", err)
+	}
+	return nil
+}
 
-	result, err := r.pool.Exec(ctx, query, delta, flightID)
+// FindMiscountedIDs returns the flights whose stored seat counts disagree
+// with their seat rows and confirmed orders
+func (r *FlightRepo) FindMiscountedIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT f.id
+		FROM flights f JOIN flight_seat_counts c ON c.flight_id = f.id
+		WHERE (f.total_seats, f.available_seats) IS DISTINCT FROM (c.total_seats, c.available_seats)
+		ORDER BY f.id
+	`)
 	if err != nil {
-		return fmt.Errorf("update available seats: %w", err)
+		return nil, fmt.Errorf("find miscounted flights: %w", err)
 	}
-
-	if result.RowsAffected() == 0 {
-		return domain.ErrInsufficientSeats
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("find miscounted flights: %w", err)
 	}
+	return ids, nil
+}
 
+// lockFlight serialises capacity changes on a flight for the rest of tx
+func lockFlight(ctx context.Context, tx pgx.Tx, flightID string) error {
+	var id string
+	err := tx.QueryRow(ctx, `SELECT id FROM flights WHERE id = $1 FOR UPDATE`, flightID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrFlightNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("lock flight: %w", err)
+	}
 	return nil
 }
 
-// MarkSeatsReserved marks seats as reserved and assigns them to an order
-func (r *FlightRepo) MarkSeatsReserved(ctx context.Context, flightID string, seatIDs []string, orderID string) error {
+// MarkSeatsReserved marks seats as reserved and assigns them to an order,
+// recording the fencing token their locks were taken under (zero for none)
+// for BookSeats to check. It fails with domain.ErrInsufficientSeats if that
+// would leave seatless orders holding more than the remaining seats and
+// overbooking allowance.
+func (r *FlightRepo) MarkSeatsReserved(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin mark seats reserved: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockFlight(ctx, tx, flightID); err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE seats
-		SET status = 'reserved', order_id = $1, updated_at = NOW()
+		SET status = 'reserved', order_id = $1, lock_token = NULLIF($4::bigint, 0), version = version + 1, updated_at = NOW()
 		WHERE flight_id = $2 AND id = ANY($3) AND status = 'available'
 	`
 
-	result, err := r.pool.Exec(ctx, query, orderID, flightID, seatIDs)
+	result, err := tx.Exec(ctx, query, orderID, flightID, seatIDs, token)
 	if err != nil {
-		return fmt.Errorf("mark seats reserved: %w", err)
+		return fmt.Errorf("mark seats reserved: %w", seatWriteError(err))
 	}
 
 	if result.RowsAffected() != int64(len(seatIDs)) {
-		return fmt.Errorf("expected to reserve %d seats, but reserved %d", len(seatIDs), result.RowsAffected())
+		return fmt.Errorf("expected to reserve %d seats, but reserved %d: %w", len(seatIDs), result.RowsAffected(), domain.ErrSeatUnavailable)
+	}
+
+	var uncommitted int
+	if err := tx.QueryRow(ctx, uncommittedSeatsQuery, flightID, orderID).Scan(&uncommitted); err != nil {
+		return fmt.Errorf("count uncommitted seats: %w", err)
+	}
+	if uncommitted < 0 {
+		return domain.ErrInsufficientSeats
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit mark seats reserved: %w", err)
+	}
+
+	return nil
+}
+
+// HoldCabinSeats holds count seats of a flight's capacity for a seatless
+// order without choosing seats. It fails with domain.ErrInsufficientSeats
+// when the available seats and overbooking allowance left after what other
+// orders already hold are fewer than count.
+func (r *FlightRepo) HoldCabinSeats(ctx context.Context, flightID string, orderID string, count int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin hold cabin seats: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockFlight(ctx, tx, flightID); err != nil {
+		return err
+	}
+
+	var uncommitted int
+	if err := tx.QueryRow(ctx, uncommittedSeatsQuery, flightID, orderID).Scan(&uncommitted); err != nil {
+		return fmt.Errorf("count uncommitted seats: %w", err)
+	}
+	if uncommitted < count {
+		return domain.ErrInsufficientSeats
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE orders SET cabin_seats = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND seatless
+	`, count, orderID)
+	if err != nil {
+		return fmt.Errorf("hold cabin seats: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrOrderNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit hold cabin seats: %w", err)
 	}
 
 	return nil
@@ -176,7 +693,7 @@ func (r *FlightRepo) MarkSeatsReserved(ctx context.Context, flightID string, sea
 func (r *FlightRepo) MarkSeatsAvailable(ctx context.Context, flightID string, seatIDs []string) error {
 	query := `
 		UPDATE seats
-		SET status = 'available', order_id = NULL, updated_at = NOW()
+		SET status = 'available', order_id = NULL, lock_token = NULL, version = version + 1, updated_at = NOW()
 		WHERE flight_id = $1 AND id = ANY($2)
 	`
 
@@ -192,22 +709,283 @@ func (r *FlightRepo) MarkSeatsAvailable(ctx context.Context, flightID string, se
 	return nil
 }
 
-// BookSeats marks seats as booked and assigns them to an order
+// ReleaseStaleSeats returns seats to available if they are still held by
+// orderID (nil for no order), in one statement, and returns the IDs of those
+// released. The guard keeps a repair from undoing a booking that took a seat
+// since it was inspected.
+func (r *FlightRepo) ReleaseStaleSeats(ctx context.Context, flightID string, seatIDs []string, orderID *string) ([]string, error) {
+	query := `
+		UPDATE seats
+		SET status = 'available', order_id = NULL, lock_token = NULL, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $1 AND id = ANY($2) AND order_id IS NOT DISTINCT FROM $3::uuid AND status <> 'blocked'
+		RETURNING id
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID, seatIDs, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("release stale seats: %w", err)
+	}
+	released, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("release stale seats: %w", err)
+	}
+
+	return released, nil
+}
+
+// ReleaseInspectedSeats returns seats to available if they are still at the
+// version they were inspected at, sending every release in one batch, and
+// returns the IDs of those released. Any write since, such as a booking that
+// took a seat, leaves it alone. The batch runs as one implicit transaction,
+// so a release that fails rolls back the others and none are returned.
+func (r *FlightRepo) ReleaseInspectedSeats(ctx context.Context, seats []domain.Seat) ([]string, error) {
+	query := `
+		UPDATE seats
+		SET status = 'available', order_id = NULL, lock_token = NULL, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $1 AND id = $2 AND version = $3 AND status <> 'blocked'
+	`
+
+	batch := &pgx.Batch{}
+	for _, seat := range seats {
+		batch.Queue(query, seat.FlightID, seat.ID, seat.Version)
+	}
+	results := r.pool.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var released []string
+	for _, seat := range seats {
+		result, err := results.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("release inspected seat %s: %w", seat.ID, err)
+		}
+		if result.RowsAffected() == 1 {
+			released = append(released, seat.ID)
+		}
+	}
+
+	return released, results.Close()
+}
+
+// BookSeats marks seats as booked and assigns them to an order. Seats another
+// order holds are left alone and fail the booking with domain.ErrDoubleBooking.
 func (r *FlightRepo) BookSeats(ctx context.Context, flightID string, seatIDs []string, orderID string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin book seats: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := bookSeats(ctx, tx, flightID, seatIDs, orderID, 0); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit book seats: %w", err)
+	}
+
+	return nil
+}
+
+// bookSeats books an order's seats within tx. A non-zero token must be the
+// one the order reserved each seat under: seats reserved under another
+// token, or no longer reserved at all, fail with domain.ErrStaleLock.
+func bookSeats(ctx context.Context, tx pgx.Tx, flightID string, seatIDs []string, orderID string, token int64) error {
 	query := `
 		UPDATE seats
-		SET status = 'booked', order_id = $1, updated_at = NOW()
-		WHERE flight_id = $2 AND id = ANY($3)
+		SET status = 'booked', order_id = $1, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $2 AND id = ANY($3) AND (order_id IS NULL OR order_id = $1)
+		  AND ($4::bigint = 0 OR lock_token = $4)
 	`
 
-	result, err := r.pool.Exec(ctx, query, orderID, flightID, seatIDs)
+	result, err := tx.Exec(ctx, query, orderID, flightID, seatIDs, token)
 	if err != nil {
-		return fmt.Errorf("book seats: %w", err)
+		return fmt.Errorf("book seats: %w", seatWriteError(err))
 	}
 
 	if result.RowsAffected() != int64(len(seatIDs)) {
+		var taken []string
+		err := tx.QueryRow(ctx, `
+			SELECT COALESCE(array_agg(id ORDER BY id), '{}')
+			FROM seats
+			WHERE flight_id = $1 AND id = ANY($2) AND order_id <> $3
+		`, flightID, seatIDs, orderID).Scan(&taken)
+		if err != nil {
+			return fmt.Errorf("find seats held by other orders: %w", err)
+		}
+		if len(taken) > 0 {
+			return fmt.Errorf("book seats %v: %w", taken, domain.ErrDoubleBooking)
+		}
+		if token != 0 {
+			return fmt.Errorf("book seats under lock token %d: %w", token, domain.ErrStaleLock)
+		}
 		return fmt.Errorf("expected to book %d seats, but booked %d", len(seatIDs), result.RowsAffected())
 	}
 
 	return nil
 }
+
+// seatConstraints keep every reserved or booked seat tied to one order of
+// its flight
+var seatConstraints = []string{"seats_held_order_check", "seats_order_flight_fkey"}
+
+// seatCountConstraint keeps a flight's available seats within its
+// overbooking allowance. Seat and order writes recount the flight, so any of
+// them that takes one seat too many fails on it.
+const seatCountConstraint = "flights_seats_check"
+
+// seatWriteError reports a seat write the database refused under
+// seatConstraints as domain.ErrDoubleBooking, and one that would oversell the
+// flight as domain.ErrInsufficientSeats; other errors are returned as is
+func seatWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	if slices.Contains(seatConstraints, pgErr.ConstraintName) {
+		return fmt.Errorf("%w (%s)", domain.ErrDoubleBooking, pgErr.ConstraintName)
+	}
+	if pgErr.ConstraintName == seatCountConstraint {
+		return fmt.Errorf("%w (%s)", domain.ErrInsufficientSeats, pgErr.ConstraintName)
+	}
+	return err
+}
+
+// UnbookSeats returns an order's booked seats to reserved, undoing BookSeats
+// while the order still holds them. Seats already unbooked are skipped.
+func (r *FlightRepo) UnbookSeats(ctx context.Context, flightID string, seatIDs []string, orderID string) error {
+	query := `
+		UPDATE seats
+		SET status = 'reserved', version = version + 1, updated_at = NOW()
+		WHERE flight_id = $1 AND id = ANY($2) AND order_id = $3 AND status = 'booked'
+	`
+
+	if _, err := r.pool.Exec(ctx, query, flightID, seatIDs, orderID); err != nil {
+		return fmt.Errorf("unbook seats: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSeatMap applies an admin seat map change in one transaction; the
+// flight's total and available seat counts follow the seats table.
+// Status guards make a change fail with domain.ErrSeatInUse if a seat was
+// reserved or booked concurrently, and domain.ErrInsufficientSeats if the
+// change leaves fewer available seats than seatless orders hold.
+func (r *FlightRepo) UpdateSeatMap(ctx context.Context, flightID string, change domain.SeatMapChange) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin seat map update: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockFlight(ctx, tx, flightID); err != nil {
+		return err
+	}
+
+	for _, seat := range change.Add {
+		// New seats start available unless the change adds them blocked
+		status := domain.SeatStatusAvailable
+		if seat.Status == domain.SeatStatusBlocked {
+			status = domain.SeatStatusBlocked
+		}
+		class := seat.Class
+		if class == "" {
+			class = domain.CabinEconomy
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO seats (id, flight_id, row_num, col, cabin_class, status)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, seat.ID, flightID, seat.Row, seat.Column, class, status)
+		if err != nil {
+			return fmt.Errorf("add seat %s: %w", seat.ID, err)
+		}
+	}
+
+	guarded := []struct {
+		seatIDs []string
+		query   string
+	}{
+		{change.Remove, `DELETE FROM seats WHERE flight_id = $1 AND id = ANY($2) AND status IN ('available', 'blocked')`},
+		{change.Block, `UPDATE seats SET status = 'blocked', version = version + 1, updated_at = NOW() WHERE flight_id = $1 AND id = ANY($2) AND status = 'available'`},
+		{change.Unblock, `UPDATE seats SET status = 'available', version = version + 1, updated_at = NOW() WHERE flight_id = $1 AND id = ANY($2) AND status = 'blocked'`},
+	}
+	for _, g := range guarded {
+		if len(g.seatIDs) == 0 {
+			continue
+		}
+		result, err := tx.Exec(ctx, g.query, flightID, g.seatIDs)
+		if err != nil {
+			return fmt.Errorf("update seat map: %w", seatWriteError(err))
+		}
+		if result.RowsAffected() != int64(len(g.seatIDs)) {
+			return domain.ErrSeatInUse
+		}
+	}
+
+	var uncommitted int
+	if err := tx.QueryRow(ctx, uncommittedSeatsQuery, flightID, nil).Scan(&uncommitted); err != nil {
+		return fmt.Errorf("count uncommitted seats: %w", err)
+	}
+	if uncommitted < 0 {
+		return domain.ErrInsufficientSeats
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit seat map update: %w", err)
+	}
+
+	return nil
+}
+
+// FareCalendar returns the cheapest bookable fare per UTC departure day on a route
+// in [from, to), using a single grouped query. Days without flights are omitted.
+func (r *FlightRepo) FareCalendar(ctx context.Context, origin, destination string, from, to time.Time) ([]domain.FareDay, error) {
+	query := `
+		SELECT (departure_time AT TIME ZONE 'UTC')::date AS day, COUNT(*), MIN(price_cents)
+		FROM flights
+		WHERE origin = $1 AND destination = $2
+		  AND departure_time >= $3 AND departure_time < $4
+		  AND available_seats > 0
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.pool.Query(ctx, query, origin, destination, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query fare calendar: %w", err)
+	}
+	defer rows.Close()
+
+	var days []domain.FareDay
+	for rows.Next() {
+		var d domain.FareDay
+		if err := rows.Scan(&d.Date, &d.FlightCount, &d.CheapestPriceCents); err != nil {
+			return nil, fmt.Errorf("scan fare day: %w", err)
+		}
+		days = append(days, d)
+	}
+
+	return days, rows.Err()
+}
+
+// Revenue sums the flight's revenue table; orders never need scanning
+func (r *FlightRepo) Revenue(ctx context.Context, flightID string) (*domain.FlightRevenue, error) {
+	query := `
+		SELECT COUNT(*) FILTER (WHERE reversed_at IS NULL),
+		       COALESCE(SUM(amount_cents) FILTER (WHERE reversed_at IS NULL), 0),
+		       COUNT(*) FILTER (WHERE reversed_at IS NOT NULL),
+		       COALESCE(SUM(amount_cents) FILTER (WHERE reversed_at IS NOT NULL), 0)
+		FROM flight_revenue
+		WHERE flight_id = $1
+	`
+
+	revenue := domain.FlightRevenue{FlightID: flightID}
+	err := r.pool.QueryRow(ctx, query, flightID).Scan(
+		&revenue.Orders, &revenue.RecognizedCents, &revenue.ReversedOrders, &revenue.ReversedCents,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query flight revenue: %w", err)
+	}
+
+	return &revenue, nil
+}
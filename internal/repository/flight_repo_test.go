@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestSeatWriteError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error // nil when the error should come back unchanged
+	}{
+		{"seat held without an order", &pgconn.PgError{Code: "23514", ConstraintName: "seats_held_order_check"}, domain.ErrDoubleBooking},
+		{"seat held by another flight's order", fmt.Errorf("exec: %w", &pgconn.PgError{Code: "23503", ConstraintName: "seats_order_flight_fkey"}), domain.ErrDoubleBooking},
+		{"flight oversold", &pgconn.PgError{Code: "23514", ConstraintName: "flights_seats_check"}, domain.ErrInsufficientSeats},
+		{"other constraint", &pgconn.PgError{Code: "23514", ConstraintName: "seats_status_check"}, nil},
+		{"not a database error", errors.New("connection reset"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := seatWriteError(tt.err)
+			if tt.want == nil && err != tt.err {
+				t.Errorf("seatWriteError(%v) = %v, want the error unchanged", tt.err, err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("seatWriteError(%v) = %v, want %v", tt.err, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecomputeSeatCounts_Flights(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	flights := NewFlightRepo(pool)
+
+	first := createTestFlight(t, pool)
+	second := createTestFlight(t, pool)
+	createTestOrder(t, pool, second, domain.OrderStatusConfirmed, "1A")
+	execTest(t, pool, `UPDATE seats SET status = 'booked' WHERE flight_id = $1 AND id = '1A'`, second)
+
+	// Counts changed behind the triggers' back, as a manual edit would
+	execTest(t, pool, `UPDATE flights SET available_seats = 1 WHERE id = ANY($1)`, []string{first, second})
+
+	// A flight that does not exist is skipped rather than failing the batch
+	if err := flights.RecomputeSeatCounts(ctx, []string{first, second, uuid.New().String()}); err != nil {
+		t.Fatalf("RecomputeSeatCounts: %v", err)
+	}
+
+	for flightID, want := range map[string]int{first: 6, second: 5} {
+		flight, err := flights.FindByID(ctx, flightID)
+		if err != nil {
+			t.Fatalf("find flight: %v", err)
+		}
+		if flight.TotalSeats != 6 || flight.AvailableSeats != want {
+			t.Errorf("flight %s counts %d of %d available, want %d of 6", flightID, flight.AvailableSeats, flight.TotalSeats, want)
+		}
+	}
+}
+
+func TestReleaseStaleSeats_OnlySeatsStillHeld(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	flightID := createTestFlight(t, pool)
+	orderID := createTestOrder(t, pool, flightID, domain.OrderStatusExpired, "1A", "1B")
+	createTestOrder(t, pool, flightID, domain.OrderStatusPaymentPending, "1C")
+
+	// 1C went to another order since the expired one was inspected, and 1D
+	// is already free
+	released, err := NewFlightRepo(pool).ReleaseStaleSeats(ctx, flightID, []string{"1A", "1B", "1C", "1D"}, &orderID)
+	if err != nil {
+		t.Fatalf("ReleaseStaleSeats: %v", err)
+	}
+	slices.Sort(released)
+	if !slices.Equal(released, []string{"1A", "1B"}) {
+		t.Errorf("released %v, want [1A 1B]", released)
+	}
+
+	statuses := seatStatuses(t, pool, flightID)
+	if statuses["1A"] != domain.SeatStatusAvailable || statuses["1B"] != domain.SeatStatusAvailable || statuses["1C"] != domain.SeatStatusReserved {
+		t.Errorf("seat statuses %v, want 1A and 1B freed and 1C kept", statuses)
+	}
+}
+
+func TestReleaseInspectedSeats_Batch(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	flights := NewFlightRepo(pool)
+
+	// inspect reserves seats on two flights and returns them as a repair
+	// would have read them
+	inspect := func(t *testing.T) (string, string, []domain.Seat) {
+		t.Helper()
+		first := createTestFlight(t, pool)
+		second := createTestFlight(t, pool)
+		createTestOrder(t, pool, first, domain.OrderStatusExpired, "1A", "1B")
+		createTestOrder(t, pool, second, domain.OrderStatusExpired, "1A")
+
+		var inspected []domain.Seat
+		for _, flightID := range []string{first, second} {
+			seats, err := flights.FindSeats(ctx, flightID)
+			if err != nil {
+				t.Fatalf("find seats: %v", err)
+			}
+			for _, seat := range seats {
+				if seat.Status == domain.SeatStatusReserved {
+					inspected = append(inspected, seat)
+				}
+			}
+		}
+		return first, second, inspected
+	}
+
+	t.Run("seats written since are kept", func(t *testing.T) {
+		first, second, inspected := inspect(t)
+		execTest(t, pool, `UPDATE seats SET version = version + 1 WHERE flight_id = $1 AND id = '1B'`, first)
+
+		released, err := flights.ReleaseInspectedSeats(ctx, inspected)
+		if err != nil {
+			t.Fatalf("ReleaseInspectedSeats: %v", err)
+		}
+		if len(released) != 2 || slices.Contains(released, "1B") {
+			t.Errorf("released %v, want both flights' 1A and not 1B", released)
+		}
+		if got := seatStatuses(t, pool, first); got["1A"] != domain.SeatStatusAvailable || got["1B"] != domain.SeatStatusReserved {
+			t.Errorf("first flight seats %v, want 1A freed and 1B kept", got)
+		}
+		if got := seatStatuses(t, pool, second); got["1A"] != domain.SeatStatusAvailable {
+			t.Errorf("second flight seats %v, want 1A freed", got)
+		}
+	})
+
+	// The server rejects the NUL byte in the middle of the batch, which rolls
+	// back the release queued before it and skips the one after
+	t.Run("a failed release releases nothing", func(t *testing.T) {
+		first, second, inspected := inspect(t)
+		inspected[1].ID += "\x00"
+
+		released, err := flights.ReleaseInspectedSeats(ctx, inspected)
+		if err == nil {
+			t.Fatal("ReleaseInspectedSeats succeeded, want the bad seat ID to fail it")
+		}
+		if len(released) != 0 {
+			t.Errorf("released %v with the batch failed, want none", released)
+		}
+		for _, flightID := range []string{first, second} {
+			if got := seatStatuses(t, pool, flightID); got["1A"] != domain.SeatStatusReserved {
+				t.Errorf("flight %s seats %v, want 1A still reserved", flightID, got)
+			}
+		}
+	})
+}
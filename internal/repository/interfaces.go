@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// The interfaces below are what services and activities hold instead of the
+// concrete repositories, so unit tests can swap in the mocks in
+// internal/mocks. Regenerate those after changing a method set.
+
+//go:generate mockery --name FlightRepository --output ../mocks --outpkg mocks --disable-version-string
+//go:generate mockery --name OrderRepository --output ../mocks --outpkg mocks --disable-version-string
+//go:generate mockery --name SeatLocker --output ../mocks --outpkg mocks --disable-version-string
+
+// FlightRepository is the flight, seat and fare data access of FlightRepo
+type FlightRepository interface {
+	GetAllFlightIDs(ctx context.Context) ([]string, error)
+	FindAll(ctx context.Context) ([]domain.Flight, error)
+	Create(ctx context.Context, flight *domain.Flight, seats []domain.Seat) (bool, error)
+	FindTakenNumbers(ctx context.Context, numbers []string) ([]string, error)
+	FindByID(ctx context.Context, id string) (*domain.Flight, error)
+	FindUpcomingIDs(ctx context.Context, from, to time.Time) ([]string, error)
+	ApplyDisruption(ctx context.Context, d domain.FlightDisruption) (bool, error)
+	SetBookingFrozen(ctx context.Context, flightID string, frozen bool) error
+	SetOverbookingPercent(ctx context.Context, flightID string, percent int) error
+	Oversold(ctx context.Context, flightID string) (int, error)
+	FindBumps(ctx context.Context, flightID string) ([]domain.Bump, error)
+	FindOnSaleIDs(ctx context.Context) ([]string, error)
+	Reprice(ctx context.Context, flightID string, curve domain.PricingCurve, reason domain.PriceChangeReason, orderID *string) (*domain.PriceChange, error)
+	FindPriceHistory(ctx context.Context, flightID string) ([]domain.PriceChange, error)
+	FindUnfinalizedIDs(ctx context.Context, before time.Time) ([]string, error)
+	CloseSales(ctx context.Context, flightID string) error
+	MarkDeparted(ctx context.Context, flightID string) (bool, error)
+	SaveManifest(ctx context.Context, manifest domain.Manifest) error
+	FindManifest(ctx context.Context, flightID string) (*domain.Manifest, error)
+	FindSeats(ctx context.Context, flightID string) ([]domain.Seat, error)
+	RecomputeSeatCounts(ctx context.Context, flightIDs []string) error
+	FindMiscountedIDs(ctx context.Context) ([]string, error)
+	MarkSeatsReserved(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error
+	HoldCabinSeats(ctx context.Context, flightID string, orderID string, count int) error
+	MarkSeatsAvailable(ctx context.Context, flightID string, seatIDs []string) error
+	ReleaseStaleSeats(ctx context.Context, flightID string, seatIDs []string, orderID *string) ([]string, error)
+	ReleaseInspectedSeats(ctx context.Context, seats []domain.Seat) ([]string, error)
+	BookSeats(ctx context.Context, flightID string, seatIDs []string, orderID string) error
+	UnbookSeats(ctx context.Context, flightID string, seatIDs []string, orderID string) error
+	UpdateSeatMap(ctx context.Context, flightID string, change domain.SeatMapChange) error
+	FareCalendar(ctx context.Context, origin, destination string, from, to time.Time) ([]domain.FareDay, error)
+	Revenue(ctx context.Context, flightID string) (*domain.FlightRevenue, error)
+}
+
+// OrderRepository is the order data access of OrderRepo
+type OrderRepository interface {
+	Create(ctx context.Context, order *domain.Order) error
+	FindByID(ctx context.Context, id string) (*domain.Order, error)
+	FindByWorkflowID(ctx context.Context, workflowID string) (*domain.Order, error)
+	FindByBookingReference(ctx context.Context, reference string) (*domain.Order, error)
+	FindOpenByFlight(ctx context.Context, flightID string) ([]*domain.Order, error)
+	List(ctx context.Context, filter domain.OrderFilter, page domain.Page) (domain.OrderPage, error)
+	FindByFlight(ctx context.Context, flightID string) ([]*domain.Order, error)
+	FindManifestEntries(ctx context.Context, flightID string) ([]domain.ManifestEntry, int, error)
+	FindByTripID(ctx context.Context, tripID string) ([]*domain.Order, error)
+	UpdateStatus(ctx context.Context, id string, status domain.OrderStatus, version int) error
+	UpdateSeats(ctx context.Context, id string, seats []string, expiresAt *time.Time, price domain.PriceBreakdown) error
+	ApplyUpsell(ctx context.Context, id string, seats []string, expiresAt time.Time, price domain.PriceBreakdown, priorityBoarding bool) error
+	ExtendHold(ctx context.Context, id string, expiresAt time.Time) error
+	SavePassengers(ctx context.Context, orderID string, passengers []domain.Passenger) error
+	FindPassengers(ctx context.Context, orderID string) ([]domain.Passenger, error)
+	FindItinerary(ctx context.Context, orderID string) (*domain.Itinerary, error)
+	SaveBoardingPass(ctx context.Context, orderID string, image []byte) error
+	FindBoardingPass(ctx context.Context, orderID string) ([]byte, error)
+	Confirm(ctx context.Context, id string, bookingReference string) error
+	ConfirmOrderTx(ctx context.Context, confirmation domain.OrderConfirmation) error
+	Unconfirm(ctx context.Context, id string) error
+	CountSeats(ctx context.Context, id string) error
+	UncountSeats(ctx context.Context, id string) error
+	ReverseRevenue(ctx context.Context, id string) error
+	ReleaseCabinSeats(ctx context.Context, id string) error
+	CheckIn(ctx context.Context, order *domain.Order, seats []string, passengers []domain.Passenger) error
+	ReassignSeats(ctx context.Context, order *domain.Order, seats []string, passengers []domain.Passenger) error
+	MarkCheckedIn(ctx context.Context, id string) error
+	Fail(ctx context.Context, id string, reason string, version int) error
+	Refund(ctx context.Context, id string, reason string, version int) error
+	RecordDeposit(ctx context.Context, id string, deposit domain.Deposit) error
+	RecordPaymentMethod(ctx context.Context, id string, method domain.PaymentMethod, code string) error
+	MarkBalancePaid(ctx context.Context, id string) error
+	FailUnpaidBalance(ctx context.Context, id, reason string) (bool, error)
+	Cancel(ctx context.Context, id string, reason string, version int) error
+	Expire(ctx context.Context, id string, version int) error
+	FindStale(ctx context.Context, expiredBefore time.Time, limit int) ([]*domain.Order, error)
+	ExpireStale(ctx context.Context, id string) (bool, error)
+	FindBumpCandidates(ctx context.Context, flightID string) ([]domain.BumpCandidate, error)
+	Bump(ctx context.Context, id string, policy domain.BumpPolicy) (*domain.Bump, error)
+	Archive(ctx context.Context, finishedBefore time.Time, limit int) (domain.OrderArchival, error)
+}
+
+// SeatLocker holds seats for orders with expiring, fenced locks, as
+// SeatLockRepo does in Redis
+type SeatLocker interface {
+	LockSeats(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64, ttl time.Duration) (int64, error)
+	ReleaseLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error
+	ExtendLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64, ttl time.Duration) error
+	VerifyLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error
+	ContendedLockTTL(ctx context.Context, flightID string, seatIDs []string, orderID string) (time.Duration, error)
+	GetLockedSeats(ctx context.Context, flightID string) (map[string]string, error)
+	GetSeatLocks(ctx context.Context, flightID string) (map[string]domain.SeatLock, error)
+}
+
+var (
+	_ FlightRepository = (*FlightRepo)(nil)
+	_ OrderRepository  = (*OrderRepo)(nil)
+	_ SeatLocker       = (*SeatLockRepo)(nil)
+)
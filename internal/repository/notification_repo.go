@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// NotificationRepo handles notification preference data access
+type NotificationRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewNotificationRepo creates a new NotificationRepo
+func NewNotificationRepo(pool *pgxpool.Pool) *NotificationRepo {
+	return &NotificationRepo{pool: pool}
+}
+
+// preferenceColumns is the column list scanned by scanPreferences
+const preferenceColumns = `order_id, channels, categories, unsubscribe_token, unsubscribed_at, updated_at`
+
+// scanPreferences scans a row selected with preferenceColumns
+func scanPreferences(row pgx.Row) (*domain.NotificationPreferences, error) {
+	var p domain.NotificationPreferences
+	err := row.Scan(&p.OrderID, &p.Channels, &p.Categories, &p.UnsubscribeToken, &p.UnsubscribedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Find returns the stored preferences for an order, or nil if none were stored
+func (r *NotificationRepo) Find(ctx context.Context, orderID string) (*domain.NotificationPreferences, error) {
+	query := `SELECT ` + preferenceColumns + ` FROM notification_preferences WHERE order_id = $1`
+
+	prefs, err := scanPreferences(r.pool.QueryRow(ctx, query, orderID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// FindByToken returns the preferences owning an unsubscribe token
+func (r *NotificationRepo) FindByToken(ctx context.Context, token string) (*domain.NotificationPreferences, error) {
+	query := `SELECT ` + preferenceColumns + ` FROM notification_preferences WHERE unsubscribe_token = $1`
+
+	prefs, err := scanPreferences(r.pool.QueryRow(ctx, query, token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrUnsubscribeTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// Upsert stores preferences; an existing row keeps its unsubscribe token
+func (r *NotificationRepo) Upsert(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (order_id, channels, categories, unsubscribe_token, unsubscribed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (order_id) DO UPDATE
+		SET channels = EXCLUDED.channels, categories = EXCLUDED.categories,
+		    unsubscribed_at = EXCLUDED.unsubscribed_at, updated_at = NOW()
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		prefs.OrderID, prefs.Channels, prefs.Categories, prefs.UnsubscribeToken, prefs.UnsubscribedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert notification preferences: %w", err)
+	}
+
+	return nil
+}
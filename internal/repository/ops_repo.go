@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// OpsRepo handles the operational records behind the ops digest
+type OpsRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewOpsRepo creates a new OpsRepo
+func NewOpsRepo(pool *pgxpool.Pool) *OpsRepo {
+	return &OpsRepo{pool: pool}
+}
+
+// RecordLockRepair records an orphaned seat lock released by reconciliation
+func (r *OpsRepo) RecordLockRepair(ctx context.Context, flightID, seatID, orderID string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO seat_lock_repairs (flight_id, seat_id, order_id) VALUES ($1, $2, $3)
+	`, flightID, seatID, orderID)
+	if err != nil {
+		return fmt.Errorf("record lock repair: %w", err)
+	}
+
+	return nil
+}
+
+// Digest counts what went wrong between since and until. Payment failures
+// are also counted for the equally long period before, for spike detection.
+func (r *OpsRepo) Digest(ctx context.Context, since, until time.Time) (domain.OpsDigest, error) {
+	digest := domain.OpsDigest{Since: since, Until: until}
+	previous := since.Add(-until.Sub(since))
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM orders
+				WHERE status = 'EXPIRED' AND updated_at >= $1 AND updated_at < $2),
+			(SELECT COUNT(*) FROM orders
+				WHERE status = 'FAILED' AND failure_reason LIKE 'payment%' AND updated_at >= $1 AND updated_at < $2),
+			(SELECT COUNT(*) FROM orders
+				WHERE status = 'FAILED' AND failure_reason LIKE 'payment%' AND updated_at >= $3 AND updated_at < $1),
+			(SELECT COUNT(*) FROM seat_lock_repairs
+				WHERE repaired_at >= $1 AND repaired_at < $2),
+			(SELECT COUNT(*) FROM webhook_deliveries
+				WHERE status = 'FAILED' AND failed_at >= $1 AND failed_at < $2)
+	`, since, until, previous).Scan(
+		&digest.ExpiredOrders, &digest.PaymentFailures, &digest.PreviousPaymentFailures,
+		&digest.ReconciliationFixes, &digest.DeadLetteredWebhooks,
+	)
+	if err != nil {
+		return digest, fmt.Errorf("query ops digest: %w", err)
+	}
+
+	return digest, nil
+}
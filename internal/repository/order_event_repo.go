@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// OrderEventRepo handles order history data access
+type OrderEventRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewOrderEventRepo creates a new OrderEventRepo
+func NewOrderEventRepo(pool *pgxpool.Pool) *OrderEventRepo {
+	return &OrderEventRepo{pool: pool}
+}
+
+// Append records an event of an order under key. An event already recorded
+// under the same key, by an earlier try of the same step, is left as it is.
+func (r *OrderEventRepo) Append(ctx context.Context, orderID, key string, eventType domain.OrderEventType, data map[string]interface{}) error {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encode order event: %w", err)
+	}
+
+	_, err = r.pool.Exec(ctx, `
+		INSERT INTO order_events (order_id, type, data, event_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (order_id, event_key) DO NOTHING
+	`, orderID, eventType, encoded, key)
+	if err != nil {
+		return fmt.Errorf("append order event: %w", err)
+	}
+	return nil
+}
+
+// ListByOrder returns the events of an order, oldest first
+func (r *OrderEventRepo) ListByOrder(ctx context.Context, orderID string) ([]domain.OrderEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, order_id, type, data, occurred_at
+		FROM order_events
+		WHERE order_id = $1
+		ORDER BY occurred_at, id
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("query order events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.OrderEvent{}
+	for rows.Next() {
+		var e domain.OrderEvent
+		if err := rows.Scan(&e.ID, &e.OrderID, &e.Type, &e.Data, &e.OccurredAt); err != nil {
+			return nil, fmt.Errorf("scan order event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -14,7 +16,8 @@ import (
 
 // OrderRepo handles order data access
 type OrderRepo struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *pgxpool.Pool // serves listings when set
 }
 
 // NewOrderRepo creates a new OrderRepo
@@ -22,16 +25,33 @@ func NewOrderRepo(pool *pgxpool.Pool) *OrderRepo {
 	return &OrderRepo{pool: pool}
 }
 
+// WithReplica has the repo's listings read from replica, which may lag the
+// primary. A nil replica keeps them on the primary.
+func (r *OrderRepo) WithReplica(replica *pgxpool.Pool) *OrderRepo {
+	r.replica = replica
+	return r
+}
+
+// reader is the pool listings read from: the replica when there is one
+func (r *OrderRepo) reader() *pgxpool.Pool {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.pool
+}
+
 // Create creates a new order
 func (r *OrderRepo) Create(ctx context.Context, order *domain.Order) error {
 	query := `
-		INSERT INTO orders (id, flight_id, workflow_id, status, seats, total_price_cents, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO orders (id, flight_id, workflow_id, status, seats, total_price_cents,
+		                    quote_id, price_breakdown, expires_at, seatless, trip_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	_, err := r.pool.Exec(ctx, query,
 		order.ID, order.FlightID, order.WorkflowID, order.Status,
-		order.Seats, order.TotalPriceCents, order.ExpiresAt,
+		order.Seats, order.TotalPriceCents, order.Price.QuoteID, order.Price, order.ExpiresAt,
+		order.Seatless, order.TripID,
 	)
 	if err != nil {
 		return fmt.Errorf("insert order: %w", err)
@@ -40,20 +60,25 @@ func (r *OrderRepo) Create(ctx context.Context, order *domain.Order) error {
 	return nil
 }
 
-// FindByID returns an order by ID
-func (r *OrderRepo) FindByID(ctx context.Context, id string) (*domain.Order, error) {
-	query := `
-		SELECT id, flight_id, workflow_id, status, seats, total_price_cents,
-		       payment_code, expires_at, confirmed_at, failure_reason, created_at, updated_at
-		FROM orders
-		WHERE id = $1
-	`
+// orderColumns is the column list scanned by scanOrder
+const orderColumns = `
+	id, flight_id, workflow_id, status, seats, total_price_cents, price_breakdown,
+	payment_code, expires_at, confirmed_at, failure_reason, booking_reference,
+	seatless, cabin_seats, trip_id, checked_in_at, priority_boarding, created_at, updated_at,
+	deposit_cents, balance_cents, balance_due_at, balance_paid_at, payment_method, version
+`
 
+// scanOrder scans a row selected with orderColumns
+func scanOrder(row pgx.Row) (*domain.Order, error) {
 	var o domain.Order
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	var deposit depositColumns
+	err := row.Scan(
 		&o.ID, &o.FlightID, &o.WorkflowID, &o.Status, &o.Seats,
-		&o.TotalPriceCents, &o.PaymentCode, &o.ExpiresAt,
-		&o.ConfirmedAt, &o.FailureReason, &o.CreatedAt, &o.UpdatedAt,
+		&o.TotalPriceCents, &o.Price, &o.PaymentCode, &o.ExpiresAt,
+		&o.ConfirmedAt, &o.FailureReason, &o.BookingReference, &o.Seatless, &o.CabinSeats,
+		&o.TripID, &o.CheckedInAt, &o.PriorityBoarding, &o.CreatedAt, &o.UpdatedAt,
+		&deposit.depositCents, &deposit.balanceCents, &deposit.balanceDueAt, &deposit.balancePaidAt,
+		&o.PaymentMethod, &o.Version,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -63,64 +88,324 @@ func (r *OrderRepo) FindByID(ctx context.Context, id string) (*domain.Order, err
 		return nil, fmt.Errorf("query order: %w", err)
 	}
 
+	o.Deposit = deposit.toDomain()
 	return &o, nil
 }
 
+// depositColumns scans the nullable deposit columns of an order
+type depositColumns struct {
+	depositCents  *int64
+	balanceCents  *int64
+	balanceDueAt  *time.Time
+	balancePaidAt *time.Time
+}
+
+// toDomain returns the order's deposit, or nil for an order paid in full
+func (c depositColumns) toDomain() *domain.Deposit {
+	if c.depositCents == nil || c.balanceCents == nil || c.balanceDueAt == nil {
+		return nil
+	}
+	return &domain.Deposit{
+		DepositCents:  *c.depositCents,
+		BalanceCents:  *c.balanceCents,
+		BalanceDueAt:  *c.balanceDueAt,
+		BalancePaidAt: c.balancePaidAt,
+	}
+}
+
+// FindByID returns an order by ID
+func (r *OrderRepo) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	return scanOrder(r.pool.QueryRow(ctx, query, id))
+}
+
 // FindByWorkflowID returns an order by workflow ID
 func (r *OrderRepo) FindByWorkflowID(ctx context.Context, workflowID string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE workflow_id = $1`
+
+	return scanOrder(r.pool.QueryRow(ctx, query, workflowID))
+}
+
+// FindByBookingReference returns the confirmed order issued a booking reference
+func (r *OrderRepo) FindByBookingReference(ctx context.Context, reference string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE booking_reference = $1`
+
+	return scanOrder(r.pool.QueryRow(ctx, query, reference))
+}
+
+// FindOpenByFlight returns the orders on a flight that are not failed,
+// expired or refunded: those still booking and those confirmed
+func (r *OrderRepo) FindOpenByFlight(ctx context.Context, flightID string) ([]*domain.Order, error) {
 	query := `
-		SELECT id, flight_id, workflow_id, status, seats, total_price_cents,
-		       payment_code, expires_at, confirmed_at, failure_reason, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
-		WHERE workflow_id = $1
+		WHERE flight_id = $1 AND status NOT IN ('FAILED', 'EXPIRED', 'PAYMENT_REFUNDED', 'CANCELLED')
+		ORDER BY created_at
 	`
 
-	var o domain.Order
-	err := r.pool.QueryRow(ctx, query, workflowID).Scan(
-		&o.ID, &o.FlightID, &o.WorkflowID, &o.Status, &o.Seats,
-		&o.TotalPriceCents, &o.PaymentCode, &o.ExpiresAt,
-		&o.ConfirmedAt, &o.FailureReason, &o.CreatedAt, &o.UpdatedAt,
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query flight orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+// List returns one page of the orders matching filter, sorted as the page
+// asks, with how many match in all
+func (r *OrderRepo) List(ctx context.Context, filter domain.OrderFilter, page domain.Page) (domain.OrderPage, error) {
+	page = page.Normalized()
+	result := domain.OrderPage{Orders: []*domain.Order{}, Page: page}
+	where, args := orderListWhere(filter)
+
+	if err := r.reader().QueryRow(ctx, `SELECT COUNT(*) FROM orders`+where, args...).Scan(&result.Total); err != nil {
+		return result, fmt.Errorf("count orders: %w", err)
+	}
+	if result.Total <= page.Offset {
+		return result, nil
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders` + where + ` ORDER BY ` + orderListOrder(page) +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	rows, err := r.reader().Query(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return result, fmt.Errorf("query orders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return result, err
+		}
+		result.Orders = append(result.Orders, order)
+	}
+
+	return result, rows.Err()
+}
+
+// orderListWhere builds the WHERE clause of an order listing, empty when the
+// filter matches every order, and its arguments
+func orderListWhere(filter domain.OrderFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		add("status = ANY($%d)", statuses)
+	}
+	if filter.FlightID != "" {
+		add("flight_id = $%d", filter.FlightID)
+	}
+	if filter.CreatedAfter != nil {
+		add("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		add("created_at < $%d", *filter.CreatedBefore)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// orderListOrder is the ORDER BY of a listing page; the ID breaks ties so
+// pages never overlap
+func orderListOrder(page domain.Page) string {
+	column := "created_at"
+	if page.Sort == domain.OrderSortPrice {
+		column = "total_price_cents"
+	}
+	direction := " ASC"
+	if page.Descending {
+		direction = " DESC"
+	}
+	return column + direction + ", id" + direction
+}
+
+// FindByFlight returns every order placed on a flight, whatever its status
+func (r *OrderRepo) FindByFlight(ctx context.Context, flightID string) ([]*domain.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE flight_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query flight orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+// FindManifestEntries returns a line per passenger of the flight's confirmed
+// orders, in confirmation order. Orders confirmed without passenger details
+// get a nameless line per seat, and seatless ones count toward seatless.
+func (r *OrderRepo) FindManifestEntries(ctx context.Context, flightID string) ([]domain.ManifestEntry, int, error) {
+	query := `
+		SELECT o.id, COALESCE(o.booking_reference, ''), o.seats, o.cabin_seats, o.checked_in_at,
+		       p.seat_id, p.name, p.document_number
+		FROM orders o
+		LEFT JOIN passengers p ON p.order_id = o.id
+		WHERE o.flight_id = $1 AND o.status = 'CONFIRMED'
+		ORDER BY o.confirmed_at, o.id, p.position
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query manifest: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		entries  []domain.ManifestEntry
+		seatless int
 	)
+	for rows.Next() {
+		var (
+			entry        domain.ManifestEntry
+			seats        []string
+			cabinSeats   int
+			seatID, name *string
+			document     *string
+		)
+		err := rows.Scan(&entry.OrderID, &entry.BookingReference, &seats, &cabinSeats, &entry.CheckedInAt,
+			&seatID, &name, &document)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan manifest entry: %w", err)
+		}
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, domain.ErrOrderNotFound
+		if name == nil {
+			// No passenger details: list the seats, or count seatless capacity
+			for _, seat := range seats {
+				e := entry
+				e.SeatID = seat
+				entries = append(entries, e)
+			}
+			if len(seats) == 0 {
+				seatless += cabinSeats
+			}
+			continue
+		}
+
+		entry.Name, entry.DocumentNumber = *name, *document
+		if seatID != nil {
+			entry.SeatID = *seatID
+		}
+		entries = append(entries, entry)
 	}
+
+	return entries, seatless, rows.Err()
+}
+
+// FindByTripID returns the legs of a trip in travel order
+func (r *OrderRepo) FindByTripID(ctx context.Context, tripID string) ([]*domain.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		WHERE trip_id = $1
+		ORDER BY (SELECT departure_time FROM flights f WHERE f.id = o.flight_id)
+	`
+
+	rows, err := r.pool.Query(ctx, query, tripID)
 	if err != nil {
-		return nil, fmt.Errorf("query order: %w", err)
+		return nil, fmt.Errorf("query trip orders: %w", err)
 	}
+	defer rows.Close()
 
-	return &o, nil
+	var orders []*domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
 }
 
-// UpdateStatus updates the order status
-func (r *OrderRepo) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) error {
+// UpdateStatus updates the order status if the order is still at version,
+// and fails with domain.ErrVersionConflict if it was written since
+func (r *OrderRepo) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus, version int) error {
 	query := `
 		UPDATE orders
-		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		SET status = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND version = $3
 	`
 
-	result, err := r.pool.Exec(ctx, query, status, id)
+	result, err := r.pool.Exec(ctx, query, status, id, version)
 	if err != nil {
 		return fmt.Errorf("update order status: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return domain.ErrOrderNotFound
+		return r.versionMiss(ctx, id)
 	}
 
 	return nil
 }
 
-// UpdateSeats updates the order seats and expiration
-func (r *OrderRepo) UpdateSeats(ctx context.Context, id string, seats []string, expiresAt *time.Time) error {
+// versionMiss tells why a compare-and-swap write matched no order:
+// domain.ErrOrderNotFound if it is gone, domain.ErrVersionConflict otherwise
+func (r *OrderRepo) versionMiss(ctx context.Context, id string) error {
+	return orderMiss(ctx, r.pool, id)
+}
+
+// orderMiss is versionMiss run on q, so a write within a transaction can
+// check in it
+func orderMiss(ctx context.Context, q interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}, id string) error {
+	var exists bool
+	if err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("check order: %w", err)
+	}
+	if !exists {
+		return domain.ErrOrderNotFound
+	}
+	return domain.ErrVersionConflict
+}
+
+// UpdateSeats updates the order seats, expiration, and the quote rescaled to the new seat count
+func (r *OrderRepo) UpdateSeats(ctx context.Context, id string, seats []string, expiresAt *time.Time, price domain.PriceBreakdown) error {
 	query := `
 		UPDATE orders
-		SET seats = $1, expires_at = $2, updated_at = NOW()
-		WHERE id = $3
+		SET seats = $1, expires_at = $2, price_breakdown = $3, total_price_cents = $4, version = version + 1, updated_at = NOW()
+		WHERE id = $5
 	`
 
-	result, err := r.pool.Exec(ctx, query, seats, expiresAt, id)
+	result, err := r.pool.Exec(ctx, query, seats, expiresAt, price, price.TotalCents, id)
 	if err != nil {
 		return fmt.Errorf("update order seats: %w", err)
 	}
@@ -132,17 +417,20 @@ func (r *OrderRepo) UpdateSeats(ctx context.Context, id string, seats []string,
 	return nil
 }
 
-// Confirm marks the order as confirmed
-func (r *OrderRepo) Confirm(ctx context.Context, id string) error {
+// ApplyUpsell records the extras an order accepted at payment: its seats,
+// upgraded or not, the quote that now charges for them and whether it
+// boards first
+func (r *OrderRepo) ApplyUpsell(ctx context.Context, id string, seats []string, expiresAt time.Time, price domain.PriceBreakdown, priorityBoarding bool) error {
 	query := `
 		UPDATE orders
-		SET status = 'CONFIRMED', confirmed_at = NOW(), updated_at = NOW()
-		WHERE id = $1
+		SET seats = $1, expires_at = $2, price_breakdown = $3, total_price_cents = $4,
+		    priority_boarding = priority_boarding OR $5, version = version + 1, updated_at = NOW()
+		WHERE id = $6
 	`
 
-	result, err := r.pool.Exec(ctx, query, id)
+	result, err := r.pool.Exec(ctx, query, seats, expiresAt, price, price.TotalCents, priorityBoarding, id)
 	if err != nil {
-		return fmt.Errorf("confirm order: %w", err)
+		return fmt.Errorf("apply upsell: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -152,19 +440,561 @@ func (r *OrderRepo) Confirm(ctx context.Context, id string) error {
 	return nil
 }
 
-// Fail marks the order as failed
-func (r *OrderRepo) Fail(ctx context.Context, id string, reason string) error {
+// ExtendHold moves the expiration of an order that is still holding seats,
+// in any of the statuses domain.HoldsSeats accepts
+func (r *OrderRepo) ExtendHold(ctx context.Context, id string, expiresAt time.Time) error {
 	query := `
 		UPDATE orders
-		SET status = 'FAILED', failure_reason = $1, updated_at = NOW()
-		WHERE id = $2
+		SET expires_at = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND status IN ('SEATS_RESERVED', 'PAYMENT_PENDING')
 	`
 
-	result, err := r.pool.Exec(ctx, query, reason, id)
+	result, err := r.pool.Exec(ctx, query, expiresAt, id)
+	if err != nil {
+		return fmt.Errorf("extend order hold: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrHoldNotExtendable
+	}
+
+	return nil
+}
+
+// SavePassengers replaces an order's passengers; list order is preserved
+func (r *OrderRepo) SavePassengers(ctx context.Context, orderID string, passengers []domain.Passenger) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin save passengers: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := savePassengers(ctx, tx, orderID, passengers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit save passengers: %w", err)
+	}
+
+	return nil
+}
+
+// savePassengers replaces an order's passengers within tx
+func savePassengers(ctx context.Context, tx pgx.Tx, orderID string, passengers []domain.Passenger) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM passengers WHERE order_id = $1`, orderID); err != nil {
+		return fmt.Errorf("clear passengers: %w", err)
+	}
+
+	for i, p := range passengers {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO passengers (order_id, seat_id, position, name, document_number, email)
+			VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+		`, orderID, p.SeatID, i, p.Name, p.DocumentNumber, p.Email)
+		if err != nil {
+			return fmt.Errorf("insert passenger: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FindPassengers returns an order's passengers in booking order
+func (r *OrderRepo) FindPassengers(ctx context.Context, orderID string) ([]domain.Passenger, error) {
+	query := `
+		SELECT COALESCE(seat_id, ''), name, document_number, email
+		FROM passengers
+		WHERE order_id = $1
+		ORDER BY position
+	`
+
+	rows, err := r.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("query passengers: %w", err)
+	}
+	defer rows.Close()
+
+	var passengers []domain.Passenger
+	for rows.Next() {
+		var p domain.Passenger
+		if err := rows.Scan(&p.SeatID, &p.Name, &p.DocumentNumber, &p.Email); err != nil {
+			return nil, fmt.Errorf("scan passenger: %w", err)
+		}
+		passengers = append(passengers, p)
+	}
+
+	return passengers, rows.Err()
+}
+
+// FindItinerary returns the receipt for a confirmed order, joined with its flight and passengers
+func (r *OrderRepo) FindItinerary(ctx context.Context, orderID string) (*domain.Itinerary, error) {
+	query := `
+		SELECT o.id, o.status, o.booking_reference, o.seats, o.price_breakdown, o.confirmed_at, o.checked_in_at,
+		       o.deposit_cents, o.balance_cents, o.balance_due_at, o.balance_paid_at,
+		       f.id, f.flight_number, f.origin, f.destination, f.departure_time, f.arrival_time,
+		       f.total_seats, f.available_seats, f.price_cents, f.status, f.delay_minutes
+		FROM orders o
+		JOIN flights f ON f.id = o.flight_id
+		WHERE o.id = $1
+	`
+
+	var (
+		it          domain.Itinerary
+		status      domain.OrderStatus
+		reference   *string
+		confirmedAt *time.Time
+		deposit     depositColumns
+		f           = &it.Flight
+	)
+	err := r.pool.QueryRow(ctx, query, orderID).Scan(
+		&it.OrderID, &status, &reference, &it.Seats, &it.Price, &confirmedAt, &it.CheckedInAt,
+		&deposit.depositCents, &deposit.balanceCents, &deposit.balanceDueAt, &deposit.balancePaidAt,
+		&f.ID, &f.FlightNumber, &f.Origin, &f.Destination, &f.DepartureTime, &f.ArrivalTime,
+		&f.TotalSeats, &f.AvailableSeats, &f.PriceCents, &f.Status, &f.DelayMinutes,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query itinerary: %w", err)
+	}
+	if status != domain.OrderStatusConfirmed || reference == nil {
+		return nil, domain.ErrOrderNotConfirmed
+	}
+	it.BookingReference = *reference
+	it.Deposit = deposit.toDomain()
+	if confirmedAt != nil {
+		it.ConfirmedAt = *confirmedAt
+	}
+
+	if it.Passengers, err = r.FindPassengers(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return &it, nil
+}
+
+// SaveBoardingPass stores the rendered boarding pass of an order, replacing any earlier one
+func (r *OrderRepo) SaveBoardingPass(ctx context.Context, orderID string, image []byte) error {
+	query := `
+		INSERT INTO boarding_passes (order_id, image)
+		VALUES ($1, $2)
+		ON CONFLICT (order_id) DO UPDATE SET image = EXCLUDED.image, created_at = NOW()
+	`
+
+	if _, err := r.pool.Exec(ctx, query, orderID, image); err != nil {
+		return fmt.Errorf("save boarding pass: %w", err)
+	}
+
+	return nil
+}
+
+// FindBoardingPass returns the rendered boarding pass of an order
+func (r *OrderRepo) FindBoardingPass(ctx context.Context, orderID string) ([]byte, error) {
+	var image []byte
+	err := r.pool.QueryRow(ctx, `SELECT image FROM boarding_passes WHERE order_id = $1`, orderID).Scan(&image)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrBoardingPassNotReady
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query boarding pass: %w", err)
+	}
+
+	return image, nil
+}
+
+// Confirm marks the order as confirmed under bookingReference and recognizes
+// its total as flight revenue in the same transaction. A retried confirmation
+// keeps the reference assigned first and does not count the revenue twice.
+func (r *OrderRepo) Confirm(ctx context.Context, id string, bookingReference string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin confirmation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := confirmOrder(ctx, tx, id, bookingReference); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit confirmation: %w", err)
+	}
+
+	return nil
+}
+
+// confirmOrder confirms the order and recognizes its revenue within tx. An
+// order that was failed, expired, cancelled or refunded since payment began
+// stays that way: it fails with domain.ErrVersionConflict.
+func confirmOrder(ctx context.Context, tx pgx.Tx, id string, bookingReference string) error {
+	result, err := tx.Exec(ctx, `
+		UPDATE orders
+		SET status = 'CONFIRMED', confirmed_at = NOW(),
+		    booking_reference = COALESCE(booking_reference, $2), version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status IN ('CREATED', 'SEATS_RESERVED', 'PAYMENT_PENDING', 'PAYMENT_PROCESSING', 'CONFIRMED')
+	`, id, bookingReference)
+	if err != nil {
+		return fmt.Errorf("confirm order: %w", seatWriteError(err))
+	}
+	if result.RowsAffected() == 0 {
+		return orderMiss(ctx, tx, id)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO flight_revenue (order_id, flight_id, amount_cents)
+		SELECT id, flight_id, total_price_cents FROM orders WHERE id = $1
+		ON CONFLICT (order_id) DO NOTHING
+	`, id)
+	if err != nil {
+		return fmt.Errorf("recognize revenue: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmOrderTx confirms an order in one transaction: it saves the
+// passengers, confirms the order and recognizes its revenue, books its seats
+// and marks them counted, which takes them off the flight's available count.
+// Either all of it commits or none of it does. Seats another order holds fail
+// it with domain.ErrDoubleBooking, seats not reserved under the
+// confirmation's lock token with domain.ErrStaleLock, and a flight with too
+// few seats left with domain.ErrInsufficientSeats. A retry is safe: the
+// reference and revenue are each applied once.
+func (r *OrderRepo) ConfirmOrderTx(ctx context.Context, confirmation domain.OrderConfirmation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin confirmation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The flight is locked first, as seat reservations do, so the seat and
+	// count writes below cannot deadlock with them
+	if err := lockFlight(ctx, tx, confirmation.FlightID); err != nil {
+		return err
+	}
+	if err := savePassengers(ctx, tx, confirmation.OrderID, confirmation.Passengers); err != nil {
+		return err
+	}
+	if err := confirmOrder(ctx, tx, confirmation.OrderID, confirmation.BookingReference); err != nil {
+		return err
+	}
+	if len(confirmation.Seats) > 0 {
+		if err := bookSeats(ctx, tx, confirmation.FlightID, confirmation.Seats, confirmation.OrderID, confirmation.LockToken); err != nil {
+			return err
+		}
+	}
+	if err := setSeatsCounted(ctx, tx, confirmation.OrderID, true); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit confirmation: %w", err)
+	}
+
+	return nil
+}
+
+// Unconfirm undoes Confirm for an order whose confirmation could not finish:
+// the order returns to PAYMENT_PROCESSING without a booking reference and its
+// revenue is dropped. Orders that are not confirmed are left alone.
+func (r *OrderRepo) Unconfirm(ctx context.Context, id string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin unconfirm: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orders
+		SET status = 'PAYMENT_PROCESSING', confirmed_at = NULL, booking_reference = NULL, version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status = 'CONFIRMED'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("unconfirm order: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM flight_revenue WHERE order_id = $1 AND reversed_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("drop revenue: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit unconfirm: %w", err)
+	}
+
+	return nil
+}
+
+// CountSeats marks an order's seats as counted against its flight, as
+// confirmation does; the bump sweep only picks counted orders. The flight's
+// available count itself follows the order's status and seats.
+func (r *OrderRepo) CountSeats(ctx context.Context, id string) error {
+	return r.countSeats(ctx, id, true)
+}
+
+// UncountSeats clears the mark CountSeats set
+func (r *OrderRepo) UncountSeats(ctx context.Context, id string) error {
+	return r.countSeats(ctx, id, false)
+}
+
+// countSeats runs setSeatsCounted in a transaction of its own
+func (r *OrderRepo) countSeats(ctx context.Context, id string, counted bool) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin seat count: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setSeatsCounted(ctx, tx, id, counted); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit seat count: %w", err)
+	}
+
+	return nil
+}
+
+// setSeatsCounted sets the order's seats_counted flag within tx; setting it
+// again changes nothing
+func setSeatsCounted(ctx context.Context, tx pgx.Tx, id string, counted bool) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orders
+		SET seats_counted = $2, version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND seats_counted <> $2
+	`, id, counted)
+	if err != nil {
+		return fmt.Errorf("mark seats counted: %w", err)
+	}
+	return nil
+}
+
+// ReverseRevenue takes a refunded order's recognized revenue back out of its
+// flight's totals. Reversing twice, or an order that never confirmed, is a no-op.
+func (r *OrderRepo) ReverseRevenue(ctx context.Context, id string) error {
+	query := `
+		UPDATE flight_revenue
+		SET reversed_at = NOW()
+		WHERE order_id = $1 AND reversed_at IS NULL
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("reverse revenue: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseCabinSeats drops the cabin capacity an unconfirmed seatless order holds
+func (r *OrderRepo) ReleaseCabinSeats(ctx context.Context, id string) error {
+	query := `
+		UPDATE orders
+		SET cabin_seats = 0, version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status <> 'CONFIRMED'
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("release cabin seats: %w", err)
+	}
+
+	return nil
+}
+
+// CheckIn moves a confirmed seatless order's cabin capacity onto seats: the
+// seats are booked, the order takes them and passengers are re-seated, all in
+// one transaction. It fails with domain.ErrSeatUnavailable if any seat was
+// taken concurrently, so the caller can pick again. available_seats is left
+// alone; confirmation already counted the capacity.
+func (r *OrderRepo) CheckIn(ctx context.Context, order *domain.Order, seats []string, passengers []domain.Passenger) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin check-in: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE orders
+		SET seats = $1, cabin_seats = 0, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND status = 'CONFIRMED' AND cabin_seats = $3
+	`, seats, order.ID, len(seats))
+	if err != nil {
+		return fmt.Errorf("assign order seats: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		// Checked in concurrently, or the capacity no longer matches
+		return domain.ErrSeatUnavailable
+	}
+
+	result, err = tx.Exec(ctx, `
+		UPDATE seats
+		SET status = 'booked', order_id = $1, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $2 AND id = ANY($3) AND status = 'available'
+	`, order.ID, order.FlightID, seats)
+	if err != nil {
+		return fmt.Errorf("book seats: %w", seatWriteError(err))
+	}
+	if result.RowsAffected() != int64(len(seats)) {
+		return domain.ErrSeatUnavailable
+	}
+
+	for i, p := range passengers {
+		_, err := tx.Exec(ctx, `
+			UPDATE passengers SET seat_id = NULLIF($1, '') WHERE order_id = $2 AND position = $3
+		`, p.SeatID, order.ID, i)
+		if err != nil {
+			return fmt.Errorf("seat passenger: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit check-in: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignSeats moves a confirmed order from its current seats to seats,
+// within one transaction: seats it gives up become available, seats it
+// takes are booked and passengers are re-seated. It fails with
+// domain.ErrSeatUnavailable if a new seat was taken concurrently or the
+// order's seats changed since it was read.
+func (r *OrderRepo) ReassignSeats(ctx context.Context, order *domain.Order, seats []string, passengers []domain.Passenger) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin seat reassignment: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE orders
+		SET seats = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND status = 'CONFIRMED' AND seats = $3
+	`, seats, order.ID, order.Seats)
+	if err != nil {
+		return fmt.Errorf("reassign order seats: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrSeatUnavailable
+	}
+
+	released := slices.DeleteFunc(slices.Clone(order.Seats), func(s string) bool { return slices.Contains(seats, s) })
+	taken := slices.DeleteFunc(slices.Clone(seats), func(s string) bool { return slices.Contains(order.Seats, s) })
+
+	_, err = tx.Exec(ctx, `
+		UPDATE seats
+		SET status = 'available', order_id = NULL, lock_token = NULL, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $1 AND id = ANY($2) AND order_id = $3 AND status = 'booked'
+	`, order.FlightID, released, order.ID)
+	if err != nil {
+		return fmt.Errorf("release seats: %w", err)
+	}
+
+	result, err = tx.Exec(ctx, `
+		UPDATE seats
+		SET status = 'booked', order_id = $1, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $2 AND id = ANY($3) AND status = 'available'
+	`, order.ID, order.FlightID, taken)
+	if err != nil {
+		return fmt.Errorf("book seats: %w", seatWriteError(err))
+	}
+	if result.RowsAffected() != int64(len(taken)) {
+		return domain.ErrSeatUnavailable
+	}
+
+	for i, p := range passengers {
+		_, err := tx.Exec(ctx, `
+			UPDATE passengers SET seat_id = NULLIF($1, '') WHERE order_id = $2 AND position = $3
+		`, p.SeatID, order.ID, i)
+		if err != nil {
+			return fmt.Errorf("seat passenger: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit seat reassignment: %w", err)
+	}
+
+	return nil
+}
+
+// MarkCheckedIn records that a confirmed order has completed online check-in
+func (r *OrderRepo) MarkCheckedIn(ctx context.Context, id string) error {
+	query := `
+		UPDATE orders
+		SET checked_in_at = NOW(), version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status = 'CONFIRMED'
+	`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("mark checked in: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrOrderNotConfirmed
+	}
+
+	return nil
+}
+
+// Fail marks the order as failed if it is still at version, and fails with
+// domain.ErrVersionConflict if it was written since
+func (r *OrderRepo) Fail(ctx context.Context, id string, reason string, version int) error {
+	query := `
+		UPDATE orders
+		SET status = 'FAILED', failure_reason = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND version = $3
+	`
+
+	result, err := r.pool.Exec(ctx, query, reason, id, version)
 	if err != nil {
 		return fmt.Errorf("fail order: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return r.versionMiss(ctx, id)
+	}
+
+	return nil
+}
+
+// Refund marks the order as refunded after its confirmation failed, if it
+// is still at version, and fails with domain.ErrVersionConflict if it was
+// written since
+func (r *OrderRepo) Refund(ctx context.Context, id string, reason string, version int) error {
+	query := `
+		UPDATE orders
+		SET status = 'PAYMENT_REFUNDED', failure_reason = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND version = $3
+	`
+
+	result, err := r.pool.Exec(ctx, query, reason, id, version)
+	if err != nil {
+		return fmt.Errorf("refund order: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.versionMiss(ctx, id)
+	}
+
+	return nil
+}
+
+// RecordDeposit records the deposit an order is paying instead of its full
+// price, and when its balance is due
+func (r *OrderRepo) RecordDeposit(ctx context.Context, id string, deposit domain.Deposit) error {
+	query := `
+		UPDATE orders
+		SET deposit_cents = $1, balance_cents = $2, balance_due_at = $3, version = version + 1, updated_at = NOW()
+		WHERE id = $4
+	`
+
+	result, err := r.pool.Exec(ctx, query, deposit.DepositCents, deposit.BalanceCents, deposit.BalanceDueAt, id)
+	if err != nil {
+		return fmt.Errorf("record deposit: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
 		return domain.ErrOrderNotFound
 	}
@@ -172,17 +1002,35 @@ func (r *OrderRepo) Fail(ctx context.Context, id string, reason string) error {
 	return nil
 }
 
-// Expire marks the order as expired
-func (r *OrderRepo) Expire(ctx context.Context, id string) error {
+// RecordPaymentMethod records the method and code an order is paid with, so
+// its balance and refunds go back through them. An ID that is a trip's
+// records them on each of its legs.
+func (r *OrderRepo) RecordPaymentMethod(ctx context.Context, id string, method domain.PaymentMethod, code string) error {
 	query := `
 		UPDATE orders
-		SET status = 'EXPIRED', updated_at = NOW()
-		WHERE id = $1
+		SET payment_method = $1, payment_code = $2, version = version + 1, updated_at = NOW()
+		WHERE id = $3 OR trip_id = $3
+	`
+
+	if _, err := r.pool.Exec(ctx, query, method.OrCard(), code, id); err != nil {
+		return fmt.Errorf("record payment method: %w", err)
+	}
+
+	return nil
+}
+
+// MarkBalancePaid records that a confirmed order's balance was charged.
+// Marking it twice keeps the first time.
+func (r *OrderRepo) MarkBalancePaid(ctx context.Context, id string) error {
+	query := `
+		UPDATE orders
+		SET balance_paid_at = COALESCE(balance_paid_at, NOW()), version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND balance_due_at IS NOT NULL
 	`
 
 	result, err := r.pool.Exec(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("expire order: %w", err)
+		return fmt.Errorf("mark balance paid: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -191,3 +1039,384 @@ func (r *OrderRepo) Expire(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// FailUnpaidBalance fails a confirmed order whose balance could not be
+// charged and, in the same transaction, takes its recognized revenue back
+// out of its flight's totals. It reports false when the order is no longer
+// confirmed with its balance unpaid, e.g. on a retry.
+func (r *OrderRepo) FailUnpaidBalance(ctx context.Context, id, reason string) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin unpaid balance: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE orders
+		SET status = 'FAILED', failure_reason = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND status = 'CONFIRMED' AND balance_due_at IS NOT NULL AND balance_paid_at IS NULL
+	`, reason, id)
+	if err != nil {
+		return false, fmt.Errorf("fail unpaid order: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE flight_revenue
+		SET reversed_at = NOW()
+		WHERE order_id = $1 AND reversed_at IS NULL
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("reverse revenue: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit unpaid balance: %w", err)
+	}
+
+	return true, nil
+}
+
+// Cancel marks the order as cancelled by an operator with a reason, if it
+// is still at version, and fails with domain.ErrVersionConflict if it was
+// written since
+func (r *OrderRepo) Cancel(ctx context.Context, id string, reason string, version int) error {
+	query := `
+		UPDATE orders
+		SET status = 'CANCELLED', failure_reason = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2 AND version = $3
+	`
+
+	result, err := r.pool.Exec(ctx, query, reason, id, version)
+	if err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.versionMiss(ctx, id)
+	}
+
+	return nil
+}
+
+// Expire marks the order as expired if it is still at version, and fails
+// with domain.ErrVersionConflict if it was written since
+func (r *OrderRepo) Expire(ctx context.Context, id string, version int) error {
+	query := `
+		UPDATE orders
+		SET status = 'EXPIRED', version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND version = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, version)
+	if err != nil {
+		return fmt.Errorf("expire order: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return r.versionMiss(ctx, id)
+	}
+
+	return nil
+}
+
+// FindStale returns up to limit orders still holding seats whose hold
+// expired before expiredBefore, oldest hold first. Their workflows normally
+// expire them; these are left over when a workflow was terminated or lost.
+func (r *OrderRepo) FindStale(ctx context.Context, expiredBefore time.Time, limit int) ([]*domain.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE status IN ('CREATED', 'SEATS_RESERVED', 'PAYMENT_PENDING', 'PAYMENT_PROCESSING')
+			AND expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, expiredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+// ExpireStale marks an order expired if it has not reached a final status,
+// and reports whether it did, so a sweep never overwrites an order that
+// confirmed or failed since it was found
+func (r *OrderRepo) ExpireStale(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE orders
+		SET status = 'EXPIRED', failure_reason = 'seat reservation expired without a running workflow', version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status IN ('CREATED', 'SEATS_RESERVED', 'PAYMENT_PENDING', 'PAYMENT_PROCESSING')
+	`
+
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("expire stale order: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// FindBumpCandidates returns the flight's confirmed seatless orders that
+// have not checked in and whose capacity is counted, so they can be moved
+func (r *OrderRepo) FindBumpCandidates(ctx context.Context, flightID string) ([]domain.BumpCandidate, error) {
+	query := `
+		SELECT id, cabin_seats, confirmed_at
+		FROM orders
+		WHERE flight_id = $1 AND status = 'CONFIRMED' AND cabin_seats > 0
+		  AND checked_in_at IS NULL AND seats_counted
+		ORDER BY confirmed_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query bump candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []domain.BumpCandidate
+	for rows.Next() {
+		var c domain.BumpCandidate
+		if err := rows.Scan(&c.OrderID, &c.Seats, &c.ConfirmedAt); err != nil {
+			return nil, fmt.Errorf("scan bump candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// Bump moves a confirmed seatless order off its oversold flight in one
+// transaction. The order goes to the earliest later flight on the same route
+// within policy.RebookWindow that has seats for it without overbooking, taking
+// its seat count and revenue along; with no such flight it is refunded. The
+// bump and its compensation are recorded, and bumping an order again returns
+// that record. It fails with domain.ErrOrderNotConfirmed if the order is no
+// longer bumpable, for instance because it checked in.
+func (r *OrderRepo) Bump(ctx context.Context, id string, policy domain.BumpPolicy) (*domain.Bump, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin bump: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if bump, err := findBump(ctx, tx, id); !errors.Is(err, pgx.ErrNoRows) {
+		return bump, err
+	}
+
+	var flight domain.Flight
+	var seats int
+	err = tx.QueryRow(ctx, `
+		SELECT f.id, f.origin, f.destination, f.departure_time, o.cabin_seats
+		FROM orders o JOIN flights f ON f.id = o.flight_id
+		WHERE o.id = $1 AND o.status = 'CONFIRMED' AND o.cabin_seats > 0
+		  AND o.checked_in_at IS NULL AND o.seats_counted
+		FOR UPDATE OF o
+	`, id).Scan(&flight.ID, &flight.Origin, &flight.Destination, &flight.DepartureTime, &seats)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrderNotConfirmed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find bumped order: %w", err)
+	}
+	if err := lockFlight(ctx, tx, flight.ID); err != nil {
+		return nil, err
+	}
+
+	bump := &domain.Bump{OrderID: id, FlightID: flight.ID, Seats: seats, Status: domain.BumpStatusRefunded}
+	alternative, err := findAlternative(ctx, tx, &flight, seats, policy.RebookWindow)
+	if err != nil {
+		return nil, err
+	}
+	if alternative != "" {
+		bump.Status = domain.BumpStatusRebooked
+		bump.AlternativeFlightID = &alternative
+	}
+	bump.CompensationCents = policy.Compensation(seats, bump.Status)
+
+	// Moving or refunding the order recounts both flights' available seats
+	if alternative != "" {
+		_, err = tx.Exec(ctx, `
+			WITH moved AS (
+				UPDATE orders SET flight_id = $2, version = version + 1, updated_at = NOW() WHERE id = $1
+			)
+			UPDATE flight_revenue SET flight_id = $2 WHERE order_id = $1
+		`, id, alternative)
+	} else {
+		_, err = tx.Exec(ctx, `
+			WITH refunded AS (
+				UPDATE orders
+				SET status = 'PAYMENT_REFUNDED', failure_reason = 'denied boarding: flight overbooked',
+				    seats_counted = FALSE, version = version + 1, updated_at = NOW()
+				WHERE id = $1
+			)
+			UPDATE flight_revenue SET reversed_at = NOW() WHERE order_id = $1 AND reversed_at IS NULL
+		`, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reaccommodate bumped order: %w", seatWriteError(err))
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO flight_bumps (order_id, flight_id, seats, status, alternative_flight_id, compensation_cents)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`, id, flight.ID, seats, bump.Status, bump.AlternativeFlightID, bump.CompensationCents).Scan(&bump.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("record bump: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit bump: %w", err)
+	}
+
+	return bump, nil
+}
+
+// findBump returns the recorded bump of an order, or pgx.ErrNoRows
+func findBump(ctx context.Context, tx pgx.Tx, orderID string) (*domain.Bump, error) {
+	var b domain.Bump
+	err := tx.QueryRow(ctx, `
+		SELECT order_id, flight_id, seats, status, alternative_flight_id, compensation_cents, created_at
+		FROM flight_bumps WHERE order_id = $1
+	`, orderID).Scan(&b.OrderID, &b.FlightID, &b.Seats, &b.Status, &b.AlternativeFlightID, &b.CompensationCents, &b.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("find bump: %w", err)
+	}
+	return &b, nil
+}
+
+// findAlternative locks and returns the earliest flight on the same route
+// leaving after flight, within window of it, that takes bookings and has
+// seats unpromised for seats more passengers. It returns "" if none does.
+// Rebooking never overbooks the alternative.
+func findAlternative(ctx context.Context, tx pgx.Tx, flight *domain.Flight, seats int, window time.Duration) (string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM flights
+		WHERE origin = $1 AND destination = $2 AND departure_time > $3 AND departure_time <= $4
+		  AND status IN ('scheduled', 'delayed') AND sales_closed_at IS NULL AND NOT booking_frozen
+		ORDER BY departure_time
+	`, flight.Origin, flight.Destination, flight.DepartureTime, flight.DepartureTime.Add(window))
+	if err != nil {
+		return "", fmt.Errorf("query alternative flights: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return "", fmt.Errorf("scan alternative flight: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := lockFlight(ctx, tx, id); err != nil {
+			return "", err
+		}
+		var unpromised int
+		if err := tx.QueryRow(ctx, `SELECT `+unpromisedSeats, id, nil).Scan(&unpromised); err != nil {
+			return "", fmt.Errorf("count alternative seats: %w", err)
+		}
+		if unpromised >= seats {
+			return id, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Archive moves up to limit finished orders that last changed before
+// finishedBefore into orders_archive, oldest first, with their passengers,
+// payment attempts, refunds and events, and prunes the webhook deliveries
+// already sent or given up on and the seat lock repairs recorded for them.
+// Orders a seat still names are left, as are confirmed orders, whose seats and
+// revenue belong to their flight. It all happens in one transaction, and
+// orders another archival has locked are skipped.
+func (r *OrderRepo) Archive(ctx context.Context, finishedBefore time.Time, limit int) (domain.OrderArchival, error) {
+	var result domain.OrderArchival
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return result, fmt.Errorf("begin archive orders: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM orders o
+		WHERE status IN ('FAILED', 'EXPIRED', 'PAYMENT_REFUNDED', 'CANCELLED')
+			AND updated_at < $1
+			AND NOT EXISTS (SELECT 1 FROM seats s WHERE s.order_id = o.id)
+		ORDER BY updated_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, finishedBefore, limit)
+	if err != nil {
+		return result, fmt.Errorf("find orders to archive: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return result, fmt.Errorf("find orders to archive: %w", err)
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO orders_archive (id, flight_id, status, created_at, finished_at, data, passengers, payments, refunds, events)
+		SELECT o.id, o.flight_id, o.status, o.created_at, o.updated_at, to_jsonb(o),
+		       COALESCE((SELECT jsonb_agg(to_jsonb(p) ORDER BY p.position) FROM passengers p WHERE p.order_id = o.id), '[]'),
+		       COALESCE((SELECT jsonb_agg(to_jsonb(pm) ORDER BY pm.created_at, pm.attempt) FROM payments pm WHERE pm.order_id = o.id), '[]'),
+		       COALESCE((SELECT jsonb_agg(to_jsonb(rf) ORDER BY rf.created_at) FROM refunds rf WHERE rf.order_id = o.id), '[]'),
+		       COALESCE((SELECT jsonb_agg(to_jsonb(ev) ORDER BY ev.occurred_at, ev.id) FROM order_events ev WHERE ev.order_id = o.id), '[]')
+		FROM orders o
+		WHERE o.id = ANY($1)
+		ON CONFLICT (id) DO NOTHING
+	`, ids)
+	if err != nil {
+		return result, fmt.Errorf("copy orders to archive: %w", err)
+	}
+
+	deliveries, err := tx.Exec(ctx, `
+		DELETE FROM webhook_deliveries WHERE order_id = ANY($1) AND status <> 'PENDING'
+	`, ids)
+	if err != nil {
+		return result, fmt.Errorf("prune webhook deliveries: %w", err)
+	}
+	repairs, err := tx.Exec(ctx, `DELETE FROM seat_lock_repairs WHERE order_id = ANY($1::text[])`, ids)
+	if err != nil {
+		return result, fmt.Errorf("prune seat lock repairs: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM payments WHERE order_id = ANY($1)`, ids); err != nil {
+		return result, fmt.Errorf("prune payments: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM order_events WHERE order_id = ANY($1)`, ids); err != nil {
+		return result, fmt.Errorf("prune order events: %w", err)
+	}
+
+	// Passengers, refunds and the order's other rows go with it
+	deleted, err := tx.Exec(ctx, `DELETE FROM orders WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return result, fmt.Errorf("delete archived orders: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return result, fmt.Errorf("commit archive orders: %w", err)
+	}
+
+	result.Orders = int(deleted.RowsAffected())
+	result.Pruned = deliveries.RowsAffected() + repairs.RowsAffected()
+	return result, nil
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestOrderListWhere(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	where, args := orderListWhere(domain.OrderFilter{})
+	if where != "" || len(args) != 0 {
+		t.Errorf("empty filter = %q %v, want no WHERE clause", where, args)
+	}
+
+	where, args = orderListWhere(domain.OrderFilter{
+		Statuses:     []domain.OrderStatus{domain.OrderStatusConfirmed, domain.OrderStatusFailed},
+		FlightID:     "flight-1",
+		CreatedAfter: &from,
+	})
+	if want := " WHERE status = ANY($1) AND flight_id = $2 AND created_at >= $3"; where != want {
+		t.Errorf("where = %q, want %q", where, want)
+	}
+	if len(args) != 3 || !slices.Equal(args[0].([]string), []string{"CONFIRMED", "FAILED"}) || args[1] != "flight-1" || args[2] != from {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestOrderListOrder(t *testing.T) {
+	if got := orderListOrder(domain.Page{Sort: domain.OrderSortPrice, Descending: true}); got != "total_price_cents DESC, id DESC" {
+		t.Errorf("price descending = %q", got)
+	}
+	if got := orderListOrder(domain.Page{}.Normalized()); got != "created_at ASC, id ASC" {
+		t.Errorf("default = %q", got)
+	}
+}
+
+// TestConfirmOrderTx_RollsBackEachStep fails each write of the confirmation
+// in turn and checks that none of the others commit
+func TestConfirmOrderTx_RollsBackEachStep(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	orders := NewOrderRepo(pool)
+
+	tests := []struct {
+		name    string
+		seats   []string
+		setup   func(t *testing.T, flightID, orderID string)
+		token   int64
+		wantErr error
+	}{
+		{
+			// The order's cabin capacity outgrows the flight once it counts
+			// as confirmed
+			name:  "mark confirmed",
+			seats: []string{"1A", "1B"},
+			setup: func(t *testing.T, flightID, orderID string) {
+				execTest(t, pool, `UPDATE orders SET cabin_seats = 7 WHERE id = $1`, orderID)
+			},
+			token:   1,
+			wantErr: domain.ErrInsufficientSeats,
+		},
+		{
+			name:  "book seats held by another order",
+			seats: []string{"1A", "1B", "1C"},
+			setup: func(t *testing.T, flightID, orderID string) {
+				createTestOrder(t, pool, flightID, domain.OrderStatusPaymentProcessing, "1C")
+			},
+			token:   1,
+			wantErr: domain.ErrDoubleBooking,
+		},
+		{
+			name:    "book seats under a stale lock token",
+			seats:   []string{"1A", "1B"},
+			token:   2,
+			wantErr: domain.ErrStaleLock,
+		},
+		{
+			// A confirmed seatless order holds five of the six seats, so
+			// booking two more oversells the flight
+			name:  "book seats past the flight's capacity",
+			seats: []string{"1A", "1B"},
+			setup: func(t *testing.T, flightID, orderID string) {
+				other := createTestOrder(t, pool, flightID, domain.OrderStatusConfirmed)
+				execTest(t, pool, `UPDATE orders SET seatless = TRUE, cabin_seats = 5 WHERE id = $1`, other)
+			},
+			token:   1,
+			wantErr: domain.ErrInsufficientSeats,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flightID := createTestFlight(t, pool)
+			orderID := createTestOrder(t, pool, flightID, domain.OrderStatusPaymentProcessing, "1A", "1B")
+			if tt.setup != nil {
+				tt.setup(t, flightID, orderID)
+			}
+
+			err := orders.ConfirmOrderTx(ctx, domain.OrderConfirmation{
+				OrderID:          orderID,
+				FlightID:         flightID,
+				Seats:            tt.seats,
+				Passengers:       []domain.Passenger{{SeatID: "1A", Name: "Ada Lovelace", DocumentNumber: "P1", Email: "ada@example.com"}},
+				BookingReference: "ABC123",
+				LockToken:        tt.token,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConfirmOrderTx error = %v, want %v", err, tt.wantErr)
+			}
+
+			order, err := orders.FindByID(ctx, orderID)
+			if err != nil {
+				t.Fatalf("find order: %v", err)
+			}
+			if order.Status != domain.OrderStatusPaymentProcessing || order.BookingReference != nil {
+				t.Errorf("order is %s with reference %v, want it unconfirmed", order.Status, order.BookingReference)
+			}
+
+			var counted bool
+			var revenue, passengers int
+			err = pool.QueryRow(ctx, `
+				SELECT o.seats_counted,
+				       (SELECT COUNT(*) FROM flight_revenue WHERE order_id = o.id),
+				       (SELECT COUNT(*) FROM passengers WHERE order_id = o.id)
+				FROM orders o WHERE o.id = $1
+			`, orderID).Scan(&counted, &revenue, &passengers)
+			if err != nil {
+				t.Fatalf("read order state: %v", err)
+			}
+			if counted || revenue != 0 || passengers != 0 {
+				t.Errorf("counted %v, %d revenue rows, %d passengers committed, want none", counted, revenue, passengers)
+			}
+
+			statuses := seatStatuses(t, pool, flightID)
+			for _, seat := range []string{"1A", "1B"} {
+				if statuses[seat] != domain.SeatStatusReserved {
+					t.Errorf("seat %s is %s, want it still reserved", seat, statuses[seat])
+				}
+			}
+		})
+	}
+}
+
+// TestExtendHold_StatusesHoldingSeats checks that a hold can be extended in
+// every status that keeps the order's seats, including after a declined
+// payment, and in none of the others
+func TestExtendHold_StatusesHoldingSeats(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	orders := NewOrderRepo(pool)
+	flightID := createTestFlight(t, pool)
+	expiresAt := time.Now().Add(15 * time.Minute).Truncate(time.Microsecond)
+
+	for _, status := range []domain.OrderStatus{
+		domain.OrderStatusSeatsReserved,
+		domain.OrderStatusPaymentPending,
+		domain.OrderStatusPaymentProcessing,
+		domain.OrderStatusExpired,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			orderID := createTestOrder(t, pool, flightID, status)
+
+			err := orders.ExtendHold(ctx, orderID, expiresAt)
+			if !domain.HoldsSeats(status) {
+				if !errors.Is(err, domain.ErrHoldNotExtendable) {
+					t.Errorf("ExtendHold error = %v, want %v", err, domain.ErrHoldNotExtendable)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtendHold: %v", err)
+			}
+			order, err := orders.FindByID(ctx, orderID)
+			if err != nil {
+				t.Fatalf("find order: %v", err)
+			}
+			if order.ExpiresAt == nil || !order.ExpiresAt.Equal(expiresAt) {
+				t.Errorf("expires at %v, want %v", order.ExpiresAt, expiresAt)
+			}
+		})
+	}
+}
+
+// TestTerminalWrites_CompareVersions checks that a terminal write made
+// against a version the order has moved on from changes nothing, and that
+// confirmation leaves a finished order alone
+func TestTerminalWrites_CompareVersions(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	orders := NewOrderRepo(pool)
+	flightID := createTestFlight(t, pool)
+
+	// A new order is at version 1; the payment moves it to 2
+	orderID := createTestOrder(t, pool, flightID, domain.OrderStatusSeatsReserved)
+	if err := orders.UpdateStatus(ctx, orderID, domain.OrderStatusPaymentProcessing, 1); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	if err := orders.Expire(ctx, orderID, 1); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Expire at a stale version: error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if err := orders.Fail(ctx, uuid.New().String(), "gone", 1); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Errorf("Fail of a missing order: error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+	order, err := orders.FindByID(ctx, orderID)
+	if err != nil {
+		t.Fatalf("find order: %v", err)
+	}
+	if order.Status != domain.OrderStatusPaymentProcessing || order.Version != 2 {
+		t.Fatalf("order is %s at version %d, want PAYMENT_PROCESSING at 2", order.Status, order.Version)
+	}
+
+	if err := orders.Expire(ctx, orderID, 2); err != nil {
+		t.Fatalf("Expire at the current version: %v", err)
+	}
+	if err := orders.Confirm(ctx, orderID, "ABC123"); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Confirm of an expired order: error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if order, err := orders.FindByID(ctx, orderID); err != nil || order.Status != domain.OrderStatusExpired {
+		t.Errorf("order = %+v, %v; want it still EXPIRED", order, err)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// PaymentRepo handles payment data access
+type PaymentRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewPaymentRepo creates a new PaymentRepo
+func NewPaymentRepo(pool *pgxpool.Pool) *PaymentRepo {
+	return &PaymentRepo{pool: pool}
+}
+
+// paymentColumns is the column list scanned by scanPayment
+const paymentColumns = `
+	id, idempotency_key, order_id, method, scope, attempt, amount_cents, status,
+	provider_ref, message, error, resumed_by_attempt, created_at, updated_at
+`
+
+// errPaymentNotFound is returned by scanPayment when no row matched
+var errPaymentNotFound = errors.New("payment not found")
+
+// scanPayment scans a row selected with paymentColumns
+func scanPayment(row pgx.Row) (*domain.Payment, error) {
+	var p domain.Payment
+	err := row.Scan(
+		&p.ID, &p.IdempotencyKey, &p.OrderID, &p.Method, &p.Scope, &p.Attempt, &p.AmountCents, &p.Status,
+		&p.ProviderRef, &p.Message, &p.Error, &p.ResumedByAttempt, &p.CreatedAt, &p.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query payment: %w", err)
+	}
+
+	return &p, nil
+}
+
+// Begin records payment as started before the provider is called. What the
+// latest attempt of the same order and scope left decides what is returned:
+//   - the same attempt, begun again, is returned as stored
+//   - a captured payment is returned, so nothing is charged again
+//   - a resumable one is marked resumed by this attempt, which takes over
+//     its idempotency key and any authorization it holds
+//
+// Otherwise payment is inserted under its own key.
+func (r *PaymentRepo) Begin(ctx context.Context, payment domain.Payment) (*domain.Payment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin payment: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	latest, err := scanPayment(tx.QueryRow(ctx, `
+		SELECT `+paymentColumns+`
+		FROM payments
+		WHERE order_id = $1 AND scope = $2
+		ORDER BY attempt DESC
+		LIMIT 1
+		FOR UPDATE
+	`, payment.OrderID, payment.Scope))
+	switch {
+	case errors.Is(err, errPaymentNotFound):
+	case err != nil:
+		return nil, err
+	case latest.Attempt == payment.Attempt || latest.Status == domain.PaymentCaptured:
+		return latest, tx.Commit(ctx)
+	case latest.Resumable():
+		_, err = tx.Exec(ctx, `
+			UPDATE payments SET resumed_by_attempt = $2, updated_at = NOW() WHERE id = $1
+		`, latest.ID, payment.Attempt)
+		if err != nil {
+			return nil, fmt.Errorf("resume payment %s: %w", latest.ID, err)
+		}
+		payment.IdempotencyKey = latest.IdempotencyKey
+		payment.ProviderRef = latest.ProviderRef
+		payment.Message = latest.Message
+		payment.Status = domain.PaymentStarted
+		if latest.Status == domain.PaymentAuthorized {
+			payment.Status = domain.PaymentAuthorized
+		}
+	}
+	if payment.Status == "" {
+		payment.Status = domain.PaymentStarted
+	}
+
+	created, err := scanPayment(tx.QueryRow(ctx, `
+		INSERT INTO payments (idempotency_key, order_id, method, scope, attempt, amount_cents, status, provider_ref, message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+paymentColumns,
+		payment.IdempotencyKey, payment.OrderID, payment.Method.OrCard(), payment.Scope, payment.Attempt, payment.AmountCents,
+		payment.Status, payment.ProviderRef, payment.Message))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit payment: %w", err)
+	}
+
+	return created, nil
+}
+
+// Record sets the outcome the provider gave a payment attempt. An empty
+// providerRef keeps the one already recorded; an empty errMsg clears the
+// attempt's error.
+func (r *PaymentRepo) Record(ctx context.Context, id string, status domain.PaymentStatus, providerRef, message, errMsg string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE payments
+		SET status = $2,
+		    provider_ref = COALESCE(NULLIF($3, ''), provider_ref),
+		    message = $4,
+		    error = NULLIF($5, ''),
+		    updated_at = NOW()
+		WHERE id = $1
+	`, id, status, providerRef, message, errMsg)
+	if err != nil {
+		return fmt.Errorf("record payment %s: %w", id, err)
+	}
+	return nil
+}
+
+// RecordProviderRef sets the outcome of the attempt holding an authorization,
+// for steps such as a capture that know only the authorization. Attempts a
+// later one resumed are left as they were. It is a no-op when no attempt
+// holds the authorization.
+func (r *PaymentRepo) RecordProviderRef(ctx context.Context, providerRef string, status domain.PaymentStatus) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE payments
+		SET status = $2, updated_at = NOW()
+		WHERE provider_ref = $1 AND resumed_by_attempt IS NULL
+	`, providerRef, status)
+	if err != nil {
+		return fmt.Errorf("record payment authorization %s: %w", providerRef, err)
+	}
+	return nil
+}
+
+// ListByOrder returns every payment attempt of an order, oldest first
+func (r *PaymentRepo) ListByOrder(ctx context.Context, orderID string) ([]domain.Payment, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+paymentColumns+` FROM payments WHERE order_id = $1 ORDER BY created_at, attempt
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("query payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []domain.Payment{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, *payment)
+	}
+	return payments, rows.Err()
+}
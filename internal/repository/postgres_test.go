@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Tests below run against the docker-compose Postgres with the migrations
+// applied, and are skipped when it is unavailable. Each creates its own
+// flights and orders and deletes them when it ends.
+
+// testPool connects to Postgres or skips the test
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	pool, err := database.NewPostgresPool(context.Background(), config.Load().Database)
+	if err != nil {
+		t.Skipf("postgres unavailable: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// createTestFlight inserts a flight with one row of seats, 1A to 1F
+func createTestFlight(t *testing.T, pool *pgxpool.Pool) string {
+	t.Helper()
+	ctx := context.Background()
+	flightID := uuid.New().String()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO flights (id, flight_number, origin, destination, departure_time, arrival_time,
+		                     total_seats, available_seats, price_cents)
+		VALUES ($1, $2, 'TST', 'DST', NOW() + INTERVAL '1 day', NOW() + INTERVAL '1 day 2 hours', 6, 6, 10000)
+	`, flightID, "T"+flightID[:8])
+	if err != nil {
+		t.Fatalf("create flight: %v", err)
+	}
+	_, err = pool.Exec(ctx, `
+		INSERT INTO seats (id, flight_id, row_num, col, status)
+		SELECT '1' || c.col, $1, 1, c.col, 'available'
+		FROM (VALUES ('A'), ('B'), ('C'), ('D'), ('E'), ('F')) AS c(col)
+	`, flightID)
+	if err != nil {
+		t.Fatalf("create seats: %v", err)
+	}
+
+	// Seats go first: a reserved seat cannot outlive its order
+	t.Cleanup(func() {
+		ctx := context.Background()
+		_, _ = pool.Exec(ctx, `DELETE FROM seats WHERE flight_id = $1`, flightID)
+		_, _ = pool.Exec(ctx, `DELETE FROM orders WHERE flight_id = $1`, flightID)
+		_, _ = pool.Exec(ctx, `DELETE FROM flights WHERE id = $1`, flightID)
+	})
+	return flightID
+}
+
+// createTestOrder inserts an order at status holding seats, which are
+// reserved for it under lock token 1
+func createTestOrder(t *testing.T, pool *pgxpool.Pool, flightID string, status domain.OrderStatus, seats ...string) string {
+	t.Helper()
+	ctx := context.Background()
+	orderID := uuid.New().String()
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO orders (id, flight_id, workflow_id, status, seats, total_price_cents)
+		VALUES ($1, $2, $3, $4, $5, 20000)
+	`, orderID, flightID, "booking-"+orderID, status, seats)
+	if err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if len(seats) > 0 {
+		if err := NewFlightRepo(pool).MarkSeatsReserved(ctx, flightID, seats, orderID, 1); err != nil {
+			t.Fatalf("reserve seats: %v", err)
+		}
+	}
+	return orderID
+}
+
+// seatStatuses returns the status of each of a flight's seats by ID
+func seatStatuses(t *testing.T, pool *pgxpool.Pool, flightID string) map[string]domain.SeatStatus {
+	t.Helper()
+	seats, err := NewFlightRepo(pool).FindSeats(context.Background(), flightID)
+	if err != nil {
+		t.Fatalf("find seats: %v", err)
+	}
+	statuses := make(map[string]domain.SeatStatus, len(seats))
+	for _, seat := range seats {
+		statuses[seat.ID] = seat.Status
+	}
+	return statuses
+}
+
+// execTest runs a statement a test needs for its setup
+func execTest(t *testing.T, pool *pgxpool.Pool, sql string, args ...any) {
+	t.Helper()
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/redisops"
+)
+
+// RateLimitRepo keeps request token buckets in Redis so limits are shared
+// across API instances
+type RateLimitRepo struct {
+	client *redis.Client
+}
+
+// NewRateLimitRepo creates a new RateLimitRepo
+func NewRateLimitRepo(client *redis.Client) *RateLimitRepo {
+	return &RateLimitRepo{client: client}
+}
+
+// rateLimitKey generates the Redis key for a rate limit bucket
+func rateLimitKey(key string) string {
+	return fmt.Sprintf("ratelimit:%s", key)
+}
+
+// Take spends one request from key's bucket, which holds limit requests and
+// refills completely over window. It returns the requests left and, when the
+// bucket was empty, how long until the next request is allowed.
+func (r *RateLimitRepo) Take(ctx context.Context, key string, limit int, window time.Duration) (int64, time.Duration, error) {
+	remaining, retryAfter, err := redisops.TakeToken(ctx, r.client, rateLimitKey(key), limit, window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count request: %w", err)
+	}
+
+	return remaining, retryAfter, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// RefundRepo handles refund data access
+type RefundRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewRefundRepo creates a new RefundRepo
+func NewRefundRepo(pool *pgxpool.Pool) *RefundRepo {
+	return &RefundRepo{pool: pool}
+}
+
+// refundColumns is the column list scanned by scanRefund
+const refundColumns = `
+	id, order_id, amount_cents, reason, status, error, created_at, completed_at
+`
+
+// errRefundNotFound is returned by scanRefund when no row matched
+var errRefundNotFound = errors.New("refund not found")
+
+// scanRefund scans a row selected with refundColumns
+func scanRefund(row pgx.Row) (*domain.Refund, error) {
+	var rf domain.Refund
+	err := row.Scan(
+		&rf.ID, &rf.OrderID, &rf.AmountCents, &rf.Reason, &rf.Status, &rf.Error,
+		&rf.CreatedAt, &rf.CompletedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errRefundNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query refund: %w", err)
+	}
+
+	return &rf, nil
+}
+
+// Begin opens a pending refund of what the order paid: its full price, less
+// the balance of a deposit not charged yet. A refund already pending for the
+// order is returned instead, so a retry does not open a second one. Orders
+// that are not confirmed fail with domain.ErrOrderNotRefundable, and with a
+// version set, orders no longer at it with domain.ErrVersionConflict.
+func (r *RefundRepo) Begin(ctx context.Context, orderID, reason string, version *int) (*domain.Refund, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin refund: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status domain.OrderStatus
+	var amount int64
+	var current int
+	err = tx.QueryRow(ctx, `
+		SELECT status,
+		       total_price_cents - CASE WHEN balance_paid_at IS NULL THEN COALESCE(balance_cents, 0) ELSE 0 END,
+		       version
+		FROM orders WHERE id = $1 FOR UPDATE
+	`, orderID).Scan(&status, &amount, &current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock order for refund: %w", err)
+	}
+
+	refund, err := scanRefund(tx.QueryRow(ctx, `
+		SELECT `+refundColumns+` FROM refunds WHERE order_id = $1 AND status = 'PENDING'
+	`, orderID))
+	if err == nil {
+		return refund, nil
+	}
+	if !errors.Is(err, errRefundNotFound) {
+		return nil, err
+	}
+	if status != domain.OrderStatusConfirmed {
+		return nil, domain.ErrOrderNotRefundable
+	}
+	if version != nil && *version != current {
+		return nil, domain.ErrVersionConflict
+	}
+
+	refund, err = scanRefund(tx.QueryRow(ctx, `
+		INSERT INTO refunds (order_id, amount_cents, reason)
+		VALUES ($1, $2, $3)
+		RETURNING `+refundColumns, orderID, amount, reason))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit refund: %w", err)
+	}
+
+	return refund, nil
+}
+
+// Complete records a pending refund as succeeded and, in the same
+// transaction, marks its order refunded with the refund's reason and takes
+// the order's recognized revenue back out of its flight's totals. completed
+// is false when the refund had already been recorded, e.g. on a retry.
+func (r *RefundRepo) Complete(ctx context.Context, id string) (refund *domain.Refund, completed bool, err error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("begin refund completion: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err = scanRefund(tx.QueryRow(ctx, `
+		UPDATE refunds
+		SET status = 'SUCCEEDED', completed_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING `+refundColumns, id))
+	if errors.Is(err, errRefundNotFound) {
+		refund, err = r.Find(ctx, id)
+		return refund, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orders
+		SET status = 'PAYMENT_REFUNDED', failure_reason = $1, version = version + 1, updated_at = NOW()
+		WHERE id = $2
+	`, refund.Reason, refund.OrderID)
+	if err != nil {
+		return nil, false, fmt.Errorf("mark order refunded: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE flight_revenue
+		SET reversed_at = NOW()
+		WHERE order_id = $1 AND reversed_at IS NULL
+	`, refund.OrderID)
+	if err != nil {
+		return nil, false, fmt.Errorf("reverse revenue: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("commit refund completion: %w", err)
+	}
+
+	return refund, true, nil
+}
+
+// Fail records a pending refund the payment provider did not complete; the
+// order stays confirmed and may be refunded again
+func (r *RefundRepo) Fail(ctx context.Context, id, reason string) (*domain.Refund, error) {
+	refund, err := scanRefund(r.pool.QueryRow(ctx, `
+		UPDATE refunds
+		SET status = 'FAILED', error = $2, completed_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING `+refundColumns, id, reason))
+	if errors.Is(err, errRefundNotFound) {
+		return r.Find(ctx, id)
+	}
+	return refund, err
+}
+
+// Find returns a refund by ID
+func (r *RefundRepo) Find(ctx context.Context, id string) (*domain.Refund, error) {
+	refund, err := scanRefund(r.pool.QueryRow(ctx, `SELECT `+refundColumns+` FROM refunds WHERE id = $1`, id))
+	if errors.Is(err, errRefundNotFound) {
+		return nil, fmt.Errorf("refund %s: %w", id, err)
+	}
+	return refund, err
+}
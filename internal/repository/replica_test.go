@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// replicaTables are the tables the repos' listings read
+var replicaTables = []string{"flights", "seats", "orders"}
+
+// testReplica returns a read-only pool standing in for a replica that has not
+// caught up with primary: its search path finds empty copies of
+// replicaTables in a schema of their own, which it also returns
+func testReplica(t *testing.T, primary *pgxpool.Pool) (*pgxpool.Pool, string) {
+	t.Helper()
+	ctx := context.Background()
+	schema := "replica_" + strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+
+	execTest(t, primary, `CREATE SCHEMA `+schema)
+	t.Cleanup(func() { _, _ = primary.Exec(context.Background(), `DROP SCHEMA `+schema+` CASCADE`) })
+	for _, table := range replicaTables {
+		execTest(t, primary, fmt.Sprintf(`CREATE TABLE %s.%s (LIKE public.%s INCLUDING DEFAULTS)`, schema, table, table))
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(config.Load().Database.DatabaseURL())
+	if err != nil {
+		t.Fatalf("parse replica config: %v", err)
+	}
+	poolConfig.ConnConfig.RuntimeParams["search_path"] = schema
+	poolConfig.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	replica, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		t.Fatalf("connect replica: %v", err)
+	}
+	t.Cleanup(replica.Close)
+	return replica, schema
+}
+
+// TestReplicaRouting checks that listings read from the replica while writes,
+// and the lookups that follow them, stay on the primary. The replica is read
+// only, so a write sent to it would fail, and it starts empty, so a listing
+// read from the primary would show rows it has not caught up with.
+func TestReplicaRouting(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	replica, schema := testReplica(t, pool)
+	flights := NewFlightRepo(pool).WithReplica(replica)
+	orders := NewOrderRepo(pool).WithReplica(replica)
+
+	flightID := createTestFlight(t, pool)
+	orderID := createTestOrder(t, pool, flightID, domain.OrderStatusSeatsReserved)
+
+	// Writes
+	if err := flights.MarkSeatsReserved(ctx, flightID, []string{"1A"}, orderID, 1); err != nil {
+		t.Fatalf("MarkSeatsReserved: %v", err)
+	}
+	if err := orders.Fail(ctx, orderID, "replica test", 1); err != nil { // a new order is at version 1
+		t.Fatalf("Fail: %v", err)
+	}
+
+	// Read-after-write lookups see them at once
+	order, err := orders.FindByID(ctx, orderID)
+	if err != nil {
+		t.Fatalf("FindByID order: %v", err)
+	}
+	if order.Status != domain.OrderStatusFailed {
+		t.Errorf("order is %s, want the FAILED just written", order.Status)
+	}
+	if _, err := flights.FindByID(ctx, flightID); err != nil {
+		t.Errorf("FindByID flight: %v", err)
+	}
+
+	// Listings lag until the replica catches up
+	listed := func() (flightListed bool, seats int, orderTotal int) {
+		t.Helper()
+		all, err := flights.FindAll(ctx)
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		seatMap, err := flights.FindSeats(ctx, flightID)
+		if err != nil {
+			t.Fatalf("FindSeats: %v", err)
+		}
+		page, err := orders.List(ctx, domain.OrderFilter{FlightID: flightID}, domain.Page{})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		flightListed = slices.ContainsFunc(all, func(f domain.Flight) bool { return f.ID == flightID })
+		return flightListed, len(seatMap), page.Total
+	}
+
+	if flightListed, seats, total := listed(); flightListed || seats != 0 || total != 0 {
+		t.Errorf("before catching up: flight listed %v, %d seats, %d orders; want none from the replica", flightListed, seats, total)
+	}
+
+	for _, table := range replicaTables {
+		column := "flight_id"
+		if table == "flights" {
+			column = "id"
+		}
+		execTest(t, pool, fmt.Sprintf(`INSERT INTO %s.%s SELECT * FROM public.%s WHERE %s = $1`, schema, table, table, column), flightID)
+	}
+
+	if flightListed, seats, total := listed(); !flightListed || seats != 6 || total != 1 {
+		t.Errorf("after catching up: flight listed %v, %d seats, %d orders; want the flight, 6 seats and 1 order", flightListed, seats, total)
+	}
+}
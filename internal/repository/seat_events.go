@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// SeatEventRepo publishes seat status changes on a Redis pub/sub channel per
+// flight, so API nodes can push them to clients without polling Postgres
+type SeatEventRepo struct {
+	client *redis.Client
+}
+
+// NewSeatEventRepo creates a new SeatEventRepo
+func NewSeatEventRepo(client *redis.Client) *SeatEventRepo {
+	return &SeatEventRepo{client: client}
+}
+
+// SeatChannel is the pub/sub channel carrying a flight's seat changes as JSON
+// domain.SeatChange messages
+func SeatChannel(flightID string) string {
+	return fmt.Sprintf("flight:%s:seats", flightID)
+}
+
+// Publish sends a seat change to the subscribers of its flight's channel.
+// Messages are fire and forget: nodes not subscribed at the time miss them.
+func (r *SeatEventRepo) Publish(ctx context.Context, change domain.SeatChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("marshal seat change: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, SeatChannel(change.FlightID), payload).Err(); err != nil {
+		return fmt.Errorf("publish seat change: %w", err)
+	}
+
+	return nil
+}
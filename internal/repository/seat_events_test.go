@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// testRedis connects to the docker-compose Redis or skips the test
+func testRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	client, err := database.NewRedisClient(context.Background(), config.Load().Redis)
+	if err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestSeatChannel(t *testing.T) {
+	if got := SeatChannel("flight-1"); got != "flight:flight-1:seats" {
+		t.Errorf("SeatChannel = %q, want flight:flight-1:seats", got)
+	}
+}
+
+func TestSeatEventRepo_PublishReachesSubscribers(t *testing.T) {
+	ctx := context.Background()
+	client := testRedis(t)
+	flightID := uuid.New().String()
+
+	sub := client.Subscribe(ctx, SeatChannel(flightID))
+	t.Cleanup(func() { sub.Close() })
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	sent := domain.SeatChange{
+		FlightID:   flightID,
+		Seats:      []string{"1A", "1B"},
+		Status:     domain.SeatStatusReserved,
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+	if err := NewSeatEventRepo(client).Publish(ctx, sent); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		var got domain.SeatChange
+		if err := json.Unmarshal([]byte(msg.Payload), &got); err != nil {
+			t.Fatalf("decode %q: %v", msg.Payload, err)
+		}
+		if got.FlightID != sent.FlightID || got.Status != sent.Status || !got.OccurredAt.Equal(sent.OccurredAt) ||
+			len(got.Seats) != 2 || got.Seats[0] != "1A" || got.Seats[1] != "1B" {
+			t.Errorf("received %+v, want %+v", got, sent)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no seat change received")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/redisops"
 )
 
 // SeatLockRepo handles distributed seat locking via Redis
@@ -18,127 +21,130 @@ func NewSeatLockRepo(client *redis.Client) *SeatLockRepo {
 	return &SeatLockRepo{client: client}
 }
 
-// seatLockKey generates the Redis key for a seat lock
-func seatLockKey(flightID, seatID string) string {
-	return fmt.Sprintf("seat:lock:%s:%s", flightID, seatID)
+// seatLocks is the lock set holding every seat lock on a flight, keyed by
+// seat ID, with the flight's fencing token counter. The hash tag keeps the
+// keys in one slot for the scripts.
+func seatLocks(flightID string) redisops.LockSet {
+	return redisops.LockSet{
+		Key:       fmt.Sprintf("seat:locks:{%s}", flightID),
+		ExpiryKey: fmt.Sprintf("seat:locks:{%s}:expiry", flightID),
+		FenceKey:  fmt.Sprintf("seat:locks:{%s}:fence", flightID),
+	}
 }
 
-// LockSeats attempts to lock multiple seats for an order
-// Returns nil if all seats were locked, error otherwise
-func (r *SeatLockRepo) LockSeats(ctx context.Context, flightID string, seatIDs []string, orderID string, ttl time.Duration) error {
-	// Use a pipeline for atomic operations
-	pipe := r.client.TxPipeline()
-
-	// First, check if any seats are already locked
-	for _, seatID := range seatIDs {
-		key := seatLockKey(flightID, seatID)
-		pipe.Get(ctx, key)
+// LockSeats locks every seat for an order, or none of them if another order
+// holds any; seats the order already holds are refreshed. The check and the
+// set run in one script, so concurrent orders cannot both take a seat. A
+// conflict fails with a *domain.SeatLockedError naming the first held seat.
+//
+// A token of zero issues a new fencing token, higher than any the flight has
+// issued; any other token is one the order already holds, which the seats
+// join. It returns the token the seats are held under, which releasing,
+// extending and booking them must present.
+func (r *SeatLockRepo) LockSeats(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64, ttl time.Duration) (int64, error) {
+	token, conflict, err := redisops.AcquireLocks(ctx, r.client, seatLocks(flightID), seatIDs, orderID, token, ttl)
+	if err != nil {
+		return 0, fmt.Errorf("lock seats: %w", err)
 	}
-
-	results, err := pipe.Exec(ctx)
-	if err != nil && err != redis.Nil {
-		return fmt.Errorf("check existing locks: %w", err)
+	if conflict != nil {
+		return 0, &domain.SeatLockedError{SeatID: seatIDs[conflict.Index], OrderID: conflict.Owner}
 	}
 
-	// Check results - if any seat is already locked by a different order, fail
-	for i, result := range results {
-		if result.Err() == nil {
-			existingOrderID, _ := result.(*redis.StringCmd).Result()
-			if existingOrderID != orderID {
-				return fmt.Errorf("seat %s already locked by order %s", seatIDs[i], existingOrderID)
-			}
-		}
-	}
-
-	// Now set all locks with NX (only if not exists) or update if same order
-	pipe = r.client.TxPipeline()
-	for _, seatID := range seatIDs {
-		key := seatLockKey(flightID, seatID)
-		pipe.Set(ctx, key, orderID, ttl)
-	}
+	return token, nil
+}
 
-	_, err = pipe.Exec(ctx)
-	if err != nil {
-		return fmt.Errorf("set seat locks: %w", err)
+// ReleaseLocks releases the seat locks an order holds under token. Locks it
+// holds under another token are left alone, so a stale caller cannot free
+// seats the order has since retaken. A token of zero releases whatever the
+// order holds, for callers that never had one such as operator repairs.
+func (r *SeatLockRepo) ReleaseLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error {
+	if _, err := redisops.ReleaseLocks(ctx, r.client, seatLocks(flightID), seatIDs, orderID, token); err != nil {
+		return fmt.Errorf("release seat locks: %w", err)
 	}
 
 	return nil
 }
 
-// ReleaseLocks releases all seat locks for an order
-func (r *SeatLockRepo) ReleaseLocks(ctx context.Context, flightID string, seatIDs []string, orderID string) error {
-	for _, seatID := range seatIDs {
-		key := seatLockKey(flightID, seatID)
-		// Only delete if the lock belongs to this order (using Lua script)
-		script := redis.NewScript(`
-			if redis.call("get", KEYS[1]) == ARGV[1] then
-				return redis.call("del", KEYS[1])
-			else
-				return 0
-			end
-		`)
-		_, err := script.Run(ctx, r.client, []string{key}, orderID).Result()
-		if err != nil && err != redis.Nil {
-			return fmt.Errorf("release seat lock %s: %w", seatID, err)
-		}
+// ExtendLocks extends the TTL of the seat locks an order holds under token;
+// a token of zero extends whatever the order holds
+func (r *SeatLockRepo) ExtendLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64, ttl time.Duration) error {
+	if _, err := redisops.ExtendLocks(ctx, r.client, seatLocks(flightID), seatIDs, orderID, token, ttl); err != nil {
+		return fmt.Errorf("extend seat locks: %w", err)
 	}
 
 	return nil
 }
 
-// ExtendLocks extends the TTL for all seat locks
-func (r *SeatLockRepo) ExtendLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, ttl time.Duration) error {
-	for _, seatID := range seatIDs {
-		key := seatLockKey(flightID, seatID)
-		// Only extend if the lock belongs to this order
-		script := redis.NewScript(`
-			if redis.call("get", KEYS[1]) == ARGV[1] then
-				return redis.call("pexpire", KEYS[1], ARGV[2])
-			else
-				return 0
-			end
-		`)
-		_, err := script.Run(ctx, r.client, []string{key}, orderID, ttl.Milliseconds()).Result()
-		if err != nil && err != redis.Nil {
-			return fmt.Errorf("extend seat lock %s: %w", seatID, err)
-		}
+// VerifyLocks fails with domain.ErrStaleLock when any of the seats is not
+// locked, another order holds one, or the order holds one under a token
+// other than token. A token of zero checks only the owner and passes seats
+// no longer locked, for bookings that never got a token.
+func (r *SeatLockRepo) VerifyLocks(ctx context.Context, flightID string, seatIDs []string, orderID string, token int64) error {
+	holders, err := redisops.LockHolders(ctx, r.client, seatLocks(flightID), seatIDs)
+	if err != nil {
+		return fmt.Errorf("verify seat locks: %w", err)
 	}
 
+	for i, holder := range holders {
+		if holder.Owner == "" {
+			if token != 0 {
+				return fmt.Errorf("seat %s no longer locked by order %s: %w", seatIDs[i], orderID, domain.ErrStaleLock)
+			}
+			continue
+		}
+		if holder.Owner != orderID || (token != 0 && holder.Token != token) {
+			return fmt.Errorf("seat %s locked by order %s under token %d, not order %s under token %d: %w",
+				seatIDs[i], holder.Owner, holder.Token, orderID, token, domain.ErrStaleLock)
+		}
+	}
 	return nil
 }
 
-// GetLockedSeats returns all locked seat IDs for a flight
-func (r *SeatLockRepo) GetLockedSeats(ctx context.Context, flightID string) (map[string]string, error) {
-	pattern := fmt.Sprintf("seat:lock:%s:*", flightID)
-	keys, err := r.client.Keys(ctx, pattern).Result()
+// ContendedLockTTL returns the shortest time left on the locks other orders
+// hold among seatIDs, or zero when no other order holds any of them
+func (r *SeatLockRepo) ContendedLockTTL(ctx context.Context, flightID string, seatIDs []string, orderID string) (time.Duration, error) {
+	holders, err := redisops.LockHolders(ctx, r.client, seatLocks(flightID), seatIDs)
 	if err != nil {
-		return nil, fmt.Errorf("get locked seat keys: %w", err)
+		return 0, fmt.Errorf("get seat lock TTLs: %w", err)
 	}
 
-	if len(keys) == 0 {
-		return make(map[string]string), nil
+	var shortest time.Duration
+	for _, holder := range holders {
+		if holder.Owner == "" || holder.Owner == orderID || holder.TTL <= 0 {
+			continue
+		}
+		if shortest == 0 || holder.TTL < shortest {
+			shortest = holder.TTL
+		}
 	}
+	return shortest, nil
+}
 
-	// Get all values
-	pipe := r.client.Pipeline()
-	cmds := make([]*redis.StringCmd, len(keys))
-	for i, key := range keys {
-		cmds[i] = pipe.Get(ctx, key)
+// GetLockedSeats returns the order holding each locked seat on a flight
+func (r *SeatLockRepo) GetLockedSeats(ctx context.Context, flightID string) (map[string]string, error) {
+	locks, err := r.GetSeatLocks(ctx, flightID)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = pipe.Exec(ctx)
-	if err != nil && err != redis.Nil {
-		return nil, fmt.Errorf("get locked seat values: %w", err)
+	owners := make(map[string]string, len(locks))
+	for seatID, lock := range locks {
+		owners[seatID] = lock.OrderID
 	}
+	return owners, nil
+}
 
-	result := make(map[string]string)
-	for i, cmd := range cmds {
-		if cmd.Err() == nil {
-			// Extract seat ID from key (seat:lock:flightID:seatID)
-			seatID := keys[i][len(fmt.Sprintf("seat:lock:%s:", flightID)):]
-			result[seatID] = cmd.Val()
-		}
+// GetSeatLocks returns every live seat lock on a flight by seat ID, with when
+// its order took it and when it expires
+func (r *SeatLockRepo) GetSeatLocks(ctx context.Context, flightID string) (map[string]domain.SeatLock, error) {
+	holders, err := redisops.ListLocks(ctx, r.client, seatLocks(flightID))
+	if err != nil {
+		return nil, fmt.Errorf("get seat locks: %w", err)
 	}
 
-	return result, nil
+	locks := make(map[string]domain.SeatLock, len(holders))
+	for seatID, holder := range holders {
+		locks[seatID] = domain.SeatLock{OrderID: holder.Owner, AcquiredAt: holder.AcquiredAt, ExpiresAt: holder.ExpiresAt}
+	}
+	return locks, nil
 }
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+)
+
+// Contention benchmark for seat locking. Requires the docker-compose Postgres and
+// Redis (skipped otherwise); run with `make bench-locking`.
+//
+// Every iteration books two random seats on one hot flight end to end: lock in
+// Redis, mark reserved in Postgres, then release. Reported metrics:
+//   conflict%     lock attempts rejected because a seat was held
+//   doublegrant%  locks granted for a seat another order held (caught by Postgres)
+
+// lockStrategy acquires locks on seatIDs for orderID or returns an error on conflict
+type lockStrategy func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error
+
+// releaseStrategy releases the locks on seatIDs a lockStrategy took for orderID
+type releaseStrategy func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error
+
+// pipelineLockKeys are the per-seat keys the pipeline strategy locks
+func pipelineLockKeys(flightID string, seatIDs []string) []string {
+	keys := make([]string, len(seatIDs))
+	for i, seatID := range seatIDs {
+		keys[i] = fmt.Sprintf("seat:lock:%s:%s", flightID, seatID)
+	}
+	return keys
+}
+
+var lockStrategies = []struct {
+	name    string
+	lock    lockStrategy
+	release releaseStrategy
+}{
+	// The check-then-set pipeline LockSeats used before its script, on the
+	// per-seat keys locks had before the per-flight lock set: another order
+	// can take a seat between the check and the set
+	{"pipeline", func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error {
+		keys := pipelineLockKeys(flightID, seatIDs)
+		pipe := locks.client.TxPipeline()
+		for _, key := range keys {
+			pipe.Get(ctx, key)
+		}
+		results, err := pipe.Exec(ctx)
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		for i, result := range results {
+			if owner, err := result.(*redis.StringCmd).Result(); err == nil && owner != orderID {
+				return fmt.Errorf("seat %s already locked", seatIDs[i])
+			}
+		}
+
+		pipe = locks.client.TxPipeline()
+		for _, key := range keys {
+			pipe.Set(ctx, key, orderID, time.Minute)
+		}
+		_, err = pipe.Exec(ctx)
+		return err
+	}, func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error {
+		return locks.client.Del(ctx, pipelineLockKeys(flightID, seatIDs)...).Err()
+	}},
+	{"atomic-lua", func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error {
+		_, err := locks.LockSeats(ctx, flightID, seatIDs, orderID, 0, time.Minute)
+		return err
+	}, func(ctx context.Context, locks *SeatLockRepo, flightID string, seatIDs []string, orderID string) error {
+		return locks.ReleaseLocks(ctx, flightID, seatIDs, orderID, 0)
+	}},
+}
+
+func BenchmarkSeatLocking(b *testing.B) {
+	ctx := context.Background()
+	cfg := config.Load()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.Database)
+	if err != nil {
+		b.Skipf("postgres unavailable: %v", err)
+	}
+	defer pool.Close()
+
+	redisClient, err := database.NewRedisClient(ctx, cfg.Redis)
+	if err != nil {
+		b.Skipf("redis unavailable: %v", err)
+	}
+	defer redisClient.Close()
+
+	flightID, cleanup, err := createHotFlight(ctx, pool)
+	if err != nil {
+		b.Fatalf("create hot flight: %v", err)
+	}
+	defer cleanup()
+
+	flights := NewFlightRepo(pool)
+	locks := NewSeatLockRepo(redisClient)
+
+	for _, strategy := range lockStrategies {
+		for _, concurrency := range []int{1, 8, 32, 128} {
+			b.Run(fmt.Sprintf("%s/concurrency=%d", strategy.name, concurrency), func(b *testing.B) {
+				runContention(b, flights, locks, flightID, strategy.lock, strategy.release, concurrency)
+			})
+		}
+	}
+}
+
+func runContention(b *testing.B, flights *FlightRepo, locks *SeatLockRepo, flightID string, lock lockStrategy, release releaseStrategy, concurrency int) {
+	ctx := context.Background()
+	var conflicts, doubleGrants int64
+
+	b.SetParallelism(concurrency)
+	b.ResetTimer()
+	start := time.Now()
+
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			orderID := uuid.New().String()
+			seats := randomSeatPair(rng)
+
+			if err := lock(ctx, locks, flightID, seats, orderID); err != nil {
+				atomic.AddInt64(&conflicts, 1)
+				continue
+			}
+
+			if err := flights.MarkSeatsReserved(ctx, flightID, seats, orderID, 0); err != nil {
+				atomic.AddInt64(&doubleGrants, 1)
+				_ = release(ctx, locks, flightID, seats, orderID)
+				continue
+			}
+
+			_ = flights.MarkSeatsAvailable(ctx, flightID, seats)
+			_ = release(ctx, locks, flightID, seats, orderID)
+		}
+	})
+
+	elapsed := time.Since(start)
+	b.ReportMetric(float64(b.N)/elapsed.Seconds(), "bookings/s")
+	b.ReportMetric(100*float64(conflicts)/float64(b.N), "conflict%")
+	b.ReportMetric(100*float64(doubleGrants)/float64(b.N), "doublegrant%")
+}
+
+// randomSeatPair picks two adjacent seats from the 20x6 hot flight
+func randomSeatPair(rng *rand.Rand) []string {
+	row := rng.Intn(20) + 1
+	col := rng.Intn(5)
+	return []string{
+		fmt.Sprintf("%d%c", row, 'A'+col),
+		fmt.Sprintf("%d%c", row, 'A'+col+1),
+	}
+}
+
+// createHotFlight inserts a 120-seat flight used only by the benchmark
+func createHotFlight(ctx context.Context, pool *pgxpool.Pool) (string, func(), error) {
+	flightID := uuid.New().String()
+	flightNumber := fmt.Sprintf("BN%04d", rand.Intn(10000))
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO flights (id, flight_number, origin, destination, departure_time, arrival_time,
+		                     total_seats, available_seats, price_cents)
+		VALUES ($1, $2, 'BNC', 'HOT', NOW() + INTERVAL '1 day', NOW() + INTERVAL '1 day 2 hours', 120, 120, 10000)
+	`, flightID, flightNumber)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, err = pool.Exec(ctx, `
+		INSERT INTO seats (id, flight_id, row_num, col, status)
+		SELECT r.row_num || c.col, $1, r.row_num, c.col, 'available'
+		FROM generate_series(1, 20) AS r(row_num)
+		CROSS JOIN (VALUES ('A'), ('B'), ('C'), ('D'), ('E'), ('F')) AS c(col)
+	`, flightID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM flights WHERE id = $1`, flightID)
+	}
+	return flightID, cleanup, nil
+}
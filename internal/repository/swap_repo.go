@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// SwapRepo handles seat swap offer data access
+type SwapRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewSwapRepo creates a new SwapRepo
+func NewSwapRepo(pool *pgxpool.Pool) *SwapRepo {
+	return &SwapRepo{pool: pool}
+}
+
+// swapOfferColumns is the column list scanned by scanSwapOffer
+const swapOfferColumns = `
+	id, flight_id, order_id, seat_id, status, accepted_order_id, accepted_seat_id,
+	failure_reason, created_at, updated_at
+`
+
+// scanSwapOffer scans a row selected with swapOfferColumns
+func scanSwapOffer(row pgx.Row) (*domain.SeatSwapOffer, error) {
+	var o domain.SeatSwapOffer
+	err := row.Scan(
+		&o.ID, &o.FlightID, &o.OrderID, &o.SeatID, &o.Status, &o.AcceptedOrderID,
+		&o.AcceptedSeatID, &o.FailureReason, &o.CreatedAt, &o.UpdatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSwapOfferNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query swap offer: %w", err)
+	}
+
+	return &o, nil
+}
+
+// CreateOffer inserts an open offer. A seat already on offer yields domain.ErrSwapNotAllowed.
+func (r *SwapRepo) CreateOffer(ctx context.Context, flightID, orderID, seatID string) (*domain.SeatSwapOffer, error) {
+	query := `
+		INSERT INTO seat_swap_offers (flight_id, order_id, seat_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + swapOfferColumns
+
+	offer, err := scanSwapOffer(r.pool.QueryRow(ctx, query, flightID, orderID, seatID))
+	if errors.Is(err, domain.ErrSwapOfferNotFound) {
+		return nil, domain.ErrSwapNotAllowed
+	}
+	return offer, err
+}
+
+// FindOffer returns an offer by ID
+func (r *SwapRepo) FindOffer(ctx context.Context, id string) (*domain.SeatSwapOffer, error) {
+	query := `SELECT ` + swapOfferColumns + ` FROM seat_swap_offers WHERE id = $1`
+
+	return scanSwapOffer(r.pool.QueryRow(ctx, query, id))
+}
+
+// ListOpenOffers returns open offers on a flight, oldest first
+func (r *SwapRepo) ListOpenOffers(ctx context.Context, flightID string) ([]domain.SeatSwapOffer, error) {
+	query := `SELECT ` + swapOfferColumns + `
+		FROM seat_swap_offers
+		WHERE flight_id = $1 AND status = 'OPEN'
+		ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query, flightID)
+	if err != nil {
+		return nil, fmt.Errorf("query swap offers: %w", err)
+	}
+	defer rows.Close()
+
+	var offers []domain.SeatSwapOffer
+	for rows.Next() {
+		offer, err := scanSwapOffer(rows)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, *offer)
+	}
+
+	return offers, rows.Err()
+}
+
+// MatchOffer claims an open offer for the accepting order; only one caller can win
+func (r *SwapRepo) MatchOffer(ctx context.Context, id, orderID, seatID string) error {
+	query := `
+		UPDATE seat_swap_offers
+		SET status = 'MATCHED', accepted_order_id = $2, accepted_seat_id = $3, updated_at = NOW()
+		WHERE id = $1 AND status = 'OPEN'
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, orderID, seatID)
+	if err != nil {
+		return fmt.Errorf("match swap offer: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSwapNotAllowed
+	}
+
+	return nil
+}
+
+// CancelOffer withdraws an open offer owned by orderID
+func (r *SwapRepo) CancelOffer(ctx context.Context, id, orderID string) error {
+	query := `
+		UPDATE seat_swap_offers
+		SET status = 'CANCELLED', updated_at = NOW()
+		WHERE id = $1 AND order_id = $2 AND status = 'OPEN'
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, orderID)
+	if err != nil {
+		return fmt.Errorf("cancel swap offer: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrSwapNotAllowed
+	}
+
+	return nil
+}
+
+// FailOffer marks a matched offer as failed with a reason
+func (r *SwapRepo) FailOffer(ctx context.Context, id, reason string) error {
+	query := `
+		UPDATE seat_swap_offers
+		SET status = 'FAILED', failure_reason = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'MATCHED'
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, reason); err != nil {
+		return fmt.Errorf("fail swap offer: %w", err)
+	}
+
+	return nil
+}
+
+// ExecuteSwap exchanges the two seats of a matched offer between their orders,
+// writes an audit record, and completes the offer in one transaction. Guards on
+// order status and seat ownership fail the swap with domain.ErrSwapNotAllowed if
+// either order changed since the offer was matched. Completed offers are a no-op.
+func (r *SwapRepo) ExecuteSwap(ctx context.Context, id string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin seat swap: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	offer, err := scanSwapOffer(tx.QueryRow(ctx,
+		`SELECT `+swapOfferColumns+` FROM seat_swap_offers WHERE id = $1 FOR UPDATE`, id))
+	if err != nil {
+		return err
+	}
+	if offer.Status == domain.SwapOfferCompleted {
+		return nil
+	}
+	if offer.Status != domain.SwapOfferMatched || offer.AcceptedOrderID == nil || offer.AcceptedSeatID == nil {
+		return domain.ErrSwapNotAllowed
+	}
+
+	if err := swapSeats(ctx, tx, offer); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO seat_swap_audit (offer_id, flight_id, offering_order_id, offering_seat_id,
+		                             accepting_order_id, accepting_seat_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, offer.ID, offer.FlightID, offer.OrderID, offer.SeatID, *offer.AcceptedOrderID, *offer.AcceptedSeatID)
+	if err != nil {
+		return fmt.Errorf("insert swap audit: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE seat_swap_offers SET status = 'COMPLETED', updated_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("complete swap offer: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit seat swap: %w", err)
+	}
+
+	return nil
+}
+
+// swapSeats rewrites both orders' seat lists and the seats' owning orders
+func swapSeats(ctx context.Context, tx pgx.Tx, offer *domain.SeatSwapOffer) error {
+	pairs := [][3]string{
+		{offer.OrderID, offer.SeatID, *offer.AcceptedSeatID},
+		{*offer.AcceptedOrderID, *offer.AcceptedSeatID, offer.SeatID},
+	}
+	for _, p := range pairs {
+		result, err := tx.Exec(ctx, `
+			UPDATE orders
+			SET seats = array_replace(seats, $2, $3), version = version + 1, updated_at = NOW()
+			WHERE id = $1 AND status = 'CONFIRMED' AND $2 = ANY(seats)
+		`, p[0], p[1], p[2])
+		if err != nil {
+			return fmt.Errorf("swap order seats: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return domain.ErrSwapNotAllowed
+		}
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE seats
+		SET order_id = CASE id WHEN $2 THEN $5::uuid ELSE $4::uuid END, version = version + 1, updated_at = NOW()
+		WHERE flight_id = $1 AND status = 'booked'
+		  AND ((id = $2 AND order_id = $4) OR (id = $3 AND order_id = $5))
+	`, offer.FlightID, offer.SeatID, *offer.AcceptedSeatID, offer.OrderID, *offer.AcceptedOrderID)
+	if err != nil {
+		return fmt.Errorf("swap seat owners: %w", seatWriteError(err))
+	}
+	if result.RowsAffected() != 2 {
+		return domain.ErrSwapNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// WebhookRepo handles webhook subscriptions and the delivery outbox
+type WebhookRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookRepo creates a new WebhookRepo
+func NewWebhookRepo(pool *pgxpool.Pool) *WebhookRepo {
+	return &WebhookRepo{pool: pool}
+}
+
+// CreateSubscription inserts a subscription and fills in its ID and timestamps
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, active, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, sub.URL, sub.Secret, sub.Events).Scan(&sub.ID, &sub.Active, &sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// ListSubscriptions returns active subscriptions, oldest first
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	query := `
+		SELECT id, url, secret, events, active, created_at
+		FROM webhook_subscriptions
+		WHERE active
+		ORDER BY created_at
+	`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []domain.WebhookSubscription
+	for rows.Next() {
+		var s domain.WebhookSubscription
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &s.Events, &s.Active, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+
+	return subs, rows.Err()
+}
+
+// DeactivateSubscription stops deliveries to a subscription and drops its pending ones
+func (r *WebhookRepo) DeactivateSubscription(ctx context.Context, id string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin webhook deactivation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE webhook_subscriptions SET active = FALSE, updated_at = NOW()
+		WHERE id = $1 AND active
+	`, id)
+	if err != nil {
+		return fmt.Errorf("deactivate webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM webhook_deliveries WHERE subscription_id = $1 AND status = 'PENDING'`, id)
+	if err != nil {
+		return fmt.Errorf("drop pending webhook deliveries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit webhook deactivation: %w", err)
+	}
+
+	return nil
+}
+
+// Enqueue adds one pending delivery per active subscription to event. Repeated
+// calls for the same event and order are ignored, so retried activities are safe.
+func (r *WebhookRepo) Enqueue(ctx context.Context, event domain.WebhookEvent, orderID string, payload []byte) error {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event, order_id, payload)
+		SELECT id, $1, $2, $3 FROM webhook_subscriptions
+		WHERE active AND $1 = ANY(events)
+		ON CONFLICT (subscription_id, event, order_id) DO NOTHING
+	`
+
+	if _, err := r.pool.Exec(ctx, query, event, orderID, payload); err != nil {
+		return fmt.Errorf("enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue leases up to limit due deliveries for lease, counting the attempt.
+// SKIP LOCKED lets several dispatchers share the outbox.
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = NOW() + $2::interval
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, s.url, s.secret, d.event, d.payload, d.attempts
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.URL, &d.Secret, &d.Event, &d.Payload, &d.Attempts); err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// MarkDelivered records a successful delivery
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id int64) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE webhook_deliveries SET status = 'DELIVERED', delivered_at = NOW(), last_error = NULL
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("mark webhook delivered: %w", err)
+	}
+
+	return nil
+}
+
+// MarkAttemptFailed records a failed attempt; a nil retryAt gives up on the delivery
+func (r *WebhookRepo) MarkAttemptFailed(ctx context.Context, id int64, reason string, retryAt *time.Time) error {
+	query := `UPDATE webhook_deliveries SET last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	args := []any{id, reason, retryAt}
+	if retryAt == nil {
+		query = `UPDATE webhook_deliveries SET last_error = $2, status = 'FAILED', failed_at = NOW() WHERE id = $1`
+		args = args[:2]
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("mark webhook attempt failed: %w", err)
+	}
+
+	return nil
+}
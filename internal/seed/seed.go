@@ -0,0 +1,212 @@
+// Package seed generates flights with realistic routes, schedules and seat
+// maps of several aircraft layouts, for populating a development or load test
+// database
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Options controls what Generate produces
+type Options struct {
+	Flights int       // how many flights to generate
+	Start   time.Time // departures start the day after
+	Days    int       // departures spread over this many days
+	Prefix  string    // flight number prefix; numbers follow from 100
+	Seed    int64     // the same seed and options generate the same flights
+}
+
+// Flight is a generated flight with its seat map and the layout it was
+// given. The flight has no ID until it is stored.
+type Flight struct {
+	domain.Flight
+	Layout string
+	Seats  []domain.Seat
+}
+
+// airport is a route endpoint, placed so routes get realistic lengths
+type airport struct {
+	code     string
+	lat, lon float64
+}
+
+var airports = []airport{
+	{"NYC", 40.71, -74.01}, {"LAX", 33.94, -118.41}, {"SFO", 37.62, -122.38},
+	{"CHI", 41.98, -87.90}, {"SEA", 47.45, -122.31}, {"BOS", 42.36, -71.01},
+	{"MIA", 25.80, -80.29}, {"DEN", 39.86, -104.67}, {"ATL", 33.64, -84.43},
+	{"DFW", 32.90, -97.04}, {"YYZ", 43.68, -79.63}, {"MEX", 19.44, -99.07},
+	{"LHR", 51.47, -0.45}, {"CDG", 49.01, 2.55}, {"FRA", 50.04, 8.56},
+	{"NRT", 35.77, 140.39},
+}
+
+// cabin is a block of consecutive rows of one class and column layout
+type cabin struct {
+	class   domain.CabinClass
+	rows    int
+	columns string
+}
+
+// layout is an aircraft seat map, front cabin first
+type layout struct {
+	name   string
+	cabins []cabin
+}
+
+// Layouts by route length: regional jets on short hops, single-aisle
+// aircraft on most routes and twin-aisle ones across oceans
+var (
+	regional = layout{"regional", []cabin{
+		{domain.CabinEconomy, 18, "ABCD"},
+	}}
+	narrowbody = layout{"narrowbody", []cabin{
+		{domain.CabinBusiness, 3, "ACDF"},
+		{domain.CabinEconomy, 25, "ABCDEF"},
+	}}
+	narrowbodyEconomy = layout{"narrowbody-economy", []cabin{
+		{domain.CabinEconomy, 30, "ABCDEF"},
+	}}
+	widebody = layout{"widebody", []cabin{
+		{domain.CabinBusiness, 8, "ADGK"},
+		{domain.CabinPremiumEconomy, 4, "ACDEFHK"},
+		{domain.CabinEconomy, 32, "ABCDEFGHJK"},
+	}}
+)
+
+// layouts are every layout, by name
+var layouts = []layout{regional, narrowbody, narrowbodyEconomy, widebody}
+
+// Layouts returns the names of the aircraft layouts, smallest first
+func Layouts() []string {
+	names := make([]string, len(layouts))
+	for i, l := range layouts {
+		names[i] = l.name
+	}
+	return names
+}
+
+// SeatMap returns the seats of the named layout, all available, and false
+// if there is no such layout
+func SeatMap(name string) ([]domain.Seat, bool) {
+	for _, l := range layouts {
+		if l.name == name {
+			return seatMap(l), true
+		}
+	}
+	return nil, false
+}
+
+// Route lengths, in kilometres, at which larger aircraft take over
+const (
+	regionalMaxKm   = 1000
+	narrowbodyMaxKm = 5000
+)
+
+// Generate returns opts.Flights flights on routes between well-known
+// airports, departing between 06:00 and 22:55 UTC over opts.Days days, with
+// block times and fares that follow the route's length
+func Generate(opts Options) []Flight {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	days := max(opts.Days, 1)
+	firstDay := opts.Start.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+	flights := make([]Flight, opts.Flights)
+	for i := range flights {
+		from := airports[rng.Intn(len(airports))]
+		to := from
+		for to.code == from.code {
+			to = airports[rng.Intn(len(airports))]
+		}
+		km := distanceKm(from, to)
+
+		departure := firstDay.
+			Add(time.Duration(rng.Intn(days)) * 24 * time.Hour).
+			Add(time.Duration(6+rng.Intn(17)) * time.Hour).
+			Add(time.Duration(5*rng.Intn(12)) * time.Minute)
+
+		l := layoutFor(km, rng)
+		seats := seatMap(l)
+		flights[i] = Flight{
+			Flight: domain.Flight{
+				FlightNumber:   fmt.Sprintf("%s%d", opts.Prefix, 100+i),
+				Origin:         from.code,
+				Destination:    to.code,
+				DepartureTime:  departure,
+				ArrivalTime:    departure.Add(blockTime(km)),
+				TotalSeats:     len(seats),
+				AvailableSeats: len(seats),
+				PriceCents:     fare(km, rng),
+				Status:         domain.FlightStatusScheduled,
+			},
+			Layout: l.name,
+			Seats:  seats,
+		}
+	}
+
+	return flights
+}
+
+// layoutFor picks the aircraft flying a route of km kilometres
+func layoutFor(km float64, rng *rand.Rand) layout {
+	switch {
+	case km < regionalMaxKm:
+		return regional
+	case km < narrowbodyMaxKm && rng.Intn(3) == 0:
+		return narrowbodyEconomy
+	case km < narrowbodyMaxKm:
+		return narrowbody
+	default:
+		return widebody
+	}
+}
+
+// seatMap lays out a layout's seats, numbering rows from 1 across cabins
+func seatMap(l layout) []domain.Seat {
+	var seats []domain.Seat
+	row := 1
+	for _, c := range l.cabins {
+		for i := 0; i < c.rows; i++ {
+			for _, col := range c.columns {
+				seats = append(seats, domain.Seat{
+					ID:     fmt.Sprintf("%d%c", row, col),
+					Row:    row,
+					Column: string(col),
+					Class:  c.class,
+					Status: domain.SeatStatusAvailable,
+				})
+			}
+			row++
+		}
+	}
+	return seats
+}
+
+// blockTime is gate-to-gate time at 800 km/h cruise plus 30 minutes of taxi,
+// climb and descent, rounded to 5 minutes
+func blockTime(km float64) time.Duration {
+	d := time.Duration(km/800*float64(time.Hour)) + 30*time.Minute
+	return d.Round(5 * time.Minute)
+}
+
+// fare is a base price plus a distance rate, varied by up to 15% either way
+// and rounded to 5 currency units
+func fare(km float64, rng *rand.Rand) int64 {
+	base := 6000 + km*7.5
+	cents := base * (0.85 + 0.3*rng.Float64())
+	return int64(math.Round(cents/500)) * 500
+}
+
+// distanceKm is the great-circle distance between two airports
+func distanceKm(a, b airport) float64 {
+	const earthRadiusKm = 6371
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(b.lat - a.lat)
+	dLon := rad(b.lon - a.lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.lat))*math.Cos(rad(b.lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
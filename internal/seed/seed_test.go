@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestGenerate(t *testing.T) {
+	start := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	opts := Options{Flights: 50, Start: start, Days: 7, Prefix: "SD", Seed: 42}
+
+	flights := Generate(opts)
+	if len(flights) != opts.Flights {
+		t.Fatalf("generated %d flights, want %d", len(flights), opts.Flights)
+	}
+	if !reflect.DeepEqual(flights, Generate(opts)) {
+		t.Fatal("the same options generated different flights")
+	}
+
+	numbers := map[string]bool{}
+	for _, f := range flights {
+		if numbers[f.FlightNumber] {
+			t.Errorf("flight number %s generated twice", f.FlightNumber)
+		}
+		numbers[f.FlightNumber] = true
+
+		if f.Origin == f.Destination {
+			t.Errorf("%s flies from %s to itself", f.FlightNumber, f.Origin)
+		}
+		if !f.ArrivalTime.After(f.DepartureTime) {
+			t.Errorf("%s arrives at %v, not after departing at %v", f.FlightNumber, f.ArrivalTime, f.DepartureTime)
+		}
+		if f.DepartureTime.Before(start.Add(9*time.Hour)) || !f.DepartureTime.Before(start.Add(8*24*time.Hour)) {
+			t.Errorf("%s departs at %v, outside the %d days after the start", f.FlightNumber, f.DepartureTime, opts.Days)
+		}
+		if f.PriceCents <= 0 {
+			t.Errorf("%s costs %d", f.FlightNumber, f.PriceCents)
+		}
+		if f.TotalSeats != len(f.Seats) || f.AvailableSeats != len(f.Seats) {
+			t.Errorf("%s has %d/%d seats counted for %d seats", f.FlightNumber, f.AvailableSeats, f.TotalSeats, len(f.Seats))
+		}
+
+		ids := map[string]bool{}
+		for _, seat := range f.Seats {
+			if !domain.IsValidSeatID(seat.ID) || ids[seat.ID] {
+				t.Errorf("%s has invalid or repeated seat %q", f.FlightNumber, seat.ID)
+			}
+			ids[seat.ID] = true
+		}
+	}
+}
+
+func TestLayoutFor(t *testing.T) {
+	jfk := airport{"NYC", 40.71, -74.01}
+	tests := []struct {
+		name string
+		to   airport
+		want []string
+	}{
+		{"short hop", airport{"BOS", 42.36, -71.01}, []string{"regional"}},
+		{"domestic", airport{"CHI", 41.98, -87.90}, []string{"narrowbody", "narrowbody-economy"}},
+		{"transatlantic", airport{"LHR", 51.47, -0.45}, []string{"widebody"}},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := layoutFor(distanceKm(jfk, tt.to), rng).name
+			for _, want := range tt.want {
+				if got == want {
+					return
+				}
+			}
+			t.Errorf("got layout %s, want one of %v", got, tt.want)
+		})
+	}
+}
+
+func TestSeatMap(t *testing.T) {
+	for _, name := range Layouts() {
+		seats, ok := SeatMap(name)
+		if !ok || len(seats) == 0 {
+			t.Errorf("layout %s has no seat map", name)
+		}
+	}
+	if _, ok := SeatMap("jumbo"); ok {
+		t.Error("unknown layout has a seat map")
+	}
+}
@@ -2,12 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/flight-booking-system/internal/config"
 	"github.com/flight-booking-system/internal/domain"
 	"github.com/flight-booking-system/internal/repository"
 	temporalpkg "github.com/flight-booking-system/internal/temporal"
@@ -15,28 +17,47 @@ import (
 
 // BookingService handles booking-related business logic
 type BookingService struct {
-	orderRepo      *repository.OrderRepo
-	flightRepo     *repository.FlightRepo
-	temporalClient *TemporalClient
+	orderRepo      repository.OrderRepository
+	flightRepo     repository.FlightRepository
+	seatLockRepo   repository.SeatLocker
+	paymentRepo    *repository.PaymentRepo
+	eventRepo      *repository.OrderEventRepo
+	temporalClient WorkflowClient
+	cfg            *config.BookingConfig
+	statusCache    *statusCache
 }
 
 // NewBookingService creates a new BookingService
 func NewBookingService(
-	orderRepo *repository.OrderRepo,
-	flightRepo *repository.FlightRepo,
-	temporalClient *TemporalClient,
+	orderRepo repository.OrderRepository,
+	flightRepo repository.FlightRepository,
+	seatLockRepo repository.SeatLocker,
+	paymentRepo *repository.PaymentRepo,
+	eventRepo *repository.OrderEventRepo,
+	temporalClient WorkflowClient,
+	cfg *config.BookingConfig,
 ) *BookingService {
 	return &BookingService{
 		orderRepo:      orderRepo,
 		flightRepo:     flightRepo,
+		seatLockRepo:   seatLockRepo,
+		paymentRepo:    paymentRepo,
+		eventRepo:      eventRepo,
 		temporalClient: temporalClient,
+		cfg:            cfg,
+		statusCache:    newStatusCache(cfg.StatusCacheTTL),
 	}
 }
 
 // CreateOrderInput contains the parameters for creating an order
 type CreateOrderInput struct {
-	FlightID string
-	Seats    []string
+	FlightID   string
+	Seats      []string
+	SeatCount  int                // seatless orders set this instead of Seats
+	Passengers []domain.Passenger // optional; when given, exactly one per seat
+
+	// SimulationSeed optionally fixes the order's simulated payment outcomes
+	SimulationSeed *int64
 }
 
 // CreateOrderOutput contains the result of order creation
@@ -49,28 +70,107 @@ type CreateOrderOutput struct {
 
 // CreateOrder creates a new booking order and starts the workflow
 func (s *BookingService) CreateOrder(ctx context.Context, input CreateOrderInput) (*CreateOrderOutput, error) {
+	if err := validatePassengers(input.Passengers, input.Seats, input.SeatCount); err != nil {
+		return nil, err
+	}
+
 	// Validate flight exists
-	_, err := s.flightRepo.FindByID(ctx, input.FlightID)
+	flight, err := s.flightRepo.FindByID(ctx, input.FlightID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate seats are not empty
-	if len(input.Seats) == 0 {
-		return nil, domain.ErrSeatUnavailable
+	return s.startOrder(ctx, flight, input)
+}
+
+// validatePassengers checks passenger details and that passengers map
+// one-to-one onto seats, or number seatCount without seats for a seatless order
+func validatePassengers(passengers []domain.Passenger, seats []string, seatCount int) error {
+	if len(passengers) == 0 {
+		return nil
+	}
+	if seatCount > 0 {
+		if len(passengers) != seatCount {
+			return fmt.Errorf("%w: %d passengers for %d seats", domain.ErrInvalidPassengers, len(passengers), seatCount)
+		}
+		for _, p := range passengers {
+			if p.SeatID != "" {
+				return fmt.Errorf("%w: seatless passengers are seated at check-in", domain.ErrInvalidPassengers)
+			}
+		}
+		seats = nil // the checks below then only cover the details
+	} else if len(passengers) != len(seats) {
+		return fmt.Errorf("%w: %d passengers for %d seats", domain.ErrInvalidPassengers, len(passengers), len(seats))
 	}
 
-	// Generate order ID
-	orderID := uuid.New().String()
+	seated := make(map[string]bool, len(passengers))
+	for _, p := range passengers {
+		switch {
+		case p.Name == "" || len(p.Name) > domain.MaxPassengerNameLength:
+			return fmt.Errorf("%w: passenger name is required", domain.ErrInvalidPassengers)
+		case !domain.IsValidDocumentNumber(p.DocumentNumber):
+			return fmt.Errorf("%w: invalid document number for %s", domain.ErrInvalidPassengers, p.Name)
+		case !domain.IsValidEmail(p.Email):
+			return fmt.Errorf("%w: invalid email for %s", domain.ErrInvalidPassengers, p.Name)
+		case seats != nil && (!slices.Contains(seats, p.SeatID) || seated[p.SeatID]):
+			return fmt.Errorf("%w: seat %q must be one of the order's seats, once", domain.ErrInvalidPassengers, p.SeatID)
+		}
+		seated[p.SeatID] = true
+	}
 
-	// Calculate expiration (15 minutes from now)
-	expiresAt := time.Now().Add(15 * time.Minute)
+	return nil
+}
 
-	// Start the booking workflow
-	temporalInput := temporalpkg.BookingWorkflowInput{
-		OrderID:  orderID,
-		FlightID: input.FlightID,
-		Seats:    input.Seats,
+// BulkOrderResult is the outcome of one seat group in a bulk request
+type BulkOrderResult struct {
+	Seats  []string
+	Output *CreateOrderOutput
+	Err    error
+}
+
+// CreateOrders starts one booking workflow per seat group on the same flight.
+// Groups fail independently; a seat requested by an earlier group fails later ones.
+func (s *BookingService) CreateOrders(ctx context.Context, flightID string, groups [][]string) ([]BulkOrderResult, error) {
+	flight, err := s.flightRepo.FindByID(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make(map[string]bool)
+	results := make([]BulkOrderResult, len(groups))
+	for i, seats := range groups {
+		results[i].Seats = seats
+		if seat, dup := firstClaimed(seats, claimed); dup {
+			results[i].Err = fmt.Errorf("seat %s requested by more than one group: %w", seat, domain.ErrSeatUnavailable)
+			continue
+		}
+		for _, seat := range seats {
+			claimed[seat] = true
+		}
+		results[i].Output, results[i].Err = s.startOrder(ctx, flight, CreateOrderInput{Seats: seats})
+	}
+
+	return results, nil
+}
+
+// firstClaimed returns the first seat already in claimed or repeated within seats
+func firstClaimed(seats []string, claimed map[string]bool) (string, bool) {
+	seen := make(map[string]bool, len(seats))
+	for _, seat := range seats {
+		if claimed[seat] || seen[seat] {
+			return seat, true
+		}
+		seen[seat] = true
+	}
+	return "", false
+}
+
+// startOrder quotes the price for the input's seats, or seat count, on flight
+// and starts the booking workflow; input.FlightID is not used
+func (s *BookingService) startOrder(ctx context.Context, flight *domain.Flight, input CreateOrderInput) (*CreateOrderOutput, error) {
+	temporalInput, err := s.bookingInput(flight, input)
+	if err != nil {
+		return nil, err
 	}
 
 	workflowID, err := s.temporalClient.StartBookingWorkflow(ctx, temporalInput)
@@ -82,17 +182,67 @@ func (s *BookingService) CreateOrder(ctx context.Context, input CreateOrderInput
 	// We return optimistically assuming the workflow will create it
 
 	return &CreateOrderOutput{
-		OrderID:    orderID,
+		OrderID:    temporalInput.OrderID,
 		WorkflowID: workflowID,
 		Status:     domain.OrderStatusSeatsReserved,
-		ExpiresAt:  expiresAt,
+		ExpiresAt:  time.Now().Add(15 * time.Minute),
+	}, nil
+}
+
+// bookingInput assigns a new order ID and locks the price for a booking
+// workflow on flight
+func (s *BookingService) bookingInput(flight *domain.Flight, input CreateOrderInput) (temporalpkg.BookingWorkflowInput, error) {
+	if flight.Status == domain.FlightStatusCancelled {
+		return temporalpkg.BookingWorkflowInput{}, domain.ErrFlightCancelled
+	}
+	if flight.BookingFrozen {
+		return temporalpkg.BookingWorkflowInput{}, domain.ErrFlightFrozen
+	}
+	if !flight.SalesOpen(time.Now(), s.cfg.SalesCloseBefore) {
+		return temporalpkg.BookingWorkflowInput{}, domain.ErrSalesClosed
+	}
+
+	// Validate seats are not empty
+	count := len(input.Seats) + input.SeatCount
+	if count == 0 {
+		return temporalpkg.BookingWorkflowInput{}, domain.ErrSeatUnavailable
+	}
+
+	// Lock the price now; the workflow carries this quote through confirmation
+	price := domain.PriceBreakdown{
+		QuoteID:       uuid.New().String(),
+		UnitFareCents: flight.PriceCents,
+		UnitFeeCents:  s.cfg.BookingFeeCents,
+	}.ForSeats(count)
+
+	return temporalpkg.BookingWorkflowInput{
+		OrderID:  uuid.New().String(),
+		FlightID: flight.ID,
+		Seats:    input.Seats,
+		Price:    price,
+
+		CabinSeats: input.SeatCount,
+
+		Passengers:        input.Passengers,
+		MaxHoldExtensions: s.cfg.MaxHoldExtensions,
+
+		UrgentPaymentWindow: s.cfg.UrgentPaymentWindow,
+		ReminderBefore:      s.cfg.HoldReminderBefore,
+		PaymentWindow:       s.cfg.PaymentWindow,
+		SimulationSeed:      input.SimulationSeed,
+		ConfirmCompensation: temporalpkg.ConfirmCompensation(s.cfg.ConfirmCompensation),
+		Upsell: domain.UpsellPolicy{
+			Window:                s.cfg.UpsellWindow,
+			UpgradeCentsPerCabin:  s.cfg.UpgradeCentsPerCabin,
+			PriorityBoardingCents: s.cfg.PriorityBoardingCents,
+		},
 	}, nil
 }
 
 // GetOrderStatus queries the workflow for current order status
 func (s *BookingService) GetOrderStatus(ctx context.Context, orderID string) (*domain.OrderStatusResponse, error) {
 	// First try to query the workflow
-	status, err := s.temporalClient.QueryBookingStatus(ctx, orderID)
+	status, err := s.queryStatus(ctx, orderID)
 	if err != nil {
 		// If workflow query fails, try to get from database
 		order, dbErr := s.orderRepo.FindByID(ctx, orderID)
@@ -100,6 +250,12 @@ func (s *BookingService) GetOrderStatus(ctx context.Context, orderID string) (*d
 			return nil, domain.ErrOrderNotFound
 		}
 
+		// Passengers are only stored once an order is confirmed
+		passengers, dbErr := s.orderRepo.FindPassengers(ctx, orderID)
+		if dbErr != nil {
+			return nil, dbErr
+		}
+
 		// Return status from database (for completed/failed/expired orders)
 		timerRemaining := 0
 		if order.ExpiresAt != nil {
@@ -116,6 +272,12 @@ func (s *BookingService) GetOrderStatus(ctx context.Context, orderID string) (*d
 			TimerRemaining:  timerRemaining,
 			PaymentAttempts: 0,
 			LastError:       stringValue(order.FailureReason),
+			Price:           order.Price,
+			Passengers:      passengers,
+			Seatless:        order.Seatless,
+			SeatCount:       len(order.Seats) + order.CabinSeats,
+			TripID:          stringValue(order.TripID),
+			Deposit:         order.Deposit,
 		}, nil
 	}
 
@@ -126,73 +288,365 @@ func (s *BookingService) GetOrderStatus(ctx context.Context, orderID string) (*d
 		TimerRemaining:  status.TimerRemaining,
 		PaymentAttempts: status.PaymentAttempts,
 		LastError:       status.LastError,
+		Price:           status.Price,
+		Passengers:      status.Passengers,
+		Seatless:        status.Seatless,
+		SeatCount:       status.SeatCount,
+		TripID:          status.TripID,
+
+		UnavailableSeats: status.UnavailableSeats,
+		AwaitingApproval: status.AwaitingApproval,
+
+		HoldExtensionsLeft: status.HoldExtensionsLeft,
+
+		UpsellOffer:      status.UpsellOffer,
+		PaymentChallenge: status.PaymentChallenge,
+		Deposit:          status.Deposit,
 	}, nil
 }
 
+// GetItinerary returns the receipt for a confirmed order
+func (s *BookingService) GetItinerary(ctx context.Context, orderID string) (*domain.Itinerary, error) {
+	return s.orderRepo.FindItinerary(ctx, orderID)
+}
+
+// ListPayments returns every attempt to take an order's payment, oldest
+// first. A trip's payment is listed under the trip rather than its legs.
+func (s *BookingService) ListPayments(ctx context.Context, orderID string) ([]domain.Payment, error) {
+	if _, err := s.orderRepo.FindByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.paymentRepo.ListByOrder(ctx, orderID)
+}
+
+// ListOrderEvents returns the history of an order, oldest first
+func (s *BookingService) ListOrderEvents(ctx context.Context, orderID string) ([]domain.OrderEvent, error) {
+	if _, err := s.orderRepo.FindByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.eventRepo.ListByOrder(ctx, orderID)
+}
+
+// ListOrders returns one page of the orders matching filter, with how many
+// match in all, as the database records them
+func (s *BookingService) ListOrders(ctx context.Context, filter domain.OrderFilter, page domain.Page) (domain.OrderPage, error) {
+	return s.orderRepo.List(ctx, filter, page)
+}
+
+// GetBoardingPass returns the PNG boarding pass of a confirmed order
+func (s *BookingService) GetBoardingPass(ctx context.Context, orderID string) ([]byte, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusConfirmed {
+		return nil, domain.ErrOrderNotConfirmed
+	}
+
+	return s.orderRepo.FindBoardingPass(ctx, orderID)
+}
+
 // UpdateSeatsOutput contains the result of seat update
 type UpdateSeatsOutput struct {
-	OrderID   string
-	Status    domain.OrderStatus
-	Seats     []string
-	ExpiresAt time.Time
+	OrderID       string
+	Status        domain.OrderStatus
+	Seats         []string
+	ExpiresAt     time.Time
+	Price         domain.PriceBreakdown
+	ReleasedSeats []string // seats given back by a downsize
 }
 
-// UpdateSeats updates the seat selection for an order
+// UpdateSeats updates the seat selection for an order and returns the result
+// of the change. Keeping only some of the current seats releases the rest at
+// once and keeps the hold's expiry; any other selection replaces the seats
+// and resets the timer. Seats that cannot be taken reject the change, as
+// does a flight whose bookings are frozen or whose sales have closed.
 // Note: Allows empty seats array to release all seats and reset timer
 func (s *BookingService) UpdateSeats(ctx context.Context, orderID string, seats []string) (*UpdateSeatsOutput, error) {
-	// Send signal to workflow
-	err := s.temporalClient.SignalUpdateSeats(ctx, orderID, seats)
+	order, err := s.orderRepo.FindByID(ctx, orderID)
 	if err != nil {
-		return nil, fmt.Errorf("signal update seats: %w", err)
+		return nil, err
+	}
+	flight, err := s.flightRepo.FindByID(ctx, order.FlightID)
+	if err != nil {
+		return nil, err
+	}
+	if flight.BookingFrozen {
+		return nil, domain.ErrFlightFrozen
+	}
+	if !flight.SalesOpen(time.Now(), s.cfg.SalesCloseBefore) {
+		return nil, domain.ErrSalesClosed
 	}
 
-	// Query updated status
-	status, err := s.temporalClient.QueryBookingStatus(ctx, orderID)
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SignalApplyTimeout)
+	defer cancel()
+
+	result, err := s.temporalClient.UpdateSeats(ctx, orderID, domain.NormalizeSeats(seats))
+	s.statusCache.invalidate(orderID)
+	if errors.Is(err, domain.ErrSeatUnavailable) {
+		return nil, s.seatConflict(ctx, order.FlightID, seats, orderID, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("query status: %w", err)
+		return nil, err
 	}
 
 	return &UpdateSeatsOutput{
-		OrderID:   status.OrderID,
-		Status:    status.Status,
-		Seats:     status.Seats,
-		ExpiresAt: status.ExpiresAt,
+		OrderID:       result.Status.OrderID,
+		Status:        result.Status.Status,
+		Seats:         result.Status.Seats,
+		ExpiresAt:     result.Status.ExpiresAt,
+		Price:         result.Status.Price,
+		ReleasedSeats: result.ReleasedSeats,
 	}, nil
 }
 
-// SubmitPayment submits a payment for an order
-func (s *BookingService) SubmitPayment(ctx context.Context, orderID string, paymentCode string) error {
-	// Validate payment code format (5 digits)
-	if !isValidPaymentCode(paymentCode) {
-		return domain.ErrInvalidPaymentCode
+// seatConflict is the error for seats an order could not take. It keeps the
+// seat cause names, and when other orders hold some of the seats it carries
+// the time left on the first of their locks to expire, so the client knows
+// when to try again.
+func (s *BookingService) seatConflict(ctx context.Context, flightID string, seats []string, orderID string, cause error) error {
+	conflict := &domain.SeatsUnavailableError{}
+	var unavailable *domain.SeatsUnavailableError
+	if errors.As(cause, &unavailable) {
+		conflict.SeatID = unavailable.SeatID
+	}
+	if ttl, err := s.seatLockRepo.ContendedLockTTL(ctx, flightID, seats, orderID); err == nil {
+		conflict.RetryAfter = ttl
+	}
+	if conflict.SeatID == "" && conflict.RetryAfter == 0 {
+		return domain.ErrSeatUnavailable
+	}
+	return conflict
+}
+
+// ExtendHoldOutput contains the refreshed hold of an order
+type ExtendHoldOutput struct {
+	OrderID        string
+	ExpiresAt      time.Time
+	ExtensionsLeft int
+}
+
+// ExtendHold refreshes an order's seat hold without changing its seats
+func (s *BookingService) ExtendHold(ctx context.Context, orderID string) (*ExtendHoldOutput, error) {
+	status, err := s.queryStatus(ctx, orderID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if !domain.HoldsSeats(status.Status) {
+		return nil, domain.ErrHoldNotExtendable
+	}
+	if status.HoldExtensionsLeft <= 0 {
+		return nil, domain.ErrHoldExtensionLimit
+	}
+
+	err = s.temporalClient.SignalExtendHold(ctx, orderID)
+	s.statusCache.invalidate(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("signal extend hold: %w", err)
+	}
+
+	status, err = s.awaitSignal(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendHoldOutput{
+		OrderID:        status.OrderID,
+		ExpiresAt:      status.ExpiresAt,
+		ExtensionsLeft: status.HoldExtensionsLeft,
+	}, nil
+}
+
+// SubmitPayment submits a payment for an order and returns the order status
+// once the workflow has taken the payment. An empty method pays by card. With
+// deposit set only the configured deposit is taken and the balance is
+// charged ahead of departure, through the same method.
+func (s *BookingService) SubmitPayment(ctx context.Context, orderID string, method domain.PaymentMethod, paymentCode string, deposit bool) (domain.OrderStatus, error) {
+	if err := checkPayment(method, paymentCode); err != nil {
+		return "", err
+	}
+
+	// Trip legs are paid together through their trip, and a partial group
+	// booking only once its customer has accepted the seats it holds
+	if status, err := s.queryStatus(ctx, orderID); err == nil {
+		switch {
+		case status.TripID != "":
+			return "", domain.ErrTripLeg
+		case status.AwaitingApproval:
+			return "", domain.ErrPartialNotApproved
+		}
+	}
+
+	var terms *domain.DepositTerms
+	if deposit {
+		t, err := s.depositTerms(ctx, orderID)
+		if err != nil {
+			return "", err
+		}
+		terms = &t
 	}
 
 	// Send payment signal to workflow
-	err := s.temporalClient.SignalProceedToPayment(ctx, orderID, paymentCode)
+	err := s.temporalClient.SignalProceedToPayment(ctx, orderID, method, paymentCode, terms)
+	s.statusCache.invalidate(orderID)
 	if err != nil {
-		return fmt.Errorf("signal payment: %w", err)
+		return "", fmt.Errorf("signal payment: %w", err)
 	}
 
+	status, err := s.awaitSignal(ctx, orderID)
+	if err != nil {
+		return "", err
+	}
+
+	return status.Status, nil
+}
+
+// checkPayment rejects a payment method the gateway does not take, or a code
+// of the wrong shape for its method
+func checkPayment(method domain.PaymentMethod, paymentCode string) error {
+	if !method.Valid() {
+		return domain.ErrInvalidPaymentMethod
+	}
+	if !method.ValidCode(paymentCode) {
+		return domain.ErrInvalidPaymentCode
+	}
 	return nil
 }
 
+// depositTerms returns the deposit an order may pay under the configured
+// policy, given its flight's departure
+func (s *BookingService) depositTerms(ctx context.Context, orderID string) (domain.DepositTerms, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return domain.DepositTerms{}, domain.ErrOrderNotFound
+	}
+	flight, err := s.flightRepo.FindByID(ctx, order.FlightID)
+	if err != nil {
+		return domain.DepositTerms{}, fmt.Errorf("load flight for deposit: %w", err)
+	}
+
+	policy := domain.DepositPolicy{Percent: s.cfg.DepositPercent, BalanceDueBefore: s.cfg.DepositBalanceDueBefore}
+	terms, ok := policy.Terms(flight.DepartureTime, time.Now())
+	if !ok {
+		return domain.DepositTerms{}, domain.ErrDepositUnavailable
+	}
+	return terms, nil
+}
+
+// RespondUpsell answers the upgrade and priority boarding offer a booking
+// makes once payment is submitted, and returns the order as it will be
+// charged
+func (s *BookingService) RespondUpsell(ctx context.Context, orderID string, response domain.UpsellResponse) (*domain.OrderStatusResponse, error) {
+	status, err := s.temporalClient.QueryBookingStatus(ctx, orderID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if status.UpsellOffer == nil {
+		return nil, domain.ErrNoUpsellOffer
+	}
+
+	err = s.temporalClient.SignalRespondUpsell(ctx, orderID, response)
+	s.statusCache.invalidate(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("signal upsell response: %w", err)
+	}
+	if _, err := s.awaitSignal(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderStatus(ctx, orderID)
+}
+
+// RespondPaymentChallenge answers the payment challenge open on an order
+// with the one-time code the customer was sent. The code is verified while
+// the order stays in payment processing; a wrong one leaves the challenge
+// open with one attempt less.
+func (s *BookingService) RespondPaymentChallenge(ctx context.Context, orderID string, code string) (domain.OrderStatus, error) {
+	if !domain.IsValidChallengeCode(code) {
+		return "", domain.ErrInvalidChallengeCode
+	}
+
+	status, err := s.temporalClient.QueryBookingStatus(ctx, orderID)
+	if err != nil {
+		return "", domain.ErrOrderNotFound
+	}
+	if status.PaymentChallenge == nil {
+		return "", domain.ErrNoPaymentChallenge
+	}
+
+	err = s.temporalClient.SignalChallengeResponse(ctx, orderID, code)
+	s.statusCache.invalidate(orderID)
+	if err != nil {
+		return "", fmt.Errorf("signal challenge response: %w", err)
+	}
+
+	return status.Status, nil
+}
+
 // CancelOrder cancels an order
 func (s *BookingService) CancelOrder(ctx context.Context, orderID string) error {
 	err := s.temporalClient.SignalCancelBooking(ctx, orderID)
+	s.statusCache.invalidate(orderID)
 	if err != nil {
 		return fmt.Errorf("signal cancel: %w", err)
 	}
 
-	return nil
+	_, err = s.awaitSignal(ctx, orderID)
+	return err
 }
 
-// Helper functions
+// queryStatus queries the workflow for an order's status, reusing a result
+// up to StatusCacheTTL old
+func (s *BookingService) queryStatus(ctx context.Context, orderID string) (*temporalpkg.BookingStatusResponse, error) {
+	return s.statusCache.query(ctx, orderID, func(ctx context.Context) (*temporalpkg.BookingStatusResponse, error) {
+		return s.temporalClient.QueryBookingStatus(ctx, orderID)
+	})
+}
+
+// awaitSignal returns the order status once the workflow has applied the
+// signal the caller just sent, so the caller never reads its pre-signal state
+func (s *BookingService) awaitSignal(ctx context.Context, orderID string) (*temporalpkg.BookingStatusResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SignalApplyTimeout)
+	defer cancel()
 
-func isValidPaymentCode(code string) bool {
-	matched, _ := regexp.MatchString(`^\d{5}$`, code)
-	return matched
+	return awaitApplied(ctx, func(ctx context.Context) (*temporalpkg.BookingStatusResponse, error) {
+		return s.temporalClient.QueryBookingStatus(ctx, orderID)
+	})
 }
 
+// Polling bounds while waiting for a signal to be applied
+const (
+	minApplyPoll = 25 * time.Millisecond
+	maxApplyPoll = 500 * time.Millisecond
+)
+
+// awaitApplied polls query until every signal pending at the first query has
+// been applied. A signal is in the workflow's history once SignalWorkflow
+// returns, so the first query already counts the caller's own signal.
+func awaitApplied(ctx context.Context, query func(context.Context) (*temporalpkg.BookingStatusResponse, error)) (*temporalpkg.BookingStatusResponse, error) {
+	status, err := query(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query status: %w", err)
+	}
+
+	target := status.Version + status.PendingSignals
+	for delay := minApplyPoll; status.Version < target && status.PendingSignals > 0; delay = min(2*delay, maxApplyPoll) {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", domain.ErrUpdatePending, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		if status, err = query(ctx); err != nil {
+			return nil, fmt.Errorf("query status: %w", err)
+		}
+	}
+
+	return status, nil
+}
+
+// Helper functions
+
 func stringValue(s *string) string {
 	if s == nil {
 		return ""
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/mocks"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// scriptedStatus returns the given statuses in order, repeating the last one
+func scriptedStatus(statuses ...temporalpkg.BookingStatusResponse) (func(context.Context) (*temporalpkg.BookingStatusResponse, error), *int) {
+	calls := 0
+	return func(context.Context) (*temporalpkg.BookingStatusResponse, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return &status, nil
+	}, &calls
+}
+
+func TestAwaitApplied(t *testing.T) {
+	t.Run("waits for pending signals", func(t *testing.T) {
+		query, calls := scriptedStatus(
+			temporalpkg.BookingStatusResponse{Version: 2, PendingSignals: 2, Seats: []string{"1A"}},
+			temporalpkg.BookingStatusResponse{Version: 3, PendingSignals: 1, Seats: []string{"1B"}},
+			temporalpkg.BookingStatusResponse{Version: 4, PendingSignals: 0, Seats: []string{"1C"}},
+		)
+		status, err := awaitApplied(context.Background(), query)
+		if err != nil {
+			t.Fatalf("awaitApplied: %v", err)
+		}
+		if status.Version != 4 || status.Seats[0] != "1C" || *calls != 3 {
+			t.Errorf("got version %d seats %v after %d queries", status.Version, status.Seats, *calls)
+		}
+	})
+
+	t.Run("later signals do not extend the wait", func(t *testing.T) {
+		query, calls := scriptedStatus(
+			temporalpkg.BookingStatusResponse{Version: 0, PendingSignals: 1},
+			temporalpkg.BookingStatusResponse{Version: 1, PendingSignals: 1},
+		)
+		status, err := awaitApplied(context.Background(), query)
+		if err != nil {
+			t.Fatalf("awaitApplied: %v", err)
+		}
+		if status.Version != 1 || *calls != 2 {
+			t.Errorf("got version %d after %d queries", status.Version, *calls)
+		}
+	})
+
+	t.Run("stops when the hold phase ends", func(t *testing.T) {
+		query, calls := scriptedStatus(
+			temporalpkg.BookingStatusResponse{Version: 0, PendingSignals: 0, Status: domain.OrderStatusPaymentProcessing},
+		)
+		if _, err := awaitApplied(context.Background(), query); err != nil {
+			t.Fatalf("awaitApplied: %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("queried %d times, want 1", *calls)
+		}
+	})
+
+	t.Run("times out as update pending", func(t *testing.T) {
+		query, _ := scriptedStatus(temporalpkg.BookingStatusResponse{Version: 0, PendingSignals: 1})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		if _, err := awaitApplied(ctx, query); !errors.Is(err, domain.ErrUpdatePending) {
+			t.Errorf("err = %v, want ErrUpdatePending", err)
+		}
+	})
+}
+
+func TestCancelOrder_SignalFailureSkipsWait(t *testing.T) {
+	workflows := mocks.NewWorkflowClient(t)
+	workflows.On("SignalCancelBooking", mock.Anything, "o1").Return(errors.New("workflow not found"))
+
+	svc := NewBookingService(mocks.NewOrderRepository(t), mocks.NewFlightRepository(t), mocks.NewSeatLocker(t),
+		nil, nil, workflows, &config.BookingConfig{SignalApplyTimeout: time.Second})
+	err := svc.CancelOrder(context.Background(), "o1")
+	if err == nil || err.Error() != "signal cancel: workflow not found" {
+		t.Errorf("got err=%v", err)
+	}
+
+	// Without the signal there is nothing to wait for
+	workflows.AssertNotCalled(t, "QueryBookingStatus", mock.Anything, mock.Anything)
+}
+
+func TestPlanRefund_ChangesNothing(t *testing.T) {
+	orders := mocks.NewOrderRepository(t)
+	orders.On("FindByID", mock.Anything, "o1").Return(&domain.Order{
+		ID: "o1", Status: domain.OrderStatusConfirmed, Seats: []string{"4A", "4B"}, Version: 3,
+	}, nil)
+	orders.On("FindByID", mock.Anything, "o2").Return(&domain.Order{ID: "o2", Status: domain.OrderStatusFailed}, nil)
+	workflows := mocks.NewWorkflowClient(t)
+
+	svc := NewBookingService(orders, mocks.NewFlightRepository(t), mocks.NewSeatLocker(t),
+		nil, nil, workflows, &config.BookingConfig{SignalApplyTimeout: time.Second})
+
+	plan, err := svc.PlanRefund(context.Background(), "o1", nil)
+	if err != nil {
+		t.Fatalf("PlanRefund: %v", err)
+	}
+	if !plan.DryRun || len(plan.Seats) != 2 || plan.Orders[0] != "o1" || plan.Workflows[0] != "refund-o1" {
+		t.Errorf("plan = %+v", plan)
+	}
+
+	stale := 2
+	if _, err := svc.PlanRefund(context.Background(), "o1", &stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("stale version: err = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if _, err := svc.PlanRefund(context.Background(), "o2", nil); !errors.Is(err, domain.ErrOrderNotRefundable) {
+		t.Errorf("failed order: err = %v, want %v", err, domain.ErrOrderNotRefundable)
+	}
+
+	// Planning never starts the refund
+	workflows.AssertNotCalled(t, "RunRefundWorkflow", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// checkInAttempts bounds how often check-in re-picks seats lost to a concurrent booking
+const checkInAttempts = 3
+
+// CheckIn checks a confirmed order in through a CheckInWorkflow once check-in
+// has opened, domain.CheckInOpensBefore departure, and returns the itinerary
+// with the boarding pass issued. seats optionally moves the order to other
+// seats in the same cabins; a seatless order without seats is seated
+// automatically. Repeating a check-in is safe.
+func (s *BookingService) CheckIn(ctx context.Context, orderID string, seats []string) (*domain.Itinerary, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusConfirmed {
+		return nil, domain.ErrOrderNotConfirmed
+	}
+
+	flight, err := s.flightRepo.FindByID(ctx, order.FlightID)
+	if err != nil {
+		return nil, err
+	}
+	if flight.Status == domain.FlightStatusCancelled {
+		return nil, domain.ErrFlightCancelled
+	}
+	if err := domain.CheckInWindow(flight.DepartureTime, time.Now()); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		picked, err := s.checkInSeats(ctx, order, seats)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.runCheckIn(ctx, temporalpkg.CheckInWorkflowInput{OrderID: orderID, Seats: picked})
+		if errors.Is(err, domain.ErrSeatUnavailable) && len(seats) == 0 && attempt < checkInAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	return s.orderRepo.FindItinerary(ctx, orderID)
+}
+
+// checkInSeats returns the seats the order checks in with, or nil to keep
+// its current ones. Requested seats must be free or already the order's,
+// and keep every passenger in the cabin they bought.
+func (s *BookingService) checkInSeats(ctx context.Context, order *domain.Order, seats []string) ([]string, error) {
+	if len(seats) == 0 && order.CabinSeats == 0 {
+		return nil, nil
+	}
+
+	flightSeats, err := s.flightRepo.FindSeats(ctx, order.FlightID)
+	if err != nil {
+		return nil, err
+	}
+	classes := make(map[string]domain.CabinClass, len(flightSeats))
+	for _, seat := range flightSeats {
+		classes[seat.ID] = seat.Class
+	}
+
+	// Seatless capacity is seated in its cabin, picked for the party when not chosen
+	current := order.Seats
+	if order.CabinSeats > 0 {
+		current = make([]string, order.CabinSeats)
+		classes[""] = domain.SeatlessCabin
+	}
+	if len(seats) == 0 {
+		cabin := slices.DeleteFunc(slices.Clone(flightSeats), func(seat domain.Seat) bool {
+			return seat.Class != domain.SeatlessCabin
+		})
+		if seats = AutoAssignSeats(cabin, order.CabinSeats); seats == nil {
+			return nil, fmt.Errorf("check in order %s: %w", order.ID, domain.ErrInsufficientSeats)
+		}
+	}
+
+	for _, seatID := range seats {
+		if _, ok := classes[seatID]; !ok || seatID == "" {
+			return nil, fmt.Errorf("seat %s: %w", seatID, domain.ErrSeatNotFound)
+		}
+	}
+	if !domain.SameCabins(classes, current, seats) {
+		return nil, domain.ErrCabinMismatch
+	}
+	for _, seat := range flightSeats {
+		if slices.Contains(seats, seat.ID) && seat.Status != domain.SeatStatusAvailable && !slices.Contains(order.Seats, seat.ID) {
+			return nil, fmt.Errorf("seat %s: %w", seat.ID, domain.ErrSeatUnavailable)
+		}
+	}
+
+	return seats, nil
+}
+
+// runCheckIn runs the check-in workflow, giving up waiting after
+// SignalApplyTimeout; the check-in still completes and shows on the itinerary
+func (s *BookingService) runCheckIn(ctx context.Context, input temporalpkg.CheckInWorkflowInput) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SignalApplyTimeout)
+	defer cancel()
+
+	err := s.temporalClient.RunCheckInWorkflow(ctx, input)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %v", domain.ErrUpdatePending, ctx.Err())
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// ConsistencyService checks that flight seat counts, seat rows, orders and
+// Redis seat locks agree, and repairs what can be repaired safely. It is the
+// operator's on-demand counterpart to the seat reconciliation workflow.
+type ConsistencyService struct {
+	flightRepo   repository.FlightRepository
+	orderRepo    repository.OrderRepository
+	seatLockRepo repository.SeatLocker
+}
+
+// NewConsistencyService creates a new ConsistencyService
+func NewConsistencyService(flightRepo repository.FlightRepository, orderRepo repository.OrderRepository, seatLockRepo repository.SeatLocker) *ConsistencyService {
+	return &ConsistencyService{
+		flightRepo:   flightRepo,
+		orderRepo:    orderRepo,
+		seatLockRepo: seatLockRepo,
+	}
+}
+
+// Verify checks one flight, or every flight when flightID is empty, and
+// returns the discrepancies found. With fix it also repairs the fixable ones
+// and marks them Fixed.
+func (s *ConsistencyService) Verify(ctx context.Context, flightID string, fix bool) ([]domain.Discrepancy, error) {
+	flightIDs := []string{flightID}
+	if flightID == "" {
+		ids, err := s.flightRepo.GetAllFlightIDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		flightIDs = ids
+	}
+
+	var found []domain.Discrepancy
+	var recount []string
+	for _, id := range flightIDs {
+		state, err := s.flightState(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		discrepancies := state.CheckConsistency()
+		if fix {
+			needsRecount, err := s.repair(ctx, state, discrepancies)
+			if err != nil {
+				return nil, err
+			}
+			if needsRecount {
+				recount = append(recount, id)
+			}
+		}
+		found = append(found, discrepancies...)
+	}
+
+	if len(recount) == 0 {
+		return found, nil
+	}
+	// Every flight's seats are repaired before the counts they change are
+	// recomputed, all in one batch
+	if err := s.flightRepo.RecomputeSeatCounts(ctx, recount); err != nil {
+		return nil, fmt.Errorf("recompute seat counts: %w", err)
+	}
+	for i, d := range found {
+		if d.Kind == domain.DiscrepancyAvailableSeats && slices.Contains(recount, d.FlightID) {
+			found[i].Fixed = true
+		}
+	}
+
+	return found, nil
+}
+
+// flightState reads what the checks compare. Orders are read last so that
+// any order a seat or lock names was already created when they were read.
+func (s *ConsistencyService) flightState(ctx context.Context, flightID string) (domain.FlightState, error) {
+	flight, err := s.flightRepo.FindByID(ctx, flightID)
+	if err != nil {
+		return domain.FlightState{}, err
+	}
+
+	seats, err := s.flightRepo.FindSeats(ctx, flightID)
+	if err != nil {
+		return domain.FlightState{}, err
+	}
+
+	locks, err := s.seatLockRepo.GetLockedSeats(ctx, flightID)
+	if err != nil {
+		return domain.FlightState{}, err
+	}
+
+	orders, err := s.orderRepo.FindByFlight(ctx, flightID)
+	if err != nil {
+		return domain.FlightState{}, err
+	}
+	byID := make(map[string]*domain.Order, len(orders))
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+
+	return domain.FlightState{Flight: *flight, Seats: seats, Locks: locks, Orders: byID}, nil
+}
+
+// repair fixes the fixable seat and lock discrepancies of a flight in place
+// and reports whether its seat counts need recomputing, which Verify does
+// once every flight's seats are repaired. Seats are released in one batch,
+// each only at the version it was inspected at, so a seat written since is
+// skipped rather than undone.
+func (s *ConsistencyService) repair(ctx context.Context, state domain.FlightState, discrepancies []domain.Discrepancy) (bool, error) {
+	flightID := state.Flight.ID
+	inspected := make(map[string]domain.Seat, len(state.Seats))
+	for _, seat := range state.Seats {
+		inspected[seat.ID] = seat
+	}
+
+	recount := false
+	var stale []domain.Seat
+	for i, d := range discrepancies {
+		if !d.Fixable {
+			continue
+		}
+
+		switch {
+		case d.Kind == domain.DiscrepancyAvailableSeats:
+			recount = true
+		case releasesSeat(d.Kind):
+			if seat, ok := inspected[d.SeatID]; ok {
+				stale = append(stale, seat)
+			}
+		case d.Kind == domain.DiscrepancyOrphanLock:
+			if err := s.seatLockRepo.ReleaseLocks(ctx, flightID, []string{d.SeatID}, d.OrderID, 0); err != nil {
+				return false, fmt.Errorf("release lock on seat %s of flight %s: %w", d.SeatID, flightID, err)
+			}
+			discrepancies[i].Fixed = true
+		}
+	}
+	if len(stale) == 0 {
+		return recount, nil
+	}
+
+	released, err := s.flightRepo.ReleaseInspectedSeats(ctx, stale)
+	if err != nil {
+		return false, fmt.Errorf("release seats on flight %s: %w", flightID, err)
+	}
+	for i, d := range discrepancies {
+		if !d.Fixable || !releasesSeat(d.Kind) || !slices.Contains(released, d.SeatID) {
+			continue
+		}
+		if d.OrderID != "" && state.Locks[d.SeatID] == d.OrderID {
+			if err := s.seatLockRepo.ReleaseLocks(ctx, flightID, []string{d.SeatID}, d.OrderID, 0); err != nil {
+				return false, fmt.Errorf("release lock on seat %s of flight %s: %w", d.SeatID, flightID, err)
+			}
+		}
+		discrepancies[i].Fixed = true
+	}
+
+	return recount || len(released) > 0, nil
+}
+
+// releasesSeat reports whether a discrepancy of kind is repaired by freeing
+// its seat
+func releasesSeat(kind domain.DiscrepancyKind) bool {
+	return kind == domain.DiscrepancySeatNoOrder || kind == domain.DiscrepancySeatDeadOrder ||
+		kind == domain.DiscrepancyStaleOrderID
+}
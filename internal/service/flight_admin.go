@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// ReleaseLocks force-releases Redis seat locks on a flight, limited to seatIDs
+// when given. With dryRun it only reports the seats, orders, and booking
+// workflows that would lose their holds.
+func (s *FlightService) ReleaseLocks(ctx context.Context, flightID string, seatIDs []string, dryRun bool) (*domain.AdminPlan, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	locked, err := s.seatLockRepo.GetLockedSeats(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &domain.AdminPlan{Action: "release-locks", DryRun: dryRun}
+	byOrder := make(map[string][]string)
+	for seatID, orderID := range locked {
+		if len(seatIDs) > 0 && !slices.Contains(seatIDs, seatID) {
+			continue
+		}
+		plan.Seats = append(plan.Seats, seatID)
+		byOrder[orderID] = append(byOrder[orderID], seatID)
+	}
+	for orderID := range byOrder {
+		plan.Orders = append(plan.Orders, orderID)
+	}
+	slices.Sort(plan.Seats)
+	slices.Sort(plan.Orders)
+	for _, orderID := range plan.Orders {
+		plan.Workflows = append(plan.Workflows, fmt.Sprintf("booking-%s", orderID))
+	}
+
+	if dryRun {
+		return plan, nil
+	}
+
+	for orderID, seats := range byOrder {
+		if err := s.seatLockRepo.ReleaseLocks(ctx, flightID, seats, orderID, 0); err != nil {
+			return nil, fmt.Errorf("release locks for order %s: %w", orderID, err)
+		}
+	}
+
+	return plan, nil
+}
+
+// SetBookingFrozen freezes or unfreezes bookings on a flight. While frozen,
+// new orders and seat changes on it are refused; holds and payments already
+// in progress carry on.
+func (s *FlightService) SetBookingFrozen(ctx context.Context, flightID string, frozen bool) (*domain.Flight, error) {
+	if err := s.flightRepo.SetBookingFrozen(ctx, flightID, frozen); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindByID(ctx, flightID)
+}
+
+// SetOverbooking sets how far beyond its seat map a flight sells seatless
+// capacity, from 0 to domain.MaxOverbookingPercent. Lowering it below what
+// is already sold fails with domain.ErrInsufficientSeats.
+func (s *FlightService) SetOverbooking(ctx context.Context, flightID string, percent int) (*domain.Flight, error) {
+	if percent < 0 || percent > domain.MaxOverbookingPercent {
+		return nil, domain.ErrInvalidOverbooking
+	}
+	if err := s.flightRepo.SetOverbookingPercent(ctx, flightID, percent); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindByID(ctx, flightID)
+}
+
+// Bumps returns the orders the departure workflow bumped off the flight
+func (s *FlightService) Bumps(ctx context.Context, flightID string) ([]domain.Bump, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindBumps(ctx, flightID)
+}
+
+// PriceHistory returns the fare changes dynamic pricing made to the flight
+func (s *FlightService) PriceHistory(ctx context.Context, flightID string) ([]domain.PriceChange, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindPriceHistory(ctx, flightID)
+}
+
+// Manifest returns the passenger and seat manifest saved when the flight
+// departed
+func (s *FlightService) Manifest(ctx context.Context, flightID string) (*domain.Manifest, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindManifest(ctx, flightID)
+}
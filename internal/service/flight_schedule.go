@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// ImportSchedule adds flights with their seat maps, each in its own
+// transaction, and reports which were added. A flight whose number is taken
+// is skipped, so importing a file again adds only what is new. With dryRun
+// nothing is stored and the report says what would be added.
+func (s *FlightService) ImportSchedule(ctx context.Context, flights []domain.ScheduledFlight, dryRun bool) (*domain.ScheduleImport, error) {
+	result := &domain.ScheduleImport{Created: []string{}, Skipped: []string{}}
+
+	if dryRun {
+		numbers := make([]string, len(flights))
+		for i, f := range flights {
+			numbers[i] = f.Flight.FlightNumber
+		}
+		taken, err := s.flightRepo.FindTakenNumbers(ctx, numbers)
+		if err != nil {
+			return nil, err
+		}
+		for _, number := range numbers {
+			if slices.Contains(taken, number) {
+				result.Skipped = append(result.Skipped, number)
+			} else {
+				result.Created = append(result.Created, number)
+			}
+		}
+		return result, nil
+	}
+
+	for _, f := range flights {
+		flight := f.Flight
+		created, err := s.flightRepo.Create(ctx, &flight, f.Seats)
+		if err != nil {
+			return result, err
+		}
+		if created {
+			result.Created = append(result.Created, flight.FlightNumber)
+		} else {
+			result.Skipped = append(result.Skipped, flight.FlightNumber)
+		}
+	}
+
+	return result, nil
+}
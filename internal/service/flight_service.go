@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/flight-booking-system/internal/domain"
 	"github.com/flight-booking-system/internal/repository"
@@ -9,12 +11,12 @@ import (
 
 // FlightService handles flight-related business logic
 type FlightService struct {
-	flightRepo   *repository.FlightRepo
-	seatLockRepo *repository.SeatLockRepo
+	flightRepo   repository.FlightRepository
+	seatLockRepo repository.SeatLocker
 }
 
 // NewFlightService creates a new FlightService
-func NewFlightService(flightRepo *repository.FlightRepo, seatLockRepo *repository.SeatLockRepo) *FlightService {
+func NewFlightService(flightRepo repository.FlightRepository, seatLockRepo repository.SeatLocker) *FlightService {
 	return &FlightService{
 		flightRepo:   flightRepo,
 		seatLockRepo: seatLockRepo,
@@ -41,43 +43,186 @@ func (s *FlightService) GetFlightWithSeats(ctx context.Context, flightID string)
 	}
 
 	// Get currently locked seats from Redis
-	lockedSeats, err := s.seatLockRepo.GetLockedSeats(ctx, flightID)
+	seatLocks, err := s.seatLockRepo.GetSeatLocks(ctx, flightID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update seat status based on locks
+	// Update seat status based on locks, showing when reserved seats free up
 	for i := range seats {
-		if _, isLocked := lockedSeats[seats[i].ID]; isLocked {
-			if seats[i].Status == domain.SeatStatusAvailable {
-				seats[i].Status = domain.SeatStatusReserved
-			}
+		lock, isLocked := seatLocks[seats[i].ID]
+		if !isLocked {
+			continue
 		}
-	}
-
-	// Calculate seat map dimensions
-	rows := 0
-	seatsPerRow := 0
-	if len(seats) > 0 {
-		rowMap := make(map[int]int)
-		for _, seat := range seats {
-			rowMap[seat.Row]++
-			if seat.Row > rows {
-				rows = seat.Row
-			}
+		if seats[i].Status == domain.SeatStatusAvailable {
+			seats[i].Status = domain.SeatStatusReserved
 		}
-		// Get seats per row from first row
-		if count, ok := rowMap[1]; ok {
-			seatsPerRow = count
+		if seats[i].Status == domain.SeatStatusReserved {
+			heldUntil := lock.HoldEndsAt()
+			seats[i].HeldUntil = &heldUntil
 		}
 	}
 
 	return &domain.FlightWithSeats{
 		Flight: *flight,
 		SeatMap: domain.SeatMap{
-			Rows:        rows,
-			SeatsPerRow: seatsPerRow,
-			Seats:       seats,
+			Rows:  domain.SeatRows(seats),
+			Seats: seats,
 		},
 	}, nil
 }
+
+// UpdateSeatMap applies an admin seat map change. Seats that are reserved,
+// booked, or currently held in Redis cannot be removed or blocked.
+func (s *FlightService) UpdateSeatMap(ctx context.Context, flightID string, change domain.SeatMapChange) (*domain.Flight, error) {
+	if _, err := s.PlanSeatMapChange(ctx, flightID, change); err != nil {
+		return nil, err
+	}
+
+	if err := s.flightRepo.UpdateSeatMap(ctx, flightID, change); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindByID(ctx, flightID)
+}
+
+// PlanSeatMapChange validates a seat map change against live seat state and
+// returns the seats it would touch without applying it
+func (s *FlightService) PlanSeatMapChange(ctx context.Context, flightID string, change domain.SeatMapChange) (*domain.AdminPlan, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	seats, err := s.flightRepo.FindSeats(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	lockedSeats, err := s.seatLockRepo.GetLockedSeats(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]domain.SeatStatus, len(seats))
+	for _, seat := range seats {
+		statuses[seat.ID] = seat.Status
+		if _, locked := lockedSeats[seat.ID]; locked && seat.Status == domain.SeatStatusAvailable {
+			statuses[seat.ID] = domain.SeatStatusReserved
+		}
+	}
+
+	if err := validateSeatMapChange(change, statuses); err != nil {
+		return nil, err
+	}
+
+	plan := &domain.AdminPlan{Action: "update-seat-map", DryRun: true}
+	for _, seat := range change.Add {
+		plan.Seats = append(plan.Seats, seat.ID)
+	}
+	for _, ids := range [][]string{change.Remove, change.Block, change.Unblock} {
+		plan.Seats = append(plan.Seats, ids...)
+	}
+
+	return plan, nil
+}
+
+// validateSeatMapChange checks a change against the current seat statuses
+func validateSeatMapChange(change domain.SeatMapChange, statuses map[string]domain.SeatStatus) error {
+	seen := make(map[string]bool)
+	claim := func(seatID string) error {
+		if seen[seatID] {
+			return fmt.Errorf("%w: seat %s listed more than once", domain.ErrInvalidSeatChange, seatID)
+		}
+		seen[seatID] = true
+		return nil
+	}
+
+	for _, seat := range change.Add {
+		if !domain.IsValidSeatID(seat.ID) || seat.ID != fmt.Sprintf("%d%s", seat.Row, seat.Column) {
+			return fmt.Errorf("%w: seat %q does not match row %d column %q", domain.ErrInvalidSeatChange, seat.ID, seat.Row, seat.Column)
+		}
+		if seat.Class != "" && !domain.IsValidCabinClass(seat.Class) {
+			return fmt.Errorf("%w: seat %s has unknown class %q", domain.ErrInvalidSeatChange, seat.ID, seat.Class)
+		}
+		if _, exists := statuses[seat.ID]; exists {
+			return fmt.Errorf("%w: %s", domain.ErrSeatExists, seat.ID)
+		}
+		if err := claim(seat.ID); err != nil {
+			return err
+		}
+	}
+
+	checks := []struct {
+		seatIDs []string
+		allowed map[domain.SeatStatus]bool
+	}{
+		{change.Remove, map[domain.SeatStatus]bool{domain.SeatStatusAvailable: true, domain.SeatStatusBlocked: true}},
+		{change.Block, map[domain.SeatStatus]bool{domain.SeatStatusAvailable: true}},
+		{change.Unblock, map[domain.SeatStatus]bool{domain.SeatStatusBlocked: true}},
+	}
+	for _, c := range checks {
+		for _, seatID := range c.seatIDs {
+			status, exists := statuses[seatID]
+			if !exists {
+				return fmt.Errorf("%w: %s", domain.ErrSeatNotFound, seatID)
+			}
+			if status == domain.SeatStatusReserved || status == domain.SeatStatusBooked {
+				return fmt.Errorf("%w: %s", domain.ErrSeatInUse, seatID)
+			}
+			if !c.allowed[status] {
+				return fmt.Errorf("%w: seat %s is %s", domain.ErrInvalidSeatChange, seatID, status)
+			}
+			if err := claim(seatID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FareCalendarInput contains the parameters for a flexible-date search
+type FareCalendarInput struct {
+	Origin      string
+	Destination string
+	Date        time.Time // target departure day (UTC)
+	FlexDays    int       // days searched either side of Date
+}
+
+// FareCalendar returns one entry per day in Date±FlexDays with the cheapest fare, if any
+func (s *FlightService) FareCalendar(ctx context.Context, input FareCalendarInput) ([]domain.FareDay, error) {
+	target := time.Date(input.Date.Year(), input.Date.Month(), input.Date.Day(), 0, 0, 0, 0, time.UTC)
+	from := target.AddDate(0, 0, -input.FlexDays)
+	to := target.AddDate(0, 0, input.FlexDays+1)
+
+	found, err := s.flightRepo.FareCalendar(ctx, input.Origin, input.Destination, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]domain.FareDay, len(found))
+	for _, d := range found {
+		byDay[d.Date.Format(time.DateOnly)] = d
+	}
+
+	calendar := make([]domain.FareDay, 0, 2*input.FlexDays+1)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		entry, ok := byDay[day.Format(time.DateOnly)]
+		if !ok {
+			entry = domain.FareDay{}
+		}
+		entry.Date = day
+		calendar = append(calendar, entry)
+	}
+
+	return calendar, nil
+}
+
+// Revenue reports the revenue recognized on a flight
+func (s *FlightService) Revenue(ctx context.Context, flightID string) (*domain.FlightRevenue, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.Revenue(ctx, flightID)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/mocks"
+)
+
+func TestValidateSeatMapChange(t *testing.T) {
+	statuses := map[string]domain.SeatStatus{
+		"1A": domain.SeatStatusAvailable,
+		"1B": domain.SeatStatusReserved,
+		"1C": domain.SeatStatusBooked,
+		"1D": domain.SeatStatusBlocked,
+	}
+
+	tests := []struct {
+		name    string
+		change  domain.SeatMapChange
+		wantErr error
+	}{
+		{"add new seat", domain.SeatMapChange{Add: []domain.Seat{{ID: "21A", Row: 21, Column: "A"}}}, nil},
+		{"add existing seat", domain.SeatMapChange{Add: []domain.Seat{{ID: "1A", Row: 1, Column: "A"}}}, domain.ErrSeatExists},
+		{"add mismatched id", domain.SeatMapChange{Add: []domain.Seat{{ID: "21B", Row: 21, Column: "A"}}}, domain.ErrInvalidSeatChange},
+		{"remove available", domain.SeatMapChange{Remove: []string{"1A"}}, nil},
+		{"remove blocked", domain.SeatMapChange{Remove: []string{"1D"}}, nil},
+		{"remove reserved", domain.SeatMapChange{Remove: []string{"1B"}}, domain.ErrSeatInUse},
+		{"remove booked", domain.SeatMapChange{Remove: []string{"1C"}}, domain.ErrSeatInUse},
+		{"remove unknown", domain.SeatMapChange{Remove: []string{"9Z"}}, domain.ErrSeatNotFound},
+		{"block reserved", domain.SeatMapChange{Block: []string{"1B"}}, domain.ErrSeatInUse},
+		{"unblock available", domain.SeatMapChange{Unblock: []string{"1A"}}, domain.ErrInvalidSeatChange},
+		{"same seat twice", domain.SeatMapChange{Block: []string{"1A"}, Remove: []string{"1A"}}, domain.ErrInvalidSeatChange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSeatMapChange(tt.change, statuses)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("got err=%v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetFlightWithSeats_LockedSeatsShowReserved(t *testing.T) {
+	flights := mocks.NewFlightRepository(t)
+	locks := mocks.NewSeatLocker(t)
+	flights.On("FindByID", mock.Anything, "f1").Return(&domain.Flight{ID: "f1"}, nil)
+	flights.On("FindSeats", mock.Anything, "f1").Return([]domain.Seat{
+		{ID: "1A", Row: 1, Column: "A", Status: domain.SeatStatusAvailable},
+		{ID: "1B", Row: 1, Column: "B", Status: domain.SeatStatusAvailable},
+		{ID: "1C", Row: 1, Column: "C", Status: domain.SeatStatusBooked},
+	}, nil)
+	expiresAt := time.Date(2025, 3, 1, 12, 15, 0, 0, time.UTC)
+	locks.On("GetSeatLocks", mock.Anything, "f1").Return(map[string]domain.SeatLock{
+		"1B": {OrderID: "o1", ExpiresAt: expiresAt},
+		"1C": {OrderID: "o2", ExpiresAt: expiresAt},
+	}, nil)
+
+	got, err := NewFlightService(flights, locks).GetFlightWithSeats(context.Background(), "f1")
+	if err != nil {
+		t.Fatalf("get flight: %v", err)
+	}
+
+	want := map[string]domain.SeatStatus{
+		"1A": domain.SeatStatusAvailable,
+		"1B": domain.SeatStatusReserved,
+		"1C": domain.SeatStatusBooked,
+	}
+	for _, seat := range got.SeatMap.Seats {
+		if seat.Status != want[seat.ID] {
+			t.Errorf("seat %s is %s, want %s", seat.ID, seat.Status, want[seat.ID])
+		}
+		// The lock outlives the hold by its grace; the seat frees when the hold ends
+		held := seat.HeldUntil != nil && seat.HeldUntil.Equal(expiresAt.Add(-domain.SeatLockGrace))
+		if held != (seat.ID == "1B") {
+			t.Errorf("seat %s held until %v, want only the locked reserved seat held", seat.ID, seat.HeldUntil)
+		}
+	}
+}
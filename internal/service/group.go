@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// CreateGroupOrder books a large party on one flight. Seats are reserved in
+// chunks under a longer hold; if some cannot be held the order keeps the rest
+// and waits for ApprovePartial before it can be paid.
+func (s *BookingService) CreateGroupOrder(ctx context.Context, input CreateOrderInput) (*CreateOrderOutput, error) {
+	if len(input.Seats) < domain.MinGroupSeats || len(input.Seats) > domain.MaxGroupSeats {
+		return nil, domain.ErrInvalidGroupSize
+	}
+	if err := validatePassengers(input.Passengers, input.Seats, 0); err != nil {
+		return nil, err
+	}
+
+	flight, err := s.flightRepo.FindByID(ctx, input.FlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	temporalInput, err := s.bookingInput(flight, CreateOrderInput{Seats: input.Seats, Passengers: input.Passengers})
+	if err != nil {
+		return nil, err
+	}
+	temporalInput.HoldDuration = s.cfg.GroupHoldDuration
+	temporalInput.ReserveChunkSize = s.cfg.GroupChunkSize
+
+	workflowID, err := s.temporalClient.StartGroupBookingWorkflow(ctx, temporalInput)
+	if err != nil {
+		return nil, fmt.Errorf("start group workflow: %w", err)
+	}
+
+	return &CreateOrderOutput{
+		OrderID:    temporalInput.OrderID,
+		WorkflowID: workflowID,
+		Status:     domain.OrderStatusCreated,
+		ExpiresAt:  time.Now().Add(s.cfg.GroupHoldDuration),
+	}, nil
+}
+
+// ApprovePartial accepts the seats a partially reserved group booking holds,
+// so the order can be paid without the seats that could not be reserved
+func (s *BookingService) ApprovePartial(ctx context.Context, orderID string) (*domain.OrderStatusResponse, error) {
+	status, err := s.queryStatus(ctx, orderID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if !status.AwaitingApproval {
+		return nil, domain.ErrNoPartialBooking
+	}
+
+	err = s.temporalClient.SignalApprovePartial(ctx, orderID)
+	s.statusCache.invalidate(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("signal approve partial: %w", err)
+	}
+	if _, err := s.awaitSignal(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderStatus(ctx, orderID)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// NotificationService manages per-order notification preferences
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepo
+	orderRepo        repository.OrderRepository
+}
+
+// NewNotificationService creates a new NotificationService
+func NewNotificationService(notificationRepo *repository.NotificationRepo, orderRepo repository.OrderRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		orderRepo:        orderRepo,
+	}
+}
+
+// GetPreferences returns an order's preferences, storing defaults on first
+// access so the order has an unsubscribe token
+func (s *NotificationService) GetPreferences(ctx context.Context, orderID string) (*domain.NotificationPreferences, error) {
+	if _, err := s.orderRepo.FindByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.notificationRepo.Find(ctx, orderID)
+	if err != nil || prefs != nil {
+		return prefs, err
+	}
+
+	defaults := domain.DefaultNotificationPreferences(orderID)
+	if defaults.UnsubscribeToken, err = newUnsubscribeToken(); err != nil {
+		return nil, err
+	}
+	if err := s.notificationRepo.Upsert(ctx, &defaults); err != nil {
+		return nil, err
+	}
+
+	// Re-read so a concurrent first access doesn't return a token that lost the insert race
+	return s.notificationRepo.Find(ctx, orderID)
+}
+
+// UpdatePreferences replaces an order's channels and categories and clears any unsubscribe
+func (s *NotificationService) UpdatePreferences(ctx context.Context, orderID string, channels []domain.NotificationChannel, categories []domain.NotificationCategory) (*domain.NotificationPreferences, error) {
+	if err := validatePreferences(channels, categories); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.GetPreferences(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy into non-nil slices; an empty list is a valid opt-out, NULL is not
+	prefs.Channels = append([]domain.NotificationChannel{}, channels...)
+	prefs.Categories = append([]domain.NotificationCategory{}, categories...)
+	prefs.UnsubscribedAt = nil
+	if err := s.notificationRepo.Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	return s.notificationRepo.Find(ctx, orderID)
+}
+
+// Unsubscribe honors an unsubscribe link: it drops one category, or all
+// notifications when category is empty
+func (s *NotificationService) Unsubscribe(ctx context.Context, token string, category domain.NotificationCategory) (*domain.NotificationPreferences, error) {
+	prefs, err := s.notificationRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == "" {
+		now := time.Now()
+		prefs.UnsubscribedAt = &now
+	} else {
+		if !slices.Contains(domain.AllNotificationCategories, category) {
+			return nil, domain.ErrInvalidPreferences
+		}
+		prefs.Categories = slices.DeleteFunc(prefs.Categories, func(c domain.NotificationCategory) bool {
+			return c == category
+		})
+	}
+
+	if err := s.notificationRepo.Upsert(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	return s.notificationRepo.Find(ctx, prefs.OrderID)
+}
+
+// validatePreferences rejects unknown or duplicate channels and categories
+func validatePreferences(channels []domain.NotificationChannel, categories []domain.NotificationCategory) error {
+	for i, c := range channels {
+		if !slices.Contains(domain.AllNotificationChannels, c) || slices.Contains(channels[:i], c) {
+			return fmt.Errorf("channel %q: %w", c, domain.ErrInvalidPreferences)
+		}
+	}
+	for i, c := range categories {
+		if !slices.Contains(domain.AllNotificationCategories, c) || slices.Contains(categories[:i], c) {
+			return fmt.Errorf("category %q: %w", c, domain.ErrInvalidPreferences)
+		}
+	}
+	return nil
+}
+
+// newUnsubscribeToken returns a random, URL-safe token
+func newUnsubscribeToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate unsubscribe token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
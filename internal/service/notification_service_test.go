@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestValidatePreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		channels   []domain.NotificationChannel
+		categories []domain.NotificationCategory
+		wantErr    bool
+	}{
+		{"defaults", []domain.NotificationChannel{"email"}, []domain.NotificationCategory{"booking", "reminder"}, false},
+		{"opt out of everything", nil, nil, false},
+		{"unknown channel", []domain.NotificationChannel{"fax"}, nil, true},
+		{"unknown category", nil, []domain.NotificationCategory{"spam"}, true},
+		{"duplicate channel", []domain.NotificationChannel{"sms", "sms"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePreferences(tt.channels, tt.categories)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, domain.ErrInvalidPreferences)) {
+				t.Errorf("got err=%v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferencesAllows(t *testing.T) {
+	prefs := domain.DefaultNotificationPreferences("order-1")
+	if !prefs.Allows(domain.ChannelEmail, domain.CategoryBooking) {
+		t.Error("defaults should allow booking email")
+	}
+	if prefs.Allows(domain.ChannelSMS, domain.CategoryBooking) {
+		t.Error("defaults should not allow sms")
+	}
+
+	now := time.Now()
+	prefs.UnsubscribedAt = &now
+	if prefs.Allows(domain.ChannelEmail, domain.CategoryBooking) {
+		t.Error("unsubscribed preferences should block every delivery")
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// defaultRefundReason is recorded on refunds an operator gives no reason for
+const defaultRefundReason = "refunded by operator"
+
+// RefundOrder returns a confirmed order's payment through a RefundWorkflow
+// and records the outcome on the order: once refunded it is
+// PAYMENT_REFUNDED and its seats are back on sale. Waiting gives up after
+// SignalApplyTimeout; the refund still completes and shows on the order.
+// With version set, an order written since that version is not refunded and
+// fails with domain.ErrVersionConflict.
+func (s *BookingService) RefundOrder(ctx context.Context, orderID, reason string, version *int) (*domain.Refund, error) {
+	if _, err := s.refundableOrder(ctx, orderID, version); err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = defaultRefundReason
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.SignalApplyTimeout)
+	defer cancel()
+
+	refund, err := s.temporalClient.RunRefundWorkflow(ctx, temporalpkg.RefundWorkflowInput{OrderID: orderID, Reason: reason, Version: version})
+	s.statusCache.invalidate(orderID)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUpdatePending, ctx.Err())
+	}
+	return refund, err
+}
+
+// PlanRefund checks an order could be refunded as RefundOrder would and
+// returns the seats it would put back on sale and the workflow that would
+// refund it, without refunding anything
+func (s *BookingService) PlanRefund(ctx context.Context, orderID string, version *int) (*domain.AdminPlan, error) {
+	order, err := s.refundableOrder(ctx, orderID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AdminPlan{
+		Action:    "refund",
+		DryRun:    true,
+		Seats:     order.Seats,
+		Orders:    []string{orderID},
+		Workflows: []string{fmt.Sprintf("refund-%s", orderID)},
+	}, nil
+}
+
+// refundableOrder loads an order an operator may refund: a confirmed one,
+// unchanged since version when it is set
+func (s *BookingService) refundableOrder(ctx context.Context, orderID string, version *int) (*domain.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusConfirmed {
+		return nil, domain.ErrOrderNotRefundable
+	}
+	if version != nil && *version != order.Version {
+		return nil, domain.ErrVersionConflict
+	}
+	return order, nil
+}
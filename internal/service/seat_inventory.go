@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// ExportInventory returns a flight's stored seat inventory, ordered by row and column
+func (s *FlightService) ExportInventory(ctx context.Context, flightID string) ([]domain.Seat, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	return s.flightRepo.FindSeats(ctx, flightID)
+}
+
+// ImportInventory replaces a flight's seat inventory with seats and returns the
+// change it applied, or would apply when dryRun is set. Reserved and booked
+// seats must appear unchanged; everything else may be added, removed, blocked,
+// or unblocked.
+func (s *FlightService) ImportInventory(ctx context.Context, flightID string, seats []domain.Seat, dryRun bool) (*domain.SeatMapChange, error) {
+	if _, err := s.flightRepo.FindByID(ctx, flightID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.flightRepo.FindSeats(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := diffInventory(current, seats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both paths check the change against live Redis holds
+	if dryRun {
+		_, err = s.PlanSeatMapChange(ctx, flightID, change)
+	} else {
+		_, err = s.UpdateSeatMap(ctx, flightID, change)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// diffInventory computes the seat map change that turns current into imported
+func diffInventory(current, imported []domain.Seat) (domain.SeatMapChange, error) {
+	var change domain.SeatMapChange
+	if len(imported) == 0 {
+		return change, fmt.Errorf("%w: inventory has no seats", domain.ErrInvalidSeatChange)
+	}
+
+	existing := make(map[string]domain.Seat, len(current))
+	for _, seat := range current {
+		existing[seat.ID] = seat
+	}
+
+	seen := make(map[string]bool, len(imported))
+	for _, seat := range imported {
+		if seen[seat.ID] {
+			return change, fmt.Errorf("%w: seat %s listed more than once", domain.ErrInvalidSeatChange, seat.ID)
+		}
+		seen[seat.ID] = true
+
+		old, ok := existing[seat.ID]
+		if !ok {
+			if seat.Status != domain.SeatStatusAvailable && seat.Status != domain.SeatStatusBlocked {
+				return change, fmt.Errorf("%w: new seat %s must be available or blocked", domain.ErrInvalidSeatChange, seat.ID)
+			}
+			change.Add = append(change.Add, domain.Seat{ID: seat.ID, Row: seat.Row, Column: seat.Column, Status: seat.Status})
+			continue
+		}
+		if err := diffSeat(old, seat, &change); err != nil {
+			return change, err
+		}
+	}
+
+	for _, seat := range current {
+		if !seen[seat.ID] {
+			change.Remove = append(change.Remove, seat.ID)
+		}
+	}
+
+	return change, nil
+}
+
+// diffSeat records the status change for a seat present in both inventories
+func diffSeat(old, seat domain.Seat, change *domain.SeatMapChange) error {
+	if old.Row != seat.Row || old.Column != seat.Column {
+		return fmt.Errorf("%w: seat %s cannot move to row %d column %q", domain.ErrInvalidSeatChange, seat.ID, seat.Row, seat.Column)
+	}
+
+	switch {
+	case old.Status == seat.Status:
+	case old.Status == domain.SeatStatusAvailable && seat.Status == domain.SeatStatusBlocked:
+		change.Block = append(change.Block, seat.ID)
+	case old.Status == domain.SeatStatusBlocked && seat.Status == domain.SeatStatusAvailable:
+		change.Unblock = append(change.Unblock, seat.ID)
+	default:
+		return fmt.Errorf("%w: seat %s cannot change from %s to %q", domain.ErrInvalidSeatChange, seat.ID, old.Status, seat.Status)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestDiffInventory(t *testing.T) {
+	seat := func(id string, row int, col string, status domain.SeatStatus) domain.Seat {
+		return domain.Seat{ID: id, Row: row, Column: col, Status: status}
+	}
+	current := []domain.Seat{
+		seat("1A", 1, "A", domain.SeatStatusAvailable),
+		seat("1B", 1, "B", domain.SeatStatusBlocked),
+		seat("1C", 1, "C", domain.SeatStatusBooked),
+		seat("1D", 1, "D", domain.SeatStatusAvailable),
+	}
+
+	change, err := diffInventory(current, []domain.Seat{
+		seat("1A", 1, "A", domain.SeatStatusBlocked),
+		seat("1B", 1, "B", domain.SeatStatusAvailable),
+		seat("1C", 1, "C", domain.SeatStatusBooked),
+		seat("2A", 2, "A", domain.SeatStatusBlocked),
+	})
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if len(change.Add) != 1 || change.Add[0].ID != "2A" || change.Add[0].Status != domain.SeatStatusBlocked {
+		t.Errorf("add: got %+v", change.Add)
+	}
+	if !slices.Equal(change.Remove, []string{"1D"}) || !slices.Equal(change.Block, []string{"1A"}) || !slices.Equal(change.Unblock, []string{"1B"}) {
+		t.Errorf("got remove %v block %v unblock %v", change.Remove, change.Block, change.Unblock)
+	}
+
+	invalid := map[string][]domain.Seat{
+		"empty":          nil,
+		"duplicate":      {seat("1A", 1, "A", domain.SeatStatusAvailable), seat("1A", 1, "A", domain.SeatStatusAvailable)},
+		"moved seat":     {seat("1A", 2, "A", domain.SeatStatusAvailable)},
+		"unbook seat":    {seat("1C", 1, "C", domain.SeatStatusAvailable)},
+		"new booked":     {seat("3A", 3, "A", domain.SeatStatusBooked)},
+		"unknown status": {seat("1A", 1, "A", "broken")},
+	}
+	for name, imported := range invalid {
+		if _, err := diffInventory(current, imported); !errors.Is(err, domain.ErrInvalidSeatChange) {
+			t.Errorf("%s: got %v, want ErrInvalidSeatChange", name, err)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Scoring weights for seat recommendations
+const (
+	positionWeight    = 3.0
+	zoneWeight        = 2.0
+	quietWeight       = 1.0
+	contentionWeight  = 0.5 // per held neighbour; hot areas are likely to lose the lock race
+	aisleSplitPenalty = 1.0
+)
+
+// RecommendSeats ranks available seats on a flight against the given preferences
+func (s *FlightService) RecommendSeats(ctx context.Context, flightID string, prefs domain.SeatPreferences, limit int) ([]domain.SeatRecommendation, error) {
+	flight, err := s.GetFlightWithSeats(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	return RecommendSeats(flight.SeatMap.Seats, prefs, limit), nil
+}
+
+// RecommendSeats ranks candidate seat groups from a seat map whose statuses
+// already reflect live holds. It is pure so auto-assignment can reuse it.
+func RecommendSeats(seats []domain.Seat, prefs domain.SeatPreferences, limit int) []domain.SeatRecommendation {
+	layout := newCabinLayout(seats)
+	party := prefs.PartySize
+	if party < 1 {
+		party = 1
+	}
+
+	var candidates []domain.SeatRecommendation
+	for row := 1; row <= layout.rows; row++ {
+		for start := 0; start+party <= len(layout.columns); start++ {
+			if rec, ok := layout.scoreGroup(row, start, party, prefs); ok {
+				candidates = append(candidates, rec)
+			}
+		}
+	}
+
+	// Stable sort keeps front-to-back, left-to-right order among equal scores
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// AutoAssignSeats picks count available seats for a seatless party at
+// check-in: the best side-by-side group in one row when there is one,
+// otherwise the first free seats front to back. It returns nil when fewer
+// than count seats are available.
+func AutoAssignSeats(seats []domain.Seat, count int) []string {
+	if recs := RecommendSeats(seats, domain.SeatPreferences{PartySize: count}, 1); len(recs) > 0 {
+		return recs[0].Seats
+	}
+
+	layout := newCabinLayout(seats)
+	var picked []string
+	for row := 1; row <= layout.rows && len(picked) < count; row++ {
+		for _, col := range layout.columns {
+			if seat, ok := layout.seats[seatKey(row, col)]; ok && seat.Status == domain.SeatStatusAvailable && len(picked) < count {
+				picked = append(picked, seat.ID)
+			}
+		}
+	}
+	if len(picked) < count {
+		return nil
+	}
+	return picked
+}
+
+// cabinLayout indexes a seat map by row and column for neighbour lookups
+type cabinLayout struct {
+	rows    int
+	columns []string
+	seats   map[string]domain.Seat
+}
+
+func newCabinLayout(seats []domain.Seat) cabinLayout {
+	layout := cabinLayout{seats: make(map[string]domain.Seat, len(seats))}
+	seen := make(map[string]bool)
+	for _, seat := range seats {
+		layout.seats[seatKey(seat.Row, seat.Column)] = seat
+		if seat.Row > layout.rows {
+			layout.rows = seat.Row
+		}
+		if !seen[seat.Column] {
+			seen[seat.Column] = true
+			layout.columns = append(layout.columns, seat.Column)
+		}
+	}
+	sort.Strings(layout.columns)
+	return layout
+}
+
+func seatKey(row int, column string) string {
+	return fmt.Sprintf("%d%s", row, column)
+}
+
+// scoreGroup scores party adjacent seats in row starting at column index start
+func (l cabinLayout) scoreGroup(row, start, party int, prefs domain.SeatPreferences) (domain.SeatRecommendation, bool) {
+	var rec domain.SeatRecommendation
+	reasons := make(map[string]bool)
+	total := 0.0
+
+	for col := start; col < start+party; col++ {
+		seat, ok := l.seats[seatKey(row, l.columns[col])]
+		if !ok || seat.Status != domain.SeatStatusAvailable {
+			return rec, false
+		}
+		score, why := l.scoreSeat(row, col, prefs)
+		total += score
+		for _, r := range why {
+			if !reasons[r] {
+				reasons[r] = true
+				rec.Reasons = append(rec.Reasons, r)
+			}
+		}
+		rec.Seats = append(rec.Seats, seat.ID)
+	}
+
+	if aisle, ok := l.aisleAfter(); ok && party > 1 && start <= aisle && start+party-1 > aisle {
+		total -= aisleSplitPenalty * float64(party)
+		rec.Reasons = append(rec.Reasons, "split by aisle")
+	}
+
+	rec.Score = math.Round(total/float64(party)*100) / 100
+	return rec, true
+}
+
+// scoreSeat scores one seat at column index col and explains the contributions
+func (l cabinLayout) scoreSeat(row, col int, prefs domain.SeatPreferences) (float64, []string) {
+	var score float64
+	var reasons []string
+
+	switch {
+	case prefs.Position == domain.SeatPositionWindow && l.isWindow(col):
+		score += positionWeight
+		reasons = append(reasons, "window")
+	case prefs.Position == domain.SeatPositionAisle && l.isAisle(col):
+		score += positionWeight
+		reasons = append(reasons, "aisle")
+	}
+
+	if prefs.Zone != domain.CabinZoneAny && l.rows > 1 {
+		depth := float64(row-1) / float64(l.rows-1)
+		if prefs.Zone == domain.CabinZoneFront {
+			depth = 1 - depth
+		}
+		score += zoneWeight * depth
+		if depth >= 0.5 {
+			reasons = append(reasons, string(prefs.Zone)+" of cabin")
+		}
+	}
+
+	// Row 1 sits by the galley and the last three rows by the lavatories
+	if prefs.Quiet && row > 1 && row <= l.rows-3 {
+		score += quietWeight
+		reasons = append(reasons, "quiet zone")
+	}
+
+	if held := l.heldNeighbours(row, col); held > 0 {
+		score -= contentionWeight * float64(held)
+		reasons = append(reasons, fmt.Sprintf("%d nearby seats on hold", held))
+	}
+
+	return score, reasons
+}
+
+func (l cabinLayout) isWindow(col int) bool {
+	return col == 0 || col == len(l.columns)-1
+}
+
+func (l cabinLayout) isAisle(col int) bool {
+	aisle, ok := l.aisleAfter()
+	return ok && (col == aisle || col == aisle+1)
+}
+
+// aisleAfter returns the column index left of a single centre aisle
+func (l cabinLayout) aisleAfter() (int, bool) {
+	n := len(l.columns)
+	if n < 4 || n%2 != 0 {
+		return 0, false
+	}
+	return n/2 - 1, true
+}
+
+// heldNeighbours counts surrounding seats currently reserved by other shoppers
+func (l cabinLayout) heldNeighbours(row, col int) int {
+	held := 0
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			c := col + dc
+			if (dr == 0 && dc == 0) || c < 0 || c >= len(l.columns) {
+				continue
+			}
+			if seat, ok := l.seats[seatKey(row+dr, l.columns[c])]; ok && seat.Status == domain.SeatStatusReserved {
+				held++
+			}
+		}
+	}
+	return held
+}
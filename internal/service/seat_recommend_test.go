@@ -0,0 +1,97 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// testCabin builds a rows x ABCDEF seat map with the given non-available statuses
+func testCabin(rows int, statuses map[string]domain.SeatStatus) []domain.Seat {
+	var seats []domain.Seat
+	for row := 1; row <= rows; row++ {
+		for _, col := range []string{"A", "B", "C", "D", "E", "F"} {
+			id := seatKey(row, col)
+			status, ok := statuses[id]
+			if !ok {
+				status = domain.SeatStatusAvailable
+			}
+			seats = append(seats, domain.Seat{ID: id, Row: row, Column: col, Status: status})
+		}
+	}
+	return seats
+}
+
+func TestRecommendSeats(t *testing.T) {
+	tests := []struct {
+		name  string
+		seats []domain.Seat
+		prefs domain.SeatPreferences
+		want  []string
+	}{
+		{
+			name:  "front window",
+			seats: testCabin(10, nil),
+			prefs: domain.SeatPreferences{Position: domain.SeatPositionWindow, Zone: domain.CabinZoneFront},
+			want:  []string{"1A"},
+		},
+		{
+			name:  "back aisle",
+			seats: testCabin(10, nil),
+			prefs: domain.SeatPreferences{Position: domain.SeatPositionAisle, Zone: domain.CabinZoneBack},
+			want:  []string{"10C"},
+		},
+		{
+			name:  "avoids held neighbours",
+			seats: testCabin(10, map[string]domain.SeatStatus{"2A": domain.SeatStatusReserved}),
+			prefs: domain.SeatPreferences{Position: domain.SeatPositionWindow, Zone: domain.CabinZoneFront},
+			want:  []string{"1F"},
+		},
+		{
+			name:  "party stays on one side of the aisle",
+			seats: testCabin(10, map[string]domain.SeatStatus{"1A": domain.SeatStatusBooked}),
+			prefs: domain.SeatPreferences{Zone: domain.CabinZoneFront, PartySize: 3},
+			want:  []string{"1D", "1E", "1F"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecommendSeats(tt.seats, tt.prefs, 1)
+			if len(got) != 1 || !slices.Equal(got[0].Seats, tt.want) {
+				t.Errorf("got %+v, want seats %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoAssignSeats(t *testing.T) {
+	booked := func(ids ...string) map[string]domain.SeatStatus {
+		statuses := make(map[string]domain.SeatStatus, len(ids))
+		for _, id := range ids {
+			statuses[id] = domain.SeatStatusBooked
+		}
+		return statuses
+	}
+
+	tests := []struct {
+		name  string
+		seats []domain.Seat
+		count int
+		want  []string
+	}{
+		{"side by side, clear of the aisle", testCabin(2, booked("1B")), 2, []string{"1D", "1E"}},
+		{"scattered when no group fits", testCabin(1, booked("1A", "1C", "1E")), 2, []string{"1B", "1D"}},
+		{"party wider than a row", testCabin(2, nil), 7, []string{"1A", "1B", "1C", "1D", "1E", "1F", "2A"}},
+		{"not enough seats", testCabin(1, booked("1A", "1C", "1E")), 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AutoAssignSeats(tt.seats, tt.count); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
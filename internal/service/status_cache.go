@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// statusCacheSweepSize is the entry count above which storing a status first
+// drops expired entries
+const statusCacheSweepSize = 1024
+
+// statusCache reuses an order's workflow status for a short TTL so UIs
+// polling the same order do not each query Temporal. Signals this instance
+// sends invalidate the order's entry; changes made by other instances or by
+// the workflow itself show up once the entry expires. A zero TTL disables it.
+type statusCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]statusCacheEntry
+	epoch   uint64 // bumped on every invalidation
+}
+
+type statusCacheEntry struct {
+	status  *temporalpkg.BookingStatusResponse
+	expires time.Time
+}
+
+func newStatusCache(ttl time.Duration) *statusCache {
+	return &statusCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]statusCacheEntry),
+	}
+}
+
+// query returns the cached status of orderID or runs query and caches its
+// result. Errors are never cached, and a result is dropped if any order was
+// invalidated while its query ran, since it may predate a signal.
+func (c *statusCache) query(ctx context.Context, orderID string, query func(context.Context) (*temporalpkg.BookingStatusResponse, error)) (*temporalpkg.BookingStatusResponse, error) {
+	if c.ttl <= 0 {
+		return query(ctx)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[orderID]
+	if ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.status, nil
+	}
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	status, err := query(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch != epoch {
+		return status, nil
+	}
+	now := c.now()
+	if len(c.entries) >= statusCacheSweepSize {
+		for id, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[orderID] = statusCacheEntry{status: status, expires: now.Add(c.ttl)}
+	return status, nil
+}
+
+// invalidate forgets orderID's status; call it once a signal to the order's
+// workflow has been sent
+func (c *statusCache) invalidate(orderID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, orderID)
+	c.epoch++
+}
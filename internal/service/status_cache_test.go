@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+func TestStatusCache(t *testing.T) {
+	newCache := func(ttl time.Duration) (*statusCache, *time.Time) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		cache := newStatusCache(ttl)
+		cache.now = func() time.Time { return now }
+		return cache, &now
+	}
+
+	t.Run("reuses a status until it expires", func(t *testing.T) {
+		cache, now := newCache(time.Second)
+		query, calls := scriptedStatus(
+			temporalpkg.BookingStatusResponse{Version: 1},
+			temporalpkg.BookingStatusResponse{Version: 2},
+		)
+
+		first, _ := cache.query(context.Background(), "order-1", query)
+		second, _ := cache.query(context.Background(), "order-1", query)
+		if first.Version != 1 || second.Version != 1 || *calls != 1 {
+			t.Errorf("got versions %d, %d after %d queries", first.Version, second.Version, *calls)
+		}
+
+		*now = now.Add(time.Second)
+		third, _ := cache.query(context.Background(), "order-1", query)
+		if third.Version != 2 || *calls != 2 {
+			t.Errorf("got version %d after %d queries", third.Version, *calls)
+		}
+	})
+
+	t.Run("invalidation forces a new query", func(t *testing.T) {
+		cache, _ := newCache(time.Minute)
+		query, calls := scriptedStatus(
+			temporalpkg.BookingStatusResponse{Version: 1},
+			temporalpkg.BookingStatusResponse{Version: 2},
+		)
+
+		cache.query(context.Background(), "order-1", query)
+		cache.invalidate("order-1")
+		status, _ := cache.query(context.Background(), "order-1", query)
+		if status.Version != 2 || *calls != 2 {
+			t.Errorf("got version %d after %d queries", status.Version, *calls)
+		}
+	})
+
+	t.Run("drops a result invalidated while querying", func(t *testing.T) {
+		cache, _ := newCache(time.Minute)
+		racing := func(context.Context) (*temporalpkg.BookingStatusResponse, error) {
+			cache.invalidate("order-1")
+			return &temporalpkg.BookingStatusResponse{Version: 1}, nil
+		}
+		query, calls := scriptedStatus(temporalpkg.BookingStatusResponse{Version: 2})
+
+		cache.query(context.Background(), "order-1", racing)
+		status, _ := cache.query(context.Background(), "order-1", query)
+		if status.Version != 2 || *calls != 1 {
+			t.Errorf("got version %d after %d queries", status.Version, *calls)
+		}
+	})
+
+	t.Run("zero TTL disables caching", func(t *testing.T) {
+		cache, _ := newCache(0)
+		query, calls := scriptedStatus(temporalpkg.BookingStatusResponse{Version: 1})
+
+		cache.query(context.Background(), "order-1", query)
+		cache.query(context.Background(), "order-1", query)
+		if *calls != 2 {
+			t.Errorf("got %d queries, want 2", *calls)
+		}
+	})
+}
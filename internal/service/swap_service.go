@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// SwapService runs the seat swap marketplace between confirmed orders
+type SwapService struct {
+	swapRepo       *repository.SwapRepo
+	orderRepo      repository.OrderRepository
+	temporalClient WorkflowClient
+}
+
+// NewSwapService creates a new SwapService
+func NewSwapService(swapRepo *repository.SwapRepo, orderRepo repository.OrderRepository, temporalClient WorkflowClient) *SwapService {
+	return &SwapService{
+		swapRepo:       swapRepo,
+		orderRepo:      orderRepo,
+		temporalClient: temporalClient,
+	}
+}
+
+// OfferSwap puts one of a confirmed order's seats up for exchange
+func (s *SwapService) OfferSwap(ctx context.Context, orderID, seatID string) (*domain.SeatSwapOffer, error) {
+	order, err := s.eligibleOrder(ctx, orderID, seatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.swapRepo.CreateOffer(ctx, order.FlightID, order.ID, seatID)
+}
+
+// ListOpenOffers returns the seats currently offered for swap on a flight
+func (s *SwapService) ListOpenOffers(ctx context.Context, flightID string) ([]domain.SeatSwapOffer, error) {
+	return s.swapRepo.ListOpenOffers(ctx, flightID)
+}
+
+// GetOffer returns a swap offer by ID
+func (s *SwapService) GetOffer(ctx context.Context, offerID string) (*domain.SeatSwapOffer, error) {
+	return s.swapRepo.FindOffer(ctx, offerID)
+}
+
+// AcceptSwap matches an open offer with a seat from another confirmed order on
+// the same flight and starts the workflow that performs the exchange
+func (s *SwapService) AcceptSwap(ctx context.Context, offerID, orderID, seatID string) (*domain.SeatSwapOffer, error) {
+	offer, err := s.swapRepo.FindOffer(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.eligibleOrder(ctx, orderID, seatID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.Status != domain.SwapOfferOpen || order.ID == offer.OrderID || order.FlightID != offer.FlightID {
+		return nil, domain.ErrSwapNotAllowed
+	}
+
+	if err := s.swapRepo.MatchOffer(ctx, offerID, orderID, seatID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.temporalClient.StartSeatSwapWorkflow(ctx, offerID); err != nil {
+		// Don't leave the offer stuck in MATCHED with nothing to execute it
+		_ = s.swapRepo.FailOffer(ctx, offerID, "seat swap workflow could not be started")
+		return nil, fmt.Errorf("start seat swap: %w", err)
+	}
+
+	return s.swapRepo.FindOffer(ctx, offerID)
+}
+
+// CancelOffer withdraws an open offer made by orderID
+func (s *SwapService) CancelOffer(ctx context.Context, offerID, orderID string) error {
+	return s.swapRepo.CancelOffer(ctx, offerID, orderID)
+}
+
+// eligibleOrder loads an order and checks it is confirmed and holds seatID
+func (s *SwapService) eligibleOrder(ctx context.Context, orderID, seatID string) (*domain.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != domain.OrderStatusConfirmed || !slices.Contains(order.Seats, seatID) {
+		return nil, domain.ErrSwapNotAllowed
+	}
+
+	return order, nil
+}
@@ -2,26 +2,68 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
 
 	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
 	temporalpkg "github.com/flight-booking-system/internal/temporal"
 	"github.com/flight-booking-system/internal/temporal/workflows"
 )
 
+// WorkflowClient starts, signals and queries the booking system's
+// workflows. Services take it instead of TemporalClient so unit tests can use
+// the mock in internal/mocks; dialing, health checks and closing stay with
+// whoever owns the TemporalClient.
+//
+//go:generate mockery --name WorkflowClient --output ../mocks --outpkg mocks --disable-version-string
+type WorkflowClient interface {
+	StartBookingWorkflow(ctx context.Context, input temporalpkg.BookingWorkflowInput) (string, error)
+	StartGroupBookingWorkflow(ctx context.Context, input temporalpkg.BookingWorkflowInput) (string, error)
+	StartTripWorkflow(ctx context.Context, input temporalpkg.TripWorkflowInput) (string, error)
+	RunCheckInWorkflow(ctx context.Context, input temporalpkg.CheckInWorkflowInput) error
+	RunRefundWorkflow(ctx context.Context, input temporalpkg.RefundWorkflowInput) (*domain.Refund, error)
+	StartSeatSwapWorkflow(ctx context.Context, offerID string) (string, error)
+	UpdateSeats(ctx context.Context, orderID string, seats []string) (*temporalpkg.SeatChangeResult, error)
+	SignalProceedToPayment(ctx context.Context, orderID string, method domain.PaymentMethod, paymentCode string, deposit *domain.DepositTerms) error
+	SignalCancelBooking(ctx context.Context, orderID string) error
+	SignalExtendHold(ctx context.Context, orderID string) error
+	SignalApprovePartial(ctx context.Context, orderID string) error
+	SignalRespondUpsell(ctx context.Context, orderID string, response domain.UpsellResponse) error
+	SignalPaymentResult(ctx context.Context, result temporalpkg.PaymentResultSignal) error
+	SignalChallengeResponse(ctx context.Context, orderID string, code string) error
+	SignalTripChallengeResponse(ctx context.Context, tripID string, code string) error
+	SignalTripPayment(ctx context.Context, tripID string, method domain.PaymentMethod, paymentCode string) error
+	SignalCancelTrip(ctx context.Context, tripID string) error
+	QueryTripStatus(ctx context.Context, tripID string) (*temporalpkg.TripStatusResponse, error)
+	QueryBookingStatus(ctx context.Context, orderID string) (*temporalpkg.BookingStatusResponse, error)
+	ListRunningBookingWorkflows(ctx context.Context) ([]string, error)
+}
+
+var _ WorkflowClient = (*TemporalClient)(nil)
+
 // TemporalClient wraps the Temporal SDK client for booking operations
 type TemporalClient struct {
 	client    client.Client
+	namespace string
 	taskQueue string
+	urgent    string
 }
 
-// NewTemporalClient creates a new Temporal client wrapper
-func NewTemporalClient(cfg *config.TemporalConfig) (*TemporalClient, error) {
+// NewTemporalClient creates a new Temporal client wrapper whose calls pass
+// through interceptors
+func NewTemporalClient(cfg *config.TemporalConfig, interceptors ...interceptor.ClientInterceptor) (*TemporalClient, error) {
 	c, err := client.Dial(client.Options{
-		HostPort:  cfg.Host,
-		Namespace: cfg.Namespace,
+		HostPort:     cfg.Host,
+		Namespace:    cfg.Namespace,
+		Interceptors: interceptors,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("dial temporal: %w", err)
@@ -29,10 +71,27 @@ func NewTemporalClient(cfg *config.TemporalConfig) (*TemporalClient, error) {
 
 	return &TemporalClient{
 		client:    c,
+		namespace: cfg.Namespace,
 		taskQueue: cfg.TaskQueue,
+		urgent:    cfg.UrgentTaskQueue,
 	}, nil
 }
 
+// HealthCheck verifies the frontend is reachable and the namespace exists
+func (tc *TemporalClient) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := tc.client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: tc.namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("describe namespace %s: %w", tc.namespace, err)
+	}
+
+	return nil
+}
+
 // Close closes the Temporal client connection
 func (tc *TemporalClient) Close() {
 	tc.client.Close()
@@ -46,6 +105,7 @@ func (tc *TemporalClient) StartBookingWorkflow(ctx context.Context, input tempor
 		ID:        workflowID,
 		TaskQueue: tc.taskQueue,
 	}
+	input.UrgentTaskQueue = tc.urgent
 
 	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.BookingWorkflow, input)
 	if err != nil {
@@ -55,26 +115,184 @@ func (tc *TemporalClient) StartBookingWorkflow(ctx context.Context, input tempor
 	return run.GetID(), nil
 }
 
-// SignalUpdateSeats sends an update seats signal to a booking workflow
-func (tc *TemporalClient) SignalUpdateSeats(ctx context.Context, orderID string, seats []string) error {
+// StartGroupBookingWorkflow starts the booking workflow of a group order
+func (tc *TemporalClient) StartGroupBookingWorkflow(ctx context.Context, input temporalpkg.BookingWorkflowInput) (string, error) {
+	workflowID := fmt.Sprintf("booking-%s", input.OrderID)
+
+	opts := client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: tc.taskQueue,
+	}
+	input.UrgentTaskQueue = tc.urgent
+
+	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.GroupBookingWorkflow, input)
+	if err != nil {
+		return "", fmt.Errorf("start group booking workflow: %w", err)
+	}
+
+	return run.GetID(), nil
+}
+
+// StartTripWorkflow starts the parent workflow of a connecting itinerary,
+// which starts a booking workflow per leg
+func (tc *TemporalClient) StartTripWorkflow(ctx context.Context, input temporalpkg.TripWorkflowInput) (string, error) {
+	opts := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("trip-%s", input.TripID),
+		TaskQueue: tc.taskQueue,
+	}
+	for i := range input.Legs {
+		input.Legs[i].UrgentTaskQueue = tc.urgent
+	}
+
+	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.TripWorkflow, input)
+	if err != nil {
+		return "", fmt.Errorf("start trip workflow: %w", err)
+	}
+
+	return run.GetID(), nil
+}
+
+// RunCheckInWorkflow checks an order in and waits for it to finish. A
+// check-in already running for the order is waited on instead of started
+// again. Seats taken concurrently fail with domain.ErrSeatUnavailable.
+func (tc *TemporalClient) RunCheckInWorkflow(ctx context.Context, input temporalpkg.CheckInWorkflowInput) error {
+	opts := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("checkin-%s", input.OrderID),
+		TaskQueue: tc.taskQueue,
+	}
+
+	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.CheckInWorkflow, input)
+	if err != nil {
+		return fmt.Errorf("start check-in workflow: %w", err)
+	}
+
+	err = run.Get(ctx, nil)
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) && appErr.Type() == temporalpkg.ErrTypeSeatUnavailable {
+		return domain.ErrSeatUnavailable
+	}
+	if err != nil {
+		return fmt.Errorf("check-in workflow: %w", err)
+	}
+
+	return nil
+}
+
+// RunRefundWorkflow refunds a confirmed order and waits for the outcome. A
+// refund already running for the order is waited on instead of started
+// again. Orders that are not confirmed fail with
+// domain.ErrOrderNotRefundable, orders no longer at input.Version with
+// domain.ErrVersionConflict, and refunds the payment provider did not
+// complete with domain.ErrRefundFailed.
+func (tc *TemporalClient) RunRefundWorkflow(ctx context.Context, input temporalpkg.RefundWorkflowInput) (*domain.Refund, error) {
+	opts := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("refund-%s", input.OrderID),
+		TaskQueue: tc.taskQueue,
+	}
+
+	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.RefundWorkflow, input)
+	if err != nil {
+		return nil, fmt.Errorf("start refund workflow: %w", err)
+	}
+
+	var refund domain.Refund
+	err = run.Get(ctx, &refund)
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		switch appErr.Type() {
+		case temporalpkg.ErrTypeNotRefundable:
+			return nil, domain.ErrOrderNotRefundable
+		case temporalpkg.ErrTypeVersionConflict:
+			return nil, domain.ErrVersionConflict
+		case temporalpkg.ErrTypeRefundFailed:
+			return nil, fmt.Errorf("%w: %s", domain.ErrRefundFailed, appErr.Message())
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("refund workflow: %w", err)
+	}
+
+	return &refund, nil
+}
+
+// StartSeatSwapWorkflow starts the workflow that executes a matched swap offer
+func (tc *TemporalClient) StartSeatSwapWorkflow(ctx context.Context, offerID string) (string, error) {
+	opts := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("seat-swap-%s", offerID),
+		TaskQueue: tc.taskQueue,
+	}
+
+	run, err := tc.client.ExecuteWorkflow(ctx, opts, workflows.SeatSwapWorkflow, temporalpkg.SeatSwapWorkflowInput{
+		OfferID: offerID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("start seat swap workflow: %w", err)
+	}
+
+	return run.GetID(), nil
+}
+
+// UpdateSeats changes a booking workflow's seats and returns the result once
+// the workflow has applied the change. Seats another order holds fail with
+// domain.ErrSeatUnavailable, as a *domain.SeatsUnavailableError naming the
+// seat when its lock was the conflict; seatless orders with domain.ErrSeatlessOrder;
+// orders no longer holding seats with domain.ErrHoldNotExtendable; flights
+// with bookings frozen with domain.ErrFlightFrozen; flights whose sales have
+// closed with domain.ErrSalesClosed.
+func (tc *TemporalClient) UpdateSeats(ctx context.Context, orderID string, seats []string) (*temporalpkg.SeatChangeResult, error) {
 	workflowID := fmt.Sprintf("booking-%s", orderID)
 
-	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{
+	var result temporalpkg.SeatChangeResult
+	handle, err := tc.client.UpdateWorkflow(ctx, workflowID, "", temporalpkg.UpdateChangeSeats, temporalpkg.SeatUpdateSignal{
 		Seats: seats,
 	})
+	if err == nil {
+		err = handle.Get(ctx, &result)
+	}
 	if err != nil {
-		return fmt.Errorf("signal update seats: %w", err)
+		return nil, seatChangeError(err)
 	}
 
-	return nil
+	return &result, nil
+}
+
+// seatChangeError maps a rejected or failed seat change to its domain error
+func seatChangeError(err error) error {
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		switch appErr.Type() {
+		case temporalpkg.ErrTypeSeatUnavailable:
+			var seatID string
+			if appErr.HasDetails() && appErr.Details(&seatID) == nil && seatID != "" {
+				return &domain.SeatsUnavailableError{SeatID: seatID}
+			}
+			return domain.ErrSeatUnavailable
+		case temporalpkg.ErrTypeSeatlessOrder:
+			return domain.ErrSeatlessOrder
+		case temporalpkg.ErrTypeNotHoldingSeats:
+			return domain.ErrHoldNotExtendable
+		case temporalpkg.ErrTypeFlightFrozen:
+			return domain.ErrFlightFrozen
+		case temporalpkg.ErrTypeSalesClosed:
+			return domain.ErrSalesClosed
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", domain.ErrUpdatePending, err)
+	}
+
+	return fmt.Errorf("update seats: %w", err)
 }
 
-// SignalProceedToPayment sends a proceed to payment signal with the payment code
-func (tc *TemporalClient) SignalProceedToPayment(ctx context.Context, orderID string, paymentCode string) error {
+// SignalProceedToPayment sends a proceed to payment signal with the payment
+// method and code, and the deposit terms when only a deposit is paid
+func (tc *TemporalClient) SignalProceedToPayment(ctx context.Context, orderID string, method domain.PaymentMethod, paymentCode string, deposit *domain.DepositTerms) error {
 	workflowID := fmt.Sprintf("booking-%s", orderID)
 
 	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{
 		PaymentCode: paymentCode,
+		Method:      method,
+		Deposit:     deposit,
 	})
 	if err != nil {
 		return fmt.Errorf("signal proceed to payment: %w", err)
@@ -95,6 +313,132 @@ func (tc *TemporalClient) SignalCancelBooking(ctx context.Context, orderID strin
 	return nil
 }
 
+// SignalExtendHold asks a booking workflow to refresh its seat hold
+func (tc *TemporalClient) SignalExtendHold(ctx context.Context, orderID string) error {
+	workflowID := fmt.Sprintf("booking-%s", orderID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalExtendHold, nil)
+	if err != nil {
+		return fmt.Errorf("signal extend hold: %w", err)
+	}
+
+	return nil
+}
+
+// SignalApprovePartial accepts the seats a partially reserved group booking holds
+func (tc *TemporalClient) SignalApprovePartial(ctx context.Context, orderID string) error {
+	workflowID := fmt.Sprintf("booking-%s", orderID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalApprovePartial, nil)
+	if err != nil {
+		return fmt.Errorf("signal approve partial: %w", err)
+	}
+
+	return nil
+}
+
+// SignalRespondUpsell answers a booking's open upsell offer
+func (tc *TemporalClient) SignalRespondUpsell(ctx context.Context, orderID string, response domain.UpsellResponse) error {
+	workflowID := fmt.Sprintf("booking-%s", orderID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalRespondUpsell, response)
+	if err != nil {
+		return fmt.Errorf("signal respond upsell: %w", err)
+	}
+
+	return nil
+}
+
+// SignalPaymentResult routes a gateway's payment result to the workflow that
+// took the payment, named by the result's reference
+func (tc *TemporalClient) SignalPaymentResult(ctx context.Context, result temporalpkg.PaymentResultSignal) error {
+	err := tc.client.SignalWorkflow(ctx, result.Reference, "", temporalpkg.SignalPaymentResult, result)
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return fmt.Errorf("%w: %s", domain.ErrPaymentNotAwaited, result.Reference)
+	}
+	if err != nil {
+		return fmt.Errorf("signal payment result: %w", err)
+	}
+
+	return nil
+}
+
+// SignalChallengeResponse answers a booking's open payment challenge with a
+// one-time code
+func (tc *TemporalClient) SignalChallengeResponse(ctx context.Context, orderID string, code string) error {
+	workflowID := fmt.Sprintf("booking-%s", orderID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalChallengeResponse, temporalpkg.ChallengeResponseSignal{
+		Code: code,
+	})
+	if err != nil {
+		return fmt.Errorf("signal challenge response: %w", err)
+	}
+
+	return nil
+}
+
+// SignalTripChallengeResponse answers a trip's open payment challenge with a
+// one-time code
+func (tc *TemporalClient) SignalTripChallengeResponse(ctx context.Context, tripID string, code string) error {
+	workflowID := fmt.Sprintf("trip-%s", tripID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalChallengeResponse, temporalpkg.ChallengeResponseSignal{
+		Code: code,
+	})
+	if err != nil {
+		return fmt.Errorf("signal trip challenge response: %w", err)
+	}
+
+	return nil
+}
+
+// SignalTripPayment sends the payment method and code for every leg to a
+// trip workflow
+func (tc *TemporalClient) SignalTripPayment(ctx context.Context, tripID string, method domain.PaymentMethod, paymentCode string) error {
+	workflowID := fmt.Sprintf("trip-%s", tripID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{
+		PaymentCode: paymentCode,
+		Method:      method,
+	})
+	if err != nil {
+		return fmt.Errorf("signal trip payment: %w", err)
+	}
+
+	return nil
+}
+
+// SignalCancelTrip sends a cancel signal to a trip workflow
+func (tc *TemporalClient) SignalCancelTrip(ctx context.Context, tripID string) error {
+	workflowID := fmt.Sprintf("trip-%s", tripID)
+
+	err := tc.client.SignalWorkflow(ctx, workflowID, "", temporalpkg.SignalCancelBooking, nil)
+	if err != nil {
+		return fmt.Errorf("signal cancel trip: %w", err)
+	}
+
+	return nil
+}
+
+// QueryTripStatus queries the current status of a trip workflow
+func (tc *TemporalClient) QueryTripStatus(ctx context.Context, tripID string) (*temporalpkg.TripStatusResponse, error) {
+	workflowID := fmt.Sprintf("trip-%s", tripID)
+
+	result, err := tc.client.QueryWorkflow(ctx, workflowID, "", temporalpkg.QueryTripStatus)
+	if err != nil {
+		return nil, fmt.Errorf("query trip status: %w", err)
+	}
+
+	var status temporalpkg.TripStatusResponse
+	if err := result.Get(&status); err != nil {
+		return nil, fmt.Errorf("decode query result: %w", err)
+	}
+
+	return &status, nil
+}
+
 // QueryBookingStatus queries the current status of a booking workflow
 func (tc *TemporalClient) QueryBookingStatus(ctx context.Context, orderID string) (*temporalpkg.BookingStatusResponse, error) {
 	workflowID := fmt.Sprintf("booking-%s", orderID)
@@ -111,3 +455,29 @@ func (tc *TemporalClient) QueryBookingStatus(ctx context.Context, orderID string
 
 	return &status, nil
 }
+
+// ListRunningBookingWorkflows returns the workflow IDs of all open BookingWorkflow executions
+func (tc *TemporalClient) ListRunningBookingWorkflows(ctx context.Context) ([]string, error) {
+	query := "WorkflowType = 'BookingWorkflow' AND ExecutionStatus = 'Running'"
+
+	var workflowIDs []string
+	var pageToken []byte
+	for {
+		resp, err := tc.client.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list booking workflows: %w", err)
+		}
+
+		for _, execution := range resp.Executions {
+			workflowIDs = append(workflowIDs, execution.Execution.WorkflowId)
+		}
+
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			return workflowIDs, nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// CreateTripInput contains the legs of a connecting itinerary, in travel order
+type CreateTripInput struct {
+	Legs []CreateOrderInput
+}
+
+// CreateTripOutput contains the result of trip creation
+type CreateTripOutput struct {
+	TripID     string
+	WorkflowID string
+	Status     domain.OrderStatus
+	Legs       []CreateOrderOutput
+}
+
+// CreateTrip books several connecting flights as one order. A parent
+// workflow books each leg and confirms them only together.
+func (s *BookingService) CreateTrip(ctx context.Context, input CreateTripInput) (*CreateTripOutput, error) {
+	if len(input.Legs) < domain.MinTripLegs || len(input.Legs) > domain.MaxTripLegs {
+		return nil, domain.ErrInvalidTrip
+	}
+
+	flights := make([]*domain.Flight, len(input.Legs))
+	for i, leg := range input.Legs {
+		if err := validatePassengers(leg.Passengers, leg.Seats, leg.SeatCount); err != nil {
+			return nil, err
+		}
+
+		flight, err := s.flightRepo.FindByID(ctx, leg.FlightID)
+		if err != nil {
+			return nil, err
+		}
+		flights[i] = flight
+	}
+	if err := domain.ValidateConnections(flights); err != nil {
+		return nil, err
+	}
+
+	tripInput := temporalpkg.TripWorkflowInput{TripID: uuid.New().String()}
+	output := &CreateTripOutput{TripID: tripInput.TripID, Status: domain.OrderStatusCreated}
+	expiresAt := time.Now().Add(15 * time.Minute)
+	for i, leg := range input.Legs {
+		legInput, err := s.bookingInput(flights[i], leg)
+		if err != nil {
+			return nil, err
+		}
+		tripInput.Legs = append(tripInput.Legs, legInput)
+		output.Legs = append(output.Legs, CreateOrderOutput{
+			OrderID:    legInput.OrderID,
+			WorkflowID: "booking-" + legInput.OrderID,
+			Status:     domain.OrderStatusCreated,
+			ExpiresAt:  expiresAt,
+		})
+	}
+
+	workflowID, err := s.temporalClient.StartTripWorkflow(ctx, tripInput)
+	if err != nil {
+		return nil, fmt.Errorf("start trip workflow: %w", err)
+	}
+	output.WorkflowID = workflowID
+
+	return output, nil
+}
+
+// GetTripStatus returns the status of a trip and each of its legs
+func (s *BookingService) GetTripStatus(ctx context.Context, tripID string) (*domain.TripStatusResponse, error) {
+	status, err := s.temporalClient.QueryTripStatus(ctx, tripID)
+	if err != nil {
+		// Finished trips are rebuilt from their legs in the database
+		return s.tripStatusFromOrders(ctx, tripID)
+	}
+
+	trip := &domain.TripStatusResponse{
+		TripID:          status.TripID,
+		Status:          status.Status,
+		PaymentAttempts: status.PaymentAttempts,
+		LastError:       status.LastError,
+
+		PaymentChallenge: status.PaymentChallenge,
+	}
+	for _, leg := range status.Legs {
+		legStatus, err := s.GetOrderStatus(ctx, leg.OrderID)
+		if err != nil {
+			// The leg's workflow has not created its order yet
+			legStatus = &domain.OrderStatusResponse{OrderID: leg.OrderID, Status: leg.Status, TripID: tripID}
+		}
+		trip.Legs = append(trip.Legs, *legStatus)
+	}
+
+	return trip, nil
+}
+
+func (s *BookingService) tripStatusFromOrders(ctx context.Context, tripID string) (*domain.TripStatusResponse, error) {
+	orders, err := s.orderRepo.FindByTripID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, domain.ErrTripNotFound
+	}
+
+	trip := &domain.TripStatusResponse{TripID: tripID}
+	statuses := make([]domain.OrderStatus, len(orders))
+	for i, order := range orders {
+		legStatus, err := s.GetOrderStatus(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		statuses[i] = legStatus.Status
+		trip.Legs = append(trip.Legs, *legStatus)
+		if trip.LastError == "" {
+			trip.LastError = legStatus.LastError
+		}
+	}
+	trip.Status = domain.TripStatus(statuses)
+
+	return trip, nil
+}
+
+// SubmitTripPayment submits one payment for every leg of a trip
+func (s *BookingService) SubmitTripPayment(ctx context.Context, tripID string, method domain.PaymentMethod, paymentCode string) error {
+	if err := checkPayment(method, paymentCode); err != nil {
+		return err
+	}
+
+	if err := s.temporalClient.SignalTripPayment(ctx, tripID, method, paymentCode); err != nil {
+		return fmt.Errorf("signal trip payment: %w", err)
+	}
+
+	return nil
+}
+
+// RespondTripChallenge answers the payment challenge open on a trip with the
+// one-time code the customer was sent
+func (s *BookingService) RespondTripChallenge(ctx context.Context, tripID string, code string) error {
+	if !domain.IsValidChallengeCode(code) {
+		return domain.ErrInvalidChallengeCode
+	}
+
+	status, err := s.temporalClient.QueryTripStatus(ctx, tripID)
+	if err != nil {
+		return domain.ErrTripNotFound
+	}
+	if status.PaymentChallenge == nil {
+		return domain.ErrNoPaymentChallenge
+	}
+
+	if err := s.temporalClient.SignalTripChallengeResponse(ctx, tripID, code); err != nil {
+		return fmt.Errorf("signal trip challenge response: %w", err)
+	}
+
+	return nil
+}
+
+// CancelTrip cancels a trip and every leg still holding seats
+func (s *BookingService) CancelTrip(ctx context.Context, tripID string) error {
+	if err := s.temporalClient.SignalCancelTrip(ctx, tripID); err != nil {
+		return fmt.Errorf("signal cancel trip: %w", err)
+	}
+
+	return nil
+}
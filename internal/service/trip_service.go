@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// TripService assembles the passenger-facing view of orders booked together
+type TripService struct {
+	orderRepo  repository.OrderRepository
+	flightRepo repository.FlightRepository
+}
+
+// NewTripService creates a new TripService
+func NewTripService(orderRepo repository.OrderRepository, flightRepo repository.FlightRepository) *TripService {
+	return &TripService{
+		orderRepo:  orderRepo,
+		flightRepo: flightRepo,
+	}
+}
+
+// GetTrip returns the consolidated itinerary of a trip, looked up by trip ID
+// or by the booking reference of any of its confirmed legs. A reference of an
+// order booked on its own returns that order as a one-leg trip.
+func (s *TripService) GetTrip(ctx context.Context, ref string) (*domain.TripSummary, error) {
+	orders, err := s.findOrders(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.TripSummary{}
+	if tripID := orders[0].TripID; tripID != nil {
+		summary.TripID = *tripID
+	}
+
+	statuses := make([]domain.OrderStatus, len(orders))
+	flights := make([]domain.Flight, len(orders))
+	for i, order := range orders {
+		leg, err := s.tripLeg(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		statuses[i] = leg.Status
+		flights[i] = leg.Flight
+		summary.Legs = append(summary.Legs, *leg)
+	}
+
+	directions := domain.LegDirections(flights)
+	for i := range summary.Legs {
+		summary.Legs[i].Direction = directions[i]
+	}
+	summary.Status = domain.TripStatus(statuses)
+
+	return summary, nil
+}
+
+// findOrders returns the orders of a trip in travel order
+func (s *TripService) findOrders(ctx context.Context, ref string) ([]*domain.Order, error) {
+	tripID := ref
+	if domain.IsValidBookingReference(ref) {
+		order, err := s.orderRepo.FindByBookingReference(ctx, ref)
+		if err != nil {
+			return nil, domain.ErrTripNotFound
+		}
+		if order.TripID == nil {
+			return []*domain.Order{order}, nil
+		}
+		tripID = *order.TripID
+	}
+
+	orders, err := s.orderRepo.FindByTripID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, domain.ErrTripNotFound
+	}
+
+	return orders, nil
+}
+
+// tripLeg describes one order; legs not yet confirmed have no receipt, so
+// they are described from the order and its flight
+func (s *TripService) tripLeg(ctx context.Context, order *domain.Order) (*domain.TripLeg, error) {
+	if order.Status == domain.OrderStatusConfirmed {
+		itinerary, err := s.orderRepo.FindItinerary(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.TripLeg{Itinerary: *itinerary, Status: order.Status}, nil
+	}
+
+	flight, err := s.flightRepo.FindByID(ctx, order.FlightID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TripLeg{
+		Itinerary: domain.Itinerary{
+			OrderID: order.ID,
+			Flight:  *flight,
+			Seats:   order.Seats,
+			Price:   order.Price,
+		},
+		Status: order.Status,
+	}, nil
+}
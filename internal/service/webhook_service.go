@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// WebhookService manages outbound webhook subscriptions
+type WebhookService struct {
+	webhookRepo *repository.WebhookRepo
+}
+
+// NewWebhookService creates a new WebhookService
+func NewWebhookService(webhookRepo *repository.WebhookRepo) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo}
+}
+
+// Subscribe registers an endpoint for events. An empty secret is replaced
+// with a generated one, which the caller must keep to verify signatures.
+func (s *WebhookService) Subscribe(ctx context.Context, endpoint, secret string, events []domain.WebhookEvent) (*domain.WebhookSubscription, error) {
+	if err := validateSubscription(endpoint, events); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("generate webhook secret: %w", err)
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	sub := &domain.WebhookSubscription{URL: endpoint, Secret: secret, Events: events}
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// ListSubscriptions returns active subscriptions
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.webhookRepo.ListSubscriptions(ctx)
+}
+
+// Unsubscribe deactivates a subscription and discards its pending deliveries
+func (s *WebhookService) Unsubscribe(ctx context.Context, id string) error {
+	return s.webhookRepo.DeactivateSubscription(ctx, id)
+}
+
+// validateSubscription requires an absolute http(s) URL and known, unique events
+func validateSubscription(endpoint string, events []domain.WebhookEvent) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url %q: %w", endpoint, domain.ErrInvalidWebhook)
+	}
+
+	if len(events) == 0 {
+		return fmt.Errorf("no events: %w", domain.ErrInvalidWebhook)
+	}
+	for i, e := range events {
+		if !slices.Contains(domain.AllWebhookEvents, e) || slices.Contains(events[:i], e) {
+			return fmt.Errorf("event %q: %w", e, domain.ErrInvalidWebhook)
+		}
+	}
+
+	return nil
+}
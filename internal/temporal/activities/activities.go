@@ -3,6 +3,7 @@ package activities
 import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/client"
 
 	"github.com/flight-booking-system/internal/config"
 	"github.com/flight-booking-system/internal/repository"
@@ -10,9 +11,21 @@ import (
 
 // BookingActivities contains all activities for the booking workflow
 type BookingActivities struct {
-	orderRepo    *repository.OrderRepo
-	flightRepo   *repository.FlightRepo
-	seatLockRepo *repository.SeatLockRepo
+	orderRepo    repository.OrderRepository
+	flightRepo   repository.FlightRepository
+	seatLockRepo repository.SeatLocker
+	swapRepo     *repository.SwapRepo
+	notifyRepo   *repository.NotificationRepo
+	webhookRepo  *repository.WebhookRepo
+	opsRepo      *repository.OpsRepo
+	refundRepo   *repository.RefundRepo
+	paymentRepo  *repository.PaymentRepo
+	eventRepo    *repository.OrderEventRepo
+	seatEvents   *repository.SeatEventRepo
+	temporal     client.Client
+	readCache    *readCache
+	sim          *simulation
+	payments     PaymentProvider
 	cfg          *config.BookingConfig
 }
 
@@ -20,12 +33,26 @@ type BookingActivities struct {
 func NewBookingActivities(
 	pool *pgxpool.Pool,
 	redisClient *redis.Client,
+	temporalClient client.Client,
 	cfg *config.BookingConfig,
 ) *BookingActivities {
+	sim := newSimulation(cfg.SimulationSeed)
 	return &BookingActivities{
 		orderRepo:    repository.NewOrderRepo(pool),
 		flightRepo:   repository.NewFlightRepo(pool),
 		seatLockRepo: repository.NewSeatLockRepo(redisClient),
+		swapRepo:     repository.NewSwapRepo(pool),
+		notifyRepo:   repository.NewNotificationRepo(pool),
+		webhookRepo:  repository.NewWebhookRepo(pool),
+		opsRepo:      repository.NewOpsRepo(pool),
+		refundRepo:   repository.NewRefundRepo(pool),
+		paymentRepo:  repository.NewPaymentRepo(pool),
+		eventRepo:    repository.NewOrderEventRepo(pool),
+		seatEvents:   repository.NewSeatEventRepo(redisClient),
+		temporal:     temporalClient,
+		readCache:    newReadCache(cfg.ActivityCacheTTL),
+		sim:          sim,
+		payments:     newPaymentProvider(cfg, sim),
 		cfg:          cfg,
 	}
 }
@@ -0,0 +1,33 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// ArchiveOrdersInput bounds one archival batch
+type ArchiveOrdersInput struct {
+	FinishedBefore time.Time // orders that last changed after it are kept
+	Limit          int
+}
+
+// ArchiveOrders moves one batch of finished orders past their retention into
+// orders_archive and prunes their delivered webhooks and lock repair records.
+// A batch is one transaction, so a retry archives whatever is left.
+func (a *BookingActivities) ArchiveOrders(ctx context.Context, input ArchiveOrdersInput) (domain.OrderArchival, error) {
+	archived, err := a.orderRepo.Archive(ctx, input.FinishedBefore, input.Limit)
+	if err != nil {
+		return archived, fmt.Errorf("archive orders: %w", err)
+	}
+
+	if archived.Orders > 0 {
+		activity.GetLogger(ctx).Info("Archived orders", "orders", archived.Orders, "pruned", archived.Pruned,
+			"finishedBefore", input.FinishedBefore)
+	}
+	return archived, nil
+}
@@ -0,0 +1,30 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flight-booking-system/internal/boardingpass"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// GenerateBoardingPassInput contains parameters for boarding pass generation
+type GenerateBoardingPassInput struct {
+	OrderID string
+}
+
+// GenerateBoardingPass renders the boarding pass of a confirmed order, one
+// panel per seat with a QR code of the booking reference and seat, and stores it
+func (a *BookingActivities) GenerateBoardingPass(ctx context.Context, input GenerateBoardingPassInput) error {
+	itinerary, err := a.orderRepo.FindItinerary(ctx, input.OrderID)
+	if err != nil {
+		return fmt.Errorf("load itinerary: %w", err)
+	}
+
+	image, err := boardingpass.Render(domain.BoardingPasses(*itinerary))
+	if err != nil {
+		return err
+	}
+
+	return a.orderRepo.SaveBoardingPass(ctx, input.OrderID, image)
+}
@@ -0,0 +1,67 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// CheckInSeatsInput contains the seats an order checks in with
+type CheckInSeatsInput struct {
+	OrderID string
+	Seats   []string
+}
+
+// AssignCheckInSeats seats a confirmed order on input.Seats: a seatless
+// order's cabin capacity moves onto them, a seated order moves from its
+// current seats. Passengers follow their seats. Seats taken concurrently
+// fail without retry so the caller can pick again.
+func (a *BookingActivities) AssignCheckInSeats(ctx context.Context, input CheckInSeatsInput) error {
+	order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+	if err != nil {
+		return fmt.Errorf("load order: %w", err)
+	}
+	if order.CabinSeats == 0 && slices.Equal(order.Seats, input.Seats) {
+		// Already applied by an earlier attempt
+		return nil
+	}
+
+	passengers, err := a.orderRepo.FindPassengers(ctx, input.OrderID)
+	if err != nil {
+		return fmt.Errorf("load passengers: %w", err)
+	}
+	passengers = domain.AssignSeats(passengers, input.Seats)
+
+	if order.CabinSeats > 0 {
+		err = a.orderRepo.CheckIn(ctx, order, input.Seats, passengers)
+	} else {
+		err = a.orderRepo.ReassignSeats(ctx, order, input.Seats, passengers)
+	}
+	if errors.Is(err, domain.ErrSeatUnavailable) {
+		return temporalpkg.NewSeatUnavailableError(strings.Join(input.Seats, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("assign check-in seats: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteCheckInInput identifies the order finishing check-in
+type CompleteCheckInInput struct {
+	OrderID string
+}
+
+// CompleteCheckIn records that the order has checked in
+func (a *BookingActivities) CompleteCheckIn(ctx context.Context, input CompleteCheckInInput) error {
+	if err := a.orderRepo.MarkCheckedIn(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("complete check-in: %w", err)
+	}
+
+	return nil
+}
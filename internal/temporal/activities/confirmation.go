@@ -0,0 +1,142 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// The activities below are the steps of a booking confirmation saga. Each
+// forward step is idempotent and has a compensation that undoes it, so the
+// workflow can unwind a confirmation that fails part way. They stay
+// registered for bookings replaying confirm version 3; newer ones confirm in
+// one transaction with ConfirmOrder.
+
+// MarkOrderConfirmed saves the passengers and confirms the order, recognizing
+// its revenue. The persisted quote must match the price being confirmed.
+func (a *BookingActivities) MarkOrderConfirmed(ctx context.Context, input ConfirmOrderInput) error {
+	if err := ensureBudget(ctx, 3); err != nil {
+		return fmt.Errorf("mark order confirmed: %w", err)
+	}
+
+	var order *domain.Order
+	err := runStep(ctx, "load order", func(ctx context.Context) (err error) {
+		order, err = a.orderRepo.FindByID(ctx, input.OrderID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if order.Price.QuoteID != input.Price.QuoteID || order.TotalPriceCents != input.Price.TotalCents {
+		return temporalpkg.NewPriceMismatchError(input.OrderID)
+	}
+
+	// Passengers are written before confirming so a confirmed order always has them
+	err = runStep(ctx, "save passengers", func(ctx context.Context) error {
+		return a.orderRepo.SavePassengers(ctx, input.OrderID, input.Passengers)
+	})
+	if err != nil {
+		return err
+	}
+
+	// A reference collision fails the step and the activity retries with a
+	// new one; an order finished since it was loaded is not retried
+	err = runStep(ctx, "confirm order", func(ctx context.Context) error {
+		ref, err := domain.NewBookingReference()
+		if err != nil {
+			return fmt.Errorf("generate booking reference: %w", err)
+		}
+		return a.orderRepo.Confirm(ctx, input.OrderID, ref)
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return temporalpkg.NewVersionConflictError(input.OrderID)
+	}
+	return err
+}
+
+// UnconfirmOrderInput identifies the order to unconfirm
+type UnconfirmOrderInput struct {
+	OrderID string
+}
+
+// UnconfirmOrder compensates MarkOrderConfirmed
+func (a *BookingActivities) UnconfirmOrder(ctx context.Context, input UnconfirmOrderInput) error {
+	if err := a.orderRepo.Unconfirm(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("unconfirm order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
+
+// BookOrderSeatsInput contains the seats an order books or unbooks
+type BookOrderSeatsInput struct {
+	OrderID  string
+	FlightID string
+	Seats    []string
+}
+
+// BookOrderSeats marks the order's reserved seats as booked. Seats the
+// database holds for another order fail it without retries.
+func (a *BookingActivities) BookOrderSeats(ctx context.Context, input BookOrderSeatsInput) error {
+	err := a.flightRepo.BookSeats(ctx, input.FlightID, input.Seats, input.OrderID)
+	if errors.Is(err, domain.ErrDoubleBooking) {
+		return temporalpkg.NewDoubleBookingError(input.OrderID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("book seats for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
+
+// UnbookSeats compensates BookOrderSeats, leaving the seats reserved by the
+// order so the booking's seat release can free them
+func (a *BookingActivities) UnbookSeats(ctx context.Context, input BookOrderSeatsInput) error {
+	if err := a.flightRepo.UnbookSeats(ctx, input.FlightID, input.Seats, input.OrderID); err != nil {
+		return fmt.Errorf("unbook seats for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
+
+// CountBookedSeatsInput names the order whose seats are counted
+type CountBookedSeatsInput struct {
+	OrderID  string
+	FlightID string
+	Count    int // seats plus seatless capacity; unused since seat counts are derived
+}
+
+// CountBookedSeats marks the order's seats counted and, with dynamic pricing
+// on confirmation, reprices the flight. The flight's available count already
+// dropped when the order was confirmed and its seats booked.
+func (a *BookingActivities) CountBookedSeats(ctx context.Context, input CountBookedSeatsInput) error {
+	if err := a.orderRepo.CountSeats(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("count seats for order %s: %w", input.OrderID, err)
+	}
+
+	a.repriceOnConfirmation(ctx, input.FlightID, input.OrderID)
+	return nil
+}
+
+// UncountBookedSeats compensates CountBookedSeats
+func (a *BookingActivities) UncountBookedSeats(ctx context.Context, input CountBookedSeatsInput) error {
+	if err := a.orderRepo.UncountSeats(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("uncount seats for order %s: %w", input.OrderID, err)
+	}
+
+	a.repriceOnConfirmation(ctx, input.FlightID, input.OrderID)
+	return nil
+}
+
+// ReleaseBookedSeatLocks drops the Redis locks of seats that are now booked in
+// Postgres. It runs after the saga completes and nothing undoes it.
+func (a *BookingActivities) ReleaseBookedSeatLocks(ctx context.Context, input BookOrderSeatsInput) error {
+	if err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID, 0); err != nil {
+		return fmt.Errorf("release booked seat locks for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/mocks"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// These tests fail the repository write behind each confirmation step and
+// check what the activity reports, and that the compensations and refund the
+// workflow then runs reach the repositories and the payment provider.
+
+var errUnavailable = errors.New("connection reset")
+
+// runActivity runs one of a's activities and returns its error
+func runActivity(a *BookingActivities, fn interface{}, input interface{}) error {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(a)
+	_, err := env.ExecuteActivity(fn, input)
+	return err
+}
+
+// failedWith reports whether an activity error carries cause; the test
+// environment keeps only the messages of the errors it wraps
+func failedWith(err, cause error) bool {
+	return err != nil && strings.Contains(err.Error(), cause.Error())
+}
+
+// errorType returns the type of the application error err carries, if any
+func errorType(err error) string {
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.Type()
+	}
+	return ""
+}
+
+// nonRetryable reports whether err stops the activity's retries
+func nonRetryable(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.NonRetryable()
+}
+
+func TestConfirmOrder_TransactionFails(t *testing.T) {
+	tests := []struct {
+		name     string
+		txErr    error
+		wantType string // empty for a retryable failure
+	}{
+		{name: "seats held by another order", txErr: fmt.Errorf("book seats [3B]: %w", domain.ErrDoubleBooking), wantType: temporalpkg.ErrTypeDoubleBooking},
+		{name: "flight oversold", txErr: fmt.Errorf("book seats: %w", domain.ErrInsufficientSeats)},
+		{name: "database unavailable", txErr: errUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, orders, locks := newConfirmActivities(t)
+			locks.On("VerifyLocks", mock.Anything, "flight-1", confirmInput.Seats, "order-1", int64(7)).Return(nil)
+			orders.On("ConfirmOrderTx", mock.Anything, mock.Anything).Return(tt.txErr)
+
+			err := runActivity(a, a.ConfirmOrder, confirmInput)
+			if !failedWith(err, tt.txErr) {
+				t.Fatalf("ConfirmOrder error = %v, want it to carry %v", err, tt.txErr)
+			}
+			if tt.wantType == "" && nonRetryable(err) {
+				t.Errorf("ConfirmOrder error %v is not retried", err)
+			}
+			if tt.wantType != "" && errorType(err) != tt.wantType {
+				t.Errorf("error type %q, want %q", errorType(err), tt.wantType)
+			}
+
+			// The transaction rolled back, so the seats are still the
+			// order's to release once the workflow compensates
+			locks.AssertNotCalled(t, "ReleaseLocks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestConfirmationSaga_StepFails(t *testing.T) {
+	t.Run("mark confirmed", func(t *testing.T) {
+		a, orders, _ := newConfirmActivities(t)
+		orders.On("SavePassengers", mock.Anything, "order-1", mock.Anything).Return(nil)
+		orders.On("Confirm", mock.Anything, "order-1", mock.Anything).Return(errUnavailable)
+
+		if err := runActivity(a, a.MarkOrderConfirmed, confirmInput); !failedWith(err, errUnavailable) {
+			t.Errorf("MarkOrderConfirmed error = %v, want %v", err, errUnavailable)
+		}
+	})
+
+	t.Run("mark confirmed at a changed price", func(t *testing.T) {
+		a, orders, _ := newConfirmActivities(t)
+		input := confirmInput
+		input.Price.TotalCents = 25000
+
+		err := runActivity(a, a.MarkOrderConfirmed, input)
+		if typ := errorType(err); typ != temporalpkg.ErrTypePriceMismatch {
+			t.Errorf("MarkOrderConfirmed error type %q, want %q", typ, temporalpkg.ErrTypePriceMismatch)
+		}
+		orders.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("book seats held by another order", func(t *testing.T) {
+		flights := mocks.NewFlightRepository(t)
+		flights.On("BookSeats", mock.Anything, "flight-1", []string{"3A", "3B"}, "order-1").
+			Return(fmt.Errorf("book seats [3B]: %w", domain.ErrDoubleBooking))
+		a := &BookingActivities{flightRepo: flights}
+
+		err := runActivity(a, a.BookOrderSeats, BookOrderSeatsInput{OrderID: "order-1", FlightID: "flight-1", Seats: []string{"3A", "3B"}})
+		if typ := errorType(err); typ != temporalpkg.ErrTypeDoubleBooking {
+			t.Errorf("BookOrderSeats error type %q, want %q", typ, temporalpkg.ErrTypeDoubleBooking)
+		}
+	})
+
+	t.Run("book seats", func(t *testing.T) {
+		flights := mocks.NewFlightRepository(t)
+		flights.On("BookSeats", mock.Anything, "flight-1", []string{"3A", "3B"}, "order-1").Return(errUnavailable)
+		a := &BookingActivities{flightRepo: flights}
+
+		err := runActivity(a, a.BookOrderSeats, BookOrderSeatsInput{OrderID: "order-1", FlightID: "flight-1", Seats: []string{"3A", "3B"}})
+		if !failedWith(err, errUnavailable) || nonRetryable(err) {
+			t.Errorf("BookOrderSeats error = %v, want a retryable %v", err, errUnavailable)
+		}
+	})
+
+	// The flight is not repriced for seats that were never counted
+	t.Run("count seats", func(t *testing.T) {
+		orders := mocks.NewOrderRepository(t)
+		orders.On("CountSeats", mock.Anything, "order-1").Return(fmt.Errorf("mark seats counted: %w", domain.ErrInsufficientSeats))
+		a := &BookingActivities{orderRepo: orders}
+
+		err := runActivity(a, a.CountBookedSeats, CountBookedSeatsInput{OrderID: "order-1", FlightID: "flight-1", Count: 2})
+		if !failedWith(err, domain.ErrInsufficientSeats) {
+			t.Errorf("CountBookedSeats error = %v, want %v", err, domain.ErrInsufficientSeats)
+		}
+	})
+}
+
+func TestConfirmationSaga_Compensations(t *testing.T) {
+	orders := mocks.NewOrderRepository(t)
+	flights := mocks.NewFlightRepository(t)
+	orders.On("Unconfirm", mock.Anything, "order-1").Return(nil).Once()
+	flights.On("UnbookSeats", mock.Anything, "flight-1", []string{"3A", "3B"}, "order-1").Return(nil).Once()
+	a := &BookingActivities{orderRepo: orders, flightRepo: flights}
+
+	if err := runActivity(a, a.UnbookSeats, BookOrderSeatsInput{OrderID: "order-1", FlightID: "flight-1", Seats: []string{"3A", "3B"}}); err != nil {
+		t.Fatalf("UnbookSeats: %v", err)
+	}
+	if err := runActivity(a, a.UnconfirmOrder, UnconfirmOrderInput{OrderID: "order-1"}); err != nil {
+		t.Fatalf("UnconfirmOrder: %v", err)
+	}
+}
+
+// refundingPayments records the refunds it is asked for
+type refundingPayments struct {
+	PaymentProvider
+	refunds []RefundRequest
+	err     error
+}
+
+func (p *refundingPayments) Refund(_ context.Context, req RefundRequest) error {
+	p.refunds = append(p.refunds, req)
+	return p.err
+}
+
+func TestConfirmationFailure_Refund(t *testing.T) {
+	t.Run("payment", func(t *testing.T) {
+		payments := &refundingPayments{}
+		a := &BookingActivities{payments: payments}
+
+		err := runActivity(a, a.RefundPayment, RefundPaymentInput{OrderID: "order-1", PaymentCode: "12345", AmountCents: 20000})
+		if err != nil {
+			t.Fatalf("RefundPayment: %v", err)
+		}
+		if len(payments.refunds) != 1 || payments.refunds[0].AmountCents != 20000 || payments.refunds[0].Method != domain.PaymentMethodCard {
+			t.Errorf("refunds = %+v, want 20000 cents back to the card", payments.refunds)
+		}
+	})
+
+	t.Run("payment declined by the provider", func(t *testing.T) {
+		payments := &refundingPayments{err: errUnavailable}
+		a := &BookingActivities{payments: payments}
+
+		if err := runActivity(a, a.RefundPayment, RefundPaymentInput{OrderID: "order-1", PaymentCode: "12345", AmountCents: 20000}); err == nil {
+			t.Error("RefundPayment succeeded, want the provider's error")
+		}
+	})
+
+	// Revenue a partial confirmation recognized is taken back with the refund
+	t.Run("order", func(t *testing.T) {
+		orders := mocks.NewOrderRepository(t)
+		orders.On("FindByID", mock.Anything, "order-1").Return(&domain.Order{ID: "order-1", Status: domain.OrderStatusConfirmed, Version: 4}, nil)
+		refunded := orders.On("Refund", mock.Anything, "order-1", "confirmation failed", 4).Return(nil).Once()
+		orders.On("ReverseRevenue", mock.Anything, "order-1").Return(nil).Once().NotBefore(refunded)
+		a := &BookingActivities{orderRepo: orders}
+
+		if err := runActivity(a, a.RefundOrder, RefundOrderInput{OrderID: "order-1", Reason: "confirmation failed"}); err != nil {
+			t.Fatalf("RefundOrder: %v", err)
+		}
+	})
+}
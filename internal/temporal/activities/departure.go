@@ -0,0 +1,110 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// FindDepartingFlightsInput bounds the departure scan
+type FindDepartingFlightsInput struct {
+	Horizon time.Duration
+}
+
+// FindDepartingFlightsOutput lists flights due a departure workflow
+type FindDepartingFlightsOutput struct {
+	FlightIDs []string
+}
+
+// FindDepartingFlights returns flights departing within the horizon that
+// have not been cancelled or marked departed, along with any whose departure
+// passed without being finalized
+func (a *BookingActivities) FindDepartingFlights(ctx context.Context, input FindDepartingFlightsInput) (FindDepartingFlightsOutput, error) {
+	flightIDs, err := a.flightRepo.FindUnfinalizedIDs(ctx, time.Now().Add(input.Horizon))
+	if err != nil {
+		return FindDepartingFlightsOutput{}, err
+	}
+	return FindDepartingFlightsOutput{FlightIDs: flightIDs}, nil
+}
+
+// FlightDepartureInput identifies a flight
+type FlightDepartureInput struct {
+	FlightID string
+}
+
+// GetFlightDepartureOutput is a flight's current departure time and status
+type GetFlightDepartureOutput struct {
+	DepartureTime time.Time
+	Status        domain.FlightStatus
+}
+
+// GetFlightDeparture reads when a flight departs; delays move it, so the
+// departure workflow reads it again after every wait
+func (a *BookingActivities) GetFlightDeparture(ctx context.Context, input FlightDepartureInput) (GetFlightDepartureOutput, error) {
+	flight, err := a.flightRepo.FindByID(ctx, input.FlightID)
+	if err != nil {
+		return GetFlightDepartureOutput{}, err
+	}
+	return GetFlightDepartureOutput{DepartureTime: flight.DepartureTime, Status: flight.Status}, nil
+}
+
+// CloseFlightSales stops new bookings and seat changes on a flight
+func (a *BookingActivities) CloseFlightSales(ctx context.Context, input FlightDepartureInput) error {
+	if err := a.flightRepo.CloseSales(ctx, input.FlightID); err != nil {
+		return fmt.Errorf("close sales on flight %s: %w", input.FlightID, err)
+	}
+	activity.GetLogger(ctx).Info("Flight sales closed", "flightID", input.FlightID)
+	return nil
+}
+
+// BuildFlightManifestOutput summarizes the manifest saved
+type BuildFlightManifestOutput struct {
+	Passengers    int
+	SeatlessCount int
+}
+
+// BuildFlightManifest lists the passengers and seats of a flight's confirmed
+// orders and saves them as its manifest, replacing any earlier one
+func (a *BookingActivities) BuildFlightManifest(ctx context.Context, input FlightDepartureInput) (BuildFlightManifestOutput, error) {
+	flight, err := a.flightRepo.FindByID(ctx, input.FlightID)
+	if err != nil {
+		return BuildFlightManifestOutput{}, err
+	}
+
+	entries, seatless, err := a.orderRepo.FindManifestEntries(ctx, input.FlightID)
+	if err != nil {
+		return BuildFlightManifestOutput{}, err
+	}
+
+	manifest := domain.Manifest{
+		FlightID:      flight.ID,
+		FlightNumber:  flight.FlightNumber,
+		DepartureTime: flight.DepartureTime,
+		Passengers:    entries,
+		SeatlessCount: seatless,
+		GeneratedAt:   time.Now(),
+	}
+	if err := a.flightRepo.SaveManifest(ctx, manifest); err != nil {
+		return BuildFlightManifestOutput{}, err
+	}
+
+	return BuildFlightManifestOutput{Passengers: len(entries), SeatlessCount: seatless}, nil
+}
+
+// MarkFlightDepartedOutput reports whether the flight was marked departed
+type MarkFlightDepartedOutput struct {
+	Departed bool // false when the flight was cancelled first
+}
+
+// MarkFlightDeparted sets the flight's status to departed unless it was cancelled
+func (a *BookingActivities) MarkFlightDeparted(ctx context.Context, input FlightDepartureInput) (MarkFlightDepartedOutput, error) {
+	departed, err := a.flightRepo.MarkDeparted(ctx, input.FlightID)
+	if err != nil {
+		return MarkFlightDepartedOutput{}, fmt.Errorf("mark flight %s departed: %w", input.FlightID, err)
+	}
+	return MarkFlightDepartedOutput{Departed: departed}, nil
+}
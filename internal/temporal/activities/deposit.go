@@ -0,0 +1,139 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// RecordDepositInput is the deposit an order is paying instead of its price
+type RecordDepositInput struct {
+	OrderID string
+	Deposit domain.Deposit
+}
+
+// RecordDeposit records on the order the deposit it pays and when its
+// balance is due
+func (a *BookingActivities) RecordDeposit(ctx context.Context, input RecordDepositInput) error {
+	if err := a.orderRepo.RecordDeposit(ctx, input.OrderID, input.Deposit); err != nil {
+		return fmt.Errorf("record deposit for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
+
+// ChargeBalanceInput identifies the balance to charge
+type ChargeBalanceInput struct {
+	OrderID     string
+	Method      domain.PaymentMethod
+	PaymentCode string
+	AmountCents int64
+	Seed        *int64
+}
+
+// ChargeBalanceOutput reports whether the balance was charged
+type ChargeBalanceOutput struct {
+	Charged bool // false when the order no longer owes it: paid, refunded or failed meanwhile
+}
+
+// ChargeBalance takes a deposit order's balance with the payment method and
+// code the deposit was paid with, through the configured PaymentProvider, and marks
+// it paid. The customer is not there to answer, so an authorization the
+// provider leaves pending or challenges is voided and declined.
+//   - Declined payments fail with a PAYMENT_DECLINED error
+//   - Other provider errors are retried by Temporal
+func (a *BookingActivities) ChargeBalance(ctx context.Context, input ChargeBalanceInput) (ChargeBalanceOutput, error) {
+	var output ChargeBalanceOutput
+	logger := activity.GetLogger(ctx)
+
+	order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+	if err != nil {
+		return output, fmt.Errorf("load order for balance: %w", err)
+	}
+	if order.Status != domain.OrderStatusConfirmed || order.Deposit == nil || order.Deposit.BalancePaidAt != nil {
+		logger.Info("Order owes no balance", "orderID", input.OrderID, "status", order.Status)
+		return output, nil
+	}
+	if !input.Method.ValidCode(input.PaymentCode) {
+		return output, temporalpkg.NewInvalidPaymentCodeError()
+	}
+
+	info := activity.GetInfo(ctx)
+	auth, captured, err := a.authorizeCharge(ctx, PaymentRequest{
+		OrderID:     input.OrderID,
+		Method:      input.Method.OrCard(),
+		PaymentCode: input.PaymentCode,
+		AmountCents: input.AmountCents,
+		Attempt:     int(info.Attempt),
+		Seed:        input.Seed,
+		Reference:   info.WorkflowExecution.ID,
+	})
+	if err != nil {
+		return output, paymentError(err)
+	}
+	if auth.Pending || auth.ChallengeRequired {
+		if voidErr := a.payments.Void(ctx, auth); voidErr != nil {
+			logger.Warn("Failed to void balance authorization", "orderID", input.OrderID, "authorization", auth.ID, "error", voidErr)
+		} else if recordErr := a.paymentRepo.RecordProviderRef(ctx, auth.ID, domain.PaymentVoided); recordErr != nil {
+			logger.Warn("Failed to record voided balance authorization", "orderID", input.OrderID, "authorization", auth.ID, "error", recordErr)
+		}
+		return output, temporalpkg.NewPaymentDeclinedError("balance charge needs the customer to confirm it")
+	}
+	if !captured {
+		if _, err := a.capturePayment(ctx, input.OrderID, auth); err != nil {
+			return output, err
+		}
+	}
+
+	if err := a.orderRepo.MarkBalancePaid(ctx, input.OrderID); err != nil {
+		return output, fmt.Errorf("mark balance paid for order %s: %w", input.OrderID, err)
+	}
+
+	logger.Info("Balance charged", "orderID", input.OrderID, "amountCents", input.AmountCents)
+	output.Charged = true
+	return output, nil
+}
+
+// FailUnpaidOrderInput identifies an order whose balance could not be charged
+type FailUnpaidOrderInput struct {
+	OrderID string
+	Reason  string
+}
+
+// FailUnpaidOrder fails a deposit order whose balance could not be charged:
+// the deposit is kept, the order's revenue reversed and its seats put back on
+// sale, and a FAILED webhook event queued. Every step is guarded by the
+// order's ID, so a retry is safe.
+func (a *BookingActivities) FailUnpaidOrder(ctx context.Context, input FailUnpaidOrderInput) error {
+	failed, err := a.orderRepo.FailUnpaidBalance(ctx, input.OrderID, input.Reason)
+	if err != nil {
+		return err
+	}
+	if !failed {
+		// A retry after the status write still releases the seats below
+		order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+		if err != nil {
+			return fmt.Errorf("load unpaid order: %w", err)
+		}
+		if order.Status != domain.OrderStatusFailed {
+			return nil
+		}
+	}
+
+	if err := a.releaseConfirmedSeats(ctx, input.OrderID); err != nil {
+		return err
+	}
+
+	if failed {
+		if err := a.PublishOrderEvent(ctx, PublishOrderEventInput{OrderID: input.OrderID, Event: domain.EventFailed}); err != nil {
+			return err
+		}
+		activity.GetLogger(ctx).Info("Failed order with unpaid balance", "orderID", input.OrderID, "reason", input.Reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// DisruptFlightsInput contains the policy of one disruption generator run
+type DisruptFlightsInput struct {
+	Policy  domain.DisruptionPolicy
+	Horizon time.Duration
+}
+
+// DisruptFlightsOutput lists the disruptions applied
+type DisruptFlightsOutput struct {
+	Disruptions []domain.FlightDisruption
+}
+
+// DisruptFlights rolls the policy for every flight departing within the
+// horizon and applies the delays and cancellations it draws. Rolling here
+// rather than in the workflow keeps the workflow deterministic.
+func (a *BookingActivities) DisruptFlights(ctx context.Context, input DisruptFlightsInput) (DisruptFlightsOutput, error) {
+	var output DisruptFlightsOutput
+
+	now := time.Now()
+	flightIDs, err := a.flightRepo.FindUpcomingIDs(ctx, now, now.Add(input.Horizon))
+	if err != nil {
+		return output, err
+	}
+
+	logger := activity.GetLogger(ctx)
+	for _, flightID := range flightIDs {
+		disruption, ok := input.Policy.Disrupt(flightID, a.sim.Float64(), a.sim.Float64())
+		if !ok {
+			continue
+		}
+
+		applied, err := a.flightRepo.ApplyDisruption(ctx, disruption)
+		if err != nil {
+			return output, fmt.Errorf("disrupt flight %s: %w", flightID, err)
+		}
+		if applied {
+			logger.Info("Flight disrupted", "flightID", flightID, "status", disruption.Status, "delay", disruption.Delay)
+			output.Disruptions = append(output.Disruptions, disruption)
+		}
+	}
+
+	return output, nil
+}
+
+// FindFlightOrdersInput identifies a flight
+type FindFlightOrdersInput struct {
+	FlightID string
+}
+
+// FlightOrder is an order affected by a disruption
+type FlightOrder struct {
+	OrderID string
+	Status  domain.OrderStatus
+}
+
+// FindFlightOrdersOutput lists the orders still booking or confirmed on a flight
+type FindFlightOrdersOutput struct {
+	Orders []FlightOrder
+}
+
+// FindFlightOrders returns the orders a disruption of the flight affects
+func (a *BookingActivities) FindFlightOrders(ctx context.Context, input FindFlightOrdersInput) (FindFlightOrdersOutput, error) {
+	var output FindFlightOrdersOutput
+
+	orders, err := a.orderRepo.FindOpenByFlight(ctx, input.FlightID)
+	if err != nil {
+		return output, err
+	}
+	for _, order := range orders {
+		output.Orders = append(output.Orders, FlightOrder{OrderID: order.ID, Status: order.Status})
+	}
+
+	return output, nil
+}
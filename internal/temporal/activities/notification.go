@@ -0,0 +1,90 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// NotifyOrderInput contains parameters for an order notification
+type NotifyOrderInput struct {
+	OrderID  string
+	Category domain.NotificationCategory
+	Status   domain.OrderStatus
+	Message  string
+}
+
+// NotifyOrderOutput lists the channels the notification was delivered on
+type NotifyOrderOutput struct {
+	Channels []domain.NotificationChannel
+}
+
+// NotifyOrder delivers a notification on each channel the order's preferences
+// allow for the category. Preferences are re-read on every call so an
+// unsubscribe takes effect before the next delivery. Delivery is simulated by
+// logging.
+func (a *BookingActivities) NotifyOrder(ctx context.Context, input NotifyOrderInput) (NotifyOrderOutput, error) {
+	var output NotifyOrderOutput
+
+	prefs, err := a.notifyRepo.Find(ctx, input.OrderID)
+	if err != nil {
+		return output, fmt.Errorf("load notification preferences: %w", err)
+	}
+	if prefs == nil {
+		defaults := domain.DefaultNotificationPreferences(input.OrderID)
+		prefs = &defaults
+	}
+
+	logger := activity.GetLogger(ctx)
+	for _, channel := range domain.AllNotificationChannels {
+		if !prefs.Allows(channel, input.Category) {
+			continue
+		}
+		logger.Info("Delivering notification",
+			"orderID", input.OrderID, "channel", channel, "status", input.Status, "message", input.Message,
+			"unsubscribe", unsubscribeLink(prefs.UnsubscribeToken))
+		output.Channels = append(output.Channels, channel)
+	}
+
+	return output, nil
+}
+
+// SendReminderInput identifies the hold a passenger is reminded about
+type SendReminderInput struct {
+	OrderID   string
+	Status    domain.OrderStatus
+	ExpiresAt time.Time
+}
+
+// SendReminder warns the passenger that the order's seat hold expires soon,
+// on the channels their reminder preferences allow, and queues a
+// HOLD_EXPIRING webhook event
+func (a *BookingActivities) SendReminder(ctx context.Context, input SendReminderInput) (NotifyOrderOutput, error) {
+	output, err := a.NotifyOrder(ctx, NotifyOrderInput{
+		OrderID:  input.OrderID,
+		Category: domain.CategoryReminder,
+		Status:   input.Status,
+		Message:  fmt.Sprintf("Your seats are held until %s UTC; pay before then or they will be released", input.ExpiresAt.UTC().Format("15:04")),
+	})
+	if err != nil {
+		return output, err
+	}
+
+	if err := a.PublishOrderEvent(ctx, PublishOrderEventInput{OrderID: input.OrderID, Event: domain.EventHoldExpiring}); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+// unsubscribeLink is the one-click unsubscribe URL carried in each notification
+func unsubscribeLink(token string) string {
+	if token == "" {
+		return ""
+	}
+	return "/api/v1/notifications/unsubscribe/" + token
+}
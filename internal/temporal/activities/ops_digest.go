@@ -0,0 +1,50 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// CollectOpsDigestInput is the period an ops digest covers
+type CollectOpsDigestInput struct {
+	Since time.Time
+	Until time.Time
+}
+
+// CollectOpsDigest counts the period's expired orders, payment failures,
+// seat lock repairs and dead-lettered webhooks
+func (a *BookingActivities) CollectOpsDigest(ctx context.Context, input CollectOpsDigestInput) (domain.OpsDigest, error) {
+	digest, err := a.opsRepo.Digest(ctx, input.Since, input.Until)
+	if err != nil {
+		return digest, fmt.Errorf("collect ops digest: %w", err)
+	}
+	return digest, nil
+}
+
+// SendOpsDigestInput addresses an ops digest to the operators
+type SendOpsDigestInput struct {
+	Address  string
+	Channels []domain.NotificationChannel
+	Digest   domain.OpsDigest
+}
+
+// SendOpsDigest delivers the digest to the ops address on each channel.
+// Delivery is simulated by logging, like passenger notifications.
+func (a *BookingActivities) SendOpsDigest(ctx context.Context, input SendOpsDigestInput) (NotifyOrderOutput, error) {
+	var output NotifyOrderOutput
+
+	logger := activity.GetLogger(ctx)
+	summary := input.Digest.Summary()
+	for _, channel := range input.Channels {
+		logger.Info("Delivering ops digest",
+			"address", input.Address, "channel", channel, "spike", input.Digest.PaymentFailureSpike, "message", summary)
+		output.Channels = append(output.Channels, channel)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,26 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// recordOrderEvent appends an event to the order's history. The event is
+// keyed by the activity that recorded it, so a retried activity does not
+// record it twice. Recording is best effort: a failure is logged and the
+// activity goes on, since the booking itself already happened.
+func (a *BookingActivities) recordOrderEvent(ctx context.Context, orderID string, eventType domain.OrderEventType, data map[string]interface{}) {
+	info := activity.GetInfo(ctx)
+	key := fmt.Sprintf("%s/%s/%s/%s", info.WorkflowExecution.ID, info.WorkflowExecution.RunID, info.ActivityID, eventType)
+
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stepTimeout)
+	defer cancel()
+
+	if err := a.eventRepo.Append(recordCtx, orderID, key, eventType, data); err != nil {
+		activity.GetLogger(ctx).Warn("Failed to record order event", "orderID", orderID, "event", eventType, "error", err)
+	}
+}
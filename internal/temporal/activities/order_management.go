@@ -2,10 +2,13 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
 )
 
 // CreateOrderInput contains parameters for creating an order
@@ -15,18 +18,14 @@ type CreateOrderInput struct {
 	WorkflowID string
 	Seats      []string
 	ExpiresAt  time.Time
+	Price      domain.PriceBreakdown
+	Seatless   bool
+	TripID     string // empty unless the order is a leg of a trip
 }
 
 // CreateOrder creates a new order in SEATS_RESERVED status
+// The price is the quote locked at request time, never the flight's current fare
 func (a *BookingActivities) CreateOrder(ctx context.Context, input CreateOrderInput) error {
-	// Get flight to calculate price
-	flight, err := a.flightRepo.FindByID(ctx, input.FlightID)
-	if err != nil {
-		return fmt.Errorf("get flight: %w", err)
-	}
-
-	// Calculate total price
-	totalPrice := flight.PriceCents * int64(len(input.Seats))
 	expiresAt := input.ExpiresAt
 
 	order := &domain.Order{
@@ -35,8 +34,13 @@ func (a *BookingActivities) CreateOrder(ctx context.Context, input CreateOrderIn
 		WorkflowID:      input.WorkflowID,
 		Status:          domain.OrderStatusSeatsReserved,
 		Seats:           input.Seats,
-		TotalPriceCents: totalPrice,
+		TotalPriceCents: input.Price.TotalCents,
+		Price:           input.Price,
 		ExpiresAt:       &expiresAt,
+		Seatless:        input.Seatless,
+	}
+	if input.TripID != "" {
+		order.TripID = &input.TripID
 	}
 
 	if err := a.orderRepo.Create(ctx, order); err != nil {
@@ -52,12 +56,32 @@ type UpdateOrderStatusInput struct {
 	Status  domain.OrderStatus
 }
 
-// UpdateOrderStatus updates the order status
+// UpdateOrderStatus updates the order status, compared and swapped against
+// the version it read so it never overwrites a newer write.
+//   - An order already in the status is left alone, so a retry is a no-op
+//   - An order finished meanwhile, e.g. by an admin tool, fails with an
+//     ORDER_CONFLICT error carrying its status
+//   - A write that lands between the read and the swap is retried by Temporal
 func (a *BookingActivities) UpdateOrderStatus(ctx context.Context, input UpdateOrderStatusInput) error {
-	if err := a.orderRepo.UpdateStatus(ctx, input.OrderID, input.Status); err != nil {
+	order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+	if err != nil {
+		return fmt.Errorf("load order for status update: %w", err)
+	}
+	if order.Status == input.Status {
+		return nil
+	}
+	if order.IsTerminal() {
+		return temporalpkg.NewOrderConflictError(input.OrderID, order.Status)
+	}
+
+	if err := a.orderRepo.UpdateStatus(ctx, input.OrderID, input.Status, order.Version); err != nil {
 		return fmt.Errorf("update order status: %w", err)
 	}
 
+	a.recordOrderEvent(ctx, input.OrderID, domain.OrderEventStatusChanged, map[string]interface{}{
+		"from": order.Status,
+		"to":   input.Status,
+	})
 	return nil
 }
 
@@ -66,12 +90,13 @@ type UpdateOrderSeatsInput struct {
 	OrderID   string
 	Seats     []string
 	ExpiresAt time.Time
+	Price     domain.PriceBreakdown
 }
 
-// UpdateOrderSeats updates the order seats and expiration time
+// UpdateOrderSeats updates the order seats, expiration time, and rescaled quote
 func (a *BookingActivities) UpdateOrderSeats(ctx context.Context, input UpdateOrderSeatsInput) error {
 	expiresAt := input.ExpiresAt
-	if err := a.orderRepo.UpdateSeats(ctx, input.OrderID, input.Seats, &expiresAt); err != nil {
+	if err := a.orderRepo.UpdateSeats(ctx, input.OrderID, input.Seats, &expiresAt, input.Price); err != nil {
 		return fmt.Errorf("update order seats: %w", err)
 	}
 
@@ -80,31 +105,91 @@ func (a *BookingActivities) UpdateOrderSeats(ctx context.Context, input UpdateOr
 
 // ConfirmOrderInput contains parameters for order confirmation
 type ConfirmOrderInput struct {
-	OrderID  string
-	FlightID string
-	Seats    []string
+	OrderID    string
+	FlightID   string
+	Seats      []string
+	CabinSeats int // seatless capacity, counted now and seated at check-in
+	Price      domain.PriceBreakdown
+	Passengers []domain.Passenger
+
+	// LockToken is the fencing token the order holds its seats under; zero
+	// skips the token check for bookings that never got one
+	LockToken int64
 }
 
-// ConfirmOrder marks the order as confirmed and updates flight availability
+// ConfirmOrder confirms the order in one database transaction: its
+// passengers, status and revenue, its booked seats and the flight's available
+// count either all change or none do. The persisted quote must match the
+// price the workflow is confirming. The seats must still be locked, and
+// reserved in the database, under input.LockToken: the Redis check turns a
+// stale caller away early, and the book write itself checks the token the
+// seats were reserved under, so a lock lost between the two cannot book them.
 func (a *BookingActivities) ConfirmOrder(ctx context.Context, input ConfirmOrderInput) error {
-	// Confirm the order
-	if err := a.orderRepo.Confirm(ctx, input.OrderID); err != nil {
+	if err := ensureBudget(ctx, 3); err != nil {
 		return fmt.Errorf("confirm order: %w", err)
 	}
 
-	// Mark seats as booked in the database
-	if err := a.flightRepo.BookSeats(ctx, input.FlightID, input.Seats, input.OrderID); err != nil {
-		return fmt.Errorf("book seats: %w", err)
+	var order *domain.Order
+	err := runStep(ctx, "load order", func(ctx context.Context) (err error) {
+		order, err = a.orderRepo.FindByID(ctx, input.OrderID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if order.Price.QuoteID != input.Price.QuoteID || order.TotalPriceCents != input.Price.TotalCents {
+		return temporalpkg.NewPriceMismatchError(input.OrderID)
 	}
 
-	// Decrease available seats count
-	seatCount := len(input.Seats)
-	if err := a.flightRepo.UpdateAvailableSeats(ctx, input.FlightID, -seatCount); err != nil {
-		return fmt.Errorf("update available seats: %w", err)
+	// A seat locked since under another token means this order lost it
+	err = runStep(ctx, "verify seat locks", func(ctx context.Context) error {
+		return a.seatLockRepo.VerifyLocks(ctx, input.FlightID, input.Seats, input.OrderID, input.LockToken)
+	})
+	if errors.Is(err, domain.ErrStaleLock) {
+		return temporalpkg.NewStaleLockError(input.OrderID, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A reference collision rolls everything back and the activity retries
+	// with a new one
+	err = runStep(ctx, "confirm order", func(ctx context.Context) error {
+		ref, err := domain.NewBookingReference()
+		if err != nil {
+			return fmt.Errorf("generate booking reference: %w", err)
+		}
+		return a.orderRepo.ConfirmOrderTx(ctx, domain.OrderConfirmation{
+			OrderID:          input.OrderID,
+			FlightID:         input.FlightID,
+			Seats:            input.Seats,
+			Passengers:       input.Passengers,
+			BookingReference: ref,
+			LockToken:        input.LockToken,
+		})
+	})
+	if errors.Is(err, domain.ErrDoubleBooking) {
+		return temporalpkg.NewDoubleBookingError(input.OrderID, err)
+	}
+	if errors.Is(err, domain.ErrStaleLock) {
+		return temporalpkg.NewStaleLockError(input.OrderID, err)
+	}
+	// The order was finished, e.g. expired, before it could be confirmed
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return temporalpkg.NewVersionConflictError(input.OrderID)
+	}
+	if err != nil {
+		return err
 	}
 
+	a.recordOrderEvent(ctx, input.OrderID, domain.OrderEventConfirmed, map[string]interface{}{"seats": input.Seats})
+	a.publishSeatChange(ctx, input.FlightID, input.Seats, domain.SeatStatusBooked)
+	a.repriceOnConfirmation(ctx, input.FlightID, input.OrderID)
+
 	// Release Redis locks since seats are now permanently booked
-	_ = a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID)
+	compensate(ctx, "release booked seat locks", func(ctx context.Context) error {
+		return a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID, input.LockToken)
+	})
 
 	return nil
 }
@@ -115,25 +200,82 @@ type FailOrderInput struct {
 	Reason  string
 }
 
-// FailOrder marks the order as failed with a reason
+// FailOrder marks the order as failed with a reason; see finishOrder
 func (a *BookingActivities) FailOrder(ctx context.Context, input FailOrderInput) error {
-	if err := a.orderRepo.Fail(ctx, input.OrderID, input.Reason); err != nil {
-		return fmt.Errorf("fail order: %w", err)
+	return a.finishOrder(ctx, input.OrderID, domain.OrderStatusFailed, "fail order", func(version int) error {
+		return a.orderRepo.Fail(ctx, input.OrderID, input.Reason, version)
+	})
+}
+
+// RefundOrderInput contains parameters for recording a refunded order
+type RefundOrderInput struct {
+	OrderID string
+	Reason  string
+}
+
+// RefundOrder marks an order whose payment was refunded and takes back any
+// revenue a partial confirmation recognized. An order a partial confirmation
+// left CONFIRMED is refunded too; see finishOrder for the others.
+func (a *BookingActivities) RefundOrder(ctx context.Context, input RefundOrderInput) error {
+	err := a.finishOrder(ctx, input.OrderID, domain.OrderStatusPaymentRefunded, "refund order", func(version int) error {
+		return a.orderRepo.Refund(ctx, input.OrderID, input.Reason, version)
+	}, domain.OrderStatusConfirmed)
+	if err != nil {
+		return err
+	}
+	if err := a.orderRepo.ReverseRevenue(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("refund order: %w", err)
 	}
 
 	return nil
 }
 
+// CancelOrderInput contains parameters for order cancellation
+type CancelOrderInput struct {
+	OrderID string
+	Reason  string
+}
+
+// CancelOrder marks the order as cancelled after its workflow was canceled;
+// see finishOrder
+func (a *BookingActivities) CancelOrder(ctx context.Context, input CancelOrderInput) error {
+	return a.finishOrder(ctx, input.OrderID, domain.OrderStatusCancelled, "cancel order", func(version int) error {
+		return a.orderRepo.Cancel(ctx, input.OrderID, input.Reason, version)
+	})
+}
+
 // ExpireOrderInput contains parameters for order expiration
 type ExpireOrderInput struct {
 	OrderID string
 }
 
-// ExpireOrder marks the order as expired
+// ExpireOrder marks the order as expired; see finishOrder
 func (a *BookingActivities) ExpireOrder(ctx context.Context, input ExpireOrderInput) error {
-	if err := a.orderRepo.Expire(ctx, input.OrderID); err != nil {
-		return fmt.Errorf("expire order: %w", err)
+	return a.finishOrder(ctx, input.OrderID, domain.OrderStatusExpired, "expire order", func(version int) error {
+		return a.orderRepo.Expire(ctx, input.OrderID, version)
+	})
+}
+
+// finishOrder moves an order to the terminal status with write, compared and
+// swapped against the version it read, as UpdateOrderStatus does. An order
+// already in status is left alone, so a retry is a no-op; one finished
+// otherwise, e.g. a CONFIRMED order a late expiry reaches, fails with an
+// ORDER_CONFLICT error carrying its status, unless it is in one of from.
+// Other errors are wrapped with action.
+func (a *BookingActivities) finishOrder(ctx context.Context, orderID string, status domain.OrderStatus, action string, write func(version int) error, from ...domain.OrderStatus) error {
+	order, err := a.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("load order to %s: %w", action, err)
+	}
+	if order.Status == status {
+		return nil
+	}
+	if order.IsTerminal() && !slices.Contains(from, order.Status) {
+		return temporalpkg.NewOrderConflictError(orderID, order.Status)
 	}
 
+	if err := write(order.Version); err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
 	return nil
 }
@@ -0,0 +1,147 @@
+package activities
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/mocks"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// confirmInput is an order confirming two seats it reserved under token 7
+var confirmInput = ConfirmOrderInput{
+	OrderID:   "order-1",
+	FlightID:  "flight-1",
+	Seats:     []string{"3A", "3B"},
+	Price:     domain.PriceBreakdown{QuoteID: "quote-1", TotalCents: 20000},
+	LockToken: 7,
+}
+
+// newConfirmActivities returns activities over mocked repositories holding
+// confirmInput's order
+func newConfirmActivities(t *testing.T) (*BookingActivities, *mocks.OrderRepository, *mocks.SeatLocker) {
+	t.Helper()
+	orders := mocks.NewOrderRepository(t)
+	locks := mocks.NewSeatLocker(t)
+	orders.On("FindByID", mock.Anything, "order-1").Return(&domain.Order{
+		ID:              "order-1",
+		FlightID:        "flight-1",
+		Price:           domain.PriceBreakdown{QuoteID: "quote-1"},
+		TotalPriceCents: 20000,
+	}, nil)
+	return &BookingActivities{orderRepo: orders, seatLockRepo: locks}, orders, locks
+}
+
+// runConfirm runs ConfirmOrder and returns the type of the application
+// error it failed with
+func runConfirm(t *testing.T, a *BookingActivities) string {
+	t.Helper()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(a)
+
+	_, err := env.ExecuteActivity(a.ConfirmOrder, confirmInput)
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("ConfirmOrder error = %v, want an application error", err)
+	}
+	if !appErr.NonRetryable() {
+		t.Errorf("ConfirmOrder error %v is retryable", err)
+	}
+	return appErr.Type()
+}
+
+func TestConfirmOrder_StaleTokenRejected(t *testing.T) {
+	t.Run("by the seat locks", func(t *testing.T) {
+		a, _, locks := newConfirmActivities(t)
+		locks.On("VerifyLocks", mock.Anything, "flight-1", confirmInput.Seats, "order-1", int64(7)).
+			Return(fmt.Errorf("seat 3A locked under token 9: %w", domain.ErrStaleLock))
+
+		if typ := runConfirm(t, a); typ != temporalpkg.ErrTypeStaleLock {
+			t.Errorf("error type %s, want %s", typ, temporalpkg.ErrTypeStaleLock)
+		}
+	})
+
+	// The locks may lapse or be retaken between the check and the write, so
+	// the write itself presents the token
+	t.Run("by the book write", func(t *testing.T) {
+		a, orders, locks := newConfirmActivities(t)
+		locks.On("VerifyLocks", mock.Anything, "flight-1", confirmInput.Seats, "order-1", int64(7)).Return(nil)
+		orders.On("ConfirmOrderTx", mock.Anything, mock.MatchedBy(func(c domain.OrderConfirmation) bool {
+			return c.LockToken == 7
+		})).Return(fmt.Errorf("book seats under lock token 7: %w", domain.ErrStaleLock))
+
+		if typ := runConfirm(t, a); typ != temporalpkg.ErrTypeStaleLock {
+			t.Errorf("error type %s, want %s", typ, temporalpkg.ErrTypeStaleLock)
+		}
+		locks.AssertNotCalled(t, "ReleaseLocks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// An order expired or cancelled while its payment went through is not
+// confirmed over, nor retried
+func TestConfirmOrder_FinishedOrderNotRetried(t *testing.T) {
+	a, orders, locks := newConfirmActivities(t)
+	locks.On("VerifyLocks", mock.Anything, "flight-1", confirmInput.Seats, "order-1", int64(7)).Return(nil)
+	orders.On("ConfirmOrderTx", mock.Anything, mock.Anything).Return(domain.ErrVersionConflict)
+
+	if typ := runConfirm(t, a); typ != temporalpkg.ErrTypeVersionConflict {
+		t.Errorf("error type %s, want %s", typ, temporalpkg.ErrTypeVersionConflict)
+	}
+}
+
+func TestFinishOrder_ComparesVersions(t *testing.T) {
+	// newFinishActivities returns activities over an order in status at version 3
+	newFinishActivities := func(t *testing.T, status domain.OrderStatus) (*BookingActivities, *mocks.OrderRepository) {
+		orders := mocks.NewOrderRepository(t)
+		orders.On("FindByID", mock.Anything, "order-1").Return(&domain.Order{ID: "order-1", Status: status, Version: 3}, nil)
+		return &BookingActivities{orderRepo: orders}, orders
+	}
+
+	t.Run("at the version read", func(t *testing.T) {
+		a, orders := newFinishActivities(t, domain.OrderStatusPaymentPending)
+		orders.On("Expire", mock.Anything, "order-1", 3).Return(nil).Once()
+
+		if err := runActivity(a, a.ExpireOrder, ExpireOrderInput{OrderID: "order-1"}); err != nil {
+			t.Fatalf("ExpireOrder: %v", err)
+		}
+	})
+
+	// A write landed between the read and the compare-and-swap; the retry
+	// reads again
+	t.Run("written since", func(t *testing.T) {
+		a, orders := newFinishActivities(t, domain.OrderStatusPaymentProcessing)
+		orders.On("Fail", mock.Anything, "order-1", "declined", 3).Return(domain.ErrVersionConflict).Once()
+
+		err := runActivity(a, a.FailOrder, FailOrderInput{OrderID: "order-1", Reason: "declined"})
+		if !failedWith(err, domain.ErrVersionConflict) || nonRetryable(err) {
+			t.Errorf("FailOrder error = %v, want a retryable %v", err, domain.ErrVersionConflict)
+		}
+	})
+
+	// A late expiry must not overwrite a booking that confirmed first
+	t.Run("finished otherwise", func(t *testing.T) {
+		a, orders := newFinishActivities(t, domain.OrderStatusConfirmed)
+
+		err := runActivity(a, a.ExpireOrder, ExpireOrderInput{OrderID: "order-1"})
+		if typ := errorType(err); typ != temporalpkg.ErrTypeOrderConflict || !nonRetryable(err) {
+			t.Errorf("ExpireOrder error = %v, want a non-retryable %s", err, temporalpkg.ErrTypeOrderConflict)
+		}
+		orders.AssertNotCalled(t, "Expire", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("retried after it finished", func(t *testing.T) {
+		a, orders := newFinishActivities(t, domain.OrderStatusCancelled)
+
+		if err := runActivity(a, a.CancelOrder, CancelOrderInput{OrderID: "order-1", Reason: "operator"}); err != nil {
+			t.Fatalf("CancelOrder: %v", err)
+		}
+		orders.AssertNotCalled(t, "Cancel", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,82 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// FindOverbookedOrdersOutput is how far a flight is oversold and the orders
+// that could be bumped to make room
+type FindOverbookedOrdersOutput struct {
+	Oversold   int
+	Candidates []domain.BumpCandidate
+}
+
+// FindOverbookedOrders counts the seats a flight has promised beyond its
+// seat rows and, if it is oversold, lists its bumpable orders
+func (a *BookingActivities) FindOverbookedOrders(ctx context.Context, input FlightDepartureInput) (FindOverbookedOrdersOutput, error) {
+	var output FindOverbookedOrdersOutput
+
+	oversold, err := a.flightRepo.Oversold(ctx, input.FlightID)
+	if err != nil {
+		return output, err
+	}
+	output.Oversold = oversold
+	if oversold <= 0 {
+		return output, nil
+	}
+
+	output.Candidates, err = a.orderRepo.FindBumpCandidates(ctx, input.FlightID)
+	return output, err
+}
+
+// BumpOrderInput identifies the order to bump and how to reaccommodate it
+type BumpOrderInput struct {
+	OrderID string
+	Policy  domain.BumpPolicy
+}
+
+// BumpOrderOutput is the recorded bump, nil if the order could no longer be
+// bumped, with the alternative flight's number and departure when rebooked
+type BumpOrderOutput struct {
+	Bump                 *domain.Bump
+	AlternativeFlight    string
+	AlternativeDeparture time.Time
+}
+
+// BumpOrder moves an order off its oversold flight, rebooking it on a later
+// flight or refunding it, and records its compensation. Retries return the
+// bump already recorded.
+func (a *BookingActivities) BumpOrder(ctx context.Context, input BumpOrderInput) (BumpOrderOutput, error) {
+	var output BumpOrderOutput
+
+	bump, err := a.orderRepo.Bump(ctx, input.OrderID, input.Policy)
+	if errors.Is(err, domain.ErrOrderNotConfirmed) {
+		// Checked in or changed since it was selected
+		activity.GetLogger(ctx).Info("Order no longer bumpable", "orderID", input.OrderID)
+		return output, nil
+	}
+	if err != nil {
+		return output, fmt.Errorf("bump order %s: %w", input.OrderID, err)
+	}
+	output.Bump = bump
+
+	if bump.AlternativeFlightID != nil {
+		flight, err := a.flightRepo.FindByID(ctx, *bump.AlternativeFlightID)
+		if err != nil {
+			return output, err
+		}
+		output.AlternativeFlight = flight.FlightNumber
+		output.AlternativeDeparture = flight.DepartureTime
+	}
+
+	activity.GetLogger(ctx).Info("Order bumped", "orderID", input.OrderID, "flightID", bump.FlightID,
+		"status", bump.Status, "seats", bump.Seats, "compensationCents", bump.CompensationCents)
+	return output, nil
+}
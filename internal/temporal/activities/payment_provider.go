@@ -0,0 +1,344 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Payment providers selectable with PAYMENT_PROVIDER
+const (
+	PaymentProviderSimulator = "simulator" // random outcomes drawn in the worker
+	PaymentProviderHTTP      = "http"      // a gateway reached over HTTP at PAYMENT_GATEWAY_URL
+)
+
+// errPaymentDeclined marks a payment the provider refused; retrying the same
+// code cannot succeed
+var errPaymentDeclined = errors.New("payment declined")
+
+// errChallengeFailed marks a one-time code the issuer rejected; the challenge
+// may be answered again
+var errChallengeFailed = errors.New("challenge code rejected")
+
+// simulatedChallengeCode is the one-time code that answers every simulated
+// payment challenge
+const simulatedChallengeCode = "123456"
+
+// PaymentRequest is one attempt to take an order's payment
+type PaymentRequest struct {
+	OrderID     string
+	Method      domain.PaymentMethod
+	PaymentCode string
+	AmountCents int64 // part of the order's price, such as a deposit; zero takes all of it
+	Attempt     int
+	Seed        *int64 // the order's own simulation seed, if any
+	Reference   string // the workflow a pending payment's result is routed back to
+
+	// IdempotencyKey is persisted before the provider is called; repeating a
+	// request with the same key never takes a second payment
+	IdempotencyKey string
+}
+
+// Authorization is a payment the provider approved but has not yet captured.
+// A pending authorization is settled by the provider out of band and its
+// result delivered to the payment callback, so it is never captured here.
+// A challenged authorization is captured only once the customer's one-time
+// code completes its challenge.
+type Authorization struct {
+	ID                string
+	Message           string
+	Pending           bool
+	ChallengeRequired bool
+}
+
+// RefundRequest returns a captured payment
+type RefundRequest struct {
+	OrderID     string
+	Method      domain.PaymentMethod
+	PaymentCode string
+	AmountCents int64
+
+	// RefundID is the recorded refund this returns, so a refund retried
+	// after a failed one is a new request; unset for refunds of a failed
+	// confirmation, which happen once per order
+	RefundID string
+}
+
+// idempotencyKey keys the request on its refund, or its order when it has none
+func (r RefundRequest) idempotencyKey() string {
+	if r.RefundID != "" {
+		return "refund:" + r.RefundID
+	}
+	return "refund:" + r.OrderID
+}
+
+// PaymentProvider takes payments for the booking activities. Declined
+// payments wrap errPaymentDeclined; any other error may succeed on retry.
+// Every call must be safe to repeat, since activities are retried.
+type PaymentProvider interface {
+	// Authorize approves a payment without taking it
+	Authorize(ctx context.Context, req PaymentRequest) (Authorization, error)
+	// Capture takes an authorized payment
+	Capture(ctx context.Context, auth Authorization) error
+	// CompleteChallenge answers a challenged authorization with the
+	// customer's one-time code; a wrong code wraps errChallengeFailed
+	CompleteChallenge(ctx context.Context, auth Authorization, code string) error
+	// Void releases an authorization that will not be captured
+	Void(ctx context.Context, auth Authorization) error
+	// Refund returns a captured payment
+	Refund(ctx context.Context, req RefundRequest) error
+}
+
+// newPaymentProvider returns the provider PAYMENT_PROVIDER selects; an
+// unknown name falls back to the simulator
+func newPaymentProvider(cfg *config.BookingConfig, sim *simulation) PaymentProvider {
+	switch cfg.PaymentProvider {
+	case PaymentProviderHTTP:
+		return newGatewayPayments(cfg.PaymentGatewayURL, cfg.PaymentValidationTimeout)
+	case PaymentProviderSimulator, "":
+	default:
+		log.Printf("Warning: unknown PAYMENT_PROVIDER %q, using the simulator", cfg.PaymentProvider)
+	}
+	return &simulatedPayments{sim: sim, profiles: paymentProfiles(cfg)}
+}
+
+// paymentProfile is how the simulator treats payments of one method
+type paymentProfile struct {
+	minSeconds, maxSeconds int // processing time of an authorization
+	failureRate            float64
+	declineRate            float64
+	declineReason          string
+	challengeRate          float64
+}
+
+// paymentProfiles returns the simulated behavior of each payment method:
+//   - cards take 1-7 seconds, fail PAYMENT_FAILURE_RATE of the time and have
+//     PAYMENT_CHALLENGE_RATE challenged by their issuer
+//   - wallets answer within 2 seconds and fail WALLET_FAILURE_RATE of the
+//     time; the wallet app has already authenticated the customer
+//   - vouchers are checked at once, and VOUCHER_DECLINE_RATE of them are
+//     declined as already redeemed
+func paymentProfiles(cfg *config.BookingConfig) map[domain.PaymentMethod]paymentProfile {
+	return map[domain.PaymentMethod]paymentProfile{
+		domain.PaymentMethodCard: {
+			minSeconds: 1, maxSeconds: 7,
+			failureRate:   cfg.PaymentFailureRate,
+			challengeRate: cfg.PaymentChallengeRate,
+		},
+		domain.PaymentMethodWallet: {
+			minSeconds: 1, maxSeconds: 2,
+			failureRate: cfg.WalletFailureRate,
+		},
+		domain.PaymentMethodVoucher: {
+			declineRate:   cfg.VoucherDeclineRate,
+			declineReason: "voucher already redeemed",
+		},
+	}
+}
+
+// simulatedPayments is the built-in provider. Each authorization draws its
+// processing time and outcome from its method's paymentProfile; challenges
+// are answered with simulatedChallengeCode. It keeps no payments, so
+// capturing, voiding and refunding only take time.
+type simulatedPayments struct {
+	sim      *simulation
+	profiles map[domain.PaymentMethod]paymentProfile
+}
+
+func (p *simulatedPayments) Authorize(ctx context.Context, req PaymentRequest) (Authorization, error) {
+	auth := Authorization{ID: "sim-" + req.IdempotencyKey}
+
+	// Special card codes for testing
+	switch req.PaymentCode {
+	case "00000":
+		// Always succeeds instantly - useful for testing success
+		auth.Message = "Payment validated (test mode)"
+		return auth, nil
+	case "99999":
+		// Always fails with retryable error - useful for testing retry flow
+		return Authorization{}, fmt.Errorf("payment validation failed: temporary gateway error")
+	case "11111":
+		// Always declined - useful for testing immediate failure
+		return Authorization{}, fmt.Errorf("%w: insufficient funds", errPaymentDeclined)
+	case "33333":
+		// Always challenged - useful for testing the one-time code flow
+		auth.Message = "Payment needs verification (test mode)"
+		auth.ChallengeRequired = true
+		return auth, nil
+	}
+
+	profile := p.profiles[req.Method.OrCard()]
+	rng := p.sim.source(req.Seed, "payment:"+strconv.Itoa(req.Attempt))
+	processingTime := time.Duration(rng.Intn(profile.maxSeconds-profile.minSeconds+1)+profile.minSeconds) * time.Second
+	if err := simulateProcessing(ctx, processingTime); err != nil {
+		return Authorization{}, err
+	}
+
+	// Simulate failure rate
+	if rng.Float64() < profile.failureRate {
+		return Authorization{}, fmt.Errorf("payment validation failed: temporary gateway error")
+	}
+
+	if profile.declineRate > 0 && rng.Float64() < profile.declineRate {
+		return Authorization{}, fmt.Errorf("%w: %s", errPaymentDeclined, profile.declineReason)
+	}
+
+	if profile.challengeRate > 0 && rng.Float64() < profile.challengeRate {
+		auth.Message = "Payment needs verification"
+		auth.ChallengeRequired = true
+		return auth, nil
+	}
+
+	auth.Message = "Payment validated successfully"
+	return auth, nil
+}
+
+func (p *simulatedPayments) CompleteChallenge(ctx context.Context, auth Authorization, code string) error {
+	if code != simulatedChallengeCode {
+		return fmt.Errorf("%w: code does not match", errChallengeFailed)
+	}
+	return nil
+}
+
+func (p *simulatedPayments) Capture(ctx context.Context, auth Authorization) error {
+	return nil
+}
+
+func (p *simulatedPayments) Void(ctx context.Context, auth Authorization) error {
+	return nil
+}
+
+func (p *simulatedPayments) Refund(ctx context.Context, req RefundRequest) error {
+	return simulateProcessing(ctx, time.Duration(p.sim.Intn(2)+1)*time.Second)
+}
+
+// gatewayPayments calls a payment gateway over HTTP, such as a mock gateway
+// standing in for a real PSP. Each call is a JSON POST carrying an
+// Idempotency-Key, so a retried activity repeats rather than duplicates it;
+// an authorization's key is the charge's persisted PaymentRequest.IdempotencyKey:
+//   - /authorizations {orderId, method, paymentCode, reference, amountCents} returns
+//     {id, message, status}; amountCents is only sent for part payments such
+//     as deposits. Status "pending" means the gateway settles the payment
+//     itself and posts the result to /api/v1/payments/callback;
+//     "challenge_required" that the customer must first answer
+//     /authorizations/{id}/challenge {code}, which a rejected code fails
+//     with 402 or 422
+//   - /authorizations/{id}/capture and /authorizations/{id}/void
+//   - /refunds {orderId, method, amountCents}, keyed on the recorded refund so
+//     an operator retrying a failed refund makes a new request
+//
+// 402 and 422 responses decline the payment with the body's error; other
+// failures are temporary.
+type gatewayPayments struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newGatewayPayments(baseURL string, timeout time.Duration) *gatewayPayments {
+	return &gatewayPayments{baseURL: baseURL, client: &http.Client{Timeout: timeout}}
+}
+
+func (p *gatewayPayments) Authorize(ctx context.Context, req PaymentRequest) (Authorization, error) {
+	var resp struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	}
+	body := map[string]interface{}{
+		"orderId":     req.OrderID,
+		"method":      req.Method.OrCard(),
+		"paymentCode": req.PaymentCode,
+		"reference":   req.Reference,
+	}
+	if req.AmountCents > 0 {
+		body["amountCents"] = req.AmountCents
+	}
+	err := p.post(ctx, "/authorizations", "authorize:"+req.IdempotencyKey, body, &resp)
+	if err != nil {
+		return Authorization{}, err
+	}
+	return Authorization{
+		ID:                resp.ID,
+		Message:           resp.Message,
+		Pending:           resp.Status == "pending",
+		ChallengeRequired: resp.Status == "challenge_required",
+	}, nil
+}
+
+func (p *gatewayPayments) CompleteChallenge(ctx context.Context, auth Authorization, code string) error {
+	err := p.post(ctx, "/authorizations/"+url.PathEscape(auth.ID)+"/challenge", "challenge:"+auth.ID+":"+code, map[string]interface{}{
+		"code": code,
+	}, nil)
+	if errors.Is(err, errPaymentDeclined) {
+		return fmt.Errorf("%w: %v", errChallengeFailed, err)
+	}
+	return err
+}
+
+func (p *gatewayPayments) Capture(ctx context.Context, auth Authorization) error {
+	return p.post(ctx, "/authorizations/"+url.PathEscape(auth.ID)+"/capture", "capture:"+auth.ID, nil, nil)
+}
+
+func (p *gatewayPayments) Void(ctx context.Context, auth Authorization) error {
+	return p.post(ctx, "/authorizations/"+url.PathEscape(auth.ID)+"/void", "void:"+auth.ID, nil, nil)
+}
+
+func (p *gatewayPayments) Refund(ctx context.Context, req RefundRequest) error {
+	return p.post(ctx, "/refunds", req.idempotencyKey(), map[string]interface{}{
+		"orderId":     req.OrderID,
+		"method":      req.Method.OrCard(),
+		"amountCents": req.AmountCents,
+	}, nil)
+}
+
+// post sends body to the gateway path and decodes a successful response into out
+func (p *gatewayPayments) post(ctx context.Context, path, idempotencyKey string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode payment gateway request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("build payment gateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("payment gateway %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusPaymentRequired || resp.StatusCode == http.StatusUnprocessableEntity:
+		var declined struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&declined)
+		if declined.Error == "" {
+			declined.Error = resp.Status
+		}
+		return fmt.Errorf("%w: %s", errPaymentDeclined, declined.Error)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("payment gateway %s: %s", path, resp.Status)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode payment gateway response: %w", err)
+		}
+	}
+	return nil
+}
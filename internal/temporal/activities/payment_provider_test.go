@@ -0,0 +1,116 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestGatewayPayments(t *testing.T) {
+	var keys, methods []string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		switch r.URL.Path {
+		case "/authorizations":
+			var req struct{ Method, PaymentCode string }
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			methods = append(methods, req.Method)
+			switch req.PaymentCode {
+			case "11111":
+				w.WriteHeader(http.StatusPaymentRequired)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "insufficient funds"})
+			case "99999":
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case "33333":
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": "auth-2", "status": "challenge_required"})
+			default:
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": "auth-1", "message": "approved"})
+			}
+		case "/authorizations/auth-1/capture", "/refunds":
+			w.WriteHeader(http.StatusNoContent)
+		case "/authorizations/auth-2/challenge":
+			var req struct{ Code string }
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Code != "123456" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer gateway.Close()
+
+	p := newGatewayPayments(gateway.URL, time.Second)
+	ctx := context.Background()
+
+	auth, err := p.Authorize(ctx, PaymentRequest{OrderID: "order-1", PaymentCode: "12345", Attempt: 2, IdempotencyKey: "order-1:run-1:2"})
+	if err != nil || auth != (Authorization{ID: "auth-1", Message: "approved"}) {
+		t.Fatalf("Authorize = %+v, %v; want auth-1 approved", auth, err)
+	}
+	if err := p.Capture(ctx, auth); err != nil {
+		t.Errorf("Capture: %v", err)
+	}
+	if err := p.Refund(ctx, RefundRequest{OrderID: "order-1", AmountCents: 100}); err != nil {
+		t.Errorf("Refund: %v", err)
+	}
+	// A refund retried after a failed one is recorded anew, so it must not
+	// replay the failure under the same key
+	if err := p.Refund(ctx, RefundRequest{OrderID: "order-1", AmountCents: 100, RefundID: "refund-2"}); err != nil {
+		t.Errorf("Refund: %v", err)
+	}
+	if want := []string{"authorize:order-1:run-1:2", "capture:auth-1", "refund:order-1", "refund:refund-2"}; len(keys) != 4 || keys[0] != want[0] || keys[1] != want[1] || keys[2] != want[2] || keys[3] != want[3] {
+		t.Errorf("idempotency keys = %v, want %v", keys, want)
+	}
+
+	if _, err := p.Authorize(ctx, PaymentRequest{OrderID: "order-1", Method: domain.PaymentMethodWallet, PaymentCode: "wlt_token-1"}); err != nil {
+		t.Errorf("wallet Authorize: %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "card" || methods[1] != "wallet" {
+		t.Errorf("methods sent = %v, want [card wallet]", methods)
+	}
+
+	_, err = p.Authorize(ctx, PaymentRequest{OrderID: "order-1", PaymentCode: "11111"})
+	if !errors.Is(err, errPaymentDeclined) || err.Error() != "payment declined: insufficient funds" {
+		t.Errorf("declined Authorize error = %v, want payment declined: insufficient funds", err)
+	}
+
+	_, err = p.Authorize(ctx, PaymentRequest{OrderID: "order-1", PaymentCode: "99999"})
+	if err == nil || errors.Is(err, errPaymentDeclined) {
+		t.Errorf("unavailable gateway error = %v, want a temporary error", err)
+	}
+
+	challenged, err := p.Authorize(ctx, PaymentRequest{OrderID: "order-1", PaymentCode: "33333"})
+	if err != nil || !challenged.ChallengeRequired {
+		t.Fatalf("challenged Authorize = %+v, %v; want a challenge", challenged, err)
+	}
+	if err := p.CompleteChallenge(ctx, challenged, "654321"); !errors.Is(err, errChallengeFailed) {
+		t.Errorf("wrong code error = %v, want a rejected code", err)
+	}
+	if err := p.CompleteChallenge(ctx, challenged, "123456"); err != nil {
+		t.Errorf("CompleteChallenge: %v", err)
+	}
+}
+
+func TestSimulatedPayments_MethodProfiles(t *testing.T) {
+	p := newPaymentProvider(&config.BookingConfig{VoucherDeclineRate: 1}, newSimulation(1))
+	ctx := context.Background()
+
+	_, err := p.Authorize(ctx, PaymentRequest{OrderID: "order-1", Method: domain.PaymentMethodVoucher, PaymentCode: "ABCD-1234-EFGH"})
+	if !errors.Is(err, errPaymentDeclined) || err.Error() != "payment declined: voucher already redeemed" {
+		t.Errorf("voucher Authorize error = %v, want payment declined: voucher already redeemed", err)
+	}
+
+	// The voucher profile does not touch cards
+	if _, err := p.Authorize(ctx, PaymentRequest{OrderID: "order-1", PaymentCode: "00000"}); err != nil {
+		t.Errorf("card Authorize: %v", err)
+	}
+}
@@ -2,74 +2,285 @@ package activities
 
 import (
 	"context"
-	"fmt"
-	"math/rand"
-	"regexp"
+	"errors"
 	"time"
 
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
 
+	"github.com/flight-booking-system/internal/domain"
 	temporalpkg "github.com/flight-booking-system/internal/temporal"
 )
 
 // ValidatePaymentInput contains payment validation parameters
 type ValidatePaymentInput struct {
 	OrderID     string
+	Method      domain.PaymentMethod // empty pays by card
 	PaymentCode string
+	AmountCents int64 // part of the order's price, such as a deposit; zero takes all of it
+
+	// Attempt and Seed make an order's simulated outcome reproducible: an
+	// order with its own seed draws each attempt's outcome from that seed
+	Attempt int
+	Seed    *int64
 }
 
-// ValidatePaymentOutput contains the validation result
+// ValidatePaymentOutput contains the validation result. A pending payment
+// is not settled yet: the workflow waits for its payment-result signal. A
+// challenged one waits for the customer's one-time code.
 type ValidatePaymentOutput struct {
-	Success bool
-	Message string
+	Success           bool
+	Message           string
+	Pending           bool
+	ChallengeRequired bool
+	AuthorizationID   string
 }
 
-// 5-digit code pattern
-var paymentCodePattern = regexp.MustCompile(`^\d{5}$`)
-
-// ValidatePayment simulates payment code validation
-// - 15% failure rate (configurable via cfg.PaymentFailureRate)
-// - Random processing time 1-8 seconds
-// - Returns non-retryable error for invalid code format
+// ValidatePayment takes the order's payment through the configured
+// PaymentProvider: it authorizes the payment code, then captures it, and
+// records the method and code on the order. The
+// charge is persisted under an idempotency key derived from the order and
+// attempt first, so an attempt that follows a lost one finishes it rather
+// than charging twice.
+//   - A pending authorization is left to the provider to settle
+//   - A challenged authorization waits for CompletePaymentChallenge
+//   - Returns non-retryable error for a code of the wrong shape for its method
+//   - Declined payments fail with a PAYMENT_DECLINED error
+//   - Other provider errors are retried by Temporal; an authorization whose
+//     capture fails is voided first
 func (a *BookingActivities) ValidatePayment(ctx context.Context, input ValidatePaymentInput) (ValidatePaymentOutput, error) {
-	// Validate payment code format (5 digits)
-	if !paymentCodePattern.MatchString(input.PaymentCode) {
+	if !input.Method.ValidCode(input.PaymentCode) {
 		return ValidatePaymentOutput{}, temporalpkg.NewInvalidPaymentCodeError()
 	}
 
-	// Special codes for testing
-	switch input.PaymentCode {
-	case "00000":
-		// Always succeeds instantly - useful for testing success
-		return ValidatePaymentOutput{Success: true, Message: "Payment validated (test mode)"}, nil
-	case "99999":
-		// Always fails with retryable error - useful for testing retry flow
-		return ValidatePaymentOutput{}, fmt.Errorf("payment validation failed: temporary gateway error")
-	case "11111":
-		// Always fails with non-retryable error - useful for testing immediate failure
-		return ValidatePaymentOutput{}, temporal.NewApplicationError(
-			"payment declined: insufficient funds",
-			temporalpkg.ErrTypePaymentDeclined,
-		)
+	auth, captured, err := a.authorizeCharge(ctx, PaymentRequest{
+		OrderID:     input.OrderID,
+		Method:      input.Method.OrCard(),
+		PaymentCode: input.PaymentCode,
+		AmountCents: input.AmountCents,
+		Attempt:     input.Attempt,
+		Seed:        input.Seed,
+		Reference:   activity.GetInfo(ctx).WorkflowExecution.ID,
+	})
+	if err != nil {
+		a.recordPaymentFailure(ctx, input.OrderID, input.Attempt, err)
+		return ValidatePaymentOutput{}, paymentError(err)
+	}
+	if captured {
+		return ValidatePaymentOutput{Success: true, Message: auth.Message}, nil
+	}
+
+	if auth.Pending {
+		activity.GetLogger(ctx).Info("Payment pending gateway confirmation", "orderID", input.OrderID, "authorization", auth.ID)
+		return ValidatePaymentOutput{
+			Message:         auth.Message,
+			Pending:         true,
+			AuthorizationID: auth.ID,
+		}, nil
+	}
+	if auth.ChallengeRequired {
+		activity.GetLogger(ctx).Info("Payment challenged by the issuer", "orderID", input.OrderID, "authorization", auth.ID)
+		return ValidatePaymentOutput{
+			Message:           auth.Message,
+			ChallengeRequired: true,
+			AuthorizationID:   auth.ID,
+		}, nil
+	}
+
+	out, err := a.capturePayment(ctx, input.OrderID, auth)
+	if err != nil {
+		a.recordPaymentFailure(ctx, input.OrderID, input.Attempt, err)
+	}
+	return out, err
+}
+
+// CompletePaymentChallengeInput answers the challenge of an authorization
+type CompletePaymentChallengeInput struct {
+	OrderID         string
+	AuthorizationID string
+	Code            string
+}
+
+// CompletePaymentChallenge completes a challenged authorization with the
+// customer's one-time code, then captures it
+//   - A wrong code fails with CHALLENGE_FAILED; the challenge stays open
+//   - Declined payments fail with a PAYMENT_DECLINED error
+//   - An authorization whose capture fails is voided
+func (a *BookingActivities) CompletePaymentChallenge(ctx context.Context, input CompletePaymentChallengeInput) (ValidatePaymentOutput, error) {
+	auth := Authorization{ID: input.AuthorizationID, Message: "Payment verified"}
+	if err := a.payments.CompleteChallenge(ctx, auth, input.Code); err != nil {
+		if errors.Is(err, errChallengeFailed) {
+			return ValidatePaymentOutput{}, temporalpkg.NewChallengeFailedError(err.Error())
+		}
+		return ValidatePaymentOutput{}, paymentError(err)
+	}
+
+	return a.capturePayment(ctx, input.OrderID, auth)
+}
+
+// authorizeCharge records the payment attempt under its idempotency key,
+// then authorizes it through the PaymentProvider and records the outcome,
+// along with the method and code on the order once authorized. An
+// earlier attempt of the same workflow run that the provider may have taken
+// is resumed under its key instead: its authorization is returned for
+// capture, or captured is set when it was already captured. A provider error
+// other than a decline records the attempt FAILED, and the next attempt
+// repeats it under the same key, since the provider may have taken it.
+func (a *BookingActivities) authorizeCharge(ctx context.Context, req PaymentRequest) (auth Authorization, captured bool, err error) {
+	logger := activity.GetLogger(ctx)
+	scope := activity.GetInfo(ctx).WorkflowExecution.RunID
+	payment, err := a.paymentRepo.Begin(ctx, domain.Payment{
+		IdempotencyKey: domain.PaymentKey(req.OrderID, scope, req.Attempt),
+		OrderID:        req.OrderID,
+		Method:         req.Method,
+		Scope:          scope,
+		Attempt:        req.Attempt,
+		AmountCents:    req.AmountCents,
+	})
+	if err != nil {
+		return Authorization{}, false, err
+	}
+	if payment.IdempotencyKey != domain.PaymentKey(req.OrderID, scope, req.Attempt) {
+		logger.Info("Resuming payment of an earlier attempt", "orderID", req.OrderID, "key", payment.IdempotencyKey, "status", payment.Status)
+	}
+
+	switch payment.Status {
+	case domain.PaymentCaptured, domain.PaymentAuthorized:
+		auth = Authorization{ID: *payment.ProviderRef, Message: payment.Message}
+		return auth, payment.Status == domain.PaymentCaptured, nil
+	}
+
+	req.IdempotencyKey = payment.IdempotencyKey
+	auth, err = a.payments.Authorize(ctx, req)
+	status := domain.PaymentAuthorized
+	switch {
+	case errors.Is(err, errPaymentDeclined):
+		if recordErr := a.paymentRepo.Record(ctx, payment.ID, domain.PaymentDeclined, "", "", err.Error()); recordErr != nil {
+			return Authorization{}, false, recordErr
+		}
+		return Authorization{}, false, err
+	case err != nil:
+		// The attempt stays resumable even if this record is lost
+		if recordErr := a.paymentRepo.Record(ctx, payment.ID, domain.PaymentFailed, "", "", err.Error()); recordErr != nil {
+			logger.Warn("Failed to record failed payment", "orderID", req.OrderID, "payment", payment.ID, "error", recordErr)
+		}
+		return Authorization{}, false, err
+	case auth.Pending:
+		status = domain.PaymentPending
+	case auth.ChallengeRequired:
+		status = domain.PaymentChallenged
+	}
+
+	// Recorded before the outcome, so an attempt that resumes this one never
+	// leaves the order without them
+	if err := a.orderRepo.RecordPaymentMethod(ctx, req.OrderID, req.Method, req.PaymentCode); err != nil {
+		return Authorization{}, false, err
+	}
+	if err := a.paymentRepo.Record(ctx, payment.ID, status, auth.ID, auth.Message, ""); err != nil {
+		return Authorization{}, false, err
 	}
+	return auth, false, nil
+}
 
-	// Simulate processing time (1-8 seconds)
-	processingTime := time.Duration(rand.Intn(7)+1) * time.Second
-	select {
-	case <-time.After(processingTime):
-		// Processing complete
-	case <-ctx.Done():
-		return ValidatePaymentOutput{}, ctx.Err()
+// capturePayment takes an authorized payment, voiding the authorization if
+// the capture fails, and records the charge's outcome
+func (a *BookingActivities) capturePayment(ctx context.Context, orderID string, auth Authorization) (ValidatePaymentOutput, error) {
+	if err := a.payments.Capture(ctx, auth); err != nil {
+		if voidErr := a.payments.Void(ctx, auth); voidErr != nil {
+			activity.GetLogger(ctx).Warn("Failed to void uncaptured payment", "orderID", orderID, "authorization", auth.ID, "error", voidErr)
+		} else if recordErr := a.paymentRepo.RecordProviderRef(ctx, auth.ID, domain.PaymentVoided); recordErr != nil {
+			activity.GetLogger(ctx).Warn("Failed to record voided payment", "orderID", orderID, "authorization", auth.ID, "error", recordErr)
+		}
+		return ValidatePaymentOutput{}, paymentError(err)
 	}
 
-	// Simulate failure rate
-	if rand.Float64() < a.cfg.PaymentFailureRate {
-		// This error IS retryable (will be retried by Temporal)
-		return ValidatePaymentOutput{}, fmt.Errorf("payment validation failed: temporary gateway error")
+	// Unrecorded, the capture is repeated by the next attempt, which the
+	// provider treats as the same capture
+	if err := a.paymentRepo.RecordProviderRef(ctx, auth.ID, domain.PaymentCaptured); err != nil {
+		return ValidatePaymentOutput{}, err
 	}
 
 	return ValidatePaymentOutput{
 		Success: true,
-		Message: "Payment validated successfully",
+		Message: auth.Message,
 	}, nil
 }
+
+// paymentError types a declined payment as PAYMENT_DECLINED, which the
+// booking workflow does not retry; other errors are returned as they are
+func paymentError(err error) error {
+	if errors.Is(err, errPaymentDeclined) {
+		return temporal.NewApplicationError(err.Error(), temporalpkg.ErrTypePaymentDeclined)
+	}
+	return err
+}
+
+// recordPaymentFailure records a declined payment attempt in the order's
+// history. Errors Temporal retries have not failed the attempt yet.
+func (a *BookingActivities) recordPaymentFailure(ctx context.Context, orderID string, attempt int, err error) {
+	var appErr *temporal.ApplicationError
+	declined := errors.Is(err, errPaymentDeclined) ||
+		(errors.As(err, &appErr) && appErr.Type() == temporalpkg.ErrTypePaymentDeclined)
+	if !declined {
+		return
+	}
+	a.recordOrderEvent(ctx, orderID, domain.OrderEventPaymentFailed, map[string]interface{}{
+		"attempt": attempt,
+		"message": err.Error(),
+	})
+}
+
+// RefundPaymentInput identifies the charge to void
+type RefundPaymentInput struct {
+	OrderID     string
+	Method      domain.PaymentMethod
+	PaymentCode string
+	AmountCents int64
+	RefundID    string // the recorded refund an operator asked for, if any
+}
+
+// RefundPayment returns the payment of an order whose confirmation failed
+// after payment, or that an operator refunds, through the configured
+// PaymentProvider. Refunding the same order twice is harmless.
+func (a *BookingActivities) RefundPayment(ctx context.Context, input RefundPaymentInput) error {
+	if !input.Method.ValidCode(input.PaymentCode) {
+		return temporalpkg.NewInvalidPaymentCodeError()
+	}
+
+	err := a.payments.Refund(ctx, RefundRequest{
+		OrderID:     input.OrderID,
+		Method:      input.Method.OrCard(),
+		PaymentCode: input.PaymentCode,
+		AmountCents: input.AmountCents,
+		RefundID:    input.RefundID,
+	})
+	if err != nil {
+		return paymentError(err)
+	}
+
+	activity.GetLogger(ctx).Info("Payment refunded", "orderID", input.OrderID, "amountCents", input.AmountCents)
+	return nil
+}
+
+// processingHeartbeat is how often simulated provider calls heartbeat
+const processingHeartbeat = time.Second
+
+// simulateProcessing waits out a simulated provider call of duration d,
+// heartbeating the time spent so far each second so a worker lost mid-call
+// is noticed within the activity's heartbeat timeout
+func simulateProcessing(ctx context.Context, d time.Duration) error {
+	start := time.Now()
+	done := time.After(d)
+	ticker := time.NewTicker(processingHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-ticker.C:
+			activity.RecordHeartbeat(ctx, time.Since(start))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package activities
+
+import (
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestSimulateProcessing_Heartbeats(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(simulateProcessing)
+
+	var beats []time.Duration
+	env.SetOnActivityHeartbeatListener(func(_ *activity.Info, details converter.EncodedValues) {
+		var elapsed time.Duration
+		if err := details.Get(&elapsed); err != nil {
+			t.Errorf("decode heartbeat: %v", err)
+		}
+		beats = append(beats, elapsed)
+	})
+
+	if _, err := env.ExecuteActivity(simulateProcessing, 1500*time.Millisecond); err != nil {
+		t.Fatalf("simulateProcessing: %v", err)
+	}
+	if len(beats) != 1 || beats[0] < processingHeartbeat {
+		t.Errorf("heartbeats = %v, want one after %s", beats, processingHeartbeat)
+	}
+}
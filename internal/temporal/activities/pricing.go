@@ -0,0 +1,77 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// RepriceFlightsOutput counts the flights a repricing run checked and those
+// whose fare it changed
+type RepriceFlightsOutput struct {
+	Checked  int
+	Repriced int
+}
+
+// RepriceFlights reprices every flight still on sale from the dynamic
+// pricing curve and its current occupancy. It does nothing while no curve is
+// configured.
+func (a *BookingActivities) RepriceFlights(ctx context.Context) (RepriceFlightsOutput, error) {
+	var output RepriceFlightsOutput
+
+	curve, err := domain.ParsePricingCurve(a.cfg.PricingCurve)
+	if err != nil {
+		return output, err
+	}
+	if len(curve) == 0 {
+		return output, nil
+	}
+
+	flightIDs, err := a.flightRepo.FindOnSaleIDs(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	for _, flightID := range flightIDs {
+		change, err := a.flightRepo.Reprice(ctx, flightID, curve, domain.PriceChangeSchedule, nil)
+		if err != nil {
+			return output, fmt.Errorf("reprice flight %s: %w", flightID, err)
+		}
+		output.Checked++
+		if change != nil {
+			output.Repriced++
+		}
+		activity.RecordHeartbeat(ctx, output.Checked)
+	}
+
+	return output, nil
+}
+
+// repriceOnConfirmation reprices a flight whose available count an order
+// just changed, when dynamic pricing recomputes on confirmation. A failure is
+// only logged: the booking stands and the next repricing corrects the fare.
+func (a *BookingActivities) repriceOnConfirmation(ctx context.Context, flightID, orderID string) {
+	if a.cfg.PricingRecompute != string(domain.PriceChangeConfirmation) || a.cfg.PricingCurve == "" {
+		return
+	}
+
+	logger := activity.GetLogger(ctx)
+	curve, err := domain.ParsePricingCurve(a.cfg.PricingCurve)
+	if err != nil {
+		logger.Warn("Invalid pricing curve", "error", err)
+		return
+	}
+
+	change, err := a.flightRepo.Reprice(ctx, flightID, curve, domain.PriceChangeConfirmation, &orderID)
+	if err != nil {
+		logger.Warn("Failed to reprice flight", "flightID", flightID, "orderID", orderID, "error", err)
+		return
+	}
+	if change != nil {
+		logger.Info("Flight repriced", "flightID", flightID, "orderID", orderID,
+			"previousPriceCents", change.PreviousPriceCents, "priceCents", change.PriceCents)
+	}
+}
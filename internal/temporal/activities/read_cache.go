@@ -0,0 +1,105 @@
+package activities
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// Keys of the read-only activity results kept in the worker's read cache
+const (
+	cacheKeyFlightIDs = "flight_ids"
+)
+
+// readCache keeps the results of read-only activities in worker memory for a
+// short TTL, so periodic jobs that read the same rows each run, on every
+// worker, do not each go to Postgres. Writes made by this worker invalidate
+// the keys they affect; writes made elsewhere show up once an entry expires.
+// A zero TTL disables it.
+type readCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+	epoch   uint64 // bumped on every invalidation
+}
+
+type readCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]readCacheEntry),
+	}
+}
+
+// cachedRead returns the cached value of key or runs load and caches its
+// result, reporting whether the value came from the cache. Errors are never
+// cached, and a result is dropped if the cache was invalidated while load ran.
+func cachedRead[T any](ctx context.Context, c *readCache, key string, load func(context.Context) (T, error)) (T, bool, error) {
+	if c.ttl <= 0 {
+		value, err := load(ctx)
+		return value, false, err
+	}
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.value.(T), true, nil
+	}
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, false, err
+	}
+
+	c.mu.Lock()
+	if c.epoch == epoch {
+		c.entries[key] = readCacheEntry{value: value, expires: c.now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	return value, false, nil
+}
+
+// invalidate drops keys, or every entry when none are given
+func (c *readCache) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	if len(keys) == 0 {
+		clear(c.entries)
+		return
+	}
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// invalidateCachedReads drops cached results so the next read goes to
+// Postgres. Activities that add or remove flights call it with
+// cacheKeyFlightIDs. It stays unexported: every exported method of
+// BookingActivities is registered as an activity.
+func (a *BookingActivities) invalidateCachedReads(keys ...string) {
+	a.readCache.invalidate(keys...)
+}
+
+// recordCacheRead counts a cache lookup on the worker's metrics handler as
+// activity_read_cache_hits or activity_read_cache_misses, tagged by key
+func recordCacheRead(ctx context.Context, key string, hit bool) {
+	name := "activity_read_cache_misses"
+	if hit {
+		name = "activity_read_cache_hits"
+	}
+	activity.GetMetricsHandler(ctx).WithTags(map[string]string{"key": key}).Counter(name).Inc(1)
+}
@@ -0,0 +1,100 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadCache(t *testing.T) {
+	newCache := func(ttl time.Duration) (*readCache, *time.Time) {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		cache := newReadCache(ttl)
+		cache.now = func() time.Time { return now }
+		return cache, &now
+	}
+	counting := func(values ...string) (func(context.Context) (string, error), *int) {
+		calls := 0
+		return func(context.Context) (string, error) {
+			value := values[min(calls, len(values)-1)]
+			calls++
+			return value, nil
+		}, &calls
+	}
+
+	t.Run("reuses a result until it expires", func(t *testing.T) {
+		cache, now := newCache(time.Minute)
+		load, calls := counting("first", "second")
+
+		first, hit1, _ := cachedRead(context.Background(), cache, "key", load)
+		second, hit2, _ := cachedRead(context.Background(), cache, "key", load)
+		if first != "first" || second != "first" || hit1 || !hit2 || *calls != 1 {
+			t.Errorf("got %q (hit %v), %q (hit %v) after %d loads", first, hit1, second, hit2, *calls)
+		}
+
+		*now = now.Add(time.Minute)
+		third, hit, _ := cachedRead(context.Background(), cache, "key", load)
+		if third != "second" || hit || *calls != 2 {
+			t.Errorf("got %q (hit %v) after %d loads", third, hit, *calls)
+		}
+	})
+
+	t.Run("invalidation forces a new load", func(t *testing.T) {
+		cache, _ := newCache(time.Minute)
+		load, calls := counting("first", "second")
+
+		cachedRead(context.Background(), cache, "key", load)
+		cache.invalidate("key")
+		value, _, _ := cachedRead(context.Background(), cache, "key", load)
+		if value != "second" || *calls != 2 {
+			t.Errorf("got %q after %d loads", value, *calls)
+		}
+
+		cache.invalidate()
+		value, _, _ = cachedRead(context.Background(), cache, "key", load)
+		if *calls != 3 {
+			t.Errorf("got %q after %d loads, want 3", value, *calls)
+		}
+	})
+
+	t.Run("drops a result invalidated while loading", func(t *testing.T) {
+		cache, _ := newCache(time.Minute)
+		racing := func(context.Context) (string, error) {
+			cache.invalidate("key")
+			return "stale", nil
+		}
+		load, calls := counting("fresh")
+
+		cachedRead(context.Background(), cache, "key", racing)
+		value, _, _ := cachedRead(context.Background(), cache, "key", load)
+		if value != "fresh" || *calls != 1 {
+			t.Errorf("got %q after %d loads", value, *calls)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		cache, _ := newCache(time.Minute)
+		failing := func(context.Context) (string, error) { return "", errors.New("db down") }
+		load, calls := counting("ok")
+
+		if _, _, err := cachedRead(context.Background(), cache, "key", failing); err == nil {
+			t.Fatal("expected the load error")
+		}
+		value, _, _ := cachedRead(context.Background(), cache, "key", load)
+		if value != "ok" || *calls != 1 {
+			t.Errorf("got %q after %d loads", value, *calls)
+		}
+	})
+
+	t.Run("zero TTL disables caching", func(t *testing.T) {
+		cache, _ := newCache(0)
+		load, calls := counting("value")
+
+		cachedRead(context.Background(), cache, "key", load)
+		cachedRead(context.Background(), cache, "key", load)
+		if *calls != 2 {
+			t.Errorf("got %d loads, want 2", *calls)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// BeginRefundInput identifies the confirmed order to refund
+type BeginRefundInput struct {
+	OrderID string
+	Reason  string
+	Version *int // order version the refund was asked against; nil refunds any
+}
+
+// BeginRefundOutput is the pending refund and the payment it returns
+type BeginRefundOutput struct {
+	RefundID    string
+	Method      domain.PaymentMethod
+	PaymentCode string
+	AmountCents int64
+}
+
+// BeginRefund records a pending refund of the order's full price. A refund
+// already pending for the order is resumed. Orders that are not confirmed
+// fail with a non-retryable ErrTypeNotRefundable error, and orders written
+// since Version with an ErrTypeVersionConflict one.
+func (a *BookingActivities) BeginRefund(ctx context.Context, input BeginRefundInput) (BeginRefundOutput, error) {
+	var output BeginRefundOutput
+
+	order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+	if err != nil {
+		return output, fmt.Errorf("load order to refund: %w", err)
+	}
+	if order.PaymentCode == nil {
+		return output, temporalpkg.NewNotRefundableError(input.OrderID)
+	}
+
+	refund, err := a.refundRepo.Begin(ctx, input.OrderID, input.Reason, input.Version)
+	if errors.Is(err, domain.ErrOrderNotRefundable) {
+		return output, temporalpkg.NewNotRefundableError(input.OrderID)
+	}
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return output, temporalpkg.NewVersionConflictError(input.OrderID)
+	}
+	if err != nil {
+		return output, fmt.Errorf("begin refund of order %s: %w", input.OrderID, err)
+	}
+
+	output.RefundID = refund.ID
+	output.Method = domain.PaymentMethodCard
+	if order.PaymentMethod != nil {
+		output.Method = *order.PaymentMethod
+	}
+	output.PaymentCode = *order.PaymentCode
+	output.AmountCents = refund.AmountCents
+	return output, nil
+}
+
+// CompleteRefundInput identifies the refund the payment provider completed
+type CompleteRefundInput struct {
+	RefundID string
+	OrderID  string
+}
+
+// CompleteRefund records a refund the payment provider completed: the order
+// is marked refunded and its revenue reversed, then its seats go back on
+// sale and a PAYMENT_REFUNDED webhook event is queued. Every step is guarded
+// by the order's ID, so a retry is safe.
+func (a *BookingActivities) CompleteRefund(ctx context.Context, input CompleteRefundInput) (domain.Refund, error) {
+	refund, completed, err := a.refundRepo.Complete(ctx, input.RefundID)
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("complete refund %s: %w", input.RefundID, err)
+	}
+
+	if err := a.releaseConfirmedSeats(ctx, input.OrderID); err != nil {
+		return *refund, err
+	}
+
+	if completed {
+		if err := a.PublishOrderEvent(ctx, PublishOrderEventInput{OrderID: input.OrderID, Event: domain.EventRefunded}); err != nil {
+			return *refund, err
+		}
+		activity.GetLogger(ctx).Info("Order refunded", "orderID", input.OrderID, "refundID", refund.ID, "amountCents", refund.AmountCents)
+	}
+
+	return *refund, nil
+}
+
+// releaseConfirmedSeats puts the seats and capacity of an order that no
+// longer holds its booking back on sale. Every step is guarded by the
+// order's ID, so repeating it is safe.
+func (a *BookingActivities) releaseConfirmedSeats(ctx context.Context, orderID string) error {
+	order, err := a.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("load order %s: %w", orderID, err)
+	}
+
+	if err := a.orderRepo.UncountSeats(ctx, order.ID); err != nil {
+		return fmt.Errorf("uncount seats for order %s: %w", order.ID, err)
+	}
+	if err := a.orderRepo.ReleaseCabinSeats(ctx, order.ID); err != nil {
+		return fmt.Errorf("release cabin seats for order %s: %w", order.ID, err)
+	}
+	if _, err := a.flightRepo.ReleaseStaleSeats(ctx, order.FlightID, order.Seats, &orderID); err != nil {
+		return err
+	}
+	a.repriceOnConfirmation(ctx, order.FlightID, order.ID)
+
+	return nil
+}
+
+// FailRefundInput records why the payment provider did not complete a refund
+type FailRefundInput struct {
+	RefundID string
+	Error    string
+}
+
+// FailRefund records a refund the payment provider did not complete. The
+// order stays confirmed and may be refunded again.
+func (a *BookingActivities) FailRefund(ctx context.Context, input FailRefundInput) (domain.Refund, error) {
+	refund, err := a.refundRepo.Fail(ctx, input.RefundID, input.Error)
+	if err != nil {
+		return domain.Refund{}, fmt.Errorf("fail refund %s: %w", input.RefundID, err)
+	}
+
+	return *refund, nil
+}
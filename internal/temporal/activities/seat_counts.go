@@ -0,0 +1,37 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// RepairSeatCountsOutput lists the flights whose seat counts were repaired
+type RepairSeatCountsOutput struct {
+	FlightIDs []string
+}
+
+// RepairSeatCounts recounts the flights whose stored total and available
+// seats disagree with their seat rows and confirmed orders. The database
+// recounts a flight on every seat and order write, so a repair means the
+// counts were changed behind its back, e.g. by hand or by a restored backup;
+// each repaired flight is logged.
+func (a *BookingActivities) RepairSeatCounts(ctx context.Context) (RepairSeatCountsOutput, error) {
+	flightIDs, err := a.flightRepo.FindMiscountedIDs(ctx)
+	if err != nil {
+		return RepairSeatCountsOutput{}, err
+	}
+	if len(flightIDs) == 0 {
+		return RepairSeatCountsOutput{}, nil
+	}
+
+	if err := a.flightRepo.RecomputeSeatCounts(ctx, flightIDs); err != nil {
+		return RepairSeatCountsOutput{}, fmt.Errorf("repair seat counts: %w", err)
+	}
+	for _, flightID := range flightIDs {
+		activity.GetLogger(ctx).Warn("Repaired drifted seat counts", "flightID", flightID)
+	}
+
+	return RepairSeatCountsOutput{FlightIDs: flightIDs}, nil
+}
@@ -0,0 +1,47 @@
+package activities
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// publishSeatChange tells API nodes that seats on a flight changed status.
+// Publishing is best effort: a failure is logged and the activity goes on,
+// since clients fall back to reading the seat map. A retried activity may
+// publish the same change again, which is harmless as it carries the status
+// rather than a delta.
+func (a *BookingActivities) publishSeatChange(ctx context.Context, flightID string, seats []string, status domain.SeatStatus) {
+	if len(seats) == 0 {
+		return
+	}
+
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stepTimeout)
+	defer cancel()
+
+	err := a.seatEvents.Publish(publishCtx, domain.SeatChange{
+		FlightID:   flightID,
+		Seats:      seats,
+		Status:     status,
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Failed to publish seat change", "flightID", flightID, "status", status, "error", err)
+	}
+}
+
+// publishSeatSelection publishes a seat change from oldSeats to newSeats: the
+// old seats the order gave up are available again and the new ones are
+// reserved. Seats kept across the change stay reserved, so they are not
+// published as available.
+func (a *BookingActivities) publishSeatSelection(ctx context.Context, flightID string, oldSeats, newSeats []string) {
+	freed := slices.DeleteFunc(slices.Clone(oldSeats), func(seat string) bool {
+		return slices.Contains(newSeats, seat)
+	})
+	a.publishSeatChange(ctx, flightID, freed, domain.SeatStatusAvailable)
+	a.publishSeatChange(ctx, flightID, newSeats, domain.SeatStatusReserved)
+}
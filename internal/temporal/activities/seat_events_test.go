@@ -0,0 +1,96 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/database"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// TestPublishSeatSelection subscribes to a flight's seat channel on the
+// docker-compose Redis, skipped when it is unavailable, and checks what a
+// seat change publishes
+func TestPublishSeatSelection(t *testing.T) {
+	ctx := context.Background()
+	client, err := database.NewRedisClient(ctx, config.Load().Redis)
+	if err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	tests := []struct {
+		name     string
+		oldSeats []string
+		newSeats []string
+		want     []domain.SeatChange // Seats and Status of each message, in order
+	}{
+		{
+			name:     "disjoint selections",
+			oldSeats: []string{"1A", "1B"},
+			newSeats: []string{"2A", "2B"},
+			want: []domain.SeatChange{
+				{Seats: []string{"1A", "1B"}, Status: domain.SeatStatusAvailable},
+				{Seats: []string{"2A", "2B"}, Status: domain.SeatStatusReserved},
+			},
+		},
+		{
+			name:     "a kept seat is not freed",
+			oldSeats: []string{"1A", "1B"},
+			newSeats: []string{"1B", "1C"},
+			want: []domain.SeatChange{
+				{Seats: []string{"1A"}, Status: domain.SeatStatusAvailable},
+				{Seats: []string{"1B", "1C"}, Status: domain.SeatStatusReserved},
+			},
+		},
+		{
+			name:     "seats added",
+			oldSeats: []string{"1A"},
+			newSeats: []string{"1A", "1B"},
+			want: []domain.SeatChange{
+				{Seats: []string{"1A", "1B"}, Status: domain.SeatStatusReserved},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flightID := uuid.New().String()
+			sub := client.Subscribe(ctx, repository.SeatChannel(flightID))
+			t.Cleanup(func() { sub.Close() })
+			if _, err := sub.Receive(ctx); err != nil {
+				t.Fatalf("subscribe: %v", err)
+			}
+
+			a := &BookingActivities{seatEvents: repository.NewSeatEventRepo(client)}
+			a.publishSeatSelection(ctx, flightID, tt.oldSeats, tt.newSeats)
+
+			for _, want := range tt.want {
+				select {
+				case msg := <-sub.Channel():
+					var got domain.SeatChange
+					if err := json.Unmarshal([]byte(msg.Payload), &got); err != nil {
+						t.Fatalf("decode %q: %v", msg.Payload, err)
+					}
+					if got.FlightID != flightID || got.Status != want.Status || !slices.Equal(got.Seats, want.Seats) {
+						t.Errorf("received %s %v on %s, want %s %v", got.Status, got.Seats, got.FlightID, want.Status, want.Seats)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("no %s message for %v", want.Status, want.Seats)
+				}
+			}
+			select {
+			case msg := <-sub.Channel():
+				t.Errorf("unexpected message %s", msg.Payload)
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
@@ -2,137 +2,359 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
 )
 
 // ReserveSeatInput contains parameters for seat reservation
 type ReserveSeatInput struct {
-	OrderID  string
-	FlightID string
-	Seats    []string
+	OrderID      string
+	FlightID     string
+	Seats        []string
+	HoldDuration time.Duration // zero uses the configured seat reservation timeout
+
+	// LockToken adds the seats to locks the order already holds under that
+	// fencing token, as a group booking's later chunks do; zero takes a new one
+	LockToken int64
+}
+
+// ReserveSeatsOutput is the fencing token the reserved seats' locks are held
+// under, which releasing, extending and confirming them must present
+type ReserveSeatsOutput struct {
+	LockToken int64
 }
 
 // ReserveSeats acquires Redis locks and marks seats as reserved in DB atomically
-// TTL is set to 16 minutes (1 min buffer over 15 min workflow timer)
-// On failure, compensates by releasing any acquired locks
-func (a *BookingActivities) ReserveSeats(ctx context.Context, input ReserveSeatInput) error {
-	// Use configured timeout + 1 minute buffer for Redis TTL
-	ttl := a.cfg.SeatReservationTimeout + time.Minute
+// TTL is set to 16 minutes (domain.SeatLockGrace over the 15 min workflow timer)
+// On failure, compensates by releasing any acquired locks. A flight with
+// bookings frozen fails with a non-retryable flight frozen error.
+func (a *BookingActivities) ReserveSeats(ctx context.Context, input ReserveSeatInput) (ReserveSeatsOutput, error) {
+	ttl := a.lockTTL(input.HoldDuration)
+
+	// Don't take locks unless the DB step can also run
+	if err := ensureBudget(ctx, 3); err != nil {
+		return ReserveSeatsOutput{}, fmt.Errorf("reserve seats for order %s: %w", input.OrderID, err)
+	}
+
+	// The service checks too, but a freeze or sales close can land after the
+	// workflow started
+	if err := a.ensureBookable(ctx, input.FlightID); err != nil {
+		return ReserveSeatsOutput{}, err
+	}
 
 	// Step 1: Acquire Redis locks
-	err := a.seatLockRepo.LockSeats(ctx, input.FlightID, input.Seats, input.OrderID, ttl)
+	var token int64
+	err := runStep(ctx, "lock seats", func(ctx context.Context) (err error) {
+		token, err = a.seatLockRepo.LockSeats(ctx, input.FlightID, input.Seats, input.OrderID, input.LockToken, ttl)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("lock seats for order %s: %w", input.OrderID, err)
+		return ReserveSeatsOutput{}, fmt.Errorf("reserve seats for order %s: %w", input.OrderID, err)
 	}
 
 	// Step 2: Mark seats as reserved in DB
-	err = a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.Seats, input.OrderID)
+	err = runStep(ctx, "mark seats reserved", func(ctx context.Context) error {
+		return a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.Seats, input.OrderID, token)
+	})
 	if err != nil {
 		// Compensate: release Redis locks
-		_ = a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID)
-		return fmt.Errorf("mark seats reserved in DB for order %s: %w", input.OrderID, err)
+		compensate(ctx, "release locks", func(ctx context.Context) error {
+			return a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID, token)
+		})
+		return ReserveSeatsOutput{}, fmt.Errorf("reserve seats for order %s: %w", input.OrderID, err)
 	}
 
-	return nil
+	a.recordOrderEvent(ctx, input.OrderID, domain.OrderEventSeatsReserved, map[string]interface{}{"seats": input.Seats})
+	a.publishSeatChange(ctx, input.FlightID, input.Seats, domain.SeatStatusReserved)
+	return ReserveSeatsOutput{LockToken: token}, nil
 }
 
-// ReleaseSeatsInput contains parameters for releasing seats
-type ReleaseSeatsInput struct {
+// HoldCabinSeatsInput contains parameters for a seatless capacity hold
+type HoldCabinSeatsInput struct {
 	OrderID  string
 	FlightID string
-	Seats    []string
+	Count    int
 }
 
-// ReleaseSeats releases Redis locks and marks seats as available in DB
-// Only releases if the lock is owned by this order (atomic via Lua script)
-func (a *BookingActivities) ReleaseSeats(ctx context.Context, input ReleaseSeatsInput) error {
-	// Step 1: Release Redis locks
-	err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID)
-	if err != nil {
-		return fmt.Errorf("release seats for order %s: %w", input.OrderID, err)
+// HoldCabinSeats holds capacity for a seatless order. No seat is locked;
+// the hold is the order's cabin_seats, which seated reservations respect.
+func (a *BookingActivities) HoldCabinSeats(ctx context.Context, input HoldCabinSeatsInput) error {
+	if err := a.flightRepo.HoldCabinSeats(ctx, input.FlightID, input.OrderID, input.Count); err != nil {
+		return fmt.Errorf("hold cabin seats for order %s: %w", input.OrderID, err)
 	}
 
-	// Step 2: Mark seats as available in DB
-	err = a.flightRepo.MarkSeatsAvailable(ctx, input.FlightID, input.Seats)
-	if err != nil {
-		return fmt.Errorf("mark seats available in DB for order %s: %w", input.OrderID, err)
+	return nil
+}
+
+// ReleaseCabinSeatsInput contains parameters for releasing a capacity hold
+type ReleaseCabinSeatsInput struct {
+	OrderID string
+}
+
+// ReleaseCabinSeats drops a seatless order's capacity hold; idempotent
+func (a *BookingActivities) ReleaseCabinSeats(ctx context.Context, input ReleaseCabinSeatsInput) error {
+	if err := a.orderRepo.ReleaseCabinSeats(ctx, input.OrderID); err != nil {
+		return fmt.Errorf("release cabin seats for order %s: %w", input.OrderID, err)
 	}
 
 	return nil
 }
 
-// RefreshSeatLocksInput contains parameters for refreshing seat locks
-type RefreshSeatLocksInput struct {
+// ReleaseSeatsInput contains parameters for releasing seats
+type ReleaseSeatsInput struct {
 	OrderID  string
 	FlightID string
 	Seats    []string
+
+	// LockToken is the fencing token the order holds the seats under; zero
+	// releases the order's locks whatever their token
+	LockToken int64
 }
 
-// RefreshSeatLocks extends the TTL for all seat locks
-// Called when user updates seat selection to reset the hold timer
-func (a *BookingActivities) RefreshSeatLocks(ctx context.Context, input RefreshSeatLocksInput) error {
-	// Use configured timeout + 1 minute buffer
-	ttl := a.cfg.SeatReservationTimeout + time.Minute
+// ReleaseSeats releases Redis locks and marks seats as available in DB
+// Only releases if the lock is owned by this order under input.LockToken
+// (atomic via Lua script), so a delayed retry cannot free seats the order
+// has since locked again
+func (a *BookingActivities) ReleaseSeats(ctx context.Context, input ReleaseSeatsInput) error {
+	// Both steps are idempotent, so a retry after a partial release is safe
+	if err := ensureBudget(ctx, 2); err != nil {
+		return fmt.Errorf("release seats for order %s: %w", input.OrderID, err)
+	}
 
-	err := a.seatLockRepo.ExtendLocks(ctx, input.FlightID, input.Seats, input.OrderID, ttl)
+	// Step 1: Release Redis locks
+	err := runStep(ctx, "release locks", func(ctx context.Context) error {
+		return a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID, input.LockToken)
+	})
 	if err != nil {
-		return fmt.Errorf("refresh seat locks for order %s: %w", input.OrderID, err)
+		return fmt.Errorf("release seats for order %s: %w", input.OrderID, err)
 	}
 
+	// Step 2: Mark seats as available in DB
+	err = runStep(ctx, "mark seats available", func(ctx context.Context) error {
+		return a.flightRepo.MarkSeatsAvailable(ctx, input.FlightID, input.Seats)
+	})
+	if err != nil {
+		return fmt.Errorf("release seats for order %s: %w", input.OrderID, err)
+	}
+
+	if len(input.Seats) > 0 {
+		a.recordOrderEvent(ctx, input.OrderID, domain.OrderEventSeatsReleased, map[string]interface{}{"seats": input.Seats})
+	}
+	a.publishSeatChange(ctx, input.FlightID, input.Seats, domain.SeatStatusAvailable)
 	return nil
 }
 
 // UpdateSeatSelectionInput contains parameters for changing seat selection
 type UpdateSeatSelectionInput struct {
-	OrderID  string
-	FlightID string
-	OldSeats []string
-	NewSeats []string
+	OrderID      string
+	FlightID     string
+	OldSeats     []string
+	NewSeats     []string
+	HoldDuration time.Duration // zero uses the configured seat reservation timeout
+
+	// LockToken is the fencing token the order holds OldSeats under; zero
+	// matches the order's locks whatever their token
+	LockToken int64
+}
+
+// UpdateSeatSelectionOutput is the fencing token the new seats' locks are
+// held under
+type UpdateSeatSelectionOutput struct {
+	LockToken int64
 }
 
 // UpdateSeatSelection releases old seats and acquires new ones atomically
-// Updates both Redis locks and DB seat status
-func (a *BookingActivities) UpdateSeatSelection(ctx context.Context, input UpdateSeatSelectionInput) error {
-	ttl := a.cfg.SeatReservationTimeout + time.Minute
+// Updates both Redis locks and DB seat status. New seats another order holds
+// fail with a non-retryable seat unavailable error, and new seats on a flight
+// with bookings frozen with a non-retryable flight frozen error. The new
+// seats are locked under a new fencing token; a failed change puts the old
+// seats back under input.LockToken.
+func (a *BookingActivities) UpdateSeatSelection(ctx context.Context, input UpdateSeatSelectionInput) (UpdateSeatSelectionOutput, error) {
+	// The freeze check, release and acquire are five steps; refuse to start a
+	// swap that can't finish
+	if err := ensureBudget(ctx, 5); err != nil {
+		return UpdateSeatSelectionOutput{}, fmt.Errorf("update seat selection: %w", err)
+	}
+
+	// Checked before the old seats go, so a refused change keeps them
+	if len(input.NewSeats) > 0 {
+		if err := a.ensureBookable(ctx, input.FlightID); err != nil {
+			return UpdateSeatSelectionOutput{}, err
+		}
+	}
 
 	// Release old seats first (Redis + DB)
 	if len(input.OldSeats) > 0 {
-		if err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.OldSeats, input.OrderID); err != nil {
-			return fmt.Errorf("release old seat locks: %w", err)
+		err := runStep(ctx, "release old seat locks", func(ctx context.Context) error {
+			return a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.OldSeats, input.OrderID, input.LockToken)
+		})
+		if err != nil {
+			return UpdateSeatSelectionOutput{}, err
 		}
-		if err := a.flightRepo.MarkSeatsAvailable(ctx, input.FlightID, input.OldSeats); err != nil {
-			return fmt.Errorf("mark old seats available: %w", err)
+		err = runStep(ctx, "mark old seats available", func(ctx context.Context) error {
+			return a.flightRepo.MarkSeatsAvailable(ctx, input.FlightID, input.OldSeats)
+		})
+		if err != nil {
+			return UpdateSeatSelectionOutput{}, err
 		}
 	}
 
 	// Acquire new seats (Redis + DB)
+	var token int64
 	if len(input.NewSeats) > 0 {
-		if err := a.seatLockRepo.LockSeats(ctx, input.FlightID, input.NewSeats, input.OrderID, ttl); err != nil {
+		var err error
+		if token, err = a.acquireNewSeats(ctx, input); err != nil {
 			// Try to re-acquire old seats on failure (best effort compensation)
-			_ = a.seatLockRepo.LockSeats(ctx, input.FlightID, input.OldSeats, input.OrderID, ttl)
-			_ = a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.OldSeats, input.OrderID)
-			return fmt.Errorf("lock new seats: %w", err)
-		}
-		if err := a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.NewSeats, input.OrderID); err != nil {
-			// Compensate: release Redis locks we just acquired
-			_ = a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.NewSeats, input.OrderID)
-			// Re-acquire old seats (best effort)
-			_ = a.seatLockRepo.LockSeats(ctx, input.FlightID, input.OldSeats, input.OrderID, ttl)
-			_ = a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.OldSeats, input.OrderID)
-			return fmt.Errorf("mark new seats reserved: %w", err)
+			a.reacquireSeats(ctx, input.FlightID, input.OldSeats, input.OrderID, input.LockToken, input.HoldDuration)
+			var locked *domain.SeatLockedError
+			if errors.As(err, &locked) {
+				return UpdateSeatSelectionOutput{}, temporalpkg.NewSeatLockedError(locked.SeatID)
+			}
+			if errors.Is(err, domain.ErrSeatsAlreadyLocked) || errors.Is(err, domain.ErrSeatUnavailable) {
+				return UpdateSeatSelectionOutput{}, temporalpkg.NewSeatUnavailableError(strings.Join(input.NewSeats, ", "))
+			}
+			return UpdateSeatSelectionOutput{}, err
 		}
 	}
 
+	a.recordOrderEvent(ctx, input.OrderID, domain.OrderEventSeatsChanged, map[string]interface{}{
+		"from": input.OldSeats,
+		"to":   input.NewSeats,
+	})
+	a.publishSeatSelection(ctx, input.FlightID, input.OldSeats, input.NewSeats)
+	return UpdateSeatSelectionOutput{LockToken: token}, nil
+}
+
+// ensureBookable fails with a non-retryable error when an admin has frozen
+// bookings on the flight or its sales have closed
+func (a *BookingActivities) ensureBookable(ctx context.Context, flightID string) error {
+	var flight *domain.Flight
+	err := runStep(ctx, "load flight", func(ctx context.Context) (err error) {
+		flight, err = a.flightRepo.FindByID(ctx, flightID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("check flight %s: %w", flightID, err)
+	}
+	if flight.BookingFrozen {
+		return temporalpkg.NewFlightFrozenError(flightID)
+	}
+	if !flight.SalesOpen(time.Now(), a.cfg.SalesCloseBefore) {
+		return temporalpkg.NewSalesClosedError(flightID)
+	}
+
 	return nil
 }
 
-// GetAllFlightIDs returns all flight IDs from the database
+// acquireNewSeats locks and reserves the new selection under a new fencing
+// token, releasing the new locks again if the DB step fails
+func (a *BookingActivities) acquireNewSeats(ctx context.Context, input UpdateSeatSelectionInput) (int64, error) {
+	ttl := a.lockTTL(input.HoldDuration)
+
+	var token int64
+	err := runStep(ctx, "lock new seats", func(ctx context.Context) (err error) {
+		token, err = a.seatLockRepo.LockSeats(ctx, input.FlightID, input.NewSeats, input.OrderID, 0, ttl)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = runStep(ctx, "mark new seats reserved", func(ctx context.Context) error {
+		return a.flightRepo.MarkSeatsReserved(ctx, input.FlightID, input.NewSeats, input.OrderID, token)
+	})
+	if err != nil {
+		// Compensate: release Redis locks we just acquired
+		compensate(ctx, "release new seat locks", func(ctx context.Context) error {
+			return a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.NewSeats, input.OrderID, token)
+		})
+		return 0, err
+	}
+
+	return token, nil
+}
+
+// reacquireSeats restores a previous selection after a failed seat change,
+// under the fencing token the order held it under
+func (a *BookingActivities) reacquireSeats(ctx context.Context, flightID string, seats []string, orderID string, token int64, hold time.Duration) {
+	if len(seats) == 0 {
+		return
+	}
+	ttl := a.lockTTL(hold)
+
+	compensate(ctx, "re-lock old seats", func(ctx context.Context) error {
+		_, err := a.seatLockRepo.LockSeats(ctx, flightID, seats, orderID, token, ttl)
+		return err
+	})
+	compensate(ctx, "re-reserve old seats", func(ctx context.Context) error {
+		return a.flightRepo.MarkSeatsReserved(ctx, flightID, seats, orderID, token)
+	})
+}
+
+// lockTTL is how long seat locks live for a hold: domain.SeatLockGrace
+// longer than the hold, so the workflow timer fires before the locks lapse
+func (a *BookingActivities) lockTTL(hold time.Duration) time.Duration {
+	if hold <= 0 {
+		hold = a.cfg.SeatReservationTimeout
+	}
+	return hold + domain.SeatLockGrace
+}
+
+// ExtendHoldInput contains parameters for refreshing a seat hold
+type ExtendHoldInput struct {
+	OrderID   string
+	FlightID  string
+	Seats     []string
+	ExpiresAt time.Time
+
+	// LockToken is the fencing token the order holds the seats under; zero
+	// extends the order's locks whatever their token
+	LockToken int64
+}
+
+// ExtendHold refreshes the Redis lock TTLs and the order expiration for the
+// current seats. Both steps are idempotent, so retries are safe. Locks the
+// order no longer holds under input.LockToken are not extended.
+func (a *BookingActivities) ExtendHold(ctx context.Context, input ExtendHoldInput) error {
+	if err := ensureBudget(ctx, 2); err != nil {
+		return fmt.Errorf("extend hold for order %s: %w", input.OrderID, err)
+	}
+
+	// Keep the locks outliving the hold by the same buffer ReserveSeats uses
+	ttl := time.Until(input.ExpiresAt) + domain.SeatLockGrace
+	err := runStep(ctx, "extend locks", func(ctx context.Context) error {
+		return a.seatLockRepo.ExtendLocks(ctx, input.FlightID, input.Seats, input.OrderID, input.LockToken, ttl)
+	})
+	if err != nil {
+		return fmt.Errorf("extend hold for order %s: %w", input.OrderID, err)
+	}
+
+	err = runStep(ctx, "extend order expiration", func(ctx context.Context) error {
+		return a.orderRepo.ExtendHold(ctx, input.OrderID, input.ExpiresAt)
+	})
+	if err != nil {
+		return fmt.Errorf("extend hold for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
+
+// GetAllFlightIDs returns all flight IDs from the database, reusing the list
+// for ACTIVITY_CACHE_TTL
 func (a *BookingActivities) GetAllFlightIDs(ctx context.Context) ([]string, error) {
-	flightIDs, err := a.flightRepo.GetAllFlightIDs(ctx)
+	flightIDs, hit, err := cachedRead(ctx, a.readCache, cacheKeyFlightIDs, a.flightRepo.GetAllFlightIDs)
 	if err != nil {
 		return nil, fmt.Errorf("get all flight IDs: %w", err)
 	}
+	recordCacheRead(ctx, cacheKeyFlightIDs, hit)
 	return flightIDs, nil
 }
 
@@ -141,10 +363,24 @@ type ReconcileSeatLocksInput struct {
 	FlightID string
 }
 
+// ReconcileSeatLocksProgress is heartbeated after each orphaned lock, so a
+// retry resumes after the last seat handled instead of starting over
+type ReconcileSeatLocksProgress struct {
+	LastSeatID string
+	Released   int
+}
+
 // ReconcileSeatLocks reconciles Redis locks with DB seat status
 // Releases orphaned Redis locks that don't match DB reserved/booked seats
 // This runs periodically to clean up after failures or crashes
 func (a *BookingActivities) ReconcileSeatLocks(ctx context.Context, input ReconcileSeatLocksInput) error {
+	var progress ReconcileSeatLocksProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err == nil {
+			activity.GetLogger(ctx).Info("Resuming seat reconciliation", "flightID", input.FlightID, "afterSeat", progress.LastSeatID)
+		}
+	}
+
 	// Get all Redis locks for this flight
 	redisLocks, err := a.seatLockRepo.GetLockedSeats(ctx, input.FlightID)
 	if err != nil {
@@ -167,7 +403,8 @@ func (a *BookingActivities) ReconcileSeatLocks(ctx context.Context, input Reconc
 		}
 	}
 
-	// Find orphaned locks (in Redis but not reserved/booked in DB)
+	// Find orphaned locks (in Redis but not reserved/booked in DB), in seat
+	// order so a resumed run can skip the seats already handled
 	orphanedLocks := make([]string, 0)
 	for seatID, redisOrderID := range redisLocks {
 		dbOrderID, existsInDB := dbReservedSeats[seatID]
@@ -176,17 +413,24 @@ func (a *BookingActivities) ReconcileSeatLocks(ctx context.Context, input Reconc
 			orphanedLocks = append(orphanedLocks, seatID)
 		}
 	}
+	slices.Sort(orphanedLocks)
 
 	// Release orphaned locks
-	if len(orphanedLocks) > 0 {
-		for _, seatID := range orphanedLocks {
-			orderID := redisLocks[seatID]
-			err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, []string{seatID}, orderID)
-			if err != nil {
-				// Log but continue - best effort cleanup
-				continue
+	for _, seatID := range orphanedLocks {
+		if seatID <= progress.LastSeatID {
+			continue
+		}
+		orderID := redisLocks[seatID]
+		if err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, []string{seatID}, orderID, 0); err == nil {
+			progress.Released++
+			if err := a.opsRepo.RecordLockRepair(ctx, input.FlightID, seatID, orderID); err != nil {
+				activity.GetLogger(ctx).Warn("Failed to record lock repair", "flightID", input.FlightID, "seatID", seatID, "error", err)
 			}
 		}
+		// Best effort cleanup: a lock that could not be released is left
+		// for the next run
+		progress.LastSeatID = seatID
+		activity.RecordHeartbeat(ctx, progress)
 	}
 
 	return nil
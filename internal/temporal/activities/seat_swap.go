@@ -0,0 +1,43 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// SeatSwapInput identifies a matched swap offer
+type SeatSwapInput struct {
+	OfferID string
+}
+
+// ExecuteSeatSwap atomically exchanges the seats of a matched offer
+func (a *BookingActivities) ExecuteSeatSwap(ctx context.Context, input SeatSwapInput) error {
+	err := a.swapRepo.ExecuteSwap(ctx, input.OfferID)
+	if errors.Is(err, domain.ErrSwapNotAllowed) || errors.Is(err, domain.ErrSwapOfferNotFound) {
+		return temporalpkg.NewSwapNotAllowedError(input.OfferID)
+	}
+	if err != nil {
+		return fmt.Errorf("execute seat swap: %w", err)
+	}
+
+	return nil
+}
+
+// FailSeatSwapInput contains parameters for failing a swap offer
+type FailSeatSwapInput struct {
+	OfferID string
+	Reason  string
+}
+
+// FailSeatSwap records that a matched offer could not be executed
+func (a *BookingActivities) FailSeatSwap(ctx context.Context, input FailSeatSwapInput) error {
+	if err := a.swapRepo.FailOffer(ctx, input.OfferID, input.Reason); err != nil {
+		return fmt.Errorf("fail seat swap: %w", err)
+	}
+
+	return nil
+}
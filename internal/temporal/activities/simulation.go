@@ -0,0 +1,56 @@
+package activities
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// randSource is the part of *rand.Rand simulated outcomes draw from
+type randSource interface {
+	Intn(n int) int
+	Float64() float64
+}
+
+// simulation draws the random outcomes of simulated payments, refunds and
+// disruptions. Seeded with SIMULATION_SEED, a worker replays the same
+// outcomes for the same sequence of calls.
+type simulation struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// newSimulation creates the shared source of simulated outcomes; a zero seed
+// picks one from the clock
+func newSimulation(seed int64) *simulation {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &simulation{rng: rand.New(rand.NewSource(seed))}
+}
+
+// source returns what one simulated call draws from: the shared source, or
+// one derived from an order's own seed and key when the order overrides it,
+// so the same seed gives that order the same outcomes in any run
+func (s *simulation) source(orderSeed *int64, key string) randSource {
+	if orderSeed != nil {
+		h := fnv.New64a()
+		h.Write([]byte(strconv.FormatInt(*orderSeed, 10) + ":" + key))
+		return rand.New(rand.NewSource(int64(h.Sum64())))
+	}
+	return s
+}
+
+func (s *simulation) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Intn(n)
+}
+
+func (s *simulation) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64()
+}
@@ -0,0 +1,33 @@
+package activities
+
+import "testing"
+
+func TestSimulation_SeedReplaysOutcomes(t *testing.T) {
+	draw := func(sim *simulation, orderSeed *int64, key string) []float64 {
+		rng := sim.source(orderSeed, key)
+		return []float64{float64(rng.Intn(7)), rng.Float64(), rng.Float64()}
+	}
+	equal := func(a, b []float64) bool {
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if a, b := draw(newSimulation(7), nil, ""), draw(newSimulation(7), nil, ""); !equal(a, b) {
+		t.Errorf("same SIMULATION_SEED drew %v then %v", a, b)
+	}
+
+	// An order's seed ignores how far the shared source has advanced
+	busy := newSimulation(7)
+	busy.Float64()
+	seed := int64(42)
+	if a, b := draw(newSimulation(7), &seed, "payment:1"), draw(busy, &seed, "payment:1"); !equal(a, b) {
+		t.Errorf("same order seed drew %v then %v", a, b)
+	}
+	if a, b := draw(busy, &seed, "payment:1"), draw(busy, &seed, "payment:2"); equal(a, b) {
+		t.Errorf("attempts 1 and 2 drew the same outcome %v", a)
+	}
+}
@@ -0,0 +1,127 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// FindStaleOrdersInput bounds one sweep for stale orders
+type FindStaleOrdersInput struct {
+	Grace time.Duration // how long past its hold an order is left to its workflow
+	Limit int
+}
+
+// StaleOrder is an order whose seat hold expired while it still held seats
+type StaleOrder struct {
+	OrderID  string
+	FlightID string
+	Seats    []string
+	Seatless bool
+}
+
+// FindStaleOrders returns orders still holding seats more than Grace after
+// their hold expired, oldest first
+func (a *BookingActivities) FindStaleOrders(ctx context.Context, input FindStaleOrdersInput) ([]StaleOrder, error) {
+	orders, err := a.orderRepo.FindStale(ctx, time.Now().Add(-input.Grace), input.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("find stale orders: %w", err)
+	}
+
+	stale := make([]StaleOrder, 0, len(orders))
+	for _, order := range orders {
+		stale = append(stale, StaleOrder{
+			OrderID:  order.ID,
+			FlightID: order.FlightID,
+			Seats:    order.Seats,
+			Seatless: order.Seatless,
+		})
+	}
+	return stale, nil
+}
+
+// ExpireStaleOrderOutput reports what happened to a stale order
+type ExpireStaleOrderOutput struct {
+	Expired       bool // false when its workflow still runs or it reached a final status meanwhile
+	SeatsReleased int
+}
+
+// ExpireStaleOrder expires a stale order whose booking workflow is no longer
+// running, releases the seat locks and seats it still holds, and queues an
+// EXPIRED webhook event. An order whose workflow runs is left to it. Every
+// step is guarded by the order's ID, so a retry is safe.
+func (a *BookingActivities) ExpireStaleOrder(ctx context.Context, input StaleOrder) (ExpireStaleOrderOutput, error) {
+	var output ExpireStaleOrderOutput
+	logger := activity.GetLogger(ctx)
+
+	running, err := a.bookingWorkflowRunning(ctx, input.OrderID)
+	if err != nil {
+		return output, fmt.Errorf("describe booking workflow of order %s: %w", input.OrderID, err)
+	}
+	if running {
+		logger.Info("Stale order still has a running workflow", "orderID", input.OrderID)
+		return output, nil
+	}
+
+	expired, err := a.orderRepo.ExpireStale(ctx, input.OrderID)
+	if err != nil {
+		return output, err
+	}
+	if !expired {
+		// A retry after the status write still releases the seats below
+		order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+		if err != nil {
+			return output, fmt.Errorf("load stale order: %w", err)
+		}
+		if order.Status != domain.OrderStatusExpired {
+			return output, nil
+		}
+	}
+	output.Expired = true
+
+	if input.Seatless {
+		if err := a.orderRepo.ReleaseCabinSeats(ctx, input.OrderID); err != nil {
+			return output, fmt.Errorf("release cabin seats for order %s: %w", input.OrderID, err)
+		}
+	} else {
+		if err := a.seatLockRepo.ReleaseLocks(ctx, input.FlightID, input.Seats, input.OrderID, 0); err != nil {
+			return output, fmt.Errorf("release seat locks for order %s: %w", input.OrderID, err)
+		}
+		orderID := input.OrderID
+		released, err := a.flightRepo.ReleaseStaleSeats(ctx, input.FlightID, input.Seats, &orderID)
+		if err != nil {
+			return output, err
+		}
+		output.SeatsReleased = len(released)
+	}
+
+	if expired {
+		if err := a.PublishOrderEvent(ctx, PublishOrderEventInput{OrderID: input.OrderID, Event: domain.EventExpired}); err != nil {
+			return output, err
+		}
+		logger.Info("Expired stale order", "orderID", input.OrderID, "flightID", input.FlightID, "seatsReleased", output.SeatsReleased)
+	}
+
+	return output, nil
+}
+
+// bookingWorkflowRunning reports whether the order's booking workflow is
+// still running; a workflow Temporal no longer knows is not
+func (a *BookingActivities) bookingWorkflowRunning(ctx context.Context, orderID string) (bool, error) {
+	resp, err := a.temporal.DescribeWorkflowExecution(ctx, "booking-"+orderID, "")
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return resp.GetWorkflowExecutionInfo().GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, nil
+}
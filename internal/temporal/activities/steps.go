@@ -0,0 +1,63 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// Per-step budgets for activities that make several sequential repository calls
+const (
+	// stepTimeout bounds one Redis or Postgres call inside an activity
+	stepTimeout = 5 * time.Second
+
+	// minStepBudget is the least activity time that must remain to start a step
+	minStepBudget = 2 * time.Second
+)
+
+// errActivityBudget is returned instead of starting work that could not finish
+// before the activity deadline. It is retryable: the next attempt gets a fresh budget.
+var errActivityBudget = errors.New("not enough time left in activity")
+
+// ensureBudget fails fast when the activity cannot fit steps more steps,
+// so multi-step activities don't start something they can't finish
+func ensureBudget(ctx context.Context, steps int) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	if remaining := time.Until(deadline); remaining < time.Duration(steps)*minStepBudget {
+		return fmt.Errorf("%d steps need %s, %s left: %w", steps, time.Duration(steps)*minStepBudget, remaining.Round(time.Millisecond), errActivityBudget)
+	}
+	return nil
+}
+
+// runStep runs one repository call under its own deadline, capped by the
+// activity's deadline, after checking enough time remains to start it
+func runStep(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if err := ensureBudget(ctx, 1); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, stepTimeout)
+	defer cancel()
+
+	if err := fn(stepCtx); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// compensate runs best-effort cleanup on a context detached from the activity
+// deadline, so the timeout that failed a forward step cannot also abort its undo
+func compensate(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stepTimeout)
+	defer cancel()
+
+	if err := fn(cleanupCtx); err != nil {
+		activity.GetLogger(ctx).Warn("Compensation step failed", "step", name, "error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// FindUpsellOfferInput identifies the seats an upsell offer would upgrade
+type FindUpsellOfferInput struct {
+	FlightID string
+	Seats    []string
+	Policy   domain.UpsellPolicy
+}
+
+// FindUpsellOffer prices what an order can be offered before its payment is
+// taken: seats in the nearest higher cabin with room for the whole order,
+// and priority boarding when the policy sells it. It returns nil when there
+// is neither.
+func (a *BookingActivities) FindUpsellOffer(ctx context.Context, input FindUpsellOfferInput) (*domain.UpsellOffer, error) {
+	seatMap, err := a.flightRepo.FindSeats(ctx, input.FlightID)
+	if err != nil {
+		return nil, fmt.Errorf("load seat map: %w", err)
+	}
+
+	offer := &domain.UpsellOffer{PriorityBoardingCents: input.Policy.PriorityBoardingCents}
+	class, seats, cabins := domain.UpgradeSeats(seatMap, input.Seats)
+	if len(seats) > 0 {
+		offer.Class = class
+		offer.Seats = seats
+		offer.UpgradeCents = input.Policy.UpgradeCentsPerCabin * int64(cabins)
+	}
+
+	if len(offer.Seats) == 0 && offer.PriorityBoardingCents <= 0 {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// RecordUpsellInput is an order's seats and quote after answering an upsell
+// offer
+type RecordUpsellInput struct {
+	OrderID          string
+	Seats            []string
+	ExpiresAt        time.Time
+	Price            domain.PriceBreakdown
+	PriorityBoarding bool
+}
+
+// RecordUpsell saves the seats, quote and priority boarding an order took
+// from an upsell offer, so the payment and confirmation that follow charge
+// for them
+func (a *BookingActivities) RecordUpsell(ctx context.Context, input RecordUpsellInput) error {
+	err := a.orderRepo.ApplyUpsell(ctx, input.OrderID, input.Seats, input.ExpiresAt, input.Price, input.PriorityBoarding)
+	if err != nil {
+		return fmt.Errorf("record upsell for order %s: %w", input.OrderID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.temporal.io/sdk/activity"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// PublishOrderEventInput contains parameters for publishing a lifecycle event
+type PublishOrderEventInput struct {
+	OrderID string
+	Event   domain.WebhookEvent
+}
+
+// PublishOrderEvent snapshots the order and queues a webhook delivery for
+// every subscription to the event. The worker's dispatcher sends them.
+func (a *BookingActivities) PublishOrderEvent(ctx context.Context, input PublishOrderEventInput) error {
+	order, err := a.orderRepo.FindByID(ctx, input.OrderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		// The order was never persisted (e.g. CreateOrder failed); nothing to describe
+		activity.GetLogger(ctx).Warn("Skipping webhook for missing order", "orderID", input.OrderID, "event", input.Event)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load order for webhook: %w", err)
+	}
+
+	payload, err := json.Marshal(domain.WebhookPayload{
+		Event:      input.Event,
+		OccurredAt: order.UpdatedAt,
+		Order: domain.WebhookOrder{
+			OrderID:         order.ID,
+			FlightID:        order.FlightID,
+			Status:          order.Status,
+			Seats:           order.Seats,
+			TotalPriceCents: order.TotalPriceCents,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	if err := a.webhookRepo.Enqueue(ctx, input.Event, input.OrderID, payload); err != nil {
+		return fmt.Errorf("publish order event: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"go.temporal.io/sdk/temporal"
+
+	"github.com/flight-booking-system/internal/domain"
 )
 
 // Workflow-level errors
@@ -11,11 +13,15 @@ var (
 	// ErrReservationExpired indicates the 15-minute seat hold timer expired
 	ErrReservationExpired = errors.New("seat reservation expired")
 
-	// ErrPaymentTimeout indicates payment validation timed out
+	// ErrPaymentTimeout indicates payment did not complete within the
+	// booking's payment window
 	ErrPaymentTimeout = errors.New("payment validation timed out")
 
 	// ErrWorkflowCanceled indicates the workflow was canceled by user
 	ErrWorkflowCanceled = errors.New("booking workflow canceled")
+
+	// ErrTripLegFailed indicates a trip leg failed or expired, so the trip did
+	ErrTripLegFailed = errors.New("trip leg failed")
 )
 
 // Non-retryable error types for Temporal retry policy
@@ -24,6 +30,22 @@ const (
 	ErrTypePaymentDeclined    = "PAYMENT_DECLINED"
 	ErrTypeInvalidPaymentCode = "INVALID_PAYMENT_CODE"
 	ErrTypeOrderExpired       = "ORDER_EXPIRED"
+	ErrTypePriceMismatch      = "PRICE_MISMATCH"
+	ErrTypeSwapNotAllowed     = "SWAP_NOT_ALLOWED"
+	ErrTypeInvalidInput       = "INVALID_INPUT"
+	ErrTypeSeatlessOrder      = "SEATLESS_ORDER"
+	ErrTypeNotHoldingSeats    = "NOT_HOLDING_SEATS"
+	ErrTypeFlightFrozen       = "FLIGHT_FROZEN"
+	ErrTypeSalesClosed        = "SALES_CLOSED"
+	ErrTypeDoubleBooking      = "DOUBLE_BOOKING"
+	ErrTypePaymentTimeout     = "PAYMENT_TIMEOUT"
+	ErrTypePaymentFailed      = "PAYMENT_FAILED"
+	ErrTypeChallengeFailed    = "CHALLENGE_FAILED"
+	ErrTypeNotRefundable      = "ORDER_NOT_REFUNDABLE"
+	ErrTypeRefundFailed       = "REFUND_FAILED"
+	ErrTypeOrderConflict      = "ORDER_CONFLICT"
+	ErrTypeVersionConflict    = "VERSION_CONFLICT"
+	ErrTypeStaleLock          = "STALE_LOCK"
 )
 
 // NewSeatUnavailableError creates a non-retryable seat error
@@ -35,6 +57,17 @@ func NewSeatUnavailableError(seatID string) error {
 	)
 }
 
+// NewSeatLockedError creates a non-retryable seat error for a seat another
+// order has locked, carrying the seat ID as its details
+func NewSeatLockedError(seatID string) error {
+	return temporal.NewApplicationErrorWithCause(
+		"seat "+seatID+" is locked by another order",
+		ErrTypeSeatUnavailable,
+		nil,
+		seatID,
+	)
+}
+
 // NewPaymentDeclinedError creates a non-retryable payment error
 func NewPaymentDeclinedError(reason string) error {
 	return temporal.NewApplicationErrorWithCause(
@@ -44,6 +77,16 @@ func NewPaymentDeclinedError(reason string) error {
 	)
 }
 
+// NewChallengeFailedError creates a non-retryable error for a wrong one-time
+// code; the challenge stays open for another code
+func NewChallengeFailedError(reason string) error {
+	return temporal.NewApplicationErrorWithCause(
+		reason,
+		ErrTypeChallengeFailed,
+		nil,
+	)
+}
+
 // NewInvalidPaymentCodeError creates a non-retryable validation error
 func NewInvalidPaymentCodeError() error {
 	return temporal.NewApplicationErrorWithCause(
@@ -52,3 +95,135 @@ func NewInvalidPaymentCodeError() error {
 		nil,
 	)
 }
+
+// NewPriceMismatchError creates a non-retryable error for an order whose
+// persisted quote no longer matches the price the workflow is confirming
+func NewPriceMismatchError(orderID string) error {
+	return temporal.NewApplicationErrorWithCause(
+		"order "+orderID+" price does not match its locked quote",
+		ErrTypePriceMismatch,
+		nil,
+	)
+}
+
+// NewSwapNotAllowedError creates a non-retryable error for a seat swap whose
+// orders no longer hold the offered seats
+func NewSwapNotAllowedError(offerID string) error {
+	return temporal.NewApplicationErrorWithCause(
+		"seat swap "+offerID+" is no longer valid",
+		ErrTypeSwapNotAllowed,
+		nil,
+	)
+}
+
+// NewInvalidInputError creates a non-retryable error for a workflow started
+// with input it cannot act on
+func NewInvalidInputError(reason string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"invalid workflow input: "+reason,
+		ErrTypeInvalidInput,
+		nil,
+	)
+}
+
+// NewSeatlessOrderError creates a non-retryable error for a seat change on an
+// order whose seats are assigned at check-in
+func NewSeatlessOrderError() error {
+	return temporal.NewNonRetryableApplicationError(
+		"seatless orders get seats at check-in",
+		ErrTypeSeatlessOrder,
+		nil,
+	)
+}
+
+// NewNotHoldingSeatsError creates a non-retryable error for a seat change on
+// an order that has left its seat hold
+func NewNotHoldingSeatsError(orderID string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" is not holding seats",
+		ErrTypeNotHoldingSeats,
+		nil,
+	)
+}
+
+// NewFlightFrozenError creates a non-retryable error for taking seats on a
+// flight whose bookings an admin has frozen
+func NewFlightFrozenError(flightID string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"bookings on flight "+flightID+" are frozen",
+		ErrTypeFlightFrozen,
+		nil,
+	)
+}
+
+// NewSalesClosedError creates a non-retryable error for taking seats on a
+// flight that has stopped selling ahead of departure
+func NewSalesClosedError(flightID string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"sales on flight "+flightID+" are closed",
+		ErrTypeSalesClosed,
+		nil,
+	)
+}
+
+// NewDoubleBookingError creates a non-retryable error for booking seats the
+// database holds for another order
+func NewDoubleBookingError(orderID string, cause error) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" cannot book seats held by another order",
+		ErrTypeDoubleBooking,
+		cause,
+	)
+}
+
+// NewStaleLockError creates a non-retryable error for a confirmation whose
+// seat locks were taken over under a newer fencing token
+func NewStaleLockError(orderID string, cause error) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" no longer holds its seat locks",
+		ErrTypeStaleLock,
+		cause,
+	)
+}
+
+// NewNotRefundableError creates a non-retryable error for a refund of an
+// order that is not confirmed
+func NewNotRefundableError(orderID string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" is not confirmed and cannot be refunded",
+		ErrTypeNotRefundable,
+		nil,
+	)
+}
+
+// NewRefundFailedError creates a non-retryable error for a refund the
+// payment provider did not complete
+func NewRefundFailedError(reason string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"refund failed: "+reason,
+		ErrTypeRefundFailed,
+		nil,
+	)
+}
+
+// NewOrderConflictError creates a non-retryable error for a write to an order
+// that was finished outside the workflow writing it. Its details carry the
+// status the order was found in.
+func NewOrderConflictError(orderID string, status domain.OrderStatus) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" is already "+string(status),
+		ErrTypeOrderConflict,
+		nil,
+		status,
+	)
+}
+
+// NewVersionConflictError creates a non-retryable error for a change asked
+// against an order version that is no longer current
+func NewVersionConflictError(orderID string) error {
+	return temporal.NewNonRetryableApplicationError(
+		"order "+orderID+" changed since it was read",
+		ErrTypeVersionConflict,
+		nil,
+	)
+}
@@ -0,0 +1,36 @@
+package temporal
+
+import (
+	"fmt"
+
+	"github.com/flight-booking-system/internal/domain"
+)
+
+// Validate checks the input a booking workflow starts from, so malformed
+// input fails the workflow at once instead of partway through the booking
+func (in BookingWorkflowInput) Validate() error {
+	switch {
+	case in.OrderID == "":
+		return NewInvalidInputError("order ID is required")
+	case in.FlightID == "":
+		return NewInvalidInputError("flight ID is required")
+	case len(in.Seats) > 0 && in.CabinSeats > 0:
+		return NewInvalidInputError("seats and cabin seats are exclusive")
+	case len(in.Seats) == 0 && in.CabinSeats <= 0:
+		return NewInvalidInputError("no seats to hold")
+	case len(in.Seats)+in.CabinSeats > domain.MaxGroupSeats:
+		return NewInvalidInputError(fmt.Sprintf("at most %d seats per order", domain.MaxGroupSeats))
+	}
+
+	seen := make(map[string]bool, len(in.Seats))
+	for _, seat := range in.Seats {
+		switch {
+		case !domain.IsValidSeatID(seat):
+			return NewInvalidInputError(fmt.Sprintf("invalid seat %q", seat))
+		case seen[seat]:
+			return NewInvalidInputError("duplicate seat " + seat)
+		}
+		seen[seat] = true
+	}
+	return nil
+}
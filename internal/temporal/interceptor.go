@@ -0,0 +1,283 @@
+package temporal
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Invocation kinds metrics are recorded under
+const (
+	InvocationWorkflow = "workflow"
+	InvocationActivity = "activity"
+	InvocationClient   = "client"
+)
+
+// InvocationCounts are the counts and latency of one workflow, activity or
+// client call
+type InvocationCounts struct {
+	Calls        int64
+	Failures     int64
+	Retries      int64 // calls that were a retry of an earlier attempt
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// InvocationStats are the counts of one invocation, such as the
+// ValidatePayment activity
+type InvocationStats struct {
+	Kind string
+	Name string
+	InvocationCounts
+}
+
+type invocationKey struct {
+	kind string
+	name string
+}
+
+// InvocationMetrics counts Temporal invocations since the process started
+type InvocationMetrics struct {
+	mu     sync.Mutex
+	counts map[invocationKey]*InvocationCounts
+}
+
+// NewInvocationMetrics creates an empty InvocationMetrics
+func NewInvocationMetrics() *InvocationMetrics {
+	return &InvocationMetrics{counts: make(map[invocationKey]*InvocationCounts)}
+}
+
+// record counts one finished invocation
+func (m *InvocationMetrics) record(kind, name string, latency time.Duration, retry bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := invocationKey{kind: kind, name: name}
+	c, ok := m.counts[key]
+	if !ok {
+		c = &InvocationCounts{}
+		m.counts[key] = c
+	}
+	c.Calls++
+	if err != nil {
+		c.Failures++
+	}
+	if retry {
+		c.Retries++
+	}
+	c.TotalLatency += latency
+	c.MaxLatency = max(c.MaxLatency, latency)
+}
+
+// Snapshot returns the counts of every invocation seen, sorted by kind and name
+func (m *InvocationMetrics) Snapshot() []InvocationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]InvocationStats, 0, len(m.counts))
+	for key, c := range m.counts {
+		out = append(out, InvocationStats{Kind: key.kind, Name: key.name, InvocationCounts: *c})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
+
+// invocationTags returns the orderID and flightID of the first argument
+// carrying them, such as a BookingWorkflowInput, as key-value log tags
+func invocationTags(args []interface{}) []interface{} {
+	for _, arg := range args {
+		v := reflect.Indirect(reflect.ValueOf(arg))
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		var tags []interface{}
+		for _, field := range []struct{ name, tag string }{{"OrderID", "orderID"}, {"FlightID", "flightID"}} {
+			if f := v.FieldByName(field.name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+				tags = append(tags, field.tag, f.String())
+			}
+		}
+		if len(tags) > 0 {
+			return tags
+		}
+	}
+	return nil
+}
+
+// emitMetrics reports one finished workflow or activity on the worker's
+// Temporal metrics handler as <kind>_invocations, _invocation_failures,
+// _invocation_retries and _invocation_latency, tagged by name and, when the
+// input has one, flight. Order IDs stay in the logs; as a tag they would
+// grow a series per order.
+func emitMetrics(handler client.MetricsHandler, kind, name string, args []interface{}, latency time.Duration, retry bool, err error) {
+	tags := map[string]string{kind: name}
+	for i := 0; i+1 < len(args); i += 2 {
+		if args[i] == "flightID" {
+			tags["flight_id"] = args[i+1].(string)
+		}
+	}
+	handler = handler.WithTags(tags)
+
+	handler.Counter(kind + "_invocations").Inc(1)
+	handler.Timer(kind + "_invocation_latency").Record(latency)
+	if err != nil {
+		handler.Counter(kind + "_invocation_failures").Inc(1)
+	}
+	if retry {
+		handler.Counter(kind + "_invocation_retries").Inc(1)
+	}
+}
+
+// NewObservabilityInterceptor returns an interceptor that logs every
+// workflow, activity and client call with its latency and outcome, tagged
+// with the orderID and flightID of its input, and counts them in metrics and
+// on the worker's Temporal metrics handler.
+// Set on client.Options it also applies to workers created from the client.
+// Client calls are logged to logger; workflows and activities to their own
+// loggers, so workflow logs stay replay-safe.
+func NewObservabilityInterceptor(metrics *InvocationMetrics, logger log.Logger) interceptor.Interceptor {
+	return &observabilityInterceptor{metrics: metrics, logger: logger}
+}
+
+type observabilityInterceptor struct {
+	interceptor.InterceptorBase
+	metrics *InvocationMetrics
+	logger  log.Logger
+}
+
+func (i *observabilityInterceptor) InterceptClient(next interceptor.ClientOutboundInterceptor) interceptor.ClientOutboundInterceptor {
+	c := &clientObserver{root: i}
+	c.Next = next
+	return c
+}
+
+func (i *observabilityInterceptor) InterceptActivity(ctx context.Context, next interceptor.ActivityInboundInterceptor) interceptor.ActivityInboundInterceptor {
+	a := &activityObserver{root: i}
+	a.Next = next
+	return a
+}
+
+func (i *observabilityInterceptor) InterceptWorkflow(ctx workflow.Context, next interceptor.WorkflowInboundInterceptor) interceptor.WorkflowInboundInterceptor {
+	w := &workflowObserver{root: i}
+	w.Next = next
+	return w
+}
+
+type activityObserver struct {
+	interceptor.ActivityInboundInterceptorBase
+	root *observabilityInterceptor
+}
+
+func (a *activityObserver) ExecuteActivity(ctx context.Context, in *interceptor.ExecuteActivityInput) (interface{}, error) {
+	start := time.Now()
+	result, err := a.Next.ExecuteActivity(ctx, in)
+	latency := time.Since(start)
+
+	info := activity.GetInfo(ctx)
+	name := info.ActivityType.Name
+	tags := invocationTags(in.Args)
+	a.root.metrics.record(InvocationActivity, name, latency, info.Attempt > 1, err)
+	emitMetrics(activity.GetMetricsHandler(ctx), InvocationActivity, name, tags, latency, info.Attempt > 1, err)
+
+	keyvals := append([]interface{}{"latency", latency}, tags...)
+	logger := activity.GetLogger(ctx)
+	if err != nil {
+		logger.Warn("Activity failed", append(keyvals, "error", err)...)
+	} else {
+		logger.Info("Activity completed", keyvals...)
+	}
+	return result, err
+}
+
+type workflowObserver struct {
+	interceptor.WorkflowInboundInterceptorBase
+	root *observabilityInterceptor
+}
+
+func (w *workflowObserver) ExecuteWorkflow(ctx workflow.Context, in *interceptor.ExecuteWorkflowInput) (interface{}, error) {
+	result, err := w.Next.ExecuteWorkflow(ctx, in)
+
+	// A replayed completion was already counted by the worker that ran it
+	if workflow.IsReplaying(ctx) {
+		return result, err
+	}
+	info := workflow.GetInfo(ctx)
+	latency := workflow.Now(ctx).Sub(info.WorkflowStartTime)
+	// Continuing as new ends the run without failing the workflow
+	failure := err
+	if workflow.IsContinueAsNewError(err) {
+		failure = nil
+	}
+	tags := invocationTags(in.Args)
+	w.root.metrics.record(InvocationWorkflow, info.WorkflowType.Name, latency, info.Attempt > 1, failure)
+	emitMetrics(workflow.GetMetricsHandler(ctx), InvocationWorkflow, info.WorkflowType.Name, tags, latency, info.Attempt > 1, failure)
+
+	keyvals := append([]interface{}{"latency", latency}, tags...)
+	logger := workflow.GetLogger(ctx)
+	if failure != nil {
+		logger.Warn("Workflow failed", append(keyvals, "error", err)...)
+	} else {
+		logger.Info("Workflow completed", keyvals...)
+	}
+	return result, err
+}
+
+type clientObserver struct {
+	interceptor.ClientOutboundInterceptorBase
+	root *observabilityInterceptor
+}
+
+// observe records and logs one client call started at start
+func (c *clientObserver) observe(call, name string, start time.Time, err error, keyvals ...interface{}) {
+	latency := time.Since(start)
+	c.root.metrics.record(InvocationClient, call+" "+name, latency, false, err)
+
+	keyvals = append([]interface{}{"call", call, "name", name, "latency", latency}, keyvals...)
+	if err != nil {
+		c.root.logger.Warn("Temporal call failed", append(keyvals, "error", err)...)
+	} else {
+		c.root.logger.Debug("Temporal call completed", keyvals...)
+	}
+}
+
+func (c *clientObserver) ExecuteWorkflow(ctx context.Context, in *interceptor.ClientExecuteWorkflowInput) (client.WorkflowRun, error) {
+	start := time.Now()
+	run, err := c.Next.ExecuteWorkflow(ctx, in)
+	c.observe("ExecuteWorkflow", in.WorkflowType, start, err, append([]interface{}{"workflowID", in.Options.ID}, invocationTags(in.Args)...)...)
+	return run, err
+}
+
+func (c *clientObserver) SignalWorkflow(ctx context.Context, in *interceptor.ClientSignalWorkflowInput) error {
+	start := time.Now()
+	err := c.Next.SignalWorkflow(ctx, in)
+	c.observe("SignalWorkflow", in.SignalName, start, err, "workflowID", in.WorkflowID)
+	return err
+}
+
+func (c *clientObserver) QueryWorkflow(ctx context.Context, in *interceptor.ClientQueryWorkflowInput) (converter.EncodedValue, error) {
+	start := time.Now()
+	value, err := c.Next.QueryWorkflow(ctx, in)
+	c.observe("QueryWorkflow", in.QueryType, start, err, "workflowID", in.WorkflowID)
+	return value, err
+}
+
+func (c *clientObserver) UpdateWorkflow(ctx context.Context, in *interceptor.ClientUpdateWorkflowInput) (client.WorkflowUpdateHandle, error) {
+	start := time.Now()
+	handle, err := c.Next.UpdateWorkflow(ctx, in)
+	c.observe("UpdateWorkflow", in.UpdateName, start, err, "workflowID", in.WorkflowID)
+	return handle, err
+}
@@ -0,0 +1,71 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func flakyActivity(ctx context.Context, input BookingWorkflowInput) error {
+	if input.OrderID == "order-fail" {
+		return errors.New("gateway down")
+	}
+	return nil
+}
+
+func observedWorkflow(ctx workflow.Context, input BookingWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 2},
+	})
+	return workflow.ExecuteActivity(ctx, flakyActivity, input).Get(ctx, nil)
+}
+
+func TestObservabilityInterceptor_CountsInvocations(t *testing.T) {
+	metrics := NewInvocationMetrics()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{NewObservabilityInterceptor(metrics, log.NewStructuredLogger(slog.Default()))},
+	})
+	env.RegisterWorkflow(observedWorkflow)
+	env.RegisterActivity(flakyActivity)
+
+	env.ExecuteWorkflow(observedWorkflow, BookingWorkflowInput{OrderID: "order-fail", FlightID: "flight-1"})
+	if !env.IsWorkflowCompleted() || env.GetWorkflowError() == nil {
+		t.Fatalf("workflow should fail, got %v", env.GetWorkflowError())
+	}
+
+	got := make(map[string]InvocationCounts)
+	for _, s := range metrics.Snapshot() {
+		got[s.Kind+" "+s.Name] = s.InvocationCounts
+	}
+	if c := got["activity flakyActivity"]; c.Calls != 2 || c.Failures != 2 || c.Retries != 1 {
+		t.Errorf("activity counts = %+v, want 2 calls, 2 failures, 1 retry", c)
+	}
+	if c := got["workflow observedWorkflow"]; c.Calls != 1 || c.Failures != 1 {
+		t.Errorf("workflow counts = %+v, want 1 failed call", c)
+	}
+}
+
+func TestInvocationTags(t *testing.T) {
+	tags := invocationTags([]interface{}{"ignored", &BookingWorkflowInput{OrderID: "o-1", FlightID: "f-1"}})
+	want := []interface{}{"orderID", "o-1", "flightID", "f-1"}
+	if len(tags) != len(want) {
+		t.Fatalf("tags = %v, want %v", tags, want)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("tags = %v, want %v", tags, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package temporal
+
+import (
+	"fmt"
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+// ReconciliationScheduleID is the Temporal Schedule that runs the seat
+// reconciliation workflow
+const ReconciliationScheduleID = "seat-reconciliation"
+
+// ReconciliationCronWorkflowID is the cron workflow that ran reconciliation
+// before the schedule replaced it
+const ReconciliationCronWorkflowID = "seat-reconciliation-cron"
+
+// DepartureScheduleID is the Temporal Schedule that runs the departure
+// scheduler workflow
+const DepartureScheduleID = "flight-departures"
+
+// RepricingScheduleID is the Temporal Schedule that runs the dynamic pricing
+// workflow
+const RepricingScheduleID = "flight-repricing"
+
+// OpsDigestScheduleID is the Temporal Schedule that sends operators their
+// daily digest
+const OpsDigestScheduleID = "ops-digest"
+
+// StaleOrderSweepScheduleID is the Temporal Schedule that expires orders
+// whose booking workflows are gone
+const StaleOrderSweepScheduleID = "stale-order-sweep"
+
+// OrderArchivalScheduleID is the Temporal Schedule that moves finished orders
+// past their retention into the archive
+const OrderArchivalScheduleID = "order-archival"
+
+// overlapPolicies maps configured overlap policy names to Temporal's
+var overlapPolicies = map[string]enumspb.ScheduleOverlapPolicy{
+	"skip":            enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	"buffer_one":      enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	"buffer_all":      enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL,
+	"cancel_other":    enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER,
+	"terminate_other": enumspb.SCHEDULE_OVERLAP_POLICY_TERMINATE_OTHER,
+	"allow_all":       enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL,
+}
+
+// ParseOverlapPolicy returns the schedule overlap policy named by name, such
+// as "skip" or "buffer_one"; case and dashes are ignored
+func ParseOverlapPolicy(name string) (enumspb.ScheduleOverlapPolicy, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	policy, ok := overlapPolicies[key]
+	if !ok {
+		return enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED, fmt.Errorf("unknown schedule overlap policy %q", name)
+	}
+	return policy, nil
+}
@@ -0,0 +1,34 @@
+package temporal
+
+import (
+	"testing"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+func TestParseOverlapPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    enumspb.ScheduleOverlapPolicy
+		wantErr bool
+	}{
+		{"skip", enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, false},
+		{"buffer_one", enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE, false},
+		{" Cancel-Other ", enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER, false},
+		{"allow_all", enumspb.SCHEDULE_OVERLAP_POLICY_ALLOW_ALL, false},
+		{"", enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED, true},
+		{"sometimes", enumspb.SCHEDULE_OVERLAP_POLICY_UNSPECIFIED, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOverlapPolicy(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOverlapPolicy(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOverlapPolicy(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -8,43 +8,217 @@ import (
 
 // Signal names as constants
 const (
-	SignalUpdateSeats   = "update-seats"
-	SignalProceedToPay  = "proceed-to-payment"
-	SignalCancelBooking = "cancel-booking"
+	SignalUpdateSeats    = "update-seats"
+	SignalProceedToPay   = "proceed-to-payment"
+	SignalCancelBooking  = "cancel-booking"
+	SignalExtendHold     = "extend-hold"
+	SignalLegReserved    = "leg-reserved"
+	SignalApprovePartial = "approve-partial"
+	SignalCloseSales     = "close-sales"
+	SignalRespondUpsell  = "respond-upsell"
+	SignalPaymentResult  = "payment-result"
+
+	SignalChallengeResponse = "challenge-response"
+	SignalPaymentChallenge  = "payment-challenge"
 )
 
 // Query names as constants
 const (
 	QueryBookingStatus = "booking-status"
+	QueryTripStatus    = "trip-status"
+	QueryPaymentStatus = "payment-status"
+)
+
+// Update names as constants
+const (
+	UpdateChangeSeats = "change-seats"
 )
 
-// SeatUpdateSignal is sent when user changes seat selection
+// SeatUpdateSignal is sent when user changes seat selection. It is also the
+// argument of the change-seats update, which replaced the signal for callers.
 type SeatUpdateSignal struct {
 	Seats []string `json:"seats"`
 }
 
+// SeatChangeResult is returned by the change-seats update once the workflow
+// has applied the change
+type SeatChangeResult struct {
+	Status        BookingStatusResponse `json:"status"`
+	ReleasedSeats []string              `json:"releasedSeats,omitempty"` // seats given back by a downsize
+}
+
 // PaymentSignal is sent when user submits payment
 type PaymentSignal struct {
-	PaymentCode string `json:"paymentCode"`
+	PaymentCode string               `json:"paymentCode"`
+	Method      domain.PaymentMethod `json:"method,omitempty"` // empty pays by card
+
+	// Prepaid is set by a trip workflow that already validated the payment
+	// for all of its legs
+	Prepaid bool `json:"prepaid,omitempty"`
+
+	// Deposit, when set, takes only a deposit now and the balance when due
+	Deposit *domain.DepositTerms `json:"deposit,omitempty"`
+}
+
+// Outcomes a gateway reports through the payment callback
+const (
+	PaymentResultSucceeded = "succeeded"
+	PaymentResultDeclined  = "declined"
+	PaymentResultFailed    = "failed"
+)
+
+// PaymentResultSignal is sent by the payment callback endpoint when a gateway
+// that confirms out of band settles an authorization. Reference is the ID of
+// the workflow that took the payment.
+type PaymentResultSignal struct {
+	Reference       string `json:"reference"`
+	AuthorizationID string `json:"authorizationId"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+}
+
+// ChallengeResponseSignal answers an open payment challenge with the one-time
+// code the customer was sent. A booking forwards it to the PaymentWorkflow
+// taking its payment.
+type ChallengeResponseSignal struct {
+	Code string `json:"code"`
+}
+
+// PaymentChallengeSignal is sent by a PaymentWorkflow to its booking when a
+// payment challenge opens, and again with a nil Challenge once it closes, so
+// the booking's status shows it
+type PaymentChallengeSignal struct {
+	Challenge *domain.PaymentChallenge `json:"challenge"`
+}
+
+// LegReservedSignal is sent by a trip leg to its parent once it holds seats
+type LegReservedSignal struct {
+	OrderID string `json:"orderId"`
 }
 
 // BookingStatusResponse is returned by the status query
 type BookingStatusResponse struct {
-	OrderID         string             `json:"orderId"`
-	FlightID        string             `json:"flightId"`
-	Status          domain.OrderStatus `json:"status"`
-	Seats           []string           `json:"seats"`
-	ExpiresAt       time.Time          `json:"expiresAt"`
-	TimerRemaining  int                `json:"timerRemaining"` // seconds
-	PaymentAttempts int                `json:"paymentAttempts"`
-	LastError       string             `json:"lastError,omitempty"`
+	OrderID         string                `json:"orderId"`
+	FlightID        string                `json:"flightId"`
+	Status          domain.OrderStatus    `json:"status"`
+	Seats           []string              `json:"seats"`
+	ExpiresAt       time.Time             `json:"expiresAt"`
+	TimerRemaining  int                   `json:"timerRemaining"` // seconds
+	PaymentAttempts int                   `json:"paymentAttempts"`
+	LastError       string                `json:"lastError,omitempty"`
+	Price           domain.PriceBreakdown `json:"price"`
+	Passengers      []domain.Passenger    `json:"passengers,omitempty"`
+	Seatless        bool                  `json:"seatless,omitempty"`
+	SeatCount       int                   `json:"seatCount"`
+	TripID          string                `json:"tripId,omitempty"`
+
+	// A group booking that could not hold every seat waits for the customer
+	// to approve the seats it holds before it takes payment
+	UnavailableSeats []string `json:"unavailableSeats,omitempty"`
+	AwaitingApproval bool     `json:"awaitingApproval,omitempty"`
+
+	HoldExtensionsLeft int `json:"holdExtensionsLeft"`
+
+	// UpsellOffer is open between the payment signal and taking payment,
+	// until answered with respond-upsell or it expires
+	UpsellOffer *domain.UpsellOffer `json:"upsellOffer,omitempty"`
+
+	// PaymentChallenge is open while the card issuer waits for the one-time
+	// code, answered with challenge-response
+	PaymentChallenge *domain.PaymentChallenge `json:"paymentChallenge,omitempty"`
+
+	// Deposit is set once the customer chose to pay a deposit; the balance
+	// is charged by a BalanceDueWorkflow after confirmation
+	Deposit *domain.Deposit `json:"deposit,omitempty"`
+
+	// Version counts signals the workflow has applied; PendingSignals are
+	// received but not yet applied and drop to zero once the hold phase ends
+	Version        int `json:"version"`
+	PendingSignals int `json:"pendingSignals"`
 }
 
 // BookingWorkflowInput contains the initial workflow parameters
 type BookingWorkflowInput struct {
-	OrderID  string   `json:"orderId"`
-	FlightID string   `json:"flightId"`
-	Seats    []string `json:"seats"`
+	OrderID  string                `json:"orderId"`
+	FlightID string                `json:"flightId"`
+	Seats    []string              `json:"seats"`
+	Price    domain.PriceBreakdown `json:"price"`
+
+	// CabinSeats is set instead of Seats for a seatless order, which holds
+	// capacity and gets seats at check-in
+	CabinSeats int `json:"cabinSeats,omitempty"`
+
+	Passengers        []domain.Passenger `json:"passengers,omitempty"`
+	MaxHoldExtensions int                `json:"maxHoldExtensions"`
+
+	// Payment activities run on UrgentTaskQueue when the hold has at most
+	// UrgentPaymentWindow left; an empty queue keeps them on the workflow's own
+	UrgentTaskQueue     string        `json:"urgentTaskQueue,omitempty"`
+	UrgentPaymentWindow time.Duration `json:"urgentPaymentWindow,omitempty"`
+
+	// ReminderBefore is the hold time left when the passenger is reminded
+	// to pay; zero sends no reminder
+	ReminderBefore time.Duration `json:"reminderBefore,omitempty"`
+
+	// ConfirmCompensation decides what happens to a payment taken for an
+	// order that then fails to confirm
+	ConfirmCompensation ConfirmCompensation `json:"confirmCompensation,omitempty"`
+
+	// PaymentWindow bounds payment processing once payment is signaled, when
+	// the hold timer no longer runs; the order expires and releases its seats
+	// when it elapses. Zero leaves payment unbounded.
+	PaymentWindow time.Duration `json:"paymentWindow,omitempty"`
+
+	// SimulationSeed, when set, makes this order's simulated payment outcomes
+	// reproducible instead of drawing them from the worker's shared seed
+	SimulationSeed *int64 `json:"simulationSeed,omitempty"`
+
+	// Upsell is offered once payment is signaled, before it is taken; a zero
+	// window skips it
+	Upsell domain.UpsellPolicy `json:"upsell,omitempty"`
+
+	// TripID is set on the legs of a trip; they take payment and cancellation
+	// from the parent TripWorkflow
+	TripID string `json:"tripId,omitempty"`
+
+	// Group bookings hold seats for HoldDuration instead of 15 minutes and
+	// reserve them ReserveChunkSize at a time; zero values keep the defaults
+	HoldDuration     time.Duration `json:"holdDuration,omitempty"`
+	ReserveChunkSize int           `json:"reserveChunkSize,omitempty"`
+
+	// Resume is set when a booking continues as new; the new run picks up
+	// the hold where the previous one left it instead of reserving seats
+	Resume *BookingResume `json:"resume,omitempty"`
+}
+
+// ConfirmCompensation is how a booking compensates a payment when its
+// confirmation fails
+type ConfirmCompensation string
+
+const (
+	// CompensateRefund voids the payment and ends the order PAYMENT_REFUNDED
+	CompensateRefund ConfirmCompensation = "refund"
+	// CompensateNone fails the order and keeps the payment. Any other value,
+	// including the empty one of bookings started before refunds existed,
+	// behaves the same.
+	CompensateNone ConfirmCompensation = "none"
+)
+
+// BookingResume is the state of a seat hold carried across continue-as-new
+type BookingResume struct {
+	Status           domain.OrderStatus    `json:"status"`
+	Seats            []string              `json:"seats"`
+	Price            domain.PriceBreakdown `json:"price"`
+	Passengers       []domain.Passenger    `json:"passengers,omitempty"`
+	ExpiresAt        time.Time             `json:"expiresAt"`
+	PaymentAttempts  int                   `json:"paymentAttempts"`
+	LastError        string                `json:"lastError,omitempty"`
+	UnavailableSeats []string              `json:"unavailableSeats,omitempty"`
+	AwaitingApproval bool                  `json:"awaitingApproval,omitempty"`
+	HoldExtensions   int                   `json:"holdExtensions"`
+	RemindedFor      time.Time             `json:"remindedFor,omitempty"` // expiry the last reminder warned about
+	LockToken        int64                 `json:"lockToken,omitempty"`   // fencing token the seat locks are held under
+	Version          int                   `json:"version"`               // keeps counting so callers waiting on a signal see it applied
 }
 
 // BookingWorkflowResult contains the workflow completion result
@@ -54,3 +228,160 @@ type BookingWorkflowResult struct {
 	Seats   []string           `json:"seats"`
 	Error   string             `json:"error,omitempty"`
 }
+
+// PaymentWorkflowInput is the payment a booking takes in a PaymentWorkflow
+// child
+type PaymentWorkflowInput struct {
+	OrderID     string               `json:"orderId"`
+	Method      domain.PaymentMethod `json:"method,omitempty"`
+	PaymentCode string               `json:"paymentCode"`
+
+	// AmountCents takes part of the order's price, such as a deposit; zero
+	// takes all of it
+	AmountCents int64 `json:"amountCents,omitempty"`
+
+	// Window bounds the attempts; zero leaves them unbounded
+	Window time.Duration `json:"window,omitempty"`
+
+	// TaskQueue runs the payment activities elsewhere than the workflow's
+	// own queue, for payments near their hold expiry
+	TaskQueue string `json:"taskQueue,omitempty"`
+
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// PaymentStatusResponse is returned by the payment status query
+type PaymentStatusResponse struct {
+	OrderID     string `json:"orderId"`
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"maxAttempts"`
+	LastError   string `json:"lastError,omitempty"`
+
+	// History lists every attempt in order; LastGatewayError is the
+	// provider's own message for the latest one that failed
+	History          []PaymentAttempt `json:"history"`
+	LastGatewayError string           `json:"lastGatewayError,omitempty"`
+
+	Challenge *domain.PaymentChallenge `json:"challenge,omitempty"`
+}
+
+// PaymentAttempt is one attempt of a PaymentWorkflow
+type PaymentAttempt struct {
+	Attempt   int       `json:"attempt"`
+	StartedAt time.Time `json:"startedAt"`
+	Error     string    `json:"error,omitempty"` // empty when the attempt succeeded
+}
+
+// PaymentWorkflowResult is what a payment leaves for its booking. A failed
+// payment carries it as the details of its error.
+type PaymentWorkflowResult struct {
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// TripWorkflowInput contains the legs of a connecting itinerary, in travel order
+type TripWorkflowInput struct {
+	TripID string                 `json:"tripId"`
+	Legs   []BookingWorkflowInput `json:"legs"`
+}
+
+// TripLegStatus is the status of one leg as seen by its trip
+type TripLegStatus struct {
+	OrderID  string             `json:"orderId"`
+	FlightID string             `json:"flightId"`
+	Status   domain.OrderStatus `json:"status"`
+}
+
+// TripStatusResponse is returned by the trip status query
+type TripStatusResponse struct {
+	TripID          string             `json:"tripId"`
+	Status          domain.OrderStatus `json:"status"`
+	Legs            []TripLegStatus    `json:"legs"`
+	PaymentAttempts int                `json:"paymentAttempts"`
+	LastError       string             `json:"lastError,omitempty"`
+
+	PaymentChallenge *domain.PaymentChallenge `json:"paymentChallenge,omitempty"`
+}
+
+// TripWorkflowResult contains the trip workflow completion result
+type TripWorkflowResult struct {
+	TripID string             `json:"tripId"`
+	Status domain.OrderStatus `json:"status"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// CheckInWorkflowInput contains the parameters for checking in an order
+type CheckInWorkflowInput struct {
+	OrderID string   `json:"orderId"`
+	Seats   []string `json:"seats,omitempty"` // new seats; empty keeps the current ones
+}
+
+// RefundWorkflowInput identifies the confirmed order to refund
+type RefundWorkflowInput struct {
+	OrderID string `json:"orderId"`
+	Reason  string `json:"reason"`
+	Version *int   `json:"version,omitempty"` // order version the refund was asked against; unset refunds any
+}
+
+// BalanceDueWorkflowInput is the balance a deposit order owes and when it is
+// charged
+type BalanceDueWorkflowInput struct {
+	OrderID      string               `json:"orderId"`
+	Method       domain.PaymentMethod `json:"method,omitempty"`
+	PaymentCode  string               `json:"paymentCode"`
+	BalanceCents int64                `json:"balanceCents"`
+	DueAt        time.Time            `json:"dueAt"`
+	Seed         *int64               `json:"seed,omitempty"`
+}
+
+// DisruptionWorkflowInput is the disruption policy of one generator run
+type DisruptionWorkflowInput struct {
+	Policy  domain.DisruptionPolicy `json:"policy"`
+	Horizon time.Duration           `json:"horizon"` // how far ahead flights can be disrupted
+}
+
+// FlightDepartureWorkflowInput identifies the flight to take through sales
+// close, manifest and departure
+type FlightDepartureWorkflowInput struct {
+	FlightID         string            `json:"flightId"`
+	SalesCloseBefore time.Duration     `json:"salesCloseBefore"` // time before departure when sales close
+	Bump             domain.BumpPolicy `json:"bump"`             // how orders on an oversold flight are reaccommodated
+}
+
+// DepartureSchedulerWorkflowInput is the departure scan of one schedule run
+type DepartureSchedulerWorkflowInput struct {
+	Horizon          time.Duration     `json:"horizon"` // how far ahead departure workflows are started
+	SalesCloseBefore time.Duration     `json:"salesCloseBefore"`
+	Bump             domain.BumpPolicy `json:"bump"`
+}
+
+// OverbookingBumpWorkflowInput identifies the oversold flight to free seats on
+type OverbookingBumpWorkflowInput struct {
+	FlightID string            `json:"flightId"`
+	Policy   domain.BumpPolicy `json:"policy"`
+}
+
+// SeatSwapWorkflowInput identifies the matched swap offer to execute
+type SeatSwapWorkflowInput struct {
+	OfferID string `json:"offerId"`
+}
+
+// OpsDigestWorkflowInput addresses one run of the operator digest
+type OpsDigestWorkflowInput struct {
+	Address     string                       `json:"address"`
+	Channels    []domain.NotificationChannel `json:"channels"`
+	Period      time.Duration                `json:"period"`      // how far back the digest looks
+	SpikeFactor float64                      `json:"spikeFactor"` // payment failures over the previous period flagged as a spike
+}
+
+// OrderArchivalWorkflowInput bounds one run of order archival
+type OrderArchivalWorkflowInput struct {
+	Retention time.Duration `json:"retention"` // how long a finished order stays in orders
+	BatchSize int           `json:"batchSize"` // orders archived per transaction
+}
+
+// StaleOrderSweepWorkflowInput bounds one run of the stale order sweeper
+type StaleOrderSweepWorkflowInput struct {
+	Grace time.Duration `json:"grace"` // how long past its hold an order is left to its workflow
+	Limit int           `json:"limit"` // most orders expired in one run
+}
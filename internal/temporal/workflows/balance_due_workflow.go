@@ -0,0 +1,64 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// BalanceDueWorkflow charges the balance of an order confirmed on a deposit.
+// The booking workflow starts it as an abandoned child once the order is
+// confirmed, so it outlives the booking.
+// - Sleeps until the balance is due
+// - Charges the balance with the payment method and code the deposit was paid with
+// - If the charge fails, fails the order and puts its seats back on sale;
+// the deposit is kept
+func BalanceDueWorkflow(ctx workflow.Context, input temporalpkg.BalanceDueWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("BalanceDueWorkflow started", "orderID", input.OrderID, "dueAt", input.DueAt)
+
+	if wait := input.DueAt.Sub(workflow.Now(ctx)); wait > 0 {
+		if err := workflow.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Second,
+			MaximumAttempts:    3,
+			NonRetryableErrorTypes: []string{
+				temporalpkg.ErrTypePaymentDeclined,
+				temporalpkg.ErrTypeInvalidPaymentCode,
+			},
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var charged activities.ChargeBalanceOutput
+	err := workflow.ExecuteActivity(ctx, a.ChargeBalance, activities.ChargeBalanceInput{
+		OrderID:     input.OrderID,
+		Method:      input.Method,
+		PaymentCode: input.PaymentCode,
+		AmountCents: input.BalanceCents,
+		Seed:        input.Seed,
+	}).Get(ctx, &charged)
+	if err == nil {
+		logger.Info("Balance settled", "orderID", input.OrderID, "charged", charged.Charged)
+		return nil
+	}
+
+	logger.Error("Balance not charged, failing order", "orderID", input.OrderID, "error", err)
+	return workflow.ExecuteActivity(ctx, a.FailUnpaidOrder, activities.FailUnpaidOrderInput{
+		OrderID: input.OrderID,
+		Reason:  "balance not paid: " + gatewayMessage(err),
+	}).Get(ctx, nil)
+}
@@ -0,0 +1,64 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestBalanceDueWorkflow_ChargesBalanceWhenDue(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	dueAt := env.Now().Add(10 * 24 * time.Hour)
+	env.OnActivity(a.ChargeBalance, mock.Anything, activities.ChargeBalanceInput{
+		OrderID: "order-1", PaymentCode: "00000", AmountCents: 20000,
+	}).Return(func(context.Context, activities.ChargeBalanceInput) (activities.ChargeBalanceOutput, error) {
+		// The charge waits for the balance to fall due
+		require.False(t, env.Now().Before(dueAt))
+		return activities.ChargeBalanceOutput{Charged: true}, nil
+	}).Once()
+
+	env.ExecuteWorkflow(workflows.BalanceDueWorkflow, temporalpkg.BalanceDueWorkflowInput{
+		OrderID: "order-1", PaymentCode: "00000", BalanceCents: 20000, DueAt: dueAt,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "FailUnpaidOrder", mock.Anything, mock.Anything)
+}
+
+func TestBalanceDueWorkflow_DeclinedBalanceFailsOrder(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.ChargeBalance, mock.Anything, mock.Anything).Return(
+		activities.ChargeBalanceOutput{}, temporalpkg.NewPaymentDeclinedError("insufficient funds"),
+	).Once()
+	env.OnActivity(a.FailUnpaidOrder, mock.Anything, activities.FailUnpaidOrderInput{
+		OrderID: "order-2", Reason: "balance not paid: insufficient funds",
+	}).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.BalanceDueWorkflow, temporalpkg.BalanceDueWorkflowInput{
+		OrderID: "order-2", PaymentCode: "00000", BalanceCents: 20000, DueAt: env.Now().Add(time.Hour),
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
@@ -3,8 +3,10 @@ package workflows
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 
@@ -13,23 +15,71 @@ import (
 	"github.com/flight-booking-system/internal/temporal/activities"
 )
 
+// holdDuration is how long seats are held before payment must start
+const holdDuration = 15 * time.Minute
+
 // BookingWorkflow manages the flight booking process
-// - Reserves seats with 15-minute timer
+// - Reserves seats, or cabin capacity for seatless orders, with 15-minute timer
+// - Reserves large groups in chunks and waits for approval when only some seats are held
 // - Handles seat update signals (resets timer)
+// - Handles extend-hold signals (resets timer, limited per order)
+// - Expires the hold when its flight closes sales before departure
+// - Offers a cabin upgrade and priority boarding once payment is signaled
 // - Processes payment on proceed signal
 // - Releases seats on timeout/failure/cancellation
 func BookingWorkflow(ctx workflow.Context, input temporalpkg.BookingWorkflowInput) (result temporalpkg.BookingWorkflowResult, err error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("BookingWorkflow started", "orderID", input.OrderID, "flightID", input.FlightID)
 
+	// Reject malformed input before anything is held, and keep seats in one
+	// order whatever order the client listed them in
+	inputVer := workflow.GetVersion(ctx, changeInput, workflow.DefaultVersion, inputVersion)
+	if inputVer >= 1 && input.Resume == nil {
+		input.Seats = domain.NormalizeSeats(input.Seats)
+		if err := input.Validate(); err != nil {
+			logger.Error("Invalid booking input", "orderID", input.OrderID, "error", err)
+			return result, err
+		}
+		logger.Info("Booking seats", "seats", input.Seats, "seatsHash", domain.SeatsHash(input.Seats))
+	}
+
 	// Initialize workflow state
 	state := &bookingState{
 		orderID:         input.OrderID,
 		flightID:        input.FlightID,
 		seats:           input.Seats,
+		price:           input.Price,
 		status:          domain.OrderStatusCreated,
 		paymentAttempts: 0,
+		passengers:      input.Passengers,
+		cabinSeats:      input.CabinSeats,
+		seatless:        input.CabinSeats > 0,
+		tripID:          input.TripID,
+		holdDuration:    holdDuration,
+
+		maxHoldExtensions: input.MaxHoldExtensions,
 	}
+	if input.HoldDuration > 0 {
+		state.holdDuration = input.HoldDuration
+	}
+	state.localWrites = workflow.GetVersion(ctx, changeLocalWrites, workflow.DefaultVersion, localWritesVersion) >= 1
+
+	// Signals sent before the hold phase starts stay buffered until then
+	seatUpdateChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalUpdateSeats)
+	paymentChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalProceedToPay)
+	cancelChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalCancelBooking)
+	extendChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalExtendHold)
+	approveChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalApprovePartial)
+	closeSalesChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalCloseSales)
+	upsellChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalRespondUpsell)
+
+	// Seat changes arrive as updates so callers learn whether they were
+	// applied. The handler queues them for the hold loop, which applies them
+	// in order with the signals; changes still queued when the workflow ends
+	// are rejected.
+	seatChangeChan := workflow.NewBufferedChannel(ctx, maxQueuedSeatChanges)
+	defer rejectSeatChanges(seatChangeChan, state)
+	state.signals = []workflow.ReceiveChannel{seatUpdateChan, paymentChan, cancelChan, extendChan, approveChan, closeSalesChan, seatChangeChan}
 
 	// Register query handler for status queries
 	if err := workflow.SetQueryHandler(ctx, temporalpkg.QueryBookingStatus, func() (temporalpkg.BookingStatusResponse, error) {
@@ -38,14 +88,35 @@ func BookingWorkflow(ctx workflow.Context, input temporalpkg.BookingWorkflowInpu
 		return result, err
 	}
 
-	// Activity options for seat operations (short timeout, retries)
+	if err := workflow.SetUpdateHandlerWithOptions(ctx, temporalpkg.UpdateChangeSeats,
+		func(ctx workflow.Context, change temporalpkg.SeatUpdateSignal) (temporalpkg.SeatChangeResult, error) {
+			state.updates++
+			defer func() { state.updates-- }()
+			future, reply := workflow.NewFuture(ctx)
+			if !seatChangeChan.SendAsync(&seatChange{seats: change.Seats, reply: reply}) {
+				return temporalpkg.SeatChangeResult{}, errTooManySeatChanges
+			}
+			var result temporalpkg.SeatChangeResult
+			err := future.Get(ctx, &result)
+			return result, err
+		},
+		workflow.UpdateHandlerOptions{Validator: func(ctx workflow.Context, change temporalpkg.SeatUpdateSignal) error {
+			return validateSeatChange(state, seatChangeChan)
+		}},
+	); err != nil {
+		return result, err
+	}
+
+	// Activity options for seat operations (short timeout, retries); seats
+	// another order holds stay taken, so those failures are not retried
 	seatActivityOptions := workflow.ActivityOptions{
 		StartToCloseTimeout: 30 * time.Second,
 		RetryPolicy: &temporal.RetryPolicy{
-			InitialInterval:    time.Second,
-			BackoffCoefficient: 2.0,
-			MaximumInterval:    10 * time.Second,
-			MaximumAttempts:    3,
+			InitialInterval:        time.Second,
+			BackoffCoefficient:     2.0,
+			MaximumInterval:        10 * time.Second,
+			MaximumAttempts:        3,
+			NonRetryableErrorTypes: []string{temporalpkg.ErrTypeSeatUnavailable},
 		},
 	}
 	seatCtx := workflow.WithActivityOptions(ctx, seatActivityOptions)
@@ -62,33 +133,65 @@ func BookingWorkflow(ctx workflow.Context, input temporalpkg.BookingWorkflowInpu
 	}
 	orderCtx := workflow.WithActivityOptions(ctx, orderActivityOptions)
 
-	// Activity options for payment (no automatic retries - we handle retries manually to track attempts)
-	paymentActivityOptions := workflow.ActivityOptions{
-		StartToCloseTimeout: 10 * time.Second,
-		RetryPolicy: &temporal.RetryPolicy{
-			MaximumAttempts: 1, // Disable automatic retries, we'll handle manually
-			NonRetryableErrorTypes: []string{
-				temporalpkg.ErrTypeInvalidPaymentCode,
-				temporalpkg.ErrTypePaymentDeclined,
-			},
-		},
-	}
-	paymentCtx := workflow.WithActivityOptions(ctx, paymentActivityOptions)
+	// Payment retries are manual so attempts are tracked; copy the options
+	// so the urgent queue below only applies to this order
+	paymentOptions := paymentActivityOptions
+	paymentCtx := workflow.WithActivityOptions(ctx, paymentOptions)
 
 	var a *activities.BookingActivities
 
-	// Setup compensation for seat release on any failure
+	// Notify the passenger and webhook subscribers of the outcome; deferred
+	// first so it runs after compensation
+	defer func() {
+		notifyCtx, _ := workflow.NewDisconnectedContext(ctx)
+		notifyCtx = workflow.WithActivityOptions(notifyCtx, orderActivityOptions)
+		notifyOutcome(notifyCtx, state)
+		if event, ok := terminalEvents[state.status]; ok {
+			publishEvent(notifyCtx, state.orderID, event)
+		}
+	}()
+
+	// Setup compensation for seat release on any failure; continuing as new
+	// hands the seats on to the next run instead
 	defer func() {
+		if workflow.IsContinueAsNewError(err) {
+			return
+		}
 		if err != nil || state.status == domain.OrderStatusExpired || state.status == domain.OrderStatusFailed {
 			// Use disconnected context for cleanup (survives workflow cancellation)
 			compensationCtx, _ := workflow.NewDisconnectedContext(ctx)
 			compensationCtx = workflow.WithActivityOptions(compensationCtx, seatActivityOptions)
+			compensationVer := workflow.GetVersion(compensationCtx, changeCompensation, workflow.DefaultVersion, compensationVersion)
 
-			releaseErr := workflow.ExecuteActivity(compensationCtx, a.ReleaseSeats, activities.ReleaseSeatsInput{
-				OrderID:  state.orderID,
-				FlightID: state.flightID,
-				Seats:    state.seats,
-			}).Get(compensationCtx, nil)
+			// An operator canceled the workflow rather than the customer
+			// the booking; the order ends cancelled, not failed
+			if compensationVer >= 2 && temporal.IsCanceledError(err) {
+				state.status = domain.OrderStatusCancelled
+				state.lastError = "booking workflow canceled by an operator"
+				logger.Info("Booking workflow canceled", "orderID", state.orderID, "seats", state.seats)
+
+				cancelErr := workflow.ExecuteActivity(compensationCtx, a.CancelOrder, activities.CancelOrderInput{
+					OrderID: state.orderID,
+					Reason:  state.lastError,
+				}).Get(compensationCtx, nil)
+				if cancelErr != nil {
+					logger.Error("Failed to mark order cancelled", "error", cancelErr)
+				}
+			}
+
+			var releaseErr error
+			if state.seatless {
+				releaseErr = workflow.ExecuteActivity(compensationCtx, a.ReleaseCabinSeats, activities.ReleaseCabinSeatsInput{
+					OrderID: state.orderID,
+				}).Get(compensationCtx, nil)
+			} else {
+				releaseErr = workflow.ExecuteActivity(compensationCtx, a.ReleaseSeats, activities.ReleaseSeatsInput{
+					OrderID:   state.orderID,
+					FlightID:  state.flightID,
+					Seats:     state.seats,
+					LockToken: state.lockToken,
+				}).Get(compensationCtx, nil)
+			}
 
 			if releaseErr != nil {
 				logger.Error("Failed to release seats during compensation", "error", releaseErr)
@@ -98,266 +201,605 @@ func BookingWorkflow(ctx workflow.Context, input temporalpkg.BookingWorkflowInpu
 		}
 	}()
 
-	// Phase 1: Create order in database first (needed for FK constraint)
-	state.expiresAt = workflow.Now(ctx).Add(15 * time.Minute)
-	err = workflow.ExecuteActivity(orderCtx, a.CreateOrder, activities.CreateOrderInput{
-		OrderID:    input.OrderID,
-		FlightID:   input.FlightID,
-		WorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
-		Seats:      input.Seats,
-		ExpiresAt:  state.expiresAt,
-	}).Get(orderCtx, nil)
-	if err != nil {
-		state.lastError = err.Error()
-		state.status = domain.OrderStatusFailed
+	// Phase 1: Hold seats, unless this run continues a hold from a run that
+	// continued as new
+	if input.Resume != nil {
+		state.resume(input.Resume)
+		logger.Info("Booking resumed after continue-as-new", "status", state.status, "seats", state.seats, "expiresAt", state.expiresAt)
+	} else if err = startHold(ctx, seatCtx, orderCtx, state, input); err != nil {
 		return state.toResult(), err
 	}
-	logger.Info("Order created in database", "orderID", input.OrderID)
-
-	// Reserve seats (both Redis locks and DB status)
-	state.status = domain.OrderStatusSeatsReserved
-	err = workflow.ExecuteActivity(seatCtx, a.ReserveSeats, activities.ReserveSeatInput{
-		OrderID:  input.OrderID,
-		FlightID: input.FlightID,
-		Seats:    input.Seats,
-	}).Get(seatCtx, nil)
-	if err != nil {
-		state.lastError = err.Error()
-		state.status = domain.OrderStatusFailed
-		return state.toResult(), err
-	}
-	logger.Info("Seats reserved", "seats", input.Seats)
-
-	// Phase 2: Wait for payment signal with 15-minute timeout
-	// Handle seat update signals to reset timer
-	seatUpdateChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalUpdateSeats)
-	paymentChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalProceedToPay)
-	cancelChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalCancelBooking)
 
+	// Phase 2: Wait for payment signal with 15-minute timeout, coming back
+	// here after a declined payment
 	var paymentSignal temporalpkg.PaymentSignal
-	paymentReceived := false
 	canceled := false
+	upsellOffered := false
+	for {
+		// Handle seat update signals to reset timer
+		holdVer := workflow.GetVersion(ctx, changeHoldSeats, workflow.DefaultVersion, holdSeatsVersion)
+		paymentReceived := false
 
-	for !paymentReceived && !canceled {
-		// Create timer for remaining hold duration
-		timerCtx, cancelTimer := workflow.WithCancel(ctx)
-		timerDuration := state.expiresAt.Sub(workflow.Now(ctx))
-		if timerDuration <= 0 {
-			// Already expired
-			state.status = domain.OrderStatusExpired
-			state.lastError = "seat reservation expired"
-			logger.Info("Seat hold expired")
-
-			// Mark order as expired in database
-			_ = workflow.ExecuteActivity(orderCtx, a.ExpireOrder, activities.ExpireOrderInput{
-				OrderID: state.orderID,
-			}).Get(orderCtx, nil)
+		for !paymentReceived && !canceled {
+			// Hold extensions and seat changes grow the history without
+			// bound, so a long hold moves to a fresh run between signals
+			if holdVer >= 2 && shouldContinueAsNew(ctx, state) {
+				logger.Info("Continuing booking as new", "historyLength", workflow.GetInfo(ctx).GetCurrentHistoryLength(), "version", state.version)
+				input.Resume = state.toResume()
+				return state.toResult(), workflow.NewContinueAsNewError(ctx, workflow.GetInfo(ctx).WorkflowType.Name, input)
+			}
 
-			return state.toResult(), temporalpkg.ErrReservationExpired
-		}
+			// Create timer for remaining hold duration
+			timerCtx, cancelTimer := workflow.WithCancel(ctx)
+			timerDuration := state.expiresAt.Sub(workflow.Now(ctx))
+			if timerDuration <= 0 {
+				// Already expired
+				state.status = domain.OrderStatusExpired
+				state.lastError = "seat reservation expired"
+				logger.Info("Seat hold expired")
 
-		holdTimer := workflow.NewTimer(timerCtx, timerDuration)
+				// Mark order as expired in database
+				_ = workflow.ExecuteActivity(orderCtx, a.ExpireOrder, activities.ExpireOrderInput{
+					OrderID: state.orderID,
+				}).Get(orderCtx, nil)
 
-		selector := workflow.NewSelector(ctx)
+				return state.toResult(), temporalpkg.ErrReservationExpired
+			}
 
-		// Handle seat update signal
-		selector.AddReceive(seatUpdateChan, func(c workflow.ReceiveChannel, more bool) {
-			var signal temporalpkg.SeatUpdateSignal
-			c.Receive(ctx, &signal)
-			logger.Info("Received seat update signal", "newSeats", signal.Seats)
+			holdTimer := workflow.NewTimer(timerCtx, timerDuration)
 
-			// Update seat selection
-			updateErr := workflow.ExecuteActivity(seatCtx, a.UpdateSeatSelection, activities.UpdateSeatSelectionInput{
-				OrderID:  state.orderID,
-				FlightID: state.flightID,
-				OldSeats: state.seats,
-				NewSeats: signal.Seats,
-			}).Get(seatCtx, nil)
+			selector := workflow.NewSelector(ctx)
 
-			if updateErr != nil {
-				logger.Error("Failed to update seats", "error", updateErr)
-				state.lastError = updateErr.Error()
-			} else {
-				state.seats = signal.Seats
-				// Reset timer by updating expiration
-				state.expiresAt = workflow.Now(ctx).Add(15 * time.Minute)
+			// Handle seat update signal, sent by callers before seat changes
+			// became updates
+			selector.AddReceive(seatUpdateChan, func(c workflow.ReceiveChannel, more bool) {
+				var signal temporalpkg.SeatUpdateSignal
+				c.Receive(ctx, &signal)
+				if inputVer >= 1 {
+					signal.Seats = domain.NormalizeSeats(signal.Seats)
+				}
+				logger.Info("Received seat update signal", "newSeats", signal.Seats, "seatsHash", domain.SeatsHash(signal.Seats))
+				defer state.applying()()
 
-				// Update order in database
-				_ = workflow.ExecuteActivity(orderCtx, a.UpdateOrderSeats, activities.UpdateOrderSeatsInput{
-					OrderID:   state.orderID,
-					Seats:     signal.Seats,
-					ExpiresAt: state.expiresAt,
-				}).Get(orderCtx, nil)
+				changeSeats(seatCtx, orderCtx, state, signal.Seats, input.HoldDuration, cancelTimer)
+			})
 
-				logger.Info("Timer reset", "expiresAt", state.expiresAt)
-			}
+			// Handle seat change update, replying once it has been applied
+			selector.AddReceive(seatChangeChan, func(c workflow.ReceiveChannel, more bool) {
+				var change *seatChange
+				c.Receive(ctx, &change)
+				seats := domain.NormalizeSeats(change.seats)
+				logger.Info("Received seat change", "newSeats", seats, "seatsHash", domain.SeatsHash(seats))
+				applied := state.applying()
 
-			cancelTimer() // Cancel current timer to restart with new duration
-		})
+				released, changeErr := changeSeats(seatCtx, orderCtx, state, seats, input.HoldDuration, cancelTimer)
+				applied()
+				change.reply.Set(temporalpkg.SeatChangeResult{
+					Status:        state.toStatusResponse(),
+					ReleasedSeats: released,
+				}, changeErr)
+			})
 
-		// Handle payment signal
-		selector.AddReceive(paymentChan, func(c workflow.ReceiveChannel, more bool) {
-			c.Receive(ctx, &paymentSignal)
-			logger.Info("Received payment signal", "code", paymentSignal.PaymentCode[:2]+"***")
-			paymentReceived = true
-			cancelTimer()
-		})
+			// Handle extend hold signal
+			selector.AddReceive(extendChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				defer state.applying()()
+				if extendHold(seatCtx, state) {
+					cancelTimer() // Restart the timer with the new expiration
+				}
+			})
 
-		// Handle cancel signal
-		selector.AddReceive(cancelChan, func(c workflow.ReceiveChannel, more bool) {
-			c.Receive(ctx, nil)
-			logger.Info("Received cancel signal")
-			canceled = true
-			cancelTimer()
-		})
+			// Handle approval of a partially reserved group booking
+			selector.AddReceive(approveChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				defer state.applying()()
+				if !state.awaitingApproval {
+					state.lastError = domain.ErrNoPartialBooking.Error()
+					return
+				}
+				logger.Info("Partial group booking approved", "seats", state.seats, "unavailable", state.unavailableSeats)
+				state.awaitingApproval = false
+				state.lastError = ""
+			})
 
-		// Handle timer expiration
-		selector.AddFuture(holdTimer, func(f workflow.Future) {
-			timerErr := f.Get(timerCtx, nil)
-			if timerErr == nil {
-				// Timer actually expired (not canceled)
-				state.status = domain.OrderStatusExpired
-				state.lastError = "seat reservation expired"
-				logger.Info("Seat hold timer expired")
-			}
-		})
+			// Handle payment signal
+			selector.AddReceive(paymentChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, &paymentSignal)
+				if state.tripID != "" && !paymentSignal.Prepaid {
+					logger.Info("Ignoring direct payment for trip leg", "tripID", state.tripID)
+					state.lastError = domain.ErrTripLeg.Error()
+					state.version++
+					return
+				}
+				if state.awaitingApproval {
+					logger.Info("Ignoring payment until the partial group booking is approved")
+					state.lastError = domain.ErrPartialNotApproved.Error()
+					state.version++
+					return
+				}
+				logger.Info("Received payment signal", "code", paymentSignal.PaymentCode[:2]+"***")
+				state.version++ // applied below by leaving the hold phase
+				paymentReceived = true
+				cancelTimer()
+			})
 
-		selector.Select(ctx)
+			// Handle cancel signal
+			selector.AddReceive(cancelChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				logger.Info("Received cancel signal")
+				state.version++ // applied below by leaving the hold phase
+				canceled = true
+				cancelTimer()
+			})
 
-		// Check if expired
-		if state.status == domain.OrderStatusExpired {
-			// Mark order as expired in database
-			_ = workflow.ExecuteActivity(orderCtx, a.ExpireOrder, activities.ExpireOrderInput{
-				OrderID: state.orderID,
-			}).Get(orderCtx, nil)
+			// Handle the flight closing sales: the hold expires at once
+			selector.AddReceive(closeSalesChan, func(c workflow.ReceiveChannel, more bool) {
+				c.Receive(ctx, nil)
+				logger.Info("Flight closed sales; expiring seat hold")
+				state.version++ // applied below by expiring the hold
+				state.status = domain.OrderStatusExpired
+				state.lastError = "sales closed before departure"
+				cancelTimer()
+			})
 
-			return state.toResult(), temporalpkg.ErrReservationExpired
-		}
-	}
+			// Remind the passenger to pay once per expiry, ReminderBefore
+			// ahead of it
+			if delay, ok := reminderDelay(input, state, workflow.Now(ctx)); ok && holdVer >= 3 {
+				selector.AddFuture(workflow.NewTimer(timerCtx, delay), func(f workflow.Future) {
+					if f.Get(timerCtx, nil) != nil {
+						return // canceled with the hold timer
+					}
+					sendReminder(orderCtx, state)
+					cancelTimer() // Restart the hold timer alongside the next reminder
+				})
+			}
 
-	// Handle cancellation
-	if canceled {
-		state.status = domain.OrderStatusFailed
-		state.lastError = "booking canceled by user"
+			// Handle timer expiration
+			selector.AddFuture(holdTimer, func(f workflow.Future) {
+				timerErr := f.Get(timerCtx, nil)
+				if timerErr == nil {
+					// Timer actually expired (not canceled)
+					state.status = domain.OrderStatusExpired
+					state.lastError = "seat reservation expired"
+					logger.Info("Seat hold timer expired")
+				}
+			})
 
-		_ = workflow.ExecuteActivity(orderCtx, a.FailOrder, activities.FailOrderInput{
-			OrderID: state.orderID,
-			Reason:  state.lastError,
-		}).Get(orderCtx, nil)
+			selector.Select(ctx)
 
-		return state.toResult(), temporalpkg.ErrWorkflowCanceled
-	}
+			// The workflow itself was canceled; compensation records it
+			if ctx.Err() != nil {
+				cancelTimer()
+				return state.toResult(), ctx.Err()
+			}
 
-	// Phase 3: Process payment with manual retry loop (3 attempts max)
-	state.status = domain.OrderStatusPaymentProcessing
-	_ = workflow.ExecuteActivity(orderCtx, a.UpdateOrderStatus, activities.UpdateOrderStatusInput{
-		OrderID: state.orderID,
-		Status:  domain.OrderStatusPaymentProcessing,
-	}).Get(orderCtx, nil)
+			// Check if expired
+			if state.status == domain.OrderStatusExpired {
+				// Mark order as expired in database
+				_ = workflow.ExecuteActivity(orderCtx, a.ExpireOrder, activities.ExpireOrderInput{
+					OrderID: state.orderID,
+				}).Get(orderCtx, nil)
 
-	const maxPaymentAttempts = 3
-	var paymentResult activities.ValidatePaymentOutput
-	var lastPaymentErr error
+				return state.toResult(), temporalpkg.ErrReservationExpired
+			}
+		}
 
-	for attempt := 1; attempt <= maxPaymentAttempts; attempt++ {
-		state.paymentAttempts = attempt
-		logger.Info("Payment validation attempt", "attempt", attempt, "maxAttempts", maxPaymentAttempts)
+		// Handle cancellation
+		if canceled {
+			state.status = domain.OrderStatusFailed
+			state.lastError = "booking canceled by user"
 
-		err = workflow.ExecuteActivity(paymentCtx, a.ValidatePayment, activities.ValidatePaymentInput{
-			OrderID:     state.orderID,
-			PaymentCode: paymentSignal.PaymentCode,
-		}).Get(paymentCtx, &paymentResult)
+			_ = workflow.ExecuteActivity(orderCtx, a.FailOrder, activities.FailOrderInput{
+				OrderID: state.orderID,
+				Reason:  state.lastError,
+			}).Get(orderCtx, nil)
 
-		if err == nil {
-			// Payment succeeded
-			logger.Info("Payment validation succeeded", "attempt", attempt)
-			break
+			return state.toResult(), temporalpkg.ErrWorkflowCanceled
 		}
 
-		lastPaymentErr = err
-		logger.Warn("Payment validation failed", "attempt", attempt, "error", err)
-
-		// Check if it's a non-retryable error type
-		var appErr *temporal.ApplicationError
-		if errors.As(err, &appErr) {
-			errType := appErr.Type()
-			// Only break if it's one of our defined non-retryable types
-			if errType == temporalpkg.ErrTypeInvalidPaymentCode || errType == temporalpkg.ErrTypePaymentDeclined {
-				logger.Error("Payment validation failed with non-retryable error", "type", errType)
-				state.lastError = "payment failed: " + appErr.Message()
-				break
-			}
+		// Phase 3: Process payment with manual retry loop (3 attempts max) in
+		// a PaymentWorkflow child. Payments on orders near expiry skip the
+		// backlog of fresh ones.
+		payVersion := workflow.GetVersion(ctx, changePayment, workflow.DefaultVersion, paymentVersion)
+		if queue := paymentTaskQueue(input, state.expiresAt.Sub(workflow.Now(ctx))); queue != "" {
+			logger.Info("Routing payment to urgent task queue", "taskQueue", queue)
+			paymentOptions.TaskQueue = queue
+			paymentCtx = workflow.WithActivityOptions(ctx, paymentOptions)
+		}
+		if state.status == domain.OrderStatusPaymentPending {
+			state.lastError = "" // the declined payment is being retried
+		}
+		state.status = domain.OrderStatusPaymentProcessing
+		writeErr := writeOrder(orderCtx, state, a.UpdateOrderStatus, activities.UpdateOrderStatusInput{
+			OrderID: state.orderID,
+			Status:  domain.OrderStatusPaymentProcessing,
+		})
+		if status, ok := orderConflict(writeErr); ok {
+			return state.toResult(), finishedElsewhere(ctx, state, status, writeErr)
 		}
 
-		// Retryable error - wait before next attempt (exponential backoff)
-		if attempt < maxPaymentAttempts {
-			backoffDuration := time.Second * time.Duration(attempt) // 1s, 2s
-			state.lastError = fmt.Sprintf("payment failed (attempt %d of %d): %s", attempt, maxPaymentAttempts, err.Error())
-			logger.Info("Waiting before retry", "backoff", backoffDuration)
-			_ = workflow.Sleep(ctx, backoffDuration)
+		if paymentSignal.Prepaid {
+			logger.Info("Payment already validated by the trip", "tripID", input.TripID)
+			break
+		}
+		if payVersion >= 3 && !upsellOffered && input.Upsell.Window > 0 {
+			upsellOffered = true
+			offerUpsell(ctx, seatCtx, orderCtx, state, input, upsellChan)
+		}
+		// Payment runs under its own window once the hold timer has stopped
+		var paymentWindow time.Duration
+		if payVersion >= 4 {
+			paymentWindow = input.PaymentWindow
+		}
+		if payVersion >= 5 {
+			// A deposit is split from the price as it stands after the upsell
+			state.deposit = nil
+			if paymentSignal.Deposit != nil {
+				deposit := paymentSignal.Deposit.Split(state.price.TotalCents)
+				state.deposit = &deposit
+			}
+			err = processPayment(ctx, state, temporalpkg.PaymentWorkflowInput{
+				OrderID:     state.orderID,
+				Method:      paymentSignal.Method,
+				PaymentCode: paymentSignal.PaymentCode,
+				AmountCents: state.paidCents(),
+				Window:      paymentWindow,
+				TaskQueue:   paymentOptions.TaskQueue,
+				Seed:        input.SimulationSeed,
+			})
 		} else {
-			// Final attempt - set error message
-			state.lastError = fmt.Sprintf("payment failed after %d attempts: %s", maxPaymentAttempts, err.Error())
+			err = validatePaymentWithin(ctx, paymentCtx, paymentWindow, state.orderID, paymentSignal.Method, paymentSignal.PaymentCode, 0, input.SimulationSeed, &state.paymentAttempts, &state.lastError, nil, state.setPaymentChallenge)
 		}
-	}
+		if err != nil {
+			if ctx.Err() != nil {
+				return state.toResult(), ctx.Err()
+			}
+			if errors.Is(err, temporalpkg.ErrPaymentTimeout) {
+				state.status = domain.OrderStatusExpired
+				_ = workflow.ExecuteActivity(orderCtx, a.ExpireOrder, activities.ExpireOrderInput{
+					OrderID: state.orderID,
+				}).Get(orderCtx, nil)
 
-	// Check final result
-	if lastPaymentErr != nil {
-		state.status = domain.OrderStatusFailed
-		if state.lastError == "" {
-			state.lastError = fmt.Sprintf("payment failed after %d attempts: %s", state.paymentAttempts, lastPaymentErr.Error())
-		}
-		logger.Error("Payment validation failed after all attempts", "attempts", state.paymentAttempts, "error", lastPaymentErr)
+				return state.toResult(), err
+			}
 
-		_ = workflow.ExecuteActivity(orderCtx, a.FailOrder, activities.FailOrderInput{
-			OrderID: state.orderID,
-			Reason:  state.lastError,
-		}).Get(orderCtx, nil)
+			// A declined payment keeps the seats held so the customer can pay
+			// again until the hold expires
+			if payVersion >= 2 && paymentDeclined(err) {
+				logger.Info("Payment declined, awaiting another payment", "expiresAt", state.expiresAt)
+				err = nil
+				state.status = domain.OrderStatusPaymentPending
+				writeErr := writeOrder(orderCtx, state, a.UpdateOrderStatus, activities.UpdateOrderStatusInput{
+					OrderID: state.orderID,
+					Status:  domain.OrderStatusPaymentPending,
+				})
+				if status, ok := orderConflict(writeErr); ok {
+					return state.toResult(), finishedElsewhere(ctx, state, status, writeErr)
+				}
+				continue
+			}
+
+			state.status = domain.OrderStatusFailed
+			logger.Error("Payment validation failed after all attempts", "attempts", state.paymentAttempts, "error", err)
 
-		return state.toResult(), lastPaymentErr
+			_ = workflow.ExecuteActivity(orderCtx, a.FailOrder, activities.FailOrderInput{
+				OrderID: state.orderID,
+				Reason:  state.lastError,
+			}).Get(orderCtx, nil)
+
+			return state.toResult(), err
+		}
+		break
 	}
 
-	// Phase 4: Confirm booking
+	// Phase 4: Confirm booking. Payment has been taken, so canceling the
+	// workflow no longer stops the booking: it confirms on a disconnected
+	// context.
+	confirmCtx, _ := workflow.NewDisconnectedContext(ctx)
+	confirmCtx = workflow.WithActivityOptions(confirmCtx, orderActivityOptions)
+	confirmVer := workflow.GetVersion(ctx, changeConfirm, workflow.DefaultVersion, confirmVersion)
 	state.status = domain.OrderStatusConfirmed
-	err = workflow.ExecuteActivity(orderCtx, a.ConfirmOrder, activities.ConfirmOrderInput{
-		OrderID:  state.orderID,
-		FlightID: state.flightID,
-		Seats:    state.seats,
-	}).Get(orderCtx, nil)
+	err = recordDeposit(confirmCtx, state)
+	if err == nil && confirmVer == 3 {
+		// Bookings that started on the saga finish on it
+		err = confirmBooking(confirmCtx, state)
+	} else if err == nil {
+		err = workflow.ExecuteActivity(confirmCtx, a.ConfirmOrder, activities.ConfirmOrderInput{
+			OrderID:    state.orderID,
+			FlightID:   state.flightID,
+			Seats:      state.seats,
+			CabinSeats: state.cabinSeats,
+			Price:      state.price,
+			Passengers: state.passengers,
+			LockToken:  state.lockToken,
+		}).Get(confirmCtx, nil)
+	}
 
 	if err != nil {
 		state.status = domain.OrderStatusFailed
 		state.lastError = "confirmation failed: " + err.Error()
 		logger.Error("Order confirmation failed", "error", err)
 
-		_ = workflow.ExecuteActivity(orderCtx, a.FailOrder, activities.FailOrderInput{
+		// The customer paid for a booking they will not get. A trip leg
+		// refunds its own share of the trip's payment, which the trip
+		// recorded on the leg.
+		refundable := !paymentSignal.Prepaid || confirmVer >= 5
+		if confirmVer >= 2 && refundable && input.ConfirmCompensation == temporalpkg.CompensateRefund &&
+			refundPayment(confirmCtx, state, paymentSignal.Method, paymentSignal.PaymentCode) {
+			return state.toResult(), err
+		}
+
+		_ = workflow.ExecuteActivity(confirmCtx, a.FailOrder, activities.FailOrderInput{
 			OrderID: state.orderID,
 			Reason:  state.lastError,
-		}).Get(orderCtx, nil)
+		}).Get(confirmCtx, nil)
 
 		return state.toResult(), err
 	}
 
 	logger.Info("Booking confirmed", "orderID", state.orderID, "seats", state.seats)
 
+	if state.deposit != nil && state.deposit.BalanceCents > 0 {
+		startBalanceDue(confirmCtx, state, paymentSignal, input.SimulationSeed)
+	}
+
+	// The boarding pass is a convenience; failing to render it never undoes the
+	// booking. Seatless orders get theirs at check-in, once they have seats.
+	if !state.seatless {
+		if passErr := workflow.ExecuteActivity(confirmCtx, a.GenerateBoardingPass, activities.GenerateBoardingPassInput{
+			OrderID: state.orderID,
+		}).Get(confirmCtx, nil); passErr != nil {
+			logger.Error("Failed to generate boarding pass", "orderID", state.orderID, "error", passErr)
+		}
+	}
+
 	// Clear the error since compensation is not needed for successful bookings
 	err = nil
 
 	// Drain any remaining signals before completing
-	drainSignals(ctx, seatUpdateChan, paymentChan, cancelChan)
+	drainSignals(ctx, seatUpdateChan, paymentChan, cancelChan, extendChan, approveChan, closeSalesChan, upsellChan)
 
 	return state.toResult(), nil
 }
 
+// recordDeposit records the deposit an order paid before it is confirmed, so
+// a failed confirmation refunds only the deposit. Orders paying their full
+// price record nothing.
+func recordDeposit(ctx workflow.Context, state *bookingState) error {
+	if state.deposit == nil {
+		return nil
+	}
+	var a *activities.BookingActivities
+	return workflow.ExecuteActivity(ctx, a.RecordDeposit, activities.RecordDepositInput{
+		OrderID: state.orderID,
+		Deposit: *state.deposit,
+	}).Get(ctx, nil)
+}
+
+// startBalanceDue starts the workflow that charges a deposit order's balance
+// when it is due. It outlives the booking; failing to start it is logged and
+// left to the stale order sweep, since the order is already confirmed.
+func startBalanceDue(ctx workflow.Context, state *bookingState, payment temporalpkg.PaymentSignal, seed *int64) {
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:        "balance-" + state.orderID,
+		ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+	})
+	child := workflow.ExecuteChildWorkflow(childCtx, BalanceDueWorkflow, temporalpkg.BalanceDueWorkflowInput{
+		OrderID:      state.orderID,
+		Method:       payment.Method,
+		PaymentCode:  payment.PaymentCode,
+		BalanceCents: state.deposit.BalanceCents,
+		DueAt:        state.deposit.BalanceDueAt,
+		Seed:         seed,
+	})
+	if err := child.GetChildWorkflowExecution().Get(ctx, nil); err != nil && !temporal.IsWorkflowExecutionAlreadyStartedError(err) {
+		workflow.GetLogger(ctx).Error("Failed to start balance due workflow", "orderID", state.orderID, "error", err)
+	}
+}
+
+// startHold creates the order and reserves its seats, or cabin capacity when
+// the order is seatless. A trip leg then tells its parent it holds seats.
+func startHold(ctx, seatCtx, orderCtx workflow.Context, state *bookingState, input temporalpkg.BookingWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+
+	// Phase 1: Create order in database first (needed for FK constraint)
+	state.expiresAt = workflow.Now(ctx).Add(state.holdDuration)
+	err := workflow.ExecuteActivity(orderCtx, a.CreateOrder, activities.CreateOrderInput{
+		OrderID:    input.OrderID,
+		FlightID:   input.FlightID,
+		WorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
+		Seats:      input.Seats,
+		ExpiresAt:  state.expiresAt,
+		Price:      state.price,
+		Seatless:   state.seatless,
+		TripID:     input.TripID,
+	}).Get(orderCtx, nil)
+	if err != nil {
+		state.lastError = err.Error()
+		state.status = domain.OrderStatusFailed
+		return err
+	}
+	logger.Info("Order created in database", "orderID", input.OrderID)
+	publishEvent(orderCtx, input.OrderID, domain.EventOrderCreated)
+
+	// Reserve seats (both Redis locks and DB status), or only capacity when
+	// the order is seatless
+	workflow.GetVersion(ctx, changeReserveSeats, workflow.DefaultVersion, reserveSeatsVersion)
+	state.status = domain.OrderStatusSeatsReserved
+	if state.seatless {
+		err = workflow.ExecuteActivity(seatCtx, a.HoldCabinSeats, activities.HoldCabinSeatsInput{
+			OrderID:  input.OrderID,
+			FlightID: input.FlightID,
+			Count:    input.CabinSeats,
+		}).Get(seatCtx, nil)
+	} else if input.ReserveChunkSize > 0 && len(input.Seats) > input.ReserveChunkSize {
+		err = reserveGroup(seatCtx, orderCtx, state, input)
+	} else {
+		var reserved activities.ReserveSeatsOutput
+		err = workflow.ExecuteActivity(seatCtx, a.ReserveSeats, activities.ReserveSeatInput{
+			OrderID:      input.OrderID,
+			FlightID:     input.FlightID,
+			Seats:        input.Seats,
+			HoldDuration: input.HoldDuration,
+		}).Get(seatCtx, &reserved)
+		state.lockToken = reserved.LockToken
+	}
+	if err != nil {
+		state.lastError = err.Error()
+		state.status = domain.OrderStatusFailed
+		return err
+	}
+	logger.Info("Seats reserved", "seats", state.seats, "cabinSeats", input.CabinSeats)
+	publishEvent(orderCtx, input.OrderID, domain.EventSeatsReserved)
+
+	// A trip leg tells its parent it holds seats, so the trip can take payment
+	if parent := workflow.GetInfo(ctx).ParentWorkflowExecution; parent != nil && state.tripID != "" {
+		if sigErr := workflow.SignalExternalWorkflow(ctx, parent.ID, "", temporalpkg.SignalLegReserved, temporalpkg.LegReservedSignal{
+			OrderID: state.orderID,
+		}).Get(ctx, nil); sigErr != nil {
+			logger.Error("Failed to signal trip", "tripID", state.tripID, "error", sigErr)
+		}
+	}
+	return nil
+}
+
 // bookingState tracks the internal workflow state
 type bookingState struct {
 	orderID         string
 	flightID        string
 	seats           []string
+	price           domain.PriceBreakdown
 	status          domain.OrderStatus
 	expiresAt       time.Time
 	paymentAttempts int
 	lastError       string
+	passengers      []domain.Passenger
+	seatless        bool
+	cabinSeats      int    // capacity held by a seatless order
+	tripID          string // set when the order is a leg of a trip
+	holdDuration    time.Duration
+	localWrites     bool  // order writes run as local activities
+	payments        int   // PaymentWorkflow children started
+	lockToken       int64 // fencing token the seat locks are held under; zero before fencing
+
+	// A group booking that holds only some seats waits for approval to pay
+	unavailableSeats []string
+	awaitingApproval bool
+
+	holdExtensions    int
+	maxHoldExtensions int
+	remindedFor       time.Time // expiry the last reminder warned about
+
+	// An upsell offer is open between the payment signal and taking payment
+	upsellOffer     *domain.UpsellOffer
+	upsellResponses workflow.ReceiveChannel
+
+	// A payment challenge is open while the issuer waits for a one-time code
+	paymentChallenge *domain.PaymentChallenge
+
+	// Set while the order pays a deposit rather than its full price
+	deposit *domain.Deposit
+
+	// Read-your-writes bookkeeping for callers that signal and then query
+	signals  []workflow.ReceiveChannel
+	version  int
+	inFlight int
+	updates  int // change-seats update handlers still running
+}
+
+// applying marks a signal as received but not yet applied until the returned
+// func runs, so queries made while its activities run report it as pending
+func (s *bookingState) applying() func() {
+	s.inFlight++
+	return func() {
+		s.inFlight--
+		s.version++
+	}
+}
+
+// setPaymentChallenge records the payment challenge open on the order, if any
+func (s *bookingState) setPaymentChallenge(challenge *domain.PaymentChallenge) {
+	s.paymentChallenge = challenge
+}
+
+// pendingSignals counts signals still to be applied; none will be once the
+// order has left the hold phase, except an answer to an open upsell offer
+func (s *bookingState) pendingSignals() int {
+	if s.upsellOffer != nil {
+		return s.inFlight + s.upsellResponses.Len()
+	}
+	if s.status != domain.OrderStatusCreated && !domain.HoldsSeats(s.status) {
+		return 0
+	}
+	pending := s.inFlight
+	for _, ch := range s.signals {
+		pending += ch.Len()
+	}
+	return pending
+}
+
+// continueAsNewHistoryLength is the history length past which a booking
+// still holding seats continues as new
+const continueAsNewHistoryLength = 2000
+
+// shouldContinueAsNew reports whether the hold should move to a fresh run:
+// its history is long or Temporal suggests it, and no signal or update is
+// waiting to be applied, since a new run would never see it. Update handlers
+// are given the chance to return their results first.
+func shouldContinueAsNew(ctx workflow.Context, state *bookingState) bool {
+	info := workflow.GetInfo(ctx)
+	if info.GetCurrentHistoryLength() < continueAsNewHistoryLength && !info.GetContinueAsNewSuggested() {
+		return false
+	}
+	if err := workflow.Await(ctx, func() bool { return state.updates == 0 }); err != nil {
+		return false
+	}
+	return state.pendingSignals() == 0
+}
+
+// paidCents is what the order's payment takes: its deposit, or its price
+func (s *bookingState) paidCents() int64 {
+	if s.deposit != nil {
+		return s.deposit.DepositCents
+	}
+	return s.price.TotalCents
+}
+
+// toResume captures the seat hold for the run that continues it
+func (s *bookingState) toResume() *temporalpkg.BookingResume {
+	return &temporalpkg.BookingResume{
+		Status:           s.status,
+		Seats:            s.seats,
+		Price:            s.price,
+		Passengers:       s.passengers,
+		ExpiresAt:        s.expiresAt,
+		PaymentAttempts:  s.paymentAttempts,
+		LastError:        s.lastError,
+		UnavailableSeats: s.unavailableSeats,
+		AwaitingApproval: s.awaitingApproval,
+		HoldExtensions:   s.holdExtensions,
+		RemindedFor:      s.remindedFor,
+		LockToken:        s.lockToken,
+		Version:          s.version,
+	}
+}
+
+// resume restores the seat hold a previous run carried over
+func (s *bookingState) resume(r *temporalpkg.BookingResume) {
+	s.status = r.Status
+	s.seats = r.Seats
+	s.price = r.Price
+	s.passengers = r.Passengers
+	s.expiresAt = r.ExpiresAt
+	s.paymentAttempts = r.PaymentAttempts
+	s.lastError = r.LastError
+	s.unavailableSeats = r.UnavailableSeats
+	s.awaitingApproval = r.AwaitingApproval
+	s.holdExtensions = r.HoldExtensions
+	s.remindedFor = r.RemindedFor
+	s.lockToken = r.LockToken
+	s.version = r.Version
 }
 
 // toStatusResponse converts state to query response
@@ -379,6 +821,23 @@ func (s *bookingState) toStatusResponse() temporalpkg.BookingStatusResponse {
 		TimerRemaining:  timerRemaining,
 		PaymentAttempts: s.paymentAttempts,
 		LastError:       s.lastError,
+		Price:           s.price,
+		Passengers:      s.passengers,
+		Seatless:        s.seatless,
+		SeatCount:       len(s.seats) + s.cabinSeats,
+		TripID:          s.tripID,
+
+		UnavailableSeats: s.unavailableSeats,
+		AwaitingApproval: s.awaitingApproval,
+
+		HoldExtensionsLeft: max(s.maxHoldExtensions-s.holdExtensions, 0),
+
+		UpsellOffer:      s.upsellOffer,
+		PaymentChallenge: s.paymentChallenge,
+		Deposit:          s.deposit,
+
+		Version:        s.version,
+		PendingSignals: s.pendingSignals(),
 	}
 }
 
@@ -392,6 +851,282 @@ func (s *bookingState) toResult() temporalpkg.BookingWorkflowResult {
 	}
 }
 
+// paymentTaskQueue returns the urgent task queue when remaining hold time is
+// within the urgent window, or "" to keep payment on the workflow's queue
+func paymentTaskQueue(input temporalpkg.BookingWorkflowInput, remaining time.Duration) string {
+	if input.UrgentTaskQueue == "" || remaining > input.UrgentPaymentWindow {
+		return ""
+	}
+	return input.UrgentTaskQueue
+}
+
+// GroupBookingWorkflow books one large party on a flight. It is a
+// BookingWorkflow with a longer hold and chunked seat reservation, started
+// under its own type so group bookings are easy to find in Temporal.
+func GroupBookingWorkflow(ctx workflow.Context, input temporalpkg.BookingWorkflowInput) (temporalpkg.BookingWorkflowResult, error) {
+	return BookingWorkflow(ctx, input)
+}
+
+// reserveGroup reserves a group booking's seats ReserveChunkSize at a time.
+// A chunk that fails is retried seat by seat, so one taken seat costs only
+// itself. When some seats could not be held the order keeps the rest, with
+// its price rescaled, and waits for the customer to approve them; it fails
+// only when no seat could be held.
+func reserveGroup(seatCtx, orderCtx workflow.Context, state *bookingState, input temporalpkg.BookingWorkflowInput) error {
+	logger := workflow.GetLogger(seatCtx)
+	var a *activities.BookingActivities
+	// Chunks after the first join the first chunk's fencing token, so the
+	// order holds every seat under one
+	reserve := func(seats []string) error {
+		var reserved activities.ReserveSeatsOutput
+		err := workflow.ExecuteActivity(seatCtx, a.ReserveSeats, activities.ReserveSeatInput{
+			OrderID:      input.OrderID,
+			FlightID:     input.FlightID,
+			Seats:        seats,
+			HoldDuration: input.HoldDuration,
+			LockToken:    state.lockToken,
+		}).Get(seatCtx, &reserved)
+		if err == nil {
+			state.lockToken = reserved.LockToken
+		}
+		return err
+	}
+
+	var reserved, unavailable []string
+	var lastErr error
+	for start := 0; start < len(input.Seats); start += input.ReserveChunkSize {
+		chunk := input.Seats[start:min(start+input.ReserveChunkSize, len(input.Seats))]
+		if lastErr = reserve(chunk); lastErr == nil {
+			reserved = append(reserved, chunk...)
+			continue
+		}
+
+		logger.Warn("Group chunk not reserved, retrying seat by seat", "chunk", chunk, "error", lastErr)
+		for _, seat := range chunk {
+			if err := reserve([]string{seat}); err != nil {
+				lastErr = err
+				unavailable = append(unavailable, seat)
+			} else {
+				reserved = append(reserved, seat)
+			}
+		}
+	}
+
+	// Compensation releases state.seats, so it only ever lists held seats
+	state.seats = reserved
+	if len(reserved) == 0 {
+		return lastErr
+	}
+	if len(unavailable) == 0 {
+		return nil
+	}
+
+	state.unavailableSeats = unavailable
+	state.awaitingApproval = true
+	state.price = state.price.ForSeats(len(reserved))
+	state.passengers = slices.DeleteFunc(domain.AssignSeats(state.passengers, reserved), func(p domain.Passenger) bool {
+		return p.SeatID == ""
+	})
+	state.lastError = fmt.Sprintf("%d of %d seats could not be reserved; approve the rest to continue", len(unavailable), len(input.Seats))
+	logger.Info("Group booking partially reserved", "reserved", len(reserved), "unavailable", unavailable)
+
+	_ = writeOrder(orderCtx, state, a.UpdateOrderSeats, activities.UpdateOrderSeatsInput{
+		OrderID:   state.orderID,
+		Seats:     reserved,
+		ExpiresAt: state.expiresAt,
+		Price:     state.price,
+	})
+
+	return nil
+}
+
+// maxQueuedSeatChanges bounds the seat changes waiting for the hold loop
+const maxQueuedSeatChanges = 16
+
+var errTooManySeatChanges = temporal.NewApplicationError("too many seat changes in progress", "TOO_MANY_SEAT_CHANGES")
+
+// seatChange is a change-seats update queued for the hold loop, which
+// settles reply with the change's result
+type seatChange struct {
+	seats []string
+	reply workflow.Settable
+}
+
+// validateSeatChange rejects a change-seats update before it is recorded in
+// the workflow's history
+func validateSeatChange(state *bookingState, queue workflow.Channel) error {
+	switch {
+	case state.seatless:
+		return temporalpkg.NewSeatlessOrderError()
+	case !domain.HoldsSeats(state.status):
+		return temporalpkg.NewNotHoldingSeatsError(state.orderID)
+	case queue.Len() >= maxQueuedSeatChanges:
+		return errTooManySeatChanges
+	}
+	return nil
+}
+
+// rejectSeatChanges fails the seat changes still queued once the workflow
+// ends, so their callers are not left waiting
+func rejectSeatChanges(queue workflow.Channel, state *bookingState) {
+	var change *seatChange
+	for queue.ReceiveAsync(&change) {
+		change.reply.Set(nil, temporalpkg.NewNotHoldingSeatsError(state.orderID))
+	}
+}
+
+// changeSeats replaces an order's seats and returns the seats it released.
+// Keeping only some of the current seats releases the rest and keeps the
+// hold's expiry; any other selection swaps the seats and calls restartTimer
+// so the hold timer picks up the new expiry. A failed change keeps the
+// current seats and becomes the order's last error.
+func changeSeats(seatCtx, orderCtx workflow.Context, state *bookingState, seats []string, holdDuration time.Duration, restartTimer func()) ([]string, error) {
+	logger := workflow.GetLogger(seatCtx)
+	if state.seatless {
+		state.lastError = domain.ErrSeatlessOrder.Error()
+		return nil, temporalpkg.NewSeatlessOrderError()
+	}
+
+	// Dropping seats releases only those and keeps the hold's expiry
+	if dropped, ok := domain.DroppedSeats(state.seats, seats); ok {
+		if err := shrinkSeats(seatCtx, orderCtx, state, seats, dropped); err != nil {
+			return nil, err
+		}
+		return dropped, nil
+	}
+
+	var a *activities.BookingActivities
+	var updated activities.UpdateSeatSelectionOutput
+	err := workflow.ExecuteActivity(seatCtx, a.UpdateSeatSelection, activities.UpdateSeatSelectionInput{
+		OrderID:   state.orderID,
+		FlightID:  state.flightID,
+		OldSeats:  state.seats,
+		NewSeats:  seats,
+		LockToken: state.lockToken,
+
+		HoldDuration: holdDuration,
+	}).Get(seatCtx, &updated)
+	defer restartTimer()
+	if err != nil {
+		logger.Error("Failed to update seats", "error", err)
+		state.lastError = err.Error()
+		return nil, err
+	}
+
+	state.lockToken = updated.LockToken
+	state.seats = seats
+	state.price = state.price.ForSeats(len(seats))
+	state.passengers = domain.AssignSeats(state.passengers, seats)
+	// Reset timer by updating expiration
+	state.expiresAt = workflow.Now(seatCtx).Add(state.holdDuration)
+
+	// Update order in database
+	_ = writeOrder(orderCtx, state, a.UpdateOrderSeats, activities.UpdateOrderSeatsInput{
+		OrderID:   state.orderID,
+		Seats:     seats,
+		ExpiresAt: state.expiresAt,
+		Price:     state.price,
+	})
+
+	logger.Info("Timer reset", "expiresAt", state.expiresAt)
+	return nil, nil
+}
+
+// shrinkSeats releases the seats an order drops and rescales its price to the
+// seats it keeps. Passengers seated in dropped seats leave the order.
+func shrinkSeats(seatCtx, orderCtx workflow.Context, state *bookingState, seats, dropped []string) error {
+	logger := workflow.GetLogger(seatCtx)
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(seatCtx, a.ReleaseSeats, activities.ReleaseSeatsInput{
+		OrderID:   state.orderID,
+		FlightID:  state.flightID,
+		Seats:     dropped,
+		LockToken: state.lockToken,
+	}).Get(seatCtx, nil)
+	if err != nil {
+		logger.Error("Failed to release dropped seats", "error", err)
+		state.lastError = err.Error()
+		return err
+	}
+
+	state.seats = seats
+	state.price = state.price.ForSeats(len(seats))
+	state.passengers = slices.DeleteFunc(domain.AssignSeats(state.passengers, seats), func(p domain.Passenger) bool {
+		return p.SeatID == ""
+	})
+
+	_ = writeOrder(orderCtx, state, a.UpdateOrderSeats, activities.UpdateOrderSeatsInput{
+		OrderID:   state.orderID,
+		Seats:     seats,
+		ExpiresAt: state.expiresAt,
+		Price:     state.price,
+	})
+
+	publishEvent(orderCtx, state.orderID, domain.EventSeatsReleased)
+	logger.Info("Dropped seats released", "dropped", dropped, "seats", seats)
+	return nil
+}
+
+// extendHold refreshes the seat hold if the order has extensions left and
+// reports whether the expiration moved
+func extendHold(ctx workflow.Context, state *bookingState) bool {
+	logger := workflow.GetLogger(ctx)
+	if state.holdExtensions >= state.maxHoldExtensions {
+		logger.Info("Ignoring extend hold signal, limit reached", "extensions", state.holdExtensions)
+		state.lastError = domain.ErrHoldExtensionLimit.Error()
+		return false
+	}
+
+	var a *activities.BookingActivities
+	expiresAt := workflow.Now(ctx).Add(state.holdDuration)
+	err := workflow.ExecuteActivity(ctx, a.ExtendHold, activities.ExtendHoldInput{
+		OrderID:   state.orderID,
+		FlightID:  state.flightID,
+		Seats:     state.seats,
+		ExpiresAt: expiresAt,
+		LockToken: state.lockToken,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to extend hold", "error", err)
+		state.lastError = err.Error()
+		return false
+	}
+
+	state.holdExtensions++
+	state.expiresAt = expiresAt
+	logger.Info("Hold extended", "expiresAt", expiresAt, "extensions", state.holdExtensions)
+	return true
+}
+
+// reminderDelay returns how long until the passenger should be reminded that
+// the hold expires, or false when no reminder is due: reminders are off, this
+// expiry was already reminded about, or less than ReminderBefore is left
+func reminderDelay(input temporalpkg.BookingWorkflowInput, state *bookingState, now time.Time) (time.Duration, bool) {
+	if input.ReminderBefore <= 0 || state.remindedFor.Equal(state.expiresAt) {
+		return 0, false
+	}
+	delay := state.expiresAt.Add(-input.ReminderBefore).Sub(now)
+	return delay, delay > 0
+}
+
+// sendReminder warns the passenger that the hold expires soon. A failed
+// reminder is logged and not retried; it never changes the booking.
+func sendReminder(ctx workflow.Context, state *bookingState) {
+	state.remindedFor = state.expiresAt
+
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(ctx, a.SendReminder, activities.SendReminderInput{
+		OrderID:   state.orderID,
+		Status:    state.status,
+		ExpiresAt: state.expiresAt,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Failed to send hold reminder", "orderID", state.orderID, "error", err)
+		return
+	}
+	workflow.GetLogger(ctx).Info("Hold reminder sent", "orderID", state.orderID, "expiresAt", state.expiresAt)
+}
+
 // drainSignals empties signal channels to prevent "unhandled signal" warnings
 func drainSignals(_ workflow.Context, channels ...workflow.ReceiveChannel) {
 	for _, ch := range channels {
@@ -404,3 +1139,65 @@ func drainSignals(_ workflow.Context, channels ...workflow.ReceiveChannel) {
 		}
 	}
 }
+
+// notifyOutcome sends the booking-category notification for a terminal
+// order status. Failures are logged; they never change the booking result.
+func notifyOutcome(ctx workflow.Context, state *bookingState) {
+	var message string
+	switch state.status {
+	case domain.OrderStatusConfirmed:
+		message = fmt.Sprintf("Booking confirmed for seats %v", state.seats)
+		if state.seatless {
+			message = fmt.Sprintf("Booking confirmed for %d seats; seats are assigned at check-in", state.cabinSeats)
+		}
+		for _, p := range state.passengers {
+			if p.SeatID != "" {
+				message += fmt.Sprintf("\n%s: %s", p.SeatID, p.Name)
+			}
+		}
+	case domain.OrderStatusExpired:
+		message = "Your seat hold expired before payment"
+	case domain.OrderStatusFailed:
+		message = "Booking failed: " + state.lastError
+	case domain.OrderStatusPaymentRefunded:
+		message = "Booking could not be completed and your payment was refunded: " + state.lastError
+	case domain.OrderStatusCancelled:
+		message = "Your booking was cancelled before payment was taken"
+	default:
+		return
+	}
+
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(ctx, a.NotifyOrder, activities.NotifyOrderInput{
+		OrderID:  state.orderID,
+		Category: domain.CategoryBooking,
+		Status:   state.status,
+		Message:  message,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Failed to send booking notification", "orderID", state.orderID, "error", err)
+	}
+}
+
+// terminalEvents maps final order statuses to their webhook events
+var terminalEvents = map[domain.OrderStatus]domain.WebhookEvent{
+	domain.OrderStatusConfirmed: domain.EventConfirmed,
+	domain.OrderStatusExpired:   domain.EventExpired,
+	domain.OrderStatusFailed:    domain.EventFailed,
+
+	domain.OrderStatusPaymentRefunded: domain.EventRefunded,
+	domain.OrderStatusCancelled:       domain.EventCancelled,
+}
+
+// publishEvent queues webhook deliveries for an order event. Failures are
+// logged; webhooks never change the booking result.
+func publishEvent(ctx workflow.Context, orderID string, event domain.WebhookEvent) {
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(ctx, a.PublishOrderEvent, activities.PublishOrderEventInput{
+		OrderID: orderID,
+		Event:   event,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Failed to publish order event", "orderID", orderID, "event", event, "error", err)
+	}
+}
@@ -1,12 +1,18 @@
 package workflows_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
 
 	"github.com/flight-booking-system/internal/domain"
 	temporalpkg "github.com/flight-booking-system/internal/temporal"
@@ -21,9 +27,13 @@ func TestBookingWorkflow_Success(t *testing.T) {
 	// Register activities (nil struct is fine since we're mocking all calls)
 	var a *activities.BookingActivities
 	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Mock activities using activity function names
-	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
 	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
@@ -62,9 +72,13 @@ func TestBookingWorkflow_TimerExpired(t *testing.T) {
 	// Register activities
 	var a *activities.BookingActivities
 	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Mock activities
-	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
 	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ExpireOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
@@ -85,6 +99,46 @@ func TestBookingWorkflow_TimerExpired(t *testing.T) {
 	require.Contains(t, workflowErr.Error(), "seat reservation expired")
 }
 
+func TestBookingWorkflow_CloseSalesExpiresHold(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	// Register activities
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Mock activities
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	start := env.Now()
+	var expiredAfter time.Duration
+	env.OnActivity(a.ExpireOrder, mock.Anything, activities.ExpireOrderInput{OrderID: "test-order-close"}).Return(
+		func(ctx context.Context, in activities.ExpireOrderInput) error {
+			expiredAfter = env.Now().Sub(start)
+			return nil
+		}).Once()
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// The flight closes sales well before the hold would expire
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalCloseSales, nil)
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-close",
+		FlightID: "test-flight-1",
+		Seats:    []string{"2B"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "seat reservation expired")
+	require.Less(t, expiredAfter, 2*time.Minute, "hold expired with sales, not with its timer")
+	env.AssertExpectations(t)
+}
+
 func TestBookingWorkflow_SeatUpdateResetsTimer(t *testing.T) {
 	testSuite := &testsuite.WorkflowTestSuite{}
 	env := testSuite.NewTestWorkflowEnvironment()
@@ -92,11 +146,15 @@ func TestBookingWorkflow_SeatUpdateResetsTimer(t *testing.T) {
 	// Register activities
 	var a *activities.BookingActivities
 	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Mock activities
-	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
 	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
-	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(activities.UpdateSeatSelectionOutput{}, nil)
 	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
@@ -142,9 +200,13 @@ func TestBookingWorkflow_QueryStatus(t *testing.T) {
 	// Register activities
 	var a *activities.BookingActivities
 	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Mock activities
-	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
 	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
@@ -188,9 +250,13 @@ func TestBookingWorkflow_Canceled(t *testing.T) {
 	// Register activities
 	var a *activities.BookingActivities
 	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	// Mock activities
-	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
 	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
 	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
@@ -213,3 +279,1537 @@ func TestBookingWorkflow_Canceled(t *testing.T) {
 	require.Error(t, workflowErr)
 	require.Contains(t, workflowErr.Error(), "booking workflow canceled")
 }
+
+func TestBookingWorkflow_OperatorCancellation(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.CancelOrder, mock.Anything, activities.CancelOrderInput{
+		OrderID: "test-order-operator", Reason: "booking workflow canceled by an operator",
+	}).Return(nil).Once()
+	env.OnActivity(a.ReleaseSeats, mock.Anything, activities.ReleaseSeatsInput{
+		OrderID: "test-order-operator", FlightID: "test-flight-1", Seats: []string{"5C"},
+	}).Return(nil).Once()
+
+	var events []domain.WebhookEvent
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in activities.PublishOrderEventInput) error {
+			events = append(events, in.Event)
+			return nil
+		})
+
+	// Canceled from the Temporal UI rather than by the cancel-booking signal
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-operator",
+		FlightID: "test-flight-1",
+		Seats:    []string{"5C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var canceledErr *temporal.CanceledError
+	require.ErrorAs(t, env.GetWorkflowError(), &canceledErr)
+	require.Contains(t, events, domain.EventCancelled)
+	env.AssertNotCalled(t, "FailOrder", mock.Anything, mock.Anything)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_SeatUpdateRescalesLockedPrice(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	// Register activities
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	quote := domain.PriceBreakdown{QuoteID: "quote-1", UnitFareCents: 10000, UnitFeeCents: 500}.ForSeats(1)
+
+	// Mock activities
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(activities.UpdateSeatSelectionOutput{}, nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.MatchedBy(func(in activities.ConfirmOrderInput) bool {
+		return in.Price.QuoteID == "quote-1" && in.Price.TotalCents == 31500
+	})).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{
+			Seats: []string{"6A", "6B", "6C"},
+		})
+	}, time.Minute)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{
+			PaymentCode: "12345",
+		})
+	}, 2*time.Minute)
+
+	// Execute workflow
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-6",
+		FlightID: "test-flight-1",
+		Seats:    []string{"6A"},
+		Price:    quote,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+}
+
+func TestBookingWorkflow_ExtendHold(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExtendHold, mock.Anything, mock.MatchedBy(func(in activities.ExtendHoldInput) bool {
+		return in.OrderID == "test-order-extend" && len(in.Seats) == 1
+	})).Return(nil).Once()
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+
+	// Extend at 14 minutes, then check the single extension is used up
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalExtendHold, nil)
+	}, 14*time.Minute)
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		var status temporalpkg.BookingStatusResponse
+		require.NoError(t, encoded.Get(&status))
+		require.Equal(t, 0, status.HoldExtensionsLeft)
+	}, 15*time.Minute)
+
+	// Pay after the original hold would have expired
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, 20*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:           "test-order-extend",
+		FlightID:          "test-flight-1",
+		Seats:             []string{"4A"},
+		MaxHoldExtensions: 1,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_ExtendHoldLimit(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExpireOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	// No extensions allowed: the signal is ignored and the hold expires on time
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalExtendHold, nil)
+	}, 14*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-limit",
+		FlightID: "test-flight-1",
+		Seats:    []string{"4B"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	workflowErr := env.GetWorkflowError()
+	require.Error(t, workflowErr)
+	require.Contains(t, workflowErr.Error(), "seat reservation expired")
+	env.AssertNotCalled(t, "ExtendHold", mock.Anything, mock.Anything)
+}
+
+func TestBookingWorkflow_PassengersFollowSeatChange(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(activities.UpdateSeatSelectionOutput{}, nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+
+	// Ada keeps 7A; Grace moves from 7B to the newly selected 8B
+	var confirmed []domain.Passenger
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, in activities.ConfirmOrderInput) error {
+			confirmed = in.Passengers
+			return nil
+		},
+	)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"8B", "7A"}})
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-passengers",
+		FlightID: "test-flight-1",
+		Seats:    []string{"7A", "7B"},
+		Passengers: []domain.Passenger{
+			{SeatID: "7A", Name: "Ada", DocumentNumber: "P12345", Email: "ada@example.com"},
+			{SeatID: "7B", Name: "Grace", DocumentNumber: "P67890", Email: "grace@example.com"},
+		},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Len(t, confirmed, 2)
+	require.Equal(t, "7A", confirmed[0].SeatID)
+	require.Equal(t, "8B", confirmed[1].SeatID)
+}
+
+func TestBookingWorkflow_QueryReportsPendingSignals(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).After(10*time.Second).Return(activities.UpdateSeatSelectionOutput{}, nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	query := func() temporalpkg.BookingStatusResponse {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		var status temporalpkg.BookingStatusResponse
+		require.NoError(t, encoded.Get(&status))
+		return status
+	}
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"5B"}})
+	}, time.Minute)
+
+	// While the seat change runs, the signal is pending and the seats unchanged
+	env.RegisterDelayedCallback(func() {
+		status := query()
+		require.Equal(t, 0, status.Version)
+		require.Equal(t, 1, status.PendingSignals)
+		require.Equal(t, []string{"5A"}, status.Seats)
+	}, time.Minute+5*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		status := query()
+		require.Equal(t, 1, status.Version)
+		require.Equal(t, 0, status.PendingSignals)
+		require.Equal(t, []string{"5B"}, status.Seats)
+
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-pending",
+		FlightID: "test-flight-1",
+		Seats:    []string{"5A"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	status := query()
+	require.Equal(t, 2, status.Version)
+	require.Equal(t, 0, status.PendingSignals)
+}
+
+func TestBookingWorkflow_BoardingPassFailureKeepsBooking(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, activities.GenerateBoardingPassInput{OrderID: "test-order-pass"}).
+		Return(errors.New("render failed"))
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-pass",
+		FlightID: "test-flight-1",
+		Seats:    []string{"3C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_SeatlessHoldsCapacity(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.MatchedBy(func(in activities.CreateOrderInput) bool {
+		return in.Seatless && len(in.Seats) == 0
+	})).Return(nil)
+	env.OnActivity(a.HoldCabinSeats, mock.Anything, activities.HoldCabinSeatsInput{
+		OrderID: "test-order-seatless", FlightID: "test-flight-1", Count: 2,
+	}).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.MatchedBy(func(in activities.ConfirmOrderInput) bool {
+		return len(in.Seats) == 0 && in.CabinSeats == 2
+	})).Return(nil)
+
+	// A seat change is refused; seats come at check-in
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"1A", "1B"}})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		var status temporalpkg.BookingStatusResponse
+		require.NoError(t, encoded.Get(&status))
+		require.True(t, status.Seatless)
+		require.Equal(t, 2, status.SeatCount)
+		require.Empty(t, status.Seats)
+		require.Equal(t, domain.ErrSeatlessOrder.Error(), status.LastError)
+
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:    "test-order-seatless",
+		FlightID:   "test-flight-1",
+		CabinSeats: 2,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "ReserveSeats", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "GenerateBoardingPass", mock.Anything, mock.Anything)
+}
+
+func TestBookingWorkflow_UrgentPaymentQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		payAfter  time.Duration
+		wantQueue string
+	}{
+		{"fresh order keeps the workflow queue", time.Minute, "default-test-taskqueue"},
+		{"order near expiry jumps the queue", 13 * time.Minute, "urgent-test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			var a *activities.BookingActivities
+			env.RegisterActivity(a)
+			env.RegisterWorkflow(workflows.PaymentWorkflow)
+			env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+			env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+			env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+			env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+			env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+			env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+
+			var gotQueue string
+			env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, _ activities.ValidatePaymentInput) (activities.ValidatePaymentOutput, error) {
+					gotQueue = activity.GetInfo(ctx).TaskQueue
+					return activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil
+				},
+			)
+
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+			}, tt.payAfter)
+
+			env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+				OrderID:  "test-order-urgent",
+				FlightID: "test-flight-1",
+				Seats:    []string{"4D"},
+
+				UrgentTaskQueue:     "urgent-test",
+				UrgentPaymentWindow: 3 * time.Minute,
+			})
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.NoError(t, env.GetWorkflowError())
+			require.Equal(t, tt.wantQueue, gotQueue)
+		})
+	}
+}
+
+func TestBookingWorkflow_PaymentWindowExpires(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExpireOrder, mock.Anything, activities.ExpireOrderInput{OrderID: "test-order-window"}).Return(nil).Once()
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Every attempt hangs until it times out, as if the provider never answered
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).After(9*time.Second).
+		Return(activities.ValidatePaymentOutput{}, errors.New("provider unavailable"))
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-window",
+		FlightID: "test-flight-1",
+		Seats:    []string{"6C"},
+
+		PaymentWindow: 15 * time.Second,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "payment validation timed out")
+
+	encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+	require.NoError(t, err)
+	var status temporalpkg.BookingStatusResponse
+	require.NoError(t, encoded.Get(&status))
+	require.Equal(t, domain.OrderStatusExpired, status.Status)
+	require.Equal(t, "payment not completed within 15s", status.LastError)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_LocalOrderWriteFallsBack(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+
+	// The status write fails while it runs locally and lands as an activity
+	var local, remote int
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, _ activities.UpdateOrderStatusInput) error {
+			if activity.GetInfo(ctx).IsLocalActivity {
+				local++
+				return errors.New("connection reset")
+			}
+			remote++
+			return nil
+		},
+	)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-local",
+		FlightID: "test-flight-1",
+		Seats:    []string{"2C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Equal(t, 2, local, "local write retried once")
+	require.Equal(t, 1, remote)
+}
+
+func TestBookingWorkflow_OrderFinishedElsewhere(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+
+	// An admin tool cancelled the order before the booking took payment;
+	// the local write and its activity fallback both find it finished
+	writes := 0
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(
+		func(context.Context, activities.UpdateOrderStatusInput) error {
+			writes++
+			return temporalpkg.NewOrderConflictError("test-order-conflict", domain.OrderStatusCancelled)
+		},
+	)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-conflict",
+		FlightID: "test-flight-1",
+		Seats:    []string{"3D"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+	require.Equal(t, 1, writes, "a conflict is not retried as an activity")
+
+	var status temporalpkg.BookingStatusResponse
+	encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+	require.NoError(t, err)
+	require.NoError(t, encoded.Get(&status))
+	require.Equal(t, domain.OrderStatusCancelled, status.Status)
+	env.AssertActivityNotCalled(t, "ValidatePayment", mock.Anything, mock.Anything)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_PaymentRetrySucceeds(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+
+	// A transient failure followed by success still confirms the booking
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{}, errors.New("payment gateway timeout"),
+	).Once()
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-retry",
+		FlightID: "test-flight-1",
+		Seats:    []string{"1A"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+}
+
+func TestBookingWorkflow_DeclinedPaymentCanBeRetried(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+
+	// The first code is declined; the order keeps its seats for a second one
+	env.OnActivity(a.ValidatePayment, mock.Anything, activities.ValidatePaymentInput{
+		OrderID: "test-order-declined", PaymentCode: "11111", Attempt: 1,
+	}).Return(activities.ValidatePaymentOutput{}, temporal.NewNonRetryableApplicationError(
+		"card declined", temporalpkg.ErrTypePaymentDeclined, nil,
+	)).Once()
+	env.OnActivity(a.ValidatePayment, mock.Anything, activities.ValidatePaymentInput{
+		OrderID: "test-order-declined", PaymentCode: "12345", Attempt: 1,
+	}).Return(activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "11111"})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		var status temporalpkg.BookingStatusResponse
+		require.NoError(t, encoded.Get(&status))
+		require.Equal(t, domain.OrderStatusPaymentPending, status.Status)
+		require.Contains(t, status.LastError, "card declined")
+
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-declined",
+		FlightID: "test-flight-1",
+		Seats:    []string{"1A"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	require.Empty(t, result.Error)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_DeclinedPaymentExpiresWithHold(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{}, temporal.NewNonRetryableApplicationError(
+			"invalid payment code", temporalpkg.ErrTypeInvalidPaymentCode, nil,
+		),
+	).Once()
+	env.OnActivity(a.ExpireOrder, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "11111"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-declined-expiry",
+		FlightID: "test-flight-1",
+		Seats:    []string{"1A"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
+
+// A declined payment leaves the order holding its seats in PAYMENT_PENDING,
+// and that hold can still be extended
+func TestBookingWorkflow_ExtendHoldAfterDecline(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, activities.ValidatePaymentInput{
+		OrderID: "test-order-declined-extend", PaymentCode: "11111", Attempt: 1,
+	}).Return(activities.ValidatePaymentOutput{}, temporal.NewNonRetryableApplicationError(
+		"card declined", temporalpkg.ErrTypePaymentDeclined, nil,
+	)).Once()
+	env.OnActivity(a.ValidatePayment, mock.Anything, activities.ValidatePaymentInput{
+		OrderID: "test-order-declined-extend", PaymentCode: "12345", Attempt: 1,
+	}).Return(activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil).Once()
+	env.OnActivity(a.ExtendHold, mock.Anything, mock.MatchedBy(func(in activities.ExtendHoldInput) bool {
+		return in.OrderID == "test-order-declined-extend"
+	})).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "11111"})
+	}, time.Minute)
+
+	// Extend at 14 minutes, while the order waits for another payment
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalExtendHold, nil)
+	}, 14*time.Minute)
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		var status temporalpkg.BookingStatusResponse
+		require.NoError(t, encoded.Get(&status))
+		require.Equal(t, domain.OrderStatusPaymentPending, status.Status)
+		require.Equal(t, 0, status.HoldExtensionsLeft)
+	}, 15*time.Minute)
+
+	// Pay after the original hold would have expired
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, 20*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:           "test-order-declined-extend",
+		FlightID:          "test-flight-1",
+		Seats:             []string{"1A"},
+		MaxHoldExtensions: 1,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_RejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input temporalpkg.BookingWorkflowInput
+	}{
+		{"no order ID", temporalpkg.BookingWorkflowInput{FlightID: "f", Seats: []string{"1A"}}},
+		{"no flight ID", temporalpkg.BookingWorkflowInput{OrderID: "o", Seats: []string{"1A"}}},
+		{"no seats", temporalpkg.BookingWorkflowInput{OrderID: "o", FlightID: "f"}},
+		{"bad seat", temporalpkg.BookingWorkflowInput{OrderID: "o", FlightID: "f", Seats: []string{"A1"}}},
+		{"duplicate seat", temporalpkg.BookingWorkflowInput{OrderID: "o", FlightID: "f", Seats: []string{"1A", "1a"}}},
+		{"too many seats", temporalpkg.BookingWorkflowInput{OrderID: "o", FlightID: "f", CabinSeats: domain.MaxGroupSeats + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			// No activity is mocked: invalid input must fail before any runs
+			var a *activities.BookingActivities
+			env.RegisterActivity(a)
+			env.RegisterWorkflow(workflows.PaymentWorkflow)
+
+			env.ExecuteWorkflow(workflows.BookingWorkflow, tt.input)
+
+			require.True(t, env.IsWorkflowCompleted())
+			err := env.GetWorkflowError()
+			require.Error(t, err)
+			var appErr *temporal.ApplicationError
+			require.True(t, errors.As(err, &appErr))
+			require.Equal(t, temporalpkg.ErrTypeInvalidInput, appErr.Type())
+		})
+	}
+}
+
+func TestBookingWorkflow_NormalizesSeats(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+
+	var reserved, updated []string
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ReserveSeatInput) (activities.ReserveSeatsOutput, error) {
+			reserved = input.Seats
+			return activities.ReserveSeatsOutput{}, nil
+		},
+	)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.UpdateSeatSelectionInput) (activities.UpdateSeatSelectionOutput, error) {
+			updated = input.NewSeats
+			return activities.UpdateSeatSelectionOutput{}, nil
+		},
+	)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"12A", "3c", "3B"}})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-normalize",
+		FlightID: "test-flight-1",
+		Seats:    []string{"10B", "2A", "10C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Equal(t, []string{"2A", "10B", "10C"}, reserved)
+	require.Equal(t, []string{"3B", "3C", "12A"}, updated)
+}
+
+func TestBookingWorkflow_ThreadsLockToken(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+
+	// Each lock hands out a token the next seat activity must present
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{LockToken: 7}, nil).Once()
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.MatchedBy(func(in activities.UpdateSeatSelectionInput) bool {
+		return in.LockToken == 7
+	})).Return(activities.UpdateSeatSelectionOutput{LockToken: 9}, nil).Once()
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.MatchedBy(func(in activities.ReleaseSeatsInput) bool {
+		return in.LockToken == 9 && in.Seats[0] == "4A"
+	})).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"4A"}})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-fencing",
+		FlightID: "test-flight-1",
+		Seats:    []string{"1A"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_DownsizeReleasesDroppedSeats(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	quote := domain.PriceBreakdown{QuoteID: "quote-1", UnitFareCents: 10000, UnitFeeCents: 500}.ForSeats(4)
+
+	var events []domain.WebhookEvent
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.PublishOrderEventInput) error {
+			events = append(events, input.Event)
+			return nil
+		},
+	)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.MatchedBy(func(in activities.ReleaseSeatsInput) bool {
+		return len(in.Seats) == 2 && in.Seats[0] == "7C" && in.Seats[1] == "7D"
+	})).Return(nil).Once()
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.MatchedBy(func(in activities.UpdateOrderSeatsInput) bool {
+		return in.Price.TotalCents == 21000 && len(in.Seats) == 2
+	})).Return(nil).Once()
+
+	var before, status temporalpkg.BookingStatusResponse
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		require.NoError(t, value.Get(&before))
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{
+			Seats: []string{"7A", "7B"},
+		})
+	}, time.Minute)
+
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		require.NoError(t, value.Get(&status))
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 2*time.Minute)
+
+	// Canceling releases only the seats the order still holds
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.MatchedBy(func(in activities.ReleaseSeatsInput) bool {
+		return len(in.Seats) == 2 && in.Seats[0] == "7A"
+	})).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-downsize",
+		FlightID: "test-flight-1",
+		Seats:    []string{"7A", "7B", "7C", "7D"},
+		Price:    quote,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Equal(t, []string{"7A", "7B"}, status.Seats)
+	require.Equal(t, int64(21000), status.Price.TotalCents)
+	require.True(t, before.ExpiresAt.Equal(status.ExpiresAt), "a downsize keeps the hold's expiry")
+	require.Contains(t, events, domain.EventSeatsReleased)
+	env.AssertActivityNotCalled(t, "UpdateSeatSelection", mock.Anything, mock.Anything)
+	env.AssertExpectations(t)
+}
+
+func TestGroupBookingWorkflow_PartialNeedsApproval(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(workflows.GroupBookingWorkflow)
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	seats := []string{"10A", "10B", "10C", "10D", "11A", "11B", "11C", "11D", "12A", "12B"}
+	quote := domain.PriceBreakdown{QuoteID: "quote-1", UnitFareCents: 10000, UnitFeeCents: 500}.ForSeats(len(seats))
+
+	// The second chunk fails because 11B is taken; its other seats still hold
+	var holds []time.Duration
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ReserveSeatInput) (activities.ReserveSeatsOutput, error) {
+			holds = append(holds, input.HoldDuration)
+			for _, seat := range input.Seats {
+				if seat == "11B" {
+					return activities.ReserveSeatsOutput{}, temporalpkg.NewSeatUnavailableError(seat)
+				}
+			}
+			return activities.ReserveSeatsOutput{}, nil
+		},
+	)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.MatchedBy(func(in activities.UpdateOrderSeatsInput) bool {
+		return len(in.Seats) == 9 && in.Price.SeatCount == 9
+	})).Return(nil).Once()
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+
+	var confirmed activities.ConfirmOrderInput
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ConfirmOrderInput) error {
+			confirmed = input
+			return nil
+		},
+	)
+
+	// Paying before approval is refused
+	var partial temporalpkg.BookingStatusResponse
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		require.NoError(t, value.Get(&partial))
+		env.SignalWorkflow(temporalpkg.SignalApprovePartial, nil)
+	}, 2*time.Minute)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, 3*time.Minute)
+
+	env.ExecuteWorkflow(workflows.GroupBookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:          "test-order-group",
+		FlightID:         "test-flight-1",
+		Seats:            seats,
+		Price:            quote,
+		HoldDuration:     45 * time.Minute,
+		ReserveChunkSize: 4,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	require.True(t, partial.AwaitingApproval)
+	require.Equal(t, []string{"11B"}, partial.UnavailableSeats)
+	require.Equal(t, domain.ErrPartialNotApproved.Error(), partial.LastError)
+	require.Equal(t, domain.OrderStatusSeatsReserved, partial.Status)
+	require.Greater(t, time.Until(partial.ExpiresAt), 40*time.Minute)
+
+	require.NotContains(t, confirmed.Seats, "11B")
+	require.Len(t, confirmed.Seats, 9)
+	require.Equal(t, 9, confirmed.Price.SeatCount)
+	for _, hold := range holds {
+		require.Equal(t, 45*time.Minute, hold)
+	}
+	env.AssertExpectations(t)
+}
+
+// updateOutcome records how the workflow settled a test update
+type updateOutcome struct {
+	rejected error
+	result   any
+	err      error
+	done     bool
+}
+
+func (o *updateOutcome) Accept()          {}
+func (o *updateOutcome) Reject(err error) { o.rejected, o.done = err, true }
+func (o *updateOutcome) Complete(result any, err error) {
+	o.result, o.err, o.done = result, err, true
+}
+
+func TestBookingWorkflow_ChangeSeatsUpdate(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.MatchedBy(func(in activities.UpdateSeatSelectionInput) bool {
+		return in.NewSeats[0] == "9A"
+	})).Return(activities.UpdateSeatSelectionOutput{}, temporalpkg.NewSeatUnavailableError("9A")).Once()
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.MatchedBy(func(in activities.UpdateSeatSelectionInput) bool {
+		return in.NewSeats[0] == "3A"
+	})).Return(activities.UpdateSeatSelectionOutput{}, nil).Once()
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	// A taken seat is reported to the caller and keeps the current seats
+	taken := &updateOutcome{}
+	env.RegisterDelayedCallback(func() {
+		env.UpdateWorkflow(temporalpkg.UpdateChangeSeats, "change-1", taken, temporalpkg.SeatUpdateSignal{Seats: []string{"9A"}})
+	}, time.Minute)
+
+	// An available selection is applied before the update completes
+	changed := &updateOutcome{}
+	env.RegisterDelayedCallback(func() {
+		env.UpdateWorkflow(temporalpkg.UpdateChangeSeats, "change-2", changed, temporalpkg.SeatUpdateSignal{Seats: []string{"3B", "3A"}})
+	}, 2*time.Minute)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 3*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-update",
+		FlightID: "test-flight-1",
+		Seats:    []string{"1A", "1B"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	require.True(t, taken.done)
+	require.NoError(t, taken.rejected)
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, taken.err, &appErr)
+	require.Equal(t, temporalpkg.ErrTypeSeatUnavailable, appErr.Type())
+
+	require.True(t, changed.done)
+	require.NoError(t, changed.err)
+	result, ok := changed.result.(temporalpkg.SeatChangeResult)
+	require.True(t, ok)
+	require.Equal(t, []string{"3A", "3B"}, result.Status.Seats)
+	require.Zero(t, result.Status.PendingSignals)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_ChangeSeatsUpdateRejected(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.HoldCabinSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseCabinSeats, mock.Anything, mock.Anything).Return(nil)
+
+	// Seatless orders refuse seat changes before they reach the history
+	outcome := &updateOutcome{}
+	env.RegisterDelayedCallback(func() {
+		env.UpdateWorkflow(temporalpkg.UpdateChangeSeats, "change-1", outcome, temporalpkg.SeatUpdateSignal{Seats: []string{"1A"}})
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalCancelBooking, nil)
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:    "test-order-update-seatless",
+		FlightID:   "test-flight-1",
+		CabinSeats: 2,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.True(t, outcome.done)
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, outcome.rejected, &appErr)
+	require.Equal(t, temporalpkg.ErrTypeSeatlessOrder, appErr.Type())
+	env.AssertActivityNotCalled(t, "UpdateSeatSelection", mock.Anything, mock.Anything)
+}
+
+func TestBookingWorkflow_ContinuesAsNewBetweenSignals(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExtendHold, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.Anything).Return(activities.UpdateSeatSelectionOutput{}, nil)
+	env.OnActivity(a.UpdateOrderSeats, mock.Anything, mock.Anything).Return(nil)
+
+	// Both signals arrive as the history grows too long; the run applies
+	// them before handing the hold on
+	env.RegisterDelayedCallback(func() {
+		env.SetCurrentHistoryLength(5000)
+		env.SignalWorkflow(temporalpkg.SignalExtendHold, nil)
+		env.SignalWorkflow(temporalpkg.SignalUpdateSeats, temporalpkg.SeatUpdateSignal{Seats: []string{"3A", "3B"}})
+	}, time.Minute)
+
+	input := temporalpkg.BookingWorkflowInput{
+		OrderID:           "test-order-continue",
+		FlightID:          "test-flight-1",
+		Seats:             []string{"1A", "1B"},
+		MaxHoldExtensions: 2,
+	}
+	env.ExecuteWorkflow(workflows.BookingWorkflow, input)
+
+	require.True(t, env.IsWorkflowCompleted())
+	var canErr *workflow.ContinueAsNewError
+	require.ErrorAs(t, env.GetWorkflowError(), &canErr)
+	var next temporalpkg.BookingWorkflowInput
+	require.NoError(t, converter.GetDefaultDataConverter().FromPayloads(canErr.Input, &next))
+	require.NotNil(t, next.Resume)
+	require.Equal(t, domain.OrderStatusSeatsReserved, next.Resume.Status)
+	require.Equal(t, []string{"3A", "3B"}, next.Resume.Seats)
+	require.Equal(t, 1, next.Resume.HoldExtensions)
+	require.Equal(t, 2, next.Resume.Version)
+	env.AssertActivityNotCalled(t, "ReleaseSeats", mock.Anything, mock.Anything)
+
+	// The next run keeps the hold and takes the payment sent to it
+	env = testSuite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.MatchedBy(func(in activities.ConfirmOrderInput) bool {
+		return len(in.Seats) == 2 && in.Seats[0] == "3A"
+	})).Return(nil)
+
+	var status temporalpkg.BookingStatusResponse
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		require.NoError(t, value.Get(&status))
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, next)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Equal(t, 2, status.Version)
+	require.Equal(t, 1, status.HoldExtensionsLeft)
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	env.AssertActivityNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "ReserveSeats", mock.Anything, mock.Anything)
+}
+
+func TestBookingWorkflow_RemindsBeforeExpiry(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExtendHold, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ExpireOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	start := env.Now()
+	var remindedAt []time.Duration
+	env.OnActivity(a.SendReminder, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.SendReminderInput) (activities.NotifyOrderOutput, error) {
+			require.Equal(t, 3*time.Minute, input.ExpiresAt.Sub(env.Now()).Round(time.Second))
+			remindedAt = append(remindedAt, env.Now().Sub(start).Round(time.Minute))
+			return activities.NotifyOrderOutput{}, nil
+		},
+	)
+
+	// Extending after the first reminder moves the expiry, which earns a
+	// reminder of its own
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalExtendHold, nil)
+	}, 13*time.Minute)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:           "test-order-reminder",
+		FlightID:          "test-flight-1",
+		Seats:             []string{"1A"},
+		MaxHoldExtensions: 1,
+		ReminderBefore:    3 * time.Minute,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "seat reservation expired")
+	require.Equal(t, []time.Duration{12 * time.Minute, 25 * time.Minute}, remindedAt)
+}
+
+// TestBookingWorkflow_ConfirmFailureCompensation covers what the workflow does
+// once a confirmation step has failed; which repository writes fail each step,
+// and how, is covered by the activity and repository tests
+func TestBookingWorkflow_ConfirmFailureCompensation(t *testing.T) {
+	unavailable := errors.New("connection reset")
+	tests := []struct {
+		name         string
+		failStep     string           // confirmation activity that fails
+		confirmVer   workflow.Version // booking-confirm version the booking started on; zero is the current one
+		failErr      error
+		compensation temporalpkg.ConfirmCompensation
+		prepaid      bool
+		refundErr    error
+		wantUndo     []string // compensations, in the order they run
+		wantStatus   domain.OrderStatus
+		wantRefund   bool
+	}{
+		{name: "confirm fails", failStep: "ConfirmOrder", failErr: unavailable, compensation: temporalpkg.CompensateRefund, wantStatus: domain.OrderStatusPaymentRefunded, wantRefund: true},
+		{name: "compensation disabled", failStep: "ConfirmOrder", failErr: unavailable, compensation: temporalpkg.CompensateNone, wantStatus: domain.OrderStatusFailed},
+		{name: "trip leg refunds its share", failStep: "ConfirmOrder", failErr: unavailable, compensation: temporalpkg.CompensateRefund, prepaid: true, wantStatus: domain.OrderStatusPaymentRefunded, wantRefund: true},
+		{name: "trip leg before legs refunded", confirmVer: 4, failStep: "ConfirmOrder", failErr: unavailable, compensation: temporalpkg.CompensateRefund, prepaid: true, wantStatus: domain.OrderStatusFailed},
+		{name: "refund fails", failStep: "ConfirmOrder", failErr: unavailable, compensation: temporalpkg.CompensateRefund, refundErr: errors.New("gateway unavailable"), wantStatus: domain.OrderStatusFailed, wantRefund: true},
+		{name: "saga confirm order", confirmVer: 3, failStep: "MarkOrderConfirmed", failErr: unavailable, compensation: temporalpkg.CompensateRefund, wantStatus: domain.OrderStatusPaymentRefunded, wantRefund: true},
+		{name: "saga book seats", confirmVer: 3, failStep: "BookOrderSeats", failErr: unavailable, compensation: temporalpkg.CompensateRefund, wantUndo: []string{"UnconfirmOrder"}, wantStatus: domain.OrderStatusPaymentRefunded, wantRefund: true},
+		{name: "saga count seats", confirmVer: 3, failStep: "CountBookedSeats", failErr: domain.ErrInsufficientSeats, compensation: temporalpkg.CompensateRefund, wantUndo: []string{"UnbookSeats", "UnconfirmOrder"}, wantStatus: domain.OrderStatusPaymentRefunded, wantRefund: true},
+		{name: "saga compensation disabled", confirmVer: 3, failStep: "BookOrderSeats", failErr: unavailable, compensation: temporalpkg.CompensateNone, wantUndo: []string{"UnconfirmOrder"}, wantStatus: domain.OrderStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			var a *activities.BookingActivities
+			env.RegisterActivity(a)
+			env.RegisterWorkflow(workflows.PaymentWorkflow)
+			env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+			env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+			env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+			env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+			env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+				activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+			).Maybe()
+			env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+			// A booking that reached confirmation on an older version finishes
+			// on it; the saga runs its steps up to the failing one
+			if tt.confirmVer != 0 {
+				env.OnGetVersion("booking-confirm", workflow.DefaultVersion, workflow.Version(5)).Return(tt.confirmVer)
+			}
+			failing := func(step string) error {
+				if step == tt.failStep {
+					return tt.failErr
+				}
+				return nil
+			}
+			env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(failing("ConfirmOrder")).Maybe()
+			env.OnActivity(a.MarkOrderConfirmed, mock.Anything, mock.Anything).Return(failing("MarkOrderConfirmed")).Maybe()
+			env.OnActivity(a.BookOrderSeats, mock.Anything, mock.Anything).Return(failing("BookOrderSeats")).Maybe()
+			env.OnActivity(a.CountBookedSeats, mock.Anything, mock.Anything).Return(failing("CountBookedSeats")).Maybe()
+
+			var undo []string
+			env.OnActivity(a.UnconfirmOrder, mock.Anything, mock.Anything).Return(
+				func(context.Context, activities.UnconfirmOrderInput) error {
+					undo = append(undo, "UnconfirmOrder")
+					return nil
+				},
+			).Maybe()
+			env.OnActivity(a.UnbookSeats, mock.Anything, mock.Anything).Return(
+				func(_ context.Context, in activities.BookOrderSeatsInput) error {
+					require.Equal(t, []string{"4A", "4B"}, in.Seats)
+					undo = append(undo, "UnbookSeats")
+					return nil
+				},
+			).Maybe()
+
+			refunds := 0
+			env.OnActivity(a.RefundPayment, mock.Anything, mock.Anything).Return(
+				func(_ context.Context, in activities.RefundPaymentInput) error {
+					refunds++
+					require.Equal(t, int64(25000), in.AmountCents)
+					require.Equal(t, "12345", in.PaymentCode)
+					return tt.refundErr
+				},
+			).Maybe()
+			var refundReason, failReason string
+			env.OnActivity(a.RefundOrder, mock.Anything, mock.Anything).Return(
+				func(_ context.Context, in activities.RefundOrderInput) error {
+					refundReason = in.Reason
+					return nil
+				},
+			).Maybe()
+			env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(
+				func(_ context.Context, in activities.FailOrderInput) error {
+					failReason = in.Reason
+					return nil
+				},
+			).Maybe()
+
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345", Prepaid: tt.prepaid})
+			}, time.Minute)
+
+			env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+				OrderID:             "test-order-refund",
+				FlightID:            "test-flight-1",
+				Seats:               []string{"4A", "4B"},
+				Price:               domain.PriceBreakdown{TotalCents: 25000},
+				ConfirmCompensation: tt.compensation,
+			})
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.Error(t, env.GetWorkflowError())
+			require.Equal(t, tt.wantUndo, undo)
+
+			var status temporalpkg.BookingStatusResponse
+			encoded, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+			require.NoError(t, err)
+			require.NoError(t, encoded.Get(&status))
+			require.Equal(t, tt.wantStatus, status.Status)
+			require.Contains(t, status.LastError, "confirmation failed")
+
+			require.Equal(t, tt.wantRefund, refunds > 0)
+			if tt.wantStatus == domain.OrderStatusPaymentRefunded {
+				require.Contains(t, refundReason, "confirmation failed")
+				require.Empty(t, failReason)
+			} else {
+				require.Empty(t, refundReason)
+				require.Contains(t, failReason, "confirmation failed")
+			}
+			if tt.refundErr != nil {
+				require.Contains(t, failReason, "refund failed")
+			}
+			env.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookingWorkflow_UpsellAccepted(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	quote := domain.PriceBreakdown{QuoteID: "quote-1", UnitFareCents: 10000, UnitFeeCents: 500}.ForSeats(2)
+	policy := domain.UpsellPolicy{Window: 30 * time.Second, UpgradeCentsPerCabin: 15000, PriorityBoardingCents: 2500}
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FindUpsellOffer, mock.Anything, mock.Anything).Return(&domain.UpsellOffer{
+		Class:                 domain.CabinBusiness,
+		Seats:                 []string{"2A", "2B"},
+		UpgradeCents:          15000,
+		PriorityBoardingCents: 2500,
+	}, nil)
+	env.OnActivity(a.UpdateSeatSelection, mock.Anything, mock.MatchedBy(func(in activities.UpdateSeatSelectionInput) bool {
+		return len(in.NewSeats) == 2 && in.NewSeats[0] == "2A" && in.OldSeats[0] == "20A"
+	})).Return(activities.UpdateSeatSelectionOutput{}, nil).Once()
+	env.OnActivity(a.RecordUpsell, mock.Anything, mock.MatchedBy(func(in activities.RecordUpsellInput) bool {
+		return in.PriorityBoarding && in.Price.TotalCents == 21000+35000
+	})).Return(nil).Once()
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.MatchedBy(func(in activities.ConfirmOrderInput) bool {
+		return in.Seats[0] == "2A" && in.Price.UpsellCents == 35000 && in.Price.TotalCents == 56000
+	})).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	var offered temporalpkg.BookingStatusResponse
+	env.RegisterDelayedCallback(func() {
+		result, err := env.QueryWorkflow(temporalpkg.QueryBookingStatus)
+		require.NoError(t, err)
+		require.NoError(t, result.Get(&offered))
+		env.SignalWorkflow(temporalpkg.SignalRespondUpsell, domain.UpsellResponse{Upgrade: true, PriorityBoarding: true})
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-upsell",
+		FlightID: "test-flight-1",
+		Seats:    []string{"20A", "20B"},
+		Price:    quote,
+		Upsell:   policy,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.NotNil(t, offered.UpsellOffer)
+	require.Equal(t, domain.CabinBusiness, offered.UpsellOffer.Class)
+	require.Equal(t, domain.OrderStatusPaymentProcessing, offered.Status)
+
+	var result temporalpkg.BookingWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, []string{"2A", "2B"}, result.Seats)
+	env.AssertExpectations(t)
+}
+
+func TestBookingWorkflow_UpsellExpires(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.PaymentWorkflow)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	quote := domain.PriceBreakdown{QuoteID: "quote-1", UnitFareCents: 10000}.ForSeats(1)
+
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.FindUpsellOffer, mock.Anything, mock.Anything).Return(&domain.UpsellOffer{PriorityBoardingCents: 2500}, nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	)
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.MatchedBy(func(in activities.ConfirmOrderInput) bool {
+		return in.Price.TotalCents == 10000
+	})).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.BookingWorkflow, temporalpkg.BookingWorkflowInput{
+		OrderID:  "test-order-upsell-expired",
+		FlightID: "test-flight-1",
+		Seats:    []string{"20A"},
+		Price:    quote,
+		Upsell:   domain.UpsellPolicy{Window: 30 * time.Second, PriorityBoardingCents: 2500},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertActivityNotCalled(t, "RecordUpsell", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "UpdateSeatSelection", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,64 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// CheckInWorkflow checks a confirmed order in once check-in has opened,
+// CheckInOpensBefore departure; the caller enforces the window
+// - Moves the order onto its new seats, or seats a seatless order
+// - Records the check-in
+// - Issues the boarding pass for the seats the order now holds
+func CheckInWorkflow(ctx workflow.Context, input temporalpkg.CheckInWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("CheckInWorkflow started", "orderID", input.OrderID, "seats", input.Seats)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:        time.Second,
+			BackoffCoefficient:     2.0,
+			MaximumInterval:        10 * time.Second,
+			MaximumAttempts:        3,
+			NonRetryableErrorTypes: []string{temporalpkg.ErrTypeSeatUnavailable},
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	if len(input.Seats) > 0 {
+		err := workflow.ExecuteActivity(ctx, a.AssignCheckInSeats, activities.CheckInSeatsInput{
+			OrderID: input.OrderID,
+			Seats:   input.Seats,
+		}).Get(ctx, nil)
+		if err != nil {
+			logger.Error("Check-in seats not assigned", "orderID", input.OrderID, "error", err)
+			return err
+		}
+	}
+
+	err := workflow.ExecuteActivity(ctx, a.CompleteCheckIn, activities.CompleteCheckInInput{
+		OrderID: input.OrderID,
+	}).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// The boarding pass from confirmation may show seats the order just left
+	err = workflow.ExecuteActivity(ctx, a.GenerateBoardingPass, activities.GenerateBoardingPassInput{
+		OrderID: input.OrderID,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Boarding pass not issued", "orderID", input.OrderID, "error", err)
+		return err
+	}
+
+	logger.Info("Order checked in", "orderID", input.OrderID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestCheckInWorkflow_ReassignsSeatsAndIssuesPass(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.AssignCheckInSeats, mock.Anything, mock.MatchedBy(func(in activities.CheckInSeatsInput) bool {
+		return in.OrderID == "order-1" && len(in.Seats) == 1 && in.Seats[0] == "9C"
+	})).Return(nil).Once()
+	env.OnActivity(a.CompleteCheckIn, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.CheckInWorkflow, temporalpkg.CheckInWorkflowInput{
+		OrderID: "order-1",
+		Seats:   []string{"9C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
+
+func TestCheckInWorkflow_KeepsSeatsWhenNoneRequested(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.CompleteCheckIn, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.CheckInWorkflow, temporalpkg.CheckInWorkflowInput{OrderID: "order-2"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertActivityNotCalled(t, "AssignCheckInSeats", mock.Anything, mock.Anything)
+}
+
+func TestCheckInWorkflow_TakenSeatFailsWithoutPass(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.AssignCheckInSeats, mock.Anything, mock.Anything).Return(
+		temporalpkg.NewSeatUnavailableError("9C"),
+	).Once()
+
+	env.ExecuteWorkflow(workflows.CheckInWorkflow, temporalpkg.CheckInWorkflowInput{
+		OrderID: "order-3",
+		Seats:   []string{"9C"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), "seat 9C is not available")
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "CompleteCheckIn", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "GenerateBoardingPass", mock.Anything, mock.Anything)
+}
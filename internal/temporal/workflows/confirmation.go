@@ -0,0 +1,104 @@
+package workflows
+
+import (
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// confirmStep is one forward step of the confirmation saga and the
+// compensation that undoes it
+type confirmStep struct {
+	name       string
+	run        func(ctx workflow.Context) error
+	compensate func(ctx workflow.Context) error
+}
+
+// confirmBooking confirms the order, books its seats and counts them against
+// the flight as separate activities. When a step fails, the steps that
+// succeeded are compensated in reverse order, leaving the order paid but
+// unconfirmed with its seats still reserved for the booking's own failure
+// handling to release. Only bookings that reached confirmation on confirm
+// version 3 run it; later ones confirm in one transaction with ConfirmOrder.
+func confirmBooking(ctx workflow.Context, state *bookingState) error {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+
+	seats := activities.BookOrderSeatsInput{
+		OrderID:  state.orderID,
+		FlightID: state.flightID,
+		Seats:    state.seats,
+	}
+	count := activities.CountBookedSeatsInput{
+		OrderID:  state.orderID,
+		FlightID: state.flightID,
+		Count:    len(state.seats) + state.cabinSeats,
+	}
+
+	steps := []confirmStep{
+		{
+			name: "confirm order",
+			run: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.MarkOrderConfirmed, activities.ConfirmOrderInput{
+					OrderID:    state.orderID,
+					FlightID:   state.flightID,
+					Seats:      state.seats,
+					CabinSeats: state.cabinSeats,
+					Price:      state.price,
+					Passengers: state.passengers,
+				}).Get(ctx, nil)
+			},
+			compensate: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.UnconfirmOrder, activities.UnconfirmOrderInput{
+					OrderID: state.orderID,
+				}).Get(ctx, nil)
+			},
+		},
+		{
+			name: "book seats",
+			run: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.BookOrderSeats, seats).Get(ctx, nil)
+			},
+			compensate: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.UnbookSeats, seats).Get(ctx, nil)
+			},
+		},
+		{
+			name: "count seats",
+			run: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.CountBookedSeats, count).Get(ctx, nil)
+			},
+			compensate: func(ctx workflow.Context) error {
+				return workflow.ExecuteActivity(ctx, a.UncountBookedSeats, count).Get(ctx, nil)
+			},
+		},
+	}
+
+	for i, step := range steps {
+		err := step.run(ctx)
+		if err == nil {
+			continue
+		}
+
+		logger.Error("Confirmation step failed, compensating", "step", step.name, "error", err)
+
+		// Compensations must run even if the workflow is being canceled
+		compensateCtx, _ := workflow.NewDisconnectedContext(ctx)
+		for j := i - 1; j >= 0; j-- {
+			if compErr := steps[j].compensate(compensateCtx); compErr != nil {
+				logger.Error("Confirmation compensation failed", "step", steps[j].name, "error", compErr)
+			}
+		}
+		return err
+	}
+
+	// The seats are booked in Postgres now, so their Redis locks only get in
+	// the way; a failure here leaves them to expire
+	if len(state.seats) > 0 {
+		if err := workflow.ExecuteActivity(ctx, a.ReleaseBookedSeatLocks, seats).Get(ctx, nil); err != nil {
+			logger.Warn("Failed to release booked seat locks", "error", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package workflows
+
+import (
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// DepartureSchedulerWorkflow starts a FlightDepartureWorkflow for every
+// flight departing within the horizon. The worker runs it on the
+// flight-departures Temporal Schedule; flights that already have a departure
+// workflow running keep it.
+func DepartureSchedulerWorkflow(ctx workflow.Context, input temporalpkg.DepartureSchedulerWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var output activities.FindDepartingFlightsOutput
+	err := workflow.ExecuteActivity(ctx, a.FindDepartingFlights, activities.FindDepartingFlightsInput{
+		Horizon: input.Horizon,
+	}).Get(ctx, &output)
+	if err != nil {
+		logger.Error("Failed to find departing flights", "error", err)
+		return err
+	}
+
+	// Departure workflows outlive the scan that started them
+	started := 0
+	for _, flightID := range output.FlightIDs {
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID:        "departure-" + flightID,
+			ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+		})
+		child := workflow.ExecuteChildWorkflow(childCtx, FlightDepartureWorkflow, temporalpkg.FlightDepartureWorkflowInput{
+			FlightID:         flightID,
+			SalesCloseBefore: input.SalesCloseBefore,
+			Bump:             input.Bump,
+		})
+		if err := child.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+			if !temporal.IsWorkflowExecutionAlreadyStartedError(err) {
+				logger.Error("Failed to start departure workflow", "flightID", flightID, "error", err)
+			}
+			continue
+		}
+		started++
+	}
+
+	logger.Info("Scheduled flight departures", "flights", len(output.FlightIDs), "started", started)
+	return nil
+}
+
+// FlightDepartureWorkflow takes one flight through departure
+//   - Closes sales SalesCloseBefore ahead of departure, so new orders and seat
+//     changes are refused
+//   - Expires the holds of orders that have not paid yet, releasing their seats
+//   - Bumps orders off the flight if it is oversold, in an
+//     OverbookingBumpWorkflow
+//   - Saves the passenger and seat manifest at departure
+//   - Marks the flight departed
+//
+// Delays move the departure time, so it is read again after every wait. A
+// flight cancelled before departure ends the workflow.
+func FlightDepartureWorkflow(ctx workflow.Context, input temporalpkg.FlightDepartureWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("FlightDepartureWorkflow started", "flightID", input.FlightID)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 5,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+	flight := activities.FlightDepartureInput{FlightID: input.FlightID}
+
+	// Phase 1: Close sales
+	due, err := waitForDeparture(ctx, input.FlightID, input.SalesCloseBefore)
+	if err != nil || !due {
+		return err
+	}
+
+	var a *activities.BookingActivities
+	if err := workflow.ExecuteActivity(ctx, a.CloseFlightSales, flight).Get(ctx, nil); err != nil {
+		logger.Error("Failed to close sales", "flightID", input.FlightID, "error", err)
+		return err
+	}
+
+	// Phase 2: Expire lingering holds; orders still creating theirs are
+	// refused by the seat reservation itself now that sales are closed
+	var orders activities.FindFlightOrdersOutput
+	err = workflow.ExecuteActivity(ctx, a.FindFlightOrders, activities.FindFlightOrdersInput{
+		FlightID: input.FlightID,
+	}).Get(ctx, &orders)
+	if err != nil {
+		logger.Error("Failed to find open orders", "flightID", input.FlightID, "error", err)
+		return err
+	}
+	for _, order := range orders.Orders {
+		if order.Status != domain.OrderStatusSeatsReserved && order.Status != domain.OrderStatusPaymentPending {
+			continue
+		}
+		// The booking may have finished since it was read
+		err := workflow.SignalExternalWorkflow(ctx, "booking-"+order.OrderID, "", temporalpkg.SignalCloseSales, nil).Get(ctx, nil)
+		if err != nil {
+			logger.Warn("Failed to expire booking on closed flight", "orderID", order.OrderID, "error", err)
+		}
+	}
+
+	// Phase 3: Reaccommodate orders the flight has no seats for
+	if workflow.GetVersion(ctx, changeDepartureBump, workflow.DefaultVersion, departureBumpVersion) >= 1 {
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: "bump-" + input.FlightID,
+		})
+		err := workflow.ExecuteChildWorkflow(childCtx, OverbookingBumpWorkflow, temporalpkg.OverbookingBumpWorkflowInput{
+			FlightID: input.FlightID,
+			Policy:   input.Bump,
+		}).Get(ctx, nil)
+		if err != nil {
+			logger.Error("Failed to bump orders off oversold flight", "flightID", input.FlightID, "error", err)
+			return err
+		}
+	}
+
+	// Phase 4: Manifest and departure
+	due, err = waitForDeparture(ctx, input.FlightID, 0)
+	if err != nil || !due {
+		return err
+	}
+
+	var manifest activities.BuildFlightManifestOutput
+	if err := workflow.ExecuteActivity(ctx, a.BuildFlightManifest, flight).Get(ctx, &manifest); err != nil {
+		logger.Error("Failed to build manifest", "flightID", input.FlightID, "error", err)
+		return err
+	}
+
+	var departed activities.MarkFlightDepartedOutput
+	if err := workflow.ExecuteActivity(ctx, a.MarkFlightDeparted, flight).Get(ctx, &departed); err != nil {
+		logger.Error("Failed to mark flight departed", "flightID", input.FlightID, "error", err)
+		return err
+	}
+
+	logger.Info("Flight departed", "flightID", input.FlightID, "departed", departed.Departed,
+		"passengers", manifest.Passengers, "seatless", manifest.SeatlessCount)
+	return nil
+}
+
+// waitForDeparture sleeps until before ahead of the flight's departure,
+// reading the departure time again after each sleep in case a delay moved
+// it. It reports false when the flight was cancelled or has already departed.
+func waitForDeparture(ctx workflow.Context, flightID string, before time.Duration) (bool, error) {
+	var a *activities.BookingActivities
+	for {
+		var departure activities.GetFlightDepartureOutput
+		err := workflow.ExecuteActivity(ctx, a.GetFlightDeparture, activities.FlightDepartureInput{
+			FlightID: flightID,
+		}).Get(ctx, &departure)
+		if err != nil {
+			return false, err
+		}
+		if departure.Status == domain.FlightStatusCancelled || departure.Status == domain.FlightStatusDeparted {
+			workflow.GetLogger(ctx).Info("Flight no longer departing", "flightID", flightID, "status", departure.Status)
+			return false, nil
+		}
+
+		wait := departure.DepartureTime.Add(-before).Sub(workflow.Now(ctx))
+		if wait <= 0 {
+			return true, nil
+		}
+		if err := workflow.Sleep(ctx, wait); err != nil {
+			return false, err
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestFlightDepartureWorkflow_ClosesSalesAndDeparts(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.OverbookingBumpWorkflow)
+
+	start := env.Now()
+	departure := start.Add(3 * time.Hour)
+	var closedAt, departedAt time.Time
+
+	// A 30 minute delay lands while the workflow waits to close sales
+	env.RegisterDelayedCallback(func() {
+		departure = departure.Add(30 * time.Minute)
+	}, 30*time.Minute)
+
+	env.OnActivity(a.GetFlightDeparture, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		func(ctx context.Context, in activities.FlightDepartureInput) (activities.GetFlightDepartureOutput, error) {
+			return activities.GetFlightDepartureOutput{DepartureTime: departure, Status: domain.FlightStatusScheduled}, nil
+		})
+	env.OnActivity(a.CloseFlightSales, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		func(ctx context.Context, in activities.FlightDepartureInput) error {
+			closedAt = env.Now()
+			return nil
+		}).Once()
+	env.OnActivity(a.FindFlightOrders, mock.Anything, activities.FindFlightOrdersInput{FlightID: "flight-1"}).Return(activities.FindFlightOrdersOutput{
+		Orders: []activities.FlightOrder{
+			{OrderID: "order-held", Status: domain.OrderStatusSeatsReserved},
+			{OrderID: "order-paying", Status: domain.OrderStatusPaymentPending},
+			{OrderID: "order-paid", Status: domain.OrderStatusConfirmed},
+		},
+	}, nil).Once()
+	env.OnSignalExternalWorkflow(mock.Anything, "booking-order-held", "", temporalpkg.SignalCloseSales, mock.Anything).Return(nil).Once()
+	env.OnSignalExternalWorkflow(mock.Anything, "booking-order-paying", "", temporalpkg.SignalCloseSales, mock.Anything).Return(nil).Once()
+	env.OnActivity(a.FindOverbookedOrders, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		activities.FindOverbookedOrdersOutput{}, nil).Once()
+	env.OnActivity(a.BuildFlightManifest, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		func(ctx context.Context, in activities.FlightDepartureInput) (activities.BuildFlightManifestOutput, error) {
+			departedAt = env.Now()
+			return activities.BuildFlightManifestOutput{Passengers: 2}, nil
+		}).Once()
+	env.OnActivity(a.MarkFlightDeparted, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		activities.MarkFlightDepartedOutput{Departed: true}, nil).Once()
+
+	env.ExecuteWorkflow(workflows.FlightDepartureWorkflow, temporalpkg.FlightDepartureWorkflowInput{
+		FlightID:         "flight-1",
+		SalesCloseBefore: 2 * time.Hour,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+
+	// Both waits follow the delayed departure
+	require.Equal(t, 90*time.Minute, closedAt.Sub(start).Round(time.Minute))
+	require.Equal(t, 210*time.Minute, departedAt.Sub(start).Round(time.Minute))
+}
+
+func TestFlightDepartureWorkflow_CancelledFlightStops(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.GetFlightDeparture, mock.Anything, mock.Anything).Return(activities.GetFlightDepartureOutput{
+		DepartureTime: env.Now().Add(time.Hour),
+		Status:        domain.FlightStatusCancelled,
+	}, nil).Once()
+
+	env.ExecuteWorkflow(workflows.FlightDepartureWorkflow, temporalpkg.FlightDepartureWorkflowInput{
+		FlightID:         "flight-2",
+		SalesCloseBefore: 2 * time.Hour,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertActivityNotCalled(t, "CloseFlightSales", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "MarkFlightDeparted", mock.Anything, mock.Anything)
+}
+
+func TestDepartureSchedulerWorkflow_StartsDepartureWorkflows(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.RegisterWorkflow(workflows.FlightDepartureWorkflow)
+
+	policy := domain.BumpPolicy{CompensationCents: 30000, RebookWindow: 24 * time.Hour}
+	env.OnActivity(a.FindDepartingFlights, mock.Anything, activities.FindDepartingFlightsInput{Horizon: 24 * time.Hour}).Return(
+		activities.FindDepartingFlightsOutput{FlightIDs: []string{"flight-1", "flight-2"}}, nil).Once()
+	for _, flightID := range []string{"flight-1", "flight-2"} {
+		env.OnWorkflow(workflows.FlightDepartureWorkflow, mock.Anything, temporalpkg.FlightDepartureWorkflowInput{
+			FlightID:         flightID,
+			SalesCloseBefore: 2 * time.Hour,
+			Bump:             policy,
+		}).Return(nil).Once()
+	}
+
+	env.ExecuteWorkflow(workflows.DepartureSchedulerWorkflow, temporalpkg.DepartureSchedulerWorkflowInput{
+		Horizon:          24 * time.Hour,
+		SalesCloseBefore: 2 * time.Hour,
+		Bump:             policy,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
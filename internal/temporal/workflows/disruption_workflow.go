@@ -0,0 +1,95 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// DisruptionWorkflow simulates weather and ATC disruptions on a cron schedule
+//   - Delays or cancels upcoming flights at random under the policy
+//   - Cancels the booking workflows of orders still booking a cancelled flight,
+//     which releases their seats
+//   - Notifies confirmed orders and publishes FLIGHT_DISRUPTED for them
+func DisruptionWorkflow(ctx workflow.Context, input temporalpkg.DisruptionWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting disruption generator")
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	// A retried roll could disrupt the same flight twice
+	disruptCtx := workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{MaximumAttempts: 1})
+
+	var a *activities.BookingActivities
+	var output activities.DisruptFlightsOutput
+	err := workflow.ExecuteActivity(disruptCtx, a.DisruptFlights, activities.DisruptFlightsInput{
+		Policy:  input.Policy,
+		Horizon: input.Horizon,
+	}).Get(ctx, &output)
+	if err != nil {
+		logger.Error("Failed to disrupt flights", "error", err)
+		return err
+	}
+
+	for _, disruption := range output.Disruptions {
+		var orders activities.FindFlightOrdersOutput
+		err := workflow.ExecuteActivity(ctx, a.FindFlightOrders, activities.FindFlightOrdersInput{
+			FlightID: disruption.FlightID,
+		}).Get(ctx, &orders)
+		if err != nil {
+			logger.Error("Failed to find disrupted orders", "flightID", disruption.FlightID, "error", err)
+			continue
+		}
+
+		for _, order := range orders.Orders {
+			if order.Status == domain.OrderStatusConfirmed {
+				notifyDisruption(ctx, order.OrderID, disruption)
+				continue
+			}
+			if disruption.Status == domain.FlightStatusCancelled {
+				// The booking may have finished since it was read
+				err := workflow.SignalExternalWorkflow(ctx, "booking-"+order.OrderID, "", temporalpkg.SignalCancelBooking, nil).Get(ctx, nil)
+				if err != nil {
+					logger.Warn("Failed to cancel booking on cancelled flight", "orderID", order.OrderID, "error", err)
+				}
+			}
+		}
+	}
+
+	logger.Info("Completed disruption generator", "disruptions", len(output.Disruptions))
+	return nil
+}
+
+// notifyDisruption tells a confirmed order's passengers about the disruption;
+// a failed notification does not stop the run
+func notifyDisruption(ctx workflow.Context, orderID string, disruption domain.FlightDisruption) {
+	message := "Your flight has been cancelled"
+	if disruption.Status == domain.FlightStatusDelayed {
+		message = fmt.Sprintf("Your flight is delayed by %s", disruption.Delay)
+	}
+
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(ctx, a.NotifyOrder, activities.NotifyOrderInput{
+		OrderID:  orderID,
+		Category: domain.CategoryBooking,
+		Status:   domain.OrderStatusConfirmed,
+		Message:  message,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Failed to notify disruption", "orderID", orderID, "error", err)
+	}
+
+	publishEvent(ctx, orderID, domain.EventFlightDisrupted)
+}
@@ -0,0 +1,78 @@
+package workflows_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestDisruptionWorkflow_CancelledFlightReleasesHoldsAndNotifiesConfirmed(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.DisruptFlights, mock.Anything, mock.Anything).Return(activities.DisruptFlightsOutput{
+		Disruptions: []domain.FlightDisruption{
+			{FlightID: "flight-1", Status: domain.FlightStatusCancelled},
+		},
+	}, nil).Once()
+	env.OnActivity(a.FindFlightOrders, mock.Anything, activities.FindFlightOrdersInput{FlightID: "flight-1"}).Return(activities.FindFlightOrdersOutput{
+		Orders: []activities.FlightOrder{
+			{OrderID: "order-held", Status: domain.OrderStatusSeatsReserved},
+			{OrderID: "order-paid", Status: domain.OrderStatusConfirmed},
+		},
+	}, nil).Once()
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.MatchedBy(func(in activities.NotifyOrderInput) bool {
+		return in.OrderID == "order-paid"
+	})).Return(nil).Once()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, activities.PublishOrderEventInput{
+		OrderID: "order-paid",
+		Event:   domain.EventFlightDisrupted,
+	}).Return(nil).Once()
+	env.OnSignalExternalWorkflow(mock.Anything, "booking-order-held", "", temporalpkg.SignalCancelBooking, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.DisruptionWorkflow, temporalpkg.DisruptionWorkflowInput{
+		Policy:  domain.DisruptionPolicy{Probability: 1, CancelShare: 1, MaxDelay: time.Hour},
+		Horizon: 48 * time.Hour,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
+
+func TestDisruptionWorkflow_DelayLeavesHoldsAlone(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.DisruptFlights, mock.Anything, mock.Anything).Return(activities.DisruptFlightsOutput{
+		Disruptions: []domain.FlightDisruption{
+			{FlightID: "flight-2", Status: domain.FlightStatusDelayed, Delay: 45 * time.Minute},
+		},
+	}, nil).Once()
+	env.OnActivity(a.FindFlightOrders, mock.Anything, mock.Anything).Return(activities.FindFlightOrdersOutput{
+		Orders: []activities.FlightOrder{{OrderID: "order-held", Status: domain.OrderStatusSeatsReserved}},
+	}, nil).Once()
+
+	env.ExecuteWorkflow(workflows.DisruptionWorkflow, temporalpkg.DisruptionWorkflowInput{
+		Policy:  domain.DisruptionPolicy{Probability: 1, MaxDelay: time.Hour},
+		Horizon: 48 * time.Hour,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertActivityNotCalled(t, "NotifyOrder", mock.Anything, mock.Anything)
+}
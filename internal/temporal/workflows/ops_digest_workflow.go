@@ -0,0 +1,59 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// OpsDigestWorkflow compiles the operational stats of the period ending now
+// and sends them to the ops address. The worker runs it on the ops-digest
+// Temporal Schedule. It returns the digest it sent.
+func OpsDigestWorkflow(ctx workflow.Context, input temporalpkg.OpsDigestWorkflowInput) (domain.OpsDigest, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	period := input.Period
+	if period <= 0 {
+		period = 24 * time.Hour
+	}
+	until := workflow.Now(ctx)
+
+	var a *activities.BookingActivities
+	var digest domain.OpsDigest
+	err := workflow.ExecuteActivity(ctx, a.CollectOpsDigest, activities.CollectOpsDigestInput{
+		Since: until.Add(-period),
+		Until: until,
+	}).Get(ctx, &digest)
+	if err != nil {
+		logger.Error("Failed to collect ops digest", "error", err)
+		return digest, err
+	}
+	digest.FlagSpike(input.SpikeFactor)
+
+	err = workflow.ExecuteActivity(ctx, a.SendOpsDigest, activities.SendOpsDigestInput{
+		Address:  input.Address,
+		Channels: input.Channels,
+		Digest:   digest,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to send ops digest", "error", err)
+		return digest, err
+	}
+
+	logger.Info("Sent ops digest", "expired", digest.ExpiredOrders, "paymentFailures", digest.PaymentFailures,
+		"spike", digest.PaymentFailureSpike, "lockRepairs", digest.ReconciliationFixes, "deadWebhooks", digest.DeadLetteredWebhooks)
+	return digest, nil
+}
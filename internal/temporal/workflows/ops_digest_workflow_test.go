@@ -0,0 +1,58 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestOpsDigestWorkflow_FlagsPaymentFailureSpike(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	var window activities.CollectOpsDigestInput
+	env.OnActivity(a.CollectOpsDigest, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, in activities.CollectOpsDigestInput) (domain.OpsDigest, error) {
+			window = in
+			return domain.OpsDigest{
+				Since: in.Since, Until: in.Until,
+				ExpiredOrders: 4, PaymentFailures: 12, PreviousPaymentFailures: 5,
+				ReconciliationFixes: 2, DeadLetteredWebhooks: 1,
+			}, nil
+		})
+
+	var sent activities.SendOpsDigestInput
+	env.OnActivity(a.SendOpsDigest, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, in activities.SendOpsDigestInput) (activities.NotifyOrderOutput, error) {
+			sent = in
+			return activities.NotifyOrderOutput{Channels: in.Channels}, nil
+		})
+
+	env.ExecuteWorkflow(workflows.OpsDigestWorkflow, temporalpkg.OpsDigestWorkflowInput{
+		Address:     "ops@example.com",
+		Channels:    []domain.NotificationChannel{domain.ChannelEmail},
+		Period:      24 * time.Hour,
+		SpikeFactor: 2,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Equal(t, 24*time.Hour, window.Until.Sub(window.Since))
+
+	require.Equal(t, "ops@example.com", sent.Address)
+	require.True(t, sent.Digest.PaymentFailureSpike)
+	require.Contains(t, sent.Digest.Summary(), "Payment failures: 12 (previous period 5) SPIKE")
+	require.Contains(t, sent.Digest.Summary(), "Dead-lettered webhooks: 1")
+}
@@ -0,0 +1,56 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// maxArchivalBatches bounds one archival run, and so its history; orders
+// left over are archived by the next run
+const maxArchivalBatches = 100
+
+// OrderArchivalWorkflow moves orders that finished more than the retention
+// period ago into orders_archive, a batch at a time, until a batch comes back
+// short. The worker runs it on the order-archival Temporal Schedule. It
+// returns the number of orders archived.
+func OrderArchivalWorkflow(ctx workflow.Context, input temporalpkg.OrderArchivalWorkflowInput) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	finishedBefore := workflow.Now(ctx).Add(-input.Retention)
+	archived := 0
+	var pruned int64
+	for batch := 0; batch < maxArchivalBatches; batch++ {
+		var output domain.OrderArchival
+		err := workflow.ExecuteActivity(ctx, a.ArchiveOrders, activities.ArchiveOrdersInput{
+			FinishedBefore: finishedBefore,
+			Limit:          input.BatchSize,
+		}).Get(ctx, &output)
+		if err != nil {
+			logger.Error("Failed to archive orders", "archived", archived, "error", err)
+			return archived, err
+		}
+		archived += output.Orders
+		pruned += output.Pruned
+		if output.Orders < input.BatchSize {
+			break
+		}
+	}
+
+	logger.Info("Archived finished orders", "archived", archived, "pruned", pruned, "finishedBefore", finishedBefore)
+	return archived, nil
+}
@@ -0,0 +1,65 @@
+package workflows_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestOrderArchivalWorkflow_ArchivesUntilShortBatch(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	retention := 30 * 24 * time.Hour
+	var cutoffs []time.Time
+	env.OnActivity(a.ArchiveOrders, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cutoffs = append(cutoffs, args.Get(1).(activities.ArchiveOrdersInput).FinishedBefore)
+	}).Return(domain.OrderArchival{Orders: 2, Pruned: 3}, nil).Twice()
+	env.OnActivity(a.ArchiveOrders, mock.Anything, mock.Anything).Return(domain.OrderArchival{Orders: 1}, nil).Once()
+
+	start := env.Now()
+	env.ExecuteWorkflow(workflows.OrderArchivalWorkflow, temporalpkg.OrderArchivalWorkflowInput{
+		Retention: retention,
+		BatchSize: 2,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var archived int
+	require.NoError(t, env.GetWorkflowResult(&archived))
+	require.Equal(t, 5, archived)
+	require.Len(t, cutoffs, 2)
+	require.Equal(t, cutoffs[0], cutoffs[1], "every batch uses the run's cutoff")
+	require.WithinDuration(t, start.Add(-retention), cutoffs[0], time.Minute)
+	env.AssertExpectations(t)
+}
+
+func TestOrderArchivalWorkflow_FailsOnArchiveError(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.ArchiveOrders, mock.Anything, mock.Anything).Return(domain.OrderArchival{}, errors.New("database unavailable"))
+
+	env.ExecuteWorkflow(workflows.OrderArchivalWorkflow, temporalpkg.OrderArchivalWorkflowInput{
+		Retention: time.Hour,
+		BatchSize: 100,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+}
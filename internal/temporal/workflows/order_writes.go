@@ -0,0 +1,65 @@
+package workflows
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// localWriteOptions bound the order writes a booking runs as local
+// activities. They are single-row updates, so a write that needs longer than
+// this is left to the regular activity.
+var localWriteOptions = workflow.LocalActivityOptions{
+	StartToCloseTimeout: 2 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    100 * time.Millisecond,
+		BackoffCoefficient: 2.0,
+		MaximumAttempts:    2,
+	},
+}
+
+// writeOrder runs a sub-second order write such as UpdateOrderStatus. Once
+// the booking runs local writes it executes in the workflow's own worker,
+// skipping the task queue round trip, and falls back to a regular activity on
+// orderCtx when the local one fails.
+func writeOrder(orderCtx workflow.Context, state *bookingState, activity, input interface{}) error {
+	if state.localWrites {
+		localCtx := workflow.WithLocalActivityOptions(orderCtx, localWriteOptions)
+		err := workflow.ExecuteLocalActivity(localCtx, activity, input).Get(localCtx, nil)
+		if _, conflict := orderConflict(err); err == nil || conflict || orderCtx.Err() != nil {
+			return err
+		}
+		workflow.GetLogger(orderCtx).Warn("Local order write failed, retrying as an activity", "orderID", state.orderID, "error", err)
+	}
+	return workflow.ExecuteActivity(orderCtx, activity, input).Get(orderCtx, nil)
+}
+
+// orderConflict reports the status an order write found the order finished
+// in, when it failed because something outside the workflow, such as an
+// admin tool, finished the order first
+func orderConflict(err error) (domain.OrderStatus, bool) {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) || appErr.Type() != temporalpkg.ErrTypeOrderConflict {
+		return "", false
+	}
+	var status domain.OrderStatus
+	if appErr.Details(&status) != nil {
+		return "", false
+	}
+	return status, true
+}
+
+// finishedElsewhere ends a booking whose order was finished outside it,
+// taking on the order's status rather than overwriting it; the error it
+// returns releases the booking's seats
+func finishedElsewhere(ctx workflow.Context, state *bookingState, status domain.OrderStatus, err error) error {
+	workflow.GetLogger(ctx).Warn("Order finished outside the booking", "orderID", state.orderID, "status", status)
+	state.status = status
+	state.lastError = "order is already " + string(status)
+	return err
+}
@@ -0,0 +1,101 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// OverbookingBumpWorkflow frees the seats an oversold flight is short before
+// departure, once sales have closed
+//   - Picks the most recently confirmed seatless orders until the capacity
+//     they hold covers the shortfall
+//   - Rebooks each on the earliest later flight on the route with seats for
+//     it, or refunds it when there is none, recording its compensation
+//   - Notifies the order and publishes ORDER_BUMPED
+//
+// It returns the number of orders bumped.
+func OverbookingBumpWorkflow(ctx workflow.Context, input temporalpkg.OverbookingBumpWorkflowInput) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 5,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var overbooked activities.FindOverbookedOrdersOutput
+	err := workflow.ExecuteActivity(ctx, a.FindOverbookedOrders, activities.FlightDepartureInput{
+		FlightID: input.FlightID,
+	}).Get(ctx, &overbooked)
+	if err != nil {
+		logger.Error("Failed to find overbooked orders", "flightID", input.FlightID, "error", err)
+		return 0, err
+	}
+	if overbooked.Oversold <= 0 {
+		return 0, nil
+	}
+
+	selected := domain.SelectBumps(overbooked.Candidates, overbooked.Oversold)
+	bumped := 0
+	for _, candidate := range selected {
+		var output activities.BumpOrderOutput
+		err := workflow.ExecuteActivity(ctx, a.BumpOrder, activities.BumpOrderInput{
+			OrderID: candidate.OrderID,
+			Policy:  input.Policy,
+		}).Get(ctx, &output)
+		if err != nil {
+			logger.Error("Failed to bump order", "orderID", candidate.OrderID, "error", err)
+			return bumped, err
+		}
+		if output.Bump == nil {
+			continue
+		}
+
+		bumped++
+		notifyBump(ctx, output)
+	}
+
+	logger.Info("Bumped orders off oversold flight", "flightID", input.FlightID,
+		"oversold", overbooked.Oversold, "bumped", bumped)
+	return bumped, nil
+}
+
+// notifyBump tells a bumped order where it now flies, or that it was
+// refunded, and what it is compensated; a failed notification does not stop
+// the run
+func notifyBump(ctx workflow.Context, output activities.BumpOrderOutput) {
+	bump := output.Bump
+	compensation := fmt.Sprintf("%d.%02d", bump.CompensationCents/100, bump.CompensationCents%100)
+
+	status := domain.OrderStatusConfirmed
+	message := fmt.Sprintf("Your flight is overbooked. You have been moved to flight %s departing %s and will receive %s in compensation",
+		output.AlternativeFlight, output.AlternativeDeparture.UTC().Format("2006-01-02 15:04 MST"), compensation)
+	if bump.Status == domain.BumpStatusRefunded {
+		status = domain.OrderStatusPaymentRefunded
+		message = fmt.Sprintf("Your flight is overbooked and no later flight had room. Your fare is refunded and you will receive %s in compensation",
+			compensation)
+	}
+
+	var a *activities.BookingActivities
+	err := workflow.ExecuteActivity(ctx, a.NotifyOrder, activities.NotifyOrderInput{
+		OrderID:  bump.OrderID,
+		Category: domain.CategoryBooking,
+		Status:   status,
+		Message:  message,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Failed to notify bump", "orderID", bump.OrderID, "error", err)
+	}
+
+	publishEvent(ctx, bump.OrderID, domain.EventOrderBumped)
+}
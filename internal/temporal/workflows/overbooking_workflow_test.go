@@ -0,0 +1,91 @@
+package workflows_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestOverbookingBumpWorkflow_BumpsLatestOrders(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	confirmed := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	policy := domain.BumpPolicy{CompensationCents: 30000, RebookWindow: 24 * time.Hour}
+	alternative := "flight-2"
+
+	env.OnActivity(a.FindOverbookedOrders, mock.Anything, activities.FlightDepartureInput{FlightID: "flight-1"}).Return(
+		activities.FindOverbookedOrdersOutput{
+			Oversold: 3,
+			Candidates: []domain.BumpCandidate{
+				{OrderID: "order-early", Seats: 2, ConfirmedAt: confirmed},
+				{OrderID: "order-late", Seats: 2, ConfirmedAt: confirmed.Add(2 * time.Hour)},
+				{OrderID: "order-middle", Seats: 1, ConfirmedAt: confirmed.Add(time.Hour)},
+			},
+		}, nil).Once()
+	env.OnActivity(a.BumpOrder, mock.Anything, activities.BumpOrderInput{OrderID: "order-late", Policy: policy}).Return(
+		activities.BumpOrderOutput{
+			Bump: &domain.Bump{
+				OrderID: "order-late", FlightID: "flight-1", Seats: 2, Status: domain.BumpStatusRebooked,
+				AlternativeFlightID: &alternative, CompensationCents: 60000,
+			},
+			AlternativeFlight:    "FB102",
+			AlternativeDeparture: confirmed.Add(48 * time.Hour),
+		}, nil).Once()
+	env.OnActivity(a.BumpOrder, mock.Anything, activities.BumpOrderInput{OrderID: "order-middle", Policy: policy}).Return(
+		activities.BumpOrderOutput{
+			Bump: &domain.Bump{
+				OrderID: "order-middle", FlightID: "flight-1", Seats: 1, Status: domain.BumpStatusRefunded,
+				CompensationCents: 60000,
+			},
+		}, nil).Once()
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.MatchedBy(func(in activities.NotifyOrderInput) bool {
+		return in.OrderID == "order-late" && in.Status == domain.OrderStatusConfirmed
+	})).Return(activities.NotifyOrderOutput{}, nil).Once()
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.MatchedBy(func(in activities.NotifyOrderInput) bool {
+		return in.OrderID == "order-middle" && in.Status == domain.OrderStatusPaymentRefunded
+	})).Return(activities.NotifyOrderOutput{}, nil).Once()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.MatchedBy(func(in activities.PublishOrderEventInput) bool {
+		return in.Event == domain.EventOrderBumped
+	})).Return(nil).Twice()
+
+	env.ExecuteWorkflow(workflows.OverbookingBumpWorkflow, temporalpkg.OverbookingBumpWorkflowInput{
+		FlightID: "flight-1",
+		Policy:   policy,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var bumped int
+	require.NoError(t, env.GetWorkflowResult(&bumped))
+	require.Equal(t, 2, bumped)
+	env.AssertExpectations(t)
+}
+
+func TestOverbookingBumpWorkflow_NotOversold(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.FindOverbookedOrders, mock.Anything, mock.Anything).Return(
+		activities.FindOverbookedOrdersOutput{Oversold: -4}, nil).Once()
+
+	env.ExecuteWorkflow(workflows.OverbookingBumpWorkflow, temporalpkg.OverbookingBumpWorkflowInput{FlightID: "flight-1"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertActivityNotCalled(t, "BumpOrder", mock.Anything, mock.Anything)
+}
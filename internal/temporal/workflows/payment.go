@@ -0,0 +1,320 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// maxPaymentAttempts bounds the manual payment retry loop
+const maxPaymentAttempts = 3
+
+// paymentCallbackTimeout bounds the wait for a gateway that settles a
+// payment out of band; an attempt it never confirms counts as failed
+const paymentCallbackTimeout = 10 * time.Minute
+
+// A payment challenge waits paymentChallengeTimeout for the customer's
+// one-time code and takes up to maxChallengeAttempts codes before the payment
+// is declined
+const (
+	paymentChallengeTimeout = 5 * time.Minute
+	maxChallengeAttempts    = 3
+)
+
+// paymentActivityOptions disables automatic retries; validatePayment retries
+// manually so attempts show up in status queries. ValidatePayment heartbeats
+// each second, so an attempt on a lost worker fails after a few seconds
+// rather than the full timeout.
+var paymentActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 10 * time.Second,
+	HeartbeatTimeout:    3 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		MaximumAttempts: 1,
+		NonRetryableErrorTypes: []string{
+			temporalpkg.ErrTypeInvalidPaymentCode,
+			temporalpkg.ErrTypePaymentDeclined,
+			temporalpkg.ErrTypeChallengeFailed,
+		},
+	},
+}
+
+// validatePayment validates a payment code with a manual retry loop (3
+// attempts max), recording progress in attempts and lastError, and each
+// attempt in history unless it is nil. It returns nil as soon as one attempt
+// succeeds. An attempt left pending by the gateway succeeds once its
+// payment-result signal does, and one the issuer challenges once the customer
+// answers with the right one-time code; onChallenge, unless nil, is told when
+// the challenge opens, changes and closes. A non-nil seed fixes the simulated
+// outcome of each attempt. A non-zero amountCents takes that part of the
+// order's price, such as a deposit, instead of all of it. The code is paid
+// with method, card when it is empty.
+func validatePayment(ctx, paymentCtx workflow.Context, orderID string, method domain.PaymentMethod, code string, amountCents int64, seed *int64, attempts *int, lastError *string, history *[]temporalpkg.PaymentAttempt, onChallenge func(*domain.PaymentChallenge)) error {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+	var paymentResult activities.ValidatePaymentOutput
+	var err error
+
+	for attempt := 1; attempt <= maxPaymentAttempts; attempt++ {
+		*attempts = attempt
+		logger.Info("Payment validation attempt", "attempt", attempt, "maxAttempts", maxPaymentAttempts)
+		record := temporalpkg.PaymentAttempt{Attempt: attempt, StartedAt: workflow.Now(ctx)}
+
+		err = workflow.ExecuteActivity(paymentCtx, a.ValidatePayment, activities.ValidatePaymentInput{
+			OrderID:     orderID,
+			Method:      method,
+			PaymentCode: code,
+			AmountCents: amountCents,
+			Attempt:     attempt,
+			Seed:        seed,
+		}).Get(paymentCtx, &paymentResult)
+		if err == nil && paymentResult.Pending {
+			logger.Info("Awaiting payment result from gateway", "attempt", attempt, "authorization", paymentResult.AuthorizationID)
+			err = awaitPaymentResult(ctx, paymentResult.AuthorizationID)
+		}
+		if err == nil && paymentResult.ChallengeRequired {
+			logger.Info("Awaiting payment challenge response", "attempt", attempt, "authorization", paymentResult.AuthorizationID)
+			err = awaitChallenge(ctx, paymentCtx, orderID, paymentResult.AuthorizationID, onChallenge)
+		}
+		if history != nil {
+			if err != nil {
+				record.Error = gatewayMessage(err)
+			}
+			*history = append(*history, record)
+		}
+
+		if err == nil {
+			logger.Info("Payment validation succeeded", "attempt", attempt)
+			return nil
+		}
+
+		logger.Warn("Payment validation failed", "attempt", attempt, "error", err)
+
+		// Only our defined non-retryable types end the loop early
+		var appErr *temporal.ApplicationError
+		if paymentDeclined(err) && errors.As(err, &appErr) {
+			logger.Error("Payment validation failed with non-retryable error", "type", appErr.Type())
+			*lastError = "payment failed: " + appErr.Message()
+			return err
+		}
+
+		// Retryable error - wait before next attempt (exponential backoff)
+		if attempt < maxPaymentAttempts {
+			backoffDuration := time.Second * time.Duration(attempt) // 1s, 2s
+			*lastError = fmt.Sprintf("payment failed (attempt %d of %d): %s", attempt, maxPaymentAttempts, err.Error())
+			logger.Info("Waiting before retry", "backoff", backoffDuration)
+			_ = workflow.Sleep(ctx, backoffDuration)
+		} else {
+			*lastError = fmt.Sprintf("payment failed after %d attempts: %s", maxPaymentAttempts, err.Error())
+		}
+	}
+
+	return err
+}
+
+// awaitPaymentResult waits for the payment-result signal settling the
+// authorization. Results for other authorizations, such as a late one from
+// an earlier attempt, are ignored. A declined payment fails with
+// PAYMENT_DECLINED; a failed or unconfirmed one with a retryable error.
+func awaitPaymentResult(ctx workflow.Context, authorizationID string) error {
+	logger := workflow.GetLogger(ctx)
+	results := workflow.GetSignalChannel(ctx, temporalpkg.SignalPaymentResult)
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+	timer := workflow.NewTimer(timerCtx, paymentCallbackTimeout)
+
+	for {
+		var result temporalpkg.PaymentResultSignal
+		timedOut := false
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(results, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, &result)
+		})
+		selector.AddFuture(timer, func(f workflow.Future) {
+			timedOut = true
+		})
+		selector.Select(ctx)
+
+		if timedOut {
+			return fmt.Errorf("payment not confirmed by the gateway within %s", paymentCallbackTimeout)
+		}
+		if result.AuthorizationID != authorizationID {
+			logger.Warn("Ignoring payment result for another authorization", "authorization", result.AuthorizationID, "awaiting", authorizationID)
+			continue
+		}
+
+		switch result.Status {
+		case temporalpkg.PaymentResultSucceeded:
+			return nil
+		case temporalpkg.PaymentResultDeclined:
+			return temporalpkg.NewPaymentDeclinedError("payment declined: " + result.Error)
+		default:
+			return fmt.Errorf("payment failed at the gateway: %s", result.Error)
+		}
+	}
+}
+
+// awaitChallenge waits for challenge-response signals answering the
+// authorization's challenge and completes it with their codes. A wrong code
+// leaves the challenge open until maxChallengeAttempts codes failed; those,
+// or no answer within paymentChallengeTimeout, decline the payment. The
+// authorization is never captured then, so the issuer lets it lapse.
+func awaitChallenge(ctx, paymentCtx workflow.Context, orderID, authorizationID string, onChallenge func(*domain.PaymentChallenge)) error {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+	responses := workflow.GetSignalChannel(ctx, temporalpkg.SignalChallengeResponse)
+
+	challenge := &domain.PaymentChallenge{
+		AuthorizationID: authorizationID,
+		ExpiresAt:       workflow.Now(ctx).Add(paymentChallengeTimeout),
+		AttemptsLeft:    maxChallengeAttempts,
+	}
+	report := func(c *domain.PaymentChallenge) {
+		if onChallenge != nil {
+			onChallenge(c)
+		}
+	}
+	report(challenge)
+	defer report(nil)
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+	timer := workflow.NewTimer(timerCtx, paymentChallengeTimeout)
+
+	for challenge.AttemptsLeft > 0 {
+		var response temporalpkg.ChallengeResponseSignal
+		timedOut := false
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(responses, func(c workflow.ReceiveChannel, more bool) {
+			c.Receive(ctx, &response)
+		})
+		selector.AddFuture(timer, func(f workflow.Future) {
+			timedOut = true
+		})
+		selector.Select(ctx)
+
+		if timedOut {
+			return temporalpkg.NewPaymentDeclinedError(fmt.Sprintf("payment challenge not answered within %s", paymentChallengeTimeout))
+		}
+
+		err := workflow.ExecuteActivity(paymentCtx, a.CompletePaymentChallenge, activities.CompletePaymentChallengeInput{
+			OrderID:         orderID,
+			AuthorizationID: authorizationID,
+			Code:            response.Code,
+		}).Get(paymentCtx, nil)
+		var appErr *temporal.ApplicationError
+		if !errors.As(err, &appErr) || appErr.Type() != temporalpkg.ErrTypeChallengeFailed {
+			return err
+		}
+
+		challenge.AttemptsLeft--
+		logger.Info("Payment challenge code rejected", "authorization", authorizationID, "attemptsLeft", challenge.AttemptsLeft)
+		if challenge.AttemptsLeft > 0 {
+			report(challenge)
+		}
+	}
+
+	return temporalpkg.NewPaymentDeclinedError("payment challenge failed: too many wrong codes")
+}
+
+// validatePaymentWithin runs validatePayment bounded by window, canceling
+// the attempt in progress and returning temporalpkg.ErrPaymentTimeout when the
+// window elapses first. A zero window leaves payment unbounded.
+func validatePaymentWithin(ctx, paymentCtx workflow.Context, window time.Duration, orderID string, method domain.PaymentMethod, code string, amountCents int64, seed *int64, attempts *int, lastError *string, history *[]temporalpkg.PaymentAttempt, onChallenge func(*domain.PaymentChallenge)) error {
+	if window <= 0 {
+		return validatePayment(ctx, paymentCtx, orderID, method, code, amountCents, seed, attempts, lastError, history, onChallenge)
+	}
+
+	attemptCtx, cancelAttempts := workflow.WithCancel(ctx)
+	defer cancelAttempts()
+	options := workflow.GetActivityOptions(paymentCtx)
+
+	done, settle := workflow.NewFuture(ctx)
+	workflow.Go(attemptCtx, func(gCtx workflow.Context) {
+		gPaymentCtx := workflow.WithActivityOptions(gCtx, options)
+		settle.Set(nil, validatePayment(gCtx, gPaymentCtx, orderID, method, code, amountCents, seed, attempts, lastError, history, onChallenge))
+	})
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	var err error
+	timedOut := false
+	selector := workflow.NewSelector(ctx)
+	selector.AddFuture(done, func(f workflow.Future) {
+		err = f.Get(ctx, nil)
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, window), func(f workflow.Future) {
+		timedOut = true
+	})
+	selector.Select(ctx)
+	if !timedOut {
+		return err
+	}
+
+	// Wait for the canceled attempts to wind down so they leave lastError alone
+	cancelAttempts()
+	_ = done.Get(ctx, nil)
+	workflow.GetLogger(ctx).Warn("Payment window elapsed", "window", window, "attempts", *attempts)
+	*lastError = fmt.Sprintf("payment not completed within %s", window)
+	return temporalpkg.ErrPaymentTimeout
+}
+
+// gatewayMessage is the payment provider's own message for a failed attempt,
+// without the activity error wrapped around it
+func gatewayMessage(err error) string {
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.Message()
+	}
+	return err.Error()
+}
+
+// paymentDeclined reports whether err rejects the payment itself, so retrying
+// the same code cannot succeed but another payment might
+func paymentDeclined(err error) bool {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) {
+		return false
+	}
+	return appErr.Type() == temporalpkg.ErrTypeInvalidPaymentCode || appErr.Type() == temporalpkg.ErrTypePaymentDeclined
+}
+
+// refundPayment voids the payment of an order that failed to confirm and
+// records the order as refunded. It reports false when the payment could not
+// be voided, leaving the order to be failed with the payment kept.
+func refundPayment(ctx workflow.Context, state *bookingState, method domain.PaymentMethod, code string) bool {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+
+	err := workflow.ExecuteActivity(ctx, a.RefundPayment, activities.RefundPaymentInput{
+		OrderID:     state.orderID,
+		Method:      method,
+		PaymentCode: code,
+		AmountCents: state.paidCents(),
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to refund payment", "orderID", state.orderID, "error", err)
+		state.lastError += "; refund failed: " + err.Error()
+		return false
+	}
+
+	state.status = domain.OrderStatusPaymentRefunded
+	logger.Info("Payment refunded after failed confirmation", "orderID", state.orderID, "amountCents", state.paidCents())
+
+	err = workflow.ExecuteActivity(ctx, a.RefundOrder, activities.RefundOrderInput{
+		OrderID: state.orderID,
+		Reason:  state.lastError,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to record refunded order", "orderID", state.orderID, "error", err)
+	}
+	return true
+}
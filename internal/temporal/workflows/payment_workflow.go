@@ -0,0 +1,144 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// paymentWorkflowGrace is how long a PaymentWorkflow may outlive its payment
+// window, winding down canceled attempts, before the server times it out
+const paymentWorkflowGrace = time.Minute
+
+// PaymentWorkflow takes one payment for a booking in a history of its own,
+// so the payment can be retried or reset without touching the booking's seats
+//   - Validates the payment code, retrying up to 3 attempts with backoff
+//   - Waits for the customer's one-time code when the issuer challenges the
+//     payment, which the booking forwards as challenge-response
+//   - Gives up when the payment window elapses first
+//   - Reports each attempt, the last error and the provider's last message
+//     through the payment-status query
+//
+// A failed payment returns a non-retryable error typed PAYMENT_DECLINED,
+// INVALID_PAYMENT_CODE, PAYMENT_TIMEOUT or PAYMENT_FAILED, with the
+// PaymentWorkflowResult as its details.
+func PaymentWorkflow(ctx workflow.Context, input temporalpkg.PaymentWorkflowInput) (temporalpkg.PaymentWorkflowResult, error) {
+	status := temporalpkg.PaymentStatusResponse{
+		OrderID:     input.OrderID,
+		MaxAttempts: maxPaymentAttempts,
+	}
+	if err := workflow.SetQueryHandler(ctx, temporalpkg.QueryPaymentStatus, func() (temporalpkg.PaymentStatusResponse, error) {
+		response := status
+		for i := len(status.History) - 1; i >= 0; i-- {
+			if status.History[i].Error != "" {
+				response.LastGatewayError = status.History[i].Error
+				break
+			}
+		}
+		return response, nil
+	}); err != nil {
+		return temporalpkg.PaymentWorkflowResult{}, err
+	}
+
+	options := paymentActivityOptions
+	options.TaskQueue = input.TaskQueue
+	paymentCtx := workflow.WithActivityOptions(ctx, options)
+
+	// The booking shows the challenge in its own status
+	onChallenge := func(challenge *domain.PaymentChallenge) {
+		status.Challenge = challenge
+		parent := workflow.GetInfo(ctx).ParentWorkflowExecution
+		if parent == nil {
+			return
+		}
+		err := workflow.SignalExternalWorkflow(ctx, parent.ID, "", temporalpkg.SignalPaymentChallenge, temporalpkg.PaymentChallengeSignal{
+			Challenge: challenge,
+		}).Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to report payment challenge to booking", "error", err)
+		}
+	}
+
+	err := validatePaymentWithin(ctx, paymentCtx, input.Window, input.OrderID, input.Method, input.PaymentCode, input.AmountCents, input.Seed, &status.Attempts, &status.LastError, &status.History, onChallenge)
+	result := temporalpkg.PaymentWorkflowResult{Attempts: status.Attempts, LastError: status.LastError}
+	if err == nil || ctx.Err() != nil {
+		return result, err
+	}
+
+	errType := temporalpkg.ErrTypePaymentFailed
+	var appErr *temporal.ApplicationError
+	switch {
+	case errors.Is(err, temporalpkg.ErrPaymentTimeout):
+		errType = temporalpkg.ErrTypePaymentTimeout
+	case paymentDeclined(err) && errors.As(err, &appErr):
+		errType = appErr.Type()
+	}
+	return result, temporal.NewNonRetryableApplicationError(result.LastError, errType, err, result)
+}
+
+// processPayment takes a booking's payment in a PaymentWorkflow child and
+// copies its attempts and last error into state once it ends. Its errors
+// match validatePaymentWithin's: paymentDeclined reports declined payments
+// and an elapsed window is temporalpkg.ErrPaymentTimeout. A windowed child
+// is also timed out by the server, so a payment no worker picks up still
+// ends.
+func processPayment(ctx workflow.Context, state *bookingState, input temporalpkg.PaymentWorkflowInput) error {
+	state.payments++
+	options := workflow.ChildWorkflowOptions{
+		WorkflowID: fmt.Sprintf("%s-payment-%d", workflow.GetInfo(ctx).WorkflowExecution.ID, state.payments),
+	}
+	if input.Window > 0 {
+		options.WorkflowExecutionTimeout = input.Window + paymentWorkflowGrace
+	}
+	childCtx := workflow.WithChildOptions(ctx, options)
+
+	// While the child runs, its payment challenge shows in the booking's
+	// status and the customer's answers to it are forwarded
+	future := workflow.ExecuteChildWorkflow(childCtx, PaymentWorkflow, input)
+	challenges := workflow.GetSignalChannel(ctx, temporalpkg.SignalPaymentChallenge)
+	responses := workflow.GetSignalChannel(ctx, temporalpkg.SignalChallengeResponse)
+
+	var result temporalpkg.PaymentWorkflowResult
+	var err error
+	for done := false; !done; {
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(future, func(f workflow.Future) {
+			err = f.Get(ctx, &result)
+			done = true
+		})
+		selector.AddReceive(challenges, func(c workflow.ReceiveChannel, more bool) {
+			var update temporalpkg.PaymentChallengeSignal
+			c.Receive(ctx, &update)
+			state.paymentChallenge = update.Challenge
+		})
+		selector.AddReceive(responses, func(c workflow.ReceiveChannel, more bool) {
+			var response temporalpkg.ChallengeResponseSignal
+			c.Receive(ctx, &response)
+			future.SignalChildWorkflow(ctx, temporalpkg.SignalChallengeResponse, response)
+		})
+		selector.Select(ctx)
+	}
+	state.paymentChallenge = nil
+
+	var appErr *temporal.ApplicationError
+	if err != nil && errors.As(err, &appErr) && appErr.HasDetails() {
+		_ = appErr.Details(&result)
+	}
+	state.paymentAttempts = result.Attempts
+	state.lastError = result.LastError
+
+	if temporal.IsTimeoutError(err) {
+		state.lastError = fmt.Sprintf("payment not completed within %s", input.Window)
+		return temporalpkg.ErrPaymentTimeout
+	}
+	if appErr != nil && appErr.Type() == temporalpkg.ErrTypePaymentTimeout {
+		return temporalpkg.ErrPaymentTimeout
+	}
+	return err
+}
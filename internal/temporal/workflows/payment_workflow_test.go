@@ -0,0 +1,233 @@
+package workflows_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestPaymentWorkflow_QueryReportsAttempts(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{}, errors.New("payment gateway timeout"),
+	).Once()
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "12345",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.PaymentWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, 2, result.Attempts)
+
+	encoded, err := env.QueryWorkflow(temporalpkg.QueryPaymentStatus)
+	require.NoError(t, err)
+	var status temporalpkg.PaymentStatusResponse
+	require.NoError(t, encoded.Get(&status))
+	require.Equal(t, "test-order-pay", status.OrderID)
+	require.Equal(t, 2, status.Attempts)
+	require.Equal(t, 3, status.MaxAttempts)
+	require.Contains(t, status.LastError, "payment gateway timeout")
+	require.Equal(t, "payment gateway timeout", status.LastGatewayError)
+	require.Len(t, status.History, 2)
+	require.Equal(t, "payment gateway timeout", status.History[0].Error)
+	require.Equal(t, 2, status.History[1].Attempt)
+	require.Empty(t, status.History[1].Error)
+}
+
+func TestPaymentWorkflow_DeclinedCarriesResult(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{}, temporal.NewNonRetryableApplicationError(
+			"card declined", temporalpkg.ErrTypePaymentDeclined, nil,
+		),
+	).Once()
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "11111",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &appErr)
+	require.Equal(t, temporalpkg.ErrTypePaymentDeclined, appErr.Type())
+	require.True(t, appErr.NonRetryable())
+
+	var result temporalpkg.PaymentWorkflowResult
+	require.NoError(t, appErr.Details(&result))
+	require.Equal(t, 1, result.Attempts)
+	require.Equal(t, "payment failed: card declined", result.LastError)
+	env.AssertExpectations(t)
+}
+
+func TestPaymentWorkflow_PendingPaymentAwaitsCallback(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Pending: true, AuthorizationID: "auth-2"}, nil,
+	).Once()
+
+	env.RegisterDelayedCallback(func() {
+		// A late result for another authorization is ignored
+		env.SignalWorkflow(temporalpkg.SignalPaymentResult, temporalpkg.PaymentResultSignal{
+			AuthorizationID: "auth-1", Status: temporalpkg.PaymentResultDeclined,
+		})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalPaymentResult, temporalpkg.PaymentResultSignal{
+			AuthorizationID: "auth-2", Status: temporalpkg.PaymentResultSucceeded,
+		})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "12345",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.PaymentWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, 1, result.Attempts)
+	env.AssertExpectations(t)
+}
+
+func TestPaymentWorkflow_PendingPaymentDeclinedByCallback(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Pending: true, AuthorizationID: "auth-1"}, nil,
+	).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalPaymentResult, temporalpkg.PaymentResultSignal{
+			AuthorizationID: "auth-1", Status: temporalpkg.PaymentResultDeclined, Error: "insufficient funds",
+		})
+	}, time.Second)
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "12345",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &appErr)
+	require.Equal(t, temporalpkg.ErrTypePaymentDeclined, appErr.Type())
+
+	var result temporalpkg.PaymentWorkflowResult
+	require.NoError(t, appErr.Details(&result))
+	require.Equal(t, 1, result.Attempts)
+	require.Equal(t, "payment failed: payment declined: insufficient funds", result.LastError)
+	env.AssertExpectations(t)
+}
+
+func TestPaymentWorkflow_ChallengeAnsweredWithOneTimeCode(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{ChallengeRequired: true, AuthorizationID: "auth-1"}, nil,
+	).Once()
+	env.OnActivity(a.CompletePaymentChallenge, mock.Anything, activities.CompletePaymentChallengeInput{
+		OrderID: "test-order-pay", AuthorizationID: "auth-1", Code: "000000",
+	}).Return(activities.ValidatePaymentOutput{}, temporalpkg.NewChallengeFailedError("code does not match")).Once()
+	env.OnActivity(a.CompletePaymentChallenge, mock.Anything, activities.CompletePaymentChallengeInput{
+		OrderID: "test-order-pay", AuthorizationID: "auth-1", Code: "123456",
+	}).Return(activities.ValidatePaymentOutput{Success: true}, nil).Once()
+
+	var open, afterWrongCode temporalpkg.PaymentStatusResponse
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryPaymentStatus)
+		require.NoError(t, err)
+		require.NoError(t, encoded.Get(&open))
+		env.SignalWorkflow(temporalpkg.SignalChallengeResponse, temporalpkg.ChallengeResponseSignal{Code: "000000"})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		encoded, err := env.QueryWorkflow(temporalpkg.QueryPaymentStatus)
+		require.NoError(t, err)
+		require.NoError(t, encoded.Get(&afterWrongCode))
+		env.SignalWorkflow(temporalpkg.SignalChallengeResponse, temporalpkg.ChallengeResponseSignal{Code: "123456"})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "33333",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.NotNil(t, open.Challenge)
+	require.Equal(t, "auth-1", open.Challenge.AuthorizationID)
+	require.Equal(t, 3, open.Challenge.AttemptsLeft)
+	require.NotNil(t, afterWrongCode.Challenge)
+	require.Equal(t, 2, afterWrongCode.Challenge.AttemptsLeft)
+
+	encoded, err := env.QueryWorkflow(temporalpkg.QueryPaymentStatus)
+	require.NoError(t, err)
+	var done temporalpkg.PaymentStatusResponse
+	require.NoError(t, encoded.Get(&done))
+	require.Nil(t, done.Challenge)
+	env.AssertExpectations(t)
+}
+
+func TestPaymentWorkflow_UnansweredChallengeDeclines(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{ChallengeRequired: true, AuthorizationID: "auth-1"}, nil,
+	).Once()
+
+	env.ExecuteWorkflow(workflows.PaymentWorkflow, temporalpkg.PaymentWorkflowInput{
+		OrderID:     "test-order-pay",
+		PaymentCode: "33333",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &appErr)
+	require.Equal(t, temporalpkg.ErrTypePaymentDeclined, appErr.Type())
+
+	var result temporalpkg.PaymentWorkflowResult
+	require.NoError(t, appErr.Details(&result))
+	require.Equal(t, 1, result.Attempts)
+	require.Contains(t, result.LastError, "payment challenge not answered")
+	env.AssertExpectations(t)
+}
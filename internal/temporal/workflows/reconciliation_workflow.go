@@ -9,15 +9,20 @@ import (
 	"github.com/flight-booking-system/internal/temporal/activities"
 )
 
-// SeatReconciliationWorkflow reconciles Redis locks with DB seat status
-// This workflow runs on a cron schedule to clean up orphaned locks
+// SeatReconciliationWorkflow reconciles Redis locks with DB seat status and
+// repairs flights whose seat counts drifted from their seats.
+// The worker runs it on the seat-reconciliation Temporal Schedule to clean up
+// orphaned locks
 func SeatReconciliationWorkflow(ctx workflow.Context) error {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting seat reconciliation workflow")
 
-	// Activity options for reconciliation
+	// Activity options for reconciliation. ReconcileSeatLocks heartbeats
+	// each lock it handles, so a lost worker is noticed quickly and the
+	// retry resumes where it stopped; finished flights are never repeated.
 	ao := workflow.ActivityOptions{
 		StartToCloseTimeout: 30 * time.Second,
+		HeartbeatTimeout:    10 * time.Second,
 		RetryPolicy: &temporal.RetryPolicy{
 			MaximumAttempts: 3,
 		},
@@ -55,6 +60,17 @@ func SeatReconciliationWorkflow(ctx workflow.Context) error {
 		logger.Info("Successfully reconciled locks for flight", "flightID", flightID)
 	}
 
+	// Seat counts are derived by the database; anything that drifted anyway
+	// is recounted
+	if workflow.GetVersion(ctx, changeReconcileSeatCounts, workflow.DefaultVersion, reconcileSeatCountsVersion) >= reconcileSeatCountsVersion {
+		var repaired activities.RepairSeatCountsOutput
+		if err := workflow.ExecuteActivity(ctx, "RepairSeatCounts").Get(ctx, &repaired); err != nil {
+			logger.Error("Failed to repair seat counts", "error", err)
+		} else if len(repaired.FlightIDs) > 0 {
+			logger.Warn("Repaired drifted seat counts", "flights", len(repaired.FlightIDs))
+		}
+	}
+
 	logger.Info("Completed seat reconciliation workflow")
 	return nil
 }
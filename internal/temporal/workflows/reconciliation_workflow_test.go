@@ -0,0 +1,44 @@
+package workflows_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestSeatReconciliationWorkflow_RepairsSeatCounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		repairErr error
+	}{
+		{"repairs drifted flights", nil},
+		{"repair failure does not fail the run", errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSuite := &testsuite.WorkflowTestSuite{}
+			env := testSuite.NewTestWorkflowEnvironment()
+
+			var a *activities.BookingActivities
+			env.RegisterActivity(a)
+
+			env.OnActivity(a.GetAllFlightIDs, mock.Anything).Return([]string{"f1", "f2"}, nil).Once()
+			env.OnActivity(a.ReconcileSeatLocks, mock.Anything, mock.Anything).Return(nil).Twice()
+			env.OnActivity(a.RepairSeatCounts, mock.Anything).
+				Return(activities.RepairSeatCountsOutput{FlightIDs: []string{"f2"}}, tt.repairErr)
+
+			env.ExecuteWorkflow(workflows.SeatReconciliationWorkflow)
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.NoError(t, env.GetWorkflowError())
+			env.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// RefundWorkflow returns a confirmed order's payment at an operator's request
+// - Records a pending refund of the order's full price
+// - Refunds the payment through the payment provider
+// - On success marks the order refunded and puts its seats back on sale;
+// on failure records the provider's error and leaves the order confirmed
+func RefundWorkflow(ctx workflow.Context, input temporalpkg.RefundWorkflowInput) (domain.Refund, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("RefundWorkflow started", "orderID", input.OrderID)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumInterval:    10 * time.Second,
+			MaximumAttempts:    3,
+			NonRetryableErrorTypes: []string{
+				temporalpkg.ErrTypeNotRefundable,
+				temporalpkg.ErrTypeVersionConflict,
+				temporalpkg.ErrTypePaymentDeclined,
+				temporalpkg.ErrTypeInvalidPaymentCode,
+			},
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var begun activities.BeginRefundOutput
+	err := workflow.ExecuteActivity(ctx, a.BeginRefund, activities.BeginRefundInput{
+		OrderID: input.OrderID,
+		Reason:  input.Reason,
+		Version: input.Version,
+	}).Get(ctx, &begun)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	var refund domain.Refund
+	err = workflow.ExecuteActivity(ctx, a.RefundPayment, activities.RefundPaymentInput{
+		OrderID:     input.OrderID,
+		Method:      begun.Method,
+		PaymentCode: begun.PaymentCode,
+		AmountCents: begun.AmountCents,
+		RefundID:    begun.RefundID,
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Refund not completed by the payment provider", "orderID", input.OrderID, "error", err)
+		failErr := workflow.ExecuteActivity(ctx, a.FailRefund, activities.FailRefundInput{
+			RefundID: begun.RefundID,
+			Error:    gatewayMessage(err),
+		}).Get(ctx, nil)
+		if failErr != nil {
+			return domain.Refund{}, failErr
+		}
+		return domain.Refund{}, temporalpkg.NewRefundFailedError(gatewayMessage(err))
+	}
+
+	err = workflow.ExecuteActivity(ctx, a.CompleteRefund, activities.CompleteRefundInput{
+		RefundID: begun.RefundID,
+		OrderID:  input.OrderID,
+	}).Get(ctx, &refund)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	logger.Info("Order refunded", "orderID", input.OrderID, "refundID", refund.ID)
+	return refund, nil
+}
@@ -0,0 +1,92 @@
+package workflows_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestRefundWorkflow_RefundsAndRecordsOrder(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.BeginRefund, mock.Anything, activities.BeginRefundInput{OrderID: "order-1", Reason: "duplicate booking"}).Return(
+		activities.BeginRefundOutput{RefundID: "refund-1", PaymentCode: "00000", AmountCents: 25000}, nil,
+	).Once()
+	env.OnActivity(a.RefundPayment, mock.Anything, activities.RefundPaymentInput{
+		OrderID: "order-1", PaymentCode: "00000", AmountCents: 25000, RefundID: "refund-1",
+	}).Return(nil).Once()
+	env.OnActivity(a.CompleteRefund, mock.Anything, activities.CompleteRefundInput{RefundID: "refund-1", OrderID: "order-1"}).Return(
+		domain.Refund{ID: "refund-1", OrderID: "order-1", AmountCents: 25000, Status: domain.RefundSucceeded}, nil,
+	).Once()
+
+	env.ExecuteWorkflow(workflows.RefundWorkflow, temporalpkg.RefundWorkflowInput{OrderID: "order-1", Reason: "duplicate booking"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var refund domain.Refund
+	require.NoError(t, env.GetWorkflowResult(&refund))
+	require.Equal(t, domain.RefundSucceeded, refund.Status)
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "FailRefund", mock.Anything, mock.Anything)
+}
+
+func TestRefundWorkflow_ProviderFailureLeavesOrderConfirmed(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.BeginRefund, mock.Anything, mock.Anything).Return(
+		activities.BeginRefundOutput{RefundID: "refund-2", PaymentCode: "00000", AmountCents: 25000}, nil,
+	).Once()
+	env.OnActivity(a.RefundPayment, mock.Anything, mock.Anything).Return(
+		temporalpkg.NewPaymentDeclinedError("card closed"),
+	).Once()
+	env.OnActivity(a.FailRefund, mock.Anything, activities.FailRefundInput{RefundID: "refund-2", Error: "card closed"}).Return(
+		domain.Refund{ID: "refund-2", Status: domain.RefundFailed}, nil,
+	).Once()
+
+	env.ExecuteWorkflow(workflows.RefundWorkflow, temporalpkg.RefundWorkflowInput{OrderID: "order-2"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.True(t, errors.As(env.GetWorkflowError(), &appErr))
+	require.Equal(t, temporalpkg.ErrTypeRefundFailed, appErr.Type())
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "CompleteRefund", mock.Anything, mock.Anything)
+}
+
+func TestRefundWorkflow_UnconfirmedOrderIsNotRefunded(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.BeginRefund, mock.Anything, mock.Anything).Return(
+		activities.BeginRefundOutput{}, temporalpkg.NewNotRefundableError("order-3"),
+	).Once()
+
+	env.ExecuteWorkflow(workflows.RefundWorkflow, temporalpkg.RefundWorkflowInput{OrderID: "order-3"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.True(t, errors.As(env.GetWorkflowError(), &appErr))
+	require.Equal(t, temporalpkg.ErrTypeNotRefundable, appErr.Type())
+	env.AssertExpectations(t)
+	env.AssertActivityNotCalled(t, "RefundPayment", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,29 @@
+package workflows_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/worker"
+
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+// TestBookingWorkflow_ReplaysHistories replays exported histories of bookings
+// run by earlier versions of BookingWorkflow, so a change that would break
+// bookings still in flight fails here rather than in production
+func TestBookingWorkflow_ReplaysHistories(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "histories", "booking_*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			replayer := worker.NewWorkflowReplayer()
+			replayer.RegisterWorkflow(workflows.BookingWorkflow)
+
+			require.NoError(t, replayer.ReplayWorkflowHistoryFromJSONFile(nil, file))
+		})
+	}
+}
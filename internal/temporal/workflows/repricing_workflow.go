@@ -0,0 +1,36 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// RepricingWorkflow reprices every flight on sale from the dynamic pricing
+// curve. The worker runs it on the flight-repricing Temporal Schedule when
+// PRICING_RECOMPUTE is "schedule". It returns the number of flights repriced.
+func RepricingWorkflow(ctx workflow.Context) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var output activities.RepriceFlightsOutput
+	if err := workflow.ExecuteActivity(ctx, a.RepriceFlights).Get(ctx, &output); err != nil {
+		logger.Error("Failed to reprice flights", "error", err)
+		return 0, err
+	}
+
+	logger.Info("Repriced flights", "checked", output.Checked, "repriced", output.Repriced)
+	return output.Repriced, nil
+}
@@ -0,0 +1,51 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// SeatSwapWorkflow executes a matched seat swap offer. The exchange itself is a
+// single database transaction; the workflow retries it and records a failure
+// on the offer if the swap can no longer be applied.
+func SeatSwapWorkflow(ctx workflow.Context, input temporalpkg.SeatSwapWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("SeatSwapWorkflow started", "offerID", input.OfferID)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:        time.Second,
+			BackoffCoefficient:     2.0,
+			MaximumInterval:        10 * time.Second,
+			MaximumAttempts:        5,
+			NonRetryableErrorTypes: []string{temporalpkg.ErrTypeSwapNotAllowed},
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	swapErr := workflow.ExecuteActivity(ctx, a.ExecuteSeatSwap, activities.SeatSwapInput{
+		OfferID: input.OfferID,
+	}).Get(ctx, nil)
+	if swapErr == nil {
+		logger.Info("Seat swap completed", "offerID", input.OfferID)
+		return nil
+	}
+
+	logger.Error("Seat swap failed", "offerID", input.OfferID, "error", swapErr)
+	err := workflow.ExecuteActivity(ctx, a.FailSeatSwap, activities.FailSeatSwapInput{
+		OfferID: input.OfferID,
+		Reason:  swapErr.Error(),
+	}).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to record seat swap failure", "offerID", input.OfferID, "error", err)
+	}
+
+	return swapErr
+}
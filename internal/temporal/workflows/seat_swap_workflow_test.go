@@ -0,0 +1,49 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestSeatSwapWorkflow_Success(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.ExecuteSeatSwap, mock.Anything, mock.Anything).Return(nil)
+
+	env.ExecuteWorkflow(workflows.SeatSwapWorkflow, temporalpkg.SeatSwapWorkflowInput{OfferID: "offer-1"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+}
+
+func TestSeatSwapWorkflow_NotAllowedRecordsFailure(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	env.OnActivity(a.ExecuteSeatSwap, mock.Anything, mock.Anything).Return(
+		temporalpkg.NewSwapNotAllowedError("offer-2"),
+	).Once()
+	env.OnActivity(a.FailSeatSwap, mock.Anything, mock.MatchedBy(func(in activities.FailSeatSwapInput) bool {
+		return in.OfferID == "offer-2"
+	})).Return(nil).Once()
+
+	env.ExecuteWorkflow(workflows.SeatSwapWorkflow, temporalpkg.SeatSwapWorkflowInput{OfferID: "offer-2"})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+}
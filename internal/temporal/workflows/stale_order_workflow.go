@@ -0,0 +1,55 @@
+package workflows
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// StaleOrderSweepWorkflow expires orders left holding seats after their
+// booking workflow was terminated or lost, such as by a namespace reset, and
+// releases their seats. The worker runs it on the stale-order-sweep Temporal
+// Schedule. Orders whose workflow still runs are left to it. It returns the
+// number of orders expired.
+func StaleOrderSweepWorkflow(ctx workflow.Context, input temporalpkg.StaleOrderSweepWorkflowInput) (int, error) {
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var a *activities.BookingActivities
+	var stale []activities.StaleOrder
+	err := workflow.ExecuteActivity(ctx, a.FindStaleOrders, activities.FindStaleOrdersInput{
+		Grace: input.Grace,
+		Limit: input.Limit,
+	}).Get(ctx, &stale)
+	if err != nil {
+		logger.Error("Failed to find stale orders", "error", err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, order := range stale {
+		var output activities.ExpireStaleOrderOutput
+		if err := workflow.ExecuteActivity(ctx, a.ExpireStaleOrder, order).Get(ctx, &output); err != nil {
+			logger.Error("Failed to expire stale order", "orderID", order.OrderID, "error", err)
+			// Continue with other orders; this one is found again next run
+			continue
+		}
+		if output.Expired {
+			expired++
+		}
+	}
+
+	logger.Info("Swept stale orders", "found", len(stale), "expired", expired)
+	return expired, nil
+}
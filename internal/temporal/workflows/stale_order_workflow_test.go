@@ -0,0 +1,47 @@
+package workflows_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func TestStaleOrderSweepWorkflow_ExpiresOrdersWithoutWorkflow(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+
+	lost := activities.StaleOrder{OrderID: "order-lost", FlightID: "flight-1", Seats: []string{"1A"}}
+	running := activities.StaleOrder{OrderID: "order-running", FlightID: "flight-1", Seats: []string{"1B"}}
+	broken := activities.StaleOrder{OrderID: "order-broken", FlightID: "flight-1", Seats: []string{"1C"}}
+
+	env.OnActivity(a.FindStaleOrders, mock.Anything, activities.FindStaleOrdersInput{Grace: 5 * time.Minute, Limit: 100}).Return(
+		[]activities.StaleOrder{lost, running, broken}, nil).Once()
+	env.OnActivity(a.ExpireStaleOrder, mock.Anything, lost).Return(
+		activities.ExpireStaleOrderOutput{Expired: true, SeatsReleased: 1}, nil).Once()
+	env.OnActivity(a.ExpireStaleOrder, mock.Anything, running).Return(
+		activities.ExpireStaleOrderOutput{}, nil).Once()
+	env.OnActivity(a.ExpireStaleOrder, mock.Anything, broken).Return(
+		activities.ExpireStaleOrderOutput{}, errors.New("redis unavailable"))
+
+	env.ExecuteWorkflow(workflows.StaleOrderSweepWorkflow, temporalpkg.StaleOrderSweepWorkflowInput{
+		Grace: 5 * time.Minute,
+		Limit: 100,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var expired int
+	require.NoError(t, env.GetWorkflowResult(&expired))
+	require.Equal(t, 1, expired)
+}
@@ -0,0 +1,344 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+)
+
+// TripWorkflow books a connecting itinerary as one order
+// - Starts a child BookingWorkflow per leg
+// - Takes payment once every leg holds its seats and validates it once
+// - Confirms each leg with a prepaid payment signal
+// - Cancels every open leg when any leg fails or expires, or on cancel
+// - Refunds the confirmed legs when another leg fails to confirm
+func TripWorkflow(ctx workflow.Context, input temporalpkg.TripWorkflowInput) (temporalpkg.TripWorkflowResult, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("TripWorkflow started", "tripID", input.TripID, "legs", len(input.Legs))
+
+	state := &tripState{
+		tripID: input.TripID,
+		status: domain.OrderStatusCreated,
+		legs:   make([]tripLeg, len(input.Legs)),
+	}
+	for i, leg := range input.Legs {
+		state.legs[i] = tripLeg{orderID: leg.OrderID, flightID: leg.FlightID, status: domain.OrderStatusCreated}
+	}
+
+	reservedChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalLegReserved)
+	paymentChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalProceedToPay)
+	cancelChan := workflow.GetSignalChannel(ctx, temporalpkg.SignalCancelBooking)
+
+	if err := workflow.SetQueryHandler(ctx, temporalpkg.QueryTripStatus, func() (temporalpkg.TripStatusResponse, error) {
+		return state.toStatusResponse(), nil
+	}); err != nil {
+		return state.toResult(), err
+	}
+
+	// Legs outlive a failed trip so each can finish its own compensation
+	selector := workflow.NewSelector(ctx)
+	children := make([]workflow.ChildWorkflowFuture, len(input.Legs))
+	for i, leg := range input.Legs {
+		leg.TripID = input.TripID
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID:        "booking-" + leg.OrderID,
+			ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+		})
+		children[i] = workflow.ExecuteChildWorkflow(childCtx, BookingWorkflow, leg)
+		selector.AddFuture(children[i], legDone(ctx, state, i))
+	}
+
+	var paymentSignal temporalpkg.PaymentSignal
+	paid := false
+	canceled := false
+
+	selector.AddReceive(reservedChan, func(c workflow.ReceiveChannel, more bool) {
+		var signal temporalpkg.LegReservedSignal
+		c.Receive(ctx, &signal)
+		if leg := state.leg(signal.OrderID); leg != nil && !leg.done {
+			leg.status = domain.OrderStatusSeatsReserved
+		}
+		if state.status == domain.OrderStatusCreated && state.allLegs(domain.OrderStatusSeatsReserved) {
+			logger.Info("All trip legs reserved")
+			state.status = domain.OrderStatusSeatsReserved
+		}
+	})
+
+	selector.AddReceive(paymentChan, func(c workflow.ReceiveChannel, more bool) {
+		var signal temporalpkg.PaymentSignal
+		c.Receive(ctx, &signal)
+		if state.status != domain.OrderStatusSeatsReserved || paid {
+			logger.Info("Ignoring trip payment", "status", state.status)
+			if state.status == domain.OrderStatusCreated {
+				state.lastError = "trip legs are still reserving seats"
+			}
+			return
+		}
+		paymentSignal = signal
+		paid = true
+	})
+
+	selector.AddReceive(cancelChan, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, nil)
+		logger.Info("Received trip cancel signal")
+		canceled = true
+	})
+
+	// Phase 1: wait for every leg to reserve and for payment; any leg ending
+	// early (failed reservation, expired hold) or a cancel fails the trip
+	for !paid && !canceled && !state.anyLegDone() {
+		selector.Select(ctx)
+	}
+
+	if !paid {
+		switch {
+		case canceled:
+			state.status = domain.OrderStatusFailed
+			state.lastError = "trip canceled by user"
+		case state.anyLeg(domain.OrderStatusExpired):
+			state.status = domain.OrderStatusExpired
+			state.lastError = "seat reservation expired"
+		default:
+			state.status = domain.OrderStatusFailed
+		}
+		abandonTrip(ctx, selector, state, children)
+		if canceled {
+			return state.toResult(), temporalpkg.ErrWorkflowCanceled
+		}
+		return state.toResult(), temporalpkg.ErrTripLegFailed
+	}
+
+	// Phase 2: validate payment once for the whole trip
+	state.status = domain.OrderStatusPaymentProcessing
+	paymentCtx := workflow.WithActivityOptions(ctx, paymentActivityOptions)
+	if err := validatePayment(ctx, paymentCtx, state.tripID, paymentSignal.Method, paymentSignal.PaymentCode, 0, nil, &state.paymentAttempts, &state.lastError, nil, func(challenge *domain.PaymentChallenge) {
+		state.paymentChallenge = challenge
+	}); err != nil {
+		state.status = domain.OrderStatusFailed
+		abandonTrip(ctx, selector, state, children)
+		return state.toResult(), err
+	}
+	state.lastError = ""
+
+	// A leg whose hold ran out during validation must not leave the others
+	// confirmed without it
+	for _, child := range children {
+		if child.IsReady() {
+			state.status = domain.OrderStatusFailed
+			abandonTrip(ctx, selector, state, children)
+			return state.toResult(), temporalpkg.ErrTripLegFailed
+		}
+	}
+
+	// Phase 3: confirm every leg
+	for i, child := range children {
+		if err := child.SignalChildWorkflow(ctx, temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{
+			PaymentCode: paymentSignal.PaymentCode,
+			Method:      paymentSignal.Method,
+			Prepaid:     true,
+		}).Get(ctx, nil); err != nil {
+			logger.Error("Failed to signal trip leg", "orderID", state.legs[i].orderID, "error", err)
+		}
+	}
+	awaitLegs(ctx, selector, state)
+
+	if !state.allLegs(domain.OrderStatusConfirmed) {
+		state.status = domain.OrderStatusFailed
+		logger.Error("Trip leg failed to confirm", "error", state.lastError)
+		if workflow.GetVersion(ctx, changeTripCompensation, workflow.DefaultVersion, tripCompensationVersion) >= 1 {
+			refundConfirmedLegs(ctx, state)
+		}
+		return state.toResult(), temporalpkg.ErrTripLegFailed
+	}
+
+	state.status = domain.OrderStatusConfirmed
+	logger.Info("Trip confirmed", "tripID", state.tripID)
+
+	drainSignals(ctx, reservedChan, paymentChan, cancelChan)
+
+	return state.toResult(), nil
+}
+
+// legDone records a finished child workflow on its leg
+func legDone(ctx workflow.Context, state *tripState, i int) func(workflow.Future) {
+	return func(f workflow.Future) {
+		leg := &state.legs[i]
+		var result temporalpkg.BookingWorkflowResult
+		err := f.Get(ctx, &result)
+		leg.done = true
+		if err == nil {
+			leg.status = result.Status
+			return
+		}
+
+		leg.status = domain.OrderStatusFailed
+		reason := err.Error()
+		var appErr *temporal.ApplicationError
+		if errors.As(err, &appErr) {
+			reason = appErr.Message()
+		}
+		if reason == temporalpkg.ErrReservationExpired.Error() || reason == temporalpkg.ErrPaymentTimeout.Error() {
+			leg.status = domain.OrderStatusExpired
+		}
+		if temporal.IsCanceledError(err) {
+			leg.status = domain.OrderStatusCancelled
+		}
+		if state.lastError == "" {
+			state.lastError = fmt.Sprintf("leg %s: %s", leg.orderID, reason)
+		}
+		workflow.GetLogger(ctx).Info("Trip leg ended", "orderID", leg.orderID, "status", leg.status)
+	}
+}
+
+// refundConfirmedLegs refunds every leg that confirmed on a trip whose other
+// legs did not, so the passenger is not left holding part of an itinerary.
+// Each runs a RefundWorkflow, which also puts the leg's seats back on sale; a
+// leg whose refund fails stays confirmed for an operator to settle.
+func refundConfirmedLegs(ctx workflow.Context, state *tripState) {
+	logger := workflow.GetLogger(ctx)
+
+	refunds := make(map[int]workflow.ChildWorkflowFuture)
+	for i, leg := range state.legs {
+		if leg.status != domain.OrderStatusConfirmed {
+			continue
+		}
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: "refund-" + leg.orderID,
+		})
+		refunds[i] = workflow.ExecuteChildWorkflow(childCtx, RefundWorkflow, temporalpkg.RefundWorkflowInput{
+			OrderID: leg.orderID,
+			Reason:  "trip failed: " + state.lastError,
+		})
+	}
+
+	for i := range state.legs {
+		refund, ok := refunds[i]
+		if !ok {
+			continue
+		}
+		leg := &state.legs[i]
+		if err := refund.Get(ctx, nil); err != nil {
+			logger.Error("Failed to refund confirmed trip leg", "orderID", leg.orderID, "error", err)
+			continue
+		}
+		leg.status = domain.OrderStatusPaymentRefunded
+	}
+}
+
+// abandonTrip cancels every leg still running and waits for all of them, so
+// each has released its seats by the time the trip completes
+func abandonTrip(ctx workflow.Context, selector workflow.Selector, state *tripState, children []workflow.ChildWorkflowFuture) {
+	for i, child := range children {
+		if state.legs[i].done || child.IsReady() {
+			continue
+		}
+		// The leg may finish on its own before the signal lands
+		if err := child.SignalChildWorkflow(ctx, temporalpkg.SignalCancelBooking, nil).Get(ctx, nil); err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to cancel trip leg", "orderID", state.legs[i].orderID, "error", err)
+		}
+	}
+	awaitLegs(ctx, selector, state)
+}
+
+// awaitLegs blocks until every child workflow has completed
+func awaitLegs(ctx workflow.Context, selector workflow.Selector, state *tripState) {
+	for !state.allLegsDone() {
+		selector.Select(ctx)
+	}
+}
+
+// tripLeg tracks one child booking
+type tripLeg struct {
+	orderID  string
+	flightID string
+	status   domain.OrderStatus
+	done     bool
+}
+
+// tripState tracks the internal trip workflow state
+type tripState struct {
+	tripID          string
+	status          domain.OrderStatus
+	legs            []tripLeg
+	paymentAttempts int
+	lastError       string
+
+	paymentChallenge *domain.PaymentChallenge
+}
+
+func (s *tripState) leg(orderID string) *tripLeg {
+	for i := range s.legs {
+		if s.legs[i].orderID == orderID {
+			return &s.legs[i]
+		}
+	}
+	return nil
+}
+
+func (s *tripState) allLegs(status domain.OrderStatus) bool {
+	for _, leg := range s.legs {
+		if leg.status != status {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *tripState) anyLeg(status domain.OrderStatus) bool {
+	for _, leg := range s.legs {
+		if leg.status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *tripState) anyLegDone() bool {
+	for _, leg := range s.legs {
+		if leg.done {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *tripState) allLegsDone() bool {
+	for _, leg := range s.legs {
+		if !leg.done {
+			return false
+		}
+	}
+	return true
+}
+
+// toStatusResponse converts state to query response
+func (s *tripState) toStatusResponse() temporalpkg.TripStatusResponse {
+	legs := make([]temporalpkg.TripLegStatus, len(s.legs))
+	for i, leg := range s.legs {
+		legs[i] = temporalpkg.TripLegStatus{OrderID: leg.orderID, FlightID: leg.flightID, Status: leg.status}
+	}
+	return temporalpkg.TripStatusResponse{
+		TripID:          s.tripID,
+		Status:          s.status,
+		Legs:            legs,
+		PaymentAttempts: s.paymentAttempts,
+		LastError:       s.lastError,
+
+		PaymentChallenge: s.paymentChallenge,
+	}
+}
+
+// toResult converts state to workflow result
+func (s *tripState) toResult() temporalpkg.TripWorkflowResult {
+	return temporalpkg.TripWorkflowResult{
+		TripID: s.tripID,
+		Status: s.status,
+		Error:  s.lastError,
+	}
+}
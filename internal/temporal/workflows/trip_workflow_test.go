@@ -0,0 +1,220 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+	"github.com/flight-booking-system/internal/temporal/workflows"
+)
+
+func newTripTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *activities.BookingActivities) {
+	t.Helper()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(workflows.BookingWorkflow)
+	env.RegisterWorkflow(workflows.RefundWorkflow)
+
+	var a *activities.BookingActivities
+	env.RegisterActivity(a)
+	env.OnActivity(a.NotifyOrder, mock.Anything, mock.Anything).Return(activities.NotifyOrderOutput{}, nil).Maybe()
+	env.OnActivity(a.PublishOrderEvent, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.GenerateBoardingPass, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.CreateOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.UpdateOrderStatus, mock.Anything, mock.Anything).Return(nil).Maybe()
+	env.OnActivity(a.FailOrder, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return env, a
+}
+
+var tripInput = temporalpkg.TripWorkflowInput{
+	TripID: "test-trip-1",
+	Legs: []temporalpkg.BookingWorkflowInput{
+		{OrderID: "leg-1", FlightID: "flight-1", Seats: []string{"1A"}},
+		{OrderID: "leg-2", FlightID: "flight-2", Seats: []string{"2A"}},
+	},
+}
+
+func TestTripWorkflow_ConfirmsAllLegs(t *testing.T) {
+	env, a := newTripTestEnv(t)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.TripWorkflow, tripInput)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result temporalpkg.TripWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, domain.OrderStatusConfirmed, result.Status)
+	env.AssertExpectations(t)
+}
+
+func TestTripWorkflow_LegFailureReleasesOtherLegs(t *testing.T) {
+	env, a := newTripTestEnv(t)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ReserveSeatInput) (activities.ReserveSeatsOutput, error) {
+			if input.OrderID == "leg-2" {
+				return activities.ReserveSeatsOutput{}, temporalpkg.NewSeatUnavailableError("2A")
+			}
+			return activities.ReserveSeatsOutput{}, nil
+		},
+	)
+
+	var released []string
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ReleaseSeatsInput) error {
+			released = append(released, input.OrderID)
+			return nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.TripWorkflow, tripInput)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), temporalpkg.ErrTripLegFailed.Error())
+	require.ElementsMatch(t, []string{"leg-1", "leg-2"}, released)
+	env.AssertActivityNotCalled(t, "ValidatePayment", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything)
+}
+
+func TestTripWorkflow_IgnoresDirectLegPayment(t *testing.T) {
+	env, a := newTripTestEnv(t)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.ExpireOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+
+	// Paying one leg directly must not confirm it on its own
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflowByID("booking-leg-1", temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.TripWorkflow, tripInput)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), temporalpkg.ErrTripLegFailed.Error())
+
+	value, err := env.QueryWorkflow(temporalpkg.QueryTripStatus)
+	require.NoError(t, err)
+	var status temporalpkg.TripStatusResponse
+	require.NoError(t, value.Get(&status))
+	require.Equal(t, domain.OrderStatusExpired, status.Status)
+	env.AssertActivityNotCalled(t, "ValidatePayment", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything)
+}
+
+func TestTripWorkflow_FailedLegRefundsItsShare(t *testing.T) {
+	env, a := newTripTestEnv(t)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ConfirmOrderInput) error {
+			if input.OrderID == "leg-2" {
+				return temporalpkg.NewDoubleBookingError("leg-2", domain.ErrDoubleBooking)
+			}
+			return nil
+		},
+	)
+
+	var refunded []string
+	env.OnActivity(a.RefundPayment, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.RefundPaymentInput) error {
+			require.Equal(t, "12345", input.PaymentCode)
+			require.Equal(t, int64(12000), input.AmountCents)
+			refunded = append(refunded, input.OrderID)
+			return nil
+		},
+	)
+	env.OnActivity(a.RefundOrder, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.BeginRefund, mock.Anything, mock.Anything).Return(
+		activities.BeginRefundOutput{RefundID: "refund-1", PaymentCode: "12345", AmountCents: 12000}, nil,
+	)
+	env.OnActivity(a.CompleteRefund, mock.Anything, mock.Anything).Return(domain.Refund{ID: "refund-1"}, nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	input := temporalpkg.TripWorkflowInput{TripID: tripInput.TripID}
+	for _, leg := range tripInput.Legs {
+		leg.Price = domain.PriceBreakdown{TotalCents: 12000}
+		leg.ConfirmCompensation = temporalpkg.CompensateRefund
+		input.Legs = append(input.Legs, leg)
+	}
+	env.ExecuteWorkflow(workflows.TripWorkflow, input)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), temporalpkg.ErrTripLegFailed.Error())
+	require.ElementsMatch(t, []string{"leg-1", "leg-2"}, refunded)
+}
+
+func TestTripWorkflow_LegConfirmFailureRefundsConfirmedLegs(t *testing.T) {
+	env, a := newTripTestEnv(t)
+	env.OnActivity(a.ReserveSeats, mock.Anything, mock.Anything).Return(activities.ReserveSeatsOutput{}, nil)
+	env.OnActivity(a.ReleaseSeats, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(a.ValidatePayment, mock.Anything, mock.Anything).Return(
+		activities.ValidatePaymentOutput{Success: true, Message: "OK"}, nil,
+	).Once()
+	env.OnActivity(a.ConfirmOrder, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.ConfirmOrderInput) error {
+			if input.OrderID == "leg-2" {
+				return temporalpkg.NewDoubleBookingError("leg-2", domain.ErrDoubleBooking)
+			}
+			return nil
+		},
+	)
+
+	// Leg 1 confirmed before leg 2 failed, so the trip refunds it
+	var begun []string
+	env.OnActivity(a.BeginRefund, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.BeginRefundInput) (activities.BeginRefundOutput, error) {
+			require.Contains(t, input.Reason, "trip failed")
+			begun = append(begun, input.OrderID)
+			return activities.BeginRefundOutput{RefundID: "refund-" + input.OrderID, PaymentCode: "12345", AmountCents: 12000}, nil
+		},
+	)
+	env.OnActivity(a.RefundPayment, mock.Anything, mock.Anything).Return(nil)
+	var completed []string
+	env.OnActivity(a.CompleteRefund, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, input activities.CompleteRefundInput) (domain.Refund, error) {
+			completed = append(completed, input.OrderID)
+			return domain.Refund{ID: input.RefundID, OrderID: input.OrderID}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(temporalpkg.SignalProceedToPay, temporalpkg.PaymentSignal{PaymentCode: "12345"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(workflows.TripWorkflow, tripInput)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.ErrorContains(t, env.GetWorkflowError(), temporalpkg.ErrTripLegFailed.Error())
+	require.Equal(t, []string{"leg-1"}, begun)
+	require.Equal(t, []string{"leg-1"}, completed)
+
+	value, err := env.QueryWorkflow(temporalpkg.QueryTripStatus)
+	require.NoError(t, err)
+	var status temporalpkg.TripStatusResponse
+	require.NoError(t, value.Get(&status))
+	require.Equal(t, domain.OrderStatusFailed, status.Status)
+	require.Equal(t, domain.OrderStatusPaymentRefunded, status.Legs[0].Status)
+	require.Equal(t, domain.OrderStatusFailed, status.Legs[1].Status)
+}
@@ -0,0 +1,115 @@
+package workflows
+
+import (
+	"slices"
+
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/flight-booking-system/internal/domain"
+	temporalpkg "github.com/flight-booking-system/internal/temporal"
+	"github.com/flight-booking-system/internal/temporal/activities"
+)
+
+// offerUpsell offers an order that has signaled payment an upgrade to a
+// higher cabin and priority boarding, and waits up to the policy's window
+// for the customer to answer on responses. Accepted extras are added to the
+// order's quote before payment is taken. A declined or expired offer, or an
+// upgrade whose seats were taken meanwhile, leaves the order as it was.
+func offerUpsell(ctx, seatCtx, orderCtx workflow.Context, state *bookingState, input temporalpkg.BookingWorkflowInput, responses workflow.ReceiveChannel) {
+	logger := workflow.GetLogger(ctx)
+	var a *activities.BookingActivities
+
+	var offer *domain.UpsellOffer
+	err := workflow.ExecuteActivity(orderCtx, a.FindUpsellOffer, activities.FindUpsellOfferInput{
+		FlightID: state.flightID,
+		Seats:    state.seats,
+		Policy:   input.Upsell,
+	}).Get(orderCtx, &offer)
+	if err != nil {
+		logger.Warn("Failed to find upsell offer", "error", err)
+		return
+	}
+	if offer == nil {
+		return
+	}
+
+	offer.ExpiresAt = workflow.Now(ctx).Add(input.Upsell.Window)
+	state.upsellOffer = offer
+	state.upsellResponses = responses
+	logger.Info("Upsell offered", "class", offer.Class, "seats", offer.Seats, "expiresAt", offer.ExpiresAt)
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	var response domain.UpsellResponse
+	answered := false
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(responses, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, &response)
+		answered = true
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, input.Upsell.Window), func(f workflow.Future) {})
+	selector.Select(ctx)
+
+	if !answered {
+		logger.Info("Upsell offer expired")
+		state.upsellOffer = nil
+		return
+	}
+
+	// The answer counts as pending until the order reflects it
+	applied := state.applying()
+	defer applied()
+	defer func() { state.upsellOffer = nil }()
+
+	seats := state.seats
+	price := state.price
+	if response.Upgrade && len(offer.Seats) > 0 {
+		var updated activities.UpdateSeatSelectionOutput
+		err := workflow.ExecuteActivity(seatCtx, a.UpdateSeatSelection, activities.UpdateSeatSelectionInput{
+			OrderID:   state.orderID,
+			FlightID:  state.flightID,
+			OldSeats:  state.seats,
+			NewSeats:  offer.Seats,
+			LockToken: state.lockToken,
+
+			HoldDuration: input.HoldDuration,
+		}).Get(seatCtx, &updated)
+		if err != nil {
+			logger.Warn("Upgrade seats no longer available; keeping the booked cabin", "error", err)
+		} else {
+			state.lockToken = updated.LockToken
+			seats = offer.Seats
+			price = price.WithUpsell(offer.UpgradeCents)
+		}
+	}
+	priorityBoarding := response.PriorityBoarding && offer.PriorityBoardingCents > 0
+	if priorityBoarding {
+		price = price.WithUpsell(offer.PriorityBoardingCents)
+	}
+	if slices.Equal(seats, state.seats) && !priorityBoarding {
+		logger.Info("Upsell offer declined")
+		return
+	}
+
+	if !slices.Equal(seats, state.seats) {
+		state.seats = seats
+		state.passengers = domain.AssignSeats(state.passengers, seats)
+		state.expiresAt = workflow.Now(ctx).Add(state.holdDuration) // the new seats' locks run from now
+	}
+	state.price = price
+
+	err = workflow.ExecuteActivity(orderCtx, a.RecordUpsell, activities.RecordUpsellInput{
+		OrderID:          state.orderID,
+		Seats:            state.seats,
+		ExpiresAt:        state.expiresAt,
+		Price:            state.price,
+		PriorityBoarding: priorityBoarding,
+	}).Get(orderCtx, nil)
+	if err != nil {
+		logger.Error("Failed to record upsell", "error", err)
+		return
+	}
+
+	logger.Info("Upsell accepted", "seats", state.seats, "priorityBoarding", priorityBoarding, "totalCents", state.price.TotalCents)
+}
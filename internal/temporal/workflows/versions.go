@@ -0,0 +1,65 @@
+package workflows
+
+import "go.temporal.io/sdk/workflow"
+
+// Change IDs of BookingWorkflow's decision points. Every booking records the
+// version it ran at each point, so a change that alters the activities,
+// timers or signals a point issues must not break replays of bookings still
+// in flight. To change a point:
+//
+//  1. Bump its version constant below
+//  2. Branch on the result of its GetVersion call: keep the old code for
+//     smaller versions and put the new code behind the new version
+//  3. Export histories of bookings run by the old code into
+//     testdata/histories (temporal workflow show --output json) and check
+//     TestBookingWorkflow_ReplaysHistories passes
+//
+// Bookings started before versioning replay as workflow.DefaultVersion,
+// which behaves as version 1 except at booking-input, where it skips the
+// validation and seat normalization added by version 1. Once no running booking reports an old
+// version in the TemporalChangeVersion search attribute, raise the point's
+// minimum supported version and delete the old branch.
+const (
+	changeInput        = "booking-input"
+	changeReserveSeats = "booking-reserve-seats"
+	changeHoldSeats    = "booking-hold-seats"
+	changePayment      = "booking-payment"
+	changeConfirm      = "booking-confirm"
+	changeCompensation = "booking-compensation"
+	changeLocalWrites  = "booking-local-writes"
+)
+
+// Current versions of BookingWorkflow's decision points
+const (
+	inputVersion        workflow.Version = 1
+	reserveSeatsVersion workflow.Version = 1
+	holdSeatsVersion    workflow.Version = 3 // 2: long holds continue as new; 3: expiry reminders
+	paymentVersion      workflow.Version = 5 // 2: declined payments return to PAYMENT_PENDING; 3: upsell offer; 4: payment window; 5: PaymentWorkflow child
+	confirmVersion      workflow.Version = 5 // 2: refund the payment when confirmation fails; 3: confirmation saga; 4: one-transaction confirmation; 5: trip legs refund their share
+	compensationVersion workflow.Version = 2 // 2: operator cancellation ends the order CANCELLED
+	localWritesVersion  workflow.Version = 1 // order status and seat writes run as local activities
+)
+
+// Change IDs and current versions of FlightDepartureWorkflow's decision
+// points, changed the same way as BookingWorkflow's
+const (
+	changeDepartureBump = "departure-bump"
+
+	departureBumpVersion workflow.Version = 1 // bump orders off oversold flights after sales close
+)
+
+// Change IDs and current versions of TripWorkflow's decision points, changed
+// the same way as BookingWorkflow's
+const (
+	changeTripCompensation = "trip-compensation"
+
+	tripCompensationVersion workflow.Version = 1 // refund confirmed legs when another leg fails to confirm
+)
+
+// Change IDs and current versions of SeatReconciliationWorkflow's decision
+// points, changed the same way as BookingWorkflow's
+const (
+	changeReconcileSeatCounts = "reconcile-seat-counts"
+
+	reconcileSeatCountsVersion workflow.Version = 1 // repair flights whose seat counts drifted
+)
@@ -0,0 +1,185 @@
+// Package timetable reads flight schedule files, in CSV or JSON, for bulk
+// import. Each row names an aircraft layout, whose seat map the flight gets.
+package timetable
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/inventory"
+	"github.com/flight-booking-system/internal/seed"
+)
+
+// csvHeader is the first line of every CSV schedule
+var csvHeader = []string{"flight_number", "origin", "destination", "departure_time", "arrival_time", "layout", "price_cents"}
+
+// Row is one flight of a schedule file. Times are RFC 3339.
+type Row struct {
+	FlightNumber  string `json:"flightNumber"`
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	DepartureTime string `json:"departureTime"`
+	ArrivalTime   string `json:"arrivalTime"`
+	Layout        string `json:"layout"`
+	PriceCents    int64  `json:"priceCents"`
+}
+
+// document is the JSON schedule layout
+type document struct {
+	Flights []Row `json:"flights"`
+}
+
+// RowError is one problem with one row of a schedule file
+type RowError struct {
+	Line    int    // CSV line, or 1-based position in the JSON flights list
+	Field   string // CSV column name
+	Message string
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s %s", e.Line, e.Field, e.Message)
+}
+
+var (
+	flightNumberPattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+	airportPattern      = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Read decodes and validates a schedule file. A file that cannot be decoded
+// fails with an error; otherwise every row that is invalid, or repeats an
+// earlier row's flight number, is reported as a RowError and the valid rows
+// are returned with their seat maps.
+func Read(r io.Reader, format inventory.Format) ([]domain.ScheduledFlight, []RowError, error) {
+	var rows []Row
+	first := 1
+	switch format {
+	case inventory.FormatJSON:
+		var doc document
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return nil, nil, fmt.Errorf("decode schedule json: %w", err)
+		}
+		rows = doc.Flights
+	case inventory.FormatCSV:
+		var err error
+		if rows, err = readCSV(r); err != nil {
+			return nil, nil, err
+		}
+		first = 2
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", inventory.ErrUnknownFormat, format)
+	}
+	if len(rows) == 0 {
+		return nil, nil, errors.New("schedule has no flights")
+	}
+
+	var flights []domain.ScheduledFlight
+	var rowErrors []RowError
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		line := first + i
+		flight, errs := validate(line, row)
+		if earlier, ok := seen[flight.Flight.FlightNumber]; ok && flight.Flight.FlightNumber != "" {
+			errs = append(errs, RowError{line, "flight_number", fmt.Sprintf("repeats line %d", earlier)})
+		} else {
+			seen[flight.Flight.FlightNumber] = line
+		}
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		flights = append(flights, flight)
+	}
+
+	return flights, rowErrors, nil
+}
+
+// validate checks one row and builds its flight and seat map
+func validate(line int, row Row) (domain.ScheduledFlight, []RowError) {
+	var errs []RowError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, RowError{line, field, fmt.Sprintf(format, args...)})
+	}
+
+	f := domain.Flight{
+		FlightNumber: strings.ToUpper(strings.TrimSpace(row.FlightNumber)),
+		Origin:       strings.ToUpper(strings.TrimSpace(row.Origin)),
+		Destination:  strings.ToUpper(strings.TrimSpace(row.Destination)),
+		PriceCents:   row.PriceCents,
+		Status:       domain.FlightStatusScheduled,
+	}
+	if !flightNumberPattern.MatchString(f.FlightNumber) {
+		fail("flight_number", "must be 2 to 10 letters and digits")
+	}
+	if !airportPattern.MatchString(f.Origin) {
+		fail("origin", "must be a 3-letter airport code")
+	}
+	if !airportPattern.MatchString(f.Destination) {
+		fail("destination", "must be a 3-letter airport code")
+	} else if f.Destination == f.Origin {
+		fail("destination", "must differ from origin")
+	}
+
+	var err error
+	departureOK, arrivalOK := true, true
+	if f.DepartureTime, err = time.Parse(time.RFC3339, strings.TrimSpace(row.DepartureTime)); err != nil {
+		fail("departure_time", "must be an RFC 3339 time")
+		departureOK = false
+	}
+	if f.ArrivalTime, err = time.Parse(time.RFC3339, strings.TrimSpace(row.ArrivalTime)); err != nil {
+		fail("arrival_time", "must be an RFC 3339 time")
+		arrivalOK = false
+	}
+	if departureOK && arrivalOK && !f.ArrivalTime.After(f.DepartureTime) {
+		fail("arrival_time", "must be after departure_time")
+	}
+	if f.PriceCents <= 0 {
+		fail("price_cents", "must be positive")
+	}
+
+	layout := strings.ToLower(strings.TrimSpace(row.Layout))
+	seats, ok := seed.SeatMap(layout)
+	if !ok {
+		fail("layout", "must be one of %s", strings.Join(seed.Layouts(), ", "))
+	}
+	f.TotalSeats, f.AvailableSeats = len(seats), len(seats)
+
+	return domain.ScheduledFlight{Flight: f, Seats: seats}, errs
+}
+
+func readCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read schedule csv: %w", err)
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(csvHeader, ",") {
+		return nil, fmt.Errorf("read schedule csv: header must be %s", strings.Join(csvHeader, ","))
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		// An unparseable price is left at zero for validate to report
+		price, _ := strconv.ParseInt(strings.TrimSpace(rec[6]), 10, 64)
+		rows = append(rows, Row{
+			FlightNumber:  rec[0],
+			Origin:        rec[1],
+			Destination:   rec[2],
+			DepartureTime: rec[3],
+			ArrivalTime:   rec[4],
+			Layout:        rec[5],
+			PriceCents:    price,
+		})
+	}
+	return rows, nil
+}
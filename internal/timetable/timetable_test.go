@@ -0,0 +1,81 @@
+package timetable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flight-booking-system/internal/inventory"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := `flight_number,origin,destination,departure_time,arrival_time,layout,price_cents
+fl301,jfk,lhr,2026-06-01T18:00:00Z,2026-06-02T06:00:00Z,widebody,65000
+FL302,LHR,LHR,2026-06-03T10:00:00Z,2026-06-03T09:00:00Z,jumbo,0
+FL301,BOS,NYC,2026-06-01T08:00:00Z,2026-06-01T09:00:00Z,regional,9000
+FL303,BOS,NYC,tomorrow,2026-06-01T09:00:00Z,regional,free
+`
+	flights, rowErrors, err := Read(strings.NewReader(input), inventory.FormatCSV)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	if len(flights) != 1 {
+		t.Fatalf("got %d valid flights, want 1", len(flights))
+	}
+	f := flights[0]
+	if f.Flight.FlightNumber != "FL301" || f.Flight.Origin != "JFK" || f.Flight.TotalSeats != len(f.Seats) || len(f.Seats) == 0 {
+		t.Errorf("flight = %+v with %d seats", f.Flight, len(f.Seats))
+	}
+
+	want := map[string]bool{
+		"line 3: destination must differ from origin":                                      true,
+		"line 3: arrival_time must be after departure_time":                                true,
+		"line 3: price_cents must be positive":                                             true,
+		"line 4: flight_number repeats line 2":                                             true,
+		"line 5: departure_time must be an RFC 3339 time":                                  true,
+		"line 5: price_cents must be positive":                                             true,
+		"line 3: layout must be one of regional, narrowbody, narrowbody-economy, widebody": true,
+	}
+	for _, e := range rowErrors {
+		if !want[e.Error()] {
+			t.Errorf("unexpected row error %q", e.Error())
+		}
+		delete(want, e.Error())
+	}
+	for missing := range want {
+		t.Errorf("missing row error %q", missing)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	input := `{"flights": [
+		{"flightNumber": "FL401", "origin": "SFO", "destination": "SEA", "departureTime": "2026-06-01T07:00:00-07:00",
+		 "arrivalTime": "2026-06-01T09:05:00-07:00", "layout": "narrowbody", "priceCents": 15900}
+	]}`
+	flights, rowErrors, err := Read(strings.NewReader(input), inventory.FormatJSON)
+	if err != nil || len(rowErrors) > 0 {
+		t.Fatalf("read: %v %v", err, rowErrors)
+	}
+	if len(flights) != 1 || flights[0].Flight.PriceCents != 15900 {
+		t.Errorf("flights = %+v", flights)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := map[string]struct {
+		format inventory.Format
+		input  string
+	}{
+		"missing header": {inventory.FormatCSV, "FL1,BOS,NYC,a,b,regional,1\n"},
+		"short line":     {inventory.FormatCSV, strings.Join(csvHeader, ",") + "\nFL1,BOS\n"},
+		"no flights":     {inventory.FormatJSON, `{"flights": []}`},
+		"bad json":       {inventory.FormatJSON, `{"flights": `},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := Read(strings.NewReader(tt.input), tt.format); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
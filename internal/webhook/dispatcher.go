@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+	"github.com/flight-booking-system/internal/repository"
+)
+
+// Headers set on every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// maxBackoff caps the delay between retries of one delivery
+const maxBackoff = time.Hour
+
+// Dispatcher drains the webhook outbox, POSTing signed payloads with retries
+type Dispatcher struct {
+	repo   *repository.WebhookRepo
+	client *http.Client
+	cfg    config.WebhookConfig
+}
+
+// NewDispatcher creates a new Dispatcher
+func NewDispatcher(repo *repository.WebhookRepo, cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:    cfg,
+	}
+}
+
+// Run polls for due deliveries until ctx is canceled
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.dispatchDue(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Webhook dispatch failed: %v", err)
+			}
+		}
+	}
+}
+
+// dispatchDue claims one batch of due deliveries and attempts each
+func (d *Dispatcher) dispatchDue(ctx context.Context) error {
+	// Lease long enough that a slow attempt isn't claimed twice
+	deliveries, err := d.repo.ClaimDue(ctx, d.cfg.BatchSize, 2*d.cfg.RequestTimeout)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.post(ctx, delivery); err != nil {
+			retryAt := d.nextAttempt(delivery.Attempts)
+			if retryAt == nil {
+				log.Printf("Webhook delivery %d failed permanently after %d attempts: %v", delivery.ID, delivery.Attempts, err)
+			}
+			if markErr := d.repo.MarkAttemptFailed(ctx, delivery.ID, err.Error(), retryAt); markErr != nil {
+				return markErr
+			}
+			continue
+		}
+		if err := d.repo.MarkDelivered(ctx, delivery.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextAttempt returns when to retry after attempts, or nil once attempts are exhausted
+func (d *Dispatcher) nextAttempt(attempts int) *time.Time {
+	if attempts >= d.cfg.MaxAttempts {
+		return nil
+	}
+
+	backoff := time.Duration(1<<min(attempts, 12)) * time.Second
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	retryAt := time.Now().Add(backoff)
+	return &retryAt
+}
+
+// post sends one delivery; any non-2xx response is a failure
+func (d *Dispatcher) post(ctx context.Context, delivery domain.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by the
+// hex HMAC-SHA256 of the raw body keyed with the subscription secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
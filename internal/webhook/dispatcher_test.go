@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flight-booking-system/internal/config"
+	"github.com/flight-booking-system/internal/domain"
+)
+
+func TestPostSignsPayload(t *testing.T) {
+	const secret = "s3cret"
+	payload := []byte(`{"event":"CONFIRMED"}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get(HeaderSignature), Sign(secret, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get(HeaderEvent); got != "CONFIRMED" {
+			t.Errorf("event header = %q", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher(nil, config.WebhookConfig{RequestTimeout: time.Second, MaxAttempts: 3})
+	err := d.post(context.Background(), domain.WebhookDelivery{
+		ID: 7, URL: srv.URL, Secret: secret, Event: domain.EventConfirmed, Payload: payload,
+	})
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+}
+
+func TestPostRejectsNon2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher(nil, config.WebhookConfig{RequestTimeout: time.Second, MaxAttempts: 3})
+	if err := d.post(context.Background(), domain.WebhookDelivery{URL: srv.URL}); err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+}
+
+func TestNextAttemptGivesUp(t *testing.T) {
+	d := NewDispatcher(nil, config.WebhookConfig{MaxAttempts: 3})
+
+	if d.nextAttempt(1) == nil || d.nextAttempt(2) == nil {
+		t.Fatal("expected retries before max attempts")
+	}
+	if d.nextAttempt(3) != nil {
+		t.Fatal("expected no retry once max attempts is reached")
+	}
+}
@@ -1,4 +1,4 @@
-const API_BASE = '/api';
+const API_BASE = '/api/v1';
 
 /**
  * Generic request helper
@@ -33,7 +33,7 @@ async function request(endpoint, options = {}) {
 
 /**
  * List all available flights
- * GET /api/flights
+ * GET /api/v1/flights
  */
 export async function fetchFlights() {
   const data = await request('/flights');
@@ -42,7 +42,7 @@ export async function fetchFlights() {
 
 /**
  * Get flight details with seat map
- * GET /api/flights/{flightId}
+ * GET /api/v1/flights/{flightId}
  */
 export async function fetchFlightDetails(flightId) {
   return request(`/flights/${flightId}`);
@@ -52,19 +52,19 @@ export async function fetchFlightDetails(flightId) {
 
 /**
  * Create a new booking order
- * POST /api/orders
- * @param {Object} params - { flightId: string, seats: string[] }
+ * POST /api/v1/orders
+ * @param {Object} params - { flightId: string, seats: string[], simulationSeed?: number }
  */
-export async function createOrder({ flightId, seats }) {
+export async function createOrder({ flightId, seats, simulationSeed }) {
   return request('/orders', {
     method: 'POST',
-    body: JSON.stringify({ flightId, seats }),
+    body: JSON.stringify({ flightId, seats, simulationSeed }),
   });
 }
 
 /**
  * Update seat selection for an order
- * PUT /api/orders/{orderId}/seats
+ * PUT /api/v1/orders/{orderId}/seats
  * @param {Object} params - { orderId: string, seats: string[] }
  */
 export async function updateSeats({ orderId, seats }) {
@@ -76,7 +76,7 @@ export async function updateSeats({ orderId, seats }) {
 
 /**
  * Get order status (for polling)
- * GET /api/orders/{orderId}/status
+ * GET /api/v1/orders/{orderId}/status
  */
 export async function fetchOrderStatus(orderId) {
   return request(`/orders/${orderId}/status`);
@@ -84,7 +84,7 @@ export async function fetchOrderStatus(orderId) {
 
 /**
  * Submit payment for an order
- * POST /api/orders/{orderId}/pay
+ * POST /api/v1/orders/{orderId}/pay
  * @param {Object} params - { orderId: string, paymentCode: string }
  */
 export async function submitPayment({ orderId, paymentCode }) {
@@ -94,9 +94,21 @@ export async function submitPayment({ orderId, paymentCode }) {
   });
 }
 
+/**
+ * Accept or decline the upgrade offered after payment is submitted
+ * POST /api/v1/orders/{orderId}/upsell
+ * @param {Object} params - { orderId: string, upgrade: boolean, priorityBoarding: boolean }
+ */
+export async function respondUpsell({ orderId, upgrade, priorityBoarding }) {
+  return request(`/orders/${orderId}/upsell`, {
+    method: 'POST',
+    body: JSON.stringify({ upgrade, priorityBoarding }),
+  });
+}
+
 /**
  * Cancel an order
- * DELETE /api/orders/{orderId}
+ * DELETE /api/v1/orders/{orderId}
  */
 export async function cancelOrder(orderId) {
   return request(`/orders/${orderId}`, {
@@ -116,6 +128,13 @@ export async function cancelOrder(orderId) {
  * @property {number} totalSeats
  * @property {number} availableSeats
  * @property {number} priceCents
+ * @property {'scheduled'|'delayed'|'cancelled'|'departed'} status
+ * @property {number} [delayMinutes]
+ * @property {boolean} [bookingFrozen] - New bookings and seat changes are refused
+ * @property {string} [salesClosedAt] - ISO 8601; set once sales close ahead of departure
+ * @property {number} [overbookingPercent] - Seatless capacity sold beyond the seats; availableSeats can go this far below zero
+ * @property {string} [priceFormatted] - Per Accept-Language, e.g. "$129.00"
+ * @property {string} [departureTimeFormatted] - Per Accept-Language, in UTC
  */
 
 /**
@@ -128,21 +147,35 @@ export async function cancelOrder(orderId) {
  * @property {number} totalSeats
  * @property {number} availableSeats
  * @property {number} priceCents
+ * @property {'scheduled'|'delayed'|'cancelled'|'departed'} status
+ * @property {number} [delayMinutes]
+ * @property {boolean} [bookingFrozen] - New bookings and seat changes are refused
+ * @property {string} [salesClosedAt] - ISO 8601; set once sales close ahead of departure
+ * @property {number} [overbookingPercent] - Seatless capacity sold beyond the seats; availableSeats can go this far below zero
+ * @property {string} [priceFormatted] - Per Accept-Language, e.g. "$129.00"
+ * @property {string} [departureTimeFormatted] - Per Accept-Language, in UTC
  * @property {SeatMap} seatMap
  */
 
 /**
  * @typedef {Object} SeatMap
- * @property {number} rows
- * @property {number} seatsPerRow
+ * @property {SeatRow[]} rows - Layout of each row that has seats
  * @property {Seat[]} seats
  */
 
+/**
+ * @typedef {Object} SeatRow
+ * @property {number} number
+ * @property {string[]} columns - Seats present in the row, in column order
+ * @property {string} class - 'economy' | 'premium_economy' | 'business' | 'first'
+ */
+
 /**
  * @typedef {Object} Seat
  * @property {string} id
  * @property {number} row
  * @property {string} column
+ * @property {string} class
  * @property {string} status - 'available' | 'reserved' | 'booked'
  */
 
@@ -154,4 +187,27 @@ export async function cancelOrder(orderId) {
  * @property {number} timerRemaining - Seconds remaining
  * @property {number} paymentAttempts
  * @property {string} lastError
+ * @property {Price} price - Price locked when the order was quoted
+ * @property {UpsellOffer} [upsellOffer] - Open between submitting payment and payment being taken
+ */
+
+/**
+ * @typedef {Object} UpsellOffer
+ * @property {string} [class] - Cabin of the upgrade seats; omitted when only priority boarding is offered
+ * @property {string[]} [seats]
+ * @property {number} [upgradeCents] - Per seat
+ * @property {number} [priorityBoardingCents] - Per seat
+ * @property {string} expiresAt - ISO 8601
+ */
+
+/**
+ * @typedef {Object} Price
+ * @property {string} quoteId
+ * @property {number} seatCount
+ * @property {number} baseFareCents
+ * @property {number} feesCents
+ * @property {number} discountCents
+ * @property {number} [upsellCents] - Upgrade and priority boarding accepted at payment
+ * @property {number} totalCents
+ * @property {string} [totalFormatted] - Per Accept-Language
  */
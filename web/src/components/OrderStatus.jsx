@@ -1,6 +1,8 @@
 import { getStatusMessage, getStatusColor } from '../hooks/useOrderStatus';
 import LoadingSpinner from './LoadingSpinner';
 
+const failedStatuses = ['FAILED', 'EXPIRED', 'PAYMENT_REFUNDED', 'CANCELLED'];
+
 function OrderStatus({ status, paymentAttempts = 0, lastError = '' }) {
   const statusMessage = getStatusMessage(status);
   const statusColor = getStatusColor(status);
@@ -15,6 +17,8 @@ function OrderStatus({ status, paymentAttempts = 0, lastError = '' }) {
         );
       case 'FAILED':
       case 'EXPIRED':
+      case 'PAYMENT_REFUNDED':
+      case 'CANCELLED':
         return (
           <svg className="w-8 h-8 text-red-500" fill="none" stroke="currentColor" viewBox="0 0 24 24">
             <path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M6 18L18 6M6 6l12 12" />
@@ -30,7 +34,7 @@ function OrderStatus({ status, paymentAttempts = 0, lastError = '' }) {
   return (
     <div className={`p-4 rounded-lg border ${
       status === 'CONFIRMED' ? 'bg-green-50 border-green-200' :
-      status === 'FAILED' || status === 'EXPIRED' ? 'bg-red-50 border-red-200' :
+      failedStatuses.includes(status) ? 'bg-red-50 border-red-200' :
       status === 'PAYMENT_PROCESSING' ? 'bg-yellow-50 border-yellow-200' :
       'bg-gray-50 border-gray-200'
     }`}>
@@ -50,7 +54,7 @@ function OrderStatus({ status, paymentAttempts = 0, lastError = '' }) {
           )}
 
           {/* Error message */}
-          {lastError && (status === 'FAILED' || status === 'EXPIRED') && (
+          {lastError && failedStatuses.includes(status) && (
             <div className="text-sm text-red-600 mt-1">
               {lastError}
             </div>
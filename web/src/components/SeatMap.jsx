@@ -17,14 +17,13 @@ function SeatMap({ seatMap, selectedSeats, onSeatClick, disabled = false }) {
     return rows;
   }, [seatMap.seats]);
 
-  const rowNumbers = Object.keys(seatsByRow)
-    .map(Number)
-    .sort((a, b) => a - b);
+  // Rows come from the server's layout, so gaps and narrower cabins render as they are
+  const rowNumbers = seatMap.rows.map((row) => row.number).filter((n) => seatsByRow[n]);
 
   const getSeatClass = (seat) => {
     const isSelected = selectedSeats.includes(seat.id);
 
-    if (seat.status === 'booked') {
+    if (seat.status === 'booked' || seat.status === 'blocked') {
       return 'bg-gray-400 text-gray-600 cursor-not-allowed';
     }
     if (seat.status === 'reserved' && !isSelected) {
@@ -38,7 +37,7 @@ function SeatMap({ seatMap, selectedSeats, onSeatClick, disabled = false }) {
 
   const handleSeatClick = (seat) => {
     if (disabled) return;
-    if (seat.status === 'booked') return;
+    if (seat.status === 'booked' || seat.status === 'blocked') return;
     if (seat.status === 'reserved' && !selectedSeats.includes(seat.id)) return;
     onSeatClick(seat.id);
   };
@@ -80,15 +79,15 @@ function SeatMap({ seatMap, selectedSeats, onSeatClick, disabled = false }) {
                 {rowNum}
               </div>
 
-              {/* Seats - split into left and right sections (3 + 3) */}
+              {/* Seats - split into left and right halves of the row */}
               <div className="flex gap-1">
-                {seatsByRow[rowNum].slice(0, 3).map((seat) => (
+                {seatsByRow[rowNum].slice(0, Math.ceil(seatsByRow[rowNum].length / 2)).map((seat) => (
                   <button
                     key={seat.id}
                     onClick={() => handleSeatClick(seat)}
                     disabled={disabled || seat.status === 'booked' || (seat.status === 'reserved' && !selectedSeats.includes(seat.id))}
                     className={`w-10 h-10 rounded text-xs font-medium transition-all ${getSeatClass(seat)}`}
-                    title={`Seat ${seat.id} - ${seat.status}`}
+                    title={`Seat ${seat.id} (${seat.class}) - ${seat.status}`}
                   >
                     {seat.column}
                   </button>
@@ -100,13 +99,13 @@ function SeatMap({ seatMap, selectedSeats, onSeatClick, disabled = false }) {
 
               {/* Right seats */}
               <div className="flex gap-1">
-                {seatsByRow[rowNum].slice(3).map((seat) => (
+                {seatsByRow[rowNum].slice(Math.ceil(seatsByRow[rowNum].length / 2)).map((seat) => (
                   <button
                     key={seat.id}
                     onClick={() => handleSeatClick(seat)}
                     disabled={disabled || seat.status === 'booked' || (seat.status === 'reserved' && !selectedSeats.includes(seat.id))}
                     className={`w-10 h-10 rounded text-xs font-medium transition-all ${getSeatClass(seat)}`}
-                    title={`Seat ${seat.id} - ${seat.status}`}
+                    title={`Seat ${seat.id} (${seat.class}) - ${seat.status}`}
                   >
                     {seat.column}
                   </button>
@@ -22,7 +22,7 @@ export function useOrderStatus(orderId, options = {}) {
     refetchInterval: (query) => {
       // Stop polling if order is in terminal state
       const status = query.state.data?.status;
-      if (status === 'CONFIRMED' || status === 'FAILED' || status === 'EXPIRED') {
+      if (isTerminalStatus(status)) {
         return false;
       }
       return refetchInterval;
@@ -35,7 +35,7 @@ export function useOrderStatus(orderId, options = {}) {
  * Check if order status is terminal (no more changes expected)
  */
 export function isTerminalStatus(status) {
-  return status === 'CONFIRMED' || status === 'FAILED' || status === 'EXPIRED';
+  return status === 'CONFIRMED' || status === 'FAILED' || status === 'EXPIRED' || status === 'PAYMENT_REFUNDED' || status === 'CANCELLED';
 }
 
 /**
@@ -50,6 +50,8 @@ export function getStatusMessage(status) {
     'CONFIRMED': 'Booking confirmed!',
     'FAILED': 'Booking failed',
     'EXPIRED': 'Reservation expired',
+    'PAYMENT_REFUNDED': 'Booking failed, payment refunded',
+    'CANCELLED': 'Booking cancelled',
   };
   return messages[status] || status;
 }
@@ -66,6 +68,8 @@ export function getStatusColor(status) {
     'CONFIRMED': 'text-green-500',
     'FAILED': 'text-red-500',
     'EXPIRED': 'text-red-500',
+    'PAYMENT_REFUNDED': 'text-red-500',
+    'CANCELLED': 'text-red-500',
   };
   return colors[status] || 'text-gray-500';
 }
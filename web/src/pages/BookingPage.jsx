@@ -136,6 +136,13 @@ function BookingPage() {
     }
   }, [orderStatus, bookingPhase]);
 
+  // A declined payment keeps the seats held, so offer the payment form again
+  useEffect(() => {
+    if (orderStatus?.status === 'PAYMENT_PENDING' && bookingPhase === 'paying') {
+      setBookingPhase('reserved');
+    }
+  }, [orderStatus, bookingPhase]);
+
   // Loading state
   if (flightLoading) {
     return (
@@ -282,6 +289,11 @@ function BookingPage() {
 
                 <div className="border-t pt-4">
                   <h3 className="font-semibold mb-3">Complete Payment</h3>
+                  {orderStatus?.status === 'PAYMENT_PENDING' && orderStatus.lastError && (
+                    <p className="text-sm text-red-600 mb-3">
+                      {orderStatus.lastError}. Try another payment code.
+                    </p>
+                  )}
                   <PaymentForm
                     onSubmit={handlePayment}
                     isLoading={paymentMutation.isPending}
@@ -10,7 +10,11 @@ export default defineConfig({
         target: 'http://localhost:8080',
         changeOrigin: true,
       },
-      '/health': {
+      '/healthz': {
+        target: 'http://localhost:8080',
+        changeOrigin: true,
+      },
+      '/readyz': {
         target: 'http://localhost:8080',
         changeOrigin: true,
       },